@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/app/usecase"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	attachment_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/attachment/v1"
+	"github.com/7oh2020/connect-tasklist/backend/util/contextkey"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ダウンロード時に1メッセージで送るバイト数
+const downloadChunkSize = 32 * 1024
+
+// AttachmentServiceHandlerの実装
+type AttachmentHandler struct {
+	usecase.IAttachmentUsecase
+	contextkey.IContextReader
+}
+
+func NewAttachmentHandler(uc usecase.IAttachmentUsecase, cr contextkey.IContextReader) *AttachmentHandler {
+	return &AttachmentHandler{uc, cr}
+}
+
+func (h *AttachmentHandler) GetAttachmentList(ctx context.Context, arg *connect.Request[attachment_v1.GetAttachmentListRequest]) (*connect.Response[attachment_v1.GetAttachmentListResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, err := h.IAttachmentUsecase.FindAttachmentsByTaskID(ctx, dto.NewIDParam(arg.Msg.TaskId), dto.NewIDParam(uid))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	attachments := make([]*attachment_v1.Attachment, len(res))
+	for i, v := range res {
+		attachments[i] = toAttachmentMessage(v)
+	}
+	return connect.NewResponse(&attachment_v1.GetAttachmentListResponse{
+		Attachments: attachments,
+	}), nil
+}
+
+func (h *AttachmentHandler) UploadAttachment(ctx context.Context, stream *connect.ClientStream[attachment_v1.UploadAttachmentRequest]) (*connect.Response[attachment_v1.UploadAttachmentResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	// 最初のメッセージはメタデータでなければならない
+	if !stream.Receive() {
+		if err := stream.Err(); err != nil {
+			return nil, err
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("metadata is required"))
+	}
+	meta := stream.Msg().GetMetadata()
+	if meta == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("metadata is required"))
+	}
+
+	createdID, err := h.IAttachmentUsecase.UploadAttachment(ctx, dto.NewUploadAttachmentParams(meta.TaskId, uid, meta.FileName), &chunkReader{stream: stream})
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQuotaExceeded:
+			return nil, connect.NewError(connect.CodeResourceExhausted, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&attachment_v1.UploadAttachmentResponse{
+		CreatedId: createdID,
+	}), nil
+}
+
+func (h *AttachmentHandler) DownloadAttachment(ctx context.Context, arg *connect.Request[attachment_v1.DownloadAttachmentRequest], stream *connect.ServerStream[attachment_v1.DownloadAttachmentResponse]) error {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	attachment, rc, err := h.IAttachmentUsecase.OpenAttachment(ctx, dto.NewIDParam(arg.Msg.AttachmentId), dto.NewIDParam(uid))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return connect.NewError(connect.CodeAborted, e)
+		default:
+			return connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	defer rc.Close()
+
+	// 最初にメタデータを送り、以降はファイルの内容を分割して送る
+	if err := stream.Send(&attachment_v1.DownloadAttachmentResponse{
+		Data: &attachment_v1.DownloadAttachmentResponse_Metadata{Metadata: toAttachmentMessage(attachment)},
+	}); err != nil {
+		return err
+	}
+	buf := make([]byte, downloadChunkSize)
+	for {
+		n, err := rc.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&attachment_v1.DownloadAttachmentResponse{
+				Data: &attachment_v1.DownloadAttachmentResponse_Chunk{Chunk: buf[:n]},
+			}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return connect.NewError(connect.CodeAborted, &domain.ErrQueryFailed{Msg: "failed to read file"})
+		}
+	}
+}
+
+func (h *AttachmentHandler) DeleteAttachment(ctx context.Context, arg *connect.Request[attachment_v1.DeleteAttachmentRequest]) (*connect.Response[attachment_v1.DeleteAttachmentResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.IAttachmentUsecase.DeleteAttachment(ctx, dto.NewIDParam(arg.Msg.AttachmentId), dto.NewIDParam(uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&attachment_v1.DeleteAttachmentResponse{}), nil
+}
+
+// AttachmentEntityをレスポンス用のメッセージに変換する
+func toAttachmentMessage(v *entity.Attachment) *attachment_v1.Attachment {
+	return &attachment_v1.Attachment{
+		Id:          v.ID.Value(),
+		TaskId:      v.TaskID.Value(),
+		UserId:      v.UserID.Value(),
+		FileName:    v.FileName,
+		ContentType: v.ContentType,
+		Size:        v.Size,
+		CreatedAt:   timestamppb.New(v.CreatedAt),
+	}
+}
+
+// アップロードされたチャンクを順に読み込むReader
+type chunkReader struct {
+	stream *connect.ClientStream[attachment_v1.UploadAttachmentRequest]
+	buf    []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if !r.stream.Receive() {
+			if err := r.stream.Err(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		chunk, ok := r.stream.Msg().Data.(*attachment_v1.UploadAttachmentRequest_Chunk)
+		if !ok {
+			return 0, errors.New("metadata must be sent only once")
+		}
+		r.buf = chunk.Chunk
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	attachment_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/attachment/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/attachment/v1/attachment_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentHandler_NewAttachmentHandler(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ attachment_v1connect.AttachmentServiceHandler = (*AttachmentHandler)(nil)
+	})
+}
+
+// ストリーミングのハンドラをテストするためのクライアントを作成する
+func newAttachmentTestClient(t *testing.T, hdr *AttachmentHandler) attachment_v1connect.AttachmentServiceClient {
+	mux := http.NewServeMux()
+	mux.Handle(attachment_v1connect.NewAttachmentServiceHandler(hdr))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return attachment_v1connect.NewAttachmentServiceClient(server.Client(), server.URL)
+}
+
+func TestAttachmentHandler_GetAttachmentList(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	tid := "tid"
+	uid := "uid"
+	attachments := []*entity.Attachment{
+		{ID: value.NewID("a1"), TaskID: value.NewID(tid), UserID: value.NewID(uid), FileName: "shot.png", ContentType: "image/png", Size: 10, StorageKey: "a1", CreatedAt: now},
+	}
+	req := connect.NewRequest(&attachment_v1.GetAttachmentListRequest{TaskId: tid})
+	paramTaskID := dto.NewIDParam(tid)
+	paramUserID := dto.NewIDParam(uid)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IAttachmentUsecase)
+			if v.err == nil {
+				uc.On("FindAttachmentsByTaskID", ctx, paramTaskID, paramUserID).Return(attachments, nil)
+			} else {
+				uc.On("FindAttachmentsByTaskID", ctx, paramTaskID, paramUserID).Return(nil, v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewAttachmentHandler(uc, cr)
+			ret, err := hdr.GetAttachmentList(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Len(t, ret.Msg.Attachments, len(attachments))
+				require.Equal(t, "a1", ret.Msg.Attachments[0].Id)
+				require.Equal(t, "shot.png", ret.Msg.Attachments[0].FileName)
+				require.Equal(t, "image/png", ret.Msg.Attachments[0].ContentType)
+				require.Equal(t, int64(10), ret.Msg.Attachments[0].Size)
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttachmentHandler_UploadAttachment(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	tid := "tid"
+	uid := "uid"
+	param := dto.NewUploadAttachmentParams(tid, uid, "memo.txt")
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: 使用量の上限を超えた場合", &domain.ErrQuotaExceeded{}, "resource_exhausted"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			var received bytes.Buffer
+			uc := new(mocks.IAttachmentUsecase)
+			uc.On("UploadAttachment", mock.Anything, param, mock.Anything).Return(id, v.err).Run(func(args mock.Arguments) {
+				_, _ = io.Copy(&received, args.Get(2).(io.Reader))
+			})
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", mock.Anything).Return(uid, nil)
+			client := newAttachmentTestClient(t, NewAttachmentHandler(uc, cr))
+
+			stream := client.UploadAttachment(ctx)
+			require.NoError(t, stream.Send(&attachment_v1.UploadAttachmentRequest{
+				Data: &attachment_v1.UploadAttachmentRequest_Metadata{Metadata: &attachment_v1.UploadAttachmentMetadata{TaskId: tid, FileName: "memo.txt"}},
+			}))
+			for _, chunk := range []string{"hello ", "world"} {
+				require.NoError(t, stream.Send(&attachment_v1.UploadAttachmentRequest{
+					Data: &attachment_v1.UploadAttachmentRequest_Chunk{Chunk: []byte(chunk)},
+				}))
+			}
+			ret, err := stream.CloseAndReceive()
+
+			require.Equal(t, "hello world", received.String(), "チャンクが順に読み込まれること")
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, id, ret.Msg.CreatedId)
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+	tt.Run("準正常系: 最初のメッセージがメタデータでない場合", func(t *testing.T) {
+		uc := new(mocks.IAttachmentUsecase)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", mock.Anything).Return(uid, nil)
+		client := newAttachmentTestClient(t, NewAttachmentHandler(uc, cr))
+
+		stream := client.UploadAttachment(ctx)
+		require.NoError(t, stream.Send(&attachment_v1.UploadAttachmentRequest{
+			Data: &attachment_v1.UploadAttachmentRequest_Chunk{Chunk: []byte("hello")},
+		}))
+		_, err := stream.CloseAndReceive()
+
+		require.EqualError(t, err, "invalid_argument: metadata is required", "エラーが一致すること")
+		uc.AssertExpectations(t)
+	})
+}
+
+func TestAttachmentHandler_DownloadAttachment(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	uid := "uid"
+	attachment := &entity.Attachment{ID: value.NewID(id), TaskID: value.NewID("tid"), UserID: value.NewID(uid), FileName: "memo.txt", ContentType: "text/plain; charset=utf-8", Size: downloadChunkSize + 1, StorageKey: id, CreatedAt: now}
+	content := strings.Repeat("a", downloadChunkSize+1)
+	paramID := dto.NewIDParam(id)
+	paramUserID := dto.NewIDParam(uid)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IAttachmentUsecase)
+			if v.err == nil {
+				uc.On("OpenAttachment", mock.Anything, paramID, paramUserID).Return(attachment, io.NopCloser(strings.NewReader(content)), nil)
+			} else {
+				uc.On("OpenAttachment", mock.Anything, paramID, paramUserID).Return(nil, nil, v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", mock.Anything).Return(uid, nil)
+			client := newAttachmentTestClient(t, NewAttachmentHandler(uc, cr))
+
+			stream, err := client.DownloadAttachment(ctx, connect.NewRequest(&attachment_v1.DownloadAttachmentRequest{AttachmentId: id}))
+			require.NoError(t, err, "エラーが発生しないこと")
+			var meta *attachment_v1.Attachment
+			var received bytes.Buffer
+			chunks := 0
+			for stream.Receive() {
+				if m := stream.Msg().GetMetadata(); m != nil {
+					meta = m
+					continue
+				}
+				received.Write(stream.Msg().GetChunk())
+				chunks++
+			}
+
+			if v.err == nil {
+				require.NoError(t, stream.Err(), "エラーが発生しないこと")
+				require.Equal(t, id, meta.Id, "最初にメタデータが返されること")
+				require.Equal(t, "memo.txt", meta.FileName)
+				require.Equal(t, content, received.String(), "内容が一致すること")
+				require.Equal(t, 2, chunks, "分割して返されること")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, stream.Err(), errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttachmentHandler_DeleteAttachment(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	req := connect.NewRequest(&attachment_v1.DeleteAttachmentRequest{AttachmentId: id})
+	paramID := dto.NewIDParam(id)
+	paramUserID := dto.NewIDParam(uid)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IAttachmentUsecase)
+			uc.On("DeleteAttachment", ctx, paramID, paramUserID).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewAttachmentHandler(uc, cr)
+			_, err := hdr.DeleteAttachment(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
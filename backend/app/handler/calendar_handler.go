@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app/usecase"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	calendar_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/calendar/v1"
+	"github.com/7oh2020/connect-tasklist/backend/util/contextkey"
+)
+
+// フィードを配信するパスのパターン。{file}は「トークン.ics」になる
+const CalendarFeedPattern = "GET /calendar/{file}"
+
+// フィードのパスの接頭辞と拡張子
+const (
+	calendarFeedPathPrefix = "/calendar/"
+	calendarFeedExt        = ".ics"
+)
+
+// CalendarServiceHandlerの実装
+type CalendarHandler struct {
+	usecase.ICalendarUsecase
+	contextkey.IContextReader
+}
+
+func NewCalendarHandler(uc usecase.ICalendarUsecase, cr contextkey.IContextReader) *CalendarHandler {
+	return &CalendarHandler{uc, cr}
+}
+
+func (h *CalendarHandler) CreateFeedToken(ctx context.Context, arg *connect.Request[calendar_v1.CreateFeedTokenRequest]) (*connect.Response[calendar_v1.CreateFeedTokenResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	token, err := h.ICalendarUsecase.IssueFeedToken(ctx, dto.NewIDParam(uid))
+	if err != nil {
+		return nil, connect.NewError(toErrorCode(err), err)
+	}
+	return connect.NewResponse(&calendar_v1.CreateFeedTokenResponse{
+		Token:    token,
+		FeedPath: calendarFeedPathPrefix + token + calendarFeedExt,
+	}), nil
+}
+
+func (h *CalendarHandler) RevokeFeedToken(ctx context.Context, arg *connect.Request[calendar_v1.RevokeFeedTokenRequest]) (*connect.Response[calendar_v1.RevokeFeedTokenResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ICalendarUsecase.RevokeFeedToken(ctx, dto.NewIDParam(uid)); err != nil {
+		return nil, connect.NewError(toErrorCode(err), err)
+	}
+	return connect.NewResponse(&calendar_v1.RevokeFeedTokenResponse{}), nil
+}
+
+// カレンダーアプリが購読するフィードを配信するhttp.Handler
+// カレンダーアプリは認証ヘッダーを送れないため、パスに含まれるトークンでユーザーを判定する
+type CalendarFeedHandler struct {
+	usecase.ICalendarUsecase
+}
+
+func NewCalendarFeedHandler(uc usecase.ICalendarUsecase) *CalendarFeedHandler {
+	return &CalendarFeedHandler{uc}
+}
+
+func (h *CalendarFeedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	file := r.PathValue("file")
+	if !strings.HasSuffix(file, calendarFeedExt) {
+		http.NotFound(w, r)
+		return
+	}
+
+	// 途中で失敗した場合にエラーを返せるように、全て書き込んでから送る
+	var buf bytes.Buffer
+	if err := h.ICalendarUsecase.RenderFeed(r.Context(), strings.TrimSuffix(file, calendarFeedExt), &buf); err != nil {
+		// トークンが無効な場合と存在しない場合を区別しない
+		if _, ok := err.(*domain.ErrNotFound); ok {
+			http.NotFound(w, r)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="tasks.ics"`)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	_, _ = w.Write(buf.Bytes())
+}
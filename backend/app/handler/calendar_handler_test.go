@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	calendar_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/calendar/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/calendar/v1/calendar_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarHandler_NewCalendarHandler(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ calendar_v1connect.CalendarServiceHandler = (*CalendarHandler)(nil)
+		var _ http.Handler = (*CalendarFeedHandler)(nil)
+	})
+}
+
+func TestCalendarHandler_CreateFeedToken(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	req := connect.NewRequest(&calendar_v1.CreateFeedTokenRequest{})
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ICalendarUsecase)
+			uc.On("IssueFeedToken", ctx, dto.NewIDParam(uid)).Return("token", v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewCalendarHandler(uc, cr)
+			ret, err := hdr.CreateFeedToken(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, "token", ret.Msg.Token)
+				require.Equal(t, "/calendar/token.ics", ret.Msg.FeedPath, "フィードのパスが返されること")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCalendarHandler_RevokeFeedToken(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	req := connect.NewRequest(&calendar_v1.RevokeFeedTokenRequest{})
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: 発行していない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ICalendarUsecase)
+			uc.On("RevokeFeedToken", ctx, dto.NewIDParam(uid)).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewCalendarHandler(uc, cr)
+			_, err := hdr.RevokeFeedToken(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCalendarFeedHandler_ServeHTTP(tt *testing.T) {
+	content := "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"
+
+	testcases := []struct {
+		title  string
+		err    error
+		status int
+	}{
+		{"正常系: 正しいトークンの場合", nil, http.StatusOK},
+		{"準正常系: トークンが無効な場合", &domain.ErrNotFound{}, http.StatusNotFound},
+		{"準正常系: その他のエラーの場合", &domain.ErrQueryFailed{}, http.StatusInternalServerError},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ICalendarUsecase)
+			uc.On("RenderFeed", mock.Anything, "token", mock.Anything).Return(v.err).Run(func(args mock.Arguments) {
+				_, _ = io.WriteString(args.Get(2).(io.Writer), content)
+			})
+			mux := http.NewServeMux()
+			mux.Handle(CalendarFeedPattern, NewCalendarFeedHandler(uc))
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/calendar/token.ics", nil))
+
+			require.Equal(t, v.status, rec.Code, "ステータスコードが一致すること")
+			if v.err == nil {
+				require.Equal(t, "text/calendar; charset=utf-8", rec.Header().Get("Content-Type"))
+				require.Equal(t, "private, no-cache", rec.Header().Get("Cache-Control"))
+				require.Equal(t, content, rec.Body.String(), "内容が一致すること")
+			} else {
+				require.NotContains(t, rec.Body.String(), "BEGIN:VCALENDAR", "途中まで書き込んだ内容を返さないこと")
+			}
+			uc.AssertExpectations(t)
+		})
+	}
+	tt.Run("準正常系: 拡張子が.icsでない場合", func(t *testing.T) {
+		uc := new(mocks.ICalendarUsecase)
+		mux := http.NewServeMux()
+		mux.Handle(CalendarFeedPattern, NewCalendarFeedHandler(uc))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/calendar/token", nil))
+
+		require.Equal(t, http.StatusNotFound, rec.Code, "ステータスコードが一致すること")
+		uc.AssertExpectations(t)
+	})
+	tt.Run("準正常系: GET以外の場合", func(t *testing.T) {
+		uc := new(mocks.ICalendarUsecase)
+		mux := http.NewServeMux()
+		mux.Handle(CalendarFeedPattern, NewCalendarFeedHandler(uc))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/calendar/token.ics", nil))
+
+		require.Equal(t, http.StatusMethodNotAllowed, rec.Code, "ステータスコードが一致すること")
+		uc.AssertExpectations(t)
+	})
+}
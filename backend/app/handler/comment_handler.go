@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/app/usecase"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	comment_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/comment/v1"
+	"github.com/7oh2020/connect-tasklist/backend/util/contextkey"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CommentServiceHandlerの実装
+type CommentHandler struct {
+	usecase.ICommentUsecase
+	contextkey.IContextReader
+}
+
+func NewCommentHandler(uc usecase.ICommentUsecase, cr contextkey.IContextReader) *CommentHandler {
+	return &CommentHandler{uc, cr}
+}
+
+func (h *CommentHandler) GetCommentList(ctx context.Context, arg *connect.Request[comment_v1.GetCommentListRequest]) (*connect.Response[comment_v1.GetCommentListResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, err := h.ICommentUsecase.FindCommentsByTaskID(ctx, dto.NewIDParam(arg.Msg.TaskId), dto.NewIDParam(uid))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&comment_v1.GetCommentListResponse{
+		Comments: toCommentMessages(res),
+	}), nil
+}
+
+func (h *CommentHandler) AddComment(ctx context.Context, arg *connect.Request[comment_v1.AddCommentRequest]) (*connect.Response[comment_v1.AddCommentResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	createdID, err := h.ICommentUsecase.AddComment(ctx, dto.NewAddCommentParams(arg.Msg.TaskId, uid, arg.Msg.Body))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&comment_v1.AddCommentResponse{
+		CreatedId: createdID,
+	}), nil
+}
+
+func (h *CommentHandler) EditComment(ctx context.Context, arg *connect.Request[comment_v1.EditCommentRequest]) (*connect.Response[comment_v1.EditCommentResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ICommentUsecase.EditComment(ctx, dto.NewEditCommentParams(arg.Msg.CommentId, uid, arg.Msg.Body)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&comment_v1.EditCommentResponse{}), nil
+}
+
+func (h *CommentHandler) DeleteComment(ctx context.Context, arg *connect.Request[comment_v1.DeleteCommentRequest]) (*connect.Response[comment_v1.DeleteCommentResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ICommentUsecase.DeleteComment(ctx, dto.NewIDParam(arg.Msg.CommentId), dto.NewIDParam(uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&comment_v1.DeleteCommentResponse{}), nil
+}
+
+// CommentEntityのスライスをレスポンス用のメッセージに変換する
+func toCommentMessages(res []*entity.Comment) []*comment_v1.Comment {
+	comments := make([]*comment_v1.Comment, len(res))
+	for i, v := range res {
+		comments[i] = &comment_v1.Comment{
+			Id:        v.ID.Value(),
+			TaskId:    v.TaskID.Value(),
+			UserId:    v.UserID.Value(),
+			Body:      v.Body,
+			CreatedAt: timestamppb.New(v.CreatedAt),
+			UpdatedAt: timestamppb.New(v.UpdatedAt),
+			IsEdited:  v.EditedAt != nil,
+		}
+		if v.EditedAt != nil {
+			comments[i].EditedAt = timestamppb.New(*v.EditedAt)
+		}
+	}
+	return comments
+}
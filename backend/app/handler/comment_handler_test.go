@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	comment_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/comment/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/comment/v1/comment_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentHandler_NewCommentHandler(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ comment_v1connect.CommentServiceHandler = (*CommentHandler)(nil)
+	})
+}
+
+func TestCommentHandler_GetCommentList(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	tid := "tid"
+	uid := "uid"
+	comments := []*entity.Comment{
+		{ID: value.NewID("c1"), TaskID: value.NewID(tid), UserID: value.NewID(uid), Body: "first", CreatedAt: now, UpdatedAt: now},
+		{ID: value.NewID("c2"), TaskID: value.NewID(tid), UserID: value.NewID(uid), Body: "second", CreatedAt: now, UpdatedAt: now, EditedAt: &now},
+	}
+	req := connect.NewRequest(&comment_v1.GetCommentListRequest{TaskId: tid})
+	paramTaskID := dto.NewIDParam(tid)
+	paramUserID := dto.NewIDParam(uid)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ICommentUsecase)
+			if v.err == nil {
+				uc.On("FindCommentsByTaskID", ctx, paramTaskID, paramUserID).Return(comments, nil)
+			} else {
+				uc.On("FindCommentsByTaskID", ctx, paramTaskID, paramUserID).Return(nil, v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewCommentHandler(uc, cr)
+			ret, err := hdr.GetCommentList(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Len(t, ret.Msg.Comments, len(comments))
+				for i, v := range ret.Msg.Comments {
+					require.Equal(t, comments[i].ID.Value(), v.Id)
+					require.Equal(t, comments[i].Body, v.Body)
+				}
+				require.False(t, ret.Msg.Comments[0].IsEdited, "編集していないこと")
+				require.Nil(t, ret.Msg.Comments[0].EditedAt, "編集日時が省略されること")
+				require.True(t, ret.Msg.Comments[1].IsEdited, "編集済みであること")
+				require.Equal(t, now, ret.Msg.Comments[1].EditedAt.AsTime(), "編集日時が一致すること")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCommentHandler_AddComment(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	arg := &comment_v1.AddCommentRequest{TaskId: "tid", Body: "comment"}
+	param := dto.NewAddCommentParams(arg.TaskId, uid, arg.Body)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ICommentUsecase)
+			if v.err == nil {
+				uc.On("AddComment", ctx, param).Return(id, nil)
+			} else {
+				uc.On("AddComment", ctx, param).Return("", v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewCommentHandler(uc, cr)
+			ret, err := hdr.AddComment(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, id, ret.Msg.CreatedId)
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCommentHandler_EditComment(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &comment_v1.EditCommentRequest{CommentId: "id", Body: "edited"}
+	param := dto.NewEditCommentParams(arg.CommentId, uid, arg.Body)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ICommentUsecase)
+			if v.err == nil {
+				uc.On("EditComment", ctx, param).Return(nil)
+			} else {
+				uc.On("EditComment", ctx, param).Return(v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewCommentHandler(uc, cr)
+			_, err := hdr.EditComment(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCommentHandler_DeleteComment(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &comment_v1.DeleteCommentRequest{CommentId: "id"}
+	paramID := dto.NewIDParam(arg.CommentId)
+	paramUserID := dto.NewIDParam(uid)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ICommentUsecase)
+			if v.err == nil {
+				uc.On("DeleteComment", ctx, paramID, paramUserID).Return(nil)
+			} else {
+				uc.On("DeleteComment", ctx, paramID, paramUserID).Return(v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewCommentHandler(uc, cr)
+			_, err := hdr.DeleteComment(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+)
+
+// エラーをレスポンス用のエラーコードに変換する
+func toErrorCode(err error) connect.Code {
+	switch err.(type) {
+	case *app.ErrInputValidationFailed:
+		return connect.CodeInvalidArgument
+	case *domain.ErrValidationFailed:
+		return connect.CodeInvalidArgument
+	case *domain.ErrNotFound:
+		return connect.CodeNotFound
+	case *domain.ErrPermissionDenied:
+		return connect.CodePermissionDenied
+	case *domain.ErrPreconditionFailed:
+		return connect.CodeFailedPrecondition
+	case *domain.ErrAlreadyExists:
+		return connect.CodeAlreadyExists
+	case *domain.ErrQuotaExceeded:
+		return connect.CodeResourceExhausted
+	case *domain.ErrQueryFailed:
+		return connect.CodeAborted
+	default:
+		return connect.CodeUnknown
+	}
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToErrorCode(tt *testing.T) {
+	testcases := []struct {
+		title string
+		err   error
+		exp   connect.Code
+	}{
+		{"正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, connect.CodeInvalidArgument},
+		{"正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, connect.CodeInvalidArgument},
+		{"正常系: 存在しない場合", &domain.ErrNotFound{}, connect.CodeNotFound},
+		{"正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, connect.CodePermissionDenied},
+		{"正常系: 前提条件エラーの場合", &domain.ErrPreconditionFailed{}, connect.CodeFailedPrecondition},
+		{"正常系: 既に存在する場合", &domain.ErrAlreadyExists{}, connect.CodeAlreadyExists},
+		{"正常系: 上限を超えた場合", &domain.ErrQuotaExceeded{}, connect.CodeResourceExhausted},
+		{"正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, connect.CodeAborted},
+		{"正常系: その他のエラーの場合", errors.New("failed"), connect.CodeUnknown},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			require.Equal(t, v.exp, toErrorCode(v.err))
+		})
+	}
+}
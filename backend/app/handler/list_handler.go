@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/app/usecase"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	list_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/list/v1"
+	"github.com/7oh2020/connect-tasklist/backend/util/contextkey"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListServiceHandlerの実装
+type ListHandler struct {
+	usecase.IListUsecase
+	contextkey.IContextReader
+}
+
+func NewListHandler(uc usecase.IListUsecase, cr contextkey.IContextReader) *ListHandler {
+	return &ListHandler{uc, cr}
+}
+
+func (h *ListHandler) GetLists(ctx context.Context, arg *connect.Request[list_v1.GetListsRequest]) (*connect.Response[list_v1.GetListsResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	// ワークスペースが指定された場合はワークスペースのリストを取得する
+	var res []*entity.List
+	if workspaceID := h.IContextReader.GetWorkspaceID(ctx); workspaceID != "" {
+		res, err = h.IListUsecase.FindListsByWorkspaceID(ctx, dto.NewIDParam(workspaceID), dto.NewIDParam(uid))
+	} else {
+		res, err = h.IListUsecase.FindListsByUserID(ctx, dto.NewIDParam(uid))
+	}
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&list_v1.GetListsResponse{
+		Lists: toListMessages(res),
+	}), nil
+}
+
+func (h *ListHandler) CreateList(ctx context.Context, arg *connect.Request[list_v1.CreateListRequest]) (*connect.Response[list_v1.CreateListResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	// ワークスペースが指定された場合はワークスペースにリストを作成する
+	var createdID string
+	if workspaceID := h.IContextReader.GetWorkspaceID(ctx); workspaceID != "" {
+		createdID, err = h.IListUsecase.CreateWorkspaceList(ctx, dto.NewCreateWorkspaceListParams(workspaceID, uid, arg.Msg.Name))
+	} else {
+		createdID, err = h.IListUsecase.CreateList(ctx, dto.NewCreateListParams(uid, arg.Msg.Name))
+	}
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&list_v1.CreateListResponse{
+		CreatedId: createdID,
+	}), nil
+}
+
+func (h *ListHandler) RenameList(ctx context.Context, arg *connect.Request[list_v1.RenameListRequest]) (*connect.Response[list_v1.RenameListResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.IListUsecase.RenameList(ctx, dto.NewRenameListParams(arg.Msg.ListId, uid, arg.Msg.Name)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&list_v1.RenameListResponse{}), nil
+}
+
+func (h *ListHandler) ArchiveList(ctx context.Context, arg *connect.Request[list_v1.ArchiveListRequest]) (*connect.Response[list_v1.ArchiveListResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.IListUsecase.ArchiveList(ctx, dto.NewIDParam(arg.Msg.ListId), dto.NewIDParam(uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrPreconditionFailed:
+			return nil, connect.NewError(connect.CodeFailedPrecondition, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&list_v1.ArchiveListResponse{}), nil
+}
+
+func (h *ListHandler) UnarchiveList(ctx context.Context, arg *connect.Request[list_v1.UnarchiveListRequest]) (*connect.Response[list_v1.UnarchiveListResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.IListUsecase.UnarchiveList(ctx, dto.NewIDParam(arg.Msg.ListId), dto.NewIDParam(uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&list_v1.UnarchiveListResponse{}), nil
+}
+
+func (h *ListHandler) DeleteList(ctx context.Context, arg *connect.Request[list_v1.DeleteListRequest]) (*connect.Response[list_v1.DeleteListResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.IListUsecase.DeleteList(ctx, dto.NewIDParam(arg.Msg.ListId), dto.NewIDParam(uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrPreconditionFailed:
+			return nil, connect.NewError(connect.CodeFailedPrecondition, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&list_v1.DeleteListResponse{}), nil
+}
+
+func (h *ListHandler) ReorderLists(ctx context.Context, arg *connect.Request[list_v1.ReorderListsRequest]) (*connect.Response[list_v1.ReorderListsResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.IListUsecase.ReorderLists(ctx, dto.NewReorderListsParams(uid, arg.Msg.ListIds)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&list_v1.ReorderListsResponse{}), nil
+}
+
+// ListEntityのスライスをレスポンス用のメッセージに変換する
+func toListMessages(res []*entity.List) []*list_v1.List {
+	lists := make([]*list_v1.List, len(res))
+	for i, v := range res {
+		lists[i] = &list_v1.List{
+			Id:         v.ID.Value(),
+			UserId:     v.UserID.Value(),
+			Name:       v.Name,
+			IsInbox:    v.IsInbox,
+			IsArchived: v.IsArchived,
+			Position:   v.Position,
+			CreatedAt:  timestamppb.New(v.CreatedAt),
+			UpdatedAt:  timestamppb.New(v.UpdatedAt),
+		}
+		if v.WorkspaceID != nil {
+			lists[i].WorkspaceId = v.WorkspaceID.Value()
+		}
+	}
+	return lists
+}
@@ -0,0 +1,376 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	list_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/list/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/list/v1/list_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListHandler_NewListHandler(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ list_v1connect.ListServiceHandler = (*ListHandler)(nil)
+	})
+}
+
+func TestListHandler_GetLists(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	lists := []*entity.List{
+		{ID: value.NewID("l1"), UserID: value.NewID(uid), Name: entity.InboxListName, IsInbox: true, Position: 0, CreatedAt: now, UpdatedAt: now},
+		{ID: value.NewID("l2"), UserID: value.NewID(uid), Name: "work", IsArchived: true, Position: 1, CreatedAt: now, UpdatedAt: now},
+	}
+	param := dto.NewIDParam(uid)
+	req := connect.NewRequest(&list_v1.GetListsRequest{})
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IListUsecase)
+			if v.err == nil {
+				uc.On("FindListsByUserID", ctx, param).Return(lists, nil)
+			} else {
+				uc.On("FindListsByUserID", ctx, param).Return(nil, v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			cr.On("GetWorkspaceID", ctx).Return("")
+			hdr := NewListHandler(uc, cr)
+			ret, err := hdr.GetLists(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				for i, v := range ret.Msg.Lists {
+					require.Equal(t, lists[i].ID.Value(), v.Id)
+					require.Equal(t, lists[i].Name, v.Name)
+					require.Equal(t, lists[i].IsInbox, v.IsInbox)
+					require.Equal(t, lists[i].IsArchived, v.IsArchived)
+					require.Equal(t, lists[i].Position, v.Position)
+				}
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListHandler_CreateList(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	arg := &list_v1.CreateListRequest{Name: "work"}
+	param := dto.NewCreateListParams(uid, arg.Name)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IListUsecase)
+			if v.err == nil {
+				uc.On("CreateList", ctx, param).Return(id, nil)
+			} else {
+				uc.On("CreateList", ctx, param).Return("", v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			cr.On("GetWorkspaceID", ctx).Return("")
+			hdr := NewListHandler(uc, cr)
+			ret, err := hdr.CreateList(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, id, ret.Msg.CreatedId)
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListHandler_WorkspaceLists(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	wid := "wid"
+	lists := []*entity.List{
+		{ID: value.NewID("l1"), UserID: value.NewID("owner"), WorkspaceID: value.NewID(wid), Name: "team", CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: ワークスペースのリストを取得する場合", func(t *testing.T) {
+		uc := new(mocks.IListUsecase)
+		uc.On("FindListsByWorkspaceID", ctx, dto.NewIDParam(wid), dto.NewIDParam(uid)).Return(lists, nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		cr.On("GetWorkspaceID", ctx).Return(wid)
+		hdr := NewListHandler(uc, cr)
+		ret, err := hdr.GetLists(ctx, connect.NewRequest(&list_v1.GetListsRequest{}))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Len(t, ret.Msg.Lists, 1)
+		require.Equal(t, wid, ret.Msg.Lists[0].WorkspaceId)
+		uc.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: ワークスペースのメンバーではない場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		uc := new(mocks.IListUsecase)
+		uc.On("FindListsByWorkspaceID", ctx, dto.NewIDParam(wid), dto.NewIDParam(uid)).Return(nil, errExp)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		cr.On("GetWorkspaceID", ctx).Return(wid)
+		hdr := NewListHandler(uc, cr)
+		_, err := hdr.GetLists(ctx, connect.NewRequest(&list_v1.GetListsRequest{}))
+
+		require.EqualError(t, err, fmt.Sprintf("permission_denied: %s", errExp.Error()), "エラーが一致すること")
+		uc.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+	tt.Run("正常系: ワークスペースにリストを作成する場合", func(t *testing.T) {
+		uc := new(mocks.IListUsecase)
+		uc.On("CreateWorkspaceList", ctx, dto.NewCreateWorkspaceListParams(wid, uid, "team")).Return("l1", nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		cr.On("GetWorkspaceID", ctx).Return(wid)
+		hdr := NewListHandler(uc, cr)
+		ret, err := hdr.CreateList(ctx, connect.NewRequest(&list_v1.CreateListRequest{Name: "team"}))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, "l1", ret.Msg.CreatedId)
+		uc.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+}
+
+func TestListHandler_RenameList(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &list_v1.RenameListRequest{ListId: "id", Name: "private"}
+	param := dto.NewRenameListParams(arg.ListId, uid, arg.Name)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: リストが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IListUsecase)
+			uc.On("RenameList", ctx, param).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewListHandler(uc, cr)
+			_, err := hdr.RenameList(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListHandler_ReorderLists(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &list_v1.ReorderListsRequest{ListIds: []string{"l2", "l1"}}
+	param := dto.NewReorderListsParams(uid, arg.ListIds)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IListUsecase)
+			uc.On("ReorderLists", ctx, param).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewListHandler(uc, cr)
+			_, err := hdr.ReorderLists(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListHandler_ArchiveList(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	req := connect.NewRequest(&list_v1.ArchiveListRequest{ListId: "id"})
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: リストが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: Inboxの場合", &domain.ErrPreconditionFailed{}, "failed_precondition"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IListUsecase)
+			uc.On("ArchiveList", ctx, dto.NewIDParam("id"), dto.NewIDParam(uid)).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewListHandler(uc, cr)
+			_, err := hdr.ArchiveList(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListHandler_UnarchiveList(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	req := connect.NewRequest(&list_v1.UnarchiveListRequest{ListId: "id"})
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: リストが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IListUsecase)
+			uc.On("UnarchiveList", ctx, dto.NewIDParam("id"), dto.NewIDParam(uid)).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewListHandler(uc, cr)
+			_, err := hdr.UnarchiveList(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListHandler_DeleteList(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	req := connect.NewRequest(&list_v1.DeleteListRequest{ListId: "id"})
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: リストが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: Inboxの場合", &domain.ErrPreconditionFailed{}, "failed_precondition"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IListUsecase)
+			uc.On("DeleteList", ctx, dto.NewIDParam("id"), dto.NewIDParam(uid)).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewListHandler(uc, cr)
+			_, err := hdr.DeleteList(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/app/usecase"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	search_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/search/v1"
+	"github.com/7oh2020/connect-tasklist/backend/util/contextkey"
+)
+
+// SearchServiceHandlerの実装
+type SearchHandler struct {
+	usecase.ISearchUsecase
+	contextkey.IContextReader
+}
+
+func NewSearchHandler(uc usecase.ISearchUsecase, cr contextkey.IContextReader) *SearchHandler {
+	return &SearchHandler{uc, cr}
+}
+
+func (h *SearchHandler) SearchTasks(ctx context.Context, arg *connect.Request[search_v1.SearchTasksRequest]) (*connect.Response[search_v1.SearchTasksResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	workspaceID := h.IContextReader.GetWorkspaceID(ctx)
+
+	res, next, err := h.ISearchUsecase.SearchTasks(ctx, dto.NewSearchTasksParams(workspaceID, uid, arg.Msg.Query, arg.Msg.PageSize, arg.Msg.PageToken))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&search_v1.SearchTasksResponse{
+		Results:       toSearchResultMessages(res),
+		NextPageToken: next,
+	}), nil
+}
+
+// TaskSearchResultEntityのスライスをレスポンス用のメッセージに変換する
+func toSearchResultMessages(res []*entity.TaskSearchResult) []*search_v1.SearchResult {
+	results := make([]*search_v1.SearchResult, len(res))
+	for i, v := range res {
+		results[i] = &search_v1.SearchResult{
+			Task:                toTaskMessage(v.Task),
+			Rank:                v.Rank,
+			NameHeadline:        v.NameHeadline,
+			DescriptionHeadline: v.DescriptionHeadline,
+		}
+	}
+	return results
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	search_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/search/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/search/v1/search_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchHandler_NewSearchHandler(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ search_v1connect.SearchServiceHandler = (*SearchHandler)(nil)
+	})
+}
+
+func TestSearchHandler_SearchTasks(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	results := []*entity.TaskSearchResult{
+		{
+			Task:                &entity.Task{ID: value.NewID("t1"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Name: "buy milk", Status: value.TaskStatusTodo, CreatedAt: now, UpdatedAt: now},
+			Rank:                0.5,
+			NameHeadline:        "<mark>buy</mark> milk",
+			DescriptionHeadline: "",
+		},
+	}
+	arg := &search_v1.SearchTasksRequest{Query: "buy", PageSize: 1, PageToken: "token"}
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title       string
+		workspaceID string
+		err         error
+		codeStr     string
+	}{
+		{"正常系: 正しい入力の場合", "", nil, ""},
+		{"正常系: ワークスペースを指定した場合", "wid", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", "", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", "", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: ワークスペースのメンバーではない場合", "wid", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", "", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", "", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			param := dto.NewSearchTasksParams(v.workspaceID, uid, arg.Query, arg.PageSize, arg.PageToken)
+			uc := new(mocks.ISearchUsecase)
+			if v.err == nil {
+				uc.On("SearchTasks", ctx, param).Return(results, "next", nil)
+			} else {
+				uc.On("SearchTasks", ctx, param).Return(nil, "", v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			cr.On("GetWorkspaceID", ctx).Return(v.workspaceID)
+			hdr := NewSearchHandler(uc, cr)
+			ret, err := hdr.SearchTasks(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, "next", ret.Msg.NextPageToken, "トークンが一致すること")
+				require.Len(t, ret.Msg.Results, 1)
+				require.Equal(t, "t1", ret.Msg.Results[0].Task.Id)
+				require.Equal(t, float32(0.5), ret.Msg.Results[0].Rank)
+				require.Equal(t, "<mark>buy</mark> milk", ret.Msg.Results[0].NameHeadline)
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+	tt.Run("準正常系: 認証されていない場合", func(t *testing.T) {
+		uc := new(mocks.ISearchUsecase)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return("", fmt.Errorf("user id not found"))
+		hdr := NewSearchHandler(uc, cr)
+		_, err := hdr.SearchTasks(ctx, req)
+
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err), "認証エラーになること")
+		uc.AssertExpectations(t)
+	})
+}
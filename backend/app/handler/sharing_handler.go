@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/app/usecase"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	sharing_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/sharing/v1"
+	"github.com/7oh2020/connect-tasklist/backend/util/contextkey"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SharingServiceHandlerの実装
+type SharingHandler struct {
+	usecase.ISharingUsecase
+	contextkey.IContextReader
+}
+
+func NewSharingHandler(uc usecase.ISharingUsecase, cr contextkey.IContextReader) *SharingHandler {
+	return &SharingHandler{uc, cr}
+}
+
+func (h *SharingHandler) GetCollaboratorList(ctx context.Context, arg *connect.Request[sharing_v1.GetCollaboratorListRequest]) (*connect.Response[sharing_v1.GetCollaboratorListResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, err := h.ISharingUsecase.FindCollaborators(ctx, dto.NewSharingTargetParams(arg.Msg.GetTaskId(), arg.Msg.GetListId(), uid))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&sharing_v1.GetCollaboratorListResponse{
+		Collaborators: toCollaboratorMessages(res),
+	}), nil
+}
+
+func (h *SharingHandler) GrantAccess(ctx context.Context, arg *connect.Request[sharing_v1.GrantAccessRequest]) (*connect.Response[sharing_v1.GrantAccessResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ISharingUsecase.GrantAccess(ctx, dto.NewGrantAccessParams(arg.Msg.GetTaskId(), arg.Msg.GetListId(), uid, arg.Msg.Email, toRole(arg.Msg.Role).Value())); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&sharing_v1.GrantAccessResponse{}), nil
+}
+
+func (h *SharingHandler) RevokeAccess(ctx context.Context, arg *connect.Request[sharing_v1.RevokeAccessRequest]) (*connect.Response[sharing_v1.RevokeAccessResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ISharingUsecase.RevokeAccess(ctx, dto.NewRevokeAccessParams(arg.Msg.GetTaskId(), arg.Msg.GetListId(), uid, arg.Msg.UserId)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&sharing_v1.RevokeAccessResponse{}), nil
+}
+
+// CollaboratorEntityのスライスをレスポンス用のメッセージに変換する
+func toCollaboratorMessages(res []*entity.Collaborator) []*sharing_v1.Collaborator {
+	collaborators := make([]*sharing_v1.Collaborator, len(res))
+	for i, v := range res {
+		collaborators[i] = &sharing_v1.Collaborator{
+			Id:        v.ID.Value(),
+			UserId:    v.UserID.Value(),
+			Email:     v.Email,
+			Role:      toRoleMessage(v.Role),
+			GrantedBy: v.GrantedBy.Value(),
+			CreatedAt: timestamppb.New(v.CreatedAt),
+			UpdatedAt: timestamppb.New(v.UpdatedAt),
+		}
+	}
+	return collaborators
+}
+
+// リクエストの権限をドメインの権限に変換する。未指定の場合はRoleNoneを返す
+func toRole(r sharing_v1.Role) value.Role {
+	switch r {
+	case sharing_v1.Role_ROLE_VIEWER:
+		return value.RoleViewer
+	case sharing_v1.Role_ROLE_EDITOR:
+		return value.RoleEditor
+	case sharing_v1.Role_ROLE_ADMIN:
+		return value.RoleAdmin
+	default:
+		return value.RoleNone
+	}
+}
+
+// ドメインの権限をレスポンス用の権限に変換する
+func toRoleMessage(r value.Role) sharing_v1.Role {
+	switch r {
+	case value.RoleViewer:
+		return sharing_v1.Role_ROLE_VIEWER
+	case value.RoleEditor:
+		return sharing_v1.Role_ROLE_EDITOR
+	case value.RoleAdmin:
+		return sharing_v1.Role_ROLE_ADMIN
+	default:
+		return sharing_v1.Role_ROLE_UNSPECIFIED
+	}
+}
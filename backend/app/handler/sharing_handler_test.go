@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	sharing_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/sharing/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/sharing/v1/sharing_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharingHandler_NewSharingHandler(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ sharing_v1connect.SharingServiceHandler = (*SharingHandler)(nil)
+	})
+}
+
+func TestSharingHandler_GetCollaboratorList(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	collaborators := []*entity.Collaborator{
+		{ID: value.NewID("c1"), ListID: value.NewID("lid"), UserID: value.NewID("u1"), Email: "u1@example.com", Role: value.RoleViewer, GrantedBy: value.NewID(uid), CreatedAt: now, UpdatedAt: now},
+		{ID: value.NewID("c2"), ListID: value.NewID("lid"), UserID: value.NewID("u2"), Email: "u2@example.com", Role: value.RoleAdmin, GrantedBy: value.NewID(uid), CreatedAt: now, UpdatedAt: now},
+	}
+	req := connect.NewRequest(&sharing_v1.GetCollaboratorListRequest{Target: &sharing_v1.GetCollaboratorListRequest_ListId{ListId: "lid"}})
+	param := dto.NewSharingTargetParams("", "lid", uid)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ISharingUsecase)
+			if v.err == nil {
+				uc.On("FindCollaborators", ctx, param).Return(collaborators, nil)
+			} else {
+				uc.On("FindCollaborators", ctx, param).Return(nil, v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewSharingHandler(uc, cr)
+			ret, err := hdr.GetCollaboratorList(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Len(t, ret.Msg.Collaborators, len(collaborators))
+				for i, v := range ret.Msg.Collaborators {
+					require.Equal(t, collaborators[i].ID.Value(), v.Id)
+					require.Equal(t, collaborators[i].UserID.Value(), v.UserId)
+					require.Equal(t, collaborators[i].Email, v.Email)
+					require.Equal(t, uid, v.GrantedBy)
+				}
+				require.Equal(t, sharing_v1.Role_ROLE_VIEWER, ret.Msg.Collaborators[0].Role)
+				require.Equal(t, sharing_v1.Role_ROLE_ADMIN, ret.Msg.Collaborators[1].Role)
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSharingHandler_GrantAccess(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &sharing_v1.GrantAccessRequest{Target: &sharing_v1.GrantAccessRequest_TaskId{TaskId: "tid"}, Email: "a@example.com", Role: sharing_v1.Role_ROLE_EDITOR}
+	param := dto.NewGrantAccessParams("tid", "", uid, arg.Email, value.RoleEditor.Value())
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ISharingUsecase)
+			uc.On("GrantAccess", ctx, param).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewSharingHandler(uc, cr)
+			_, err := hdr.GrantAccess(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSharingHandler_RevokeAccess(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &sharing_v1.RevokeAccessRequest{Target: &sharing_v1.RevokeAccessRequest_ListId{ListId: "lid"}, UserId: "other"}
+	param := dto.NewRevokeAccessParams("", "lid", uid, arg.UserId)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ISharingUsecase)
+			uc.On("RevokeAccess", ctx, param).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewSharingHandler(uc, cr)
+			_, err := hdr.RevokeAccess(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/app/usecase"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	tag_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/tag/v1"
+	"github.com/7oh2020/connect-tasklist/backend/util/contextkey"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TagServiceHandlerの実装
+type TagHandler struct {
+	usecase.ITagUsecase
+	contextkey.IContextReader
+}
+
+func NewTagHandler(uc usecase.ITagUsecase, cr contextkey.IContextReader) *TagHandler {
+	return &TagHandler{uc, cr}
+}
+
+func (h *TagHandler) GetTagList(ctx context.Context, arg *connect.Request[tag_v1.GetTagListRequest]) (*connect.Response[tag_v1.GetTagListResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, err := h.ITagUsecase.FindTagsByUserID(ctx, dto.NewIDParam(uid))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&tag_v1.GetTagListResponse{
+		Tags: toTagMessages(res),
+	}), nil
+}
+
+func (h *TagHandler) CreateTag(ctx context.Context, arg *connect.Request[tag_v1.CreateTagRequest]) (*connect.Response[tag_v1.CreateTagResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	createdID, err := h.ITagUsecase.CreateTag(ctx, dto.NewCreateTagParams(uid, arg.Msg.Name))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrAlreadyExists:
+			return nil, connect.NewError(connect.CodeAlreadyExists, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&tag_v1.CreateTagResponse{
+		CreatedId: createdID,
+	}), nil
+}
+
+func (h *TagHandler) RenameTag(ctx context.Context, arg *connect.Request[tag_v1.RenameTagRequest]) (*connect.Response[tag_v1.RenameTagResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITagUsecase.RenameTag(ctx, dto.NewRenameTagParams(arg.Msg.TagId, uid, arg.Msg.Name)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrAlreadyExists:
+			return nil, connect.NewError(connect.CodeAlreadyExists, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&tag_v1.RenameTagResponse{}), nil
+}
+
+func (h *TagHandler) DeleteTag(ctx context.Context, arg *connect.Request[tag_v1.DeleteTagRequest]) (*connect.Response[tag_v1.DeleteTagResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITagUsecase.DeleteTag(ctx, dto.NewIDParam(arg.Msg.TagId), dto.NewIDParam(uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&tag_v1.DeleteTagResponse{}), nil
+}
+
+func (h *TagHandler) AttachTag(ctx context.Context, arg *connect.Request[tag_v1.AttachTagRequest]) (*connect.Response[tag_v1.AttachTagResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITagUsecase.AttachTag(ctx, dto.NewTagTaskParams(arg.Msg.TaskId, arg.Msg.TagId, uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&tag_v1.AttachTagResponse{}), nil
+}
+
+func (h *TagHandler) DetachTag(ctx context.Context, arg *connect.Request[tag_v1.DetachTagRequest]) (*connect.Response[tag_v1.DetachTagResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITagUsecase.DetachTag(ctx, dto.NewTagTaskParams(arg.Msg.TaskId, arg.Msg.TagId, uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&tag_v1.DetachTagResponse{}), nil
+}
+
+// TagEntityのスライスをレスポンス用のメッセージに変換する
+func toTagMessages(res []*entity.Tag) []*tag_v1.Tag {
+	tags := make([]*tag_v1.Tag, len(res))
+	for i, v := range res {
+		tags[i] = &tag_v1.Tag{
+			Id:         v.ID.Value(),
+			UserId:     v.UserID.Value(),
+			Name:       v.Name,
+			CreatedAt:  timestamppb.New(v.CreatedAt),
+			UpdatedAt:  timestamppb.New(v.UpdatedAt),
+			UsageCount: v.UsageCount,
+		}
+	}
+	return tags
+}
@@ -0,0 +1,305 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	tag_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/tag/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/tag/v1/tag_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagHandler_NewTagHandler(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ tag_v1connect.TagServiceHandler = (*TagHandler)(nil)
+	})
+}
+
+func TestTagHandler_GetTagList(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	tags := []*entity.Tag{
+		{ID: value.NewID("g1"), UserID: value.NewID(uid), Name: "home", CreatedAt: now, UpdatedAt: now, UsageCount: 3},
+		{ID: value.NewID("g2"), UserID: value.NewID(uid), Name: "work", CreatedAt: now, UpdatedAt: now, UsageCount: 0},
+	}
+	param := dto.NewIDParam(uid)
+	req := connect.NewRequest(&tag_v1.GetTagListRequest{})
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITagUsecase)
+			if v.err == nil {
+				uc.On("FindTagsByUserID", ctx, param).Return(tags, nil)
+			} else {
+				uc.On("FindTagsByUserID", ctx, param).Return(nil, v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTagHandler(uc, cr)
+			ret, err := hdr.GetTagList(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				for i, v := range ret.Msg.Tags {
+					require.Equal(t, tags[i].ID.Value(), v.Id)
+					require.Equal(t, tags[i].Name, v.Name)
+					require.Equal(t, tags[i].UsageCount, v.UsageCount)
+				}
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTagHandler_CreateTag(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	arg := &tag_v1.CreateTagRequest{Name: "work"}
+	param := dto.NewCreateTagParams(uid, arg.Name)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 同じ名前のタグが存在する場合", &domain.ErrAlreadyExists{}, "already_exists"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITagUsecase)
+			if v.err == nil {
+				uc.On("CreateTag", ctx, param).Return(id, nil)
+			} else {
+				uc.On("CreateTag", ctx, param).Return("", v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTagHandler(uc, cr)
+			ret, err := hdr.CreateTag(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, id, ret.Msg.CreatedId)
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTagHandler_RenameTag(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &tag_v1.RenameTagRequest{TagId: "id", Name: "office"}
+	param := dto.NewRenameTagParams(arg.TagId, uid, arg.Name)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: 同じ名前のタグが存在する場合", &domain.ErrAlreadyExists{}, "already_exists"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITagUsecase)
+			if v.err == nil {
+				uc.On("RenameTag", ctx, param).Return(nil)
+			} else {
+				uc.On("RenameTag", ctx, param).Return(v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTagHandler(uc, cr)
+			_, err := hdr.RenameTag(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTagHandler_DeleteTag(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &tag_v1.DeleteTagRequest{TagId: "id"}
+	paramID := dto.NewIDParam(arg.TagId)
+	paramUserID := dto.NewIDParam(uid)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITagUsecase)
+			if v.err == nil {
+				uc.On("DeleteTag", ctx, paramID, paramUserID).Return(nil)
+			} else {
+				uc.On("DeleteTag", ctx, paramID, paramUserID).Return(v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTagHandler(uc, cr)
+			_, err := hdr.DeleteTag(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTagHandler_AttachTag(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &tag_v1.AttachTagRequest{TaskId: "tid", TagId: "gid"}
+	param := dto.NewTagTaskParams(arg.TaskId, arg.TagId, uid)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITagUsecase)
+			if v.err == nil {
+				uc.On("AttachTag", ctx, param).Return(nil)
+			} else {
+				uc.On("AttachTag", ctx, param).Return(v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTagHandler(uc, cr)
+			_, err := hdr.AttachTag(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTagHandler_DetachTag(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &tag_v1.DetachTagRequest{TaskId: "tid", TagId: "gid"}
+	param := dto.NewTagTaskParams(arg.TaskId, arg.TagId, uid)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITagUsecase)
+			if v.err == nil {
+				uc.On("DetachTag", ctx, param).Return(nil)
+			} else {
+				uc.On("DetachTag", ctx, param).Return(v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTagHandler(uc, cr)
+			_, err := hdr.DetachTag(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
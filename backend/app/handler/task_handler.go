@@ -2,11 +2,14 @@ package handler
 
 import (
 	"context"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/7oh2020/connect-tasklist/backend/app"
 	"github.com/7oh2020/connect-tasklist/backend/app/usecase"
 	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
 	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
 	task_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1"
 	"github.com/7oh2020/connect-tasklist/backend/util/contextkey"
@@ -31,32 +34,164 @@ func (h *TaskHandler) GetTaskList(ctx context.Context, arg *connect.Request[task
 		return nil, connect.NewError(connect.CodeUnauthenticated, err)
 	}
 
-	res, err := h.ITaskUsecase.FindTasksByUserID(ctx, dto.NewIDParam(uid))
+	var res []*entity.Task
+	var next string
+	order := toTaskOrder(arg.Msg.Order)
+	workspaceID := h.IContextReader.GetWorkspaceID(ctx)
+	paged := isTaskPageRequest(arg.Msg)
+	switch {
+	case paged && (arg.Msg.AssignedToMe || len(arg.Msg.TagIds) > 0 || arg.Msg.ListId != "" || workspaceID != ""):
+		err = &app.ErrInputValidationFailed{Msg: "paging cannot be combined with tag_ids, list_id, assigned_to_me or workspace"}
+	case paged:
+		res, next, err = h.ITaskUsecase.FindTaskPage(ctx, toTaskPageParams(uid, arg.Msg))
+	case arg.Msg.AssignedToMe:
+		res, err = h.ITaskUsecase.FindTasksByAssigneeID(ctx, dto.NewAssignedFilterParams(uid, order.Value()))
+	case len(arg.Msg.TagIds) > 0:
+		matchAll := arg.Msg.TagMatch == task_v1.TagMatch_TAG_MATCH_ALL
+		res, err = h.ITaskUsecase.FindTasksByTags(ctx, dto.NewTagFilterParams(uid, arg.Msg.ListId, arg.Msg.TagIds, matchAll, order.Value()))
+	case arg.Msg.ListId != "":
+		res, err = h.ITaskUsecase.FindTasksByListID(ctx, dto.NewListFilterParams(arg.Msg.ListId, uid, order.Value()))
+	case workspaceID != "":
+		// ワークスペースが指定された場合はワークスペースの全てのリストのタスクを取得する
+		res, err = h.ITaskUsecase.FindTasksByWorkspaceID(ctx, dto.NewWorkspaceFilterParams(workspaceID, uid, order.Value()))
+	case order == value.TaskOrderPriority:
+		res, err = h.ITaskUsecase.FindTasksByUserIDOrderByPriority(ctx, dto.NewIDParam(uid))
+	case order == value.TaskOrderPosition:
+		res, err = h.ITaskUsecase.FindTasksByUserIDOrderByPosition(ctx, dto.NewIDParam(uid))
+	default:
+		res, err = h.ITaskUsecase.FindTasksByUserID(ctx, dto.NewIDParam(uid))
+	}
 	if err != nil {
 		switch e := err.(type) {
 		case *app.ErrInputValidationFailed:
 			return nil, connect.NewError(connect.CodeInvalidArgument, e)
 		case *domain.ErrValidationFailed:
 			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
 		case *domain.ErrQueryFailed:
 			return nil, connect.NewError(connect.CodeAborted, e)
 		default:
 			return nil, connect.NewError(connect.CodeUnknown, e)
 		}
 	}
-	tasks := make([]*task_v1.Task, len(res))
-	for i, v := range res {
-		tasks[i] = &task_v1.Task{
-			Id:          v.ID.Value(),
-			UserId:      v.UserID.Value(),
-			Name:        v.Name,
-			IsCompleted: v.IsCompleted,
-			CreatedAt:   timestamppb.New(v.CreatedAt),
-			UpdatedAt:   timestamppb.New(v.UpdatedAt),
+	return connect.NewResponse(&task_v1.GetTaskListResponse{
+		Tasks:         toTaskMessages(res),
+		NextPageToken: next,
+	}), nil
+}
+
+// 絞り込み、並び替え、ページングのいずれかが指定されているか
+func isTaskPageRequest(msg *task_v1.GetTaskListRequest) bool {
+	return msg.Filter != nil || msg.SortField != task_v1.TaskSortField_TASK_SORT_FIELD_UNSPECIFIED ||
+		msg.SortDirection != task_v1.SortDirection_SORT_DIRECTION_UNSPECIFIED || msg.PageSize != 0 || msg.PageToken != ""
+}
+
+// リクエストの絞り込みと並び替えの条件をパラメータに変換する
+func toTaskPageParams(uid string, msg *task_v1.GetTaskListRequest) *dto.TaskPageParams {
+	filter := msg.Filter
+	if filter == nil {
+		filter = &task_v1.TaskFilter{}
+	}
+	field := toTaskSortField(msg.SortField)
+	return dto.NewTaskPageParams(uid, toTaskCompletion(filter.Completion).Value(),
+		toTime(filter.CreatedFrom), toTime(filter.CreatedTo), toTime(filter.UpdatedFrom), toTime(filter.UpdatedTo),
+		filter.NameContains, field.Value(), toDescending(field, msg.SortDirection), msg.PageSize, msg.PageToken)
+}
+
+// 並び替えの向きを降順かどうかに変換する。未指定の場合は名前は昇順、それ以外は降順にする
+func toDescending(field value.TaskSortField, d task_v1.SortDirection) bool {
+	switch d {
+	case task_v1.SortDirection_SORT_DIRECTION_ASC:
+		return false
+	case task_v1.SortDirection_SORT_DIRECTION_DESC:
+		return true
+	default:
+		return field != value.TaskSortFieldName
+	}
+}
+
+func (h *TaskHandler) GetOverdueTaskList(ctx context.Context, arg *connect.Request[task_v1.GetOverdueTaskListRequest]) (*connect.Response[task_v1.GetOverdueTaskListResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, err := h.ITaskUsecase.FindOverdueTasksByUserID(ctx, dto.NewIDParam(uid))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
 		}
 	}
-	return connect.NewResponse(&task_v1.GetTaskListResponse{
-		Tasks: tasks,
+	return connect.NewResponse(&task_v1.GetOverdueTaskListResponse{
+		Tasks: toTaskMessages(res),
+	}), nil
+}
+
+func (h *TaskHandler) GetDueTaskList(ctx context.Context, arg *connect.Request[task_v1.GetDueTaskListRequest]) (*connect.Response[task_v1.GetDueTaskListResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, err := h.ITaskUsecase.FindTasksDueBetween(ctx, dto.NewDueRangeParams(uid, toTime(arg.Msg.From), toTime(arg.Msg.To)))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.GetDueTaskListResponse{
+		Tasks: toTaskMessages(res),
+	}), nil
+}
+
+func (h *TaskHandler) GetTaskTree(ctx context.Context, arg *connect.Request[task_v1.GetTaskTreeRequest]) (*connect.Response[task_v1.GetTaskTreeResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, err := h.ITaskUsecase.FindTaskTree(ctx, dto.NewIDParam(arg.Msg.TaskId), dto.NewIDParam(uid))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.GetTaskTreeResponse{
+		Root: toTaskNode(arg.Msg.TaskId, res),
 	}), nil
 }
 
@@ -68,13 +203,19 @@ func (h *TaskHandler) CreateTask(ctx context.Context, arg *connect.Request[task_
 		return nil, connect.NewError(connect.CodeUnauthenticated, err)
 	}
 
-	createdID, err := h.ITaskUsecase.CreateTask(ctx, dto.NewCreateTaskParams(uid, arg.Msg.Name))
+	createdID, err := h.ITaskUsecase.CreateTask(ctx, dto.NewCreateTaskParams(uid, arg.Msg.ListId, arg.Msg.Name))
 	if err != nil {
 		switch e := err.(type) {
 		case *app.ErrInputValidationFailed:
 			return nil, connect.NewError(connect.CodeInvalidArgument, e)
 		case *domain.ErrValidationFailed:
 			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrPreconditionFailed:
+			return nil, connect.NewError(connect.CodeFailedPrecondition, e)
 		case *domain.ErrQueryFailed:
 			return nil, connect.NewError(connect.CodeAborted, e)
 		default:
@@ -86,6 +227,124 @@ func (h *TaskHandler) CreateTask(ctx context.Context, arg *connect.Request[task_
 	}), nil
 }
 
+func (h *TaskHandler) CreateSubtask(ctx context.Context, arg *connect.Request[task_v1.CreateSubtaskRequest]) (*connect.Response[task_v1.CreateSubtaskResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	createdID, err := h.ITaskUsecase.CreateSubtask(ctx, dto.NewCreateSubtaskParams(uid, arg.Msg.ParentTaskId, arg.Msg.Name))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrPreconditionFailed:
+			return nil, connect.NewError(connect.CodeFailedPrecondition, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.CreateSubtaskResponse{
+		CreatedId: createdID,
+	}), nil
+}
+
+func (h *TaskHandler) MoveSubtask(ctx context.Context, arg *connect.Request[task_v1.MoveSubtaskRequest]) (*connect.Response[task_v1.MoveSubtaskResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITaskUsecase.MoveSubtask(ctx, dto.NewMoveSubtaskParams(arg.Msg.TaskId, uid, arg.Msg.ParentTaskId)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrPreconditionFailed:
+			return nil, connect.NewError(connect.CodeFailedPrecondition, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.MoveSubtaskResponse{}), nil
+}
+
+func (h *TaskHandler) MoveTaskToList(ctx context.Context, arg *connect.Request[task_v1.MoveTaskToListRequest]) (*connect.Response[task_v1.MoveTaskToListResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITaskUsecase.MoveTaskToList(ctx, dto.NewMoveTaskToListParams(arg.Msg.TaskId, uid, arg.Msg.ListId)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrPreconditionFailed:
+			return nil, connect.NewError(connect.CodeFailedPrecondition, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.MoveTaskToListResponse{}), nil
+}
+
+func (h *TaskHandler) MoveTask(ctx context.Context, arg *connect.Request[task_v1.MoveTaskRequest]) (*connect.Response[task_v1.MoveTaskResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	after := arg.Msg.Placement == task_v1.MovePlacement_MOVE_PLACEMENT_AFTER
+	if err := h.ITaskUsecase.MoveTask(ctx, dto.NewMoveTaskParams(arg.Msg.TaskId, uid, arg.Msg.TargetTaskId, after)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.MoveTaskResponse{}), nil
+}
+
 func (h *TaskHandler) ChangeTaskName(ctx context.Context, arg *connect.Request[task_v1.ChangeTaskNameRequest]) (*connect.Response[task_v1.ChangeTaskNameResponse], error) {
 	// コンテキストから値を取得する
 	var uid string
@@ -113,7 +372,7 @@ func (h *TaskHandler) ChangeTaskName(ctx context.Context, arg *connect.Request[t
 	return connect.NewResponse(&task_v1.ChangeTaskNameResponse{}), nil
 }
 
-func (h *TaskHandler) CompleteTask(ctx context.Context, arg *connect.Request[task_v1.CompleteTaskRequest]) (*connect.Response[task_v1.CompleteTaskResponse], error) {
+func (h *TaskHandler) ChangeTaskPriority(ctx context.Context, arg *connect.Request[task_v1.ChangeTaskPriorityRequest]) (*connect.Response[task_v1.ChangeTaskPriorityResponse], error) {
 	// コンテキストから値を取得する
 	var uid string
 	var err error
@@ -121,7 +380,7 @@ func (h *TaskHandler) CompleteTask(ctx context.Context, arg *connect.Request[tas
 		return nil, connect.NewError(connect.CodeUnauthenticated, err)
 	}
 
-	if err := h.ITaskUsecase.CompleteTask(ctx, dto.NewIDParam(arg.Msg.TaskId), dto.NewIDParam(uid)); err != nil {
+	if err := h.ITaskUsecase.ChangeTaskPriority(ctx, dto.NewChangeTaskPriorityParams(arg.Msg.TaskId, uid, toPriority(arg.Msg.Priority).Value())); err != nil {
 		switch e := err.(type) {
 		case *app.ErrInputValidationFailed:
 			return nil, connect.NewError(connect.CodeInvalidArgument, e)
@@ -137,10 +396,10 @@ func (h *TaskHandler) CompleteTask(ctx context.Context, arg *connect.Request[tas
 			return nil, connect.NewError(connect.CodeUnknown, e)
 		}
 	}
-	return connect.NewResponse(&task_v1.CompleteTaskResponse{}), nil
+	return connect.NewResponse(&task_v1.ChangeTaskPriorityResponse{}), nil
 }
 
-func (h *TaskHandler) UncompleteTask(ctx context.Context, arg *connect.Request[task_v1.UncompleteTaskRequest]) (*connect.Response[task_v1.UncompleteTaskResponse], error) {
+func (h *TaskHandler) ChangeTaskDescription(ctx context.Context, arg *connect.Request[task_v1.ChangeTaskDescriptionRequest]) (*connect.Response[task_v1.ChangeTaskDescriptionResponse], error) {
 	// コンテキストから値を取得する
 	var uid string
 	var err error
@@ -148,7 +407,7 @@ func (h *TaskHandler) UncompleteTask(ctx context.Context, arg *connect.Request[t
 		return nil, connect.NewError(connect.CodeUnauthenticated, err)
 	}
 
-	if err := h.ITaskUsecase.UncompleteTask(ctx, dto.NewIDParam(arg.Msg.TaskId), dto.NewIDParam(uid)); err != nil {
+	if err := h.ITaskUsecase.ChangeTaskDescription(ctx, dto.NewChangeTaskDescriptionParams(arg.Msg.TaskId, uid, arg.Msg.Description)); err != nil {
 		switch e := err.(type) {
 		case *app.ErrInputValidationFailed:
 			return nil, connect.NewError(connect.CodeInvalidArgument, e)
@@ -164,10 +423,10 @@ func (h *TaskHandler) UncompleteTask(ctx context.Context, arg *connect.Request[t
 			return nil, connect.NewError(connect.CodeUnknown, e)
 		}
 	}
-	return connect.NewResponse(&task_v1.UncompleteTaskResponse{}), nil
+	return connect.NewResponse(&task_v1.ChangeTaskDescriptionResponse{}), nil
 }
 
-func (h *TaskHandler) DeleteTask(ctx context.Context, arg *connect.Request[task_v1.DeleteTaskRequest]) (*connect.Response[task_v1.DeleteTaskResponse], error) {
+func (h *TaskHandler) AssignTask(ctx context.Context, arg *connect.Request[task_v1.AssignTaskRequest]) (*connect.Response[task_v1.AssignTaskResponse], error) {
 	// コンテキストから値を取得する
 	var uid string
 	var err error
@@ -175,7 +434,7 @@ func (h *TaskHandler) DeleteTask(ctx context.Context, arg *connect.Request[task_
 		return nil, connect.NewError(connect.CodeUnauthenticated, err)
 	}
 
-	if err := h.ITaskUsecase.DeleteTask(ctx, dto.NewIDParam(arg.Msg.TaskId), dto.NewIDParam(uid)); err != nil {
+	if err := h.ITaskUsecase.AssignTask(ctx, dto.NewAssignTaskParams(arg.Msg.TaskId, uid, arg.Msg.AssigneeId)); err != nil {
 		switch e := err.(type) {
 		case *app.ErrInputValidationFailed:
 			return nil, connect.NewError(connect.CodeInvalidArgument, e)
@@ -185,12 +444,781 @@ func (h *TaskHandler) DeleteTask(ctx context.Context, arg *connect.Request[task_
 			return nil, connect.NewError(connect.CodeNotFound, e)
 		case *domain.ErrPermissionDenied:
 			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrPreconditionFailed:
+			return nil, connect.NewError(connect.CodeFailedPrecondition, e)
 		case *domain.ErrQueryFailed:
 			return nil, connect.NewError(connect.CodeAborted, e)
 		default:
 			return nil, connect.NewError(connect.CodeUnknown, e)
 		}
 	}
-	return connect.NewResponse(&task_v1.DeleteTaskResponse{}), nil
+	return connect.NewResponse(&task_v1.AssignTaskResponse{}), nil
+}
+
+func (h *TaskHandler) UnassignTask(ctx context.Context, arg *connect.Request[task_v1.UnassignTaskRequest]) (*connect.Response[task_v1.UnassignTaskResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITaskUsecase.UnassignTask(ctx, dto.NewIDParam(arg.Msg.TaskId), dto.NewIDParam(uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.UnassignTaskResponse{}), nil
+}
+
+func (h *TaskHandler) CompleteTask(ctx context.Context, arg *connect.Request[task_v1.CompleteTaskRequest]) (*connect.Response[task_v1.CompleteTaskResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITaskUsecase.CompleteTask(ctx, dto.NewIDParam(arg.Msg.TaskId), dto.NewIDParam(uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrPreconditionFailed:
+			return nil, connect.NewError(connect.CodeFailedPrecondition, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.CompleteTaskResponse{}), nil
+}
 
+func (h *TaskHandler) UncompleteTask(ctx context.Context, arg *connect.Request[task_v1.UncompleteTaskRequest]) (*connect.Response[task_v1.UncompleteTaskResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITaskUsecase.UncompleteTask(ctx, dto.NewIDParam(arg.Msg.TaskId), dto.NewIDParam(uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrPreconditionFailed:
+			return nil, connect.NewError(connect.CodeFailedPrecondition, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.UncompleteTaskResponse{}), nil
+}
+
+func (h *TaskHandler) TransitionTask(ctx context.Context, arg *connect.Request[task_v1.TransitionTaskRequest]) (*connect.Response[task_v1.TransitionTaskResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITaskUsecase.TransitionTask(ctx, dto.NewTransitionTaskParams(arg.Msg.TaskId, uid, toTaskStatus(arg.Msg.Status).Value())); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrPreconditionFailed:
+			return nil, connect.NewError(connect.CodeFailedPrecondition, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.TransitionTaskResponse{}), nil
+}
+
+func (h *TaskHandler) SetTaskDueDate(ctx context.Context, arg *connect.Request[task_v1.SetTaskDueDateRequest]) (*connect.Response[task_v1.SetTaskDueDateResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITaskUsecase.SetTaskDueDate(ctx, dto.NewSetTaskDueDateParams(arg.Msg.TaskId, uid, toTime(arg.Msg.DueAt))); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.SetTaskDueDateResponse{}), nil
+}
+
+func (h *TaskHandler) ClearTaskDueDate(ctx context.Context, arg *connect.Request[task_v1.ClearTaskDueDateRequest]) (*connect.Response[task_v1.ClearTaskDueDateResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITaskUsecase.ClearTaskDueDate(ctx, dto.NewIDParam(arg.Msg.TaskId), dto.NewIDParam(uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.ClearTaskDueDateResponse{}), nil
+}
+
+func (h *TaskHandler) SetTaskRecurrence(ctx context.Context, arg *connect.Request[task_v1.SetTaskRecurrenceRequest]) (*connect.Response[task_v1.SetTaskRecurrenceResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITaskUsecase.SetTaskRecurrence(ctx, dto.NewSetTaskRecurrenceParams(arg.Msg.TaskId, uid, arg.Msg.Rule, arg.Msg.Timezone)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrPreconditionFailed:
+			return nil, connect.NewError(connect.CodeFailedPrecondition, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.SetTaskRecurrenceResponse{}), nil
+}
+
+func (h *TaskHandler) ClearTaskRecurrence(ctx context.Context, arg *connect.Request[task_v1.ClearTaskRecurrenceRequest]) (*connect.Response[task_v1.ClearTaskRecurrenceResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITaskUsecase.ClearTaskRecurrence(ctx, dto.NewIDParam(arg.Msg.TaskId), dto.NewIDParam(uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.ClearTaskRecurrenceResponse{}), nil
+}
+
+func (h *TaskHandler) AddTaskDependency(ctx context.Context, arg *connect.Request[task_v1.AddTaskDependencyRequest]) (*connect.Response[task_v1.AddTaskDependencyResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITaskUsecase.AddTaskDependency(ctx, dto.NewTaskDependencyParams(arg.Msg.TaskId, uid, arg.Msg.BlockerTaskId)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.AddTaskDependencyResponse{}), nil
+}
+
+func (h *TaskHandler) RemoveTaskDependency(ctx context.Context, arg *connect.Request[task_v1.RemoveTaskDependencyRequest]) (*connect.Response[task_v1.RemoveTaskDependencyResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITaskUsecase.RemoveTaskDependency(ctx, dto.NewTaskDependencyParams(arg.Msg.TaskId, uid, arg.Msg.BlockerTaskId)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.RemoveTaskDependencyResponse{}), nil
+}
+
+func (h *TaskHandler) DeleteTask(ctx context.Context, arg *connect.Request[task_v1.DeleteTaskRequest]) (*connect.Response[task_v1.DeleteTaskResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITaskUsecase.DeleteTask(ctx, dto.NewIDParam(arg.Msg.TaskId), dto.NewIDParam(uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.DeleteTaskResponse{}), nil
+
+}
+
+func (h *TaskHandler) ListTrash(ctx context.Context, arg *connect.Request[task_v1.ListTrashRequest]) (*connect.Response[task_v1.ListTrashResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, err := h.ITaskUsecase.FindTrashedTasksByUserID(ctx, dto.NewIDParam(uid))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.ListTrashResponse{
+		Tasks: toTaskMessages(res),
+	}), nil
+}
+
+func (h *TaskHandler) RestoreTask(ctx context.Context, arg *connect.Request[task_v1.RestoreTaskRequest]) (*connect.Response[task_v1.RestoreTaskResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITaskUsecase.RestoreTask(ctx, dto.NewIDParam(arg.Msg.TaskId), dto.NewIDParam(uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrPreconditionFailed:
+			return nil, connect.NewError(connect.CodeFailedPrecondition, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.RestoreTaskResponse{}), nil
+}
+
+func (h *TaskHandler) EmptyTrash(ctx context.Context, arg *connect.Request[task_v1.EmptyTrashRequest]) (*connect.Response[task_v1.EmptyTrashResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITaskUsecase.EmptyTrash(ctx, dto.NewIDParam(uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.EmptyTrashResponse{}), nil
+}
+
+func (h *TaskHandler) GetTaskHistory(ctx context.Context, arg *connect.Request[task_v1.GetTaskHistoryRequest]) (*connect.Response[task_v1.GetTaskHistoryResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, next, err := h.ITaskUsecase.FindTaskHistory(ctx, dto.NewTaskHistoryParams(arg.Msg.TaskId, uid, arg.Msg.PageSize, arg.Msg.PageToken))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	histories := make([]*task_v1.TaskHistory, len(res))
+	for i, v := range res {
+		histories[i] = toTaskHistoryMessage(v)
+	}
+	return connect.NewResponse(&task_v1.GetTaskHistoryResponse{
+		Histories:     histories,
+		NextPageToken: next,
+	}), nil
+}
+
+func (h *TaskHandler) BatchMutateTasks(ctx context.Context, arg *connect.Request[task_v1.BatchMutateTasksRequest]) (*connect.Response[task_v1.BatchMutateTasksResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	ops := make([]*dto.TaskOperationParams, len(arg.Msg.Operations))
+	for i, v := range arg.Msg.Operations {
+		ops[i] = dto.NewTaskOperationParams(int32(v.Kind)-1, v.TaskId, v.ListId, v.Name)
+	}
+	atomic := arg.Msg.Mode != task_v1.BatchMode_BATCH_MODE_BEST_EFFORT
+	res, err := h.ITaskUsecase.BatchMutateTasks(ctx, dto.NewBatchMutateTasksParams(uid, ops, atomic))
+	if err != nil {
+		return nil, connect.NewError(toErrorCode(err), err)
+	}
+	committed := true
+	results := make([]*task_v1.TaskOperationResult, len(res))
+	for i, v := range res {
+		results[i] = &task_v1.TaskOperationResult{
+			Status: task_v1.TaskOperationStatus(v.Status + 1),
+			TaskId: v.TaskID,
+		}
+		if v.Err != nil {
+			results[i].ErrorCode = toErrorCode(v.Err).String()
+			results[i].ErrorMessage = v.Err.Error()
+		}
+		if atomic && v.Status == value.TaskOperationStatusFailed {
+			committed = false
+		}
+	}
+	return connect.NewResponse(&task_v1.BatchMutateTasksResponse{
+		Results:   results,
+		Committed: committed,
+	}), nil
+}
+
+func (h *TaskHandler) Undo(ctx context.Context, arg *connect.Request[task_v1.UndoRequest]) (*connect.Response[task_v1.UndoResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, err := h.ITaskUsecase.Undo(ctx, dto.NewIDParam(uid))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrPreconditionFailed:
+			return nil, connect.NewError(connect.CodeFailedPrecondition, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.UndoResponse{
+		TaskId: res.TaskID.Value(),
+		Action: toTaskHistoryActionMessage(res.Action),
+	}), nil
+}
+
+func (h *TaskHandler) Redo(ctx context.Context, arg *connect.Request[task_v1.RedoRequest]) (*connect.Response[task_v1.RedoResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, err := h.ITaskUsecase.Redo(ctx, dto.NewIDParam(uid))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrPreconditionFailed:
+			return nil, connect.NewError(connect.CodeFailedPrecondition, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&task_v1.RedoResponse{
+		TaskId: res.TaskID.Value(),
+		Action: toTaskHistoryActionMessage(res.Action),
+	}), nil
+}
+
+// TaskEntityをレスポンス用のメッセージに変換する
+func toTaskMessage(v *entity.Task) *task_v1.Task {
+	task := &task_v1.Task{
+		Id:                 v.ID.Value(),
+		UserId:             v.UserID.Value(),
+		Name:               v.Name,
+		IsCompleted:        v.Status == value.TaskStatusDone,
+		CreatedAt:          timestamppb.New(v.CreatedAt),
+		UpdatedAt:          timestamppb.New(v.UpdatedAt),
+		Priority:           toPriorityMessage(v.Priority),
+		Description:        v.Description,
+		DescriptionHtml:    v.DescriptionHTML,
+		Position:           v.Position.Value(),
+		RecurrenceRule:     v.RecurrenceRule.Value(),
+		RecurrenceTimezone: v.RecurrenceTimezone,
+		IsBlocked:          v.IsBlocked,
+		Status:             toTaskStatusMessage(v.Status),
+	}
+	if v.DueAt != nil {
+		task.DueAt = timestamppb.New(*v.DueAt)
+	}
+	if v.ParentID != nil {
+		task.ParentId = v.ParentID.Value()
+	}
+	if v.ListID != nil {
+		task.ListId = v.ListID.Value()
+	}
+	if v.DeletedAt != nil {
+		task.DeletedAt = timestamppb.New(*v.DeletedAt)
+	}
+	if v.AssigneeID != nil {
+		task.AssigneeId = v.AssigneeID.Value()
+	}
+	return task
+}
+
+// TaskEntityのスライスをレスポンス用のメッセージに変換する
+func toTaskMessages(res []*entity.Task) []*task_v1.Task {
+	tasks := make([]*task_v1.Task, len(res))
+	for i, v := range res {
+		tasks[i] = toTaskMessage(v)
+	}
+	return tasks
+}
+
+// フラットなタスクのスライスをrootIDを根とするツリーに変換する
+func toTaskNode(rootID string, res []*entity.Task) *task_v1.TaskNode {
+	var root *task_v1.TaskNode
+	nodes := make(map[string]*task_v1.TaskNode, len(res))
+	for _, v := range res {
+		nodes[v.ID.Value()] = &task_v1.TaskNode{Task: toTaskMessage(v)}
+	}
+	// 作成日時の順序を保ったまま親ノードに子ノードを追加する
+	for _, v := range res {
+		node := nodes[v.ID.Value()]
+		if v.ID.Equal(rootID) {
+			root = node
+			continue
+		}
+		if v.ParentID == nil {
+			continue
+		}
+		if parent, ok := nodes[v.ParentID.Value()]; ok {
+			parent.Children = append(parent.Children, node)
+		}
+	}
+	return root
+}
+
+// ドメインの優先度をレスポンス用の値に変換する
+func toPriorityMessage(p value.Priority) task_v1.Priority {
+	switch p {
+	case value.PriorityNone:
+		return task_v1.Priority_PRIORITY_NONE
+	case value.PriorityLow:
+		return task_v1.Priority_PRIORITY_LOW
+	case value.PriorityMedium:
+		return task_v1.Priority_PRIORITY_MEDIUM
+	case value.PriorityHigh:
+		return task_v1.Priority_PRIORITY_HIGH
+	case value.PriorityUrgent:
+		return task_v1.Priority_PRIORITY_URGENT
+	default:
+		return task_v1.Priority_PRIORITY_UNSPECIFIED
+	}
+}
+
+// リクエストの優先度をドメインの優先度に変換する。未指定の場合はPriorityUnknownを返す
+func toPriority(p task_v1.Priority) value.Priority {
+	switch p {
+	case task_v1.Priority_PRIORITY_NONE:
+		return value.PriorityNone
+	case task_v1.Priority_PRIORITY_LOW:
+		return value.PriorityLow
+	case task_v1.Priority_PRIORITY_MEDIUM:
+		return value.PriorityMedium
+	case task_v1.Priority_PRIORITY_HIGH:
+		return value.PriorityHigh
+	case task_v1.Priority_PRIORITY_URGENT:
+		return value.PriorityUrgent
+	default:
+		return value.PriorityUnknown
+	}
+}
+
+// リクエストの状態をドメインの状態に変換する。未指定の場合はTaskStatusUnknownを返す
+func toTaskStatus(s task_v1.TaskStatus) value.TaskStatus {
+	switch s {
+	case task_v1.TaskStatus_TASK_STATUS_TODO:
+		return value.TaskStatusTodo
+	case task_v1.TaskStatus_TASK_STATUS_IN_PROGRESS:
+		return value.TaskStatusInProgress
+	case task_v1.TaskStatus_TASK_STATUS_WAITING:
+		return value.TaskStatusWaiting
+	case task_v1.TaskStatus_TASK_STATUS_DONE:
+		return value.TaskStatusDone
+	case task_v1.TaskStatus_TASK_STATUS_CANCELLED:
+		return value.TaskStatusCancelled
+	default:
+		return value.TaskStatusUnknown
+	}
+}
+
+// ドメインの状態をレスポンス用の値に変換する
+func toTaskStatusMessage(s value.TaskStatus) task_v1.TaskStatus {
+	switch s {
+	case value.TaskStatusTodo:
+		return task_v1.TaskStatus_TASK_STATUS_TODO
+	case value.TaskStatusInProgress:
+		return task_v1.TaskStatus_TASK_STATUS_IN_PROGRESS
+	case value.TaskStatusWaiting:
+		return task_v1.TaskStatus_TASK_STATUS_WAITING
+	case value.TaskStatusDone:
+		return task_v1.TaskStatus_TASK_STATUS_DONE
+	case value.TaskStatusCancelled:
+		return task_v1.TaskStatus_TASK_STATUS_CANCELLED
+	default:
+		return task_v1.TaskStatus_TASK_STATUS_UNSPECIFIED
+	}
+}
+
+// TaskHistoryEntityをレスポンス用のメッセージに変換する
+func toTaskHistoryMessage(v *entity.TaskHistory) *task_v1.TaskHistory {
+	history := &task_v1.TaskHistory{
+		Id:        v.ID.Value(),
+		TaskId:    v.TaskID.Value(),
+		Action:    toTaskHistoryActionMessage(v.Action),
+		OldValue:  v.OldValue,
+		NewValue:  v.NewValue,
+		CreatedAt: timestamppb.New(v.CreatedAt),
+	}
+	if v.ActorID != nil {
+		history.ActorId = v.ActorID.Value()
+	}
+	return history
+}
+
+// ドメインの変更の種類をレスポンス用の変更の種類に変換する
+func toTaskHistoryActionMessage(a value.TaskHistoryAction) task_v1.TaskHistoryAction {
+	switch a {
+	case value.TaskHistoryActionCreated:
+		return task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_CREATED
+	case value.TaskHistoryActionRenamed:
+		return task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_RENAMED
+	case value.TaskHistoryActionStatusChanged:
+		return task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_STATUS_CHANGED
+	case value.TaskHistoryActionDeleted:
+		return task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_DELETED
+	case value.TaskHistoryActionRestored:
+		return task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_RESTORED
+	case value.TaskHistoryActionPriorityChanged:
+		return task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_PRIORITY_CHANGED
+	case value.TaskHistoryActionDescriptionChanged:
+		return task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_DESCRIPTION_CHANGED
+	case value.TaskHistoryActionAssigneeChanged:
+		return task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_ASSIGNEE_CHANGED
+	case value.TaskHistoryActionDueDateChanged:
+		return task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_DUE_DATE_CHANGED
+	case value.TaskHistoryActionRecurrenceChanged:
+		return task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_RECURRENCE_CHANGED
+	case value.TaskHistoryActionParentChanged:
+		return task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_PARENT_CHANGED
+	case value.TaskHistoryActionPositionChanged:
+		return task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_POSITION_CHANGED
+	case value.TaskHistoryActionListChanged:
+		return task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_LIST_CHANGED
+	case value.TaskHistoryActionDependencyAdded:
+		return task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_DEPENDENCY_ADDED
+	case value.TaskHistoryActionDependencyRemoved:
+		return task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_DEPENDENCY_REMOVED
+	default:
+		return task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_UNSPECIFIED
+	}
+}
+
+// リクエストの並び順をドメインの並び順に変換する。未指定の場合は更新日時の新しい順
+func toTaskOrder(o task_v1.TaskOrder) value.TaskOrder {
+	switch o {
+	case task_v1.TaskOrder_TASK_ORDER_PRIORITY:
+		return value.TaskOrderPriority
+	case task_v1.TaskOrder_TASK_ORDER_POSITION:
+		return value.TaskOrderPosition
+	default:
+		return value.TaskOrderUpdatedAt
+	}
+}
+
+// リクエストの完了状態をTaskCompletionに変換する。範囲外の値はバリデーションで拒否されるように変換する
+func toTaskCompletion(c task_v1.TaskCompletion) value.TaskCompletion {
+	switch c {
+	case task_v1.TaskCompletion_TASK_COMPLETION_UNSPECIFIED:
+		return value.TaskCompletionAll
+	case task_v1.TaskCompletion_TASK_COMPLETION_OPEN:
+		return value.TaskCompletionOpen
+	case task_v1.TaskCompletion_TASK_COMPLETION_CLOSED:
+		return value.TaskCompletionClosed
+	default:
+		return value.TaskCompletion(-1)
+	}
+}
+
+// リクエストの並び替えの項目をTaskSortFieldに変換する。範囲外の値はバリデーションで拒否されるように変換する
+func toTaskSortField(f task_v1.TaskSortField) value.TaskSortField {
+	switch f {
+	case task_v1.TaskSortField_TASK_SORT_FIELD_UNSPECIFIED, task_v1.TaskSortField_TASK_SORT_FIELD_UPDATED_AT:
+		return value.TaskSortFieldUpdatedAt
+	case task_v1.TaskSortField_TASK_SORT_FIELD_CREATED_AT:
+		return value.TaskSortFieldCreatedAt
+	case task_v1.TaskSortField_TASK_SORT_FIELD_NAME:
+		return value.TaskSortFieldName
+	case task_v1.TaskSortField_TASK_SORT_FIELD_PRIORITY:
+		return value.TaskSortFieldPriority
+	default:
+		return value.TaskSortField(-1)
+	}
+}
+
+// リクエストの日時をtime.Timeに変換する。未指定の場合はゼロ値を返す
+func toTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1/task_v1connect"
 	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestTaskHandler_NewTaskHandler(tt *testing.T) {
@@ -29,8 +30,8 @@ func TestTaskHandler_GetTaskList(tt *testing.T) {
 	now := time.Now().UTC()
 	uid := "uid"
 	tasks := []*entity.Task{
-		{ID: value.NewID("t1"), UserID: value.NewID(uid), Name: "task1", IsCompleted: false, CreatedAt: now, UpdatedAt: now},
-		{ID: value.NewID("t2"), UserID: value.NewID(uid), Name: "task2", IsCompleted: false, CreatedAt: now, UpdatedAt: now},
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), Name: "task1", CreatedAt: now, UpdatedAt: now},
+		{ID: value.NewID("t2"), UserID: value.NewID(uid), Name: "task2", CreatedAt: now, UpdatedAt: now, IsBlocked: true},
 	}
 	arg := &task_v1.GetTaskListRequest{}
 	param := dto.NewIDParam(uid)
@@ -57,6 +58,7 @@ func TestTaskHandler_GetTaskList(tt *testing.T) {
 			}
 			cr := new(mocks.IContextReader)
 			cr.On("GetUserID", ctx).Return(uid, nil)
+			cr.On("GetWorkspaceID", ctx).Return("")
 			hdr := NewTaskHandler(uc, cr)
 			ret, err := hdr.GetTaskList(ctx, req)
 
@@ -66,7 +68,9 @@ func TestTaskHandler_GetTaskList(tt *testing.T) {
 					require.Equal(t, tasks[i].ID.Value(), v.Id)
 					require.Equal(t, tasks[i].UserID.Value(), v.UserId)
 					require.Equal(t, tasks[i].Name, v.Name)
-					require.Equal(t, tasks[i].IsCompleted, v.IsCompleted)
+					require.Equal(t, tasks[i].Status == value.TaskStatusDone, v.IsCompleted)
+					require.Equal(t, toTaskStatusMessage(tasks[i].Status), v.Status)
+					require.Equal(t, tasks[i].IsBlocked, v.IsBlocked)
 				}
 			} else {
 				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
@@ -82,8 +86,8 @@ func TestTaskHandler_CreateTask(tt *testing.T) {
 	ctx := context.Background()
 	id := "id"
 	uid := "uid"
-	arg := &task_v1.CreateTaskRequest{Name: "task"}
-	param := dto.NewCreateTaskParams(uid, arg.Name)
+	arg := &task_v1.CreateTaskRequest{Name: "task", ListId: "lid"}
+	param := dto.NewCreateTaskParams(uid, arg.ListId, arg.Name)
 	req := connect.NewRequest(arg)
 
 	testcases := []struct {
@@ -94,6 +98,9 @@ func TestTaskHandler_CreateTask(tt *testing.T) {
 		{"正常系: 正しい入力の場合", nil, ""},
 		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
 		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: リストが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: リストがアーカイブされている場合", &domain.ErrPreconditionFailed{}, "failed_precondition"},
 		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
 		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
 	}
@@ -235,6 +242,7 @@ func TestTaskHandler_CompleteTask(tt *testing.T) {
 		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
 		{"準正常系: タスクが存在しない場合", &domain.ErrNotFound{}, "not_found"},
 		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: 前提条件を満たさない場合", &domain.ErrPreconditionFailed{}, "failed_precondition"},
 		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
 		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
 	}
@@ -282,6 +290,7 @@ func TestTaskHandler_UncompleteTask(tt *testing.T) {
 		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
 		{"準正常系: タスクが存在しない場合", &domain.ErrNotFound{}, "not_found"},
 		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: 前提条件を満たさない場合", &domain.ErrPreconditionFailed{}, "failed_precondition"},
 		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
 		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
 	}
@@ -309,3 +318,1601 @@ func TestTaskHandler_UncompleteTask(tt *testing.T) {
 		})
 	}
 }
+
+func TestTaskHandler_TransitionTask(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &task_v1.TransitionTaskRequest{TaskId: "id", Status: task_v1.TaskStatus_TASK_STATUS_IN_PROGRESS}
+	param := dto.NewTransitionTaskParams(arg.TaskId, uid, value.TaskStatusInProgress.Value())
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: タスクが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: 前提条件を満たさない場合", &domain.ErrPreconditionFailed{}, "failed_precondition"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("TransitionTask", ctx, param).Return(nil)
+			} else {
+				uc.On("TransitionTask", ctx, param).Return(v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			_, err := hdr.TransitionTask(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+	tt.Run("準正常系: 状態が未指定の場合は不明な状態として渡すこと", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "invalid status"}
+		uc := new(mocks.ITaskUsecase)
+		uc.On("TransitionTask", ctx, dto.NewTransitionTaskParams("id", uid, value.TaskStatusUnknown.Value())).Return(errExp)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		hdr := NewTaskHandler(uc, cr)
+		_, err := hdr.TransitionTask(ctx, connect.NewRequest(&task_v1.TransitionTaskRequest{TaskId: "id"}))
+
+		require.EqualError(t, err, fmt.Sprintf("invalid_argument: %s", errExp.Error()), "エラーが一致すること")
+		uc.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+}
+
+func TestTaskHandler_GetOverdueTaskList(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	due := now.Add(-time.Hour)
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), Name: "task1", CreatedAt: now, UpdatedAt: now, DueAt: &due},
+	}
+	arg := &task_v1.GetOverdueTaskListRequest{}
+	param := dto.NewIDParam(uid)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("FindOverdueTasksByUserID", ctx, param).Return(tasks, nil)
+			} else {
+				uc.On("FindOverdueTasksByUserID", ctx, param).Return(nil, v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			ret, err := hdr.GetOverdueTaskList(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Len(t, ret.Msg.Tasks, len(tasks))
+				for i, v := range ret.Msg.Tasks {
+					require.Equal(t, tasks[i].ID.Value(), v.Id)
+					require.Equal(t, *tasks[i].DueAt, v.DueAt.AsTime())
+				}
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_GetDueTaskList(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	from := now
+	to := now.AddDate(0, 0, 7)
+	due := now.AddDate(0, 0, 1)
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), Name: "task1", CreatedAt: now, UpdatedAt: now, DueAt: &due},
+	}
+	arg := &task_v1.GetDueTaskListRequest{From: timestamppb.New(from), To: timestamppb.New(to)}
+	param := dto.NewDueRangeParams(uid, arg.From.AsTime(), arg.To.AsTime())
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("FindTasksDueBetween", ctx, param).Return(tasks, nil)
+			} else {
+				uc.On("FindTasksDueBetween", ctx, param).Return(nil, v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			ret, err := hdr.GetDueTaskList(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Len(t, ret.Msg.Tasks, len(tasks))
+				for i, v := range ret.Msg.Tasks {
+					require.Equal(t, tasks[i].ID.Value(), v.Id)
+					require.Equal(t, *tasks[i].DueAt, v.DueAt.AsTime())
+				}
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_SetTaskDueDate(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	due := time.Now().UTC().AddDate(0, 0, 1)
+	arg := &task_v1.SetTaskDueDateRequest{TaskId: id, DueAt: timestamppb.New(due)}
+	param := dto.NewSetTaskDueDateParams(arg.TaskId, uid, arg.DueAt.AsTime())
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: タスクが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("SetTaskDueDate", ctx, param).Return(nil)
+			} else {
+				uc.On("SetTaskDueDate", ctx, param).Return(v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			_, err := hdr.SetTaskDueDate(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_ClearTaskDueDate(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	arg := &task_v1.ClearTaskDueDateRequest{TaskId: id}
+	paramID := dto.NewIDParam(arg.TaskId)
+	paramUserID := dto.NewIDParam(uid)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: タスクが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("ClearTaskDueDate", ctx, paramID, paramUserID).Return(nil)
+			} else {
+				uc.On("ClearTaskDueDate", ctx, paramID, paramUserID).Return(v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			_, err := hdr.ClearTaskDueDate(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_GetTaskList_OrderByPriority(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), Name: "task1", CreatedAt: now, UpdatedAt: now, Priority: value.PriorityUrgent},
+		{ID: value.NewID("t2"), UserID: value.NewID(uid), Name: "task2", CreatedAt: now, UpdatedAt: now, Priority: value.PriorityNone},
+	}
+	arg := &task_v1.GetTaskListRequest{Order: task_v1.TaskOrder_TASK_ORDER_PRIORITY}
+	param := dto.NewIDParam(uid)
+	req := connect.NewRequest(arg)
+
+	tt.Run("正常系: 優先度順を指定した場合", func(t *testing.T) {
+		uc := new(mocks.ITaskUsecase)
+		uc.On("FindTasksByUserIDOrderByPriority", ctx, param).Return(tasks, nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		cr.On("GetWorkspaceID", ctx).Return("")
+		hdr := NewTaskHandler(uc, cr)
+		ret, err := hdr.GetTaskList(ctx, req)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Len(t, ret.Msg.Tasks, len(tasks))
+		require.Equal(t, task_v1.Priority_PRIORITY_URGENT, ret.Msg.Tasks[0].Priority)
+		require.Equal(t, task_v1.Priority_PRIORITY_NONE, ret.Msg.Tasks[1].Priority)
+		uc.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+}
+
+func TestTaskHandler_GetTaskList_Workspace(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID("owner"), ListID: value.NewID("lid"), Name: "task1", CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: ワークスペースが指定された場合", func(t *testing.T) {
+		uc := new(mocks.ITaskUsecase)
+		uc.On("FindTasksByWorkspaceID", ctx, dto.NewWorkspaceFilterParams("wid", uid, value.TaskOrderPriority.Value())).Return(tasks, nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		cr.On("GetWorkspaceID", ctx).Return("wid")
+		hdr := NewTaskHandler(uc, cr)
+		ret, err := hdr.GetTaskList(ctx, connect.NewRequest(&task_v1.GetTaskListRequest{Order: task_v1.TaskOrder_TASK_ORDER_PRIORITY}))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Len(t, ret.Msg.Tasks, len(tasks))
+		uc.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+	tt.Run("正常系: リストの指定が優先されること", func(t *testing.T) {
+		uc := new(mocks.ITaskUsecase)
+		uc.On("FindTasksByListID", ctx, dto.NewListFilterParams("lid", uid, value.TaskOrderUpdatedAt.Value())).Return(tasks, nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		cr.On("GetWorkspaceID", ctx).Return("wid")
+		hdr := NewTaskHandler(uc, cr)
+		_, err := hdr.GetTaskList(ctx, connect.NewRequest(&task_v1.GetTaskListRequest{ListId: "lid"}))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		uc.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+}
+
+func TestTaskHandler_GetTaskList_Page(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	from := now.Add(-24 * time.Hour)
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), Name: "task1", CreatedAt: now, UpdatedAt: now},
+	}
+
+	testcases := []struct {
+		title   string
+		arg     *task_v1.GetTaskListRequest
+		param   *dto.TaskPageParams
+		err     error
+		codeStr string
+	}{
+		{
+			"正常系: ページサイズのみを指定した場合は更新日時の降順になること",
+			&task_v1.GetTaskListRequest{PageSize: 10},
+			dto.NewTaskPageParams(uid, value.TaskCompletionAll.Value(), time.Time{}, time.Time{}, time.Time{}, time.Time{}, "", value.TaskSortFieldUpdatedAt.Value(), true, 10, ""),
+			nil, "",
+		},
+		{
+			"正常系: 名前順を指定した場合は昇順になること",
+			&task_v1.GetTaskListRequest{SortField: task_v1.TaskSortField_TASK_SORT_FIELD_NAME, PageToken: "token"},
+			dto.NewTaskPageParams(uid, value.TaskCompletionAll.Value(), time.Time{}, time.Time{}, time.Time{}, time.Time{}, "", value.TaskSortFieldName.Value(), false, 0, "token"),
+			nil, "",
+		},
+		{
+			"正常系: 絞り込みと並び順を指定した場合",
+			&task_v1.GetTaskListRequest{
+				Filter:        &task_v1.TaskFilter{Completion: task_v1.TaskCompletion_TASK_COMPLETION_OPEN, CreatedFrom: timestamppb.New(from), UpdatedTo: timestamppb.New(now), NameContains: "task"},
+				SortField:     task_v1.TaskSortField_TASK_SORT_FIELD_PRIORITY,
+				SortDirection: task_v1.SortDirection_SORT_DIRECTION_ASC,
+			},
+			dto.NewTaskPageParams(uid, value.TaskCompletionOpen.Value(), from, time.Time{}, time.Time{}, now, "task", value.TaskSortFieldPriority.Value(), false, 0, ""),
+			nil, "",
+		},
+		{
+			"準正常系: アプリ側バリデーションエラーの場合",
+			&task_v1.GetTaskListRequest{PageSize: 1000},
+			dto.NewTaskPageParams(uid, value.TaskCompletionAll.Value(), time.Time{}, time.Time{}, time.Time{}, time.Time{}, "", value.TaskSortFieldUpdatedAt.Value(), true, 1000, ""),
+			&app.ErrInputValidationFailed{}, "invalid_argument",
+		},
+		{
+			"準正常系: ページトークンが不正な場合",
+			&task_v1.GetTaskListRequest{PageToken: "invalid"},
+			dto.NewTaskPageParams(uid, value.TaskCompletionAll.Value(), time.Time{}, time.Time{}, time.Time{}, time.Time{}, "", value.TaskSortFieldUpdatedAt.Value(), true, 0, "invalid"),
+			&domain.ErrValidationFailed{}, "invalid_argument",
+		},
+		{
+			"準正常系: クエリエラーの場合",
+			&task_v1.GetTaskListRequest{PageSize: 10},
+			dto.NewTaskPageParams(uid, value.TaskCompletionAll.Value(), time.Time{}, time.Time{}, time.Time{}, time.Time{}, "", value.TaskSortFieldUpdatedAt.Value(), true, 10, ""),
+			&domain.ErrQueryFailed{}, "aborted",
+		},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("FindTaskPage", ctx, v.param).Return(tasks, "next", nil)
+			} else {
+				uc.On("FindTaskPage", ctx, v.param).Return(nil, "", v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			cr.On("GetWorkspaceID", ctx).Return("")
+			hdr := NewTaskHandler(uc, cr)
+			ret, err := hdr.GetTaskList(ctx, connect.NewRequest(v.arg))
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Len(t, ret.Msg.Tasks, len(tasks))
+				require.Equal(t, "next", ret.Msg.NextPageToken, "次のページのトークンが返されること")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+
+	combined := []struct {
+		title       string
+		arg         *task_v1.GetTaskListRequest
+		workspaceID string
+	}{
+		{"準正常系: タグと同時に指定した場合", &task_v1.GetTaskListRequest{PageSize: 10, TagIds: []string{"tag"}}, ""},
+		{"準正常系: リストと同時に指定した場合", &task_v1.GetTaskListRequest{PageSize: 10, ListId: "lid"}, ""},
+		{"準正常系: 担当タスクと同時に指定した場合", &task_v1.GetTaskListRequest{PageSize: 10, AssignedToMe: true}, ""},
+		{"準正常系: ワークスペースと同時に指定した場合", &task_v1.GetTaskListRequest{PageSize: 10}, "wid"},
+	}
+	for _, v := range combined {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			cr.On("GetWorkspaceID", ctx).Return(v.workspaceID)
+			hdr := NewTaskHandler(uc, cr)
+			_, err := hdr.GetTaskList(ctx, connect.NewRequest(v.arg))
+
+			require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "入力エラーになること")
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_ChangeTaskPriority(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	testcases := []struct {
+		title    string
+		priority task_v1.Priority
+		param    *dto.ChangeTaskPriorityParams
+		err      error
+		codeStr  string
+	}{
+		{"正常系: 正しい入力の場合", task_v1.Priority_PRIORITY_HIGH, dto.NewChangeTaskPriorityParams(id, uid, value.PriorityHigh.Value()), nil, ""},
+		{"正常系: 優先度なしを指定した場合", task_v1.Priority_PRIORITY_NONE, dto.NewChangeTaskPriorityParams(id, uid, value.PriorityNone.Value()), nil, ""},
+		{"準正常系: 優先度が未指定の場合", task_v1.Priority_PRIORITY_UNSPECIFIED, dto.NewChangeTaskPriorityParams(id, uid, value.PriorityUnknown.Value()), &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: タスクが存在しない場合", task_v1.Priority_PRIORITY_HIGH, dto.NewChangeTaskPriorityParams(id, uid, value.PriorityHigh.Value()), &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", task_v1.Priority_PRIORITY_HIGH, dto.NewChangeTaskPriorityParams(id, uid, value.PriorityHigh.Value()), &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", task_v1.Priority_PRIORITY_HIGH, dto.NewChangeTaskPriorityParams(id, uid, value.PriorityHigh.Value()), &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", task_v1.Priority_PRIORITY_HIGH, dto.NewChangeTaskPriorityParams(id, uid, value.PriorityHigh.Value()), &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			req := connect.NewRequest(&task_v1.ChangeTaskPriorityRequest{TaskId: id, Priority: v.priority})
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("ChangeTaskPriority", ctx, v.param).Return(nil)
+			} else {
+				uc.On("ChangeTaskPriority", ctx, v.param).Return(v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			_, err := hdr.ChangeTaskPriority(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_ChangeTaskDescription(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	arg := &task_v1.ChangeTaskDescriptionRequest{TaskId: id, Description: "**memo**"}
+	param := dto.NewChangeTaskDescriptionParams(arg.TaskId, uid, arg.Description)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: タスクが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("ChangeTaskDescription", ctx, param).Return(nil)
+			} else {
+				uc.On("ChangeTaskDescription", ctx, param).Return(v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			_, err := hdr.ChangeTaskDescription(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_GetTaskTree(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID(id), UserID: value.NewID(uid), Name: "parent", CreatedAt: now, UpdatedAt: now},
+		{ID: value.NewID("c1"), UserID: value.NewID(uid), Name: "child1", CreatedAt: now, UpdatedAt: now, ParentID: value.NewID(id)},
+		{ID: value.NewID("c2"), UserID: value.NewID(uid), Name: "child2", CreatedAt: now, UpdatedAt: now, ParentID: value.NewID(id)},
+		{ID: value.NewID("g1"), UserID: value.NewID(uid), Name: "grandchild", CreatedAt: now, UpdatedAt: now, ParentID: value.NewID("c1")},
+	}
+	arg := &task_v1.GetTaskTreeRequest{TaskId: id}
+	paramID := dto.NewIDParam(arg.TaskId)
+	paramUserID := dto.NewIDParam(uid)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: タスクが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("FindTaskTree", ctx, paramID, paramUserID).Return(tasks, nil)
+			} else {
+				uc.On("FindTaskTree", ctx, paramID, paramUserID).Return(nil, v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			ret, err := hdr.GetTaskTree(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				root := ret.Msg.Root
+				require.Equal(t, id, root.Task.Id)
+				require.Len(t, root.Children, 2)
+				require.Equal(t, "c1", root.Children[0].Task.Id)
+				require.Equal(t, id, root.Children[0].Task.ParentId)
+				require.Equal(t, "c2", root.Children[1].Task.Id)
+				require.Len(t, root.Children[0].Children, 1)
+				require.Equal(t, "g1", root.Children[0].Children[0].Task.Id)
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_CreateSubtask(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	arg := &task_v1.CreateSubtaskRequest{ParentTaskId: "pid", Name: "task"}
+	param := dto.NewCreateSubtaskParams(uid, arg.ParentTaskId, arg.Name)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 親タスクが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: 前提条件を満たさない場合", &domain.ErrPreconditionFailed{}, "failed_precondition"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("CreateSubtask", ctx, param).Return(id, nil)
+			} else {
+				uc.On("CreateSubtask", ctx, param).Return("", v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			ret, err := hdr.CreateSubtask(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, id, ret.Msg.CreatedId)
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_MoveSubtask(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &task_v1.MoveSubtaskRequest{TaskId: "id", ParentTaskId: "pid"}
+	param := dto.NewMoveSubtaskParams(arg.TaskId, uid, arg.ParentTaskId)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: タスクが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: 前提条件を満たさない場合", &domain.ErrPreconditionFailed{}, "failed_precondition"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("MoveSubtask", ctx, param).Return(nil)
+			} else {
+				uc.On("MoveSubtask", ctx, param).Return(v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			_, err := hdr.MoveSubtask(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_GetTaskList_FilterByTags(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	tagIDs := []string{"g1", "g2"}
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), Name: "task1", CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: 全てのタグを指定した場合", func(t *testing.T) {
+		req := connect.NewRequest(&task_v1.GetTaskListRequest{TagIds: tagIDs, TagMatch: task_v1.TagMatch_TAG_MATCH_ALL})
+		uc := new(mocks.ITaskUsecase)
+		uc.On("FindTasksByTags", ctx, dto.NewTagFilterParams(uid, "", tagIDs, true, 0)).Return(tasks, nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		cr.On("GetWorkspaceID", ctx).Return("")
+		hdr := NewTaskHandler(uc, cr)
+		ret, err := hdr.GetTaskList(ctx, req)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Len(t, ret.Msg.Tasks, len(tasks))
+		uc.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+	tt.Run("正常系: 条件が未指定で優先度順の場合", func(t *testing.T) {
+		req := connect.NewRequest(&task_v1.GetTaskListRequest{TagIds: tagIDs, Order: task_v1.TaskOrder_TASK_ORDER_PRIORITY})
+		uc := new(mocks.ITaskUsecase)
+		uc.On("FindTasksByTags", ctx, dto.NewTagFilterParams(uid, "", tagIDs, false, 1)).Return(tasks, nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		cr.On("GetWorkspaceID", ctx).Return("")
+		hdr := NewTaskHandler(uc, cr)
+		ret, err := hdr.GetTaskList(ctx, req)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Len(t, ret.Msg.Tasks, len(tasks))
+		uc.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+	tt.Run("正常系: リストとタグを指定した場合", func(t *testing.T) {
+		req := connect.NewRequest(&task_v1.GetTaskListRequest{TagIds: tagIDs, ListId: "lid"})
+		uc := new(mocks.ITaskUsecase)
+		uc.On("FindTasksByTags", ctx, dto.NewTagFilterParams(uid, "lid", tagIDs, false, 0)).Return(tasks, nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		cr.On("GetWorkspaceID", ctx).Return("")
+		hdr := NewTaskHandler(uc, cr)
+		ret, err := hdr.GetTaskList(ctx, req)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Len(t, ret.Msg.Tasks, len(tasks))
+		uc.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+}
+
+func TestTaskHandler_GetTaskList_FilterByList(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	lid := "lid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), ListID: value.NewID(lid), Name: "task1", CreatedAt: now, UpdatedAt: now},
+	}
+	req := connect.NewRequest(&task_v1.GetTaskListRequest{ListId: lid, Order: task_v1.TaskOrder_TASK_ORDER_PRIORITY})
+	param := dto.NewListFilterParams(lid, uid, 1)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: リストが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("FindTasksByListID", ctx, param).Return(tasks, nil)
+			} else {
+				uc.On("FindTasksByListID", ctx, param).Return(nil, v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			cr.On("GetWorkspaceID", ctx).Return("")
+			hdr := NewTaskHandler(uc, cr)
+			ret, err := hdr.GetTaskList(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Len(t, ret.Msg.Tasks, len(tasks))
+				require.Equal(t, lid, ret.Msg.Tasks[0].ListId)
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_MoveTaskToList(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &task_v1.MoveTaskToListRequest{TaskId: "id", ListId: "lid"}
+	param := dto.NewMoveTaskToListParams(arg.TaskId, uid, arg.ListId)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: タスクが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: 前提条件を満たさない場合", &domain.ErrPreconditionFailed{}, "failed_precondition"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("MoveTaskToList", ctx, param).Return(nil)
+			} else {
+				uc.On("MoveTaskToList", ctx, param).Return(v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			_, err := hdr.MoveTaskToList(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_GetTaskList_OrderByPosition(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "9", Name: "task1", CreatedAt: now, UpdatedAt: now},
+		{ID: value.NewID("t2"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task2", CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: リストを指定しない場合", func(t *testing.T) {
+		req := connect.NewRequest(&task_v1.GetTaskListRequest{Order: task_v1.TaskOrder_TASK_ORDER_POSITION})
+		uc := new(mocks.ITaskUsecase)
+		uc.On("FindTasksByUserIDOrderByPosition", ctx, dto.NewIDParam(uid)).Return(tasks, nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		cr.On("GetWorkspaceID", ctx).Return("")
+		hdr := NewTaskHandler(uc, cr)
+		ret, err := hdr.GetTaskList(ctx, req)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Len(t, ret.Msg.Tasks, len(tasks))
+		require.Equal(t, "9", ret.Msg.Tasks[0].Position)
+		uc.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+	tt.Run("正常系: リストを指定した場合", func(t *testing.T) {
+		req := connect.NewRequest(&task_v1.GetTaskListRequest{ListId: "lid", Order: task_v1.TaskOrder_TASK_ORDER_POSITION})
+		uc := new(mocks.ITaskUsecase)
+		uc.On("FindTasksByListID", ctx, dto.NewListFilterParams("lid", uid, 2)).Return(tasks, nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		cr.On("GetWorkspaceID", ctx).Return("")
+		hdr := NewTaskHandler(uc, cr)
+		ret, err := hdr.GetTaskList(ctx, req)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Len(t, ret.Msg.Tasks, len(tasks))
+		uc.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+}
+
+func TestTaskHandler_MoveTask(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &task_v1.MoveTaskRequest{TaskId: "id", TargetTaskId: "tid", Placement: task_v1.MovePlacement_MOVE_PLACEMENT_AFTER}
+	param := dto.NewMoveTaskParams(arg.TaskId, uid, arg.TargetTaskId, true)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: タスクが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("MoveTask", ctx, param).Return(nil)
+			} else {
+				uc.On("MoveTask", ctx, param).Return(v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			_, err := hdr.MoveTask(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+	tt.Run("正常系: 位置が未指定の場合は前に移動すること", func(t *testing.T) {
+		uc := new(mocks.ITaskUsecase)
+		uc.On("MoveTask", ctx, dto.NewMoveTaskParams("id", uid, "tid", false)).Return(nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		hdr := NewTaskHandler(uc, cr)
+		_, err := hdr.MoveTask(ctx, connect.NewRequest(&task_v1.MoveTaskRequest{TaskId: "id", TargetTaskId: "tid"}))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		uc.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+}
+
+func TestTaskHandler_SetTaskRecurrence(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &task_v1.SetTaskRecurrenceRequest{TaskId: "id", Rule: "FREQ=DAILY", Timezone: "Asia/Tokyo"}
+	param := dto.NewSetTaskRecurrenceParams(arg.TaskId, uid, arg.Rule, arg.Timezone)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: タスクが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: 前提条件を満たさない場合", &domain.ErrPreconditionFailed{}, "failed_precondition"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("SetTaskRecurrence", ctx, param).Return(nil)
+			} else {
+				uc.On("SetTaskRecurrence", ctx, param).Return(v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			_, err := hdr.SetTaskRecurrence(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_ClearTaskRecurrence(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &task_v1.ClearTaskRecurrenceRequest{TaskId: "id"}
+	paramID := dto.NewIDParam(arg.TaskId)
+	paramUserID := dto.NewIDParam(uid)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: タスクが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("ClearTaskRecurrence", ctx, paramID, paramUserID).Return(nil)
+			} else {
+				uc.On("ClearTaskRecurrence", ctx, paramID, paramUserID).Return(v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			_, err := hdr.ClearTaskRecurrence(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_AddTaskDependency(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &task_v1.AddTaskDependencyRequest{TaskId: "id", BlockerTaskId: "bid"}
+	param := dto.NewTaskDependencyParams(arg.TaskId, uid, arg.BlockerTaskId)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: タスクが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("AddTaskDependency", ctx, param).Return(nil)
+			} else {
+				uc.On("AddTaskDependency", ctx, param).Return(v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			_, err := hdr.AddTaskDependency(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_RemoveTaskDependency(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &task_v1.RemoveTaskDependencyRequest{TaskId: "id", BlockerTaskId: "bid"}
+	param := dto.NewTaskDependencyParams(arg.TaskId, uid, arg.BlockerTaskId)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: タスクが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("RemoveTaskDependency", ctx, param).Return(nil)
+			} else {
+				uc.On("RemoveTaskDependency", ctx, param).Return(v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			_, err := hdr.RemoveTaskDependency(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_ListTrash(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), Name: "task1", CreatedAt: now, UpdatedAt: now, DeletedAt: &now},
+	}
+	arg := &task_v1.ListTrashRequest{}
+	param := dto.NewIDParam(uid)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("FindTrashedTasksByUserID", ctx, param).Return(tasks, nil)
+			} else {
+				uc.On("FindTrashedTasksByUserID", ctx, param).Return(nil, v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			ret, err := hdr.ListTrash(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Len(t, ret.Msg.Tasks, len(tasks))
+				for i, v := range ret.Msg.Tasks {
+					require.Equal(t, tasks[i].ID.Value(), v.Id)
+					require.Equal(t, *tasks[i].DeletedAt, v.DeletedAt.AsTime())
+				}
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_RestoreTask(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	arg := &task_v1.RestoreTaskRequest{TaskId: id}
+	paramID := dto.NewIDParam(arg.TaskId)
+	paramUserID := dto.NewIDParam(uid)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: ゴミ箱にタスクが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: 親タスクがゴミ箱にある場合", &domain.ErrPreconditionFailed{}, "failed_precondition"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			uc.On("RestoreTask", ctx, paramID, paramUserID).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			_, err := hdr.RestoreTask(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_GetTaskHistory(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	uid := "uid"
+	histories := []*entity.TaskHistory{
+		{ID: value.NewID("h2"), TaskID: value.NewID(id), ActorID: value.NewID(uid), Action: value.TaskHistoryActionRenamed, OldValue: "old", NewValue: "new", CreatedAt: now},
+		{ID: value.NewID("h1"), TaskID: value.NewID(id), Action: value.TaskHistoryActionCreated, NewValue: "old", CreatedAt: now.Add(-time.Second)},
+	}
+	arg := &task_v1.GetTaskHistoryRequest{TaskId: id, PageSize: 2, PageToken: "token"}
+	param := dto.NewTaskHistoryParams(arg.TaskId, uid, arg.PageSize, arg.PageToken)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: タスクが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("FindTaskHistory", ctx, param).Return(histories, "next", nil)
+			} else {
+				uc.On("FindTaskHistory", ctx, param).Return(nil, "", v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			ret, err := hdr.GetTaskHistory(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, "next", ret.Msg.NextPageToken, "トークンが一致すること")
+				require.Len(t, ret.Msg.Histories, len(histories))
+				require.Equal(t, task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_RENAMED, ret.Msg.Histories[0].Action)
+				require.Equal(t, uid, ret.Msg.Histories[0].ActorId)
+				require.Equal(t, "old", ret.Msg.Histories[0].OldValue)
+				require.Equal(t, "new", ret.Msg.Histories[0].NewValue)
+				require.Equal(t, task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_CREATED, ret.Msg.Histories[1].Action)
+				require.Empty(t, ret.Msg.Histories[1].ActorId, "削除されたユーザーのIDが空であること")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_EmptyTrash(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &task_v1.EmptyTrashRequest{}
+	paramUserID := dto.NewIDParam(uid)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			uc.On("EmptyTrash", ctx, paramUserID).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			_, err := hdr.EmptyTrash(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_GetTaskList_AssignedToMe(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID("owner"), ListID: value.NewID("lid"), Name: "task1", CreatedAt: now, UpdatedAt: now, AssigneeID: value.NewID(uid)},
+	}
+	// 担当するタスクの一覧ではタグとリストの条件は無視される
+	arg := &task_v1.GetTaskListRequest{AssignedToMe: true, Order: task_v1.TaskOrder_TASK_ORDER_PRIORITY, TagIds: []string{"tag"}, ListId: "lid"}
+	param := dto.NewAssignedFilterParams(uid, value.TaskOrderPriority.Value())
+	req := connect.NewRequest(arg)
+
+	tt.Run("正常系: 自分が担当するタスクを取得する場合", func(t *testing.T) {
+		uc := new(mocks.ITaskUsecase)
+		uc.On("FindTasksByAssigneeID", ctx, param).Return(tasks, nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		cr.On("GetWorkspaceID", ctx).Return("")
+		hdr := NewTaskHandler(uc, cr)
+		ret, err := hdr.GetTaskList(ctx, req)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Len(t, ret.Msg.Tasks, 1)
+		require.Equal(t, "owner", ret.Msg.Tasks[0].UserId)
+		require.Equal(t, uid, ret.Msg.Tasks[0].AssigneeId)
+		uc.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+}
+
+func TestTaskHandler_AssignTask(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &task_v1.AssignTaskRequest{TaskId: "id", AssigneeId: "aid"}
+	param := dto.NewAssignTaskParams(arg.TaskId, uid, arg.AssigneeId)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: 担当者がタスクを閲覧できない場合", &domain.ErrPreconditionFailed{}, "failed_precondition"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			uc.On("AssignTask", ctx, param).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			_, err := hdr.AssignTask(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_UnassignTask(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &task_v1.UnassignTaskRequest{TaskId: "id"}
+	paramID := dto.NewIDParam(arg.TaskId)
+	paramUserID := dto.NewIDParam(uid)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			uc.On("UnassignTask", ctx, paramID, paramUserID).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			_, err := hdr.UnassignTask(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_BatchMutateTasks(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &task_v1.BatchMutateTasksRequest{
+		Operations: []*task_v1.TaskOperation{
+			{Kind: task_v1.TaskOperationKind_TASK_OPERATION_KIND_COMPLETE, TaskId: "t1"},
+			{Kind: task_v1.TaskOperationKind_TASK_OPERATION_KIND_DELETE, TaskId: "t2"},
+		},
+	}
+	ops := []*dto.TaskOperationParams{
+		dto.NewTaskOperationParams(value.TaskOperationKindComplete.Value(), "t1", "", ""),
+		dto.NewTaskOperationParams(value.TaskOperationKindDelete.Value(), "t2", "", ""),
+	}
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			results := []*entity.TaskOperationResult{
+				{Status: value.TaskOperationStatusAborted, TaskID: "t1"},
+				{Status: value.TaskOperationStatusFailed, TaskID: "t2", Err: &domain.ErrNotFound{Msg: "task not found"}},
+			}
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("BatchMutateTasks", ctx, dto.NewBatchMutateTasksParams(uid, ops, true)).Return(results, nil)
+			} else {
+				uc.On("BatchMutateTasks", ctx, dto.NewBatchMutateTasksParams(uid, ops, true)).Return(nil, v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			ret, err := hdr.BatchMutateTasks(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.False(t, ret.Msg.Committed, "失敗した操作がある場合はコミットされないこと")
+				require.Len(t, ret.Msg.Results, len(results))
+				require.Equal(t, task_v1.TaskOperationStatus_TASK_OPERATION_STATUS_ABORTED, ret.Msg.Results[0].Status)
+				require.Empty(t, ret.Msg.Results[0].ErrorCode)
+				require.Equal(t, task_v1.TaskOperationStatus_TASK_OPERATION_STATUS_FAILED, ret.Msg.Results[1].Status)
+				require.Equal(t, "not_found", ret.Msg.Results[1].ErrorCode, "操作ごとのエラーコードが一致すること")
+				require.Equal(t, "task not found", ret.Msg.Results[1].ErrorMessage)
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+	tt.Run("正常系: ベストエフォートの場合は失敗した操作があってもコミットされること", func(t *testing.T) {
+		results := []*entity.TaskOperationResult{
+			{Status: value.TaskOperationStatusSucceeded, TaskID: "t1"},
+			{Status: value.TaskOperationStatusFailed, TaskID: "t2", Err: &domain.ErrPreconditionFailed{Msg: "task has open subtasks"}},
+		}
+		bestEffort := &task_v1.BatchMutateTasksRequest{Operations: arg.Operations, Mode: task_v1.BatchMode_BATCH_MODE_BEST_EFFORT}
+		uc := new(mocks.ITaskUsecase)
+		uc.On("BatchMutateTasks", ctx, dto.NewBatchMutateTasksParams(uid, ops, false)).Return(results, nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		hdr := NewTaskHandler(uc, cr)
+		ret, err := hdr.BatchMutateTasks(ctx, connect.NewRequest(bestEffort))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.True(t, ret.Msg.Committed, "コミットされること")
+		require.Equal(t, task_v1.TaskOperationStatus_TASK_OPERATION_STATUS_SUCCEEDED, ret.Msg.Results[0].Status)
+		require.Equal(t, "failed_precondition", ret.Msg.Results[1].ErrorCode)
+		uc.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+}
+
+func TestTaskHandler_Undo(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), TaskID: value.NewID("id"), Action: value.TaskHistoryActionStatusChanged}
+	req := connect.NewRequest(&task_v1.UndoRequest{})
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: 取り消す操作がない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: 操作後にタスクが変更されている場合", &domain.ErrPreconditionFailed{}, "failed_precondition"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("Undo", ctx, dto.NewIDParam(uid)).Return(entry, nil)
+			} else {
+				uc.On("Undo", ctx, dto.NewIDParam(uid)).Return(nil, v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			ret, err := hdr.Undo(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, "id", ret.Msg.TaskId)
+				require.Equal(t, task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_STATUS_CHANGED, ret.Msg.Action)
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_Redo(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackRedo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionDeleted}
+	req := connect.NewRequest(&task_v1.RedoRequest{})
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: やり直す操作がない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: 取り消した後にタスクが変更されている場合", &domain.ErrPreconditionFailed{}, "failed_precondition"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITaskUsecase)
+			if v.err == nil {
+				uc.On("Redo", ctx, dto.NewIDParam(uid)).Return(entry, nil)
+			} else {
+				uc.On("Redo", ctx, dto.NewIDParam(uid)).Return(nil, v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTaskHandler(uc, cr)
+			ret, err := hdr.Redo(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, "id", ret.Msg.TaskId)
+				require.Equal(t, task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_DELETED, ret.Msg.Action)
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
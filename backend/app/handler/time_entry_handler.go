@@ -0,0 +1,335 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/app/usecase"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	timeentry_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/timeentry/v1"
+	"github.com/7oh2020/connect-tasklist/backend/util/contextkey"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TimeEntryServiceHandlerの実装
+type TimeEntryHandler struct {
+	usecase.ITimeEntryUsecase
+	contextkey.IContextReader
+}
+
+func NewTimeEntryHandler(uc usecase.ITimeEntryUsecase, cr contextkey.IContextReader) *TimeEntryHandler {
+	return &TimeEntryHandler{uc, cr}
+}
+
+func (h *TimeEntryHandler) GetRunningTimer(ctx context.Context, arg *connect.Request[timeentry_v1.GetRunningTimerRequest]) (*connect.Response[timeentry_v1.GetRunningTimerResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, err := h.ITimeEntryUsecase.FindRunningTimer(ctx, dto.NewIDParam(uid))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	ret := &timeentry_v1.GetRunningTimerResponse{}
+	if res != nil {
+		ret.TimeEntry = toTimeEntryMessage(res)
+	}
+	return connect.NewResponse(ret), nil
+}
+
+func (h *TimeEntryHandler) StartTimer(ctx context.Context, arg *connect.Request[timeentry_v1.StartTimerRequest]) (*connect.Response[timeentry_v1.StartTimerResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	createdID, err := h.ITimeEntryUsecase.StartTimer(ctx, dto.NewStartTimerParams(arg.Msg.TaskId, uid, arg.Msg.Note))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrAlreadyExists:
+			return nil, connect.NewError(connect.CodeAlreadyExists, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&timeentry_v1.StartTimerResponse{
+		CreatedId: createdID,
+	}), nil
+}
+
+func (h *TimeEntryHandler) StopTimer(ctx context.Context, arg *connect.Request[timeentry_v1.StopTimerRequest]) (*connect.Response[timeentry_v1.StopTimerResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, err := h.ITimeEntryUsecase.StopTimer(ctx, dto.NewIDParam(uid))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&timeentry_v1.StopTimerResponse{
+		TimeEntry: toTimeEntryMessage(res),
+	}), nil
+}
+
+func (h *TimeEntryHandler) GetTimeEntryList(ctx context.Context, arg *connect.Request[timeentry_v1.GetTimeEntryListRequest]) (*connect.Response[timeentry_v1.GetTimeEntryListResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, err := h.ITimeEntryUsecase.FindTimeEntries(ctx, toTimeEntryRangeParams(uid, arg.Msg.Range))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	entries := make([]*timeentry_v1.TimeEntry, len(res))
+	for i, v := range res {
+		entries[i] = toTimeEntryMessage(v)
+	}
+	return connect.NewResponse(&timeentry_v1.GetTimeEntryListResponse{
+		TimeEntries: entries,
+	}), nil
+}
+
+func (h *TimeEntryHandler) GetTimeSummary(ctx context.Context, arg *connect.Request[timeentry_v1.GetTimeSummaryRequest]) (*connect.Response[timeentry_v1.GetTimeSummaryResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, err := h.ITimeEntryUsecase.SummarizeTimeEntries(ctx, toTimeEntryRangeParams(uid, arg.Msg.Range))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(toTimeSummaryMessage(res)), nil
+}
+
+func (h *TimeEntryHandler) ExportTimeEntries(ctx context.Context, arg *connect.Request[timeentry_v1.ExportTimeEntriesRequest]) (*connect.Response[timeentry_v1.ExportTimeEntriesResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, err := h.ITimeEntryUsecase.ExportTimeEntriesCSV(ctx, toTimeEntryRangeParams(uid, arg.Msg.Range))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&timeentry_v1.ExportTimeEntriesResponse{
+		FileName:    "time_entries.csv",
+		ContentType: "text/csv; charset=utf-8",
+		Content:     res,
+	}), nil
+}
+
+func (h *TimeEntryHandler) CreateTimeEntry(ctx context.Context, arg *connect.Request[timeentry_v1.CreateTimeEntryRequest]) (*connect.Response[timeentry_v1.CreateTimeEntryResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	createdID, err := h.ITimeEntryUsecase.CreateTimeEntry(ctx, dto.NewCreateTimeEntryParams(arg.Msg.TaskId, uid, toTime(arg.Msg.StartedAt), toTime(arg.Msg.EndedAt), arg.Msg.Note))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&timeentry_v1.CreateTimeEntryResponse{
+		CreatedId: createdID,
+	}), nil
+}
+
+func (h *TimeEntryHandler) UpdateTimeEntry(ctx context.Context, arg *connect.Request[timeentry_v1.UpdateTimeEntryRequest]) (*connect.Response[timeentry_v1.UpdateTimeEntryResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	var endedAt *time.Time
+	if arg.Msg.EndedAt != nil {
+		t := arg.Msg.EndedAt.AsTime()
+		endedAt = &t
+	}
+	if err := h.ITimeEntryUsecase.UpdateTimeEntry(ctx, dto.NewUpdateTimeEntryParams(arg.Msg.TimeEntryId, uid, toTime(arg.Msg.StartedAt), endedAt, arg.Msg.Note)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&timeentry_v1.UpdateTimeEntryResponse{}), nil
+}
+
+func (h *TimeEntryHandler) DeleteTimeEntry(ctx context.Context, arg *connect.Request[timeentry_v1.DeleteTimeEntryRequest]) (*connect.Response[timeentry_v1.DeleteTimeEntryResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.ITimeEntryUsecase.DeleteTimeEntry(ctx, dto.NewIDParam(arg.Msg.TimeEntryId), dto.NewIDParam(uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&timeentry_v1.DeleteTimeEntryResponse{}), nil
+}
+
+// リクエストの期間をパラメータに変換する。期間がない場合は検証でエラーになる
+func toTimeEntryRangeParams(uid string, r *timeentry_v1.TimeRange) *dto.TimeEntryRangeParams {
+	if r == nil {
+		return dto.NewTimeEntryRangeParams(uid, time.Time{}, time.Time{}, "")
+	}
+	return dto.NewTimeEntryRangeParams(uid, toTime(r.From), toTime(r.To), r.Timezone)
+}
+
+// TimeEntryEntityをレスポンス用のメッセージに変換する。計測中のタイマーの作業時間は0にする
+func toTimeEntryMessage(v *entity.TimeEntry) *timeentry_v1.TimeEntry {
+	msg := &timeentry_v1.TimeEntry{
+		Id:        v.ID.Value(),
+		TaskId:    v.TaskID.Value(),
+		TaskName:  v.TaskName,
+		StartedAt: timestamppb.New(v.StartedAt),
+		Note:      v.Note,
+		CreatedAt: timestamppb.New(v.CreatedAt),
+		UpdatedAt: timestamppb.New(v.UpdatedAt),
+	}
+	if v.EndedAt != nil {
+		msg.EndedAt = timestamppb.New(*v.EndedAt)
+		msg.DurationSeconds = int64(v.Duration(*v.EndedAt).Seconds())
+	}
+	return msg
+}
+
+// TimeSummaryをレスポンス用のメッセージに変換する
+func toTimeSummaryMessage(v *entity.TimeSummary) *timeentry_v1.GetTimeSummaryResponse {
+	tasks := make([]*timeentry_v1.TaskTimeTotal, len(v.ByTask))
+	for i, t := range v.ByTask {
+		tasks[i] = &timeentry_v1.TaskTimeTotal{
+			TaskId:          t.TaskID.Value(),
+			TaskName:        t.TaskName,
+			DurationSeconds: int64(t.Duration.Seconds()),
+		}
+	}
+	days := make([]*timeentry_v1.DayTimeTotal, len(v.ByDay))
+	for i, d := range v.ByDay {
+		days[i] = &timeentry_v1.DayTimeTotal{
+			Date:            d.Date,
+			DurationSeconds: int64(d.Duration.Seconds()),
+		}
+	}
+	return &timeentry_v1.GetTimeSummaryResponse{
+		TotalSeconds: int64(v.Total.Seconds()),
+		Tasks:        tasks,
+		Days:         days,
+	}
+}
@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	timeentry_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/timeentry/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/timeentry/v1/timeentry_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestTimeEntryHandler_NewTimeEntryHandler(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ timeentry_v1connect.TimeEntryServiceHandler = (*TimeEntryHandler)(nil)
+	})
+}
+
+func TestTimeEntryHandler_GetRunningTimer(tt *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	uid := "uid"
+	running := &entity.TimeEntry{ID: value.NewID("id"), UserID: value.NewID(uid), TaskID: value.NewID("tid"), StartedAt: start, CreatedAt: start, UpdatedAt: start}
+	req := connect.NewRequest(&timeentry_v1.GetRunningTimerRequest{})
+
+	tt.Run("正常系: 計測中の場合", func(t *testing.T) {
+		uc := new(mocks.ITimeEntryUsecase)
+		uc.On("FindRunningTimer", ctx, dto.NewIDParam(uid)).Return(running, nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		hdr := NewTimeEntryHandler(uc, cr)
+		ret, err := hdr.GetRunningTimer(ctx, req)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, "id", ret.Msg.TimeEntry.Id)
+		require.Equal(t, start, ret.Msg.TimeEntry.StartedAt.AsTime())
+		require.Nil(t, ret.Msg.TimeEntry.EndedAt, "終了日時が省略されること")
+		require.Zero(t, ret.Msg.TimeEntry.DurationSeconds, "作業時間が0であること")
+		uc.AssertExpectations(t)
+	})
+	tt.Run("正常系: 計測していない場合", func(t *testing.T) {
+		uc := new(mocks.ITimeEntryUsecase)
+		uc.On("FindRunningTimer", ctx, dto.NewIDParam(uid)).Return(nil, nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		hdr := NewTimeEntryHandler(uc, cr)
+		ret, err := hdr.GetRunningTimer(ctx, req)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Nil(t, ret.Msg.TimeEntry, "作業時間が省略されること")
+		uc.AssertExpectations(t)
+	})
+}
+
+func TestTimeEntryHandler_StartTimer(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	tid := "tid"
+	uid := "uid"
+	req := connect.NewRequest(&timeentry_v1.StartTimerRequest{TaskId: tid, Note: "note"})
+	param := dto.NewStartTimerParams(tid, uid, "note")
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: 計測中のタイマーがある場合", &domain.ErrAlreadyExists{}, "already_exists"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITimeEntryUsecase)
+			if v.err == nil {
+				uc.On("StartTimer", ctx, param).Return(id, nil)
+			} else {
+				uc.On("StartTimer", ctx, param).Return("", v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTimeEntryHandler(uc, cr)
+			ret, err := hdr.StartTimer(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, id, ret.Msg.CreatedId)
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTimeEntryHandler_StopTimer(tt *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Minute)
+	uid := "uid"
+	stopped := &entity.TimeEntry{ID: value.NewID("id"), UserID: value.NewID(uid), TaskID: value.NewID("tid"), StartedAt: start, EndedAt: &end, CreatedAt: start, UpdatedAt: end}
+	req := connect.NewRequest(&timeentry_v1.StopTimerRequest{})
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: 計測していない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITimeEntryUsecase)
+			if v.err == nil {
+				uc.On("StopTimer", ctx, dto.NewIDParam(uid)).Return(stopped, nil)
+			} else {
+				uc.On("StopTimer", ctx, dto.NewIDParam(uid)).Return(nil, v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewTimeEntryHandler(uc, cr)
+			ret, err := hdr.StopTimer(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, end, ret.Msg.TimeEntry.EndedAt.AsTime())
+				require.Equal(t, int64(5400), ret.Msg.TimeEntry.DurationSeconds)
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTimeEntryHandler_GetTimeSummary(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	summary := &entity.TimeSummary{
+		Total:  3 * time.Hour,
+		ByTask: []*entity.TaskTimeTotal{{TaskID: value.NewID("t1"), TaskName: "task1", Duration: 3 * time.Hour}},
+		ByDay:  []*entity.DayTimeTotal{{Date: "2024-01-01", Duration: time.Hour}, {Date: "2024-01-02", Duration: 2 * time.Hour}},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		req := connect.NewRequest(&timeentry_v1.GetTimeSummaryRequest{Range: &timeentry_v1.TimeRange{From: timestamppb.New(from), To: timestamppb.New(to), Timezone: "Asia/Tokyo"}})
+		uc := new(mocks.ITimeEntryUsecase)
+		uc.On("SummarizeTimeEntries", ctx, dto.NewTimeEntryRangeParams(uid, from, to, "Asia/Tokyo")).Return(summary, nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		hdr := NewTimeEntryHandler(uc, cr)
+		ret, err := hdr.GetTimeSummary(ctx, req)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, int64(10800), ret.Msg.TotalSeconds)
+		require.Len(t, ret.Msg.Tasks, 1)
+		require.Equal(t, "task1", ret.Msg.Tasks[0].TaskName)
+		require.Len(t, ret.Msg.Days, 2)
+		require.Equal(t, "2024-01-02", ret.Msg.Days[1].Date)
+		require.Equal(t, int64(7200), ret.Msg.Days[1].DurationSeconds)
+		uc.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 期間を指定しない場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "from is empty"}
+		req := connect.NewRequest(&timeentry_v1.GetTimeSummaryRequest{})
+		uc := new(mocks.ITimeEntryUsecase)
+		uc.On("SummarizeTimeEntries", ctx, dto.NewTimeEntryRangeParams(uid, time.Time{}, time.Time{}, "")).Return(nil, errExp)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		hdr := NewTimeEntryHandler(uc, cr)
+		_, err := hdr.GetTimeSummary(ctx, req)
+
+		require.EqualError(t, err, fmt.Sprintf("invalid_argument: %s", errExp.Error()), "エラーが一致すること")
+		uc.AssertExpectations(t)
+	})
+}
+
+func TestTimeEntryHandler_ExportTimeEntries(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	content := []byte("id,task_id,task_name,date,started_at,ended_at,duration_seconds,note\n")
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		req := connect.NewRequest(&timeentry_v1.ExportTimeEntriesRequest{Range: &timeentry_v1.TimeRange{From: timestamppb.New(from), To: timestamppb.New(to)}})
+		uc := new(mocks.ITimeEntryUsecase)
+		uc.On("ExportTimeEntriesCSV", ctx, dto.NewTimeEntryRangeParams(uid, from, to, "")).Return(content, nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		hdr := NewTimeEntryHandler(uc, cr)
+		ret, err := hdr.ExportTimeEntries(ctx, req)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, "time_entries.csv", ret.Msg.FileName)
+		require.Equal(t, "text/csv; charset=utf-8", ret.Msg.ContentType)
+		require.Equal(t, content, ret.Msg.Content)
+		uc.AssertExpectations(t)
+	})
+}
+
+func TestTimeEntryHandler_UpdateTimeEntry(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	start := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	tt.Run("正常系: 終了日時を指定する場合", func(t *testing.T) {
+		req := connect.NewRequest(&timeentry_v1.UpdateTimeEntryRequest{TimeEntryId: id, StartedAt: timestamppb.New(start), EndedAt: timestamppb.New(end), Note: "note"})
+		uc := new(mocks.ITimeEntryUsecase)
+		uc.On("UpdateTimeEntry", ctx, dto.NewUpdateTimeEntryParams(id, uid, start, &end, "note")).Return(nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		hdr := NewTimeEntryHandler(uc, cr)
+		_, err := hdr.UpdateTimeEntry(ctx, req)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		uc.AssertExpectations(t)
+	})
+	tt.Run("正常系: 終了日時を省略する場合", func(t *testing.T) {
+		req := connect.NewRequest(&timeentry_v1.UpdateTimeEntryRequest{TimeEntryId: id, StartedAt: timestamppb.New(start)})
+		uc := new(mocks.ITimeEntryUsecase)
+		uc.On("UpdateTimeEntry", ctx, dto.NewUpdateTimeEntryParams(id, uid, start, nil, "")).Return(nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		hdr := NewTimeEntryHandler(uc, cr)
+		_, err := hdr.UpdateTimeEntry(ctx, req)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		uc.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/app/usecase"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	transfer_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/transfer/v1"
+	"github.com/7oh2020/connect-tasklist/backend/util/contextkey"
+)
+
+// TransferServiceHandlerの実装
+type TransferHandler struct {
+	usecase.ITransferUsecase
+	contextkey.IContextReader
+}
+
+func NewTransferHandler(uc usecase.ITransferUsecase, cr contextkey.IContextReader) *TransferHandler {
+	return &TransferHandler{uc, cr}
+}
+
+func (h *TransferHandler) ExportTasks(ctx context.Context, arg *connect.Request[transfer_v1.ExportTasksRequest], stream *connect.ServerStream[transfer_v1.ExportTasksResponse]) error {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	// ファイルの内容を添付ファイルのダウンロードと同じ大きさに分割して送る
+	w := bufio.NewWriterSize(&exportChunkWriter{stream: stream}, downloadChunkSize)
+	if err := h.ITransferUsecase.ExportTasks(ctx, dto.NewExportTasksParams(uid, arg.Msg.ListId, int32(arg.Msg.Format)-1), w); err != nil {
+		return connect.NewError(toErrorCode(err), err)
+	}
+	return w.Flush()
+}
+
+func (h *TransferHandler) ImportTasks(ctx context.Context, stream *connect.ClientStream[transfer_v1.ImportTasksRequest]) (*connect.Response[transfer_v1.ImportTasksResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	// 最初のメッセージはメタデータでなければならない
+	if !stream.Receive() {
+		if err := stream.Err(); err != nil {
+			return nil, err
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("metadata is required"))
+	}
+	meta := stream.Msg().GetMetadata()
+	if meta == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("metadata is required"))
+	}
+
+	res, err := h.ITransferUsecase.ImportTasks(ctx, dto.NewImportTasksParams(uid, meta.ListId, int32(meta.Format)-1, meta.DryRun), &importChunkReader{stream: stream})
+	if err != nil {
+		// アップロードの受信が中断された場合は中断の理由をエラーコードにする
+		switch {
+		case errors.Is(err, context.Canceled):
+			return nil, connect.NewError(connect.CodeCanceled, err)
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, connect.NewError(connect.CodeDeadlineExceeded, err)
+		}
+		return nil, connect.NewError(toErrorCode(err), err)
+	}
+	msg := &transfer_v1.ImportTasksResponse{
+		Results: make([]*transfer_v1.TaskImportResult, len(res)),
+		DryRun:  meta.DryRun,
+	}
+	for i, v := range res {
+		msg.Results[i] = toTaskImportResultMessage(v)
+		switch v.Status {
+		case value.TaskImportStatusCreated:
+			msg.CreatedCount++
+		case value.TaskImportStatusDuplicate:
+			msg.DuplicateCount++
+		default:
+			msg.FailedCount++
+		}
+	}
+	return connect.NewResponse(msg), nil
+}
+
+// TaskImportResultEntityをレスポンス用のメッセージに変換する
+func toTaskImportResultMessage(v *entity.TaskImportResult) *transfer_v1.TaskImportResult {
+	msg := &transfer_v1.TaskImportResult{
+		Line:   v.Line,
+		Name:   v.Name,
+		Status: transfer_v1.TaskImportStatus(v.Status.Value() + 1),
+		TaskId: v.TaskID,
+	}
+	if v.Err != nil {
+		msg.ErrorCode = toErrorCode(v.Err).String()
+		msg.ErrorMessage = v.Err.Error()
+	}
+	return msg
+}
+
+// 書き込まれた内容をdownloadChunkSizeごとに分割して送るWriter
+type exportChunkWriter struct {
+	stream *connect.ServerStream[transfer_v1.ExportTasksResponse]
+}
+
+func (w *exportChunkWriter) Write(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		end := min(n+downloadChunkSize, len(p))
+		if err := w.stream.Send(&transfer_v1.ExportTasksResponse{Chunk: p[n:end]}); err != nil {
+			return n, err
+		}
+		n = end
+	}
+	return n, nil
+}
+
+// アップロードされたチャンクを順に読み込むReader
+type importChunkReader struct {
+	stream *connect.ClientStream[transfer_v1.ImportTasksRequest]
+	buf    []byte
+}
+
+func (r *importChunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if !r.stream.Receive() {
+			if err := r.stream.Err(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		chunk, ok := r.stream.Msg().Data.(*transfer_v1.ImportTasksRequest_Chunk)
+		if !ok {
+			return 0, &app.ErrInputValidationFailed{Msg: "metadata must be sent only once"}
+		}
+		r.buf = chunk.Chunk
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	transfer_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/transfer/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/transfer/v1/transfer_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferHandler_NewTransferHandler(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ transfer_v1connect.TransferServiceHandler = (*TransferHandler)(nil)
+	})
+}
+
+// ストリーミングのハンドラをテストするためのクライアントを作成する
+func newTransferTestClient(t *testing.T, hdr *TransferHandler) transfer_v1connect.TransferServiceClient {
+	mux := http.NewServeMux()
+	mux.Handle(transfer_v1connect.NewTransferServiceHandler(hdr))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return transfer_v1connect.NewTransferServiceClient(server.Client(), server.URL)
+}
+
+func TestTransferHandler_ExportTasks(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	lid := "lid"
+	content := strings.Repeat("a", downloadChunkSize+1)
+	param := dto.NewExportTasksParams(uid, lid, value.TaskFileFormatCSV.Value())
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.ITransferUsecase)
+			uc.On("ExportTasks", mock.Anything, param, mock.Anything).Return(v.err).Run(func(args mock.Arguments) {
+				if v.err == nil {
+					_, _ = io.WriteString(args.Get(2).(io.Writer), content)
+				}
+			})
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", mock.Anything).Return(uid, nil)
+			client := newTransferTestClient(t, NewTransferHandler(uc, cr))
+
+			stream, err := client.ExportTasks(ctx, connect.NewRequest(&transfer_v1.ExportTasksRequest{Format: transfer_v1.TaskFileFormat_TASK_FILE_FORMAT_CSV, ListId: lid}))
+			require.NoError(t, err, "エラーが発生しないこと")
+			var received bytes.Buffer
+			chunks := 0
+			for stream.Receive() {
+				received.Write(stream.Msg().Chunk)
+				chunks++
+			}
+
+			if v.err == nil {
+				require.NoError(t, stream.Err(), "エラーが発生しないこと")
+				require.Equal(t, content, received.String(), "内容が一致すること")
+				require.Equal(t, 2, chunks, "分割して返されること")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, stream.Err(), errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransferHandler_ImportTasks(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	lid := "lid"
+	param := dto.NewImportTasksParams(uid, lid, value.TaskFileFormatMarkdown.Value(), true)
+	results := []*entity.TaskImportResult{
+		{Line: 1, Name: "a", Status: value.TaskImportStatusCreated, TaskID: "id"},
+		{Line: 2, Name: "a", Status: value.TaskImportStatusDuplicate},
+		{Line: 3, Name: "- b", Status: value.TaskImportStatusFailed, Err: &app.ErrInputValidationFailed{Msg: "not a checklist item"}},
+	}
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: 前提条件エラーの場合", &domain.ErrPreconditionFailed{}, "failed_precondition"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+		{"準正常系: アップロードが中断された場合", context.Canceled, "canceled"},
+		{"準正常系: アップロードがタイムアウトした場合", context.DeadlineExceeded, "deadline_exceeded"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			var received bytes.Buffer
+			uc := new(mocks.ITransferUsecase)
+			call := uc.On("ImportTasks", mock.Anything, param, mock.Anything)
+			if v.err == nil {
+				call.Return(results, nil)
+			} else {
+				call.Return(nil, v.err)
+			}
+			call.Run(func(args mock.Arguments) {
+				_, _ = io.Copy(&received, args.Get(2).(io.Reader))
+			})
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", mock.Anything).Return(uid, nil)
+			client := newTransferTestClient(t, NewTransferHandler(uc, cr))
+
+			stream := client.ImportTasks(ctx)
+			require.NoError(t, stream.Send(&transfer_v1.ImportTasksRequest{
+				Data: &transfer_v1.ImportTasksRequest_Metadata{Metadata: &transfer_v1.ImportTasksMetadata{Format: transfer_v1.TaskFileFormat_TASK_FILE_FORMAT_MARKDOWN, ListId: lid, DryRun: true}},
+			}))
+			for _, chunk := range []string{"- [ ] a\n", "- [ ] a\n- b\n"} {
+				require.NoError(t, stream.Send(&transfer_v1.ImportTasksRequest{
+					Data: &transfer_v1.ImportTasksRequest_Chunk{Chunk: []byte(chunk)},
+				}))
+			}
+			ret, err := stream.CloseAndReceive()
+
+			require.Equal(t, "- [ ] a\n- [ ] a\n- b\n", received.String(), "チャンクが順に読み込まれること")
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Len(t, ret.Msg.Results, len(results))
+				require.Equal(t, transfer_v1.TaskImportStatus_TASK_IMPORT_STATUS_CREATED, ret.Msg.Results[0].Status)
+				require.Equal(t, "id", ret.Msg.Results[0].TaskId)
+				require.Equal(t, transfer_v1.TaskImportStatus_TASK_IMPORT_STATUS_DUPLICATE, ret.Msg.Results[1].Status)
+				require.Equal(t, transfer_v1.TaskImportStatus_TASK_IMPORT_STATUS_FAILED, ret.Msg.Results[2].Status)
+				require.Equal(t, int32(3), ret.Msg.Results[2].Line)
+				require.Equal(t, "invalid_argument", ret.Msg.Results[2].ErrorCode, "行ごとのエラーコードが返されること")
+				require.Equal(t, "not a checklist item", ret.Msg.Results[2].ErrorMessage)
+				require.Equal(t, int32(1), ret.Msg.CreatedCount)
+				require.Equal(t, int32(1), ret.Msg.DuplicateCount)
+				require.Equal(t, int32(1), ret.Msg.FailedCount)
+				require.True(t, ret.Msg.DryRun, "試行であること")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+	tt.Run("準正常系: 最初のメッセージがメタデータでない場合", func(t *testing.T) {
+		uc := new(mocks.ITransferUsecase)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", mock.Anything).Return(uid, nil)
+		client := newTransferTestClient(t, NewTransferHandler(uc, cr))
+
+		stream := client.ImportTasks(ctx)
+		require.NoError(t, stream.Send(&transfer_v1.ImportTasksRequest{
+			Data: &transfer_v1.ImportTasksRequest_Chunk{Chunk: []byte("- [ ] a")},
+		}))
+		_, err := stream.CloseAndReceive()
+
+		require.EqualError(t, err, "invalid_argument: metadata is required", "エラーが一致すること")
+		uc.AssertExpectations(t)
+	})
+	tt.Run("準正常系: メタデータを2回送った場合", func(t *testing.T) {
+		uc := new(mocks.ITransferUsecase)
+		uc.On("ImportTasks", mock.Anything, param, mock.Anything).Return(nil, func(ctx context.Context, arg *dto.ImportTasksParams, r io.Reader) error {
+			_, err := io.ReadAll(r)
+			return err
+		})
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", mock.Anything).Return(uid, nil)
+		client := newTransferTestClient(t, NewTransferHandler(uc, cr))
+
+		stream := client.ImportTasks(ctx)
+		metadata := &transfer_v1.ImportTasksRequest{
+			Data: &transfer_v1.ImportTasksRequest_Metadata{Metadata: &transfer_v1.ImportTasksMetadata{Format: transfer_v1.TaskFileFormat_TASK_FILE_FORMAT_MARKDOWN, ListId: lid, DryRun: true}},
+		}
+		require.NoError(t, stream.Send(metadata))
+		require.NoError(t, stream.Send(&transfer_v1.ImportTasksRequest{
+			Data: &transfer_v1.ImportTasksRequest_Chunk{Chunk: []byte("- [ ] a\n")},
+		}))
+		require.NoError(t, stream.Send(metadata))
+		_, err := stream.CloseAndReceive()
+
+		require.EqualError(t, err, "invalid_argument: metadata must be sent only once", "エラーが一致すること")
+		uc.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/app/usecase"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	task_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1"
+	view_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/view/v1"
+	"github.com/7oh2020/connect-tasklist/backend/util/contextkey"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ViewServiceHandlerの実装
+type ViewHandler struct {
+	usecase.IViewUsecase
+	contextkey.IContextReader
+}
+
+func NewViewHandler(uc usecase.IViewUsecase, cr contextkey.IContextReader) *ViewHandler {
+	return &ViewHandler{uc, cr}
+}
+
+func (h *ViewHandler) GetViewList(ctx context.Context, arg *connect.Request[view_v1.GetViewListRequest]) (*connect.Response[view_v1.GetViewListResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, err := h.IViewUsecase.FindViewsByUserID(ctx, dto.NewIDParam(uid))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&view_v1.GetViewListResponse{
+		Views: toViewMessages(res),
+	}), nil
+}
+
+func (h *ViewHandler) CreateView(ctx context.Context, arg *connect.Request[view_v1.CreateViewRequest]) (*connect.Response[view_v1.CreateViewResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	createdID, err := h.IViewUsecase.CreateView(ctx, dto.NewCreateViewParams(uid, arg.Msg.Name, toViewQueryParams(arg.Msg.Query)))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrAlreadyExists:
+			return nil, connect.NewError(connect.CodeAlreadyExists, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&view_v1.CreateViewResponse{
+		CreatedId: createdID,
+	}), nil
+}
+
+func (h *ViewHandler) UpdateView(ctx context.Context, arg *connect.Request[view_v1.UpdateViewRequest]) (*connect.Response[view_v1.UpdateViewResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.IViewUsecase.UpdateView(ctx, dto.NewUpdateViewParams(arg.Msg.ViewId, uid, arg.Msg.Name, toViewQueryParams(arg.Msg.Query))); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrPreconditionFailed:
+			return nil, connect.NewError(connect.CodeFailedPrecondition, e)
+		case *domain.ErrAlreadyExists:
+			return nil, connect.NewError(connect.CodeAlreadyExists, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&view_v1.UpdateViewResponse{}), nil
+}
+
+func (h *ViewHandler) DeleteView(ctx context.Context, arg *connect.Request[view_v1.DeleteViewRequest]) (*connect.Response[view_v1.DeleteViewResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.IViewUsecase.DeleteView(ctx, dto.NewIDParam(arg.Msg.ViewId), dto.NewIDParam(uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrPreconditionFailed:
+			return nil, connect.NewError(connect.CodeFailedPrecondition, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&view_v1.DeleteViewResponse{}), nil
+}
+
+func (h *ViewHandler) ExecuteView(ctx context.Context, arg *connect.Request[view_v1.ExecuteViewRequest]) (*connect.Response[view_v1.ExecuteViewResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, next, err := h.IViewUsecase.ExecuteView(ctx, dto.NewExecuteViewParams(arg.Msg.ViewId, uid, arg.Msg.Timezone, arg.Msg.PageSize, arg.Msg.PageToken))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	groups := make([]*view_v1.TaskGroup, len(res))
+	for i, v := range res {
+		groups[i] = &view_v1.TaskGroup{Key: v.Key, Tasks: toTaskMessages(v.Tasks)}
+	}
+	return connect.NewResponse(&view_v1.ExecuteViewResponse{
+		Groups:        groups,
+		NextPageToken: next,
+	}), nil
+}
+
+// リクエストのビューの条件をパラメータに変換する。未指定の場合は絞り込まない条件にする
+func toViewQueryParams(q *view_v1.ViewQuery) *dto.ViewQueryParams {
+	if q == nil {
+		q = &view_v1.ViewQuery{}
+	}
+	field := toTaskSortField(q.SortField)
+	return dto.NewViewQueryParams(toTaskCompletion(q.Completion).Value(), toViewPeriod(q.Due).Value(), toViewPeriod(q.Updated).Value(),
+		q.NameContains, field.Value(), toDescending(field, q.SortDirection), toViewGroupBy(q.GroupBy).Value())
+}
+
+// リクエストの期間をViewPeriodに変換する。範囲外の値はバリデーションで拒否されるように変換する
+func toViewPeriod(p view_v1.ViewPeriod) value.ViewPeriod {
+	switch p {
+	case view_v1.ViewPeriod_VIEW_PERIOD_UNSPECIFIED:
+		return value.ViewPeriodAny
+	case view_v1.ViewPeriod_VIEW_PERIOD_TODAY:
+		return value.ViewPeriodToday
+	case view_v1.ViewPeriod_VIEW_PERIOD_THIS_WEEK:
+		return value.ViewPeriodThisWeek
+	case view_v1.ViewPeriod_VIEW_PERIOD_PAST:
+		return value.ViewPeriodPast
+	case view_v1.ViewPeriod_VIEW_PERIOD_UNSET:
+		return value.ViewPeriodUnset
+	default:
+		return value.ViewPeriod(-1)
+	}
+}
+
+// リクエストのまとめる項目をViewGroupByに変換する。範囲外の値はバリデーションで拒否されるように変換する
+func toViewGroupBy(g view_v1.ViewGroupBy) value.ViewGroupBy {
+	switch g {
+	case view_v1.ViewGroupBy_VIEW_GROUP_BY_UNSPECIFIED:
+		return value.ViewGroupByNone
+	case view_v1.ViewGroupBy_VIEW_GROUP_BY_STATUS:
+		return value.ViewGroupByStatus
+	case view_v1.ViewGroupBy_VIEW_GROUP_BY_PRIORITY:
+		return value.ViewGroupByPriority
+	case view_v1.ViewGroupBy_VIEW_GROUP_BY_LIST:
+		return value.ViewGroupByList
+	case view_v1.ViewGroupBy_VIEW_GROUP_BY_DUE_DATE:
+		return value.ViewGroupByDueDate
+	default:
+		return value.ViewGroupBy(-1)
+	}
+}
+
+// ViewEntityのスライスをレスポンス用のメッセージに変換する
+func toViewMessages(res []*entity.View) []*view_v1.View {
+	views := make([]*view_v1.View, len(res))
+	for i, v := range res {
+		views[i] = &view_v1.View{
+			Id:        v.ID.Value(),
+			Name:      v.Name,
+			Query:     toViewQueryMessage(v.Query),
+			IsBuiltin: v.IsBuiltin,
+		}
+		if !v.IsBuiltin {
+			views[i].CreatedAt = timestamppb.New(v.CreatedAt)
+			views[i].UpdatedAt = timestamppb.New(v.UpdatedAt)
+		}
+	}
+	return views
+}
+
+// ビューの条件をレスポンス用のメッセージに変換する
+// 完了状態、期間、まとめる項目は値が一致する。並び替えの項目は未指定の値の分だけずれる
+func toViewQueryMessage(q value.ViewQuery) *view_v1.ViewQuery {
+	direction := task_v1.SortDirection_SORT_DIRECTION_ASC
+	if q.Sort.Descending {
+		direction = task_v1.SortDirection_SORT_DIRECTION_DESC
+	}
+	return &view_v1.ViewQuery{
+		Completion:    task_v1.TaskCompletion(q.Completion),
+		Due:           view_v1.ViewPeriod(q.Due),
+		Updated:       view_v1.ViewPeriod(q.Updated),
+		NameContains:  q.NameContains,
+		SortField:     task_v1.TaskSortField(q.Sort.Field + 1),
+		SortDirection: direction,
+		GroupBy:       view_v1.ViewGroupBy(q.GroupBy),
+	}
+}
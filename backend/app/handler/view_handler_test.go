@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	task_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1"
+	view_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/view/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/view/v1/view_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewHandler_NewViewHandler(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ view_v1connect.ViewServiceHandler = (*ViewHandler)(nil)
+	})
+}
+
+func TestViewHandler_GetViewList(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	views := []*entity.View{
+		{ID: value.NewID("builtin-today"), UserID: value.NewID(uid), Name: "Today", IsBuiltin: true, Query: value.ViewQuery{Completion: value.TaskCompletionOpen, Due: value.ViewPeriodToday, Sort: value.TaskSort{Field: value.TaskSortFieldPriority, Descending: true}}},
+		{ID: value.NewID("v1"), UserID: value.NewID(uid), Name: "work", CreatedAt: now, UpdatedAt: now, Query: value.ViewQuery{Due: value.ViewPeriodUnset, Sort: value.TaskSort{Field: value.TaskSortFieldName}, GroupBy: value.ViewGroupByDueDate}},
+	}
+	param := dto.NewIDParam(uid)
+	req := connect.NewRequest(&view_v1.GetViewListRequest{})
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IViewUsecase)
+			if v.err == nil {
+				uc.On("FindViewsByUserID", ctx, param).Return(views, nil)
+			} else {
+				uc.On("FindViewsByUserID", ctx, param).Return(nil, v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewViewHandler(uc, cr)
+			ret, err := hdr.GetViewList(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Len(t, ret.Msg.Views, len(views))
+				builtin := ret.Msg.Views[0]
+				require.True(t, builtin.IsBuiltin, "組み込みのビューであること")
+				require.Nil(t, builtin.CreatedAt, "組み込みのビューには作成日時がないこと")
+				require.Equal(t, task_v1.TaskCompletion_TASK_COMPLETION_OPEN, builtin.Query.Completion)
+				require.Equal(t, view_v1.ViewPeriod_VIEW_PERIOD_TODAY, builtin.Query.Due)
+				require.Equal(t, task_v1.TaskSortField_TASK_SORT_FIELD_PRIORITY, builtin.Query.SortField)
+				require.Equal(t, task_v1.SortDirection_SORT_DIRECTION_DESC, builtin.Query.SortDirection)
+				saved := ret.Msg.Views[1]
+				require.Equal(t, "v1", saved.Id)
+				require.Equal(t, now, saved.CreatedAt.AsTime())
+				require.Equal(t, view_v1.ViewPeriod_VIEW_PERIOD_UNSET, saved.Query.Due)
+				require.Equal(t, task_v1.TaskSortField_TASK_SORT_FIELD_NAME, saved.Query.SortField)
+				require.Equal(t, task_v1.SortDirection_SORT_DIRECTION_ASC, saved.Query.SortDirection)
+				require.Equal(t, view_v1.ViewGroupBy_VIEW_GROUP_BY_DUE_DATE, saved.Query.GroupBy)
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestViewHandler_CreateView(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	testcases := []struct {
+		title   string
+		query   *view_v1.ViewQuery
+		param   *dto.CreateViewParams
+		err     error
+		codeStr string
+	}{
+		{
+			"正常系: 正しい入力の場合",
+			&view_v1.ViewQuery{Completion: task_v1.TaskCompletion_TASK_COMPLETION_CLOSED, Updated: view_v1.ViewPeriod_VIEW_PERIOD_THIS_WEEK, NameContains: "milk", SortField: task_v1.TaskSortField_TASK_SORT_FIELD_CREATED_AT, SortDirection: task_v1.SortDirection_SORT_DIRECTION_ASC, GroupBy: view_v1.ViewGroupBy_VIEW_GROUP_BY_STATUS},
+			dto.NewCreateViewParams(uid, "view", dto.NewViewQueryParams(value.TaskCompletionClosed.Value(), value.ViewPeriodAny.Value(), value.ViewPeriodThisWeek.Value(), "milk", value.TaskSortFieldCreatedAt.Value(), false, value.ViewGroupByStatus.Value())),
+			nil, "",
+		},
+		{
+			"正常系: 条件を指定しない場合は更新日時の降順になること",
+			nil,
+			dto.NewCreateViewParams(uid, "view", dto.NewViewQueryParams(value.TaskCompletionAll.Value(), value.ViewPeriodAny.Value(), value.ViewPeriodAny.Value(), "", value.TaskSortFieldUpdatedAt.Value(), true, value.ViewGroupByNone.Value())),
+			nil, "",
+		},
+		{
+			"準正常系: アプリ側バリデーションエラーの場合",
+			&view_v1.ViewQuery{Due: view_v1.ViewPeriod(9)},
+			dto.NewCreateViewParams(uid, "view", dto.NewViewQueryParams(0, -1, 0, "", 0, true, 0)),
+			&app.ErrInputValidationFailed{}, "invalid_argument",
+		},
+		{
+			"準正常系: 同じ名前のビューが存在する場合",
+			nil,
+			dto.NewCreateViewParams(uid, "view", dto.NewViewQueryParams(0, 0, 0, "", 0, true, 0)),
+			&domain.ErrAlreadyExists{}, "already_exists",
+		},
+		{
+			"準正常系: クエリエラーの場合",
+			nil,
+			dto.NewCreateViewParams(uid, "view", dto.NewViewQueryParams(0, 0, 0, "", 0, true, 0)),
+			&domain.ErrQueryFailed{}, "aborted",
+		},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IViewUsecase)
+			if v.err == nil {
+				uc.On("CreateView", ctx, v.param).Return(id, nil)
+			} else {
+				uc.On("CreateView", ctx, v.param).Return("", v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewViewHandler(uc, cr)
+			ret, err := hdr.CreateView(ctx, connect.NewRequest(&view_v1.CreateViewRequest{Name: "view", Query: v.query}))
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, id, ret.Msg.CreatedId)
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestViewHandler_UpdateView(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &view_v1.UpdateViewRequest{ViewId: "id", Name: "renamed", Query: &view_v1.ViewQuery{SortField: task_v1.TaskSortField_TASK_SORT_FIELD_NAME}}
+	param := dto.NewUpdateViewParams(arg.ViewId, uid, arg.Name, dto.NewViewQueryParams(0, 0, 0, "", value.TaskSortFieldName.Value(), false, 0))
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: ビューが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: 組み込みのビューの場合", &domain.ErrPreconditionFailed{}, "failed_precondition"},
+		{"準正常系: 同じ名前のビューが存在する場合", &domain.ErrAlreadyExists{}, "already_exists"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IViewUsecase)
+			uc.On("UpdateView", ctx, param).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewViewHandler(uc, cr)
+			_, err := hdr.UpdateView(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestViewHandler_DeleteView(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	arg := &view_v1.DeleteViewRequest{ViewId: "id"}
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ビューが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: 組み込みのビューの場合", &domain.ErrPreconditionFailed{}, "failed_precondition"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IViewUsecase)
+			uc.On("DeleteView", ctx, dto.NewIDParam(arg.ViewId), dto.NewIDParam(uid)).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewViewHandler(uc, cr)
+			_, err := hdr.DeleteView(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestViewHandler_ExecuteView(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	groups := []*entity.TaskGroup{
+		{Key: "todo", Tasks: []*entity.Task{{ID: value.NewID("t1"), UserID: value.NewID(uid), Name: "task1", CreatedAt: now, UpdatedAt: now}}},
+		{Key: "done", Tasks: []*entity.Task{{ID: value.NewID("t2"), UserID: value.NewID(uid), Name: "task2", Status: value.TaskStatusDone, CreatedAt: now, UpdatedAt: now}}},
+	}
+	arg := &view_v1.ExecuteViewRequest{ViewId: "builtin-today", Timezone: "Asia/Tokyo", PageSize: 10, PageToken: "token"}
+	param := dto.NewExecuteViewParams(arg.ViewId, uid, arg.Timezone, arg.PageSize, arg.PageToken)
+	req := connect.NewRequest(arg)
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: ビューが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: アクセス権がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IViewUsecase)
+			if v.err == nil {
+				uc.On("ExecuteView", ctx, param).Return(groups, "next", nil)
+			} else {
+				uc.On("ExecuteView", ctx, param).Return(nil, "", v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewViewHandler(uc, cr)
+			ret, err := hdr.ExecuteView(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Len(t, ret.Msg.Groups, len(groups))
+				for i, g := range ret.Msg.Groups {
+					require.Equal(t, groups[i].Key, g.Key)
+					require.Equal(t, groups[i].Tasks[0].ID.Value(), g.Tasks[0].Id)
+				}
+				require.Equal(t, "next", ret.Msg.NextPageToken, "次のページのトークンが返されること")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
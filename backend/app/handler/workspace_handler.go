@@ -0,0 +1,379 @@
+package handler
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/app/usecase"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	workspace_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/workspace/v1"
+	"github.com/7oh2020/connect-tasklist/backend/util/contextkey"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// WorkspaceServiceHandlerの実装
+type WorkspaceHandler struct {
+	usecase.IWorkspaceUsecase
+	contextkey.IContextReader
+}
+
+func NewWorkspaceHandler(uc usecase.IWorkspaceUsecase, cr contextkey.IContextReader) *WorkspaceHandler {
+	return &WorkspaceHandler{uc, cr}
+}
+
+func (h *WorkspaceHandler) GetWorkspaceList(ctx context.Context, arg *connect.Request[workspace_v1.GetWorkspaceListRequest]) (*connect.Response[workspace_v1.GetWorkspaceListResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, err := h.IWorkspaceUsecase.FindWorkspacesByUserID(ctx, dto.NewIDParam(uid))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&workspace_v1.GetWorkspaceListResponse{
+		Workspaces: toWorkspaceMessages(res),
+	}), nil
+}
+
+func (h *WorkspaceHandler) CreateWorkspace(ctx context.Context, arg *connect.Request[workspace_v1.CreateWorkspaceRequest]) (*connect.Response[workspace_v1.CreateWorkspaceResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	createdID, err := h.IWorkspaceUsecase.CreateWorkspace(ctx, dto.NewCreateWorkspaceParams(uid, arg.Msg.Name))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&workspace_v1.CreateWorkspaceResponse{
+		CreatedId: createdID,
+	}), nil
+}
+
+func (h *WorkspaceHandler) DeleteWorkspace(ctx context.Context, arg *connect.Request[workspace_v1.DeleteWorkspaceRequest]) (*connect.Response[workspace_v1.DeleteWorkspaceResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.IWorkspaceUsecase.DeleteWorkspace(ctx, dto.NewIDParam(arg.Msg.WorkspaceId), dto.NewIDParam(uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&workspace_v1.DeleteWorkspaceResponse{}), nil
+}
+
+func (h *WorkspaceHandler) GetMemberList(ctx context.Context, arg *connect.Request[workspace_v1.GetMemberListRequest]) (*connect.Response[workspace_v1.GetMemberListResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	res, err := h.IWorkspaceUsecase.FindWorkspaceMembers(ctx, dto.NewIDParam(arg.Msg.WorkspaceId), dto.NewIDParam(uid))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&workspace_v1.GetMemberListResponse{
+		Members: toMemberMessages(res),
+	}), nil
+}
+
+func (h *WorkspaceHandler) ChangeMemberRole(ctx context.Context, arg *connect.Request[workspace_v1.ChangeMemberRoleRequest]) (*connect.Response[workspace_v1.ChangeMemberRoleResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.IWorkspaceUsecase.ChangeMemberRole(ctx, dto.NewChangeMemberRoleParams(arg.Msg.WorkspaceId, uid, arg.Msg.UserId, toWorkspaceRole(arg.Msg.Role).Value())); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&workspace_v1.ChangeMemberRoleResponse{}), nil
+}
+
+func (h *WorkspaceHandler) RemoveMember(ctx context.Context, arg *connect.Request[workspace_v1.RemoveMemberRequest]) (*connect.Response[workspace_v1.RemoveMemberResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.IWorkspaceUsecase.RemoveMember(ctx, dto.NewWorkspaceMemberParams(arg.Msg.WorkspaceId, uid, arg.Msg.UserId)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrPreconditionFailed:
+			return nil, connect.NewError(connect.CodeFailedPrecondition, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&workspace_v1.RemoveMemberResponse{}), nil
+}
+
+func (h *WorkspaceHandler) GetInvitationList(ctx context.Context, arg *connect.Request[workspace_v1.GetInvitationListRequest]) (*connect.Response[workspace_v1.GetInvitationListResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	// ワークスペースを省略した場合は自分宛ての招待を取得する
+	var res []*entity.WorkspaceInvitation
+	if arg.Msg.WorkspaceId != "" {
+		res, err = h.IWorkspaceUsecase.FindWorkspaceInvitations(ctx, dto.NewIDParam(arg.Msg.WorkspaceId), dto.NewIDParam(uid))
+	} else {
+		res, err = h.IWorkspaceUsecase.FindInvitationsByUserID(ctx, dto.NewIDParam(uid))
+	}
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&workspace_v1.GetInvitationListResponse{
+		Invitations: toInvitationMessages(res),
+	}), nil
+}
+
+func (h *WorkspaceHandler) InviteMember(ctx context.Context, arg *connect.Request[workspace_v1.InviteMemberRequest]) (*connect.Response[workspace_v1.InviteMemberResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	createdID, err := h.IWorkspaceUsecase.InviteMember(ctx, dto.NewInviteMemberParams(arg.Msg.WorkspaceId, uid, arg.Msg.Email, toWorkspaceRole(arg.Msg.Role).Value()))
+	if err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrAlreadyExists:
+			return nil, connect.NewError(connect.CodeAlreadyExists, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&workspace_v1.InviteMemberResponse{
+		CreatedId: createdID,
+	}), nil
+}
+
+func (h *WorkspaceHandler) AcceptInvitation(ctx context.Context, arg *connect.Request[workspace_v1.AcceptInvitationRequest]) (*connect.Response[workspace_v1.AcceptInvitationResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.IWorkspaceUsecase.AcceptInvitation(ctx, dto.NewIDParam(arg.Msg.InvitationId), dto.NewIDParam(uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrAlreadyExists:
+			return nil, connect.NewError(connect.CodeAlreadyExists, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&workspace_v1.AcceptInvitationResponse{}), nil
+}
+
+func (h *WorkspaceHandler) DeleteInvitation(ctx context.Context, arg *connect.Request[workspace_v1.DeleteInvitationRequest]) (*connect.Response[workspace_v1.DeleteInvitationResponse], error) {
+	// コンテキストから値を取得する
+	var uid string
+	var err error
+	if uid, err = h.IContextReader.GetUserID(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if err := h.IWorkspaceUsecase.DeleteInvitation(ctx, dto.NewIDParam(arg.Msg.InvitationId), dto.NewIDParam(uid)); err != nil {
+		switch e := err.(type) {
+		case *app.ErrInputValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrValidationFailed:
+			return nil, connect.NewError(connect.CodeInvalidArgument, e)
+		case *domain.ErrNotFound:
+			return nil, connect.NewError(connect.CodeNotFound, e)
+		case *domain.ErrPermissionDenied:
+			return nil, connect.NewError(connect.CodePermissionDenied, e)
+		case *domain.ErrQueryFailed:
+			return nil, connect.NewError(connect.CodeAborted, e)
+		default:
+			return nil, connect.NewError(connect.CodeUnknown, e)
+		}
+	}
+	return connect.NewResponse(&workspace_v1.DeleteInvitationResponse{}), nil
+}
+
+func toWorkspaceMessages(res []*entity.Workspace) []*workspace_v1.Workspace {
+	workspaces := make([]*workspace_v1.Workspace, len(res))
+	for i, v := range res {
+		workspaces[i] = &workspace_v1.Workspace{
+			Id:        v.ID.Value(),
+			Name:      v.Name,
+			Role:      toWorkspaceRoleMessage(v.Role),
+			CreatedAt: timestamppb.New(v.CreatedAt),
+			UpdatedAt: timestamppb.New(v.UpdatedAt),
+		}
+	}
+	return workspaces
+}
+
+func toMemberMessages(res []*entity.WorkspaceMember) []*workspace_v1.Member {
+	members := make([]*workspace_v1.Member, len(res))
+	for i, v := range res {
+		members[i] = &workspace_v1.Member{
+			UserId:    v.UserID.Value(),
+			Email:     v.Email,
+			Role:      toWorkspaceRoleMessage(v.Role),
+			CreatedAt: timestamppb.New(v.CreatedAt),
+			UpdatedAt: timestamppb.New(v.UpdatedAt),
+		}
+	}
+	return members
+}
+
+func toInvitationMessages(res []*entity.WorkspaceInvitation) []*workspace_v1.Invitation {
+	invitations := make([]*workspace_v1.Invitation, len(res))
+	for i, v := range res {
+		invitations[i] = &workspace_v1.Invitation{
+			Id:            v.ID.Value(),
+			WorkspaceId:   v.WorkspaceID.Value(),
+			WorkspaceName: v.WorkspaceName,
+			Email:         v.Email.Value(),
+			Role:          toWorkspaceRoleMessage(v.Role),
+			InvitedBy:     v.InvitedBy.Value(),
+			CreatedAt:     timestamppb.New(v.CreatedAt),
+		}
+	}
+	return invitations
+}
+
+// リクエストのワークスペースの権限をドメインの権限に変換する。未指定の場合はWorkspaceRoleNoneを返す
+func toWorkspaceRole(r workspace_v1.WorkspaceRole) value.WorkspaceRole {
+	switch r {
+	case workspace_v1.WorkspaceRole_WORKSPACE_ROLE_MEMBER:
+		return value.WorkspaceRoleMember
+	case workspace_v1.WorkspaceRole_WORKSPACE_ROLE_ADMIN:
+		return value.WorkspaceRoleAdmin
+	case workspace_v1.WorkspaceRole_WORKSPACE_ROLE_OWNER:
+		return value.WorkspaceRoleOwner
+	default:
+		return value.WorkspaceRoleNone
+	}
+}
+
+// ドメインのワークスペースの権限をレスポンス用の権限に変換する
+func toWorkspaceRoleMessage(r value.WorkspaceRole) workspace_v1.WorkspaceRole {
+	switch r {
+	case value.WorkspaceRoleMember:
+		return workspace_v1.WorkspaceRole_WORKSPACE_ROLE_MEMBER
+	case value.WorkspaceRoleAdmin:
+		return workspace_v1.WorkspaceRole_WORKSPACE_ROLE_ADMIN
+	case value.WorkspaceRoleOwner:
+		return workspace_v1.WorkspaceRole_WORKSPACE_ROLE_OWNER
+	default:
+		return workspace_v1.WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED
+	}
+}
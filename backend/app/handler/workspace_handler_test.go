@@ -0,0 +1,460 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	workspace_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/workspace/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/workspace/v1/workspace_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceHandler_NewWorkspaceHandler(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ workspace_v1connect.WorkspaceServiceHandler = (*WorkspaceHandler)(nil)
+	})
+}
+
+func TestWorkspaceHandler_GetWorkspaceList(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	workspaces := []*entity.Workspace{
+		{ID: value.NewID("w1"), Name: "team", Role: value.WorkspaceRoleOwner, CreatedAt: now, UpdatedAt: now},
+		{ID: value.NewID("w2"), Name: "club", Role: value.WorkspaceRoleMember, CreatedAt: now, UpdatedAt: now},
+	}
+	req := connect.NewRequest(&workspace_v1.GetWorkspaceListRequest{})
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IWorkspaceUsecase)
+			if v.err == nil {
+				uc.On("FindWorkspacesByUserID", ctx, dto.NewIDParam(uid)).Return(workspaces, nil)
+			} else {
+				uc.On("FindWorkspacesByUserID", ctx, dto.NewIDParam(uid)).Return(nil, v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewWorkspaceHandler(uc, cr)
+			ret, err := hdr.GetWorkspaceList(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Len(t, ret.Msg.Workspaces, len(workspaces))
+				for i, v := range ret.Msg.Workspaces {
+					require.Equal(t, workspaces[i].ID.Value(), v.Id)
+					require.Equal(t, workspaces[i].Name, v.Name)
+					require.Equal(t, toWorkspaceRoleMessage(workspaces[i].Role), v.Role)
+				}
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWorkspaceHandler_CreateWorkspace(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	req := connect.NewRequest(&workspace_v1.CreateWorkspaceRequest{Name: "team"})
+	param := dto.NewCreateWorkspaceParams(uid, "team")
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IWorkspaceUsecase)
+			if v.err == nil {
+				uc.On("CreateWorkspace", ctx, param).Return("wid", nil)
+			} else {
+				uc.On("CreateWorkspace", ctx, param).Return("", v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewWorkspaceHandler(uc, cr)
+			ret, err := hdr.CreateWorkspace(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, "wid", ret.Msg.CreatedId)
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWorkspaceHandler_DeleteWorkspace(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	req := connect.NewRequest(&workspace_v1.DeleteWorkspaceRequest{WorkspaceId: "wid"})
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: 所有者ではない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IWorkspaceUsecase)
+			uc.On("DeleteWorkspace", ctx, dto.NewIDParam("wid"), dto.NewIDParam(uid)).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewWorkspaceHandler(uc, cr)
+			_, err := hdr.DeleteWorkspace(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWorkspaceHandler_GetMemberList(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	members := []*entity.WorkspaceMember{
+		{WorkspaceID: value.NewID("wid"), UserID: value.NewID(uid), Email: "dev@example.com", Role: value.WorkspaceRoleOwner, CreatedAt: now, UpdatedAt: now},
+		{WorkspaceID: value.NewID("wid"), UserID: value.NewID("u2"), Email: "u2@example.com", Role: value.WorkspaceRoleMember, CreatedAt: now, UpdatedAt: now},
+	}
+	req := connect.NewRequest(&workspace_v1.GetMemberListRequest{WorkspaceId: "wid"})
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: メンバーではない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IWorkspaceUsecase)
+			if v.err == nil {
+				uc.On("FindWorkspaceMembers", ctx, dto.NewIDParam("wid"), dto.NewIDParam(uid)).Return(members, nil)
+			} else {
+				uc.On("FindWorkspaceMembers", ctx, dto.NewIDParam("wid"), dto.NewIDParam(uid)).Return(nil, v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewWorkspaceHandler(uc, cr)
+			ret, err := hdr.GetMemberList(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Len(t, ret.Msg.Members, len(members))
+				for i, v := range ret.Msg.Members {
+					require.Equal(t, members[i].UserID.Value(), v.UserId)
+					require.Equal(t, members[i].Email, v.Email)
+					require.Equal(t, toWorkspaceRoleMessage(members[i].Role), v.Role)
+				}
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWorkspaceHandler_ChangeMemberRole(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	req := connect.NewRequest(&workspace_v1.ChangeMemberRoleRequest{WorkspaceId: "wid", UserId: "u2", Role: workspace_v1.WorkspaceRole_WORKSPACE_ROLE_ADMIN})
+	param := dto.NewChangeMemberRoleParams("wid", uid, "u2", value.WorkspaceRoleAdmin.Value())
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: メンバーが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: 所有者ではない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IWorkspaceUsecase)
+			uc.On("ChangeMemberRole", ctx, param).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewWorkspaceHandler(uc, cr)
+			_, err := hdr.ChangeMemberRole(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWorkspaceHandler_RemoveMember(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	req := connect.NewRequest(&workspace_v1.RemoveMemberRequest{WorkspaceId: "wid", UserId: "u2"})
+	param := dto.NewWorkspaceMemberParams("wid", uid, "u2")
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: メンバーが存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: 権限がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: 所有者を削除する場合", &domain.ErrPreconditionFailed{}, "failed_precondition"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IWorkspaceUsecase)
+			uc.On("RemoveMember", ctx, param).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewWorkspaceHandler(uc, cr)
+			_, err := hdr.RemoveMember(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWorkspaceHandler_GetInvitationList(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	invitations := []*entity.WorkspaceInvitation{
+		{ID: value.NewID("iid"), WorkspaceID: value.NewID("wid"), WorkspaceName: "team", Email: value.NewEmail("dev@example.com"), Role: value.WorkspaceRoleMember, InvitedBy: value.NewID("owner"), CreatedAt: now},
+	}
+
+	tt.Run("正常系: ワークスペースを指定した場合", func(t *testing.T) {
+		uc := new(mocks.IWorkspaceUsecase)
+		uc.On("FindWorkspaceInvitations", ctx, dto.NewIDParam("wid"), dto.NewIDParam(uid)).Return(invitations, nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		hdr := NewWorkspaceHandler(uc, cr)
+		ret, err := hdr.GetInvitationList(ctx, connect.NewRequest(&workspace_v1.GetInvitationListRequest{WorkspaceId: "wid"}))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Len(t, ret.Msg.Invitations, 1)
+		require.Equal(t, "dev@example.com", ret.Msg.Invitations[0].Email)
+		require.Equal(t, workspace_v1.WorkspaceRole_WORKSPACE_ROLE_MEMBER, ret.Msg.Invitations[0].Role)
+		uc.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+	tt.Run("正常系: ワークスペースを省略した場合は自分宛ての招待を取得すること", func(t *testing.T) {
+		uc := new(mocks.IWorkspaceUsecase)
+		uc.On("FindInvitationsByUserID", ctx, dto.NewIDParam(uid)).Return(invitations, nil)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		hdr := NewWorkspaceHandler(uc, cr)
+		ret, err := hdr.GetInvitationList(ctx, connect.NewRequest(&workspace_v1.GetInvitationListRequest{}))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, "team", ret.Msg.Invitations[0].WorkspaceName)
+		uc.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 管理者ではない場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		uc := new(mocks.IWorkspaceUsecase)
+		uc.On("FindWorkspaceInvitations", ctx, dto.NewIDParam("wid"), dto.NewIDParam(uid)).Return(nil, errExp)
+		cr := new(mocks.IContextReader)
+		cr.On("GetUserID", ctx).Return(uid, nil)
+		hdr := NewWorkspaceHandler(uc, cr)
+		_, err := hdr.GetInvitationList(ctx, connect.NewRequest(&workspace_v1.GetInvitationListRequest{WorkspaceId: "wid"}))
+
+		require.EqualError(t, err, fmt.Sprintf("permission_denied: %s", errExp.Error()), "エラーが一致すること")
+		uc.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceHandler_InviteMember(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	req := connect.NewRequest(&workspace_v1.InviteMemberRequest{WorkspaceId: "wid", Email: "new@example.com", Role: workspace_v1.WorkspaceRole_WORKSPACE_ROLE_MEMBER})
+	param := dto.NewInviteMemberParams("wid", uid, "new@example.com", value.WorkspaceRoleMember.Value())
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: ドメイン側バリデーションエラーの場合", &domain.ErrValidationFailed{}, "invalid_argument"},
+		{"準正常系: 権限がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: 既にメンバーの場合", &domain.ErrAlreadyExists{}, "already_exists"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IWorkspaceUsecase)
+			if v.err == nil {
+				uc.On("InviteMember", ctx, param).Return("iid", nil)
+			} else {
+				uc.On("InviteMember", ctx, param).Return("", v.err)
+			}
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewWorkspaceHandler(uc, cr)
+			ret, err := hdr.InviteMember(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, "iid", ret.Msg.CreatedId)
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWorkspaceHandler_AcceptInvitation(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	req := connect.NewRequest(&workspace_v1.AcceptInvitationRequest{InvitationId: "iid"})
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: 招待が存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: 既にメンバーの場合", &domain.ErrAlreadyExists{}, "already_exists"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IWorkspaceUsecase)
+			uc.On("AcceptInvitation", ctx, dto.NewIDParam("iid"), dto.NewIDParam(uid)).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewWorkspaceHandler(uc, cr)
+			_, err := hdr.AcceptInvitation(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWorkspaceHandler_DeleteInvitation(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	req := connect.NewRequest(&workspace_v1.DeleteInvitationRequest{InvitationId: "iid"})
+
+	testcases := []struct {
+		title   string
+		err     error
+		codeStr string
+	}{
+		{"正常系: 正しい入力の場合", nil, ""},
+		{"準正常系: アプリ側バリデーションエラーの場合", &app.ErrInputValidationFailed{}, "invalid_argument"},
+		{"準正常系: 招待が存在しない場合", &domain.ErrNotFound{}, "not_found"},
+		{"準正常系: 権限がない場合", &domain.ErrPermissionDenied{}, "permission_denied"},
+		{"準正常系: クエリエラーの場合", &domain.ErrQueryFailed{}, "aborted"},
+		{"準正常系: その他のエラーの場合", &app.ErrInternal{}, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			uc := new(mocks.IWorkspaceUsecase)
+			uc.On("DeleteInvitation", ctx, dto.NewIDParam("iid"), dto.NewIDParam(uid)).Return(v.err)
+			cr := new(mocks.IContextReader)
+			cr.On("GetUserID", ctx).Return(uid, nil)
+			hdr := NewWorkspaceHandler(uc, cr)
+			_, err := hdr.DeleteInvitation(ctx, req)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				errMsg := fmt.Sprintf("%s: %s", v.codeStr, v.err.Error())
+				require.EqualError(t, err, errMsg, "エラーが一致すること")
+			}
+			uc.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
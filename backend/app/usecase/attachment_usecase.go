@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"html"
+	"io"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/service"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+)
+
+// 添付ファイルの操作
+type IAttachmentUsecase interface {
+	FindAttachmentsByTaskID(ctx context.Context, taskID *dto.IDParam, userID *dto.IDParam) ([]*entity.Attachment, error)
+	UploadAttachment(ctx context.Context, arg *dto.UploadAttachmentParams, r io.Reader) (string, error)
+	OpenAttachment(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) (*entity.Attachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error
+	PurgeDeletedBlobs(ctx context.Context) (int64, error)
+}
+
+type AttachmentUsecase struct {
+	service.IAttachmentService
+}
+
+func NewAttachmentUsecase(srv service.IAttachmentService) *AttachmentUsecase {
+	return &AttachmentUsecase{srv}
+}
+
+func (u *AttachmentUsecase) FindAttachmentsByTaskID(ctx context.Context, taskID *dto.IDParam, userID *dto.IDParam) ([]*entity.Attachment, error) {
+	if err := taskID.Validate(); err != nil {
+		return nil, err
+	}
+	if err := userID.Validate(); err != nil {
+		return nil, err
+	}
+	return u.IAttachmentService.FindAttachmentsByTaskID(ctx, taskID.Value(), userID.Value())
+}
+
+func (u *AttachmentUsecase) UploadAttachment(ctx context.Context, arg *dto.UploadAttachmentParams, r io.Reader) (string, error) {
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	return u.IAttachmentService.UploadAttachment(ctx, arg.TaskID(), arg.UserID(), html.EscapeString(arg.FileName()), r)
+}
+
+func (u *AttachmentUsecase) OpenAttachment(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) (*entity.Attachment, io.ReadCloser, error) {
+	if err := id.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := userID.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return u.IAttachmentService.OpenAttachment(ctx, id.Value(), userID.Value())
+}
+
+func (u *AttachmentUsecase) DeleteAttachment(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	if err := userID.Validate(); err != nil {
+		return err
+	}
+	return u.IAttachmentService.DeleteAttachment(ctx, id.Value(), userID.Value())
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentUsecase_NewAttachmentUsecase(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ IAttachmentUsecase = (*AttachmentUsecase)(nil)
+	})
+}
+
+func TestAttachmentUsecase_FindAttachmentsByTaskID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	tid := "tid"
+	uid := "uid"
+	attachments := []*entity.Attachment{
+		{ID: value.NewID("a1"), TaskID: value.NewID(tid), UserID: value.NewID(uid), FileName: "shot.png", ContentType: "image/png", Size: 10, StorageKey: "a1", CreatedAt: now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IAttachmentService)
+		srv.On("FindAttachmentsByTaskID", ctx, tid, uid).Return(attachments, nil)
+		uc := NewAttachmentUsecase(srv)
+		ret, err := uc.FindAttachmentsByTaskID(ctx, dto.NewIDParam(tid), dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, attachments, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.IAttachmentService)
+		uc := NewAttachmentUsecase(srv)
+		_, err := uc.FindAttachmentsByTaskID(ctx, dto.NewIDParam(strings.Repeat("*", 51)), dto.NewIDParam(uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestAttachmentUsecase_UploadAttachment(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	tid := "tid"
+	uid := "uid"
+	r := strings.NewReader("hello")
+
+	tt.Run("正常系: ファイル名がエスケープされること", func(t *testing.T) {
+		srv := new(mocks.IAttachmentService)
+		srv.On("UploadAttachment", ctx, tid, uid, "&lt;b&gt;.txt", r).Return(id, nil)
+		uc := NewAttachmentUsecase(srv)
+		ret, err := uc.UploadAttachment(ctx, dto.NewUploadAttachmentParams(tid, uid, "<b>.txt"), r)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, id, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "file name must be 255 characters or less"}
+		srv := new(mocks.IAttachmentService)
+		uc := NewAttachmentUsecase(srv)
+		_, err := uc.UploadAttachment(ctx, dto.NewUploadAttachmentParams(tid, uid, strings.Repeat("*", 256)), r)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestAttachmentUsecase_OpenAttachment(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	uid := "uid"
+	attachment := &entity.Attachment{ID: value.NewID(id), TaskID: value.NewID("tid"), UserID: value.NewID(uid), FileName: "memo.txt", ContentType: "text/plain; charset=utf-8", Size: 5, StorageKey: id, CreatedAt: now}
+	rc := io.NopCloser(strings.NewReader("hello"))
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IAttachmentService)
+		srv.On("OpenAttachment", ctx, id, uid).Return(attachment, rc, nil)
+		uc := NewAttachmentUsecase(srv)
+		ret, retRC, err := uc.OpenAttachment(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, attachment, ret)
+		require.Equal(t, rc, retRC)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.IAttachmentService)
+		uc := NewAttachmentUsecase(srv)
+		_, _, err := uc.OpenAttachment(ctx, dto.NewIDParam(strings.Repeat("*", 51)), dto.NewIDParam(uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestAttachmentUsecase_DeleteAttachment(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IAttachmentService)
+		srv.On("DeleteAttachment", ctx, id, uid).Return(nil)
+		uc := NewAttachmentUsecase(srv)
+		err := uc.DeleteAttachment(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.IAttachmentService)
+		uc := NewAttachmentUsecase(srv)
+		err := uc.DeleteAttachment(ctx, dto.NewIDParam(id), dto.NewIDParam(strings.Repeat("*", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
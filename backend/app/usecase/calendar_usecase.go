@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"io"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain/service"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+)
+
+// カレンダーのフィードの操作
+type ICalendarUsecase interface {
+	IssueFeedToken(ctx context.Context, userID *dto.IDParam) (string, error)
+	RevokeFeedToken(ctx context.Context, userID *dto.IDParam) error
+	RenderFeed(ctx context.Context, token string, w io.Writer) error
+}
+
+type CalendarUsecase struct {
+	service.ICalendarService
+}
+
+func NewCalendarUsecase(srv service.ICalendarService) *CalendarUsecase {
+	return &CalendarUsecase{srv}
+}
+
+func (u *CalendarUsecase) IssueFeedToken(ctx context.Context, userID *dto.IDParam) (string, error) {
+	if err := userID.Validate(); err != nil {
+		return "", err
+	}
+	return u.ICalendarService.IssueFeedToken(ctx, userID.Value())
+}
+
+func (u *CalendarUsecase) RevokeFeedToken(ctx context.Context, userID *dto.IDParam) error {
+	if err := userID.Validate(); err != nil {
+		return err
+	}
+	return u.ICalendarService.RevokeFeedToken(ctx, userID.Value())
+}
+
+// トークンのフィードのタスクをiCalendarの形式でwに書き込む
+func (u *CalendarUsecase) RenderFeed(ctx context.Context, token string, w io.Writer) error {
+	tasks, err := u.ICalendarService.FindFeedTasks(ctx, token)
+	if err != nil {
+		return err
+	}
+	if err := encodeTaskCalendar(w, tasks); err != nil {
+		return &app.ErrInternal{Msg: "failed to write calendar"}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarUsecase_NewCalendarUsecase(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ ICalendarUsecase = (*CalendarUsecase)(nil)
+	})
+}
+
+func TestCalendarUsecase_IssueFeedToken(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ICalendarService)
+		srv.On("IssueFeedToken", ctx, uid).Return("token", nil)
+		uc := NewCalendarUsecase(srv)
+		ret, err := uc.IssueFeedToken(ctx, dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, "token", ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ICalendarService)
+		uc := NewCalendarUsecase(srv)
+		_, err := uc.IssueFeedToken(ctx, dto.NewIDParam(strings.Repeat("a", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestCalendarUsecase_RevokeFeedToken(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ICalendarService)
+		srv.On("RevokeFeedToken", ctx, uid).Return(nil)
+		uc := NewCalendarUsecase(srv)
+		err := uc.RevokeFeedToken(ctx, dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 発行していない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "feed not found"}
+		srv := new(mocks.ICalendarService)
+		srv.On("RevokeFeedToken", ctx, uid).Return(errExp)
+		uc := NewCalendarUsecase(srv)
+		err := uc.RevokeFeedToken(ctx, dto.NewIDParam(uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestCalendarUsecase_RenderFeed(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	tasks := []*entity.Task{
+		{ID: value.NewID("tid"), Name: "write", Status: value.TaskStatusTodo, CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: フィードのタスクを出力すること", func(t *testing.T) {
+		srv := new(mocks.ICalendarService)
+		srv.On("FindFeedTasks", ctx, "token").Return(tasks, nil)
+		uc := NewCalendarUsecase(srv)
+		var buf bytes.Buffer
+		err := uc.RenderFeed(ctx, "token", &buf)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Contains(t, buf.String(), "UID:tid@connect-tasklist\r\nDTSTAMP:20240101T090000Z\r\n")
+		require.Contains(t, buf.String(), "SUMMARY:write\r\n")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: トークンが無効な場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "feed not found"}
+		srv := new(mocks.ICalendarService)
+		srv.On("FindFeedTasks", ctx, "token").Return(nil, errExp)
+		uc := NewCalendarUsecase(srv)
+		var buf bytes.Buffer
+		err := uc.RenderFeed(ctx, "token", &buf)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		require.Empty(t, buf.String(), "何も出力しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 書き込みに失敗した場合", func(t *testing.T) {
+		errExp := &app.ErrInternal{Msg: "failed to write calendar"}
+		srv := new(mocks.ICalendarService)
+		srv.On("FindFeedTasks", ctx, "token").Return(tasks, nil)
+		uc := NewCalendarUsecase(srv)
+		err := uc.RenderFeed(ctx, "token", &failingWriter{})
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"html"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/service"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+)
+
+// コメントの操作
+type ICommentUsecase interface {
+	FindCommentsByTaskID(ctx context.Context, taskID *dto.IDParam, userID *dto.IDParam) ([]*entity.Comment, error)
+	AddComment(ctx context.Context, arg *dto.AddCommentParams) (string, error)
+	EditComment(ctx context.Context, arg *dto.EditCommentParams) error
+	DeleteComment(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error
+}
+
+type CommentUsecase struct {
+	service.ICommentService
+}
+
+func NewCommentUsecase(srv service.ICommentService) *CommentUsecase {
+	return &CommentUsecase{srv}
+}
+
+func (u *CommentUsecase) FindCommentsByTaskID(ctx context.Context, taskID *dto.IDParam, userID *dto.IDParam) ([]*entity.Comment, error) {
+	if err := taskID.Validate(); err != nil {
+		return nil, err
+	}
+	if err := userID.Validate(); err != nil {
+		return nil, err
+	}
+	return u.ICommentService.FindCommentsByTaskID(ctx, taskID.Value(), userID.Value())
+}
+
+func (u *CommentUsecase) AddComment(ctx context.Context, arg *dto.AddCommentParams) (string, error) {
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	return u.ICommentService.AddComment(ctx, arg.TaskID(), arg.UserID(), html.EscapeString(arg.Body()))
+}
+
+func (u *CommentUsecase) EditComment(ctx context.Context, arg *dto.EditCommentParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	return u.ICommentService.EditComment(ctx, arg.ID(), arg.UserID(), html.EscapeString(arg.Body()))
+}
+
+func (u *CommentUsecase) DeleteComment(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	if err := userID.Validate(); err != nil {
+		return err
+	}
+	return u.ICommentService.DeleteComment(ctx, id.Value(), userID.Value())
+}
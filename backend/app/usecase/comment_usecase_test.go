@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentUsecase_NewCommentUsecase(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ ICommentUsecase = (*CommentUsecase)(nil)
+	})
+}
+
+func TestCommentUsecase_FindCommentsByTaskID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	tid := "tid"
+	uid := "uid"
+	comments := []*entity.Comment{
+		{ID: value.NewID("c1"), TaskID: value.NewID(tid), UserID: value.NewID(uid), Body: "comment", CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ICommentService)
+		srv.On("FindCommentsByTaskID", ctx, tid, uid).Return(comments, nil)
+		uc := NewCommentUsecase(srv)
+		ret, err := uc.FindCommentsByTaskID(ctx, dto.NewIDParam(tid), dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, comments, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ICommentService)
+		uc := NewCommentUsecase(srv)
+		_, err := uc.FindCommentsByTaskID(ctx, dto.NewIDParam(strings.Repeat("*", 51)), dto.NewIDParam(uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestCommentUsecase_AddComment(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	tid := "tid"
+	uid := "uid"
+
+	tt.Run("正常系: 本文がエスケープされること", func(t *testing.T) {
+		srv := new(mocks.ICommentService)
+		srv.On("AddComment", ctx, tid, uid, "&lt;b&gt;done&lt;/b&gt;").Return(id, nil)
+		uc := NewCommentUsecase(srv)
+		ret, err := uc.AddComment(ctx, dto.NewAddCommentParams(tid, uid, "<b>done</b>"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, id, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "body must be 5000 characters or less"}
+		srv := new(mocks.ICommentService)
+		uc := NewCommentUsecase(srv)
+		_, err := uc.AddComment(ctx, dto.NewAddCommentParams(tid, uid, strings.Repeat("*", 5001)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestCommentUsecase_EditComment(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 本文がエスケープされること", func(t *testing.T) {
+		srv := new(mocks.ICommentService)
+		srv.On("EditComment", ctx, id, uid, "a&amp;b").Return(nil)
+		uc := NewCommentUsecase(srv)
+		err := uc.EditComment(ctx, dto.NewEditCommentParams(id, uid, "a&b"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ICommentService)
+		uc := NewCommentUsecase(srv)
+		err := uc.EditComment(ctx, dto.NewEditCommentParams(strings.Repeat("*", 51), uid, "comment"))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestCommentUsecase_DeleteComment(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ICommentService)
+		srv.On("DeleteComment", ctx, id, uid).Return(nil)
+		uc := NewCommentUsecase(srv)
+		err := uc.DeleteComment(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ICommentService)
+		uc := NewCommentUsecase(srv)
+		err := uc.DeleteComment(ctx, dto.NewIDParam(strings.Repeat("*", 51)), dto.NewIDParam(uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
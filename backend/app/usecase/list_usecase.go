@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"html"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/service"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+)
+
+// リストの操作
+type IListUsecase interface {
+	FindListsByUserID(ctx context.Context, userID *dto.IDParam) ([]*entity.List, error)
+	FindListsByWorkspaceID(ctx context.Context, workspaceID *dto.IDParam, userID *dto.IDParam) ([]*entity.List, error)
+	CreateList(ctx context.Context, arg *dto.CreateListParams) (string, error)
+	CreateWorkspaceList(ctx context.Context, arg *dto.CreateWorkspaceListParams) (string, error)
+	RenameList(ctx context.Context, arg *dto.RenameListParams) error
+	ArchiveList(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error
+	UnarchiveList(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error
+	DeleteList(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error
+	ReorderLists(ctx context.Context, arg *dto.ReorderListsParams) error
+}
+
+type ListUsecase struct {
+	service.IListService
+}
+
+func NewListUsecase(srv service.IListService) *ListUsecase {
+	return &ListUsecase{srv}
+}
+
+func (u *ListUsecase) FindListsByUserID(ctx context.Context, userID *dto.IDParam) ([]*entity.List, error) {
+	if err := userID.Validate(); err != nil {
+		return nil, err
+	}
+	return u.IListService.FindListsByUserID(ctx, userID.Value())
+}
+
+func (u *ListUsecase) CreateList(ctx context.Context, arg *dto.CreateListParams) (string, error) {
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	return u.IListService.CreateList(ctx, arg.UserID(), html.EscapeString(arg.Name()))
+}
+
+func (u *ListUsecase) FindListsByWorkspaceID(ctx context.Context, workspaceID *dto.IDParam, userID *dto.IDParam) ([]*entity.List, error) {
+	if err := workspaceID.Validate(); err != nil {
+		return nil, err
+	}
+	if err := userID.Validate(); err != nil {
+		return nil, err
+	}
+	return u.IListService.FindListsByWorkspaceID(ctx, workspaceID.Value(), userID.Value())
+}
+
+func (u *ListUsecase) CreateWorkspaceList(ctx context.Context, arg *dto.CreateWorkspaceListParams) (string, error) {
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	return u.IListService.CreateWorkspaceList(ctx, arg.WorkspaceID(), arg.UserID(), html.EscapeString(arg.Name()))
+}
+
+func (u *ListUsecase) RenameList(ctx context.Context, arg *dto.RenameListParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	return u.IListService.RenameList(ctx, arg.ID(), arg.UserID(), html.EscapeString(arg.Name()))
+}
+
+func (u *ListUsecase) ArchiveList(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	if err := userID.Validate(); err != nil {
+		return err
+	}
+	return u.IListService.ArchiveList(ctx, id.Value(), userID.Value())
+}
+
+func (u *ListUsecase) UnarchiveList(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	if err := userID.Validate(); err != nil {
+		return err
+	}
+	return u.IListService.UnarchiveList(ctx, id.Value(), userID.Value())
+}
+
+func (u *ListUsecase) DeleteList(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	if err := userID.Validate(); err != nil {
+		return err
+	}
+	return u.IListService.DeleteList(ctx, id.Value(), userID.Value())
+}
+
+func (u *ListUsecase) ReorderLists(ctx context.Context, arg *dto.ReorderListsParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	return u.IListService.ReorderLists(ctx, arg.UserID(), arg.ListIDs())
+}
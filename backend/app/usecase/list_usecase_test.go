@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListUsecase_NewListUsecase(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ IListUsecase = (*ListUsecase)(nil)
+	})
+}
+
+func TestListUsecase_FindListsByUserID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	lists := []*entity.List{
+		{ID: value.NewID("l1"), UserID: value.NewID(uid), Name: entity.InboxListName, IsInbox: true, CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IListService)
+		srv.On("FindListsByUserID", ctx, uid).Return(lists, nil)
+		uc := NewListUsecase(srv)
+		ret, err := uc.FindListsByUserID(ctx, dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, lists, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.IListService)
+		uc := NewListUsecase(srv)
+		_, err := uc.FindListsByUserID(ctx, dto.NewIDParam(strings.Repeat("*", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestListUsecase_CreateList(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IListService)
+		srv.On("CreateList", ctx, uid, "a&amp;b").Return(id, nil)
+		uc := NewListUsecase(srv)
+		ret, err := uc.CreateList(ctx, dto.NewCreateListParams(uid, "a&b"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, id, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "name must be 100 characters or less"}
+		srv := new(mocks.IListService)
+		uc := NewListUsecase(srv)
+		_, err := uc.CreateList(ctx, dto.NewCreateListParams(uid, strings.Repeat("*", 101)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestListUsecase_FindListsByWorkspaceID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	lists := []*entity.List{
+		{ID: value.NewID("lid"), UserID: value.NewID("owner"), WorkspaceID: value.NewID("wid"), Name: "team", CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IListService)
+		srv.On("FindListsByWorkspaceID", ctx, "wid", uid).Return(lists, nil)
+		uc := NewListUsecase(srv)
+		ret, err := uc.FindListsByWorkspaceID(ctx, dto.NewIDParam("wid"), dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, lists, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.IListService)
+		uc := NewListUsecase(srv)
+		_, err := uc.FindListsByWorkspaceID(ctx, dto.NewIDParam(strings.Repeat("*", 51)), dto.NewIDParam(uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestListUsecase_CreateWorkspaceList(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IListService)
+		srv.On("CreateWorkspaceList", ctx, "wid", uid, "a&amp;b").Return(id, nil)
+		uc := NewListUsecase(srv)
+		ret, err := uc.CreateWorkspaceList(ctx, dto.NewCreateWorkspaceListParams("wid", uid, "a&b"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, id, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "name must be 100 characters or less"}
+		srv := new(mocks.IListService)
+		uc := NewListUsecase(srv)
+		_, err := uc.CreateWorkspaceList(ctx, dto.NewCreateWorkspaceListParams("wid", uid, strings.Repeat("*", 101)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestListUsecase_RenameList(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IListService)
+		srv.On("RenameList", ctx, id, uid, "private").Return(nil)
+		uc := NewListUsecase(srv)
+		err := uc.RenameList(ctx, dto.NewRenameListParams(id, uid, "private"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "name must be 100 characters or less"}
+		srv := new(mocks.IListService)
+		uc := NewListUsecase(srv)
+		err := uc.RenameList(ctx, dto.NewRenameListParams(id, uid, strings.Repeat("*", 101)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestListUsecase_ArchiveList(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IListService)
+		srv.On("ArchiveList", ctx, id, uid).Return(nil)
+		uc := NewListUsecase(srv)
+		err := uc.ArchiveList(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.IListService)
+		uc := NewListUsecase(srv)
+		err := uc.ArchiveList(ctx, dto.NewIDParam(strings.Repeat("*", 51)), dto.NewIDParam(uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestListUsecase_UnarchiveList(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IListService)
+		srv.On("UnarchiveList", ctx, id, uid).Return(nil)
+		uc := NewListUsecase(srv)
+		err := uc.UnarchiveList(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.IListService)
+		uc := NewListUsecase(srv)
+		err := uc.UnarchiveList(ctx, dto.NewIDParam(id), dto.NewIDParam(strings.Repeat("*", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestListUsecase_DeleteList(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IListService)
+		srv.On("DeleteList", ctx, id, uid).Return(nil)
+		uc := NewListUsecase(srv)
+		err := uc.DeleteList(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.IListService)
+		uc := NewListUsecase(srv)
+		err := uc.DeleteList(ctx, dto.NewIDParam(strings.Repeat("*", 51)), dto.NewIDParam(uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestListUsecase_ReorderLists(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	ids := []string{"l2", "l1"}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IListService)
+		srv.On("ReorderLists", ctx, uid, ids).Return(nil)
+		uc := NewListUsecase(srv)
+		err := uc.ReorderLists(ctx, dto.NewReorderListsParams(uid, ids))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.IListService)
+		uc := NewListUsecase(srv)
+		err := uc.ReorderLists(ctx, dto.NewReorderListsParams(uid, []string{strings.Repeat("*", 51)}))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
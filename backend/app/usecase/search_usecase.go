@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/domain/service"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+)
+
+// 検索語に一致した部分をmark要素に置き換える
+var highlightReplacer = strings.NewReplacer(entity.HighlightStart, "<mark>", entity.HighlightStop, "</mark>")
+
+// タスクの検索
+type ISearchUsecase interface {
+	SearchTasks(ctx context.Context, arg *dto.SearchTasksParams) ([]*entity.TaskSearchResult, string, error)
+}
+
+type SearchUsecase struct {
+	service.ISearchService
+}
+
+func NewSearchUsecase(srv service.ISearchService) *SearchUsecase {
+	return &SearchUsecase{srv}
+}
+
+// タスクを検索し、一致した部分をmark要素で囲んだHTMLの見出しを返す。続きがある場合は次のページのトークンを返す
+func (u *SearchUsecase) SearchTasks(ctx context.Context, arg *dto.SearchTasksParams) ([]*entity.TaskSearchResult, string, error) {
+	if err := arg.Validate(); err != nil {
+		return nil, "", err
+	}
+	cursor, err := value.DecodeSearchCursor(arg.PageToken())
+	if err != nil {
+		return nil, "", err
+	}
+	var results []*entity.TaskSearchResult
+	var next *value.SearchCursor
+	if arg.WorkspaceID() != "" {
+		results, next, err = u.ISearchService.SearchWorkspaceTasks(ctx, arg.WorkspaceID(), arg.UserID(), arg.Query(), arg.PageSize(), cursor)
+	} else {
+		results, next, err = u.ISearchService.SearchTasks(ctx, arg.UserID(), arg.Query(), arg.PageSize(), cursor)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	for _, v := range results {
+		// 名前は登録時にエスケープ済み。説明はMarkdownの原文のためエスケープしてから置き換える
+		v.NameHeadline = highlightReplacer.Replace(v.NameHeadline)
+		v.DescriptionHeadline = highlightReplacer.Replace(html.EscapeString(v.DescriptionHeadline))
+	}
+	if next == nil {
+		return results, "", nil
+	}
+	return results, next.Encode(), nil
+}
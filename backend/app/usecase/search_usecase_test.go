@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchUsecase_NewSearchUsecase(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ ISearchUsecase = (*SearchUsecase)(nil)
+	})
+}
+
+func TestSearchUsecase_SearchTasks(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	newResults := func() []*entity.TaskSearchResult {
+		return []*entity.TaskSearchResult{
+			{
+				Task:                &entity.Task{ID: value.NewID("t1"), UserID: value.NewID(uid), Name: "buy &amp; milk"},
+				Rank:                0.5,
+				NameHeadline:        entity.HighlightStart + "buy" + entity.HighlightStop + " &amp; milk",
+				DescriptionHeadline: "<b>" + entity.HighlightStart + "buy" + entity.HighlightStop + "</b> at the shop",
+			},
+		}
+	}
+
+	tt.Run("正常系: 一致した部分がmark要素で囲まれること", func(t *testing.T) {
+		srv := new(mocks.ISearchService)
+		srv.On("SearchTasks", ctx, uid, "buy", int32(20), (*value.SearchCursor)(nil)).Return(newResults(), nil, nil)
+		uc := NewSearchUsecase(srv)
+		res, next, err := uc.SearchTasks(ctx, dto.NewSearchTasksParams("", uid, "buy", 0, ""))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Len(t, res, 1, "件数が一致すること")
+		require.Equal(t, "<mark>buy</mark> &amp; milk", res[0].NameHeadline, "名前は二重にエスケープされないこと")
+		require.Equal(t, "&lt;b&gt;<mark>buy</mark>&lt;/b&gt; at the shop", res[0].DescriptionHeadline, "説明はエスケープされること")
+		require.Empty(t, next, "トークンが空であること")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("正常系: トークンを指定した場合は続きを取得すること", func(t *testing.T) {
+		cursor := &value.SearchCursor{Rank: 0.8, ID: "t0"}
+		nextCursor := &value.SearchCursor{Rank: 0.5, ID: "t1"}
+		srv := new(mocks.ISearchService)
+		srv.On("SearchTasks", ctx, uid, "buy", int32(1), cursor).Return(newResults(), nextCursor, nil)
+		uc := NewSearchUsecase(srv)
+		_, next, err := uc.SearchTasks(ctx, dto.NewSearchTasksParams("", uid, "buy", 1, cursor.Encode()))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, nextCursor.Encode(), next, "トークンが一致すること")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("正常系: ワークスペースを指定した場合はワークスペースのタスクを検索すること", func(t *testing.T) {
+		srv := new(mocks.ISearchService)
+		srv.On("SearchWorkspaceTasks", ctx, "wid", uid, "buy", int32(20), (*value.SearchCursor)(nil)).Return(newResults(), nil, nil)
+		uc := NewSearchUsecase(srv)
+		res, _, err := uc.SearchTasks(ctx, dto.NewSearchTasksParams("wid", uid, "buy", 0, ""))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Len(t, res, 1, "件数が一致すること")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "query is empty"}
+		srv := new(mocks.ISearchService)
+		uc := NewSearchUsecase(srv)
+		_, _, err := uc.SearchTasks(ctx, dto.NewSearchTasksParams("", uid, "", 0, ""))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正なトークンの場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "invalid page token"}
+		srv := new(mocks.ISearchService)
+		uc := NewSearchUsecase(srv)
+		_, _, err := uc.SearchTasks(ctx, dto.NewSearchTasksParams("", uid, "buy", 0, "***"))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 検索に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		srv := new(mocks.ISearchService)
+		srv.On("SearchTasks", ctx, uid, "buy", int32(20), (*value.SearchCursor)(nil)).Return(nil, nil, errExp)
+		uc := NewSearchUsecase(srv)
+		_, _, err := uc.SearchTasks(ctx, dto.NewSearchTasksParams("", uid, "buy", 0, ""))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/domain/service"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+)
+
+// タスクとリストの共有の操作
+type ISharingUsecase interface {
+	FindCollaborators(ctx context.Context, arg *dto.SharingTargetParams) ([]*entity.Collaborator, error)
+	GrantAccess(ctx context.Context, arg *dto.GrantAccessParams) error
+	RevokeAccess(ctx context.Context, arg *dto.RevokeAccessParams) error
+}
+
+type SharingUsecase struct {
+	service.ISharingService
+}
+
+func NewSharingUsecase(srv service.ISharingService) *SharingUsecase {
+	return &SharingUsecase{srv}
+}
+
+func (u *SharingUsecase) FindCollaborators(ctx context.Context, arg *dto.SharingTargetParams) ([]*entity.Collaborator, error) {
+	if err := arg.Validate(); err != nil {
+		return nil, err
+	}
+	if arg.TaskID() != "" {
+		return u.ISharingService.FindTaskCollaborators(ctx, arg.TaskID(), arg.UserID())
+	}
+	return u.ISharingService.FindListCollaborators(ctx, arg.ListID(), arg.UserID())
+}
+
+func (u *SharingUsecase) GrantAccess(ctx context.Context, arg *dto.GrantAccessParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	target := arg.Target()
+	if target.TaskID() != "" {
+		return u.ISharingService.GrantTaskAccess(ctx, target.TaskID(), target.UserID(), arg.Email(), value.Role(arg.Role()))
+	}
+	return u.ISharingService.GrantListAccess(ctx, target.ListID(), target.UserID(), arg.Email(), value.Role(arg.Role()))
+}
+
+func (u *SharingUsecase) RevokeAccess(ctx context.Context, arg *dto.RevokeAccessParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	target := arg.Target()
+	if target.TaskID() != "" {
+		return u.ISharingService.RevokeTaskAccess(ctx, target.TaskID(), target.UserID(), arg.CollaboratorID())
+	}
+	return u.ISharingService.RevokeListAccess(ctx, target.ListID(), target.UserID(), arg.CollaboratorID())
+}
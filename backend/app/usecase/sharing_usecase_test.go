@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharingUsecase_NewSharingUsecase(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ ISharingUsecase = (*SharingUsecase)(nil)
+	})
+}
+
+func TestSharingUsecase_FindCollaborators(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	collaborators := []*entity.Collaborator{
+		{ID: value.NewID("cid"), TaskID: value.NewID("tid"), UserID: value.NewID("other"), Role: value.RoleViewer, GrantedBy: value.NewID("uid"), CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: タスクを指定した場合", func(t *testing.T) {
+		srv := new(mocks.ISharingService)
+		srv.On("FindTaskCollaborators", ctx, "tid", "uid").Return(collaborators, nil)
+		uc := NewSharingUsecase(srv)
+		ret, err := uc.FindCollaborators(ctx, dto.NewSharingTargetParams("tid", "", "uid"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, collaborators, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("正常系: リストを指定した場合", func(t *testing.T) {
+		srv := new(mocks.ISharingService)
+		srv.On("FindListCollaborators", ctx, "lid", "uid").Return(collaborators, nil)
+		uc := NewSharingUsecase(srv)
+		ret, err := uc.FindCollaborators(ctx, dto.NewSharingTargetParams("", "lid", "uid"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, collaborators, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "either task_id or list_id is required"}
+		srv := new(mocks.ISharingService)
+		uc := NewSharingUsecase(srv)
+		_, err := uc.FindCollaborators(ctx, dto.NewSharingTargetParams("", "", "uid"))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestSharingUsecase_GrantAccess(tt *testing.T) {
+	ctx := context.Background()
+
+	tt.Run("正常系: タスクを指定した場合", func(t *testing.T) {
+		srv := new(mocks.ISharingService)
+		srv.On("GrantTaskAccess", ctx, "tid", "uid", "a@example.com", value.RoleEditor).Return(nil)
+		uc := NewSharingUsecase(srv)
+		err := uc.GrantAccess(ctx, dto.NewGrantAccessParams("tid", "", "uid", "a@example.com", value.RoleEditor.Value()))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("正常系: リストを指定した場合", func(t *testing.T) {
+		srv := new(mocks.ISharingService)
+		srv.On("GrantListAccess", ctx, "lid", "uid", "a@example.com", value.RoleViewer).Return(nil)
+		uc := NewSharingUsecase(srv)
+		err := uc.GrantAccess(ctx, dto.NewGrantAccessParams("", "lid", "uid", "a@example.com", value.RoleViewer.Value()))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "invalid role"}
+		srv := new(mocks.ISharingService)
+		uc := NewSharingUsecase(srv)
+		err := uc.GrantAccess(ctx, dto.NewGrantAccessParams("tid", "", "uid", "a@example.com", value.RoleNone.Value()))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestSharingUsecase_RevokeAccess(tt *testing.T) {
+	ctx := context.Background()
+
+	tt.Run("正常系: タスクを指定した場合", func(t *testing.T) {
+		srv := new(mocks.ISharingService)
+		srv.On("RevokeTaskAccess", ctx, "tid", "uid", "other").Return(nil)
+		uc := NewSharingUsecase(srv)
+		err := uc.RevokeAccess(ctx, dto.NewRevokeAccessParams("tid", "", "uid", "other"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("正常系: リストを指定した場合", func(t *testing.T) {
+		srv := new(mocks.ISharingService)
+		srv.On("RevokeListAccess", ctx, "lid", "uid", "other").Return(nil)
+		uc := NewSharingUsecase(srv)
+		err := uc.RevokeAccess(ctx, dto.NewRevokeAccessParams("", "lid", "uid", "other"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "either task_id or list_id is required"}
+		srv := new(mocks.ISharingService)
+		uc := NewSharingUsecase(srv)
+		err := uc.RevokeAccess(ctx, dto.NewRevokeAccessParams("tid", "lid", "uid", "other"))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"html"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/service"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+)
+
+// タグの操作
+type ITagUsecase interface {
+	FindTagsByUserID(ctx context.Context, userID *dto.IDParam) ([]*entity.Tag, error)
+	CreateTag(ctx context.Context, arg *dto.CreateTagParams) (string, error)
+	RenameTag(ctx context.Context, arg *dto.RenameTagParams) error
+	DeleteTag(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error
+	AttachTag(ctx context.Context, arg *dto.TagTaskParams) error
+	DetachTag(ctx context.Context, arg *dto.TagTaskParams) error
+}
+
+type TagUsecase struct {
+	service.ITagService
+}
+
+func NewTagUsecase(srv service.ITagService) *TagUsecase {
+	return &TagUsecase{srv}
+}
+
+func (u *TagUsecase) FindTagsByUserID(ctx context.Context, userID *dto.IDParam) ([]*entity.Tag, error) {
+	if err := userID.Validate(); err != nil {
+		return nil, err
+	}
+	return u.ITagService.FindTagsByUserID(ctx, userID.Value())
+}
+
+func (u *TagUsecase) CreateTag(ctx context.Context, arg *dto.CreateTagParams) (string, error) {
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	return u.ITagService.CreateTag(ctx, arg.UserID(), html.EscapeString(arg.Name()))
+}
+
+func (u *TagUsecase) RenameTag(ctx context.Context, arg *dto.RenameTagParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	return u.ITagService.RenameTag(ctx, arg.ID(), arg.UserID(), html.EscapeString(arg.Name()))
+}
+
+func (u *TagUsecase) DeleteTag(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	if err := userID.Validate(); err != nil {
+		return err
+	}
+	return u.ITagService.DeleteTag(ctx, id.Value(), userID.Value())
+}
+
+func (u *TagUsecase) AttachTag(ctx context.Context, arg *dto.TagTaskParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	return u.ITagService.AttachTag(ctx, arg.TaskID(), arg.TagID(), arg.UserID())
+}
+
+func (u *TagUsecase) DetachTag(ctx context.Context, arg *dto.TagTaskParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	return u.ITagService.DetachTag(ctx, arg.TaskID(), arg.TagID(), arg.UserID())
+}
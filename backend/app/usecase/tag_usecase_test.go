@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagUsecase_NewTagUsecase(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ ITagUsecase = (*TagUsecase)(nil)
+	})
+}
+
+func TestTagUsecase_FindTagsByUserID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	tags := []*entity.Tag{
+		{ID: value.NewID("g1"), UserID: value.NewID(uid), Name: "home", CreatedAt: now, UpdatedAt: now, UsageCount: 1},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITagService)
+		srv.On("FindTagsByUserID", ctx, uid).Return(tags, nil)
+		uc := NewTagUsecase(srv)
+		ret, err := uc.FindTagsByUserID(ctx, dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tags, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ITagService)
+		uc := NewTagUsecase(srv)
+		_, err := uc.FindTagsByUserID(ctx, dto.NewIDParam(strings.Repeat("*", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTagUsecase_CreateTag(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITagService)
+		srv.On("CreateTag", ctx, uid, "a&amp;b").Return(id, nil)
+		uc := NewTagUsecase(srv)
+		ret, err := uc.CreateTag(ctx, dto.NewCreateTagParams(uid, "a&b"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, id, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "name must be 50 characters or less"}
+		srv := new(mocks.ITagService)
+		uc := NewTagUsecase(srv)
+		_, err := uc.CreateTag(ctx, dto.NewCreateTagParams(uid, strings.Repeat("*", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTagUsecase_RenameTag(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITagService)
+		srv.On("RenameTag", ctx, id, uid, "office").Return(nil)
+		uc := NewTagUsecase(srv)
+		err := uc.RenameTag(ctx, dto.NewRenameTagParams(id, uid, "office"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "name must be 50 characters or less"}
+		srv := new(mocks.ITagService)
+		uc := NewTagUsecase(srv)
+		err := uc.RenameTag(ctx, dto.NewRenameTagParams(id, uid, strings.Repeat("*", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTagUsecase_DeleteTag(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITagService)
+		srv.On("DeleteTag", ctx, id, uid).Return(nil)
+		uc := NewTagUsecase(srv)
+		err := uc.DeleteTag(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ITagService)
+		uc := NewTagUsecase(srv)
+		err := uc.DeleteTag(ctx, dto.NewIDParam(strings.Repeat("*", 51)), dto.NewIDParam(uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTagUsecase_AttachTag(tt *testing.T) {
+	ctx := context.Background()
+	tid := "tid"
+	gid := "gid"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITagService)
+		srv.On("AttachTag", ctx, tid, gid, uid).Return(nil)
+		uc := NewTagUsecase(srv)
+		err := uc.AttachTag(ctx, dto.NewTagTaskParams(tid, gid, uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ITagService)
+		uc := NewTagUsecase(srv)
+		err := uc.AttachTag(ctx, dto.NewTagTaskParams(tid, strings.Repeat("*", 51), uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTagUsecase_DetachTag(tt *testing.T) {
+	ctx := context.Background()
+	tid := "tid"
+	gid := "gid"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITagService)
+		srv.On("DetachTag", ctx, tid, gid, uid).Return(nil)
+		uc := NewTagUsecase(srv)
+		err := uc.DetachTag(ctx, dto.NewTagTaskParams(tid, gid, uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ITagService)
+		uc := NewTagUsecase(srv)
+		err := uc.DetachTag(ctx, dto.NewTagTaskParams(strings.Repeat("*", 51), gid, uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
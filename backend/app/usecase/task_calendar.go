@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+)
+
+// UIDのドメイン部分。タスクのIDと組み合わせて、タスクごとに変わらないUIDにする
+const calendarUIDDomain = "connect-tasklist"
+
+// iCalendarの日時(UTC)の形式
+const calendarTimeLayout = "20060102T150405Z"
+
+// iCalendarの1行の最大オクテット数(改行を除く)
+const calendarLineLimit = 75
+
+// iCalendarの優先度。添字が優先度の値になり、優先度なしは出力しない
+var calendarPriorities = []string{"", "9", "5", "3", "1"}
+
+// iCalendarのTEXTの値で特別な意味を持つ文字のエスケープ
+var calendarTextEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// タスクをRFC 5545のVTODOとしてiCalendarの形式でwに書き込む
+func encodeTaskCalendar(w io.Writer, tasks []*entity.Task) error {
+	cw := &calendarWriter{w: w}
+	cw.property("BEGIN", "VCALENDAR")
+	cw.property("VERSION", "2.0")
+	cw.property("PRODID", "-//connect-tasklist//Tasks//EN")
+	cw.property("CALSCALE", "GREGORIAN")
+	cw.property("METHOD", "PUBLISH")
+	cw.property("X-WR-CALNAME", "Tasks")
+	cw.property("REFRESH-INTERVAL;VALUE=DURATION", "PT1H")
+	cw.property("X-PUBLISHED-TTL", "PT1H")
+	for _, v := range tasks {
+		cw.property("BEGIN", "VTODO")
+		cw.property("UID", calendarUID(v.ID.Value()))
+		// 同じ内容のフィードが同じ出力になるように、出力時刻ではなく更新日時を使用する
+		cw.property("DTSTAMP", calendarTime(v.UpdatedAt))
+		cw.property("CREATED", calendarTime(v.CreatedAt))
+		cw.property("LAST-MODIFIED", calendarTime(v.UpdatedAt))
+		cw.property("SUMMARY", calendarText(html.UnescapeString(v.Name)))
+		if v.Description != "" {
+			cw.property("DESCRIPTION", calendarText(v.Description))
+		}
+		if v.DueAt != nil {
+			cw.property("DUE", calendarTime(*v.DueAt))
+		}
+		cw.property("STATUS", calendarStatus(v.Status))
+		if v.Status == value.TaskStatusDone {
+			cw.property("COMPLETED", calendarTime(v.UpdatedAt))
+			cw.property("PERCENT-COMPLETE", "100")
+		}
+		if p := v.Priority.Value(); p > 0 && int(p) < len(calendarPriorities) {
+			cw.property("PRIORITY", calendarPriorities[p])
+		}
+		if v.ParentID != nil {
+			cw.property("RELATED-TO;RELTYPE=PARENT", calendarUID(v.ParentID.Value()))
+		}
+		cw.property("END", "VTODO")
+	}
+	cw.property("END", "VCALENDAR")
+	return cw.err
+}
+
+// タスクのIDからUIDを作成する
+func calendarUID(id string) string {
+	return id + "@" + calendarUIDDomain
+}
+
+// 日時をUTCのiCalendarの形式にする
+func calendarTime(t time.Time) string {
+	return t.UTC().Format(calendarTimeLayout)
+}
+
+// タスクの状態をVTODOの状態にする。待機中は未着手として扱う
+func calendarStatus(s value.TaskStatus) string {
+	switch s {
+	case value.TaskStatusInProgress:
+		return "IN-PROCESS"
+	case value.TaskStatusDone:
+		return "COMPLETED"
+	case value.TaskStatusCancelled:
+		return "CANCELLED"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+// TEXTの値をエスケープする。TEXTに含められない制御文字は取り除く
+func calendarText(s string) string {
+	s = calendarTextEscaper.Replace(s)
+	return strings.Map(func(r rune) rune {
+		if r != '\t' && (r < 0x20 || r == 0x7f) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// iCalendarの行を書き込むWriter。最初に発生したエラーを保持し、以降は何も書き込まない
+type calendarWriter struct {
+	w   io.Writer
+	err error
+}
+
+// プロパティを1行で書き込む。75オクテットを超える場合はUTF-8の文字の途中で分割しないように折り返す
+func (c *calendarWriter) property(name string, val string) {
+	if c.err != nil {
+		return
+	}
+	line := name + ":" + val
+	var b strings.Builder
+	limit := calendarLineLimit
+	for len(line) > limit {
+		n := limit
+		for n > 0 && line[n]&0xC0 == 0x80 {
+			n--
+		}
+		b.WriteString(line[:n])
+		b.WriteString("\r\n ")
+		line = line[n:]
+		// 継続行は先頭の空白を含めて75オクテットにする
+		limit = calendarLineLimit - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	_, c.err = io.WriteString(c.w, b.String())
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeTaskCalendar(tt *testing.T) {
+	created := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	updated := time.Date(2024, 1, 3, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	due := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	tt.Run("正常系: タスクをVTODOとして出力すること", func(t *testing.T) {
+		tasks := []*entity.Task{
+			{ID: value.NewID("t1"), Name: "Tom &amp; Jerry", Status: value.TaskStatusTodo, Priority: value.PriorityHigh, DueAt: &due, Description: "a;b,c\\d\r\ne", CreatedAt: created, UpdatedAt: created},
+			{ID: value.NewID("t2"), Name: "report", Status: value.TaskStatusDone, Priority: value.PriorityUrgent, ParentID: value.NewID("t1"), CreatedAt: created, UpdatedAt: updated},
+			{ID: value.NewID("t3"), Name: "wait", Status: value.TaskStatusWaiting, CreatedAt: created, UpdatedAt: created},
+		}
+		var buf bytes.Buffer
+		err := encodeTaskCalendar(&buf, tasks)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"PRODID:-//connect-tasklist//Tasks//EN",
+			"CALSCALE:GREGORIAN",
+			"METHOD:PUBLISH",
+			"X-WR-CALNAME:Tasks",
+			"REFRESH-INTERVAL;VALUE=DURATION:PT1H",
+			"X-PUBLISHED-TTL:PT1H",
+			"BEGIN:VTODO",
+			"UID:t1@connect-tasklist",
+			"DTSTAMP:20240101T090000Z",
+			"CREATED:20240101T090000Z",
+			"LAST-MODIFIED:20240101T090000Z",
+			"SUMMARY:Tom & Jerry",
+			`DESCRIPTION:a\;b\,c\\d\ne`,
+			"DUE:20240105T000000Z",
+			"STATUS:NEEDS-ACTION",
+			"PRIORITY:3",
+			"END:VTODO",
+			"BEGIN:VTODO",
+			"UID:t2@connect-tasklist",
+			"DTSTAMP:20240103T000000Z",
+			"CREATED:20240101T090000Z",
+			"LAST-MODIFIED:20240103T000000Z",
+			"SUMMARY:report",
+			"STATUS:COMPLETED",
+			"COMPLETED:20240103T000000Z",
+			"PERCENT-COMPLETE:100",
+			"PRIORITY:1",
+			"RELATED-TO;RELTYPE=PARENT:t1@connect-tasklist",
+			"END:VTODO",
+			"BEGIN:VTODO",
+			"UID:t3@connect-tasklist",
+			"DTSTAMP:20240101T090000Z",
+			"CREATED:20240101T090000Z",
+			"LAST-MODIFIED:20240101T090000Z",
+			"SUMMARY:wait",
+			"STATUS:NEEDS-ACTION",
+			"END:VTODO",
+			"END:VCALENDAR",
+			"",
+		}, "\r\n"), buf.String())
+	})
+	tt.Run("正常系: 長い行はUTF-8の文字の途中で分割せずに折り返すこと", func(t *testing.T) {
+		name := strings.Repeat("あ", 60)
+		tasks := []*entity.Task{
+			{ID: value.NewID("t1"), Name: name, Status: value.TaskStatusInProgress, CreatedAt: created, UpdatedAt: created},
+		}
+		var buf bytes.Buffer
+		err := encodeTaskCalendar(&buf, tasks)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Contains(t, buf.String(), "STATUS:IN-PROCESS\r\n")
+		var summary []string
+		for _, line := range strings.Split(buf.String(), "\r\n") {
+			require.LessOrEqual(t, len(line), 75, "75オクテット以下であること")
+			if strings.HasPrefix(line, "SUMMARY:") || (len(summary) > 0 && strings.HasPrefix(line, " ")) {
+				summary = append(summary, line)
+			}
+		}
+		require.Len(t, summary, 3, "折り返されること")
+		for _, line := range summary {
+			require.True(t, strings.HasPrefix(line, "SUMMARY:") || strings.HasPrefix(line, " あ"), "文字の途中で分割しないこと")
+		}
+		unfolded := strings.ReplaceAll(buf.String(), "\r\n ", "")
+		require.Contains(t, unfolded, "SUMMARY:"+name+"\r\n", "折り返しを戻すと元の値になること")
+	})
+	tt.Run("正常系: タスクがない場合は空のカレンダーを出力すること", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := encodeTaskCalendar(&buf, nil)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.True(t, strings.HasPrefix(buf.String(), "BEGIN:VCALENDAR\r\n"))
+		require.True(t, strings.HasSuffix(buf.String(), "X-PUBLISHED-TTL:PT1H\r\nEND:VCALENDAR\r\n"))
+	})
+	tt.Run("準正常系: 書き込みに失敗した場合", func(t *testing.T) {
+		err := encodeTaskCalendar(&failingWriter{}, nil)
+
+		require.EqualError(t, err, "failed", "エラーが一致すること")
+	})
+}
+
+func TestCalendarText(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   string
+		exp   string
+	}{
+		{"正常系: 特別な意味を持つ文字をエスケープすること", `a\b;c,d`, `a\\b\;c\,d`},
+		{"正常系: 改行を\\nにすること", "a\nb\r\nc\rd", `a\nb\nc\nd`},
+		{"正常系: 制御文字を取り除くこと", "a\x00b\tc\x7f", "ab\tc"},
+		{"正常系: コロンはエスケープしないこと", "a:b", "a:b"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			require.Equal(t, v.exp, calendarText(v.arg))
+		})
+	}
+}
+
+// 常に失敗するWriter
+type failingWriter struct{}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("failed")
+}
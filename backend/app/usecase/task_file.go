@@ -0,0 +1,392 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+)
+
+// 優先度の名前。添字が優先度の値になる
+var taskPriorityNames = []string{"none", "low", "medium", "high", "urgent"}
+
+// todo.txtの優先度の文字。添字が優先度の値になり、優先度なしは文字を付けない
+var todoTxtPriorities = []string{"", "D", "C", "B", "A"}
+
+// CSVの列。インポート時は1行目の列名で列を判定する
+var taskCSVHeader = []string{"name", "status", "priority", "due_at", "description", "created_at"}
+
+// Markdownのチェックリストの項目(- [ ] foo)
+var markdownChecklistPattern = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX])\]\s+(.*)$`)
+
+// Markdownのリストの項目
+var markdownListPattern = regexp.MustCompile(`^\s*[-*+]\s`)
+
+// ファイルから読み込んだタスクの1行。文字列はエスケープする前の値
+type taskFileRow struct {
+	line        int32
+	name        string
+	status      value.TaskStatus
+	priority    value.Priority
+	dueAt       *time.Time
+	description string
+	// 行の解析に失敗した場合のエラー
+	err error
+}
+
+// JSONの1タスク
+type taskJSON struct {
+	Name        string `json:"name"`
+	Status      string `json:"status,omitempty"`
+	Priority    string `json:"priority,omitempty"`
+	DueAt       string `json:"due_at,omitempty"`
+	Description string `json:"description,omitempty"`
+	CreatedAt   string `json:"created_at,omitempty"`
+}
+
+// タスクを指定した形式でwに書き込む。タスク名は保存時のエスケープを戻して出力する
+func encodeTaskFile(w io.Writer, format value.TaskFileFormat, tasks []*entity.Task) error {
+	switch format {
+	case value.TaskFileFormatJSON:
+		items := make([]taskJSON, len(tasks))
+		for i, t := range tasks {
+			items[i] = taskJSON{
+				Name:        html.UnescapeString(t.Name),
+				Status:      t.Status.String(),
+				Priority:    formatTaskPriority(t.Priority),
+				DueAt:       formatTaskDueAt(t.DueAt),
+				Description: t.Description,
+				CreatedAt:   t.CreatedAt.UTC().Format(time.RFC3339),
+			}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+	case value.TaskFileFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(taskCSVHeader); err != nil {
+			return err
+		}
+		for _, t := range tasks {
+			if err := cw.Write([]string{
+				toCSVText(t.Name),
+				t.Status.String(),
+				formatTaskPriority(t.Priority),
+				formatTaskDueAt(t.DueAt),
+				guardCSVFormula(t.Description),
+				t.CreatedAt.UTC().Format(time.RFC3339),
+			}); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case value.TaskFileFormatTodoTxt:
+		for _, t := range tasks {
+			if _, err := io.WriteString(w, formatTodoTxtLine(t)+"\n"); err != nil {
+				return err
+			}
+		}
+		return nil
+	case value.TaskFileFormatMarkdown:
+		for _, t := range tasks {
+			check := " "
+			if t.Status.IsClosed() {
+				check = "x"
+			}
+			if _, err := io.WriteString(w, "- ["+check+"] "+html.UnescapeString(t.Name)+"\n"); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return &app.ErrInputValidationFailed{Msg: "invalid file format"}
+	}
+}
+
+// ファイルを指定した形式で読み込む。行ごとの解析の失敗は行のエラーにし、ファイル全体を読み込めない場合のみエラーを返す
+func decodeTaskFile(format value.TaskFileFormat, data []byte) ([]taskFileRow, error) {
+	// 先頭のBOMは読み飛ばす
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	switch format {
+	case value.TaskFileFormatJSON:
+		return decodeTaskJSON(data)
+	case value.TaskFileFormatCSV:
+		return decodeTaskCSV(data)
+	case value.TaskFileFormatTodoTxt:
+		var rows []taskFileRow
+		for i, line := range splitLines(data) {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			rows = append(rows, parseTodoTxtLine(int32(i+1), line))
+		}
+		return rows, nil
+	case value.TaskFileFormatMarkdown:
+		var rows []taskFileRow
+		for i, line := range splitLines(data) {
+			m := markdownChecklistPattern.FindStringSubmatch(line)
+			if m == nil {
+				// 見出しや段落は読み飛ばし、チェックボックスのないリストの項目のみエラーにする
+				if markdownListPattern.MatchString(line) {
+					rows = append(rows, taskFileRow{line: int32(i + 1), name: strings.TrimSpace(line), err: &app.ErrInputValidationFailed{Msg: "not a checklist item"}})
+				}
+				continue
+			}
+			status := value.TaskStatusTodo.String()
+			if m[1] != " " {
+				status = value.TaskStatusDone.String()
+			}
+			rows = append(rows, newTaskFileRow(int32(i+1), m[2], status, "", "", ""))
+		}
+		return rows, nil
+	default:
+		return nil, &app.ErrInputValidationFailed{Msg: "invalid file format"}
+	}
+}
+
+// JSONの配列を読み込む。要素ごとに解析し、行番号には要素の番号を使う
+func decodeTaskJSON(data []byte) ([]taskFileRow, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, &app.ErrInputValidationFailed{Msg: "file must be a JSON array"}
+	}
+	rows := make([]taskFileRow, len(items))
+	for i, raw := range items {
+		var item taskJSON
+		if err := json.Unmarshal(raw, &item); err != nil {
+			rows[i] = taskFileRow{line: int32(i + 1), err: &app.ErrInputValidationFailed{Msg: "invalid task object"}}
+			continue
+		}
+		rows[i] = newTaskFileRow(int32(i+1), item.Name, item.Status, item.Priority, item.DueAt, item.Description)
+	}
+	return rows, nil
+}
+
+// 1行目を列名としてCSVを読み込む。name以外の列は省略できる
+func decodeTaskCSV(data []byte) ([]taskFileRow, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return nil, &app.ErrInputValidationFailed{Msg: "header row is required"}
+	}
+	columns := make(map[string]int, len(header))
+	for i, v := range header {
+		columns[strings.ToLower(strings.TrimSpace(v))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, &app.ErrInputValidationFailed{Msg: "name column is required"}
+	}
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return fromCSVText(record[i])
+	}
+	var rows []taskFileRow
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		var pe *csv.ParseError
+		if errors.As(err, &pe) {
+			rows = append(rows, taskFileRow{line: int32(pe.StartLine), err: &app.ErrInputValidationFailed{Msg: "invalid CSV row"}})
+			continue
+		}
+		if err != nil {
+			return nil, &app.ErrInputValidationFailed{Msg: "invalid CSV"}
+		}
+		line, _ := r.FieldPos(0)
+		rows = append(rows, newTaskFileRow(int32(line), field(record, "name"), field(record, "status"), field(record, "priority"), field(record, "due_at"), field(record, "description")))
+	}
+}
+
+// todo.txtの1行を読み込む。完了マーク、優先度、日付の後の文字列を名前にし、due:、pri:、status:のタグを取り除く
+func parseTodoTxtLine(line int32, s string) taskFileRow {
+	fields := strings.Fields(s)
+	status := ""
+	priority := ""
+	due := ""
+	if len(fields) > 0 && fields[0] == "x" {
+		// 完了した行は完了日と作成日が続く
+		status = value.TaskStatusDone.String()
+		fields = fields[1:]
+		for i := 0; i < 2 && len(fields) > 0 && isTodoTxtDate(fields[0]); i++ {
+			fields = fields[1:]
+		}
+	} else {
+		if len(fields) > 0 && len(fields[0]) == 3 && fields[0][0] == '(' && fields[0][1] >= 'A' && fields[0][1] <= 'Z' && fields[0][2] == ')' {
+			priority = fields[0][1:2]
+			fields = fields[1:]
+		}
+		if len(fields) > 0 && isTodoTxtDate(fields[0]) {
+			fields = fields[1:]
+		}
+	}
+	var words []string
+	for _, f := range fields {
+		key, v, ok := strings.Cut(f, ":")
+		switch {
+		case ok && key == "due":
+			due = v
+		case ok && key == "pri":
+			priority = v
+		case ok && key == "status":
+			status = v
+		default:
+			words = append(words, f)
+		}
+	}
+	row := newTaskFileRow(line, strings.Join(words, " "), status, "", due, "")
+	if priority != "" {
+		p, err := parseTodoTxtPriority(priority)
+		if err != nil && row.err == nil {
+			row.err = err
+		}
+		row.priority = p
+	}
+	return row
+}
+
+// todo.txtの1行を作成する。完了または中止したタスクは更新日を完了日として出力する
+func formatTodoTxtLine(t *entity.Task) string {
+	var parts []string
+	letter := ""
+	if t.Priority >= value.PriorityNone && t.Priority <= value.PriorityUrgent {
+		letter = todoTxtPriorities[t.Priority]
+	}
+	created := t.CreatedAt.UTC().Format(time.DateOnly)
+	if t.Status.IsClosed() {
+		parts = append(parts, "x", t.UpdatedAt.UTC().Format(time.DateOnly), created, html.UnescapeString(t.Name))
+		// 完了した行は優先度を先頭に書かないため、タグで残す
+		if letter != "" {
+			parts = append(parts, "pri:"+letter)
+		}
+	} else {
+		if letter != "" {
+			parts = append(parts, "("+letter+")")
+		}
+		parts = append(parts, created, html.UnescapeString(t.Name))
+	}
+	if t.Status != value.TaskStatusTodo && t.Status != value.TaskStatusDone {
+		parts = append(parts, "status:"+t.Status.String())
+	}
+	if t.DueAt != nil {
+		parts = append(parts, "due:"+t.DueAt.UTC().Format(time.DateOnly))
+	}
+	return strings.Join(parts, " ")
+}
+
+// 文字列の値からタスクの1行を作成する。空の値は既定値にし、最初に失敗した値のエラーを行のエラーにする
+func newTaskFileRow(line int32, name string, status string, priority string, dueAt string, description string) taskFileRow {
+	row := taskFileRow{line: line, name: strings.TrimSpace(name), status: value.TaskStatusTodo, description: description}
+	var errs []error
+	if status != "" {
+		row.status = value.ParseTaskStatus(strings.ToLower(strings.TrimSpace(status)))
+		if row.status == value.TaskStatusUnknown {
+			errs = append(errs, &app.ErrInputValidationFailed{Msg: "invalid status"})
+		}
+	}
+	if priority != "" {
+		row.priority = parseTaskPriority(priority)
+		if row.priority == value.PriorityUnknown {
+			errs = append(errs, &app.ErrInputValidationFailed{Msg: "invalid priority"})
+		}
+	}
+	if dueAt != "" {
+		v, err := parseTaskDueAt(dueAt)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		row.dueAt = v
+	}
+	if len(errs) > 0 {
+		row.err = errs[0]
+	}
+	return row
+}
+
+func formatTaskPriority(p value.Priority) string {
+	if p < value.PriorityNone || p > value.PriorityUrgent {
+		return ""
+	}
+	return taskPriorityNames[p]
+}
+
+// 優先度の名前を優先度に変換する。不明な名前の場合はPriorityUnknownを返す
+func parseTaskPriority(name string) value.Priority {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for i, v := range taskPriorityNames {
+		if v == name {
+			return value.Priority(i)
+		}
+	}
+	return value.PriorityUnknown
+}
+
+// todo.txtの優先度の文字を優先度に変換する。Aを緊急とし、D以降は全て低にする
+func parseTodoTxtPriority(letter string) (value.Priority, error) {
+	if len(letter) != 1 || letter[0] < 'A' || letter[0] > 'Z' {
+		return value.PriorityUnknown, &app.ErrInputValidationFailed{Msg: "invalid priority"}
+	}
+	for i, v := range todoTxtPriorities {
+		if v == letter {
+			return value.Priority(i), nil
+		}
+	}
+	return value.PriorityLow, nil
+}
+
+func formatTaskDueAt(dueAt *time.Time) string {
+	if dueAt == nil {
+		return ""
+	}
+	return dueAt.UTC().Format(time.RFC3339)
+}
+
+// 期限をRFC3339形式または日付のみの形式で読み込む。日付のみの場合はUTCの0時にする
+func parseTaskDueAt(s string) (*time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if v, err := time.Parse(layout, s); err == nil {
+			v = v.UTC()
+			return &v, nil
+		}
+	}
+	return nil, &app.ErrInputValidationFailed{Msg: "invalid due date"}
+}
+
+func isTodoTxtDate(s string) bool {
+	_, err := time.Parse(time.DateOnly, s)
+	return err == nil
+}
+
+// CSVの出力時に付けた数式の無効化を戻す
+func fromCSVText(s string) string {
+	if len(s) > 1 && s[0] == '\'' && strings.ContainsRune("=+-@\t\r", rune(s[1])) {
+		return s[1:]
+	}
+	return s
+}
+
+// 改行で分割する。CRLFの場合はCRを取り除く
+func splitLines(data []byte) []string {
+	lines := strings.Split(string(data), "\n")
+	for i, v := range lines {
+		lines[i] = strings.TrimSuffix(v, "\r")
+	}
+	return lines
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeTaskFile(tt *testing.T) {
+	created := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	updated := time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC)
+	due := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	tasks := []*entity.Task{
+		{Name: "Tom &amp; Jerry", Status: value.TaskStatusTodo, Priority: value.PriorityHigh, DueAt: &due, Description: "=memo", CreatedAt: created, UpdatedAt: created},
+		{Name: "report", Status: value.TaskStatusDone, Priority: value.PriorityUrgent, CreatedAt: created, UpdatedAt: updated},
+		{Name: "wait", Status: value.TaskStatusWaiting, CreatedAt: created, UpdatedAt: created},
+	}
+
+	testcases := []struct {
+		title  string
+		format value.TaskFileFormat
+		exp    string
+	}{
+		{"正常系: JSONの場合", value.TaskFileFormatJSON, strings.Join([]string{
+			`[`,
+			`  {`,
+			`    "name": "Tom & Jerry",`,
+			`    "status": "todo",`,
+			`    "priority": "high",`,
+			`    "due_at": "2024-01-05T00:00:00Z",`,
+			`    "description": "=memo",`,
+			`    "created_at": "2024-01-01T09:00:00Z"`,
+			`  },`,
+			`  {`,
+			`    "name": "report",`,
+			`    "status": "done",`,
+			`    "priority": "urgent",`,
+			`    "created_at": "2024-01-01T09:00:00Z"`,
+			`  },`,
+			`  {`,
+			`    "name": "wait",`,
+			`    "status": "waiting",`,
+			`    "priority": "none",`,
+			`    "created_at": "2024-01-01T09:00:00Z"`,
+			`  }`,
+			`]`,
+			``,
+		}, "\n")},
+		{"正常系: CSVの場合", value.TaskFileFormatCSV, strings.Join([]string{
+			"name,status,priority,due_at,description,created_at",
+			"Tom & Jerry,todo,high,2024-01-05T00:00:00Z,'=memo,2024-01-01T09:00:00Z",
+			"report,done,urgent,,,2024-01-01T09:00:00Z",
+			"wait,waiting,none,,,2024-01-01T09:00:00Z",
+			"",
+		}, "\n")},
+		{"正常系: todo.txtの場合", value.TaskFileFormatTodoTxt, strings.Join([]string{
+			"(B) 2024-01-01 Tom & Jerry due:2024-01-05",
+			"x 2024-01-03 2024-01-01 report pri:A",
+			"2024-01-01 wait status:waiting",
+			"",
+		}, "\n")},
+		{"正常系: Markdownの場合", value.TaskFileFormatMarkdown, strings.Join([]string{
+			"- [ ] Tom & Jerry",
+			"- [x] report",
+			"- [ ] wait",
+			"",
+		}, "\n")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := encodeTaskFile(&buf, v.format, tasks)
+
+			require.NoError(t, err, "エラーが発生しないこと")
+			require.Equal(t, v.exp, buf.String())
+		})
+	}
+	tt.Run("準正常系: 形式が不正な場合", func(t *testing.T) {
+		err := encodeTaskFile(&bytes.Buffer{}, value.TaskFileFormat(9), tasks)
+
+		require.EqualError(t, err, "invalid file format", "エラーが一致すること")
+	})
+}
+
+func TestDecodeTaskFile(tt *testing.T) {
+	due := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	testcases := []struct {
+		title  string
+		format value.TaskFileFormat
+		data   string
+		exp    []taskFileRow
+	}{
+		{"正常系: JSONの場合", value.TaskFileFormatJSON, `[{"name":" Tom & Jerry ","status":"done","priority":"High","due_at":"2024-01-05","description":"memo"},{"name":"a","priority":"max"},{"name":1}]`, []taskFileRow{
+			{line: 1, name: "Tom & Jerry", status: value.TaskStatusDone, priority: value.PriorityHigh, dueAt: &due, description: "memo"},
+			{line: 2, name: "a", status: value.TaskStatusTodo, priority: value.PriorityUnknown, err: errors.New("invalid priority")},
+			{line: 3, err: errors.New("invalid task object")},
+		}},
+		{"正常系: CSVの場合は列名で列を判定すること", value.TaskFileFormatCSV, "\ufeffpriority,Name,due_at\r\nlow,'=SUM(A1),2024-01-05T00:00:00Z\r\n,\"multi\nline\",\r\nhigh,b,tomorrow\r\n", []taskFileRow{
+			{line: 2, name: "=SUM(A1)", status: value.TaskStatusTodo, priority: value.PriorityLow, dueAt: &due},
+			{line: 3, name: "multi\nline", status: value.TaskStatusTodo},
+			{line: 5, name: "b", status: value.TaskStatusTodo, priority: value.PriorityHigh, err: errors.New("invalid due date")},
+		}},
+		{"正常系: CSVの行が不正な場合は行のエラーにすること", value.TaskFileFormatCSV, "name\n\"a\"b\nc\n", []taskFileRow{
+			{line: 2, err: errors.New("invalid CSV row")},
+			{line: 3, name: "c", status: value.TaskStatusTodo},
+		}},
+		{"正常系: todo.txtの場合", value.TaskFileFormatTodoTxt, "(A) 2024-01-01 call +work @phone due:2024-01-05\n\nx 2024-01-03 2024-01-01 report pri:C\nx done status:cancelled\n(E) low\n(1) not priority\nbad due:soon\n", []taskFileRow{
+			{line: 1, name: "call +work @phone", status: value.TaskStatusTodo, priority: value.PriorityUrgent, dueAt: &due},
+			{line: 3, name: "report", status: value.TaskStatusDone, priority: value.PriorityMedium},
+			{line: 4, name: "done", status: value.TaskStatusCancelled},
+			{line: 5, name: "low", status: value.TaskStatusTodo, priority: value.PriorityLow},
+			{line: 6, name: "(1) not priority", status: value.TaskStatusTodo},
+			{line: 7, name: "bad", status: value.TaskStatusTodo, err: errors.New("invalid due date")},
+		}},
+		{"正常系: Markdownの場合はチェックリスト以外を読み飛ばすこと", value.TaskFileFormatMarkdown, "# Tasks\n\nintro\n- [ ] write\n  * [X] nested\n- plain\n", []taskFileRow{
+			{line: 4, name: "write", status: value.TaskStatusTodo},
+			{line: 5, name: "nested", status: value.TaskStatusDone},
+			{line: 6, name: "- plain", err: errors.New("not a checklist item")},
+		}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			ret, err := decodeTaskFile(v.format, []byte(v.data))
+
+			require.NoError(t, err, "エラーが発生しないこと")
+			require.Len(t, ret, len(v.exp))
+			for i, exp := range v.exp {
+				if exp.err == nil {
+					require.NoError(t, ret[i].err, "行のエラーが発生しないこと")
+				} else {
+					require.EqualError(t, ret[i].err, exp.err.Error(), "行のエラーが一致すること")
+				}
+				ret[i].err = nil
+				exp.err = nil
+				require.Equal(t, exp, ret[i])
+			}
+		})
+	}
+
+	errTestcases := []struct {
+		title  string
+		format value.TaskFileFormat
+		data   string
+		err    error
+	}{
+		{"準正常系: JSONが配列でない場合", value.TaskFileFormatJSON, `{"name":"a"}`, errors.New("file must be a JSON array")},
+		{"準正常系: CSVにname列がない場合", value.TaskFileFormatCSV, "title\na\n", errors.New("name column is required")},
+		{"準正常系: CSVが空の場合", value.TaskFileFormatCSV, "", errors.New("header row is required")},
+		{"準正常系: 形式が不正な場合", value.TaskFileFormat(-1), "", errors.New("invalid file format")},
+	}
+	for _, v := range errTestcases {
+		tt.Run(v.title, func(t *testing.T) {
+			_, err := decodeTaskFile(v.format, []byte(v.data))
+
+			require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+		})
+	}
+}
@@ -3,28 +3,67 @@ package usecase
 import (
 	"context"
 	"html"
+	"time"
 
+	"github.com/7oh2020/connect-tasklist/backend/app"
 	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
 	"github.com/7oh2020/connect-tasklist/backend/domain/service"
 	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	"github.com/7oh2020/connect-tasklist/backend/util/markdown"
 )
 
 // タスクの操作
 type ITaskUsecase interface {
 	FindTasksByUserID(ctx context.Context, userID *dto.IDParam) ([]*entity.Task, error)
+	FindTasksByUserIDOrderByPriority(ctx context.Context, userID *dto.IDParam) ([]*entity.Task, error)
+	FindTasksByUserIDOrderByPosition(ctx context.Context, userID *dto.IDParam) ([]*entity.Task, error)
+	FindTaskPage(ctx context.Context, arg *dto.TaskPageParams) ([]*entity.Task, string, error)
+	FindOverdueTasksByUserID(ctx context.Context, userID *dto.IDParam) ([]*entity.Task, error)
+	FindTasksDueBetween(ctx context.Context, arg *dto.DueRangeParams) ([]*entity.Task, error)
+	FindTasksByListID(ctx context.Context, arg *dto.ListFilterParams) ([]*entity.Task, error)
+	FindTasksByTags(ctx context.Context, arg *dto.TagFilterParams) ([]*entity.Task, error)
+	FindTasksByAssigneeID(ctx context.Context, arg *dto.AssignedFilterParams) ([]*entity.Task, error)
+	FindTasksByWorkspaceID(ctx context.Context, arg *dto.WorkspaceFilterParams) ([]*entity.Task, error)
+	FindTaskTree(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) ([]*entity.Task, error)
 	CreateTask(ctx context.Context, arg *dto.CreateTaskParams) (string, error)
+	CreateSubtask(ctx context.Context, arg *dto.CreateSubtaskParams) (string, error)
+	MoveSubtask(ctx context.Context, arg *dto.MoveSubtaskParams) error
+	MoveTaskToList(ctx context.Context, arg *dto.MoveTaskToListParams) error
+	MoveTask(ctx context.Context, arg *dto.MoveTaskParams) error
+	RebalanceTaskPositions(ctx context.Context) error
 	ChangeTaskName(ctx context.Context, arg *dto.ChangeTaskNameParams) error
+	ChangeTaskPriority(ctx context.Context, arg *dto.ChangeTaskPriorityParams) error
+	ChangeTaskDescription(ctx context.Context, arg *dto.ChangeTaskDescriptionParams) error
+	AssignTask(ctx context.Context, arg *dto.AssignTaskParams) error
+	UnassignTask(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error
 	CompleteTask(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error
 	UncompleteTask(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error
+	TransitionTask(ctx context.Context, arg *dto.TransitionTaskParams) error
+	SetTaskRecurrence(ctx context.Context, arg *dto.SetTaskRecurrenceParams) error
+	ClearTaskRecurrence(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error
+	SetTaskDueDate(ctx context.Context, arg *dto.SetTaskDueDateParams) error
+	ClearTaskDueDate(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error
+	AddTaskDependency(ctx context.Context, arg *dto.TaskDependencyParams) error
+	RemoveTaskDependency(ctx context.Context, arg *dto.TaskDependencyParams) error
 	DeleteTask(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error
+	BatchMutateTasks(ctx context.Context, arg *dto.BatchMutateTasksParams) ([]*entity.TaskOperationResult, error)
+	FindTrashedTasksByUserID(ctx context.Context, userID *dto.IDParam) ([]*entity.Task, error)
+	RestoreTask(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error
+	EmptyTrash(ctx context.Context, userID *dto.IDParam) error
+	PurgeTrashedTasks(ctx context.Context, retention time.Duration) (int64, error)
+	FindTaskHistory(ctx context.Context, arg *dto.TaskHistoryParams) ([]*entity.TaskHistory, string, error)
+	Undo(ctx context.Context, userID *dto.IDParam) (*entity.TaskUndoEntry, error)
+	Redo(ctx context.Context, userID *dto.IDParam) (*entity.TaskUndoEntry, error)
 }
 
 type TaskUsecase struct {
 	service.ITaskService
+	markdown.IMarkdownRenderer
 }
 
-func NewTaskUsecase(srv service.ITaskService) *TaskUsecase {
-	return &TaskUsecase{srv}
+func NewTaskUsecase(srv service.ITaskService, mr markdown.IMarkdownRenderer) *TaskUsecase {
+	return &TaskUsecase{srv, mr}
 }
 
 func (u *TaskUsecase) FindTasksByUserID(ctx context.Context, userID *dto.IDParam) ([]*entity.Task, error) {
@@ -34,11 +73,139 @@ func (u *TaskUsecase) FindTasksByUserID(ctx context.Context, userID *dto.IDParam
 	return u.ITaskService.FindTasksByUserID(ctx, userID.Value())
 }
 
+func (u *TaskUsecase) FindTasksByUserIDOrderByPriority(ctx context.Context, userID *dto.IDParam) ([]*entity.Task, error) {
+	if err := userID.Validate(); err != nil {
+		return nil, err
+	}
+	return u.ITaskService.FindTasksByUserIDOrderByPriority(ctx, userID.Value())
+}
+
+func (u *TaskUsecase) FindTasksByUserIDOrderByPosition(ctx context.Context, userID *dto.IDParam) ([]*entity.Task, error) {
+	if err := userID.Validate(); err != nil {
+		return nil, err
+	}
+	return u.ITaskService.FindTasksByUserIDOrderByPosition(ctx, userID.Value())
+}
+
+// 個人のタスクを絞り込んで1ページ分を取得する。続きがある場合は次のページのトークンを返す
+func (u *TaskUsecase) FindTaskPage(ctx context.Context, arg *dto.TaskPageParams) ([]*entity.Task, string, error) {
+	if err := arg.Validate(); err != nil {
+		return nil, "", err
+	}
+	cursor, err := value.DecodeTaskPageCursor(arg.PageToken())
+	if err != nil {
+		return nil, "", err
+	}
+	filter := &value.TaskFilter{
+		Completion:  value.TaskCompletion(arg.Completion()),
+		CreatedFrom: arg.CreatedFrom(),
+		CreatedTo:   arg.CreatedTo(),
+		UpdatedFrom: arg.UpdatedFrom(),
+		UpdatedTo:   arg.UpdatedTo(),
+		// 名前はエスケープして保存しているため、同じようにエスケープして比較する
+		NameContains: html.EscapeString(arg.NameContains()),
+	}
+	sort := value.TaskSort{Field: value.TaskSortField(arg.SortField()), Descending: arg.Descending()}
+	tasks, next, err := u.ITaskService.FindTaskPage(ctx, arg.UserID(), filter, sort, arg.PageSize(), cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	if next == nil {
+		return tasks, "", nil
+	}
+	return tasks, next.Encode(), nil
+}
+
+func (u *TaskUsecase) FindOverdueTasksByUserID(ctx context.Context, userID *dto.IDParam) ([]*entity.Task, error) {
+	if err := userID.Validate(); err != nil {
+		return nil, err
+	}
+	return u.ITaskService.FindOverdueTasksByUserID(ctx, userID.Value())
+}
+
+func (u *TaskUsecase) FindTasksDueBetween(ctx context.Context, arg *dto.DueRangeParams) ([]*entity.Task, error) {
+	if err := arg.Validate(); err != nil {
+		return nil, err
+	}
+	return u.ITaskService.FindTasksDueBetween(ctx, arg.UserID(), arg.From(), arg.To())
+}
+
 func (u *TaskUsecase) CreateTask(ctx context.Context, arg *dto.CreateTaskParams) (string, error) {
 	if err := arg.Validate(); err != nil {
 		return "", err
 	}
-	return u.ITaskService.CreateTask(ctx, arg.UserID(), html.EscapeString(arg.Name()))
+	return u.ITaskService.CreateTask(ctx, arg.UserID(), arg.ListID(), html.EscapeString(arg.Name()))
+}
+
+func (u *TaskUsecase) FindTasksByListID(ctx context.Context, arg *dto.ListFilterParams) ([]*entity.Task, error) {
+	if err := arg.Validate(); err != nil {
+		return nil, err
+	}
+	return u.ITaskService.FindTasksByListID(ctx, arg.ListID(), arg.UserID(), value.TaskOrder(arg.Order()))
+}
+
+func (u *TaskUsecase) FindTasksByAssigneeID(ctx context.Context, arg *dto.AssignedFilterParams) ([]*entity.Task, error) {
+	if err := arg.Validate(); err != nil {
+		return nil, err
+	}
+	return u.ITaskService.FindTasksByAssigneeID(ctx, arg.UserID(), value.TaskOrder(arg.Order()))
+}
+
+func (u *TaskUsecase) FindTasksByWorkspaceID(ctx context.Context, arg *dto.WorkspaceFilterParams) ([]*entity.Task, error) {
+	if err := arg.Validate(); err != nil {
+		return nil, err
+	}
+	return u.ITaskService.FindTasksByWorkspaceID(ctx, arg.WorkspaceID(), arg.UserID(), value.TaskOrder(arg.Order()))
+}
+
+func (u *TaskUsecase) FindTasksByTags(ctx context.Context, arg *dto.TagFilterParams) ([]*entity.Task, error) {
+	if err := arg.Validate(); err != nil {
+		return nil, err
+	}
+	return u.ITaskService.FindTasksByUserIDAndTags(ctx, arg.UserID(), arg.ListID(), arg.TagIDs(), arg.MatchAll(), value.TaskOrder(arg.Order()))
+}
+
+func (u *TaskUsecase) FindTaskTree(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) ([]*entity.Task, error) {
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+	if err := userID.Validate(); err != nil {
+		return nil, err
+	}
+	return u.ITaskService.FindTaskTree(ctx, id.Value(), userID.Value())
+}
+
+func (u *TaskUsecase) CreateSubtask(ctx context.Context, arg *dto.CreateSubtaskParams) (string, error) {
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	return u.ITaskService.CreateSubtask(ctx, arg.UserID(), arg.ParentID(), html.EscapeString(arg.Name()))
+}
+
+func (u *TaskUsecase) MoveSubtask(ctx context.Context, arg *dto.MoveSubtaskParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	return u.ITaskService.MoveSubtask(ctx, arg.ID(), arg.UserID(), arg.ParentID())
+}
+
+func (u *TaskUsecase) MoveTaskToList(ctx context.Context, arg *dto.MoveTaskToListParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	return u.ITaskService.MoveTaskToList(ctx, arg.ID(), arg.UserID(), arg.ListID())
+}
+
+func (u *TaskUsecase) MoveTask(ctx context.Context, arg *dto.MoveTaskParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	return u.ITaskService.MoveTask(ctx, arg.ID(), arg.UserID(), arg.TargetID(), arg.After())
+}
+
+// 位置のキーが長くなりすぎたリストのタスクを再配置する。バックグラウンドで定期的に実行する
+func (u *TaskUsecase) RebalanceTaskPositions(ctx context.Context) error {
+	return u.ITaskService.RebalanceTaskPositions(ctx)
 }
 
 func (u *TaskUsecase) ChangeTaskName(ctx context.Context, arg *dto.ChangeTaskNameParams) error {
@@ -48,6 +215,42 @@ func (u *TaskUsecase) ChangeTaskName(ctx context.Context, arg *dto.ChangeTaskNam
 	return u.ITaskService.ChangeTaskName(ctx, arg.ID(), arg.UserID(), html.EscapeString(arg.Name()))
 }
 
+func (u *TaskUsecase) ChangeTaskPriority(ctx context.Context, arg *dto.ChangeTaskPriorityParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	return u.ITaskService.ChangeTaskPriority(ctx, arg.ID(), arg.UserID(), value.Priority(arg.Priority()))
+}
+
+func (u *TaskUsecase) ChangeTaskDescription(ctx context.Context, arg *dto.ChangeTaskDescriptionParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	// 原文とは別に表示用のHTMLを生成して保存する
+	descriptionHTML, err := u.IMarkdownRenderer.Render(arg.Description())
+	if err != nil {
+		return &app.ErrInternal{Msg: "failed to render description"}
+	}
+	return u.ITaskService.ChangeTaskDescription(ctx, arg.ID(), arg.UserID(), arg.Description(), descriptionHTML)
+}
+
+func (u *TaskUsecase) AssignTask(ctx context.Context, arg *dto.AssignTaskParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	return u.ITaskService.AssignTask(ctx, arg.ID(), arg.UserID(), arg.AssigneeID())
+}
+
+func (u *TaskUsecase) UnassignTask(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	if err := userID.Validate(); err != nil {
+		return err
+	}
+	return u.ITaskService.UnassignTask(ctx, id.Value(), userID.Value())
+}
+
 func (u *TaskUsecase) CompleteTask(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error {
 	if err := id.Validate(); err != nil {
 		return err
@@ -68,6 +271,61 @@ func (u *TaskUsecase) UncompleteTask(ctx context.Context, id *dto.IDParam, userI
 	return u.ITaskService.UncompleteTask(ctx, id.Value(), userID.Value())
 }
 
+func (u *TaskUsecase) TransitionTask(ctx context.Context, arg *dto.TransitionTaskParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	return u.ITaskService.TransitionTask(ctx, arg.ID(), arg.UserID(), value.TaskStatus(arg.Status()))
+}
+
+func (u *TaskUsecase) SetTaskRecurrence(ctx context.Context, arg *dto.SetTaskRecurrenceParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	return u.ITaskService.SetTaskRecurrence(ctx, arg.ID(), arg.UserID(), value.RecurrenceRule(arg.Rule()), arg.Timezone())
+}
+
+func (u *TaskUsecase) ClearTaskRecurrence(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	if err := userID.Validate(); err != nil {
+		return err
+	}
+	return u.ITaskService.ClearTaskRecurrence(ctx, id.Value(), userID.Value())
+}
+
+func (u *TaskUsecase) SetTaskDueDate(ctx context.Context, arg *dto.SetTaskDueDateParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	return u.ITaskService.SetTaskDueDate(ctx, arg.ID(), arg.UserID(), arg.DueAt())
+}
+
+func (u *TaskUsecase) ClearTaskDueDate(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	if err := userID.Validate(); err != nil {
+		return err
+	}
+	return u.ITaskService.ClearTaskDueDate(ctx, id.Value(), userID.Value())
+}
+
+func (u *TaskUsecase) AddTaskDependency(ctx context.Context, arg *dto.TaskDependencyParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	return u.ITaskService.AddTaskDependency(ctx, arg.TaskID(), arg.UserID(), arg.BlockerID())
+}
+
+func (u *TaskUsecase) RemoveTaskDependency(ctx context.Context, arg *dto.TaskDependencyParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	return u.ITaskService.RemoveTaskDependency(ctx, arg.TaskID(), arg.UserID(), arg.BlockerID())
+}
+
 func (u *TaskUsecase) DeleteTask(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error {
 	if err := id.Validate(); err != nil {
 		return err
@@ -77,3 +335,80 @@ func (u *TaskUsecase) DeleteTask(ctx context.Context, id *dto.IDParam, userID *d
 	}
 	return u.ITaskService.DeleteTask(ctx, id.Value(), userID.Value())
 }
+
+func (u *TaskUsecase) BatchMutateTasks(ctx context.Context, arg *dto.BatchMutateTasksParams) ([]*entity.TaskOperationResult, error) {
+	if err := arg.Validate(); err != nil {
+		return nil, err
+	}
+	ops := make([]value.TaskOperation, len(arg.Operations()))
+	for i, v := range arg.Operations() {
+		ops[i] = value.TaskOperation{
+			Kind:   value.TaskOperationKind(v.Kind()),
+			TaskID: v.TaskID(),
+			ListID: v.ListID(),
+			Name:   html.EscapeString(v.Name()),
+		}
+	}
+	return u.ITaskService.BatchMutateTasks(ctx, arg.UserID(), ops, arg.Atomic())
+}
+
+func (u *TaskUsecase) FindTrashedTasksByUserID(ctx context.Context, userID *dto.IDParam) ([]*entity.Task, error) {
+	if err := userID.Validate(); err != nil {
+		return nil, err
+	}
+	return u.ITaskService.FindTrashedTasksByUserID(ctx, userID.Value())
+}
+
+func (u *TaskUsecase) RestoreTask(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	if err := userID.Validate(); err != nil {
+		return err
+	}
+	return u.ITaskService.RestoreTask(ctx, id.Value(), userID.Value())
+}
+
+func (u *TaskUsecase) EmptyTrash(ctx context.Context, userID *dto.IDParam) error {
+	if err := userID.Validate(); err != nil {
+		return err
+	}
+	return u.ITaskService.EmptyTrash(ctx, userID.Value())
+}
+
+func (u *TaskUsecase) PurgeTrashedTasks(ctx context.Context, retention time.Duration) (int64, error) {
+	return u.ITaskService.PurgeTrashedTasks(ctx, retention)
+}
+
+// タスクの変更履歴を新しい順に取得する。続きがある場合は次のページのトークンを返す
+func (u *TaskUsecase) FindTaskHistory(ctx context.Context, arg *dto.TaskHistoryParams) ([]*entity.TaskHistory, string, error) {
+	if err := arg.Validate(); err != nil {
+		return nil, "", err
+	}
+	cursor, err := value.DecodePageCursor(arg.PageToken())
+	if err != nil {
+		return nil, "", err
+	}
+	histories, next, err := u.ITaskService.FindTaskHistory(ctx, arg.ID(), arg.UserID(), arg.PageSize(), cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	if next == nil {
+		return histories, "", nil
+	}
+	return histories, next.Encode(), nil
+}
+
+func (u *TaskUsecase) Undo(ctx context.Context, userID *dto.IDParam) (*entity.TaskUndoEntry, error) {
+	if err := userID.Validate(); err != nil {
+		return nil, err
+	}
+	return u.ITaskService.Undo(ctx, userID.Value())
+}
+
+func (u *TaskUsecase) Redo(ctx context.Context, userID *dto.IDParam) (*entity.TaskUndoEntry, error) {
+	if err := userID.Validate(); err != nil {
+		return nil, err
+	}
+	return u.ITaskService.Redo(ctx, userID.Value())
+}
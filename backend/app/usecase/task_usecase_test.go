@@ -2,11 +2,13 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
 	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
 	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
 	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
@@ -25,14 +27,15 @@ func TestTaskUsecase_FindTasksByUserID(tt *testing.T) {
 	now := time.Now().UTC()
 	uid := "uid"
 	tasks := []*entity.Task{
-		{ID: value.NewID("t1"), UserID: value.NewID(uid), Name: "task1", IsCompleted: false, CreatedAt: now, UpdatedAt: now},
-		{ID: value.NewID("t2"), UserID: value.NewID(uid), Name: "task2", IsCompleted: false, CreatedAt: now, UpdatedAt: now},
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), Name: "task1", CreatedAt: now, UpdatedAt: now},
+		{ID: value.NewID("t2"), UserID: value.NewID(uid), Name: "task2", CreatedAt: now, UpdatedAt: now},
 	}
 
 	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
 		srv := new(mocks.ITaskService)
 		srv.On("FindTasksByUserID", ctx, uid).Return(tasks, nil)
-		uc := NewTaskUsecase(srv)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
 		ret, err := uc.FindTasksByUserID(ctx, dto.NewIDParam(uid))
 
 		require.NoError(t, err, "エラーが発生しないこと")
@@ -43,7 +46,8 @@ func TestTaskUsecase_FindTasksByUserID(tt *testing.T) {
 		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
 		uid := strings.Repeat("*", 51)
 		srv := new(mocks.ITaskService)
-		uc := NewTaskUsecase(srv)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
 		_, err := uc.FindTasksByUserID(ctx, dto.NewIDParam(uid))
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
@@ -57,10 +61,11 @@ func TestTaskUsecase_CreateTask(tt *testing.T) {
 	uid := "uid"
 
 	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
-		arg := dto.NewCreateTaskParams(uid, "task")
+		arg := dto.NewCreateTaskParams(uid, "lid", "task")
 		srv := new(mocks.ITaskService)
-		srv.On("CreateTask", ctx, uid, arg.Name()).Return(id, nil)
-		uc := NewTaskUsecase(srv)
+		srv.On("CreateTask", ctx, uid, "lid", arg.Name()).Return(id, nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
 		ret, err := uc.CreateTask(ctx, arg)
 
 		require.NoError(t, err, "エラーが発生しないこと")
@@ -69,9 +74,10 @@ func TestTaskUsecase_CreateTask(tt *testing.T) {
 	})
 	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
 		errExp := &app.ErrInputValidationFailed{Msg: "name must be 100 characters or less"}
-		arg := dto.NewCreateTaskParams(uid, strings.Repeat("*", 101))
+		arg := dto.NewCreateTaskParams(uid, "", strings.Repeat("*", 101))
 		srv := new(mocks.ITaskService)
-		uc := NewTaskUsecase(srv)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
 		_, err := uc.CreateTask(ctx, arg)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
@@ -89,7 +95,8 @@ func TestTaskUsecase_ChangeTaskName(tt *testing.T) {
 		arg := dto.NewChangeTaskNameParams(id, uid, "new task")
 		srv := new(mocks.ITaskService)
 		srv.On("ChangeTaskName", ctx, id, uid, arg.Name()).Return(nil)
-		uc := NewTaskUsecase(srv)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
 		err := uc.ChangeTaskName(ctx, arg)
 
 		require.NoError(t, err, "エラーが発生しないこと")
@@ -99,7 +106,8 @@ func TestTaskUsecase_ChangeTaskName(tt *testing.T) {
 		errExp := &app.ErrInputValidationFailed{Msg: "name must be 100 characters or less"}
 		arg := dto.NewChangeTaskNameParams(id, uid, strings.Repeat("*", 101))
 		srv := new(mocks.ITaskService)
-		uc := NewTaskUsecase(srv)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
 		err := uc.ChangeTaskName(ctx, arg)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
@@ -116,7 +124,8 @@ func TestTaskUsecase_DeleteTask(tt *testing.T) {
 	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
 		srv := new(mocks.ITaskService)
 		srv.On("DeleteTask", ctx, id, uid).Return(nil)
-		uc := NewTaskUsecase(srv)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
 		err := uc.DeleteTask(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
 
 		require.NoError(t, err, "エラーが発生しないこと")
@@ -126,7 +135,8 @@ func TestTaskUsecase_DeleteTask(tt *testing.T) {
 		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
 		id := strings.Repeat("*", 101)
 		srv := new(mocks.ITaskService)
-		uc := NewTaskUsecase(srv)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
 		err := uc.DeleteTask(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
@@ -142,7 +152,8 @@ func TestTaskUsecase_CompleteTask(tt *testing.T) {
 	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
 		srv := new(mocks.ITaskService)
 		srv.On("CompleteTask", ctx, id, uid).Return(nil)
-		uc := NewTaskUsecase(srv)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
 		err := uc.CompleteTask(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
 
 		require.NoError(t, err, "エラーが発生しないこと")
@@ -152,7 +163,8 @@ func TestTaskUsecase_CompleteTask(tt *testing.T) {
 		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
 		id := strings.Repeat("*", 101)
 		srv := new(mocks.ITaskService)
-		uc := NewTaskUsecase(srv)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
 		err := uc.CompleteTask(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
@@ -168,7 +180,8 @@ func TestTaskUsecase_UncompleteTask(tt *testing.T) {
 	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
 		srv := new(mocks.ITaskService)
 		srv.On("UncompleteTask", ctx, id, uid).Return(nil)
-		uc := NewTaskUsecase(srv)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
 		err := uc.UncompleteTask(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
 
 		require.NoError(t, err, "エラーが発生しないこと")
@@ -178,10 +191,1069 @@ func TestTaskUsecase_UncompleteTask(tt *testing.T) {
 		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
 		id := strings.Repeat("*", 101)
 		srv := new(mocks.ITaskService)
-		uc := NewTaskUsecase(srv)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
 		err := uc.UncompleteTask(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
 		srv.AssertExpectations(t)
 	})
 }
+
+func TestTaskUsecase_TransitionTask(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("TransitionTask", ctx, id, uid, value.TaskStatusWaiting).Return(nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.TransitionTask(ctx, dto.NewTransitionTaskParams(id, uid, value.TaskStatusWaiting.Value()))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "invalid status"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.TransitionTask(ctx, dto.NewTransitionTaskParams(id, uid, value.TaskStatusUnknown.Value()))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_FindOverdueTasksByUserID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	due := now.Add(-time.Hour)
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), Name: "task1", CreatedAt: now, UpdatedAt: now, DueAt: &due},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("FindOverdueTasksByUserID", ctx, uid).Return(tasks, nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		ret, err := uc.FindOverdueTasksByUserID(ctx, dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.ElementsMatch(t, tasks, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		uid := strings.Repeat("*", 51)
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		_, err := uc.FindOverdueTasksByUserID(ctx, dto.NewIDParam(uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_FindTasksDueBetween(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	from := now
+	to := now.AddDate(0, 0, 7)
+	due := now.AddDate(0, 0, 1)
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), Name: "task1", CreatedAt: now, UpdatedAt: now, DueAt: &due},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("FindTasksDueBetween", ctx, uid, from, to).Return(tasks, nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		ret, err := uc.FindTasksDueBetween(ctx, dto.NewDueRangeParams(uid, from, to))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.ElementsMatch(t, tasks, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "from must be before to"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		_, err := uc.FindTasksDueBetween(ctx, dto.NewDueRangeParams(uid, to, from))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_SetTaskDueDate(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	due := time.Now().UTC().AddDate(0, 0, 1)
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("SetTaskDueDate", ctx, id, uid, due).Return(nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.SetTaskDueDate(ctx, dto.NewSetTaskDueDateParams(id, uid, due))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "due_at is empty"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.SetTaskDueDate(ctx, dto.NewSetTaskDueDateParams(id, uid, time.Time{}))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_ClearTaskDueDate(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("ClearTaskDueDate", ctx, id, uid).Return(nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.ClearTaskDueDate(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		id := strings.Repeat("*", 51)
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.ClearTaskDueDate(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_FindTasksByUserIDOrderByPriority(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), Name: "task1", CreatedAt: now, UpdatedAt: now, Priority: value.PriorityUrgent},
+		{ID: value.NewID("t2"), UserID: value.NewID(uid), Name: "task2", CreatedAt: now, UpdatedAt: now, Priority: value.PriorityLow},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("FindTasksByUserIDOrderByPriority", ctx, uid).Return(tasks, nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		ret, err := uc.FindTasksByUserIDOrderByPriority(ctx, dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tasks, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		uid := strings.Repeat("*", 51)
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		_, err := uc.FindTasksByUserIDOrderByPriority(ctx, dto.NewIDParam(uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_ChangeTaskPriority(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("ChangeTaskPriority", ctx, id, uid, value.PriorityHigh).Return(nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.ChangeTaskPriority(ctx, dto.NewChangeTaskPriorityParams(id, uid, value.PriorityHigh.Value()))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "invalid priority"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.ChangeTaskPriority(ctx, dto.NewChangeTaskPriorityParams(id, uid, value.PriorityUnknown.Value()))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_ChangeTaskDescription(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	source := "**memo**"
+	rendered := "<p><strong>memo</strong></p>\n"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("ChangeTaskDescription", ctx, id, uid, source, rendered).Return(nil)
+		mr := new(mocks.IMarkdownRenderer)
+		mr.On("Render", source).Return(rendered, nil)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.ChangeTaskDescription(ctx, dto.NewChangeTaskDescriptionParams(id, uid, source))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+		mr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "description must be 10000 characters or less"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.ChangeTaskDescription(ctx, dto.NewChangeTaskDescriptionParams(id, uid, strings.Repeat("*", 10001)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+		mr.AssertExpectations(t)
+	})
+	tt.Run("異常系: HTMLへの変換に失敗した場合", func(t *testing.T) {
+		errExp := &app.ErrInternal{Msg: "failed to render description"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		mr.On("Render", source).Return("", errors.New("error"))
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.ChangeTaskDescription(ctx, dto.NewChangeTaskDescriptionParams(id, uid, source))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+		mr.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_FindTaskTree(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID(id), UserID: value.NewID(uid), Name: "parent", CreatedAt: now, UpdatedAt: now},
+		{ID: value.NewID("c1"), UserID: value.NewID(uid), Name: "child", CreatedAt: now, UpdatedAt: now, ParentID: value.NewID(id)},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("FindTaskTree", ctx, id, uid).Return(tasks, nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		ret, err := uc.FindTaskTree(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tasks, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		_, err := uc.FindTaskTree(ctx, dto.NewIDParam(strings.Repeat("*", 51)), dto.NewIDParam(uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_CreateSubtask(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	pid := "pid"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		arg := dto.NewCreateSubtaskParams(uid, pid, "<b>task</b>")
+		srv := new(mocks.ITaskService)
+		srv.On("CreateSubtask", ctx, uid, pid, "&lt;b&gt;task&lt;/b&gt;").Return(id, nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		ret, err := uc.CreateSubtask(ctx, arg)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, id, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "name must be 100 characters or less"}
+		arg := dto.NewCreateSubtaskParams(uid, pid, strings.Repeat("*", 101))
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		_, err := uc.CreateSubtask(ctx, arg)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_MoveSubtask(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	pid := "pid"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("MoveSubtask", ctx, id, uid, pid).Return(nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.MoveSubtask(ctx, dto.NewMoveSubtaskParams(id, uid, pid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.MoveSubtask(ctx, dto.NewMoveSubtaskParams(id, uid, strings.Repeat("*", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_FindTasksByTags(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	tagIDs := []string{"g1", "g2"}
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), Name: "task1", CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("FindTasksByUserIDAndTags", ctx, uid, "", tagIDs, true, value.TaskOrderPriority).Return(tasks, nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		ret, err := uc.FindTasksByTags(ctx, dto.NewTagFilterParams(uid, "", tagIDs, true, 1))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tasks, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		_, err := uc.FindTasksByTags(ctx, dto.NewTagFilterParams(uid, "", []string{strings.Repeat("*", 51)}, false, 0))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_FindTasksByListID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	lid := "lid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), ListID: value.NewID(lid), Name: "task1", CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("FindTasksByListID", ctx, lid, uid, value.TaskOrderPriority).Return(tasks, nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		ret, err := uc.FindTasksByListID(ctx, dto.NewListFilterParams(lid, uid, 1))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tasks, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		_, err := uc.FindTasksByListID(ctx, dto.NewListFilterParams(strings.Repeat("*", 51), uid, 0))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_MoveTaskToList(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	lid := "lid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("MoveTaskToList", ctx, id, uid, lid).Return(nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.MoveTaskToList(ctx, dto.NewMoveTaskToListParams(id, uid, lid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.MoveTaskToList(ctx, dto.NewMoveTaskToListParams(id, uid, strings.Repeat("*", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_FindTasksByUserIDOrderByPosition(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	now := time.Now().UTC()
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task1", CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("FindTasksByUserIDOrderByPosition", ctx, uid).Return(tasks, nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		ret, err := uc.FindTasksByUserIDOrderByPosition(ctx, dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tasks, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		_, err := uc.FindTasksByUserIDOrderByPosition(ctx, dto.NewIDParam(strings.Repeat("*", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_FindTaskPage(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	now := time.Now().UTC()
+	from := now.Add(-time.Hour)
+	var zero time.Time
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "milk &amp; eggs", CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: 条件が値オブジェクトに変換されること", func(t *testing.T) {
+		filter := &value.TaskFilter{Completion: value.TaskCompletionOpen, CreatedFrom: &from, NameContains: "milk &amp;"}
+		sort := value.TaskSort{Field: value.TaskSortFieldName}
+		srv := new(mocks.ITaskService)
+		srv.On("FindTaskPage", ctx, uid, filter, sort, int32(20), (*value.TaskPageCursor)(nil)).Return(tasks, nil, nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		ret, next, err := uc.FindTaskPage(ctx, dto.NewTaskPageParams(uid, 1, from, zero, zero, zero, "milk &", 2, false, 0, ""))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tasks, ret)
+		require.Empty(t, next, "トークンが空であること")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("正常系: トークンを指定した場合は続きを取得すること", func(t *testing.T) {
+		sort := value.TaskSort{Field: value.TaskSortFieldUpdatedAt, Descending: true}
+		cursor := &value.TaskPageCursor{Sort: sort, Time: now, ID: "t0"}
+		nextCursor := &value.TaskPageCursor{Sort: sort, Time: now, ID: "t1"}
+		srv := new(mocks.ITaskService)
+		srv.On("FindTaskPage", ctx, uid, &value.TaskFilter{}, sort, int32(1), cursor).Return(tasks, nextCursor, nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		_, next, err := uc.FindTaskPage(ctx, dto.NewTaskPageParams(uid, 0, zero, zero, zero, zero, "", 0, true, 1, cursor.Encode()))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, nextCursor.Encode(), next, "トークンが一致すること")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "invalid sort field"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		_, _, err := uc.FindTaskPage(ctx, dto.NewTaskPageParams(uid, 0, zero, zero, zero, zero, "", 9, false, 0, ""))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正なトークンの場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "invalid page token"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		_, _, err := uc.FindTaskPage(ctx, dto.NewTaskPageParams(uid, 0, zero, zero, zero, zero, "", 0, false, 0, "***"))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_MoveTask(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	tid := "tid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("MoveTask", ctx, id, uid, tid, true).Return(nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.MoveTask(ctx, dto.NewMoveTaskParams(id, uid, tid, true))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.MoveTask(ctx, dto.NewMoveTaskParams(id, uid, strings.Repeat("*", 51), false))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_RebalanceTaskPositions(tt *testing.T) {
+	ctx := context.Background()
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("RebalanceTaskPositions", ctx).Return(nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.RebalanceTaskPositions(ctx)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_SetTaskRecurrence(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("SetTaskRecurrence", ctx, id, uid, value.RecurrenceRule("FREQ=DAILY"), "Asia/Tokyo").Return(nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.SetTaskRecurrence(ctx, dto.NewSetTaskRecurrenceParams(id, uid, "FREQ=DAILY", "Asia/Tokyo"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("正常系: タイムゾーンが空の場合はUTCになること", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("SetTaskRecurrence", ctx, id, uid, value.RecurrenceRule("FREQ=WEEKLY"), "UTC").Return(nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.SetTaskRecurrence(ctx, dto.NewSetTaskRecurrenceParams(id, uid, "FREQ=WEEKLY", ""))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "rule is empty"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.SetTaskRecurrence(ctx, dto.NewSetTaskRecurrenceParams(id, uid, "", "UTC"))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_ClearTaskRecurrence(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("ClearTaskRecurrence", ctx, id, uid).Return(nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.ClearTaskRecurrence(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		id := strings.Repeat("*", 51)
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.ClearTaskRecurrence(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_AddTaskDependency(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	bid := "bid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("AddTaskDependency", ctx, id, uid, bid).Return(nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.AddTaskDependency(ctx, dto.NewTaskDependencyParams(id, uid, bid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.AddTaskDependency(ctx, dto.NewTaskDependencyParams(id, uid, strings.Repeat("*", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_RemoveTaskDependency(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	bid := "bid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("RemoveTaskDependency", ctx, id, uid, bid).Return(nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.RemoveTaskDependency(ctx, dto.NewTaskDependencyParams(id, uid, bid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.RemoveTaskDependency(ctx, dto.NewTaskDependencyParams(strings.Repeat("*", 51), uid, bid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_FindTrashedTasksByUserID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), Name: "task1", CreatedAt: now, UpdatedAt: now, DeletedAt: &now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("FindTrashedTasksByUserID", ctx, uid).Return(tasks, nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		ret, err := uc.FindTrashedTasksByUserID(ctx, dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.ElementsMatch(t, tasks, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		uid := strings.Repeat("*", 51)
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		_, err := uc.FindTrashedTasksByUserID(ctx, dto.NewIDParam(uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_RestoreTask(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("RestoreTask", ctx, id, uid).Return(nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.RestoreTask(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		id := strings.Repeat("*", 51)
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.RestoreTask(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_EmptyTrash(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("EmptyTrash", ctx, uid).Return(nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.EmptyTrash(ctx, dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		uid := strings.Repeat("*", 51)
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.EmptyTrash(ctx, dto.NewIDParam(uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_PurgeTrashedTasks(tt *testing.T) {
+	ctx := context.Background()
+	retention := 24 * time.Hour
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("PurgeTrashedTasks", ctx, retention).Return(int64(1), nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		n, err := uc.PurgeTrashedTasks(ctx, retention)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, int64(1), n, "削除した件数が一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_FindTaskHistory(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	now := time.Now().UTC()
+	histories := []*entity.TaskHistory{
+		{ID: value.NewID("h1"), TaskID: value.NewID(id), ActorID: value.NewID(uid), Action: value.TaskHistoryActionCreated, NewValue: "task", CreatedAt: now},
+	}
+
+	tt.Run("正常系: 続きがない場合はトークンが空になること", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("FindTaskHistory", ctx, id, uid, int32(20), (*value.PageCursor)(nil)).Return(histories, nil, nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		result, next, err := uc.FindTaskHistory(ctx, dto.NewTaskHistoryParams(id, uid, 0, ""))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, histories, result, "履歴が一致すること")
+		require.Empty(t, next, "トークンが空であること")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("正常系: トークンを指定した場合は続きを取得すること", func(t *testing.T) {
+		cursor := &value.PageCursor{Time: now, ID: "h2"}
+		nextCursor := &value.PageCursor{Time: now.Add(-time.Second), ID: "h1"}
+		srv := new(mocks.ITaskService)
+		srv.On("FindTaskHistory", ctx, id, uid, int32(1), cursor).Return(histories, nextCursor, nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		result, next, err := uc.FindTaskHistory(ctx, dto.NewTaskHistoryParams(id, uid, 1, cursor.Encode()))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, histories, result, "履歴が一致すること")
+		require.Equal(t, nextCursor.Encode(), next, "トークンが一致すること")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "page_size must be 100 or less"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		_, _, err := uc.FindTaskHistory(ctx, dto.NewTaskHistoryParams(id, uid, 101, ""))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正なトークンの場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "invalid page token"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		_, _, err := uc.FindTaskHistory(ctx, dto.NewTaskHistoryParams(id, uid, 20, "!!"))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_FindTasksByAssigneeID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID("owner"), ListID: value.NewID("lid"), Name: "task1", CreatedAt: now, UpdatedAt: now, AssigneeID: value.NewID(uid)},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("FindTasksByAssigneeID", ctx, uid, value.TaskOrderPosition).Return(tasks, nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		ret, err := uc.FindTasksByAssigneeID(ctx, dto.NewAssignedFilterParams(uid, 2))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tasks, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "invalid order"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		_, err := uc.FindTasksByAssigneeID(ctx, dto.NewAssignedFilterParams(uid, 5))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_FindTasksByWorkspaceID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID("owner"), ListID: value.NewID("lid"), Name: "task1", CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("FindTasksByWorkspaceID", ctx, "wid", uid, value.TaskOrderPriority).Return(tasks, nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		ret, err := uc.FindTasksByWorkspaceID(ctx, dto.NewWorkspaceFilterParams("wid", uid, 1))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tasks, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		_, err := uc.FindTasksByWorkspaceID(ctx, dto.NewWorkspaceFilterParams(strings.Repeat("*", 51), uid, 0))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_AssignTask(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	aid := "aid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("AssignTask", ctx, id, uid, aid).Return(nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.AssignTask(ctx, dto.NewAssignTaskParams(id, uid, aid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.AssignTask(ctx, dto.NewAssignTaskParams(id, uid, strings.Repeat("*", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_UnassignTask(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("UnassignTask", ctx, id, uid).Return(nil)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.UnassignTask(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ITaskService)
+		mr := new(mocks.IMarkdownRenderer)
+		uc := NewTaskUsecase(srv, mr)
+		err := uc.UnassignTask(ctx, dto.NewIDParam(strings.Repeat("*", 51)), dto.NewIDParam(uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_BatchMutateTasks(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+
+	tt.Run("正常系: タスク名がエスケープされること", func(t *testing.T) {
+		results := []*entity.TaskOperationResult{
+			{Status: value.TaskOperationStatusSucceeded, TaskID: "created"},
+			{Status: value.TaskOperationStatusSucceeded, TaskID: "tid"},
+		}
+		srv := new(mocks.ITaskService)
+		srv.On("BatchMutateTasks", ctx, uid, []value.TaskOperation{
+			{Kind: value.TaskOperationKindCreate, ListID: "lid", Name: "&lt;b&gt;task&lt;/b&gt;"},
+			{Kind: value.TaskOperationKindComplete, TaskID: "tid"},
+		}, true).Return(results, nil)
+		uc := NewTaskUsecase(srv, new(mocks.IMarkdownRenderer))
+		ret, err := uc.BatchMutateTasks(ctx, dto.NewBatchMutateTasksParams(uid, []*dto.TaskOperationParams{
+			dto.NewTaskOperationParams(0, "", "lid", "<b>task</b>"),
+			dto.NewTaskOperationParams(2, "tid", "", ""),
+		}, true))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, results, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "operations is empty"}
+		srv := new(mocks.ITaskService)
+		uc := NewTaskUsecase(srv, new(mocks.IMarkdownRenderer))
+		_, err := uc.BatchMutateTasks(ctx, dto.NewBatchMutateTasksParams(uid, nil, false))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_Undo(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), TaskID: value.NewID("id"), Action: value.TaskHistoryActionDeleted}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("Undo", ctx, uid).Return(entry, nil)
+		uc := NewTaskUsecase(srv, new(mocks.IMarkdownRenderer))
+		ret, err := uc.Undo(ctx, dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, entry, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ITaskService)
+		uc := NewTaskUsecase(srv, new(mocks.IMarkdownRenderer))
+		_, err := uc.Undo(ctx, dto.NewIDParam(strings.Repeat("*", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTaskUsecase_Redo(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackRedo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionDeleted}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("Redo", ctx, uid).Return(entry, nil)
+		uc := NewTaskUsecase(srv, new(mocks.IMarkdownRenderer))
+		ret, err := uc.Redo(ctx, dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, entry, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.ITaskService)
+		uc := NewTaskUsecase(srv, new(mocks.IMarkdownRenderer))
+		_, err := uc.Redo(ctx, dto.NewIDParam(strings.Repeat("*", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
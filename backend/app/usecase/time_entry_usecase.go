@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/service"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	"github.com/7oh2020/connect-tasklist/backend/util/clock"
+)
+
+// 作業時間の操作
+type ITimeEntryUsecase interface {
+	FindRunningTimer(ctx context.Context, userID *dto.IDParam) (*entity.TimeEntry, error)
+	StartTimer(ctx context.Context, arg *dto.StartTimerParams) (string, error)
+	StopTimer(ctx context.Context, userID *dto.IDParam) (*entity.TimeEntry, error)
+	FindTimeEntries(ctx context.Context, arg *dto.TimeEntryRangeParams) ([]*entity.TimeEntry, error)
+	SummarizeTimeEntries(ctx context.Context, arg *dto.TimeEntryRangeParams) (*entity.TimeSummary, error)
+	ExportTimeEntriesCSV(ctx context.Context, arg *dto.TimeEntryRangeParams) ([]byte, error)
+	CreateTimeEntry(ctx context.Context, arg *dto.CreateTimeEntryParams) (string, error)
+	UpdateTimeEntry(ctx context.Context, arg *dto.UpdateTimeEntryParams) error
+	DeleteTimeEntry(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error
+}
+
+type TimeEntryUsecase struct {
+	service.ITimeEntryService
+	clock.IClockManager
+}
+
+func NewTimeEntryUsecase(srv service.ITimeEntryService, cm clock.IClockManager) *TimeEntryUsecase {
+	return &TimeEntryUsecase{srv, cm}
+}
+
+func (u *TimeEntryUsecase) FindRunningTimer(ctx context.Context, userID *dto.IDParam) (*entity.TimeEntry, error) {
+	if err := userID.Validate(); err != nil {
+		return nil, err
+	}
+	return u.ITimeEntryService.FindRunningTimer(ctx, userID.Value())
+}
+
+func (u *TimeEntryUsecase) StartTimer(ctx context.Context, arg *dto.StartTimerParams) (string, error) {
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	return u.ITimeEntryService.StartTimer(ctx, arg.TaskID(), arg.UserID(), html.EscapeString(arg.Note()))
+}
+
+func (u *TimeEntryUsecase) StopTimer(ctx context.Context, userID *dto.IDParam) (*entity.TimeEntry, error) {
+	if err := userID.Validate(); err != nil {
+		return nil, err
+	}
+	return u.ITimeEntryService.StopTimer(ctx, userID.Value())
+}
+
+func (u *TimeEntryUsecase) FindTimeEntries(ctx context.Context, arg *dto.TimeEntryRangeParams) ([]*entity.TimeEntry, error) {
+	if err := arg.Validate(); err != nil {
+		return nil, err
+	}
+	return u.ITimeEntryService.FindTimeEntries(ctx, arg.UserID(), arg.From(), arg.To())
+}
+
+func (u *TimeEntryUsecase) SummarizeTimeEntries(ctx context.Context, arg *dto.TimeEntryRangeParams) (*entity.TimeSummary, error) {
+	if err := arg.Validate(); err != nil {
+		return nil, err
+	}
+	return u.ITimeEntryService.SummarizeTimeEntries(ctx, arg.UserID(), arg.From(), arg.To(), arg.Timezone())
+}
+
+// 期間と重なる作業時間をCSVで出力する。日時はtimezoneのRFC3339形式で、計測中のタイマーは終了日時を空にして現在日時までの時間を出力する
+func (u *TimeEntryUsecase) ExportTimeEntriesCSV(ctx context.Context, arg *dto.TimeEntryRangeParams) ([]byte, error) {
+	if err := arg.Validate(); err != nil {
+		return nil, err
+	}
+	loc, err := u.IClockManager.LoadLocation(arg.Timezone())
+	if err != nil {
+		return nil, &app.ErrInputValidationFailed{Msg: "invalid timezone"}
+	}
+	entries, err := u.ITimeEntryService.FindTimeEntries(ctx, arg.UserID(), arg.From(), arg.To())
+	if err != nil {
+		return nil, err
+	}
+	now := u.IClockManager.GetNow()
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{"id", "task_id", "task_name", "date", "started_at", "ended_at", "duration_seconds", "note"}); err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		endedAt := ""
+		if e.EndedAt != nil {
+			endedAt = e.EndedAt.In(loc).Format(time.RFC3339)
+		}
+		if err := w.Write([]string{
+			e.ID.Value(),
+			e.TaskID.Value(),
+			toCSVText(e.TaskName),
+			e.StartedAt.In(loc).Format(time.DateOnly),
+			e.StartedAt.In(loc).Format(time.RFC3339),
+			endedAt,
+			strconv.FormatInt(int64(e.Duration(now).Seconds()), 10),
+			toCSVText(e.Note),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (u *TimeEntryUsecase) CreateTimeEntry(ctx context.Context, arg *dto.CreateTimeEntryParams) (string, error) {
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	return u.ITimeEntryService.CreateTimeEntry(ctx, arg.TaskID(), arg.UserID(), arg.StartedAt(), arg.EndedAt(), html.EscapeString(arg.Note()))
+}
+
+func (u *TimeEntryUsecase) UpdateTimeEntry(ctx context.Context, arg *dto.UpdateTimeEntryParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	return u.ITimeEntryService.UpdateTimeEntry(ctx, arg.ID(), arg.UserID(), arg.StartedAt(), arg.EndedAt(), html.EscapeString(arg.Note()))
+}
+
+func (u *TimeEntryUsecase) DeleteTimeEntry(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	if err := userID.Validate(); err != nil {
+		return err
+	}
+	return u.ITimeEntryService.DeleteTimeEntry(ctx, id.Value(), userID.Value())
+}
+
+// 保存時にエスケープした文字列を戻し、表計算ソフトで数式として解釈されないようにする
+func toCSVText(s string) string {
+	return guardCSVFormula(html.UnescapeString(s))
+}
+
+// 表計算ソフトで数式として解釈される文字で始まる場合は先頭に'を付ける
+func guardCSVFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeEntryUsecase_NewTimeEntryUsecase(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ ITimeEntryUsecase = (*TimeEntryUsecase)(nil)
+	})
+}
+
+func TestTimeEntryUsecase_StartTimer(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	tid := "tid"
+	uid := "uid"
+
+	tt.Run("正常系: メモがエスケープされること", func(t *testing.T) {
+		srv := new(mocks.ITimeEntryService)
+		srv.On("StartTimer", ctx, tid, uid, "&lt;b&gt;note&lt;/b&gt;").Return(id, nil)
+		uc := NewTimeEntryUsecase(srv, new(mocks.IClockManager))
+		createdID, err := uc.StartTimer(ctx, dto.NewStartTimerParams(tid, uid, "<b>note</b>"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, id, createdID)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "note must be 255 characters or less"}
+		srv := new(mocks.ITimeEntryService)
+		uc := NewTimeEntryUsecase(srv, new(mocks.IClockManager))
+		_, err := uc.StartTimer(ctx, dto.NewStartTimerParams(tid, uid, strings.Repeat("a", 256)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTimeEntryUsecase_SummarizeTimeEntries(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	summary := &entity.TimeSummary{Total: time.Hour}
+
+	tt.Run("正常系: タイムゾーンを指定しない場合はUTCで集計すること", func(t *testing.T) {
+		srv := new(mocks.ITimeEntryService)
+		srv.On("SummarizeTimeEntries", ctx, uid, from, to, "UTC").Return(summary, nil)
+		uc := NewTimeEntryUsecase(srv, new(mocks.IClockManager))
+		ret, err := uc.SummarizeTimeEntries(ctx, dto.NewTimeEntryRangeParams(uid, from, to, ""))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, summary, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "from must be before to"}
+		srv := new(mocks.ITimeEntryService)
+		uc := NewTimeEntryUsecase(srv, new(mocks.IClockManager))
+		_, err := uc.SummarizeTimeEntries(ctx, dto.NewTimeEntryRangeParams(uid, to, from, ""))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTimeEntryUsecase_ExportTimeEntriesCSV(tt *testing.T) {
+	ctx := context.Background()
+	loc, _ := time.LoadLocation("Asia/Tokyo")
+	uid := "uid"
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, loc)
+	to := from.AddDate(0, 0, 7)
+	now := time.Date(2024, 1, 2, 10, 30, 0, 0, loc)
+	end := time.Date(2024, 1, 1, 10, 0, 0, 0, loc)
+	entries := []*entity.TimeEntry{
+		{ID: value.NewID("e1"), UserID: value.NewID(uid), TaskID: value.NewID("t1"), TaskName: "Tom &amp; Jerry", StartedAt: time.Date(2024, 1, 1, 9, 0, 0, 0, loc), EndedAt: &end, Note: "a, &quot;b&quot;"},
+		{ID: value.NewID("e2"), UserID: value.NewID(uid), TaskID: value.NewID("t2"), TaskName: "=SUM(A1)", StartedAt: time.Date(2024, 1, 2, 10, 0, 0, 0, loc), Note: "-1"},
+	}
+
+	tt.Run("正常系: CSVで出力すること", func(t *testing.T) {
+		srv := new(mocks.ITimeEntryService)
+		srv.On("FindTimeEntries", ctx, uid, from, to).Return(entries, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("LoadLocation", "Asia/Tokyo").Return(loc, nil)
+		cm.On("GetNow").Return(now)
+		uc := NewTimeEntryUsecase(srv, cm)
+		ret, err := uc.ExportTimeEntriesCSV(ctx, dto.NewTimeEntryRangeParams(uid, from, to, "Asia/Tokyo"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, strings.Join([]string{
+			"id,task_id,task_name,date,started_at,ended_at,duration_seconds,note",
+			`e1,t1,Tom & Jerry,2024-01-01,2024-01-01T09:00:00+09:00,2024-01-01T10:00:00+09:00,3600,"a, ""b"""`,
+			"e2,t2,'=SUM(A1),2024-01-02,2024-01-02T10:00:00+09:00,,1800,'-1",
+			"",
+		}, "\n"), string(ret), "エスケープを戻し、数式として解釈されないこと")
+		srv.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: タイムゾーンが不正な場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "invalid timezone"}
+		srv := new(mocks.ITimeEntryService)
+		cm := new(mocks.IClockManager)
+		cm.On("LoadLocation", "Invalid/Zone").Return(nil, errors.New("unknown time zone"))
+		uc := NewTimeEntryUsecase(srv, cm)
+		_, err := uc.ExportTimeEntriesCSV(ctx, dto.NewTimeEntryRangeParams(uid, from, to, "Invalid/Zone"))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "range must be 366 days or less"}
+		srv := new(mocks.ITimeEntryService)
+		uc := NewTimeEntryUsecase(srv, new(mocks.IClockManager))
+		_, err := uc.ExportTimeEntriesCSV(ctx, dto.NewTimeEntryRangeParams(uid, from, from.AddDate(0, 0, 367), ""))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTimeEntryUsecase_UpdateTimeEntry(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	start := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	tt.Run("正常系: メモがエスケープされること", func(t *testing.T) {
+		srv := new(mocks.ITimeEntryService)
+		srv.On("UpdateTimeEntry", ctx, id, uid, start, &end, "a &amp; b").Return(nil)
+		uc := NewTimeEntryUsecase(srv, new(mocks.IClockManager))
+		err := uc.UpdateTimeEntry(ctx, dto.NewUpdateTimeEntryParams(id, uid, start, &end, "a & b"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "started_at is empty"}
+		srv := new(mocks.ITimeEntryService)
+		uc := NewTimeEntryUsecase(srv, new(mocks.IClockManager))
+		err := uc.UpdateTimeEntry(ctx, dto.NewUpdateTimeEntryParams(id, uid, time.Time{}, &end, ""))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"html"
+	"io"
+	"sort"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/domain/service"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	"github.com/7oh2020/connect-tasklist/backend/util/markdown"
+)
+
+// インポートできるファイルの最大サイズ(バイト)
+const maxImportFileSize = 1024 * 1024
+
+// 一度にインポートできる最大行数
+const maxImportRows = 500
+
+// タスクのインポートとエクスポート
+type ITransferUsecase interface {
+	ExportTasks(ctx context.Context, arg *dto.ExportTasksParams, w io.Writer) error
+	ImportTasks(ctx context.Context, arg *dto.ImportTasksParams, r io.Reader) ([]*entity.TaskImportResult, error)
+}
+
+type TransferUsecase struct {
+	service.ITaskService
+	markdown.IMarkdownRenderer
+}
+
+func NewTransferUsecase(srv service.ITaskService, mr markdown.IMarkdownRenderer) *TransferUsecase {
+	return &TransferUsecase{srv, mr}
+}
+
+// リストのタスクを並び順に、リストを指定しない場合は自分の全てのタスクを指定した形式でwに書き込む
+func (u *TransferUsecase) ExportTasks(ctx context.Context, arg *dto.ExportTasksParams, w io.Writer) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	var tasks []*entity.Task
+	var err error
+	if arg.ListID() == "" {
+		tasks, err = u.ITaskService.FindTasksByUserID(ctx, arg.UserID())
+	} else {
+		tasks, err = u.ITaskService.FindTasksByListID(ctx, arg.ListID(), arg.UserID(), value.TaskOrderPosition)
+	}
+	if err != nil {
+		return err
+	}
+	if err := encodeTaskFile(w, value.TaskFileFormat(arg.Format()), tasks); err != nil {
+		return &app.ErrInternal{Msg: "failed to write file"}
+	}
+	return nil
+}
+
+// rを指定した形式で読み込んでタスクを作成し、行番号の順に1行ずつの結果を返す
+// 読み込みや入力の検証に失敗した行は作成せずに失敗として結果に含める
+func (u *TransferUsecase) ImportTasks(ctx context.Context, arg *dto.ImportTasksParams, r io.Reader) ([]*entity.TaskImportResult, error) {
+	if err := arg.Validate(); err != nil {
+		return nil, err
+	}
+	// アップロードの手順の誤りは入力エラー、中断はそのままのエラー、それ以外の読み込みの失敗は内部エラーにする
+	data, err := io.ReadAll(io.LimitReader(r, maxImportFileSize+1))
+	if err != nil {
+		var inputErr *app.ErrInputValidationFailed
+		switch {
+		case errors.As(err, &inputErr):
+			return nil, inputErr
+		case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+			return nil, err
+		default:
+			return nil, &app.ErrInternal{Msg: "failed to read file"}
+		}
+	}
+	if len(data) > maxImportFileSize {
+		return nil, &app.ErrInputValidationFailed{Msg: "file must be 1MB or less"}
+	}
+	rows, err := decodeTaskFile(value.TaskFileFormat(arg.Format()), data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > maxImportRows {
+		return nil, &app.ErrInputValidationFailed{Msg: "file must have 500 rows or less"}
+	}
+
+	var imports []value.TaskImportRow
+	var failed []*entity.TaskImportResult
+	for _, row := range rows {
+		name := html.EscapeString(row.name)
+		descriptionHTML, err := u.renderImportRow(row)
+		if err != nil {
+			failed = append(failed, &entity.TaskImportResult{Line: row.line, Name: name, Status: value.TaskImportStatusFailed, Err: err})
+			continue
+		}
+		imports = append(imports, value.TaskImportRow{
+			Line:            row.line,
+			Name:            name,
+			Status:          row.status,
+			Priority:        row.priority,
+			DueAt:           row.dueAt,
+			Description:     row.description,
+			DescriptionHTML: descriptionHTML,
+		})
+	}
+	results, err := u.ITaskService.ImportTasks(ctx, arg.UserID(), arg.ListID(), imports, arg.DryRun())
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, failed...)
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Line < results[j].Line
+	})
+	return results, nil
+}
+
+// 1行の入力を検証し、説明文から表示用のHTMLを生成する
+func (u *TransferUsecase) renderImportRow(row taskFileRow) (string, error) {
+	if row.err != nil {
+		return "", row.err
+	}
+	if len([]rune(row.name)) > 100 {
+		return "", &app.ErrInputValidationFailed{Msg: "name must be 100 characters or less"}
+	}
+	if len([]rune(row.description)) > 10000 {
+		return "", &app.ErrInputValidationFailed{Msg: "description must be 10000 characters or less"}
+	}
+	if row.description == "" {
+		return "", nil
+	}
+	descriptionHTML, err := u.IMarkdownRenderer.Render(row.description)
+	if err != nil {
+		return "", &app.ErrInternal{Msg: "failed to render description"}
+	}
+	return descriptionHTML, nil
+}
@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferUsecase_NewTransferUsecase(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ ITransferUsecase = (*TransferUsecase)(nil)
+	})
+}
+
+func TestTransferUsecase_ExportTasks(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	lid := "lid"
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	tasks := []*entity.Task{
+		{ID: value.NewID("id"), UserID: value.NewID(uid), Name: "write", Status: value.TaskStatusDone, CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: リストを指定した場合はリストのタスクを出力すること", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("FindTasksByListID", ctx, lid, uid, value.TaskOrderPosition).Return(tasks, nil)
+		uc := NewTransferUsecase(srv, new(mocks.IMarkdownRenderer))
+		var buf bytes.Buffer
+		err := uc.ExportTasks(ctx, dto.NewExportTasksParams(uid, lid, value.TaskFileFormatMarkdown.Value()), &buf)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, "- [x] write\n", buf.String())
+		srv.AssertExpectations(t)
+	})
+	tt.Run("正常系: リストを指定しない場合は自分の全てのタスクを出力すること", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("FindTasksByUserID", ctx, uid).Return(tasks, nil)
+		uc := NewTransferUsecase(srv, new(mocks.IMarkdownRenderer))
+		var buf bytes.Buffer
+		err := uc.ExportTasks(ctx, dto.NewExportTasksParams(uid, "", value.TaskFileFormatTodoTxt.Value()), &buf)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, "x 2024-01-01 2024-01-01 write\n", buf.String())
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: リストが存在しない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "list not found"}
+		srv := new(mocks.ITaskService)
+		srv.On("FindTasksByListID", ctx, lid, uid, value.TaskOrderPosition).Return(nil, errExp)
+		uc := NewTransferUsecase(srv, new(mocks.IMarkdownRenderer))
+		var buf bytes.Buffer
+		err := uc.ExportTasks(ctx, dto.NewExportTasksParams(uid, lid, value.TaskFileFormatJSON.Value()), &buf)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		require.Empty(t, buf.String(), "何も出力しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "invalid file format"}
+		srv := new(mocks.ITaskService)
+		uc := NewTransferUsecase(srv, new(mocks.IMarkdownRenderer))
+		err := uc.ExportTasks(ctx, dto.NewExportTasksParams(uid, lid, -1), &bytes.Buffer{})
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestTransferUsecase_ImportTasks(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	lid := "lid"
+
+	tt.Run("正常系: 名前をエスケープし、失敗した行と合わせて行番号の順に返すこと", func(t *testing.T) {
+		data := `[{"name":"<b>a</b>","description":"**memo**"},{"name":"b","status":"closed"},{"name":"c","priority":"low"}]`
+		rows := []value.TaskImportRow{
+			{Line: 1, Name: "&lt;b&gt;a&lt;/b&gt;", Status: value.TaskStatusTodo, Description: "**memo**", DescriptionHTML: "<p><strong>memo</strong></p>\n"},
+			{Line: 3, Name: "c", Status: value.TaskStatusTodo, Priority: value.PriorityLow},
+		}
+		srv := new(mocks.ITaskService)
+		srv.On("ImportTasks", ctx, uid, lid, rows, true).Return([]*entity.TaskImportResult{
+			{Line: 1, Name: rows[0].Name, Status: value.TaskImportStatusCreated},
+			{Line: 3, Name: "c", Status: value.TaskImportStatusDuplicate},
+		}, nil)
+		mr := new(mocks.IMarkdownRenderer)
+		mr.On("Render", "**memo**").Return("<p><strong>memo</strong></p>\n", nil)
+		uc := NewTransferUsecase(srv, mr)
+		ret, err := uc.ImportTasks(ctx, dto.NewImportTasksParams(uid, lid, value.TaskFileFormatJSON.Value(), true), strings.NewReader(data))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, []*entity.TaskImportResult{
+			{Line: 1, Name: rows[0].Name, Status: value.TaskImportStatusCreated},
+			{Line: 2, Name: "b", Status: value.TaskImportStatusFailed, Err: &app.ErrInputValidationFailed{Msg: "invalid status"}},
+			{Line: 3, Name: "c", Status: value.TaskImportStatusDuplicate},
+		}, ret)
+		srv.AssertExpectations(t)
+		mr.AssertExpectations(t)
+	})
+	tt.Run("正常系: 名前が長すぎる行は失敗にすること", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("ImportTasks", ctx, uid, "", []value.TaskImportRow(nil), false).Return([]*entity.TaskImportResult(nil), nil)
+		uc := NewTransferUsecase(srv, new(mocks.IMarkdownRenderer))
+		ret, err := uc.ImportTasks(ctx, dto.NewImportTasksParams(uid, "", value.TaskFileFormatMarkdown.Value(), false), strings.NewReader("- [ ] "+strings.Repeat("a", 101)))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Len(t, ret, 1)
+		require.Equal(t, value.TaskImportStatusFailed, ret[0].Status)
+		require.EqualError(t, ret[0].Err, "name must be 100 characters or less", "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 説明文の変換に失敗した場合", func(t *testing.T) {
+		srv := new(mocks.ITaskService)
+		srv.On("ImportTasks", ctx, uid, lid, []value.TaskImportRow(nil), false).Return([]*entity.TaskImportResult(nil), nil)
+		mr := new(mocks.IMarkdownRenderer)
+		mr.On("Render", "memo").Return("", errors.New("failed"))
+		uc := NewTransferUsecase(srv, mr)
+		ret, err := uc.ImportTasks(ctx, dto.NewImportTasksParams(uid, lid, value.TaskFileFormatCSV.Value(), false), strings.NewReader("name,description\na,memo\n"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, []*entity.TaskImportResult{
+			{Line: 2, Name: "a", Status: value.TaskImportStatusFailed, Err: &app.ErrInternal{Msg: "failed to render description"}},
+		}, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: リストにインポートできない場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{Msg: "permission denied"}
+		srv := new(mocks.ITaskService)
+		srv.On("ImportTasks", ctx, uid, lid, []value.TaskImportRow{{Line: 1, Name: "a", Status: value.TaskStatusTodo}}, false).Return(nil, errExp)
+		uc := NewTransferUsecase(srv, new(mocks.IMarkdownRenderer))
+		_, err := uc.ImportTasks(ctx, dto.NewImportTasksParams(uid, lid, value.TaskFileFormatTodoTxt.Value(), false), strings.NewReader("a\n"))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+
+	testcases := []struct {
+		title  string
+		format value.TaskFileFormat
+		data   string
+		err    error
+	}{
+		{"準正常系: ファイルが大きすぎる場合", value.TaskFileFormatTodoTxt, strings.Repeat("a", maxImportFileSize+1), errors.New("file must be 1MB or less")},
+		{"準正常系: 行が多すぎる場合", value.TaskFileFormatTodoTxt, strings.Repeat("a\n", maxImportRows+1), errors.New("file must have 500 rows or less")},
+		{"準正常系: ファイルを読み込めない場合", value.TaskFileFormatJSON, "{", errors.New("file must be a JSON array")},
+		{"準正常系: 形式が不正な場合", value.TaskFileFormat(4), "", errors.New("invalid file format")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			srv := new(mocks.ITaskService)
+			uc := NewTransferUsecase(srv, new(mocks.IMarkdownRenderer))
+			_, err := uc.ImportTasks(ctx, dto.NewImportTasksParams(uid, lid, v.format.Value(), false), strings.NewReader(v.data))
+
+			require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			srv.AssertExpectations(t)
+		})
+	}
+
+	readTestcases := []struct {
+		title string
+		err   error
+		exp   error
+	}{
+		{"準正常系: 手順の誤りで読み込めない場合はエラーをそのまま返すこと", &app.ErrInputValidationFailed{Msg: "metadata must be sent only once"}, &app.ErrInputValidationFailed{Msg: "metadata must be sent only once"}},
+		{"準正常系: アップロードが中断された場合はエラーをそのまま返すこと", context.Canceled, context.Canceled},
+		{"準正常系: アップロードがタイムアウトした場合はエラーをそのまま返すこと", context.DeadlineExceeded, context.DeadlineExceeded},
+		{"準正常系: その他の理由で読み込めない場合は内部エラーにすること", errors.New("stream closed"), &app.ErrInternal{Msg: "failed to read file"}},
+	}
+	for _, v := range readTestcases {
+		tt.Run(v.title, func(t *testing.T) {
+			srv := new(mocks.ITaskService)
+			uc := NewTransferUsecase(srv, new(mocks.IMarkdownRenderer))
+			_, err := uc.ImportTasks(ctx, dto.NewImportTasksParams(uid, lid, value.TaskFileFormatJSON.Value(), false), iotest.ErrReader(v.err))
+
+			require.IsType(t, v.exp, err, "エラーの種類が一致すること")
+			require.EqualError(t, err, v.exp.Error(), "エラーが一致すること")
+			srv.AssertExpectations(t)
+		})
+	}
+}
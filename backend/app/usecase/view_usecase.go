@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"html"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/domain/service"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+)
+
+// 保存済みのビューの操作
+type IViewUsecase interface {
+	FindViewsByUserID(ctx context.Context, userID *dto.IDParam) ([]*entity.View, error)
+	CreateView(ctx context.Context, arg *dto.CreateViewParams) (string, error)
+	UpdateView(ctx context.Context, arg *dto.UpdateViewParams) error
+	DeleteView(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error
+	// まとめたタスクと次のページのトークンを返す。続きがない場合のトークンは空
+	ExecuteView(ctx context.Context, arg *dto.ExecuteViewParams) ([]*entity.TaskGroup, string, error)
+}
+
+type ViewUsecase struct {
+	service.IViewService
+}
+
+func NewViewUsecase(srv service.IViewService) *ViewUsecase {
+	return &ViewUsecase{srv}
+}
+
+func (u *ViewUsecase) FindViewsByUserID(ctx context.Context, userID *dto.IDParam) ([]*entity.View, error) {
+	if err := userID.Validate(); err != nil {
+		return nil, err
+	}
+	return u.IViewService.FindViewsByUserID(ctx, userID.Value())
+}
+
+func (u *ViewUsecase) CreateView(ctx context.Context, arg *dto.CreateViewParams) (string, error) {
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	return u.IViewService.CreateView(ctx, arg.UserID(), html.EscapeString(arg.Name()), toViewQuery(arg.Query()))
+}
+
+func (u *ViewUsecase) UpdateView(ctx context.Context, arg *dto.UpdateViewParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	return u.IViewService.UpdateView(ctx, arg.ID(), arg.UserID(), html.EscapeString(arg.Name()), toViewQuery(arg.Query()))
+}
+
+func (u *ViewUsecase) DeleteView(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	if err := userID.Validate(); err != nil {
+		return err
+	}
+	return u.IViewService.DeleteView(ctx, id.Value(), userID.Value())
+}
+
+func (u *ViewUsecase) ExecuteView(ctx context.Context, arg *dto.ExecuteViewParams) ([]*entity.TaskGroup, string, error) {
+	if err := arg.Validate(); err != nil {
+		return nil, "", err
+	}
+	cursor, err := value.DecodeTaskPageCursor(arg.PageToken())
+	if err != nil {
+		return nil, "", err
+	}
+	groups, next, err := u.IViewService.ExecuteView(ctx, arg.ID(), arg.UserID(), arg.Timezone(), arg.PageSize(), cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	if next == nil {
+		return groups, "", nil
+	}
+	return groups, next.Encode(), nil
+}
+
+// 入力された条件を値オブジェクトに変換する
+func toViewQuery(arg *dto.ViewQueryParams) value.ViewQuery {
+	return value.ViewQuery{
+		Completion: value.TaskCompletion(arg.Completion()),
+		Due:        value.ViewPeriod(arg.DuePeriod()),
+		Updated:    value.ViewPeriod(arg.UpdatedPeriod()),
+		// 名前はエスケープして保存しているため、同じようにエスケープして比較する
+		NameContains: html.EscapeString(arg.NameContains()),
+		Sort:         value.TaskSort{Field: value.TaskSortField(arg.SortField()), Descending: arg.Descending()},
+		GroupBy:      value.ViewGroupBy(arg.GroupBy()),
+	}
+}
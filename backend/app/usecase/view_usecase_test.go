@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewUsecase_NewViewUsecase(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ IViewUsecase = (*ViewUsecase)(nil)
+	})
+}
+
+func TestViewUsecase_FindViewsByUserID(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	views := []*entity.View{
+		{ID: value.NewID("builtin-today"), UserID: value.NewID(uid), Name: "Today", IsBuiltin: true},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IViewService)
+		srv.On("FindViewsByUserID", ctx, uid).Return(views, nil)
+		uc := NewViewUsecase(srv)
+		ret, err := uc.FindViewsByUserID(ctx, dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, views, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.IViewService)
+		uc := NewViewUsecase(srv)
+		_, err := uc.FindViewsByUserID(ctx, dto.NewIDParam(strings.Repeat("*", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestViewUsecase_CreateView(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 条件が値オブジェクトに変換されること", func(t *testing.T) {
+		query := value.ViewQuery{
+			Completion:   value.TaskCompletionOpen,
+			Due:          value.ViewPeriodUnset,
+			Updated:      value.ViewPeriodThisWeek,
+			NameContains: "a&amp;b",
+			Sort:         value.TaskSort{Field: value.TaskSortFieldName},
+			GroupBy:      value.ViewGroupByList,
+		}
+		srv := new(mocks.IViewService)
+		srv.On("CreateView", ctx, uid, "x&amp;y", query).Return(id, nil)
+		uc := NewViewUsecase(srv)
+		ret, err := uc.CreateView(ctx, dto.NewCreateViewParams(uid, "x&y", dto.NewViewQueryParams(1, 4, 2, "a&b", 2, false, 3)))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, id, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "invalid due period"}
+		srv := new(mocks.IViewService)
+		uc := NewViewUsecase(srv)
+		_, err := uc.CreateView(ctx, dto.NewCreateViewParams(uid, "view", dto.NewViewQueryParams(0, 9, 0, "", 0, true, 0)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestViewUsecase_UpdateView(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		query := value.ViewQuery{Sort: value.TaskSort{Field: value.TaskSortFieldUpdatedAt, Descending: true}}
+		srv := new(mocks.IViewService)
+		srv.On("UpdateView", ctx, id, uid, "view", query).Return(nil)
+		uc := NewViewUsecase(srv)
+		err := uc.UpdateView(ctx, dto.NewUpdateViewParams(id, uid, "view", dto.NewViewQueryParams(0, 0, 0, "", 0, true, 0)))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "name must be 50 characters or less"}
+		srv := new(mocks.IViewService)
+		uc := NewViewUsecase(srv)
+		err := uc.UpdateView(ctx, dto.NewUpdateViewParams(id, uid, strings.Repeat("*", 51), dto.NewViewQueryParams(0, 0, 0, "", 0, true, 0)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestViewUsecase_DeleteView(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IViewService)
+		srv.On("DeleteView", ctx, id, uid).Return(nil)
+		uc := NewViewUsecase(srv)
+		err := uc.DeleteView(ctx, dto.NewIDParam(id), dto.NewIDParam(uid))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.IViewService)
+		uc := NewViewUsecase(srv)
+		err := uc.DeleteView(ctx, dto.NewIDParam(strings.Repeat("*", 51)), dto.NewIDParam(uid))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestViewUsecase_ExecuteView(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	now := time.Now().UTC()
+	groups := []*entity.TaskGroup{
+		{Key: "", Tasks: []*entity.Task{{ID: value.NewID("t1"), UserID: value.NewID(uid), Name: "task1", CreatedAt: now, UpdatedAt: now}}},
+	}
+
+	tt.Run("正常系: 続きがない場合はトークンが空になること", func(t *testing.T) {
+		srv := new(mocks.IViewService)
+		srv.On("ExecuteView", ctx, id, uid, "UTC", int32(20), (*value.TaskPageCursor)(nil)).Return(groups, nil, nil)
+		uc := NewViewUsecase(srv)
+		ret, next, err := uc.ExecuteView(ctx, dto.NewExecuteViewParams(id, uid, "", 0, ""))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, groups, ret)
+		require.Empty(t, next, "トークンが空であること")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("正常系: トークンを指定した場合は続きを取得すること", func(t *testing.T) {
+		sort := value.TaskSort{Field: value.TaskSortFieldName}
+		cursor := &value.TaskPageCursor{Sort: sort, Name: "a", ID: "t0"}
+		nextCursor := &value.TaskPageCursor{Sort: sort, Name: "task1", ID: "t1"}
+		srv := new(mocks.IViewService)
+		srv.On("ExecuteView", ctx, id, uid, "Asia/Tokyo", int32(1), cursor).Return(groups, nextCursor, nil)
+		uc := NewViewUsecase(srv)
+		_, next, err := uc.ExecuteView(ctx, dto.NewExecuteViewParams(id, uid, "Asia/Tokyo", 1, cursor.Encode()))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, nextCursor.Encode(), next, "トークンが一致すること")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正なトークンの場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "invalid page token"}
+		srv := new(mocks.IViewService)
+		uc := NewViewUsecase(srv)
+		_, _, err := uc.ExecuteView(ctx, dto.NewExecuteViewParams(id, uid, "", 0, "!"))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
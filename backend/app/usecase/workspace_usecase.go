@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"html"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/domain/service"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+)
+
+// ワークスペースとメンバー、招待の操作
+type IWorkspaceUsecase interface {
+	FindWorkspacesByUserID(ctx context.Context, userID *dto.IDParam) ([]*entity.Workspace, error)
+	CreateWorkspace(ctx context.Context, arg *dto.CreateWorkspaceParams) (string, error)
+	DeleteWorkspace(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error
+	FindWorkspaceMembers(ctx context.Context, workspaceID *dto.IDParam, userID *dto.IDParam) ([]*entity.WorkspaceMember, error)
+	ChangeMemberRole(ctx context.Context, arg *dto.ChangeMemberRoleParams) error
+	RemoveMember(ctx context.Context, arg *dto.WorkspaceMemberParams) error
+	FindWorkspaceInvitations(ctx context.Context, workspaceID *dto.IDParam, userID *dto.IDParam) ([]*entity.WorkspaceInvitation, error)
+	FindInvitationsByUserID(ctx context.Context, userID *dto.IDParam) ([]*entity.WorkspaceInvitation, error)
+	InviteMember(ctx context.Context, arg *dto.InviteMemberParams) (string, error)
+	AcceptInvitation(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error
+	DeleteInvitation(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error
+}
+
+type WorkspaceUsecase struct {
+	service.IWorkspaceService
+}
+
+func NewWorkspaceUsecase(srv service.IWorkspaceService) *WorkspaceUsecase {
+	return &WorkspaceUsecase{srv}
+}
+
+func (u *WorkspaceUsecase) FindWorkspacesByUserID(ctx context.Context, userID *dto.IDParam) ([]*entity.Workspace, error) {
+	if err := userID.Validate(); err != nil {
+		return nil, err
+	}
+	return u.IWorkspaceService.FindWorkspacesByUserID(ctx, userID.Value())
+}
+
+func (u *WorkspaceUsecase) CreateWorkspace(ctx context.Context, arg *dto.CreateWorkspaceParams) (string, error) {
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	return u.IWorkspaceService.CreateWorkspace(ctx, arg.UserID(), html.EscapeString(arg.Name()))
+}
+
+func (u *WorkspaceUsecase) DeleteWorkspace(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	if err := userID.Validate(); err != nil {
+		return err
+	}
+	return u.IWorkspaceService.DeleteWorkspace(ctx, id.Value(), userID.Value())
+}
+
+func (u *WorkspaceUsecase) FindWorkspaceMembers(ctx context.Context, workspaceID *dto.IDParam, userID *dto.IDParam) ([]*entity.WorkspaceMember, error) {
+	if err := workspaceID.Validate(); err != nil {
+		return nil, err
+	}
+	if err := userID.Validate(); err != nil {
+		return nil, err
+	}
+	return u.IWorkspaceService.FindWorkspaceMembers(ctx, workspaceID.Value(), userID.Value())
+}
+
+func (u *WorkspaceUsecase) ChangeMemberRole(ctx context.Context, arg *dto.ChangeMemberRoleParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	member := arg.Member()
+	return u.IWorkspaceService.ChangeMemberRole(ctx, member.WorkspaceID(), member.UserID(), member.MemberID(), value.WorkspaceRole(arg.Role()))
+}
+
+func (u *WorkspaceUsecase) RemoveMember(ctx context.Context, arg *dto.WorkspaceMemberParams) error {
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	return u.IWorkspaceService.RemoveMember(ctx, arg.WorkspaceID(), arg.UserID(), arg.MemberID())
+}
+
+func (u *WorkspaceUsecase) FindWorkspaceInvitations(ctx context.Context, workspaceID *dto.IDParam, userID *dto.IDParam) ([]*entity.WorkspaceInvitation, error) {
+	if err := workspaceID.Validate(); err != nil {
+		return nil, err
+	}
+	if err := userID.Validate(); err != nil {
+		return nil, err
+	}
+	return u.IWorkspaceService.FindWorkspaceInvitations(ctx, workspaceID.Value(), userID.Value())
+}
+
+func (u *WorkspaceUsecase) FindInvitationsByUserID(ctx context.Context, userID *dto.IDParam) ([]*entity.WorkspaceInvitation, error) {
+	if err := userID.Validate(); err != nil {
+		return nil, err
+	}
+	return u.IWorkspaceService.FindInvitationsByUserID(ctx, userID.Value())
+}
+
+func (u *WorkspaceUsecase) InviteMember(ctx context.Context, arg *dto.InviteMemberParams) (string, error) {
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	return u.IWorkspaceService.InviteMember(ctx, arg.WorkspaceID(), arg.UserID(), arg.Email(), value.WorkspaceRole(arg.Role()))
+}
+
+func (u *WorkspaceUsecase) AcceptInvitation(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	if err := userID.Validate(); err != nil {
+		return err
+	}
+	return u.IWorkspaceService.AcceptInvitation(ctx, id.Value(), userID.Value())
+}
+
+func (u *WorkspaceUsecase) DeleteInvitation(ctx context.Context, id *dto.IDParam, userID *dto.IDParam) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	if err := userID.Validate(); err != nil {
+		return err
+	}
+	return u.IWorkspaceService.DeleteInvitation(ctx, id.Value(), userID.Value())
+}
@@ -0,0 +1,296 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/dto"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceUsecase_NewWorkspaceUsecase(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ IWorkspaceUsecase = (*WorkspaceUsecase)(nil)
+	})
+}
+
+func TestWorkspaceUsecase_FindWorkspacesByUserID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	workspaces := []*entity.Workspace{
+		{ID: value.NewID("wid"), Name: "team", Role: value.WorkspaceRoleOwner, CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IWorkspaceService)
+		srv.On("FindWorkspacesByUserID", ctx, "uid").Return(workspaces, nil)
+		uc := NewWorkspaceUsecase(srv)
+		ret, err := uc.FindWorkspacesByUserID(ctx, dto.NewIDParam("uid"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, workspaces, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.IWorkspaceService)
+		uc := NewWorkspaceUsecase(srv)
+		_, err := uc.FindWorkspacesByUserID(ctx, dto.NewIDParam(strings.Repeat("*", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceUsecase_CreateWorkspace(tt *testing.T) {
+	ctx := context.Background()
+
+	tt.Run("正常系: 名前がエスケープされること", func(t *testing.T) {
+		srv := new(mocks.IWorkspaceService)
+		srv.On("CreateWorkspace", ctx, "uid", "a&amp;b").Return("wid", nil)
+		uc := NewWorkspaceUsecase(srv)
+		ret, err := uc.CreateWorkspace(ctx, dto.NewCreateWorkspaceParams("uid", "a&b"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, "wid", ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "name must be 100 characters or less"}
+		srv := new(mocks.IWorkspaceService)
+		uc := NewWorkspaceUsecase(srv)
+		_, err := uc.CreateWorkspace(ctx, dto.NewCreateWorkspaceParams("uid", strings.Repeat("*", 101)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceUsecase_DeleteWorkspace(tt *testing.T) {
+	ctx := context.Background()
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IWorkspaceService)
+		srv.On("DeleteWorkspace", ctx, "wid", "uid").Return(nil)
+		uc := NewWorkspaceUsecase(srv)
+		err := uc.DeleteWorkspace(ctx, dto.NewIDParam("wid"), dto.NewIDParam("uid"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.IWorkspaceService)
+		uc := NewWorkspaceUsecase(srv)
+		err := uc.DeleteWorkspace(ctx, dto.NewIDParam(strings.Repeat("*", 51)), dto.NewIDParam("uid"))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceUsecase_FindWorkspaceMembers(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	members := []*entity.WorkspaceMember{
+		{WorkspaceID: value.NewID("wid"), UserID: value.NewID("uid"), Email: "dev@example.com", Role: value.WorkspaceRoleOwner, CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IWorkspaceService)
+		srv.On("FindWorkspaceMembers", ctx, "wid", "uid").Return(members, nil)
+		uc := NewWorkspaceUsecase(srv)
+		ret, err := uc.FindWorkspaceMembers(ctx, dto.NewIDParam("wid"), dto.NewIDParam("uid"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, members, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.IWorkspaceService)
+		uc := NewWorkspaceUsecase(srv)
+		_, err := uc.FindWorkspaceMembers(ctx, dto.NewIDParam("wid"), dto.NewIDParam(strings.Repeat("*", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceUsecase_ChangeMemberRole(tt *testing.T) {
+	ctx := context.Background()
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IWorkspaceService)
+		srv.On("ChangeMemberRole", ctx, "wid", "uid", "mid", value.WorkspaceRoleAdmin).Return(nil)
+		uc := NewWorkspaceUsecase(srv)
+		err := uc.ChangeMemberRole(ctx, dto.NewChangeMemberRoleParams("wid", "uid", "mid", value.WorkspaceRoleAdmin.Value()))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "invalid role"}
+		srv := new(mocks.IWorkspaceService)
+		uc := NewWorkspaceUsecase(srv)
+		err := uc.ChangeMemberRole(ctx, dto.NewChangeMemberRoleParams("wid", "uid", "mid", value.WorkspaceRoleOwner.Value()))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceUsecase_RemoveMember(tt *testing.T) {
+	ctx := context.Background()
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IWorkspaceService)
+		srv.On("RemoveMember", ctx, "wid", "uid", "mid").Return(nil)
+		uc := NewWorkspaceUsecase(srv)
+		err := uc.RemoveMember(ctx, dto.NewWorkspaceMemberParams("wid", "uid", "mid"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.IWorkspaceService)
+		uc := NewWorkspaceUsecase(srv)
+		err := uc.RemoveMember(ctx, dto.NewWorkspaceMemberParams("wid", "uid", strings.Repeat("*", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceUsecase_FindWorkspaceInvitations(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	invitations := []*entity.WorkspaceInvitation{
+		{ID: value.NewID("iid"), WorkspaceID: value.NewID("wid"), Email: value.NewEmail("new@example.com"), Role: value.WorkspaceRoleMember, InvitedBy: value.NewID("uid"), CreatedAt: now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IWorkspaceService)
+		srv.On("FindWorkspaceInvitations", ctx, "wid", "uid").Return(invitations, nil)
+		uc := NewWorkspaceUsecase(srv)
+		ret, err := uc.FindWorkspaceInvitations(ctx, dto.NewIDParam("wid"), dto.NewIDParam("uid"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, invitations, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.IWorkspaceService)
+		uc := NewWorkspaceUsecase(srv)
+		_, err := uc.FindWorkspaceInvitations(ctx, dto.NewIDParam(strings.Repeat("*", 51)), dto.NewIDParam("uid"))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceUsecase_FindInvitationsByUserID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	invitations := []*entity.WorkspaceInvitation{
+		{ID: value.NewID("iid"), WorkspaceID: value.NewID("wid"), WorkspaceName: "team", Email: value.NewEmail("dev@example.com"), Role: value.WorkspaceRoleMember, InvitedBy: value.NewID("owner"), CreatedAt: now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IWorkspaceService)
+		srv.On("FindInvitationsByUserID", ctx, "uid").Return(invitations, nil)
+		uc := NewWorkspaceUsecase(srv)
+		ret, err := uc.FindInvitationsByUserID(ctx, dto.NewIDParam("uid"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, invitations, ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.IWorkspaceService)
+		uc := NewWorkspaceUsecase(srv)
+		_, err := uc.FindInvitationsByUserID(ctx, dto.NewIDParam(strings.Repeat("*", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceUsecase_InviteMember(tt *testing.T) {
+	ctx := context.Background()
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IWorkspaceService)
+		srv.On("InviteMember", ctx, "wid", "uid", "new@example.com", value.WorkspaceRoleMember).Return("iid", nil)
+		uc := NewWorkspaceUsecase(srv)
+		ret, err := uc.InviteMember(ctx, dto.NewInviteMemberParams("wid", "uid", "new@example.com", value.WorkspaceRoleMember.Value()))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, "iid", ret)
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "invalid role"}
+		srv := new(mocks.IWorkspaceService)
+		uc := NewWorkspaceUsecase(srv)
+		_, err := uc.InviteMember(ctx, dto.NewInviteMemberParams("wid", "uid", "new@example.com", 0))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceUsecase_AcceptInvitation(tt *testing.T) {
+	ctx := context.Background()
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IWorkspaceService)
+		srv.On("AcceptInvitation", ctx, "iid", "uid").Return(nil)
+		uc := NewWorkspaceUsecase(srv)
+		err := uc.AcceptInvitation(ctx, dto.NewIDParam("iid"), dto.NewIDParam("uid"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.IWorkspaceService)
+		uc := NewWorkspaceUsecase(srv)
+		err := uc.AcceptInvitation(ctx, dto.NewIDParam(strings.Repeat("*", 51)), dto.NewIDParam("uid"))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceUsecase_DeleteInvitation(tt *testing.T) {
+	ctx := context.Background()
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		srv := new(mocks.IWorkspaceService)
+		srv.On("DeleteInvitation", ctx, "iid", "uid").Return(nil)
+		uc := NewWorkspaceUsecase(srv)
+		err := uc.DeleteInvitation(ctx, dto.NewIDParam("iid"), dto.NewIDParam("uid"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		srv.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &app.ErrInputValidationFailed{Msg: "id must be 50 characters or less"}
+		srv := new(mocks.IWorkspaceService)
+		uc := NewWorkspaceUsecase(srv)
+		err := uc.DeleteInvitation(ctx, dto.NewIDParam("iid"), dto.NewIDParam(strings.Repeat("*", 51)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		srv.AssertExpectations(t)
+	})
+}
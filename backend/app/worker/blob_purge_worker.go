@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app/usecase"
+)
+
+// 削除された添付ファイルの実体をストレージから定期的に削除する
+type BlobPurgeWorker struct {
+	usecase.IAttachmentUsecase
+	interval time.Duration
+}
+
+func NewBlobPurgeWorker(uc usecase.IAttachmentUsecase, interval time.Duration) *BlobPurgeWorker {
+	return &BlobPurgeWorker{uc, interval}
+}
+
+// ctxがキャンセルされるまでinterval毎にストレージからの削除を実行する
+func (w *BlobPurgeWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// 失敗したファイルは削除待ちのまま残り、次の実行で再試行する
+			n, err := w.IAttachmentUsecase.PurgeDeletedBlobs(ctx)
+			if err != nil {
+				log.Printf("failed to purge deleted blobs: %v", err)
+			}
+			if n > 0 {
+				log.Printf("purged %d deleted blobs", n)
+			}
+		}
+	}
+}
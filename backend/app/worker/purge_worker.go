@@ -0,0 +1,42 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app/usecase"
+)
+
+// 保持期間を過ぎたゴミ箱のタスクを定期的に完全に削除する
+type PurgeWorker struct {
+	usecase.ITaskUsecase
+	interval  time.Duration
+	retention time.Duration
+}
+
+func NewPurgeWorker(uc usecase.ITaskUsecase, interval time.Duration, retention time.Duration) *PurgeWorker {
+	return &PurgeWorker{uc, interval, retention}
+}
+
+// ctxがキャンセルされるまでinterval毎に完全削除を実行する
+func (w *PurgeWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// 失敗した場合も次の実行で再試行する
+			n, err := w.ITaskUsecase.PurgeTrashedTasks(ctx, w.retention)
+			if err != nil {
+				log.Printf("failed to purge trashed tasks: %v", err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("purged %d trashed tasks", n)
+			}
+		}
+	}
+}
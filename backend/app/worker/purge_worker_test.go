@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeWorker_NewPurgeWorker(tt *testing.T) {
+	w := NewPurgeWorker(new(mocks.ITaskUsecase), time.Minute, time.Hour)
+	require.NotNil(tt, w)
+}
+
+func TestPurgeWorker_Run(tt *testing.T) {
+	retention := time.Hour
+	testcases := []struct {
+		title string
+		n     int64
+		err   error
+	}{
+		{"正常系: 完全削除が成功した場合", 1, nil},
+		{"準正常系: 完全削除が失敗した場合も実行を続けること", 0, &domain.ErrQueryFailed{}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			uc := new(mocks.ITaskUsecase)
+			// 2回目の実行でキャンセルする
+			uc.On("PurgeTrashedTasks", ctx, retention).Return(v.n, v.err).Once()
+			uc.On("PurgeTrashedTasks", ctx, retention).Return(v.n, v.err).Once().Run(func(mock.Arguments) { cancel() })
+			w := NewPurgeWorker(uc, time.Millisecond, retention)
+			go func() {
+				w.Run(ctx)
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				cancel()
+				t.Fatal("ワーカーが終了すること")
+			}
+			uc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app/usecase"
+)
+
+// タスクの位置のキーを定期的に再配置する
+type RebalanceWorker struct {
+	usecase.ITaskUsecase
+	interval time.Duration
+}
+
+func NewRebalanceWorker(uc usecase.ITaskUsecase, interval time.Duration) *RebalanceWorker {
+	return &RebalanceWorker{uc, interval}
+}
+
+// ctxがキャンセルされるまでinterval毎に再配置を実行する
+func (w *RebalanceWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// 失敗した場合も次の実行で再試行する
+			if err := w.ITaskUsecase.RebalanceTaskPositions(ctx); err != nil {
+				log.Printf("failed to rebalance task positions: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebalanceWorker_NewRebalanceWorker(tt *testing.T) {
+	w := NewRebalanceWorker(new(mocks.ITaskUsecase), time.Minute)
+	require.NotNil(tt, w)
+}
+
+func TestRebalanceWorker_Run(tt *testing.T) {
+	testcases := []struct {
+		title string
+		err   error
+	}{
+		{"正常系: 再配置が成功した場合", nil},
+		{"準正常系: 再配置が失敗した場合も実行を続けること", &domain.ErrQueryFailed{}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			uc := new(mocks.ITaskUsecase)
+			// 2回目の実行でキャンセルする
+			uc.On("RebalanceTaskPositions", ctx).Return(v.err).Once()
+			uc.On("RebalanceTaskPositions", ctx).Return(v.err).Once().Run(func(mock.Arguments) { cancel() })
+			w := NewRebalanceWorker(uc, time.Millisecond)
+			go func() {
+				w.Run(ctx)
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				cancel()
+				t.Fatal("ワーカーが終了すること")
+			}
+			uc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+-- name: FindAttachmentByID :one
+SELECT id, task_id, user_id, file_name, content_type, size, storage_key, created_at
+FROM attachments
+WHERE id = $1
+LIMIT 1;
+
+-- name: FindAttachmentsByTaskID :many
+SELECT id, task_id, user_id, file_name, content_type, size, storage_key, created_at
+FROM attachments
+WHERE task_id = $1
+ORDER BY created_at ASC, id ASC;
+
+-- name: SumAttachmentSizeByUserID :one
+-- ゴミ箱のタスクの添付ファイルも完全に削除されるまでは使用量に含める
+SELECT COALESCE(SUM(size), 0)::BIGINT AS total
+FROM attachments
+WHERE user_id = $1;
+
+-- name: CreateAttachment :one
+INSERT INTO attachments(id, task_id, user_id, file_name, content_type, size, storage_key, created_at)
+VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id;
+
+-- name: DeleteAttachment :exec
+DELETE FROM attachments
+WHERE id = $1;
+
+-- name: FindBlobDeletions :many
+SELECT storage_key
+FROM blob_deletions
+ORDER BY created_at ASC
+LIMIT $1;
+
+-- name: DeleteBlobDeletion :exec
+DELETE FROM blob_deletions
+WHERE storage_key = $1;
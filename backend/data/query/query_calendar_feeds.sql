@@ -0,0 +1,15 @@
+-- name: UpsertCalendarFeed :exec
+-- トークンを発行する。既にフィードがある場合はトークンを置き換える
+INSERT INTO calendar_feeds(user_id, token_hash, created_at)
+VALUES($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET token_hash = EXCLUDED.token_hash, created_at = EXCLUDED.created_at;
+
+-- name: FindCalendarFeedByTokenHash :one
+SELECT user_id, token_hash, created_at
+FROM calendar_feeds
+WHERE token_hash = $1;
+
+-- name: DeleteCalendarFeed :execrows
+DELETE FROM calendar_feeds
+WHERE user_id = $1;
@@ -0,0 +1,71 @@
+-- name: FindTaskRole :one
+-- タスク自身、祖先のタスク、タスクが属するリストのいずれかで共有された権限と、ワークスペースのメンバーの権限のうち最も強いものを取得する。どちらもない場合は0
+-- ワークスペースのメンバーは編集者、管理者は管理者、所有者は所有者の権限を持つ
+WITH RECURSIVE ancestors AS (
+  SELECT tasks.id, tasks.parent_id, tasks.list_id FROM tasks WHERE tasks.id = sqlc.arg(task_id)
+  UNION ALL
+  SELECT t.id, t.parent_id, t.list_id FROM tasks t JOIN ancestors a ON t.id = a.parent_id
+)
+SELECT GREATEST(
+  (SELECT COALESCE(MAX(collaborators.role), 0)
+    FROM collaborators
+    WHERE collaborators.user_id = sqlc.arg(user_id)
+      AND (collaborators.task_id IN (SELECT ancestors.id FROM ancestors)
+        OR collaborators.list_id IN (SELECT ancestors.list_id FROM ancestors))),
+  (SELECT COALESCE(MAX(workspace_members.role + 1), 0)
+    FROM lists
+    JOIN workspace_members ON workspace_members.workspace_id = lists.workspace_id
+    WHERE lists.id IN (SELECT ancestors.list_id FROM ancestors) AND workspace_members.user_id = sqlc.arg(user_id))
+)::SMALLINT AS role;
+
+-- name: FindListRole :one
+-- リストで共有された権限と、ワークスペースのメンバーの権限のうち強い方を取得する。どちらもない場合は0
+SELECT GREATEST(
+  (SELECT COALESCE(MAX(collaborators.role), 0)
+    FROM collaborators
+    WHERE collaborators.list_id = sqlc.arg(list_id) AND collaborators.user_id = sqlc.arg(user_id)),
+  (SELECT COALESCE(MAX(workspace_members.role + 1), 0)
+    FROM lists
+    JOIN workspace_members ON workspace_members.workspace_id = lists.workspace_id
+    WHERE lists.id = sqlc.arg(list_id) AND workspace_members.user_id = sqlc.arg(user_id))
+)::SMALLINT AS role;
+
+-- name: FindCollaboratorsByTaskID :many
+SELECT collaborators.id, collaborators.task_id, collaborators.list_id, collaborators.user_id, users.email, collaborators.role, collaborators.granted_by, collaborators.created_at, collaborators.updated_at
+FROM collaborators
+JOIN users ON users.id = collaborators.user_id
+WHERE collaborators.task_id = $1
+ORDER BY collaborators.created_at ASC, collaborators.id ASC;
+
+-- name: FindCollaboratorsByListID :many
+SELECT collaborators.id, collaborators.task_id, collaborators.list_id, collaborators.user_id, users.email, collaborators.role, collaborators.granted_by, collaborators.created_at, collaborators.updated_at
+FROM collaborators
+JOIN users ON users.id = collaborators.user_id
+WHERE collaborators.list_id = $1
+ORDER BY collaborators.created_at ASC, collaborators.id ASC;
+
+-- name: FindTaskCollaborator :one
+SELECT id, task_id, list_id, user_id, role, granted_by, created_at, updated_at
+FROM collaborators
+WHERE task_id = $1 AND user_id = $2
+LIMIT 1;
+
+-- name: FindListCollaborator :one
+SELECT id, task_id, list_id, user_id, role, granted_by, created_at, updated_at
+FROM collaborators
+WHERE list_id = $1 AND user_id = $2
+LIMIT 1;
+
+-- name: CreateCollaborator :one
+INSERT INTO collaborators(id, task_id, list_id, user_id, role, granted_by, created_at, updated_at)
+VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id;
+
+-- name: UpdateCollaborator :exec
+UPDATE collaborators
+SET role = $2, granted_by = $3, updated_at = $4
+WHERE id = $1;
+
+-- name: DeleteCollaborator :exec
+DELETE FROM collaborators
+WHERE id = $1;
@@ -0,0 +1,25 @@
+-- name: FindCommentByID :one
+SELECT id, task_id, user_id, body, created_at, updated_at, edited_at
+FROM comments
+WHERE id = $1
+LIMIT 1;
+
+-- name: FindCommentsByTaskID :many
+SELECT id, task_id, user_id, body, created_at, updated_at, edited_at
+FROM comments
+WHERE task_id = $1
+ORDER BY created_at ASC, id ASC;
+
+-- name: CreateComment :one
+INSERT INTO comments(id, task_id, user_id, body, created_at, updated_at)
+VALUES($1, $2, $3, $4, $5, $6)
+RETURNING id;
+
+-- name: UpdateComment :exec
+UPDATE comments
+SET body = $2, updated_at = $3, edited_at = $4
+WHERE id = $1;
+
+-- name: DeleteComment :exec
+DELETE FROM comments
+WHERE id = $1;
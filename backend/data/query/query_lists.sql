@@ -0,0 +1,48 @@
+-- name: FindListByID :one
+SELECT id, user_id, name, is_inbox, is_archived, position, created_at, updated_at, workspace_id
+FROM lists
+WHERE id = $1
+LIMIT 1;
+
+-- name: FindInboxByUserID :one
+SELECT id, user_id, name, is_inbox, is_archived, position, created_at, updated_at, workspace_id
+FROM lists
+WHERE user_id = $1 AND is_inbox
+LIMIT 1;
+
+-- name: FindListsByUserID :many
+-- ワークスペースのリストを除いた個人のリストを取得する
+SELECT id, user_id, name, is_inbox, is_archived, position, created_at, updated_at, workspace_id
+FROM lists
+WHERE user_id = $1 AND workspace_id IS NULL
+ORDER BY position ASC, created_at ASC;
+
+-- name: FindListsByWorkspaceID :many
+SELECT id, user_id, name, is_inbox, is_archived, position, created_at, updated_at, workspace_id
+FROM lists
+WHERE workspace_id = $1
+ORDER BY position ASC, created_at ASC;
+
+-- name: FindMaxListPosition :one
+SELECT COALESCE(MAX(position), -1)::INTEGER AS max_position
+FROM lists
+WHERE user_id = $1 AND workspace_id IS NULL;
+
+-- name: FindMaxWorkspaceListPosition :one
+SELECT COALESCE(MAX(position), -1)::INTEGER AS max_position
+FROM lists
+WHERE workspace_id = $1;
+
+-- name: CreateList :one
+INSERT INTO lists(id, user_id, name, is_inbox, is_archived, position, created_at, updated_at, workspace_id)
+VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id;
+
+-- name: UpdateList :exec
+UPDATE lists
+SET name = $2, is_archived = $3, position = $4, updated_at = $5
+WHERE id = $1;
+
+-- name: DeleteList :exec
+DELETE FROM lists
+WHERE id = $1;
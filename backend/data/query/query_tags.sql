@@ -0,0 +1,44 @@
+-- name: FindTagByID :one
+SELECT id, user_id, name, created_at, updated_at
+FROM tags
+WHERE id = $1
+LIMIT 1;
+
+-- name: FindTagByUserIDAndName :one
+SELECT id, user_id, name, created_at, updated_at
+FROM tags
+WHERE user_id = $1 AND name = $2
+LIMIT 1;
+
+-- ゴミ箱のタスクは使用数に含めない
+-- name: FindTagsByUserID :many
+SELECT tags.id, tags.user_id, tags.name, tags.created_at, tags.updated_at, COUNT(tasks.id) AS usage_count
+FROM tags
+LEFT JOIN task_tags ON task_tags.tag_id = tags.id
+LEFT JOIN tasks ON tasks.id = task_tags.task_id AND tasks.deleted_at IS NULL
+WHERE tags.user_id = $1
+GROUP BY tags.id
+ORDER BY tags.name ASC;
+
+-- name: CreateTag :one
+INSERT INTO tags(id, user_id, name, created_at, updated_at)
+VALUES($1, $2, $3, $4, $5)
+RETURNING id;
+
+-- name: UpdateTag :exec
+UPDATE tags
+SET name = $2, updated_at = $3
+WHERE id = $1;
+
+-- name: DeleteTag :exec
+DELETE FROM tags
+WHERE id = $1;
+
+-- name: AttachTag :exec
+INSERT INTO task_tags(task_id, tag_id, created_at)
+VALUES($1, $2, $3)
+ON CONFLICT (task_id, tag_id) DO NOTHING;
+
+-- name: DetachTag :exec
+DELETE FROM task_tags
+WHERE task_id = $1 AND tag_id = $2;
@@ -0,0 +1,13 @@
+-- name: FindTaskHistories :many
+-- 新しい順に履歴を取得する。cursorを指定した場合はその位置より古い履歴を取得する
+SELECT id, task_id, actor_id, action, old_value, new_value, created_at
+FROM task_histories
+WHERE task_id = @task_id
+  AND (sqlc.narg(cursor_time)::TIMESTAMPTZ IS NULL
+    OR (created_at, id) < (sqlc.narg(cursor_time)::TIMESTAMPTZ, sqlc.narg(cursor_id)::VARCHAR))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(max_rows);
+
+-- name: CreateTaskHistory :exec
+INSERT INTO task_histories(id, task_id, actor_id, action, old_value, new_value, created_at)
+VALUES($1, $2, $3, $4, $5, $6, $7);
@@ -0,0 +1,31 @@
+-- name: CreateTaskUndoEntry :exec
+INSERT INTO task_undo_entries(id, user_id, stack, task_id, action, old_value, new_value, next_task_id, created_at)
+VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: PopTaskUndoEntry :one
+-- スタックの最新の操作を削除して返す。期限切れの操作は取り出さない
+DELETE FROM task_undo_entries
+WHERE id = (
+  SELECT e.id FROM task_undo_entries e
+  WHERE e.user_id = @user_id AND e.stack = @stack AND e.created_at > @expired_before
+  ORDER BY e.seq DESC
+  LIMIT 1
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING id, user_id, stack, task_id, action, old_value, new_value, next_task_id, created_at;
+
+-- name: DeleteTaskUndoEntries :exec
+DELETE FROM task_undo_entries
+WHERE user_id = $1 AND stack = $2;
+
+-- name: TrimTaskUndoEntries :exec
+-- 期限切れの操作と、新しい順にmax_entries件を超えた古い操作を削除する
+DELETE FROM task_undo_entries
+WHERE task_undo_entries.user_id = @user_id AND task_undo_entries.stack = @stack
+  AND (task_undo_entries.created_at <= @expired_before
+    OR task_undo_entries.id IN (
+      SELECT e.id FROM task_undo_entries e
+      WHERE e.user_id = @user_id AND e.stack = @stack
+      ORDER BY e.seq DESC
+      OFFSET sqlc.arg(max_entries)::INTEGER
+    ));
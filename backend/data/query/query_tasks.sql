@@ -1,25 +1,489 @@
 -- name: FindTaskByID :one
-SELECT id, user_id, name, is_completed, created_at, updated_at
+SELECT id, user_id, name, created_at, updated_at, due_at, priority, description, description_html, parent_id, list_id, position, recurrence_rule, recurrence_timezone, status, deleted_at, assignee_id
 FROM tasks
-WHERE id = $1
+WHERE id = $1 AND deleted_at IS NULL
 LIMIT 1;
 
 -- name: FindTasksByUserID :many
-SELECT id, user_id, name, is_completed, created_at, updated_at
+SELECT id, user_id, name, created_at, updated_at, due_at, priority, description, description_html, parent_id, list_id, position, recurrence_rule, recurrence_timezone, status, deleted_at, assignee_id
 FROM tasks
-WHERE user_id = $1
+WHERE tasks.user_id = $1 AND tasks.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM lists WHERE lists.id = tasks.list_id AND (lists.is_archived OR lists.workspace_id IS NOT NULL))
 ORDER BY updated_at DESC;
 
+-- name: FindTasksByUserIDOrderByPriority :many
+SELECT id, user_id, name, created_at, updated_at, due_at, priority, description, description_html, parent_id, list_id, position, recurrence_rule, recurrence_timezone, status, deleted_at, assignee_id
+FROM tasks
+WHERE tasks.user_id = $1 AND tasks.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM lists WHERE lists.id = tasks.list_id AND (lists.is_archived OR lists.workspace_id IS NOT NULL))
+ORDER BY priority DESC, updated_at DESC;
+
+-- name: FindTasksByUserIDOrderByPosition :many
+-- リストの表示順、リスト内の手動の並び順にタスクを取得する
+SELECT tasks.id, tasks.user_id, tasks.name, tasks.created_at, tasks.updated_at, tasks.due_at, tasks.priority, tasks.description, tasks.description_html, tasks.parent_id, tasks.list_id, tasks.position, tasks.recurrence_rule, tasks.recurrence_timezone, tasks.status, tasks.deleted_at, tasks.assignee_id
+FROM tasks
+JOIN lists ON lists.id = tasks.list_id
+WHERE tasks.user_id = $1 AND tasks.deleted_at IS NULL AND NOT lists.is_archived AND lists.workspace_id IS NULL
+ORDER BY lists.position, lists.created_at, tasks.position, tasks.updated_at DESC;
+
+-- name: FindTaskPageOrderByUpdatedAtAsc :many
+-- 個人のタスクを絞り込み、1ページ分を取得する。ワークスペースとアーカイブされたリストのタスクは除外する
+-- completion: 0=全て, 1=未完了(未着手、進行中、待機中), 2=完了または中止。name_patternはILIKEのパターン
+-- cursorを指定した場合はその位置より後のタスクを取得する。FindTaskPageOrderBy*は並び替えのみ異なる
+-- 更新日時の昇順、同じ場合はIDの昇順
+SELECT id, user_id, name, created_at, updated_at, due_at, priority, description, description_html, parent_id, list_id, position, recurrence_rule, recurrence_timezone, status, deleted_at, assignee_id
+FROM tasks
+WHERE tasks.user_id = @user_id AND tasks.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM lists WHERE lists.id = tasks.list_id AND (lists.is_archived OR lists.workspace_id IS NOT NULL))
+  AND (@completion::INTEGER = 0 OR (@completion::INTEGER = 1 AND tasks.status NOT IN (3, 4)) OR (@completion::INTEGER = 2 AND tasks.status IN (3, 4)))
+  AND (sqlc.narg(created_from)::TIMESTAMPTZ IS NULL OR tasks.created_at >= sqlc.narg(created_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(created_to)::TIMESTAMPTZ IS NULL OR tasks.created_at < sqlc.narg(created_to)::TIMESTAMPTZ)
+  AND (sqlc.narg(updated_from)::TIMESTAMPTZ IS NULL OR tasks.updated_at >= sqlc.narg(updated_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(updated_to)::TIMESTAMPTZ IS NULL OR tasks.updated_at < sqlc.narg(updated_to)::TIMESTAMPTZ)
+  AND (sqlc.narg(due_from)::TIMESTAMPTZ IS NULL OR tasks.due_at >= sqlc.narg(due_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(due_to)::TIMESTAMPTZ IS NULL OR tasks.due_at < sqlc.narg(due_to)::TIMESTAMPTZ)
+  AND (NOT @no_due_date::BOOLEAN OR tasks.due_at IS NULL)
+  AND tasks.name ILIKE @name_pattern::TEXT
+  AND (sqlc.narg(cursor_id)::VARCHAR IS NULL OR (tasks.updated_at, tasks.id) > (sqlc.narg(cursor_time)::TIMESTAMPTZ, sqlc.narg(cursor_id)::VARCHAR))
+ORDER BY tasks.updated_at, tasks.id
+LIMIT sqlc.arg(max_rows);
+
+-- name: FindTaskPageOrderByUpdatedAtDesc :many
+-- 更新日時の降順、同じ場合はIDの降順
+SELECT id, user_id, name, created_at, updated_at, due_at, priority, description, description_html, parent_id, list_id, position, recurrence_rule, recurrence_timezone, status, deleted_at, assignee_id
+FROM tasks
+WHERE tasks.user_id = @user_id AND tasks.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM lists WHERE lists.id = tasks.list_id AND (lists.is_archived OR lists.workspace_id IS NOT NULL))
+  AND (@completion::INTEGER = 0 OR (@completion::INTEGER = 1 AND tasks.status NOT IN (3, 4)) OR (@completion::INTEGER = 2 AND tasks.status IN (3, 4)))
+  AND (sqlc.narg(created_from)::TIMESTAMPTZ IS NULL OR tasks.created_at >= sqlc.narg(created_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(created_to)::TIMESTAMPTZ IS NULL OR tasks.created_at < sqlc.narg(created_to)::TIMESTAMPTZ)
+  AND (sqlc.narg(updated_from)::TIMESTAMPTZ IS NULL OR tasks.updated_at >= sqlc.narg(updated_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(updated_to)::TIMESTAMPTZ IS NULL OR tasks.updated_at < sqlc.narg(updated_to)::TIMESTAMPTZ)
+  AND (sqlc.narg(due_from)::TIMESTAMPTZ IS NULL OR tasks.due_at >= sqlc.narg(due_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(due_to)::TIMESTAMPTZ IS NULL OR tasks.due_at < sqlc.narg(due_to)::TIMESTAMPTZ)
+  AND (NOT @no_due_date::BOOLEAN OR tasks.due_at IS NULL)
+  AND tasks.name ILIKE @name_pattern::TEXT
+  AND (sqlc.narg(cursor_id)::VARCHAR IS NULL OR (tasks.updated_at, tasks.id) < (sqlc.narg(cursor_time)::TIMESTAMPTZ, sqlc.narg(cursor_id)::VARCHAR))
+ORDER BY tasks.updated_at DESC, tasks.id DESC
+LIMIT sqlc.arg(max_rows);
+
+-- name: FindTaskPageOrderByCreatedAtAsc :many
+-- 作成日時の昇順、同じ場合はIDの昇順
+SELECT id, user_id, name, created_at, updated_at, due_at, priority, description, description_html, parent_id, list_id, position, recurrence_rule, recurrence_timezone, status, deleted_at, assignee_id
+FROM tasks
+WHERE tasks.user_id = @user_id AND tasks.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM lists WHERE lists.id = tasks.list_id AND (lists.is_archived OR lists.workspace_id IS NOT NULL))
+  AND (@completion::INTEGER = 0 OR (@completion::INTEGER = 1 AND tasks.status NOT IN (3, 4)) OR (@completion::INTEGER = 2 AND tasks.status IN (3, 4)))
+  AND (sqlc.narg(created_from)::TIMESTAMPTZ IS NULL OR tasks.created_at >= sqlc.narg(created_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(created_to)::TIMESTAMPTZ IS NULL OR tasks.created_at < sqlc.narg(created_to)::TIMESTAMPTZ)
+  AND (sqlc.narg(updated_from)::TIMESTAMPTZ IS NULL OR tasks.updated_at >= sqlc.narg(updated_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(updated_to)::TIMESTAMPTZ IS NULL OR tasks.updated_at < sqlc.narg(updated_to)::TIMESTAMPTZ)
+  AND (sqlc.narg(due_from)::TIMESTAMPTZ IS NULL OR tasks.due_at >= sqlc.narg(due_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(due_to)::TIMESTAMPTZ IS NULL OR tasks.due_at < sqlc.narg(due_to)::TIMESTAMPTZ)
+  AND (NOT @no_due_date::BOOLEAN OR tasks.due_at IS NULL)
+  AND tasks.name ILIKE @name_pattern::TEXT
+  AND (sqlc.narg(cursor_id)::VARCHAR IS NULL OR (tasks.created_at, tasks.id) > (sqlc.narg(cursor_time)::TIMESTAMPTZ, sqlc.narg(cursor_id)::VARCHAR))
+ORDER BY tasks.created_at, tasks.id
+LIMIT sqlc.arg(max_rows);
+
+-- name: FindTaskPageOrderByCreatedAtDesc :many
+-- 作成日時の降順、同じ場合はIDの降順
+SELECT id, user_id, name, created_at, updated_at, due_at, priority, description, description_html, parent_id, list_id, position, recurrence_rule, recurrence_timezone, status, deleted_at, assignee_id
+FROM tasks
+WHERE tasks.user_id = @user_id AND tasks.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM lists WHERE lists.id = tasks.list_id AND (lists.is_archived OR lists.workspace_id IS NOT NULL))
+  AND (@completion::INTEGER = 0 OR (@completion::INTEGER = 1 AND tasks.status NOT IN (3, 4)) OR (@completion::INTEGER = 2 AND tasks.status IN (3, 4)))
+  AND (sqlc.narg(created_from)::TIMESTAMPTZ IS NULL OR tasks.created_at >= sqlc.narg(created_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(created_to)::TIMESTAMPTZ IS NULL OR tasks.created_at < sqlc.narg(created_to)::TIMESTAMPTZ)
+  AND (sqlc.narg(updated_from)::TIMESTAMPTZ IS NULL OR tasks.updated_at >= sqlc.narg(updated_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(updated_to)::TIMESTAMPTZ IS NULL OR tasks.updated_at < sqlc.narg(updated_to)::TIMESTAMPTZ)
+  AND (sqlc.narg(due_from)::TIMESTAMPTZ IS NULL OR tasks.due_at >= sqlc.narg(due_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(due_to)::TIMESTAMPTZ IS NULL OR tasks.due_at < sqlc.narg(due_to)::TIMESTAMPTZ)
+  AND (NOT @no_due_date::BOOLEAN OR tasks.due_at IS NULL)
+  AND tasks.name ILIKE @name_pattern::TEXT
+  AND (sqlc.narg(cursor_id)::VARCHAR IS NULL OR (tasks.created_at, tasks.id) < (sqlc.narg(cursor_time)::TIMESTAMPTZ, sqlc.narg(cursor_id)::VARCHAR))
+ORDER BY tasks.created_at DESC, tasks.id DESC
+LIMIT sqlc.arg(max_rows);
+
+-- name: FindTaskPageOrderByNameAsc :many
+-- 名前の昇順、同じ場合はIDの昇順
+SELECT id, user_id, name, created_at, updated_at, due_at, priority, description, description_html, parent_id, list_id, position, recurrence_rule, recurrence_timezone, status, deleted_at, assignee_id
+FROM tasks
+WHERE tasks.user_id = @user_id AND tasks.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM lists WHERE lists.id = tasks.list_id AND (lists.is_archived OR lists.workspace_id IS NOT NULL))
+  AND (@completion::INTEGER = 0 OR (@completion::INTEGER = 1 AND tasks.status NOT IN (3, 4)) OR (@completion::INTEGER = 2 AND tasks.status IN (3, 4)))
+  AND (sqlc.narg(created_from)::TIMESTAMPTZ IS NULL OR tasks.created_at >= sqlc.narg(created_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(created_to)::TIMESTAMPTZ IS NULL OR tasks.created_at < sqlc.narg(created_to)::TIMESTAMPTZ)
+  AND (sqlc.narg(updated_from)::TIMESTAMPTZ IS NULL OR tasks.updated_at >= sqlc.narg(updated_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(updated_to)::TIMESTAMPTZ IS NULL OR tasks.updated_at < sqlc.narg(updated_to)::TIMESTAMPTZ)
+  AND (sqlc.narg(due_from)::TIMESTAMPTZ IS NULL OR tasks.due_at >= sqlc.narg(due_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(due_to)::TIMESTAMPTZ IS NULL OR tasks.due_at < sqlc.narg(due_to)::TIMESTAMPTZ)
+  AND (NOT @no_due_date::BOOLEAN OR tasks.due_at IS NULL)
+  AND tasks.name ILIKE @name_pattern::TEXT
+  AND (sqlc.narg(cursor_id)::VARCHAR IS NULL OR (tasks.name, tasks.id) > (sqlc.narg(cursor_name)::VARCHAR, sqlc.narg(cursor_id)::VARCHAR))
+ORDER BY tasks.name, tasks.id
+LIMIT sqlc.arg(max_rows);
+
+-- name: FindTaskPageOrderByNameDesc :many
+-- 名前の降順、同じ場合はIDの降順
+SELECT id, user_id, name, created_at, updated_at, due_at, priority, description, description_html, parent_id, list_id, position, recurrence_rule, recurrence_timezone, status, deleted_at, assignee_id
+FROM tasks
+WHERE tasks.user_id = @user_id AND tasks.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM lists WHERE lists.id = tasks.list_id AND (lists.is_archived OR lists.workspace_id IS NOT NULL))
+  AND (@completion::INTEGER = 0 OR (@completion::INTEGER = 1 AND tasks.status NOT IN (3, 4)) OR (@completion::INTEGER = 2 AND tasks.status IN (3, 4)))
+  AND (sqlc.narg(created_from)::TIMESTAMPTZ IS NULL OR tasks.created_at >= sqlc.narg(created_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(created_to)::TIMESTAMPTZ IS NULL OR tasks.created_at < sqlc.narg(created_to)::TIMESTAMPTZ)
+  AND (sqlc.narg(updated_from)::TIMESTAMPTZ IS NULL OR tasks.updated_at >= sqlc.narg(updated_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(updated_to)::TIMESTAMPTZ IS NULL OR tasks.updated_at < sqlc.narg(updated_to)::TIMESTAMPTZ)
+  AND (sqlc.narg(due_from)::TIMESTAMPTZ IS NULL OR tasks.due_at >= sqlc.narg(due_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(due_to)::TIMESTAMPTZ IS NULL OR tasks.due_at < sqlc.narg(due_to)::TIMESTAMPTZ)
+  AND (NOT @no_due_date::BOOLEAN OR tasks.due_at IS NULL)
+  AND tasks.name ILIKE @name_pattern::TEXT
+  AND (sqlc.narg(cursor_id)::VARCHAR IS NULL OR (tasks.name, tasks.id) < (sqlc.narg(cursor_name)::VARCHAR, sqlc.narg(cursor_id)::VARCHAR))
+ORDER BY tasks.name DESC, tasks.id DESC
+LIMIT sqlc.arg(max_rows);
+
+-- name: FindTaskPageOrderByPriorityAsc :many
+-- 優先度の昇順、同じ場合はIDの昇順
+SELECT id, user_id, name, created_at, updated_at, due_at, priority, description, description_html, parent_id, list_id, position, recurrence_rule, recurrence_timezone, status, deleted_at, assignee_id
+FROM tasks
+WHERE tasks.user_id = @user_id AND tasks.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM lists WHERE lists.id = tasks.list_id AND (lists.is_archived OR lists.workspace_id IS NOT NULL))
+  AND (@completion::INTEGER = 0 OR (@completion::INTEGER = 1 AND tasks.status NOT IN (3, 4)) OR (@completion::INTEGER = 2 AND tasks.status IN (3, 4)))
+  AND (sqlc.narg(created_from)::TIMESTAMPTZ IS NULL OR tasks.created_at >= sqlc.narg(created_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(created_to)::TIMESTAMPTZ IS NULL OR tasks.created_at < sqlc.narg(created_to)::TIMESTAMPTZ)
+  AND (sqlc.narg(updated_from)::TIMESTAMPTZ IS NULL OR tasks.updated_at >= sqlc.narg(updated_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(updated_to)::TIMESTAMPTZ IS NULL OR tasks.updated_at < sqlc.narg(updated_to)::TIMESTAMPTZ)
+  AND (sqlc.narg(due_from)::TIMESTAMPTZ IS NULL OR tasks.due_at >= sqlc.narg(due_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(due_to)::TIMESTAMPTZ IS NULL OR tasks.due_at < sqlc.narg(due_to)::TIMESTAMPTZ)
+  AND (NOT @no_due_date::BOOLEAN OR tasks.due_at IS NULL)
+  AND tasks.name ILIKE @name_pattern::TEXT
+  AND (sqlc.narg(cursor_id)::VARCHAR IS NULL OR (tasks.priority, tasks.id) > (sqlc.narg(cursor_priority)::SMALLINT, sqlc.narg(cursor_id)::VARCHAR))
+ORDER BY tasks.priority, tasks.id
+LIMIT sqlc.arg(max_rows);
+
+-- name: FindTaskPageOrderByPriorityDesc :many
+-- 優先度の降順、同じ場合はIDの降順
+SELECT id, user_id, name, created_at, updated_at, due_at, priority, description, description_html, parent_id, list_id, position, recurrence_rule, recurrence_timezone, status, deleted_at, assignee_id
+FROM tasks
+WHERE tasks.user_id = @user_id AND tasks.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM lists WHERE lists.id = tasks.list_id AND (lists.is_archived OR lists.workspace_id IS NOT NULL))
+  AND (@completion::INTEGER = 0 OR (@completion::INTEGER = 1 AND tasks.status NOT IN (3, 4)) OR (@completion::INTEGER = 2 AND tasks.status IN (3, 4)))
+  AND (sqlc.narg(created_from)::TIMESTAMPTZ IS NULL OR tasks.created_at >= sqlc.narg(created_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(created_to)::TIMESTAMPTZ IS NULL OR tasks.created_at < sqlc.narg(created_to)::TIMESTAMPTZ)
+  AND (sqlc.narg(updated_from)::TIMESTAMPTZ IS NULL OR tasks.updated_at >= sqlc.narg(updated_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(updated_to)::TIMESTAMPTZ IS NULL OR tasks.updated_at < sqlc.narg(updated_to)::TIMESTAMPTZ)
+  AND (sqlc.narg(due_from)::TIMESTAMPTZ IS NULL OR tasks.due_at >= sqlc.narg(due_from)::TIMESTAMPTZ)
+  AND (sqlc.narg(due_to)::TIMESTAMPTZ IS NULL OR tasks.due_at < sqlc.narg(due_to)::TIMESTAMPTZ)
+  AND (NOT @no_due_date::BOOLEAN OR tasks.due_at IS NULL)
+  AND tasks.name ILIKE @name_pattern::TEXT
+  AND (sqlc.narg(cursor_id)::VARCHAR IS NULL OR (tasks.priority, tasks.id) < (sqlc.narg(cursor_priority)::SMALLINT, sqlc.narg(cursor_id)::VARCHAR))
+ORDER BY tasks.priority DESC, tasks.id DESC
+LIMIT sqlc.arg(max_rows);
+
+-- name: FindTasksByListID :many
+-- sort_order: 0=更新日時の新しい順, 1=優先度の高い順, 2=手動の並び順
+SELECT id, user_id, name, created_at, updated_at, due_at, priority, description, description_html, parent_id, list_id, position, recurrence_rule, recurrence_timezone, status, deleted_at, assignee_id
+FROM tasks
+WHERE list_id = @list_id AND deleted_at IS NULL
+ORDER BY CASE WHEN @sort_order::INTEGER = 1 THEN priority ELSE 0 END DESC,
+  CASE WHEN @sort_order::INTEGER = 2 THEN position ELSE '' END,
+  updated_at DESC;
+
+-- name: FindTasksByAssigneeID :many
+-- 担当するタスクのうち、所有しているか、リストまたはタスク自身か祖先のタスクが共有されているか、ワークスペースのメンバーであるタスクを取得する
+-- sort_order: 0=更新日時の新しい順, 1=優先度の高い順, 2=手動の並び順
+WITH RECURSIVE chain AS (
+  SELECT tasks.id AS task_id, tasks.id AS ancestor_id, tasks.parent_id
+  FROM tasks WHERE tasks.assignee_id = @assignee_id AND tasks.deleted_at IS NULL
+  UNION ALL
+  SELECT chain.task_id, t.id, t.parent_id FROM tasks t JOIN chain ON t.id = chain.parent_id
+)
+SELECT tasks.id, tasks.user_id, tasks.name, tasks.created_at, tasks.updated_at, tasks.due_at, tasks.priority, tasks.description, tasks.description_html, tasks.parent_id, tasks.list_id, tasks.position, tasks.recurrence_rule, tasks.recurrence_timezone, tasks.status, tasks.deleted_at, tasks.assignee_id
+FROM tasks
+JOIN lists ON lists.id = tasks.list_id
+WHERE tasks.assignee_id = @assignee_id AND tasks.deleted_at IS NULL AND NOT lists.is_archived
+  AND (
+    tasks.user_id = @assignee_id
+    OR EXISTS (SELECT 1 FROM collaborators WHERE collaborators.list_id = tasks.list_id AND collaborators.user_id = @assignee_id)
+    OR EXISTS (SELECT 1 FROM workspace_members WHERE workspace_members.workspace_id = lists.workspace_id AND workspace_members.user_id = @assignee_id)
+    OR EXISTS (
+      SELECT 1 FROM chain JOIN collaborators ON collaborators.task_id = chain.ancestor_id
+      WHERE chain.task_id = tasks.id AND collaborators.user_id = @assignee_id
+    )
+  )
+ORDER BY CASE WHEN @sort_order::INTEGER = 1 THEN tasks.priority ELSE 0 END DESC,
+  CASE WHEN @sort_order::INTEGER = 2 THEN lists.position ELSE 0 END,
+  CASE WHEN @sort_order::INTEGER = 2 THEN tasks.position ELSE '' END,
+  tasks.updated_at DESC;
+
+-- name: FindTasksByWorkspaceID :many
+-- ワークスペースの全てのリストのタスクを取得する。アーカイブされたリストのタスクは除外する
+-- sort_order: 0=更新日時の新しい順, 1=優先度の高い順, 2=手動の並び順
+SELECT tasks.id, tasks.user_id, tasks.name, tasks.created_at, tasks.updated_at, tasks.due_at, tasks.priority, tasks.description, tasks.description_html, tasks.parent_id, tasks.list_id, tasks.position, tasks.recurrence_rule, tasks.recurrence_timezone, tasks.status, tasks.deleted_at, tasks.assignee_id
+FROM tasks
+JOIN lists ON lists.id = tasks.list_id
+WHERE lists.workspace_id = @workspace_id AND tasks.deleted_at IS NULL AND NOT lists.is_archived
+ORDER BY CASE WHEN @sort_order::INTEGER = 1 THEN tasks.priority ELSE 0 END DESC,
+  CASE WHEN @sort_order::INTEGER = 2 THEN lists.position ELSE 0 END,
+  CASE WHEN @sort_order::INTEGER = 2 THEN tasks.position ELSE '' END,
+  tasks.updated_at DESC;
+
+-- name: FindOverdueTasksByUserID :many
+SELECT id, user_id, name, created_at, updated_at, due_at, priority, description, description_html, parent_id, list_id, position, recurrence_rule, recurrence_timezone, status, deleted_at, assignee_id
+FROM tasks
+WHERE tasks.user_id = @user_id AND tasks.status NOT IN (3, 4) AND tasks.due_at < @now AND tasks.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM lists WHERE lists.id = tasks.list_id AND (lists.is_archived OR lists.workspace_id IS NOT NULL))
+ORDER BY due_at ASC;
+
+-- name: FindTasksDueBetween :many
+SELECT id, user_id, name, created_at, updated_at, due_at, priority, description, description_html, parent_id, list_id, position, recurrence_rule, recurrence_timezone, status, deleted_at, assignee_id
+FROM tasks
+WHERE tasks.user_id = @user_id AND tasks.due_at >= @due_from AND tasks.due_at < @due_to AND tasks.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM lists WHERE lists.id = tasks.list_id AND (lists.is_archived OR lists.workspace_id IS NOT NULL))
+ORDER BY due_at ASC;
+
+-- name: FindTasksByUserIDAndTags :many
+-- match_allがtrueの場合は全てのタグ、falseの場合はいずれかのタグが付いたタスクを取得する
+-- list_idを指定しない場合はユーザーのタスクのうち、アーカイブされたリストのタスクを除外する
+-- list_idを指定する場合はリストの権限を確認済みのため、他のユーザーのタスクも含める
+-- tag_idsは重複を除いた数と比較する
+-- sort_order: 0=更新日時の新しい順, 1=優先度の高い順, 2=手動の並び順
+SELECT id, user_id, name, created_at, updated_at, due_at, priority, description, description_html, parent_id, list_id, position, recurrence_rule, recurrence_timezone, status, deleted_at, assignee_id
+FROM tasks
+WHERE tasks.deleted_at IS NULL AND (
+  SELECT COUNT(DISTINCT task_tags.tag_id) FROM task_tags
+  WHERE task_tags.task_id = tasks.id AND task_tags.tag_id = ANY(@tag_ids::VARCHAR[])
+) >= CASE WHEN @match_all::BOOLEAN THEN cardinality(ARRAY(SELECT DISTINCT unnest(@tag_ids::VARCHAR[]))) ELSE 1 END
+  AND CASE WHEN sqlc.narg(list_id)::VARCHAR IS NULL
+    THEN tasks.user_id = @user_id AND NOT EXISTS (SELECT 1 FROM lists WHERE lists.id = tasks.list_id AND (lists.is_archived OR lists.workspace_id IS NOT NULL))
+    ELSE tasks.list_id = sqlc.narg(list_id)::VARCHAR
+  END
+ORDER BY CASE WHEN @sort_order::INTEGER = 1 THEN tasks.priority ELSE 0 END DESC,
+  CASE WHEN @sort_order::INTEGER = 2 THEN tasks.position ELSE '' END,
+  tasks.updated_at DESC;
+
+-- name: FindTaskTree :many
+WITH RECURSIVE tree AS (
+  SELECT tasks.id FROM tasks WHERE tasks.id = $1 AND tasks.deleted_at IS NULL
+  UNION ALL
+  SELECT t.id FROM tasks t JOIN tree ON t.parent_id = tree.id WHERE t.deleted_at IS NULL
+)
+SELECT tasks.id, tasks.user_id, tasks.name, tasks.created_at, tasks.updated_at, tasks.due_at, tasks.priority, tasks.description, tasks.description_html, tasks.parent_id, tasks.list_id, tasks.position, tasks.recurrence_rule, tasks.recurrence_timezone, tasks.status, tasks.deleted_at, tasks.assignee_id
+FROM tasks
+JOIN tree ON tasks.id = tree.id
+ORDER BY tasks.created_at ASC;
+
+-- name: FindAncestorIDs :many
+WITH RECURSIVE ancestors AS (
+  SELECT tasks.id, tasks.parent_id FROM tasks WHERE tasks.id = $1
+  UNION ALL
+  SELECT t.id, t.parent_id FROM tasks t JOIN ancestors a ON t.id = a.parent_id
+)
+SELECT ancestors.id FROM ancestors;
+
+-- name: CountOpenDescendants :one
+-- 完了(3)と中止(4)以外のタスクを未完了として数える
+WITH RECURSIVE descendants AS (
+  SELECT tasks.id, tasks.status FROM tasks WHERE tasks.parent_id = $1 AND tasks.deleted_at IS NULL
+  UNION ALL
+  SELECT t.id, t.status FROM tasks t JOIN descendants d ON t.parent_id = d.id WHERE t.deleted_at IS NULL
+)
+SELECT COUNT(*) FROM descendants WHERE descendants.status NOT IN (3, 4);
+
+-- name: FindBlockerIDs :many
+-- 指定したタスクが直接または間接的に依存している全てのタスクのIDを取得する
+-- ゴミ箱から復元した時に循環しないよう、ゴミ箱のタスクも辿る
+WITH RECURSIVE blockers AS (
+  SELECT task_dependencies.blocker_id FROM task_dependencies WHERE task_dependencies.task_id = $1
+  UNION
+  SELECT d.blocker_id FROM task_dependencies d JOIN blockers b ON d.task_id = b.blocker_id
+)
+SELECT blockers.blocker_id FROM blockers;
+
+-- name: CountOpenBlockers :one
+SELECT COUNT(*)
+FROM task_dependencies
+JOIN tasks ON tasks.id = task_dependencies.blocker_id
+WHERE task_dependencies.task_id = $1 AND tasks.status NOT IN (3, 4) AND tasks.deleted_at IS NULL;
+
+-- name: FindBlockedTaskIDs :many
+-- 指定したタスクのうち未完了のブロッカーが残っているタスクのIDを取得する
+SELECT DISTINCT task_dependencies.task_id
+FROM task_dependencies
+JOIN tasks ON tasks.id = task_dependencies.blocker_id
+WHERE task_dependencies.task_id = ANY(@task_ids::VARCHAR[]) AND tasks.status NOT IN (3, 4) AND tasks.deleted_at IS NULL;
+
+-- name: FindMinTaskPosition :one
+-- リストの先頭のタスクの位置を取得する。タスクが存在しない場合は空文字を返す
+SELECT COALESCE(MIN(position), '')::VARCHAR AS position
+FROM tasks
+WHERE list_id = $1 AND deleted_at IS NULL;
+
+-- name: FindPrevTaskPosition :one
+-- 指定した位置の直前にあるタスクの位置を取得する。存在しない場合は空文字を返す
+SELECT COALESCE(MAX(position), '')::VARCHAR AS position
+FROM tasks
+WHERE list_id = @list_id AND position < @position AND id <> @id AND deleted_at IS NULL;
+
+-- name: FindNextTaskPosition :one
+-- 指定した位置の直後にあるタスクの位置を取得する。存在しない場合は空文字を返す
+SELECT COALESCE(MIN(position), '')::VARCHAR AS position
+FROM tasks
+WHERE list_id = @list_id AND position > @position AND id <> @id AND deleted_at IS NULL;
+
+-- name: FindListIDsToRebalance :many
+-- 位置のキーが長くなりすぎた、または重複しているリストを取得する
+SELECT list_id
+FROM tasks
+WHERE deleted_at IS NULL
+GROUP BY list_id
+HAVING MAX(LENGTH(position)) > @max_length::INTEGER OR COUNT(*) <> COUNT(DISTINCT position);
+
+-- name: FindTaskIDsByListIDOrderByPosition :many
+-- 再配置中に並び順が変更されないように行をロックする
+SELECT id
+FROM tasks
+WHERE list_id = $1 AND deleted_at IS NULL
+ORDER BY position, updated_at DESC
+FOR UPDATE;
+
 -- name: CreateTask :one
-INSERT INTO tasks(id, user_id, name, is_completed, created_at, updated_at)
-VALUES($1, $2, $3, $4, $5, $6)
+INSERT INTO tasks(id, user_id, name, created_at, updated_at, due_at, priority, description, description_html, parent_id, list_id, position, recurrence_rule, recurrence_timezone, status, assignee_id)
+VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 RETURNING id;
 
 -- name: UpdateTask :exec
 UPDATE tasks
-SET name = $2, is_completed = $3, updated_at = $4
+SET name = $2, status = $3, updated_at = $4, due_at = $5, priority = $6, description = $7, description_html = $8, parent_id = $9, list_id = $10, recurrence_rule = $11, recurrence_timezone = $12, assignee_id = $13
 WHERE id = $1;
 
+-- name: UpdateTaskTreeListID :exec
+-- 指定したタスクとその全ての子孫タスクを別のリストに移動する
+-- 復元した時に親と別のリストにならないよう、ゴミ箱のサブタスクも合わせて移動する
+WITH RECURSIVE tree AS (
+  SELECT tasks.id FROM tasks WHERE tasks.id = @id
+  UNION ALL
+  SELECT t.id FROM tasks t JOIN tree ON t.parent_id = tree.id
+)
+UPDATE tasks
+SET list_id = @list_id, updated_at = @updated_at
+FROM tree
+WHERE tasks.id = tree.id;
+
+-- name: UpdateTaskPosition :exec
+-- 並び順の変更は内容の更新ではないため更新日時は変更しない
+UPDATE tasks
+SET position = @position
+WHERE id = @id;
+
+-- name: AddTaskDependency :execrows
+INSERT INTO task_dependencies(task_id, blocker_id, created_at)
+VALUES($1, $2, $3)
+ON CONFLICT (task_id, blocker_id) DO NOTHING;
+
+-- name: RemoveTaskDependency :execrows
+DELETE FROM task_dependencies
+WHERE task_id = $1 AND blocker_id = $2;
+
+-- name: FindTrashedTaskByID :one
+SELECT id, user_id, name, created_at, updated_at, due_at, priority, description, description_html, parent_id, list_id, position, recurrence_rule, recurrence_timezone, status, deleted_at, assignee_id
+FROM tasks
+WHERE id = $1 AND deleted_at IS NOT NULL
+LIMIT 1;
+
+-- name: FindTrashedTasksByUserID :many
+-- ゴミ箱に移動したタスクを取得する。親と一緒にゴミ箱に移動したサブタスクは除外する
+SELECT id, user_id, name, created_at, updated_at, due_at, priority, description, description_html, parent_id, list_id, position, recurrence_rule, recurrence_timezone, status, deleted_at, assignee_id
+FROM tasks
+WHERE tasks.user_id = $1 AND tasks.deleted_at IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM tasks p WHERE p.id = tasks.parent_id AND p.deleted_at IS NOT NULL)
+ORDER BY deleted_at DESC;
+
 -- name: DeleteTask :exec
+-- 指定したタスクとその全ての子孫タスクをゴミ箱に移動する
+WITH RECURSIVE tree AS (
+  SELECT tasks.id FROM tasks WHERE tasks.id = @id AND tasks.deleted_at IS NULL
+  UNION ALL
+  SELECT t.id FROM tasks t JOIN tree ON t.parent_id = tree.id WHERE t.deleted_at IS NULL
+)
+UPDATE tasks
+SET deleted_at = @deleted_at
+FROM tree
+WHERE tasks.id = tree.id;
+
+-- name: RestoreTask :exec
+-- 指定したタスクと、そのタスクと一緒にゴミ箱に移動した子孫タスクを復元する
+WITH RECURSIVE tree AS (
+  SELECT tasks.id FROM tasks WHERE tasks.id = @id AND tasks.deleted_at = @deleted_at
+  UNION ALL
+  SELECT t.id FROM tasks t JOIN tree ON t.parent_id = tree.id WHERE t.deleted_at = @deleted_at
+)
+UPDATE tasks
+SET deleted_at = NULL
+FROM tree
+WHERE tasks.id = tree.id;
+
+-- name: PurgeTrashedTasksByUserID :exec
+-- ゴミ箱のタスクを完全に削除する。サブタスクも合わせて削除される
 DELETE FROM tasks
-WHERE id = $1;
+WHERE user_id = $1 AND deleted_at IS NOT NULL;
+
+-- name: PurgeTasksDeletedBefore :execrows
+-- 指定した日時より前にゴミ箱に移動したタスクを完全に削除する
+DELETE FROM tasks
+WHERE deleted_at < @deleted_before;
+
+-- name: IsDefaultSearchConfig :one
+-- configがtasks.search_configの既定値と一致するか確認する。configが存在しないテキスト検索設定の場合はエラーになる
+SELECT COALESCE((
+  SELECT columns.column_default::TEXT
+  FROM information_schema.columns
+  WHERE columns.table_schema = current_schema() AND columns.table_name = 'tasks' AND columns.column_name = 'search_config'
+), '') = quote_literal(CAST(@config::TEXT AS REGCONFIG)::TEXT) || '::regconfig' AS matched;
+
+-- name: SearchTasksByUserID :many
+-- 名前と説明がqueryに一致するタスクを順位の高い順に取得する。ワークスペースとアーカイブされたリストのタスクは除外する
+-- cursorを指定した場合はその位置より後のタスクを取得する。強調表示は取得したタスクのみ作成する
+WITH q AS (
+  SELECT websearch_to_tsquery(CAST(@config::TEXT AS REGCONFIG), @query::TEXT) AS query
+), matched AS (
+  SELECT tasks.id, tasks.user_id, tasks.name, tasks.created_at, tasks.updated_at, tasks.due_at, tasks.priority, tasks.description, tasks.description_html, tasks.parent_id, tasks.list_id, tasks.position, tasks.recurrence_rule, tasks.recurrence_timezone, tasks.status, tasks.deleted_at, tasks.assignee_id,
+    ts_rank(tasks.search_vector, q.query) AS rank
+  FROM tasks, q
+  WHERE tasks.user_id = @user_id AND tasks.deleted_at IS NULL AND tasks.search_vector @@ q.query
+    AND NOT EXISTS (SELECT 1 FROM lists WHERE lists.id = tasks.list_id AND (lists.is_archived OR lists.workspace_id IS NOT NULL))
+)
+SELECT matched.id, matched.user_id, matched.name, matched.created_at, matched.updated_at, matched.due_at, matched.priority, matched.description, matched.description_html, matched.parent_id, matched.list_id, matched.position, matched.recurrence_rule, matched.recurrence_timezone, matched.status, matched.deleted_at, matched.assignee_id,
+  matched.rank::REAL AS rank,
+  ts_headline(CAST(@config::TEXT AS REGCONFIG), matched.name, q.query, @name_options::TEXT)::TEXT AS name_headline,
+  ts_headline(CAST(@config::TEXT AS REGCONFIG), matched.description, q.query, @description_options::TEXT)::TEXT AS description_headline
+FROM matched, q
+WHERE sqlc.narg(cursor_rank)::REAL IS NULL
+  OR matched.rank < sqlc.narg(cursor_rank)::REAL
+  OR (matched.rank = sqlc.narg(cursor_rank)::REAL AND matched.id > sqlc.narg(cursor_id)::VARCHAR)
+ORDER BY matched.rank DESC, matched.id
+LIMIT sqlc.arg(max_rows);
+
+-- name: SearchTasksByWorkspaceID :many
+-- ワークスペースの全てのリストから名前と説明がqueryに一致するタスクを順位の高い順に取得する。アーカイブされたリストのタスクは除外する
+WITH q AS (
+  SELECT websearch_to_tsquery(CAST(@config::TEXT AS REGCONFIG), @query::TEXT) AS query
+), matched AS (
+  SELECT tasks.id, tasks.user_id, tasks.name, tasks.created_at, tasks.updated_at, tasks.due_at, tasks.priority, tasks.description, tasks.description_html, tasks.parent_id, tasks.list_id, tasks.position, tasks.recurrence_rule, tasks.recurrence_timezone, tasks.status, tasks.deleted_at, tasks.assignee_id,
+    ts_rank(tasks.search_vector, q.query) AS rank
+  FROM tasks
+  JOIN lists ON lists.id = tasks.list_id
+  CROSS JOIN q
+  WHERE lists.workspace_id = @workspace_id AND tasks.deleted_at IS NULL AND NOT lists.is_archived AND tasks.search_vector @@ q.query
+)
+SELECT matched.id, matched.user_id, matched.name, matched.created_at, matched.updated_at, matched.due_at, matched.priority, matched.description, matched.description_html, matched.parent_id, matched.list_id, matched.position, matched.recurrence_rule, matched.recurrence_timezone, matched.status, matched.deleted_at, matched.assignee_id,
+  matched.rank::REAL AS rank,
+  ts_headline(CAST(@config::TEXT AS REGCONFIG), matched.name, q.query, @name_options::TEXT)::TEXT AS name_headline,
+  ts_headline(CAST(@config::TEXT AS REGCONFIG), matched.description, q.query, @description_options::TEXT)::TEXT AS description_headline
+FROM matched, q
+WHERE sqlc.narg(cursor_rank)::REAL IS NULL
+  OR matched.rank < sqlc.narg(cursor_rank)::REAL
+  OR (matched.rank = sqlc.narg(cursor_rank)::REAL AND matched.id > sqlc.narg(cursor_id)::VARCHAR)
+ORDER BY matched.rank DESC, matched.id
+LIMIT sqlc.arg(max_rows);
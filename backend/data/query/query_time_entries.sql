@@ -0,0 +1,37 @@
+-- name: FindTimeEntryByID :one
+SELECT id, user_id, task_id, started_at, ended_at, note, created_at, updated_at
+FROM time_entries
+WHERE id = $1
+LIMIT 1;
+
+-- name: FindRunningTimeEntryByUserID :one
+SELECT id, user_id, task_id, started_at, ended_at, note, created_at, updated_at
+FROM time_entries
+WHERE user_id = $1 AND ended_at IS NULL
+LIMIT 1;
+
+-- 期間と重なる作業時間をタスク名と共に開始日時の古い順に取得する
+-- ゴミ箱のタスクの作業時間は含めない
+-- name: FindTimeEntriesByUserIDBetween :many
+SELECT time_entries.id, time_entries.user_id, time_entries.task_id, time_entries.started_at, time_entries.ended_at, time_entries.note, time_entries.created_at, time_entries.updated_at, tasks.name AS task_name
+FROM time_entries
+INNER JOIN tasks ON tasks.id = time_entries.task_id
+WHERE time_entries.user_id = @user_id
+  AND tasks.deleted_at IS NULL
+  AND time_entries.started_at < @range_to
+  AND (time_entries.ended_at IS NULL OR time_entries.ended_at > @range_from::TIMESTAMPTZ)
+ORDER BY time_entries.started_at ASC, time_entries.id ASC;
+
+-- name: CreateTimeEntry :one
+INSERT INTO time_entries(id, user_id, task_id, started_at, ended_at, note, created_at, updated_at)
+VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id;
+
+-- name: UpdateTimeEntry :exec
+UPDATE time_entries
+SET started_at = $2, ended_at = $3, note = $4, updated_at = $5
+WHERE id = $1;
+
+-- name: DeleteTimeEntry :exec
+DELETE FROM time_entries
+WHERE id = $1;
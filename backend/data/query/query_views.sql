@@ -0,0 +1,31 @@
+-- name: FindViewByID :one
+SELECT id, user_id, name, completion, due_period, updated_period, name_contains, sort_field, sort_descending, group_by, created_at, updated_at
+FROM views
+WHERE id = $1
+LIMIT 1;
+
+-- name: FindViewByUserIDAndName :one
+SELECT id, user_id, name, completion, due_period, updated_period, name_contains, sort_field, sort_descending, group_by, created_at, updated_at
+FROM views
+WHERE user_id = $1 AND name = $2
+LIMIT 1;
+
+-- name: FindViewsByUserID :many
+SELECT id, user_id, name, completion, due_period, updated_period, name_contains, sort_field, sort_descending, group_by, created_at, updated_at
+FROM views
+WHERE user_id = $1
+ORDER BY name ASC;
+
+-- name: CreateView :one
+INSERT INTO views(id, user_id, name, completion, due_period, updated_period, name_contains, sort_field, sort_descending, group_by, created_at, updated_at)
+VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING id;
+
+-- name: UpdateView :exec
+UPDATE views
+SET name = $2, completion = $3, due_period = $4, updated_period = $5, name_contains = $6, sort_field = $7, sort_descending = $8, group_by = $9, updated_at = $10
+WHERE id = $1;
+
+-- name: DeleteView :exec
+DELETE FROM views
+WHERE id = $1;
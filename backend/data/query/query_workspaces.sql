@@ -0,0 +1,101 @@
+-- name: FindWorkspaceByID :one
+SELECT id, name, created_at, updated_at
+FROM workspaces
+WHERE id = $1
+LIMIT 1;
+
+-- name: FindWorkspacesByUserID :many
+-- ユーザーがメンバーであるワークスペースを参加した順に取得する
+SELECT workspaces.id, workspaces.name, workspaces.created_at, workspaces.updated_at, workspace_members.role
+FROM workspaces
+JOIN workspace_members ON workspace_members.workspace_id = workspaces.id
+WHERE workspace_members.user_id = $1
+ORDER BY workspace_members.created_at ASC, workspaces.id ASC;
+
+-- name: CreateWorkspace :one
+INSERT INTO workspaces(id, name, created_at, updated_at)
+VALUES($1, $2, $3, $4)
+RETURNING id;
+
+-- name: DeleteWorkspace :exec
+DELETE FROM workspaces
+WHERE id = $1;
+
+-- name: FindWorkspaceMember :one
+SELECT workspace_id, user_id, role, created_at, updated_at
+FROM workspace_members
+WHERE workspace_id = $1 AND user_id = $2
+LIMIT 1;
+
+-- name: FindWorkspaceMembers :many
+SELECT workspace_members.workspace_id, workspace_members.user_id, users.email, workspace_members.role, workspace_members.created_at, workspace_members.updated_at
+FROM workspace_members
+JOIN users ON users.id = workspace_members.user_id
+WHERE workspace_members.workspace_id = $1
+ORDER BY workspace_members.created_at ASC, workspace_members.user_id ASC;
+
+-- name: CreateWorkspaceMember :exec
+INSERT INTO workspace_members(workspace_id, user_id, role, created_at, updated_at)
+VALUES($1, $2, $3, $4, $5);
+
+-- name: UpdateWorkspaceMember :exec
+UPDATE workspace_members
+SET role = $3, updated_at = $4
+WHERE workspace_id = $1 AND user_id = $2;
+
+-- name: DeleteWorkspaceMember :exec
+DELETE FROM workspace_members
+WHERE workspace_id = $1 AND user_id = $2;
+
+-- name: UpdateWorkspaceTasksOwner :exec
+-- ワークスペースのリストに属するタスクのうち、from_user_idが所有するタスクの所有者を移す。ゴミ箱のタスクも含む
+UPDATE tasks
+SET user_id = @to_user_id
+WHERE tasks.user_id = @from_user_id
+  AND tasks.list_id IN (SELECT lists.id FROM lists WHERE lists.workspace_id = @workspace_id::VARCHAR);
+
+-- name: UpdateWorkspaceListsOwner :exec
+-- ワークスペースのリストのうち、from_user_idが作成したリストの所有者を移す
+UPDATE lists
+SET user_id = @to_user_id
+WHERE workspace_id = @workspace_id::VARCHAR AND user_id = @from_user_id;
+
+-- name: FindWorkspaceInvitationByID :one
+SELECT id, workspace_id, email, role, invited_by, created_at
+FROM workspace_invitations
+WHERE id = $1
+LIMIT 1;
+
+-- name: FindWorkspaceInvitationByEmail :one
+SELECT id, workspace_id, email, role, invited_by, created_at
+FROM workspace_invitations
+WHERE workspace_id = $1 AND email = $2
+LIMIT 1;
+
+-- name: FindWorkspaceInvitationsByWorkspaceID :many
+SELECT id, workspace_id, email, role, invited_by, created_at
+FROM workspace_invitations
+WHERE workspace_id = $1
+ORDER BY created_at ASC, id ASC;
+
+-- name: FindWorkspaceInvitationsByEmail :many
+-- 招待されたユーザーはまだメンバーではないため、ワークスペースの名前も合わせて取得する
+SELECT workspace_invitations.id, workspace_invitations.workspace_id, workspaces.name AS workspace_name, workspace_invitations.email, workspace_invitations.role, workspace_invitations.invited_by, workspace_invitations.created_at
+FROM workspace_invitations
+JOIN workspaces ON workspaces.id = workspace_invitations.workspace_id
+WHERE workspace_invitations.email = $1
+ORDER BY workspace_invitations.created_at ASC, workspace_invitations.id ASC;
+
+-- name: CreateWorkspaceInvitation :one
+INSERT INTO workspace_invitations(id, workspace_id, email, role, invited_by, created_at)
+VALUES($1, $2, $3, $4, $5, $6)
+RETURNING id;
+
+-- name: UpdateWorkspaceInvitation :exec
+UPDATE workspace_invitations
+SET role = $2, invited_by = $3
+WHERE id = $1;
+
+-- name: DeleteWorkspaceInvitation :exec
+DELETE FROM workspace_invitations
+WHERE id = $1;
@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN priority SMALLINT NOT NULL DEFAULT(0) CHECK(priority BETWEEN 0 AND 4);
+
+CREATE INDEX tasks_user_id_priority_updated_at_idx ON tasks(user_id, priority DESC, updated_at DESC);
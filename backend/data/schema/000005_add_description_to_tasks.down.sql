@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP COLUMN description_html;
+ALTER TABLE tasks DROP COLUMN description;
@@ -0,0 +1,2 @@
+ALTER TABLE tasks ADD COLUMN description TEXT NOT NULL DEFAULT('');
+ALTER TABLE tasks ADD COLUMN description_html TEXT NOT NULL DEFAULT('');
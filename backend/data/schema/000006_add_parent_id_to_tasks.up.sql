@@ -0,0 +1,4 @@
+-- 親タスクを削除するとサブタスクも削除される
+ALTER TABLE tasks ADD COLUMN parent_id VARCHAR(50) REFERENCES tasks(id) ON DELETE CASCADE;
+
+CREATE INDEX tasks_parent_id_idx ON tasks(parent_id);
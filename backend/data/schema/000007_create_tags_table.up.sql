@@ -0,0 +1,18 @@
+CREATE TABLE tags(
+  id VARCHAR(50) PRIMARY KEY,
+  user_id VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name VARCHAR(50) NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL,
+  UNIQUE(user_id, name)
+);
+
+CREATE TABLE task_tags(
+  task_id VARCHAR(50) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  tag_id VARCHAR(50) NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY(task_id, tag_id)
+);
+
+-- タグからタスクを逆引きするためのインデックス
+CREATE INDEX task_tags_tag_id_idx ON task_tags(tag_id);
@@ -0,0 +1,5 @@
+DROP INDEX tasks_list_id_updated_at_idx;
+
+ALTER TABLE tasks DROP COLUMN list_id;
+
+DROP TABLE IF EXISTS lists;
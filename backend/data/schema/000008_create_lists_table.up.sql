@@ -0,0 +1,31 @@
+CREATE TABLE lists(
+  id VARCHAR(50) PRIMARY KEY,
+  user_id VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name VARCHAR(100) NOT NULL,
+  is_inbox BOOLEAN NOT NULL DEFAULT(false),
+  is_archived BOOLEAN NOT NULL DEFAULT(false),
+  position INTEGER NOT NULL DEFAULT(0),
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX lists_user_id_position_idx ON lists(user_id, position);
+
+-- Inboxはユーザーごとに1つだけ
+CREATE UNIQUE INDEX lists_user_id_inbox_idx ON lists(user_id) WHERE is_inbox;
+
+-- 既存のユーザーごとにInboxを作成し、既存のタスクをInboxに移動する
+INSERT INTO lists(id, user_id, name, is_inbox, is_archived, position, created_at, updated_at)
+SELECT gen_random_uuid()::VARCHAR, users.id, 'Inbox', true, false, 0, NOW(), NOW()
+FROM users;
+
+-- リストを削除するとリスト内のタスクも削除される
+ALTER TABLE tasks ADD COLUMN list_id VARCHAR(50) REFERENCES lists(id) ON DELETE CASCADE;
+
+UPDATE tasks SET list_id = lists.id
+FROM lists
+WHERE lists.user_id = tasks.user_id AND lists.is_inbox;
+
+ALTER TABLE tasks ALTER COLUMN list_id SET NOT NULL;
+
+CREATE INDEX tasks_list_id_updated_at_idx ON tasks(list_id, updated_at DESC);
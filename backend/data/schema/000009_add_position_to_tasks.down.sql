@@ -0,0 +1,3 @@
+DROP INDEX tasks_list_id_position_idx;
+
+ALTER TABLE tasks DROP COLUMN position;
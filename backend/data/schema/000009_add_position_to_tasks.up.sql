@@ -0,0 +1,14 @@
+-- 手動の並び順を表すキー。辞書順で比較するためバイト順の照合順序を使用する
+ALTER TABLE tasks ADD COLUMN position VARCHAR(255) COLLATE "C" NOT NULL DEFAULT('');
+
+-- 既存のタスクにはリストごとに更新日時の新しい順でキーを割り当てる。キーの末尾は0以外にする
+UPDATE tasks SET position = ranked.position
+FROM (
+  SELECT id, LPAD((ROW_NUMBER() OVER (PARTITION BY list_id ORDER BY updated_at DESC))::TEXT, 8, '0') || 'i' AS position
+  FROM tasks
+) AS ranked
+WHERE tasks.id = ranked.id;
+
+ALTER TABLE tasks ALTER COLUMN position DROP DEFAULT;
+
+CREATE INDEX tasks_list_id_position_idx ON tasks(list_id, position);
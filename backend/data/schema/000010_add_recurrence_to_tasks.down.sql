@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP COLUMN recurrence_timezone;
+ALTER TABLE tasks DROP COLUMN recurrence_rule;
@@ -0,0 +1,4 @@
+-- RFC 5545のRRULE。繰り返さないタスクの場合は空文字
+ALTER TABLE tasks ADD COLUMN recurrence_rule VARCHAR(255) NOT NULL DEFAULT('');
+-- 次の回の日時を計算するIANAのタイムゾーン名
+ALTER TABLE tasks ADD COLUMN recurrence_timezone VARCHAR(64) NOT NULL DEFAULT('');
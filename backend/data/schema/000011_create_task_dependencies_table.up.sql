@@ -0,0 +1,11 @@
+-- task_idのタスクはblocker_idのタスクが完了するまで開始できない
+CREATE TABLE task_dependencies(
+  task_id VARCHAR(50) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  blocker_id VARCHAR(50) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY(task_id, blocker_id),
+  CHECK(task_id <> blocker_id)
+);
+
+-- ブロッカーから依存しているタスクを逆引きするためのインデックス
+CREATE INDEX task_dependencies_blocker_id_idx ON task_dependencies(blocker_id);
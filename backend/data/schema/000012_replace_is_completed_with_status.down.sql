@@ -0,0 +1,6 @@
+ALTER TABLE tasks ADD COLUMN is_completed BOOLEAN NOT NULL DEFAULT(false);
+
+-- 中止のタスクは完了として扱わない
+UPDATE tasks SET is_completed = true WHERE status = 3;
+
+ALTER TABLE tasks DROP COLUMN status;
@@ -0,0 +1,7 @@
+-- 0=未着手, 1=進行中, 2=待機中, 3=完了, 4=中止
+ALTER TABLE tasks ADD COLUMN status SMALLINT NOT NULL DEFAULT(0) CHECK(status BETWEEN 0 AND 4);
+
+-- 完了済みのタスクは完了の状態に移行する
+UPDATE tasks SET status = 3 WHERE is_completed;
+
+ALTER TABLE tasks DROP COLUMN is_completed;
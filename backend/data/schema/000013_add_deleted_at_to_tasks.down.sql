@@ -0,0 +1,6 @@
+-- ゴミ箱のタスクは完全に削除する
+DELETE FROM tasks WHERE deleted_at IS NOT NULL;
+
+DROP INDEX tasks_deleted_at_idx;
+
+ALTER TABLE tasks DROP COLUMN deleted_at;
@@ -0,0 +1,5 @@
+-- ゴミ箱に移動した日時。ゴミ箱にないタスクの場合はNULL
+ALTER TABLE tasks ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- 保持期間を過ぎたタスクの完全削除で使用する
+CREATE INDEX tasks_deleted_at_idx ON tasks(deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,14 @@
+CREATE TABLE task_histories(
+  id VARCHAR(50) PRIMARY KEY,
+  task_id VARCHAR(50) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  -- 変更したユーザー。ユーザーが削除されても履歴は残す
+  actor_id VARCHAR(50) REFERENCES users(id) ON DELETE SET NULL,
+  -- 0=作成, 1=名前の変更, 2=状態の変更, 3=削除, 4=復元
+  action SMALLINT NOT NULL CHECK(action BETWEEN 0 AND 4),
+  old_value TEXT NOT NULL DEFAULT(''),
+  new_value TEXT NOT NULL DEFAULT(''),
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+-- 新しい順のページングで使用する
+CREATE INDEX task_histories_task_id_created_at_idx ON task_histories(task_id, created_at DESC, id DESC);
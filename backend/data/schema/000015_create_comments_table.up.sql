@@ -0,0 +1,13 @@
+CREATE TABLE comments(
+  id VARCHAR(50) PRIMARY KEY,
+  -- タスクが完全に削除された場合はコメントも削除する
+  task_id VARCHAR(50) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  user_id VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  body TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL,
+  -- 本文を編集した日時。編集していない場合はNULL
+  edited_at TIMESTAMPTZ
+);
+
+CREATE INDEX comments_task_id_created_at_idx ON comments(task_id, created_at);
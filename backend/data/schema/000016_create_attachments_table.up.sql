@@ -0,0 +1,34 @@
+CREATE TABLE attachments(
+  id VARCHAR(50) PRIMARY KEY,
+  task_id VARCHAR(50) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  -- アップロードしたユーザー。使用量の集計に使用する
+  user_id VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  file_name TEXT NOT NULL,
+  -- 内容から判定したMIMEタイプ
+  content_type VARCHAR(100) NOT NULL,
+  size BIGINT NOT NULL CHECK(size > 0),
+  -- ファイルの本体を保存したストレージのキー
+  storage_key VARCHAR(255) NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX attachments_task_id_idx ON attachments(task_id, created_at);
+CREATE INDEX attachments_user_id_idx ON attachments(user_id);
+
+-- 削除された添付ファイルのストレージのキー。ストレージからの削除が完了するまで保持する
+CREATE TABLE blob_deletions(
+  storage_key VARCHAR(255) PRIMARY KEY,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT(NOW())
+);
+
+-- タスクの完全削除による連鎖削除でもストレージのファイルが残らないように、メタデータの削除時にキーを記録する
+CREATE FUNCTION enqueue_blob_deletion() RETURNS TRIGGER AS $$
+BEGIN
+  INSERT INTO blob_deletions(storage_key) VALUES(OLD.storage_key) ON CONFLICT DO NOTHING;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER attachments_enqueue_blob_deletion
+AFTER DELETE ON attachments
+FOR EACH ROW EXECUTE FUNCTION enqueue_blob_deletion();
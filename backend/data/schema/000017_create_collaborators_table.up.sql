@@ -0,0 +1,18 @@
+CREATE TABLE collaborators(
+  id VARCHAR(50) PRIMARY KEY,
+  -- タスクとリストのいずれか一方を共有する。共有したタスクやリストが完全に削除された場合は共有も削除する
+  task_id VARCHAR(50) REFERENCES tasks(id) ON DELETE CASCADE,
+  list_id VARCHAR(50) REFERENCES lists(id) ON DELETE CASCADE,
+  user_id VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  -- 1: 閲覧者, 2: 編集者, 3: 管理者
+  role SMALLINT NOT NULL CHECK (role BETWEEN 1 AND 3),
+  granted_by VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL,
+  CHECK (num_nonnulls(task_id, list_id) = 1)
+);
+
+-- 同じユーザーに同じタスクやリストを重複して共有しない
+CREATE UNIQUE INDEX collaborators_task_id_user_id_idx ON collaborators(task_id, user_id) WHERE task_id IS NOT NULL;
+CREATE UNIQUE INDEX collaborators_list_id_user_id_idx ON collaborators(list_id, user_id) WHERE list_id IS NOT NULL;
+CREATE INDEX collaborators_user_id_idx ON collaborators(user_id);
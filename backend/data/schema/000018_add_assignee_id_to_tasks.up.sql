@@ -0,0 +1,5 @@
+-- タスクの担当者。担当者がいない場合はNULL。ユーザーが削除された場合は担当者なしに戻す
+ALTER TABLE tasks ADD COLUMN assignee_id VARCHAR(50) REFERENCES users(id) ON DELETE SET NULL;
+
+-- 自分が担当するタスクの一覧で使用する
+CREATE INDEX tasks_assignee_id_idx ON tasks(assignee_id) WHERE assignee_id IS NOT NULL;
@@ -0,0 +1,9 @@
+ALTER TABLE lists DROP CONSTRAINT lists_inbox_personal_check;
+
+DROP INDEX lists_workspace_id_position_idx;
+
+ALTER TABLE lists DROP COLUMN workspace_id;
+
+DROP TABLE IF EXISTS workspace_invitations;
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
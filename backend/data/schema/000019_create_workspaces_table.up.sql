@@ -0,0 +1,44 @@
+CREATE TABLE workspaces(
+  id VARCHAR(50) PRIMARY KEY,
+  name VARCHAR(100) NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE workspace_members(
+  workspace_id VARCHAR(50) NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+  user_id VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  -- 1: メンバー, 2: 管理者, 3: 所有者
+  role SMALLINT NOT NULL CHECK (role BETWEEN 1 AND 3),
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (workspace_id, user_id)
+);
+
+-- 所有者はワークスペースごとに1人だけ
+CREATE UNIQUE INDEX workspace_members_owner_idx ON workspace_members(workspace_id) WHERE role = 3;
+CREATE INDEX workspace_members_user_id_idx ON workspace_members(user_id);
+
+-- メンバーへの招待。招待されたユーザーが承諾するとメンバーになり招待は削除される
+CREATE TABLE workspace_invitations(
+  id VARCHAR(50) PRIMARY KEY,
+  workspace_id VARCHAR(50) NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+  -- 招待したメールアドレス。招待の時点でユーザーが登録されている必要はない
+  email VARCHAR(100) NOT NULL,
+  -- 1: メンバー, 2: 管理者
+  role SMALLINT NOT NULL CHECK (role BETWEEN 1 AND 2),
+  invited_by VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+-- 同じメールアドレスを同じワークスペースに重複して招待しない
+CREATE UNIQUE INDEX workspace_invitations_workspace_id_email_idx ON workspace_invitations(workspace_id, email);
+CREATE INDEX workspace_invitations_email_idx ON workspace_invitations(email);
+
+-- ワークスペースのリスト。NULLの場合は個人のリスト。ワークスペースが削除された場合はリストとタスクも削除する
+ALTER TABLE lists ADD COLUMN workspace_id VARCHAR(50) REFERENCES workspaces(id) ON DELETE CASCADE;
+
+CREATE INDEX lists_workspace_id_position_idx ON lists(workspace_id, position) WHERE workspace_id IS NOT NULL;
+
+-- Inboxは個人のリストのみ
+ALTER TABLE lists ADD CONSTRAINT lists_inbox_personal_check CHECK (NOT is_inbox OR workspace_id IS NULL);
@@ -0,0 +1,4 @@
+DROP INDEX tasks_search_vector_idx;
+
+ALTER TABLE tasks DROP COLUMN search_vector;
+ALTER TABLE tasks DROP COLUMN search_config;
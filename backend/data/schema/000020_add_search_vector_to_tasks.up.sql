@@ -0,0 +1,12 @@
+-- 全文検索に使用するテキスト検索設定。アプリケーションの検索設定(SEARCH_CONFIG)と一致させる
+-- 設定を変更する場合は既定値を変更し、既存のタスクを更新すると検索用の列が再計算される
+--   ALTER TABLE tasks ALTER COLUMN search_config SET DEFAULT 'english';
+--   UPDATE tasks SET search_config = 'english';
+ALTER TABLE tasks ADD COLUMN search_config REGCONFIG NOT NULL DEFAULT('simple');
+
+-- 名前と説明の全文検索用の列。名前に一致した場合の順位を高くする
+ALTER TABLE tasks ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+  setweight(to_tsvector(search_config, name), 'A') || setweight(to_tsvector(search_config, description), 'B')
+) STORED;
+
+CREATE INDEX tasks_search_vector_idx ON tasks USING GIN(search_vector);
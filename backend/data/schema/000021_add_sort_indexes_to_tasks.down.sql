@@ -0,0 +1,4 @@
+DROP INDEX tasks_user_id_priority_id_idx;
+DROP INDEX tasks_user_id_name_id_idx;
+DROP INDEX tasks_user_id_created_at_id_idx;
+DROP INDEX tasks_user_id_updated_at_id_idx;
@@ -0,0 +1,6 @@
+-- タスク一覧のページングで使用する。並び替えの項目ごとに(user_id, 項目, id)の順に走査できるようにする
+-- 降順の場合はインデックスを逆順に走査する
+CREATE INDEX tasks_user_id_updated_at_id_idx ON tasks(user_id, updated_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX tasks_user_id_created_at_id_idx ON tasks(user_id, created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX tasks_user_id_name_id_idx ON tasks(user_id, name, id) WHERE deleted_at IS NULL;
+CREATE INDEX tasks_user_id_priority_id_idx ON tasks(user_id, priority, id) WHERE deleted_at IS NULL;
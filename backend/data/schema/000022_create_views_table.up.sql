@@ -0,0 +1,19 @@
+CREATE TABLE views(
+  id VARCHAR(50) PRIMARY KEY,
+  user_id VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name VARCHAR(50) NOT NULL,
+  -- 0: 全て, 1: 未完了, 2: 完了
+  completion SMALLINT NOT NULL DEFAULT(0),
+  -- 0: 絞り込まない, 1: 今日, 2: 今週, 3: 今日より前, 4: 未設定
+  due_period SMALLINT NOT NULL DEFAULT(0),
+  updated_period SMALLINT NOT NULL DEFAULT(0),
+  name_contains VARCHAR(100) NOT NULL DEFAULT(''),
+  -- 0: 更新日時, 1: 作成日時, 2: 名前, 3: 優先度
+  sort_field SMALLINT NOT NULL DEFAULT(0),
+  sort_descending BOOLEAN NOT NULL DEFAULT(TRUE),
+  -- 0: まとめない, 1: 状態, 2: 優先度, 3: リスト, 4: 期限の日付
+  group_by SMALLINT NOT NULL DEFAULT(0),
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL,
+  UNIQUE(user_id, name)
+);
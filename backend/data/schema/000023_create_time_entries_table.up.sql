@@ -0,0 +1,20 @@
+CREATE TABLE time_entries(
+  id VARCHAR(50) PRIMARY KEY,
+  -- 作業したユーザー
+  user_id VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  -- タスクが完全に削除された場合は作業時間も削除する
+  task_id VARCHAR(50) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  started_at TIMESTAMPTZ NOT NULL,
+  -- 計測中のタイマーはNULL
+  ended_at TIMESTAMPTZ,
+  -- エスケープ後の長さは入力の上限を超えうるためTEXTにする
+  note TEXT NOT NULL DEFAULT(''),
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL,
+  CHECK(ended_at IS NULL OR ended_at >= started_at)
+);
+
+CREATE INDEX time_entries_user_id_started_at_idx ON time_entries(user_id, started_at);
+
+-- 計測中のタイマーはユーザーごとに1つまで
+CREATE UNIQUE INDEX time_entries_user_id_running_idx ON time_entries(user_id) WHERE ended_at IS NULL;
@@ -0,0 +1,20 @@
+CREATE TABLE task_undo_entries(
+  id VARCHAR(50) PRIMARY KEY,
+  -- 積んだ順に取り出すための連番
+  seq BIGSERIAL NOT NULL,
+  -- 操作したユーザー。スタックはユーザーごとに持つ
+  user_id VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  -- 0=取り消し, 1=やり直し
+  stack SMALLINT NOT NULL CHECK(stack BETWEEN 0 AND 1),
+  -- タスクが完全に削除された場合は操作も取り消せない
+  task_id VARCHAR(50) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  -- 操作の種類と変更前後の値。0から4はtask_historiesと同じで、5以降は取り消しのスタックのみで使う
+  action SMALLINT NOT NULL CHECK(action BETWEEN 0 AND 14),
+  old_value TEXT NOT NULL DEFAULT(''),
+  new_value TEXT NOT NULL DEFAULT(''),
+  -- 繰り返しのタスクを完了にしたときに作成した次の回のタスク
+  next_task_id VARCHAR(50) REFERENCES tasks(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX task_undo_entries_user_id_stack_seq_idx ON task_undo_entries(user_id, stack, seq DESC);
@@ -0,0 +1,7 @@
+CREATE TABLE calendar_feeds(
+  -- フィードはユーザーごとに1つ。トークンを再発行すると古いトークンは使えなくなる
+  user_id VARCHAR(50) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  -- フィードのURLに含めるトークンのSHA-256ハッシュ(16進数)。トークンそのものは保存しない
+  token_hash VARCHAR(64) NOT NULL UNIQUE,
+  created_at TIMESTAMPTZ NOT NULL
+);
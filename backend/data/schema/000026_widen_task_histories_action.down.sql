@@ -0,0 +1,5 @@
+DELETE FROM task_histories WHERE action > 4;
+
+ALTER TABLE task_histories DROP CONSTRAINT task_histories_action_check;
+
+ALTER TABLE task_histories ADD CONSTRAINT task_histories_action_check CHECK(action BETWEEN 0 AND 4);
@@ -0,0 +1,4 @@
+-- 5=優先度の変更, 6=説明文の変更, 7=担当者の変更, 8=期限の変更, 9=繰り返しの変更, 10=親タスクの変更
+ALTER TABLE task_histories DROP CONSTRAINT task_histories_action_check;
+
+ALTER TABLE task_histories ADD CONSTRAINT task_histories_action_check CHECK(action BETWEEN 0 AND 10);
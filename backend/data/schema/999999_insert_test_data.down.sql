@@ -11,3 +11,9 @@ DELETE FROM tasks WHERE id = 't1';
 DELETE FROM tasks WHERE id = 't2';
 DELETE FROM tasks WHERE id = 't3';
 DELETE FROM tasks WHERE id = 't4';
+
+-- Lists
+
+DELETE FROM lists WHERE id = 'l1';
+DELETE FROM lists WHERE id = 'l2';
+DELETE FROM lists WHERE id = 'l3';
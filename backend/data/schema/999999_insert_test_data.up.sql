@@ -13,16 +13,27 @@ VALUES('dev', 'dev@example.com', '$2a$10$YfHxWNfL8Ba2ltl6TRHMVuN0WPXxAuB5L7w1Y0j
 INSERT INTO users(id, email, password, created_at, updated_at)
 VALUES('admin', 'admin@example.com', '$2a$10$YfHxWNfL8Ba2ltl6TRHMVuN0WPXxAuB5L7w1Y0jqaFcn2bUDoUq9W', NOW(), NOW());
 
+-- Lists
+
+INSERT INTO lists(id, user_id, name, is_inbox, is_archived, position, created_at, updated_at)
+VALUES('l1', 'test', 'Inbox', true, false, 0, NOW(), NOW());
+
+INSERT INTO lists(id, user_id, name, is_inbox, is_archived, position, created_at, updated_at)
+VALUES('l2', 'dev', 'Inbox', true, false, 0, NOW(), NOW());
+
+INSERT INTO lists(id, user_id, name, is_inbox, is_archived, position, created_at, updated_at)
+VALUES('l3', 'admin', 'Inbox', true, false, 0, NOW(), NOW());
+
 -- Tasks
 
-INSERT INTO tasks(id, user_id, name, is_completed, created_at, updated_at)
-VALUES('t1', 'test', 'Test Task 1', false, NOW(), NOW());
+INSERT INTO tasks(id, user_id, list_id, name, status, created_at, updated_at, position)
+VALUES('t1', 'test', 'l1', 'Test Task 1', 0, NOW(), NOW(), 'i');
 
-INSERT INTO tasks(id, user_id, name, is_completed, created_at, updated_at)
-VALUES('t2', 'test', 'Test Task 2', true, NOW(), NOW());
+INSERT INTO tasks(id, user_id, list_id, name, status, created_at, updated_at, position)
+VALUES('t2', 'test', 'l1', 'Test Task 2', 3, NOW(), NOW(), 'r');
 
-INSERT INTO tasks(id, user_id, name, is_completed, created_at, updated_at)
-VALUES('t3', 'dev', 'Dev Task 1', false, NOW(), NOW());
+INSERT INTO tasks(id, user_id, list_id, name, status, created_at, updated_at, position)
+VALUES('t3', 'dev', 'l2', 'Dev Task 1', 0, NOW(), NOW(), 'i');
 
-INSERT INTO tasks(id, user_id, name, is_completed, created_at, updated_at)
-VALUES('t4', 'admin', 'Admin Task 1', false, NOW(), NOW());
+INSERT INTO tasks(id, user_id, list_id, name, status, created_at, updated_at, position)
+VALUES('t4', 'admin', 'l3', 'Admin Task 1', 0, NOW(), NOW(), 'i');
@@ -47,3 +47,39 @@ func (e *ErrPermissionDenied) Error() string {
 	}
 	return "permission denied"
 }
+
+// 操作の前提条件を満たしていない場合のエラー
+type ErrPreconditionFailed struct {
+	Msg string
+}
+
+func (e *ErrPreconditionFailed) Error() string {
+	if e.Msg != "" {
+		return e.Msg
+	}
+	return "failed precondition"
+}
+
+// 同じデータが既に存在する場合のエラー
+type ErrAlreadyExists struct {
+	Msg string
+}
+
+func (e *ErrAlreadyExists) Error() string {
+	if e.Msg != "" {
+		return e.Msg
+	}
+	return "already exists"
+}
+
+// 使用量の上限を超える場合のエラー
+type ErrQuotaExceeded struct {
+	Msg string
+}
+
+func (e *ErrQuotaExceeded) Error() string {
+	if e.Msg != "" {
+		return e.Msg
+	}
+	return "quota exceeded"
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+)
+
+// タスクの添付ファイルのメタデータ
+type Attachment struct {
+	ID     *value.ID
+	TaskID *value.ID
+	// アップロードしたユーザー
+	UserID   *value.ID
+	FileName string
+	// 内容から判定したMIMEタイプ
+	ContentType string
+	// ファイルのバイト数
+	Size int64
+	// ファイルの本体を保存したストレージのキー
+	StorageKey string
+	CreatedAt  time.Time
+}
+
+// フィールドの妥当性を検証する
+func (a *Attachment) Validate() error {
+	if err := a.ID.Validate(); err != nil {
+		return err
+	}
+	if err := a.TaskID.Validate(); err != nil {
+		return err
+	}
+	if err := a.UserID.Validate(); err != nil {
+		return err
+	}
+	if a.FileName == "" {
+		return &domain.ErrValidationFailed{Msg: "file name is empty"}
+	}
+	if a.ContentType == "" {
+		return &domain.ErrValidationFailed{Msg: "content type is empty"}
+	}
+	if a.Size <= 0 {
+		return &domain.ErrValidationFailed{Msg: "file is empty"}
+	}
+	if a.StorageKey == "" {
+		return &domain.ErrValidationFailed{Msg: "storage key is empty"}
+	}
+	return nil
+}
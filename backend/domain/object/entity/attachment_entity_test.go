@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentEntity_Validate(tt *testing.T) {
+	newAttachment := func(f func(a *Attachment)) *Attachment {
+		a := &Attachment{ID: value.NewID("id"), TaskID: value.NewID("tid"), UserID: value.NewID("uid"), FileName: "shot.png", ContentType: "image/png", Size: 10, StorageKey: "id"}
+		f(a)
+		return a
+	}
+	testcases := []struct {
+		title string
+		arg   *Attachment
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", newAttachment(func(a *Attachment) {}), nil},
+		{"準正常系: IDが空の場合", newAttachment(func(a *Attachment) { a.ID = value.NewID("") }), &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: TaskIDが空の場合", newAttachment(func(a *Attachment) { a.TaskID = value.NewID("") }), &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: UserIDが空の場合", newAttachment(func(a *Attachment) { a.UserID = value.NewID("") }), &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: ファイル名が空の場合", newAttachment(func(a *Attachment) { a.FileName = "" }), &domain.ErrValidationFailed{Msg: "file name is empty"}},
+		{"準正常系: MIMEタイプが空の場合", newAttachment(func(a *Attachment) { a.ContentType = "" }), &domain.ErrValidationFailed{Msg: "content type is empty"}},
+		{"準正常系: サイズが0の場合", newAttachment(func(a *Attachment) { a.Size = 0 }), &domain.ErrValidationFailed{Msg: "file is empty"}},
+		{"準正常系: ストレージのキーが空の場合", newAttachment(func(a *Attachment) { a.StorageKey = "" }), &domain.ErrValidationFailed{Msg: "storage key is empty"}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+)
+
+// ユーザーのタスクを購読するカレンダーのフィード
+type CalendarFeed struct {
+	UserID *value.ID
+	// フィードのURLに含めるトークンのハッシュ
+	TokenHash string
+	CreatedAt time.Time
+}
+
+// フィールドの妥当性を検証する
+func (e *CalendarFeed) Validate() error {
+	if err := e.UserID.Validate(); err != nil {
+		return err
+	}
+	if e.TokenHash == "" {
+		return &domain.ErrValidationFailed{Msg: "token hash is empty"}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarFeedEntity_Validate(tt *testing.T) {
+	now := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	testcases := []struct {
+		title string
+		arg   *CalendarFeed
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", &CalendarFeed{UserID: value.NewID("uid"), TokenHash: "hash", CreatedAt: now}, nil},
+		{"準正常系: UserIDが空の場合", &CalendarFeed{UserID: value.NewID(""), TokenHash: "hash", CreatedAt: now}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: トークンのハッシュが空の場合", &CalendarFeed{UserID: value.NewID("uid"), CreatedAt: now}, &domain.ErrValidationFailed{Msg: "token hash is empty"}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+)
+
+// タスクまたはリストを共有したユーザー。タスクとリストのいずれか一方のみを持つ
+type Collaborator struct {
+	ID *value.ID
+	// 共有したタスク。リストを共有した場合はnil
+	TaskID *value.ID
+	// 共有したリスト。タスクを共有した場合はnil
+	ListID *value.ID
+	// 共有されたユーザー
+	UserID *value.ID
+	// 共有されたユーザーのメールアドレス。一覧を取得した場合のみ設定される
+	Email string
+	Role  value.Role
+	// 共有したユーザー
+	GrantedBy *value.ID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// フィールドの妥当性を検証する
+func (c *Collaborator) Validate() error {
+	if err := c.ID.Validate(); err != nil {
+		return err
+	}
+	if (c.TaskID == nil) == (c.ListID == nil) {
+		return &domain.ErrValidationFailed{Msg: "either task or list must be shared"}
+	}
+	if c.TaskID != nil {
+		if err := c.TaskID.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.ListID != nil {
+		if err := c.ListID.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := c.UserID.Validate(); err != nil {
+		return err
+	}
+	if err := c.Role.Validate(); err != nil {
+		return err
+	}
+	if err := c.GrantedBy.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
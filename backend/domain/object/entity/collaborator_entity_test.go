@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollaboratorEntity_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *Collaborator
+		err   error
+	}{
+		{"正常系: タスクを共有した場合", &Collaborator{ID: value.NewID("id"), TaskID: value.NewID("tid"), UserID: value.NewID("uid"), Role: value.RoleViewer, GrantedBy: value.NewID("owner")}, nil},
+		{"正常系: リストを共有した場合", &Collaborator{ID: value.NewID("id"), ListID: value.NewID("lid"), UserID: value.NewID("uid"), Role: value.RoleAdmin, GrantedBy: value.NewID("owner")}, nil},
+		{"準正常系: IDが空の場合", &Collaborator{ID: value.NewID(""), TaskID: value.NewID("tid"), UserID: value.NewID("uid"), Role: value.RoleViewer, GrantedBy: value.NewID("owner")}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: タスクとリストの両方がない場合", &Collaborator{ID: value.NewID("id"), UserID: value.NewID("uid"), Role: value.RoleViewer, GrantedBy: value.NewID("owner")}, &domain.ErrValidationFailed{Msg: "either task or list must be shared"}},
+		{"準正常系: タスクとリストの両方がある場合", &Collaborator{ID: value.NewID("id"), TaskID: value.NewID("tid"), ListID: value.NewID("lid"), UserID: value.NewID("uid"), Role: value.RoleViewer, GrantedBy: value.NewID("owner")}, &domain.ErrValidationFailed{Msg: "either task or list must be shared"}},
+		{"準正常系: TaskIDが空の場合", &Collaborator{ID: value.NewID("id"), TaskID: value.NewID(""), UserID: value.NewID("uid"), Role: value.RoleViewer, GrantedBy: value.NewID("owner")}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: UserIDが空の場合", &Collaborator{ID: value.NewID("id"), TaskID: value.NewID("tid"), UserID: value.NewID(""), Role: value.RoleViewer, GrantedBy: value.NewID("owner")}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: 所有者の権限を付与する場合", &Collaborator{ID: value.NewID("id"), TaskID: value.NewID("tid"), UserID: value.NewID("uid"), Role: value.RoleOwner, GrantedBy: value.NewID("owner")}, &domain.ErrValidationFailed{Msg: "invalid role"}},
+		{"準正常系: GrantedByが空の場合", &Collaborator{ID: value.NewID("id"), TaskID: value.NewID("tid"), UserID: value.NewID("uid"), Role: value.RoleViewer, GrantedBy: value.NewID("")}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
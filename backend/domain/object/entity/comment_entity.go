@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+)
+
+// タスクへのコメント
+type Comment struct {
+	ID     *value.ID
+	TaskID *value.ID
+	// コメントを書いたユーザー
+	UserID    *value.ID
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// 本文を編集した日時。編集していない場合はnil
+	EditedAt *time.Time
+}
+
+// フィールドの妥当性を検証する
+func (c *Comment) Validate() error {
+	if err := c.ID.Validate(); err != nil {
+		return err
+	}
+	if err := c.TaskID.Validate(); err != nil {
+		return err
+	}
+	if err := c.UserID.Validate(); err != nil {
+		return err
+	}
+	if c.Body == "" {
+		return &domain.ErrValidationFailed{Msg: "body is empty"}
+	}
+	return nil
+}
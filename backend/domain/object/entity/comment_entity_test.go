@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentEntity_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *Comment
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", &Comment{ID: value.NewID("id"), TaskID: value.NewID("tid"), UserID: value.NewID("uid"), Body: "comment"}, nil},
+		{"準正常系: IDが空の場合", &Comment{ID: value.NewID(""), TaskID: value.NewID("tid"), UserID: value.NewID("uid"), Body: "comment"}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: TaskIDが空の場合", &Comment{ID: value.NewID("id"), TaskID: value.NewID(""), UserID: value.NewID("uid"), Body: "comment"}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: UserIDが空の場合", &Comment{ID: value.NewID("id"), TaskID: value.NewID("tid"), UserID: value.NewID(""), Body: "comment"}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: 本文が空の場合", &Comment{ID: value.NewID("id"), TaskID: value.NewID("tid"), UserID: value.NewID("uid"), Body: ""}, &domain.ErrValidationFailed{Msg: "body is empty"}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+)
+
+// タスクをまとめるリスト(プロジェクト)
+type List struct {
+	ID     *value.ID
+	UserID *value.ID
+	Name   string
+	// リストを指定せずに作成したタスクが入るリスト。ユーザーごとに1つだけ存在する
+	IsInbox    bool
+	IsArchived bool
+	Position   int32
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	// リストが属するワークスペース。個人のリストの場合はnil
+	WorkspaceID *value.ID
+}
+
+// Inboxのリスト名
+const InboxListName = "Inbox"
+
+// フィールドの妥当性を検証する
+func (l *List) Validate() error {
+	if err := l.ID.Validate(); err != nil {
+		return err
+	}
+	if err := l.UserID.Validate(); err != nil {
+		return err
+	}
+	if l.Name == "" {
+		return &domain.ErrValidationFailed{Msg: "name is empty"}
+	}
+	if l.Position < 0 {
+		return &domain.ErrValidationFailed{Msg: "position must be 0 or more"}
+	}
+	if l.IsInbox && l.IsArchived {
+		return &domain.ErrValidationFailed{Msg: "inbox cannot be archived"}
+	}
+	if l.WorkspaceID != nil {
+		if err := l.WorkspaceID.Validate(); err != nil {
+			return err
+		}
+		if l.IsInbox {
+			return &domain.ErrValidationFailed{Msg: "inbox cannot belong to a workspace"}
+		}
+	}
+	return nil
+}
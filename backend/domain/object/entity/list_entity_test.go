@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListEntity_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *List
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", &List{ID: value.NewID("id"), UserID: value.NewID("uid"), Name: "list"}, nil},
+		{"正常系: アーカイブされている場合", &List{ID: value.NewID("id"), UserID: value.NewID("uid"), Name: "list", IsArchived: true}, nil},
+		{"準正常系: IDが空の場合", &List{ID: value.NewID(""), UserID: value.NewID("uid"), Name: "list"}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: UserIDが空の場合", &List{ID: value.NewID("id"), UserID: value.NewID(""), Name: "list"}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: nameが空の場合", &List{ID: value.NewID("id"), UserID: value.NewID("uid"), Name: ""}, &domain.ErrValidationFailed{Msg: "name is empty"}},
+		{"準正常系: 表示順が負の場合", &List{ID: value.NewID("id"), UserID: value.NewID("uid"), Name: "list", Position: -1}, &domain.ErrValidationFailed{Msg: "position must be 0 or more"}},
+		{"準正常系: Inboxがアーカイブされている場合", &List{ID: value.NewID("id"), UserID: value.NewID("uid"), Name: InboxListName, IsInbox: true, IsArchived: true}, &domain.ErrValidationFailed{Msg: "inbox cannot be archived"}},
+		{"正常系: ワークスペースのリストの場合", &List{ID: value.NewID("id"), UserID: value.NewID("uid"), Name: "list", WorkspaceID: value.NewID("wid")}, nil},
+		{"準正常系: WorkspaceIDが空の場合", &List{ID: value.NewID("id"), UserID: value.NewID("uid"), Name: "list", WorkspaceID: value.NewID("")}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: Inboxがワークスペースに属する場合", &List{ID: value.NewID("id"), UserID: value.NewID("uid"), Name: InboxListName, IsInbox: true, WorkspaceID: value.NewID("wid")}, &domain.ErrValidationFailed{Msg: "inbox cannot belong to a workspace"}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
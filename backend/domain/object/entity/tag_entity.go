@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+)
+
+type Tag struct {
+	ID        *value.ID
+	UserID    *value.ID
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// タグが付いているタスクの数。一覧取得時のみ集計される
+	UsageCount int64
+}
+
+// フィールドの妥当性を検証する
+func (t *Tag) Validate() error {
+	if err := t.ID.Validate(); err != nil {
+		return err
+	}
+	if err := t.UserID.Validate(); err != nil {
+		return err
+	}
+	if t.Name == "" {
+		return &domain.ErrValidationFailed{Msg: "name is empty"}
+	}
+	return nil
+}
@@ -8,12 +8,30 @@ import (
 )
 
 type Task struct {
-	ID          *value.ID
-	UserID      *value.ID
-	Name        string
-	IsCompleted bool
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID              *value.ID
+	UserID          *value.ID
+	Name            string
+	Status          value.TaskStatus
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DueAt           *time.Time
+	Priority        value.Priority
+	Description     string
+	DescriptionHTML string
+	ParentID        *value.ID
+	ListID          *value.ID
+	// リスト内の手動の並び順
+	Position value.Rank
+	// 繰り返しのルール。繰り返さない場合は空
+	RecurrenceRule value.RecurrenceRule
+	// 次の回の日時を計算するタイムゾーン
+	RecurrenceTimezone string
+	// 未完了のブロッカーが残っているか。一覧取得時のみ設定される
+	IsBlocked bool
+	// ゴミ箱に移動した日時。ゴミ箱にない場合はnil
+	DeletedAt *time.Time
+	// 担当者のID。作成者(UserID)とは別に設定する。担当者がいない場合はnil
+	AssigneeID *value.ID
 }
 
 // フィールドの妥当性を検証する
@@ -24,8 +42,41 @@ func (t *Task) Validate() error {
 	if err := t.UserID.Validate(); err != nil {
 		return err
 	}
+	if err := t.ListID.Validate(); err != nil {
+		return err
+	}
+	if t.ParentID != nil {
+		if err := t.ParentID.Validate(); err != nil {
+			return err
+		}
+		if t.ParentID.Equal(t.ID.Value()) {
+			return &domain.ErrValidationFailed{Msg: "task cannot be its own parent"}
+		}
+	}
+	if t.AssigneeID != nil {
+		if err := t.AssigneeID.Validate(); err != nil {
+			return err
+		}
+	}
 	if t.Name == "" {
 		return &domain.ErrValidationFailed{Msg: "name is empty"}
 	}
+	if err := t.Priority.Validate(); err != nil {
+		return err
+	}
+	if err := t.Status.Validate(); err != nil {
+		return err
+	}
+	if err := t.Position.Validate(); err != nil {
+		return err
+	}
+	if t.RecurrenceRule != "" {
+		if err := t.RecurrenceRule.Validate(); err != nil {
+			return err
+		}
+		if t.RecurrenceTimezone == "" {
+			return &domain.ErrValidationFailed{Msg: "recurrence timezone is empty"}
+		}
+	}
 	return nil
 }
@@ -14,10 +14,25 @@ func TestTaskEntity_Validate(tt *testing.T) {
 		arg   *Task
 		err   error
 	}{
-		{"正常系: 正しい入力の場合", &Task{ID: value.NewID("id"), UserID: value.NewID("uid"), Name: "task"}, nil},
-		{"準正常系: IDが空の場合", &Task{ID: value.NewID(""), UserID: value.NewID("uid"), Name: "task"}, &domain.ErrValidationFailed{Msg: "id is empty"}},
-		{"準正常系: UserIDが空の場合", &Task{ID: value.NewID("id"), UserID: value.NewID(""), Name: "task"}, &domain.ErrValidationFailed{Msg: "id is empty"}},
-		{"準正常系: nameが空の場合", &Task{ID: value.NewID("id"), UserID: value.NewID("uid"), Name: ""}, &domain.ErrValidationFailed{Msg: "name is empty"}},
+		{"正常系: 正しい入力の場合", &Task{ID: value.NewID("id"), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Position: "i", Name: "task"}, nil},
+		{"準正常系: IDが空の場合", &Task{ID: value.NewID(""), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Position: "i", Name: "task"}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: UserIDが空の場合", &Task{ID: value.NewID("id"), UserID: value.NewID(""), ListID: value.NewID("lid"), Position: "i", Name: "task"}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: ListIDが空の場合", &Task{ID: value.NewID("id"), UserID: value.NewID("uid"), ListID: value.NewID(""), Position: "i", Name: "task"}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: nameが空の場合", &Task{ID: value.NewID("id"), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Position: "i", Name: ""}, &domain.ErrValidationFailed{Msg: "name is empty"}},
+		{"正常系: 優先度が設定されている場合", &Task{ID: value.NewID("id"), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Position: "i", Name: "task", Priority: value.PriorityUrgent}, nil},
+		{"正常系: 親タスクが設定されている場合", &Task{ID: value.NewID("id"), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Position: "i", Name: "task", ParentID: value.NewID("pid")}, nil},
+		{"準正常系: 親タスクのIDが空の場合", &Task{ID: value.NewID("id"), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Position: "i", Name: "task", ParentID: value.NewID("")}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: 自分自身が親タスクの場合", &Task{ID: value.NewID("id"), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Position: "i", Name: "task", ParentID: value.NewID("id")}, &domain.ErrValidationFailed{Msg: "task cannot be its own parent"}},
+		{"正常系: 担当者が設定されている場合", &Task{ID: value.NewID("id"), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Position: "i", Name: "task", AssigneeID: value.NewID("aid")}, nil},
+		{"準正常系: 担当者のIDが空の場合", &Task{ID: value.NewID("id"), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Position: "i", Name: "task", AssigneeID: value.NewID("")}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: 優先度が不正な場合", &Task{ID: value.NewID("id"), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Position: "i", Name: "task", Priority: value.PriorityUnknown}, &domain.ErrValidationFailed{Msg: "invalid priority"}},
+		{"正常系: 状態が中止の場合", &Task{ID: value.NewID("id"), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Position: "i", Name: "task", Status: value.TaskStatusCancelled}, nil},
+		{"準正常系: 状態が不正な場合", &Task{ID: value.NewID("id"), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Position: "i", Name: "task", Status: value.TaskStatusUnknown}, &domain.ErrValidationFailed{Msg: "invalid status"}},
+		{"準正常系: 位置が空の場合", &Task{ID: value.NewID("id"), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Position: "", Name: "task"}, &domain.ErrValidationFailed{Msg: "rank is empty"}},
+		{"準正常系: 位置が不正な場合", &Task{ID: value.NewID("id"), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Position: "I", Name: "task"}, &domain.ErrValidationFailed{Msg: "rank contains invalid characters"}},
+		{"正常系: 繰り返しが設定されている場合", &Task{ID: value.NewID("id"), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Position: "i", Name: "task", RecurrenceRule: "FREQ=DAILY", RecurrenceTimezone: "Asia/Tokyo"}, nil},
+		{"準正常系: 繰り返しのルールが不正な場合", &Task{ID: value.NewID("id"), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Position: "i", Name: "task", RecurrenceRule: "FREQ=HOURLY", RecurrenceTimezone: "Asia/Tokyo"}, &domain.ErrValidationFailed{Msg: "unsupported recurrence frequency"}},
+		{"準正常系: 繰り返しのタイムゾーンが空の場合", &Task{ID: value.NewID("id"), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Position: "i", Name: "task", RecurrenceRule: "FREQ=DAILY"}, &domain.ErrValidationFailed{Msg: "recurrence timezone is empty"}},
 	}
 	for _, v := range testcases {
 		tt.Run(v.title, func(t *testing.T) {
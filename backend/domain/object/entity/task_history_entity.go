@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+)
+
+// タスクの変更履歴
+type TaskHistory struct {
+	ID     *value.ID
+	TaskID *value.ID
+	// 変更したユーザー。ユーザーが削除された場合はnil
+	ActorID *value.ID
+	Action  value.TaskHistoryAction
+	// 変更前の値。作成の場合は空
+	OldValue string
+	// 変更後の値
+	NewValue  string
+	CreatedAt time.Time
+}
+
+// フィールドの妥当性を検証する
+func (h *TaskHistory) Validate() error {
+	if err := h.ID.Validate(); err != nil {
+		return err
+	}
+	if err := h.TaskID.Validate(); err != nil {
+		return err
+	}
+	if h.ActorID != nil {
+		if err := h.ActorID.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := h.Action.Validate(); err != nil {
+		return err
+	}
+	// 取り消しのスタックにのみ記録する操作は変更履歴には記録しない
+	if !h.Action.IsHistory() {
+		return &domain.ErrValidationFailed{Msg: "invalid history action"}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskHistoryEntity_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *TaskHistory
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", &TaskHistory{ID: value.NewID("id"), TaskID: value.NewID("tid"), ActorID: value.NewID("uid"), Action: value.TaskHistoryActionRenamed}, nil},
+		{"正常系: 変更したユーザーが削除されている場合", &TaskHistory{ID: value.NewID("id"), TaskID: value.NewID("tid"), Action: value.TaskHistoryActionCreated}, nil},
+		{"準正常系: IDが空の場合", &TaskHistory{ID: value.NewID(""), TaskID: value.NewID("tid"), ActorID: value.NewID("uid")}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: TaskIDが空の場合", &TaskHistory{ID: value.NewID("id"), TaskID: value.NewID(""), ActorID: value.NewID("uid")}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: ActorIDが空の場合", &TaskHistory{ID: value.NewID("id"), TaskID: value.NewID("tid"), ActorID: value.NewID("")}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: 操作の種類が不正な場合", &TaskHistory{ID: value.NewID("id"), TaskID: value.NewID("tid"), ActorID: value.NewID("uid"), Action: value.TaskHistoryAction(99)}, &domain.ErrValidationFailed{Msg: "invalid history action"}},
+		{"準正常系: 取り消しのスタックにのみ記録する操作の場合", &TaskHistory{ID: value.NewID("id"), TaskID: value.NewID("tid"), ActorID: value.NewID("uid"), Action: value.TaskHistoryActionPositionChanged}, &domain.ErrValidationFailed{Msg: "invalid history action"}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
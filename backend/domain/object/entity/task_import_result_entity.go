@@ -0,0 +1,15 @@
+package entity
+
+import "github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+
+// タスクのインポートの1行の結果
+type TaskImportResult struct {
+	// ファイル内の行番号
+	Line   int32
+	Name   string
+	Status value.TaskImportStatus
+	// 作成したタスクのID。作成しなかった場合と試行の場合は空
+	TaskID string
+	// 失敗した場合のエラー。失敗以外の場合はnil
+	Err error
+}
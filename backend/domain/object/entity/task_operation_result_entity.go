@@ -0,0 +1,12 @@
+package entity
+
+import "github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+
+// タスクの一括操作の1件の結果
+type TaskOperationResult struct {
+	Status value.TaskOperationStatus
+	// 操作したタスクのID。作成に成功した場合は作成したタスクのID
+	TaskID string
+	// 失敗した場合のエラー。成功した場合と取り消された場合はnil
+	Err error
+}
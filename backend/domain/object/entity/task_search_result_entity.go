@@ -0,0 +1,18 @@
+package entity
+
+// 検索語に一致した部分の前後に挿入される文字。タスクの名前や説明には現れない私用領域の文字を使用する
+const (
+	HighlightStart = "\uE000"
+	HighlightStop  = "\uE001"
+)
+
+// タスクの全文検索の結果
+type TaskSearchResult struct {
+	Task *Task
+	// 検索語との関連度。値が大きいほど関連が高い
+	Rank float32
+	// 一致した部分をHighlightStartとHighlightStopで囲んだ名前
+	NameHeadline string
+	// 一致した部分をHighlightStartとHighlightStopで囲んだ説明の抜粋
+	DescriptionHeadline string
+}
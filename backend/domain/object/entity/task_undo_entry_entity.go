@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+)
+
+// 取り消しまたはやり直しができるタスクの操作
+type TaskUndoEntry struct {
+	ID     *value.ID
+	UserID *value.ID
+	Stack  value.UndoStack
+	TaskID *value.ID
+	// 操作の種類と変更前後の値。名前と状態の変更はTaskHistoryと同じ形式、それ以外は操作の種類ごとの形式で記録する
+	Action   value.TaskHistoryAction
+	OldValue string
+	NewValue string
+	// 繰り返しのタスクを完了にしたときに作成した次の回のタスク。それ以外の場合はnil
+	NextTaskID *value.ID
+	CreatedAt  time.Time
+}
+
+// フィールドの妥当性を検証する
+func (e *TaskUndoEntry) Validate() error {
+	if err := e.ID.Validate(); err != nil {
+		return err
+	}
+	if err := e.UserID.Validate(); err != nil {
+		return err
+	}
+	if err := e.Stack.Validate(); err != nil {
+		return err
+	}
+	if err := e.TaskID.Validate(); err != nil {
+		return err
+	}
+	if err := e.Action.Validate(); err != nil {
+		return err
+	}
+	if e.NextTaskID != nil {
+		if err := e.NextTaskID.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskUndoEntryEntity_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *TaskUndoEntry
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", &TaskUndoEntry{ID: value.NewID("id"), UserID: value.NewID("uid"), TaskID: value.NewID("tid"), Action: value.TaskHistoryActionRenamed}, nil},
+		{"正常系: 次の回のタスクがある場合", &TaskUndoEntry{ID: value.NewID("id"), UserID: value.NewID("uid"), Stack: value.UndoStackRedo, TaskID: value.NewID("tid"), Action: value.TaskHistoryActionStatusChanged, NextTaskID: value.NewID("next")}, nil},
+		{"準正常系: IDが空の場合", &TaskUndoEntry{ID: value.NewID(""), UserID: value.NewID("uid"), TaskID: value.NewID("tid")}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: UserIDが空の場合", &TaskUndoEntry{ID: value.NewID("id"), UserID: value.NewID(""), TaskID: value.NewID("tid")}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: スタックの種類が不正な場合", &TaskUndoEntry{ID: value.NewID("id"), UserID: value.NewID("uid"), Stack: value.UndoStack(2), TaskID: value.NewID("tid")}, &domain.ErrValidationFailed{Msg: "invalid undo stack"}},
+		{"準正常系: TaskIDが空の場合", &TaskUndoEntry{ID: value.NewID("id"), UserID: value.NewID("uid"), TaskID: value.NewID("")}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: 操作の種類が不正な場合", &TaskUndoEntry{ID: value.NewID("id"), UserID: value.NewID("uid"), TaskID: value.NewID("tid"), Action: value.TaskHistoryAction(99)}, &domain.ErrValidationFailed{Msg: "invalid history action"}},
+		{"準正常系: NextTaskIDが空の場合", &TaskUndoEntry{ID: value.NewID("id"), UserID: value.NewID("uid"), TaskID: value.NewID("tid"), NextTaskID: value.NewID("")}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
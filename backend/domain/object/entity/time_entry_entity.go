@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+)
+
+// タスクの作業時間
+type TimeEntry struct {
+	ID *value.ID
+	// 作業したユーザー
+	UserID    *value.ID
+	TaskID    *value.ID
+	StartedAt time.Time
+	// 計測中のタイマーはnil
+	EndedAt   *time.Time
+	Note      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// タスク名。一覧取得時のみ設定する
+	TaskName string
+}
+
+// フィールドの妥当性を検証する
+func (e *TimeEntry) Validate() error {
+	if err := e.ID.Validate(); err != nil {
+		return err
+	}
+	if err := e.UserID.Validate(); err != nil {
+		return err
+	}
+	if err := e.TaskID.Validate(); err != nil {
+		return err
+	}
+	if e.StartedAt.IsZero() {
+		return &domain.ErrValidationFailed{Msg: "started_at is empty"}
+	}
+	if e.EndedAt != nil && e.EndedAt.Before(e.StartedAt) {
+		return &domain.ErrValidationFailed{Msg: "ended_at must not be before started_at"}
+	}
+	return nil
+}
+
+// タイマーが計測中かどうか
+func (e *TimeEntry) IsRunning() bool {
+	return e.EndedAt == nil
+}
+
+// 作業した時間。計測中の場合はnowまでの時間
+func (e *TimeEntry) Duration(now time.Time) time.Duration {
+	end := now
+	if e.EndedAt != nil {
+		end = *e.EndedAt
+	}
+	if end.Before(e.StartedAt) {
+		return 0
+	}
+	return end.Sub(e.StartedAt)
+}
+
+// 期間内の作業時間の集計
+type TimeSummary struct {
+	Total time.Duration
+	// 作業時間の長い順
+	ByTask []*TaskTimeTotal
+	// 日付の古い順。作業していない日は含まない
+	ByDay []*DayTimeTotal
+}
+
+// タスクごとの作業時間の合計
+type TaskTimeTotal struct {
+	TaskID   *value.ID
+	TaskName string
+	Duration time.Duration
+}
+
+// 日ごとの作業時間の合計
+type DayTimeTotal struct {
+	// YYYY-MM-DD形式の日付
+	Date     string
+	Duration time.Duration
+}
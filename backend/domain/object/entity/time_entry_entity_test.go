@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeEntryEntity_Validate(tt *testing.T) {
+	start := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	before := start.Add(-time.Second)
+	testcases := []struct {
+		title string
+		arg   *TimeEntry
+		err   error
+	}{
+		{"正常系: 終了した作業時間の場合", &TimeEntry{ID: value.NewID("id"), UserID: value.NewID("uid"), TaskID: value.NewID("tid"), StartedAt: start, EndedAt: &end}, nil},
+		{"正常系: 計測中の場合", &TimeEntry{ID: value.NewID("id"), UserID: value.NewID("uid"), TaskID: value.NewID("tid"), StartedAt: start}, nil},
+		{"正常系: 開始日時と終了日時が同じ場合", &TimeEntry{ID: value.NewID("id"), UserID: value.NewID("uid"), TaskID: value.NewID("tid"), StartedAt: start, EndedAt: &start}, nil},
+		{"準正常系: IDが空の場合", &TimeEntry{ID: value.NewID(""), UserID: value.NewID("uid"), TaskID: value.NewID("tid"), StartedAt: start}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: UserIDが空の場合", &TimeEntry{ID: value.NewID("id"), UserID: value.NewID(""), TaskID: value.NewID("tid"), StartedAt: start}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: TaskIDが空の場合", &TimeEntry{ID: value.NewID("id"), UserID: value.NewID("uid"), TaskID: value.NewID(""), StartedAt: start}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: 開始日時が空の場合", &TimeEntry{ID: value.NewID("id"), UserID: value.NewID("uid"), TaskID: value.NewID("tid")}, &domain.ErrValidationFailed{Msg: "started_at is empty"}},
+		{"準正常系: 終了日時が開始日時より前の場合", &TimeEntry{ID: value.NewID("id"), UserID: value.NewID("uid"), TaskID: value.NewID("tid"), StartedAt: start, EndedAt: &before}, &domain.ErrValidationFailed{Msg: "ended_at must not be before started_at"}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
+
+func TestTimeEntryEntity_Duration(tt *testing.T) {
+	start := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Minute)
+	now := start.Add(2 * time.Hour)
+	testcases := []struct {
+		title string
+		arg   *TimeEntry
+		now   time.Time
+		exp   time.Duration
+	}{
+		{"正常系: 終了した作業時間の場合", &TimeEntry{StartedAt: start, EndedAt: &end}, now, 90 * time.Minute},
+		{"正常系: 計測中の場合は現在日時までの時間", &TimeEntry{StartedAt: start}, now, 2 * time.Hour},
+		{"正常系: 現在日時が開始日時より前の場合は0", &TimeEntry{StartedAt: start}, start.Add(-time.Minute), 0},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			require.Equal(t, v.exp, v.arg.Duration(v.now), "作業時間が一致すること")
+		})
+	}
+}
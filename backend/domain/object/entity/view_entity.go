@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+)
+
+// 名前を付けて保存したタスク一覧の条件
+type View struct {
+	ID     *value.ID
+	UserID *value.ID
+	Name   string
+	Query  value.ViewQuery
+	// 組み込みのビューは全てのユーザーで共通のため変更や削除はできない
+	IsBuiltin bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// フィールドの妥当性を検証する
+func (v *View) Validate() error {
+	if err := v.ID.Validate(); err != nil {
+		return err
+	}
+	if err := v.UserID.Validate(); err != nil {
+		return err
+	}
+	if v.Name == "" {
+		return &domain.ErrValidationFailed{Msg: "name is empty"}
+	}
+	return v.Query.Validate()
+}
+
+// ビューの実行結果でまとめたタスク。まとめない場合はKeyが空のグループが1つになる
+type TaskGroup struct {
+	Key   string
+	Tasks []*Task
+}
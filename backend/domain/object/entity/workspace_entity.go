@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+)
+
+// 複数のユーザーでリストとタスクを共有するワークスペース
+type Workspace struct {
+	ID   *value.ID
+	Name string
+	// 取得したユーザーの権限。ユーザーのワークスペースの一覧を取得した場合のみ設定される
+	Role      value.WorkspaceRole
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// フィールドの妥当性を検証する
+func (w *Workspace) Validate() error {
+	if err := w.ID.Validate(); err != nil {
+		return err
+	}
+	if w.Name == "" {
+		return &domain.ErrValidationFailed{Msg: "name is empty"}
+	}
+	return nil
+}
+
+// ワークスペースのメンバー
+type WorkspaceMember struct {
+	WorkspaceID *value.ID
+	UserID      *value.ID
+	// メンバーのメールアドレス。一覧を取得した場合のみ設定される
+	Email     string
+	Role      value.WorkspaceRole
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// フィールドの妥当性を検証する
+func (m *WorkspaceMember) Validate() error {
+	if err := m.WorkspaceID.Validate(); err != nil {
+		return err
+	}
+	if err := m.UserID.Validate(); err != nil {
+		return err
+	}
+	if err := m.Role.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ワークスペースへの招待。招待されたメールアドレスのユーザーが承諾するとメンバーになる
+type WorkspaceInvitation struct {
+	ID          *value.ID
+	WorkspaceID *value.ID
+	// ワークスペースの名前。招待されたユーザーの招待の一覧を取得した場合のみ設定される
+	WorkspaceName string
+	Email         *value.Email
+	// 承諾した場合に付与される権限。所有者の権限は付与できない
+	Role value.WorkspaceRole
+	// 招待したユーザー
+	InvitedBy *value.ID
+	CreatedAt time.Time
+}
+
+// フィールドの妥当性を検証する
+func (i *WorkspaceInvitation) Validate() error {
+	if err := i.ID.Validate(); err != nil {
+		return err
+	}
+	if err := i.WorkspaceID.Validate(); err != nil {
+		return err
+	}
+	if err := i.Email.Validate(); err != nil {
+		return err
+	}
+	if err := i.Role.Validate(); err != nil {
+		return err
+	}
+	if i.Role == value.WorkspaceRoleOwner {
+		return &domain.ErrValidationFailed{Msg: "owner cannot be invited"}
+	}
+	if err := i.InvitedBy.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceEntity_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *Workspace
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", &Workspace{ID: value.NewID("id"), Name: "team"}, nil},
+		{"準正常系: IDが空の場合", &Workspace{ID: value.NewID(""), Name: "team"}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: 名前が空の場合", &Workspace{ID: value.NewID("id"), Name: ""}, &domain.ErrValidationFailed{Msg: "name is empty"}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
+
+func TestWorkspaceMemberEntity_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *WorkspaceMember
+		err   error
+	}{
+		{"正常系: メンバーの場合", &WorkspaceMember{WorkspaceID: value.NewID("wid"), UserID: value.NewID("uid"), Role: value.WorkspaceRoleMember}, nil},
+		{"正常系: 所有者の場合", &WorkspaceMember{WorkspaceID: value.NewID("wid"), UserID: value.NewID("uid"), Role: value.WorkspaceRoleOwner}, nil},
+		{"準正常系: WorkspaceIDが空の場合", &WorkspaceMember{WorkspaceID: value.NewID(""), UserID: value.NewID("uid"), Role: value.WorkspaceRoleMember}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: UserIDが空の場合", &WorkspaceMember{WorkspaceID: value.NewID("wid"), UserID: value.NewID(""), Role: value.WorkspaceRoleMember}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: 権限がない場合", &WorkspaceMember{WorkspaceID: value.NewID("wid"), UserID: value.NewID("uid"), Role: value.WorkspaceRoleNone}, &domain.ErrValidationFailed{Msg: "invalid workspace role"}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
+
+func TestWorkspaceInvitationEntity_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *WorkspaceInvitation
+		err   error
+	}{
+		{"正常系: メンバーとして招待した場合", &WorkspaceInvitation{ID: value.NewID("id"), WorkspaceID: value.NewID("wid"), Email: value.NewEmail("test@example.com"), Role: value.WorkspaceRoleMember, InvitedBy: value.NewID("uid")}, nil},
+		{"正常系: 管理者として招待した場合", &WorkspaceInvitation{ID: value.NewID("id"), WorkspaceID: value.NewID("wid"), Email: value.NewEmail("test@example.com"), Role: value.WorkspaceRoleAdmin, InvitedBy: value.NewID("uid")}, nil},
+		{"準正常系: IDが空の場合", &WorkspaceInvitation{ID: value.NewID(""), WorkspaceID: value.NewID("wid"), Email: value.NewEmail("test@example.com"), Role: value.WorkspaceRoleMember, InvitedBy: value.NewID("uid")}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+		{"準正常系: メールアドレスが空の場合", &WorkspaceInvitation{ID: value.NewID("id"), WorkspaceID: value.NewID("wid"), Email: value.NewEmail(""), Role: value.WorkspaceRoleMember, InvitedBy: value.NewID("uid")}, &domain.ErrValidationFailed{Msg: "email is empty"}},
+		{"準正常系: 権限が不明の場合", &WorkspaceInvitation{ID: value.NewID("id"), WorkspaceID: value.NewID("wid"), Email: value.NewEmail("test@example.com"), Role: value.WorkspaceRoleUnknown, InvitedBy: value.NewID("uid")}, &domain.ErrValidationFailed{Msg: "invalid workspace role"}},
+		{"準正常系: 所有者として招待した場合", &WorkspaceInvitation{ID: value.NewID("id"), WorkspaceID: value.NewID("wid"), Email: value.NewEmail("test@example.com"), Role: value.WorkspaceRoleOwner, InvitedBy: value.NewID("uid")}, &domain.ErrValidationFailed{Msg: "owner cannot be invited"}},
+		{"準正常系: InvitedByが空の場合", &WorkspaceInvitation{ID: value.NewID("id"), WorkspaceID: value.NewID("wid"), Email: value.NewEmail("test@example.com"), Role: value.WorkspaceRoleMember, InvitedBy: value.NewID("")}, &domain.ErrValidationFailed{Msg: "id is empty"}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package value
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+)
+
+// キーセットページングの位置。前のページの最後の要素の日時とIDを保持する
+type PageCursor struct {
+	Time time.Time
+	ID   string
+}
+
+// クライアントに渡すページトークンに変換する
+func (c *PageCursor) Encode() string {
+	raw := strconv.FormatInt(c.Time.UnixNano(), 10) + ":" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ページトークンを位置に変換する。トークンが空の場合は先頭のページとしてnilを返す
+func DecodePageCursor(token string) (*PageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, &domain.ErrValidationFailed{Msg: "invalid page token"}
+	}
+	nsec, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return nil, &domain.ErrValidationFailed{Msg: "invalid page token"}
+	}
+	n, err := strconv.ParseInt(nsec, 10, 64)
+	if err != nil {
+		return nil, &domain.ErrValidationFailed{Msg: "invalid page token"}
+	}
+	return &PageCursor{Time: time.Unix(0, n).UTC(), ID: id}, nil
+}
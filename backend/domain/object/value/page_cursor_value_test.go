@@ -0,0 +1,51 @@
+package value
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageCursor_Encode(tt *testing.T) {
+	tt.Run("正常系: 変換したトークンから元の位置に戻せること", func(t *testing.T) {
+		c := &PageCursor{Time: time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC), ID: "id:1"}
+		res, err := DecodePageCursor(c.Encode())
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, c, res, "位置が一致すること")
+	})
+}
+
+func TestPageCursor_DecodePageCursor(tt *testing.T) {
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+	testcases := []struct {
+		title string
+		arg   string
+		exp   *PageCursor
+		err   error
+	}{
+		{"正常系: 空の場合は先頭のページになること", "", nil, nil},
+		{"正常系: 正しいトークンの場合", encode("1000:id"), &PageCursor{Time: time.Unix(0, 1000).UTC(), ID: "id"}, nil},
+		{"準正常系: base64ではない場合", "***", nil, errors.New("invalid page token")},
+		{"準正常系: 区切りがない場合", encode("1000"), nil, errors.New("invalid page token")},
+		{"準正常系: IDが空の場合", encode("1000:"), nil, errors.New("invalid page token")},
+		{"準正常系: 日時が数値ではない場合", encode("abc:id"), nil, errors.New("invalid page token")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			res, err := DecodePageCursor(v.arg)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, v.exp, res, "位置が一致すること")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
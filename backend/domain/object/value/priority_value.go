@@ -0,0 +1,27 @@
+package value
+
+import "github.com/7oh2020/connect-tasklist/backend/domain"
+
+// タスクの優先度。値が大きいほど優先度が高い
+type Priority int32
+
+const (
+	// 不明な優先度。入力値の変換に失敗した場合に使用する
+	PriorityUnknown Priority = -1
+	PriorityNone    Priority = 0
+	PriorityLow     Priority = 1
+	PriorityMedium  Priority = 2
+	PriorityHigh    Priority = 3
+	PriorityUrgent  Priority = 4
+)
+
+func (p Priority) Value() int32 {
+	return int32(p)
+}
+
+func (p Priority) Validate() error {
+	if p < PriorityNone || p > PriorityUrgent {
+		return &domain.ErrValidationFailed{Msg: "invalid priority"}
+	}
+	return nil
+}
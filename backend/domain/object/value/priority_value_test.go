@@ -0,0 +1,35 @@
+package value
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriority_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   Priority
+		err   error
+	}{
+		{"正常系: 優先度なしの場合", PriorityNone, nil},
+		{"正常系: 優先度が低の場合", PriorityLow, nil},
+		{"正常系: 優先度が中の場合", PriorityMedium, nil},
+		{"正常系: 優先度が高の場合", PriorityHigh, nil},
+		{"正常系: 優先度が緊急の場合", PriorityUrgent, nil},
+		{"準正常系: 優先度が不明の場合", PriorityUnknown, errors.New("invalid priority")},
+		{"準正常系: 優先度が範囲外の場合", Priority(5), errors.New("invalid priority")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package value
+
+import (
+	"strings"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+)
+
+// 手動の並び順を表すキー。辞書順で比較し、2つのキーの間に常に新しいキーを作成できる
+type Rank string
+
+// キーに使用する文字。バイト順に並んでいる
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// キーの最大長。DBのカラム長と一致させる
+const rankMaxLength = 255
+
+func (r Rank) Value() string {
+	return string(r)
+}
+
+func (r Rank) Validate() error {
+	if r == "" {
+		return &domain.ErrValidationFailed{Msg: "rank is empty"}
+	}
+	if len(r) > rankMaxLength {
+		return &domain.ErrValidationFailed{Msg: "rank is too long"}
+	}
+	for _, c := range r {
+		if !strings.ContainsRune(rankDigits, c) {
+			return &domain.ErrValidationFailed{Msg: "rank contains invalid characters"}
+		}
+	}
+	// 末尾が最小の文字の場合はその直前にキーを作成できないため許可しない
+	if r[len(r)-1] == rankDigits[0] {
+		return &domain.ErrValidationFailed{Msg: "rank must not end with 0"}
+	}
+	return nil
+}
+
+// beforeとafterの間に並ぶキーを作成する。beforeが空の場合は先頭、afterが空の場合は末尾を表す
+func RankBetween(before Rank, after Rank) (Rank, error) {
+	if before != "" {
+		if err := before.Validate(); err != nil {
+			return "", err
+		}
+	}
+	if after != "" {
+		if err := after.Validate(); err != nil {
+			return "", err
+		}
+	}
+	if before != "" && after != "" && before >= after {
+		return "", &domain.ErrValidationFailed{Msg: "rank range is invalid"}
+	}
+	return Rank(rankMidpoint(string(before), string(after))), nil
+}
+
+// n個のキーを等間隔に作成する。キーの長さは全て同じになる
+func SpreadRanks(n int) []Rank {
+	base := len(rankDigits)
+	// 隣り合うキーの間隔が2以上になる長さを求める
+	length, capacity := 1, base
+	for capacity < 2*(n+1) {
+		length++
+		capacity *= base
+	}
+	step := capacity / (n + 1)
+	ranks := make([]Rank, n)
+	for i := range ranks {
+		v := step * (i + 1)
+		// 末尾が最小の文字にならないようにずらす。間隔が2以上あるため順序は変わらない
+		if v%base == 0 {
+			v++
+		}
+		buf := make([]byte, length)
+		for j := length - 1; j >= 0; j-- {
+			buf[j] = rankDigits[v%base]
+			v /= base
+		}
+		ranks[i] = Rank(buf)
+	}
+	return ranks
+}
+
+// aとbの中間のキーを求める。aが空の場合は最小、bが空の場合は最大として扱う
+func rankMidpoint(a string, b string) string {
+	if b != "" {
+		// 共通の接頭辞はそのまま残す。aが短い場合は最小の文字で埋めて比較する
+		n := 0
+		for n < len(b) && rankDigitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + rankMidpoint(rest, b[n:])
+		}
+	}
+	digitA := 0
+	if a != "" {
+		digitA = strings.IndexByte(rankDigits, a[0])
+	}
+	digitB := len(rankDigits)
+	if b != "" {
+		digitB = strings.IndexByte(rankDigits, b[0])
+	}
+	if digitB-digitA > 1 {
+		return string(rankDigits[(digitA+digitB+1)/2])
+	}
+	// 先頭の文字が隣り合っている場合
+	if len(b) > 1 {
+		return b[:1]
+	}
+	rest := ""
+	if len(a) > 1 {
+		rest = a[1:]
+	}
+	return string(rankDigits[digitA]) + rankMidpoint(rest, "")
+}
+
+func rankDigitAt(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return rankDigits[0]
+}
@@ -0,0 +1,108 @@
+package value
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRank_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   Rank
+		err   error
+	}{
+		{"正常系: 1文字の場合", Rank("i"), nil},
+		{"正常系: 複数文字の場合", Rank("00000001i"), nil},
+		{"準正常系: 空の場合", Rank(""), errors.New("rank is empty")},
+		{"準正常系: 長すぎる場合", Rank(strings.Repeat("i", 256)), errors.New("rank is too long")},
+		{"準正常系: 大文字を含む場合", Rank("aB"), errors.New("rank contains invalid characters")},
+		{"準正常系: 末尾が0の場合", Rank("i0"), errors.New("rank must not end with 0")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
+
+func TestRank_RankBetween(tt *testing.T) {
+	testcases := []struct {
+		title  string
+		before Rank
+		after  Rank
+		exp    Rank
+		err    error
+	}{
+		{"正常系: 両方が空の場合", "", "", "i", nil},
+		{"正常系: 先頭に追加する場合", "", "i", "9", nil},
+		{"正常系: 末尾に追加する場合", "i", "", "r", nil},
+		{"正常系: 間に余裕がある場合", "a", "c", "b", nil},
+		{"正常系: 先頭の文字が隣り合う場合", "a", "b", "ai", nil},
+		{"正常系: 共通の接頭辞がある場合", "ab", "ad", "ac", nil},
+		{"正常系: afterが長い場合", "a", "bi", "b", nil},
+		{"正常系: 最小のキーの前に追加する場合", "", "01", "00i", nil},
+		{"準正常系: 順序が逆の場合", "b", "a", "", errors.New("rank range is invalid")},
+		{"準正常系: 同じキーの場合", "a", "a", "", errors.New("rank range is invalid")},
+		{"準正常系: 不正なキーの場合", "a0", "", "", errors.New("rank must not end with 0")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			ret, err := RankBetween(v.before, v.after)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, v.exp, ret)
+				require.NoError(t, ret.Validate(), "作成したキーが正しいこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+	tt.Run("正常系: 先頭に繰り返し追加しても順序が保たれること", func(t *testing.T) {
+		var first Rank
+		for i := 0; i < 100; i++ {
+			ret, err := RankBetween("", first)
+			require.NoError(t, err, "エラーが発生しないこと")
+			if first != "" {
+				require.Less(t, string(ret), string(first))
+			}
+			first = ret
+		}
+	})
+}
+
+func TestRank_SpreadRanks(tt *testing.T) {
+	testcases := []struct {
+		title  string
+		n      int
+		length int
+	}{
+		{"正常系: 0個の場合", 0, 0},
+		{"正常系: 1個の場合", 1, 1},
+		{"正常系: 1文字で足りない場合", 20, 2},
+		{"正常系: 多数の場合", 1000, 3},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			ret := SpreadRanks(v.n)
+
+			require.Len(t, ret, v.n)
+			for i, r := range ret {
+				require.NoError(t, r.Validate(), "作成したキーが正しいこと")
+				require.Len(t, string(r), v.length)
+				if i > 0 {
+					require.Less(t, string(ret[i-1]), string(r), "昇順に並ぶこと")
+				}
+			}
+		})
+	}
+}
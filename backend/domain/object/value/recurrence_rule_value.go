@@ -0,0 +1,268 @@
+package value
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+)
+
+// RFC 5545のRRULEによる繰り返しのルール。FREQ、INTERVAL、BYDAY、COUNT、UNTILに対応する
+// 例: FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10
+type RecurrenceRule string
+
+const (
+	RecurrenceDaily   = "DAILY"
+	RecurrenceWeekly  = "WEEKLY"
+	RecurrenceMonthly = "MONTHLY"
+	RecurrenceYearly  = "YEARLY"
+)
+
+// ルールの最大長。DBのカラム長と一致させる
+const recurrenceRuleMaxLength = 255
+
+// INTERVALとCOUNTの上限
+const recurrenceMaxNumber = 999
+
+// 存在しない日付(31日や2月29日など)をスキップして次の日付を探す最大の回数
+const recurrenceMaxSkip = 100
+
+// UNTILの形式。日付のみの場合はタイムゾーンでの日付で比較する
+const (
+	recurrenceUntilDateLayout     = "20060102"
+	recurrenceUntilDateTimeLayout = "20060102T150405Z"
+)
+
+// BYDAYの曜日。月曜日から順に並べる
+var recurrenceWeekdays = []struct {
+	code    string
+	weekday time.Weekday
+}{
+	{"MO", time.Monday},
+	{"TU", time.Tuesday},
+	{"WE", time.Wednesday},
+	{"TH", time.Thursday},
+	{"FR", time.Friday},
+	{"SA", time.Saturday},
+	{"SU", time.Sunday},
+}
+
+// 解析済みのルール
+type recurrence struct {
+	freq      string
+	interval  int
+	byDay     map[time.Weekday]bool
+	count     int
+	until     string
+	untilTime time.Time
+}
+
+func (r RecurrenceRule) Value() string {
+	return string(r)
+}
+
+func (r RecurrenceRule) Validate() error {
+	_, err := parseRecurrenceRule(string(r))
+	return err
+}
+
+// afterより後の次の日時を求める。時刻とタイムゾーンはafterのものを引き継ぐ
+// 繰り返しが終了している場合はfalseを返す
+func (r RecurrenceRule) Next(after time.Time) (time.Time, bool, error) {
+	rec, err := parseRecurrenceRule(string(r))
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	// COUNTはこのルールを持つタスクを含む残りの回数
+	if rec.count == 1 {
+		return time.Time{}, false, nil
+	}
+	next, ok := rec.next(after)
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	if rec.until != "" {
+		if rec.untilTime.IsZero() {
+			if next.Format(recurrenceUntilDateLayout) > rec.until {
+				return time.Time{}, false, nil
+			}
+		} else if next.After(rec.untilTime) {
+			return time.Time{}, false, nil
+		}
+	}
+	return next, true, nil
+}
+
+// 次の回のタスクに引き継ぐルールを作成する。COUNTが指定されている場合は1減らす
+func (r RecurrenceRule) NextRule() (RecurrenceRule, error) {
+	rec, err := parseRecurrenceRule(string(r))
+	if err != nil {
+		return "", err
+	}
+	if rec.count > 1 {
+		rec.count--
+	}
+	return RecurrenceRule(rec.String()), nil
+}
+
+func parseRecurrenceRule(s string) (*recurrence, error) {
+	if s == "" {
+		return nil, &domain.ErrValidationFailed{Msg: "recurrence rule is empty"}
+	}
+	if len(s) > recurrenceRuleMaxLength {
+		return nil, &domain.ErrValidationFailed{Msg: "recurrence rule is too long"}
+	}
+	rec := &recurrence{interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, &domain.ErrValidationFailed{Msg: "invalid recurrence rule"}
+		}
+		if seen[key] {
+			return nil, &domain.ErrValidationFailed{Msg: "duplicate recurrence rule part"}
+		}
+		seen[key] = true
+		switch key {
+		case "FREQ":
+			switch val {
+			case RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly, RecurrenceYearly:
+				rec.freq = val
+			default:
+				return nil, &domain.ErrValidationFailed{Msg: "unsupported recurrence frequency"}
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 || n > recurrenceMaxNumber {
+				return nil, &domain.ErrValidationFailed{Msg: "invalid INTERVAL"}
+			}
+			rec.interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 || n > recurrenceMaxNumber {
+				return nil, &domain.ErrValidationFailed{Msg: "invalid COUNT"}
+			}
+			rec.count = n
+		case "UNTIL":
+			if t, err := time.Parse(recurrenceUntilDateTimeLayout, val); err == nil {
+				rec.untilTime = t
+			} else if _, err := time.Parse(recurrenceUntilDateLayout, val); err != nil {
+				return nil, &domain.ErrValidationFailed{Msg: "invalid UNTIL"}
+			}
+			rec.until = val
+		case "BYDAY":
+			rec.byDay = make(map[time.Weekday]bool)
+			for _, code := range strings.Split(val, ",") {
+				weekday, ok := recurrenceWeekday(code)
+				if !ok {
+					return nil, &domain.ErrValidationFailed{Msg: "invalid BYDAY"}
+				}
+				rec.byDay[weekday] = true
+			}
+		default:
+			return nil, &domain.ErrValidationFailed{Msg: "unsupported recurrence rule part"}
+		}
+	}
+	if rec.freq == "" {
+		return nil, &domain.ErrValidationFailed{Msg: "FREQ is required"}
+	}
+	if rec.count > 0 && rec.until != "" {
+		return nil, &domain.ErrValidationFailed{Msg: "COUNT and UNTIL cannot be used together"}
+	}
+	if rec.byDay != nil && rec.freq != RecurrenceDaily && rec.freq != RecurrenceWeekly {
+		return nil, &domain.ErrValidationFailed{Msg: "BYDAY is only supported with DAILY or WEEKLY"}
+	}
+	return rec, nil
+}
+
+// 正規化した文字列に変換する
+func (rec *recurrence) String() string {
+	parts := []string{"FREQ=" + rec.freq}
+	if rec.interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(rec.interval))
+	}
+	if rec.byDay != nil {
+		codes := make([]string, 0, len(rec.byDay))
+		for _, v := range recurrenceWeekdays {
+			if rec.byDay[v.weekday] {
+				codes = append(codes, v.code)
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if rec.count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(rec.count))
+	}
+	if rec.until != "" {
+		parts = append(parts, "UNTIL="+rec.until)
+	}
+	return strings.Join(parts, ";")
+}
+
+// UNTILとCOUNTを考慮せずに次の日時を求める
+func (rec *recurrence) next(after time.Time) (time.Time, bool) {
+	switch rec.freq {
+	case RecurrenceDaily:
+		next := addDays(after, rec.interval)
+		if rec.byDay == nil {
+			return next, true
+		}
+		// 曜日は7日周期のため7回以内に見つからない場合は該当する日が存在しない
+		for i := 0; i < 7; i++ {
+			if rec.byDay[next.Weekday()] {
+				return next, true
+			}
+			next = addDays(next, rec.interval)
+		}
+		return time.Time{}, false
+	case RecurrenceWeekly:
+		if rec.byDay == nil {
+			return addDays(after, 7*rec.interval), true
+		}
+		// 週の始まりは月曜日とする
+		offset := (int(after.Weekday()) + 6) % 7
+		for i := offset + 1; i < 7; i++ {
+			if rec.byDay[recurrenceWeekdays[i].weekday] {
+				return addDays(after, i-offset), true
+			}
+		}
+		monday := addDays(after, 7*rec.interval-offset)
+		for i := 0; i < 7; i++ {
+			if rec.byDay[recurrenceWeekdays[i].weekday] {
+				return addDays(monday, i), true
+			}
+		}
+		return time.Time{}, false
+	case RecurrenceMonthly:
+		return addPeriods(after, 0, rec.interval)
+	case RecurrenceYearly:
+		return addPeriods(after, rec.interval, 0)
+	}
+	return time.Time{}, false
+}
+
+// 壁時計の時刻を保ったままn日後の日時を求める
+func addDays(t time.Time, n int) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day()+n, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// 同じ日付が存在する次の年または月の日時を求める。存在しない日付はスキップする
+func addPeriods(t time.Time, years int, months int) (time.Time, bool) {
+	for i := 1; i <= recurrenceMaxSkip; i++ {
+		next := time.Date(t.Year()+years*i, t.Month()+time.Month(months*i), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+		if next.Day() == t.Day() {
+			return next, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func recurrenceWeekday(code string) (time.Weekday, bool) {
+	for _, v := range recurrenceWeekdays {
+		if v.code == code {
+			return v.weekday, true
+		}
+	}
+	return time.Sunday, false
+}
@@ -0,0 +1,119 @@
+package value
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecurrenceRule_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   RecurrenceRule
+		err   error
+	}{
+		{"正常系: FREQのみの場合", "FREQ=DAILY", nil},
+		{"正常系: 全ての項目を指定した場合", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10", nil},
+		{"正常系: UNTILが日付の場合", "FREQ=MONTHLY;UNTIL=20261231", nil},
+		{"正常系: UNTILが日時の場合", "FREQ=YEARLY;UNTIL=20301231T150000Z", nil},
+		{"準正常系: 空の場合", "", errors.New("recurrence rule is empty")},
+		{"準正常系: 長すぎる場合", RecurrenceRule("FREQ=DAILY;BYDAY=" + strings.Repeat("MO,", 100) + "MO"), errors.New("recurrence rule is too long")},
+		{"準正常系: 形式が不正な場合", "FREQ", errors.New("invalid recurrence rule")},
+		{"準正常系: 値が空の場合", "FREQ=", errors.New("invalid recurrence rule")},
+		{"準正常系: 項目が重複する場合", "FREQ=DAILY;FREQ=WEEKLY", errors.New("duplicate recurrence rule part")},
+		{"準正常系: FREQがない場合", "INTERVAL=2", errors.New("FREQ is required")},
+		{"準正常系: 未対応のFREQの場合", "FREQ=HOURLY", errors.New("unsupported recurrence frequency")},
+		{"準正常系: 未対応の項目の場合", "FREQ=YEARLY;BYMONTH=1", errors.New("unsupported recurrence rule part")},
+		{"準正常系: INTERVALが0の場合", "FREQ=DAILY;INTERVAL=0", errors.New("invalid INTERVAL")},
+		{"準正常系: INTERVALが大きすぎる場合", "FREQ=DAILY;INTERVAL=1000", errors.New("invalid INTERVAL")},
+		{"準正常系: COUNTが数値でない場合", "FREQ=DAILY;COUNT=x", errors.New("invalid COUNT")},
+		{"準正常系: UNTILが不正な場合", "FREQ=DAILY;UNTIL=2026-12-31", errors.New("invalid UNTIL")},
+		{"準正常系: BYDAYが不正な場合", "FREQ=WEEKLY;BYDAY=MO,XX", errors.New("invalid BYDAY")},
+		{"準正常系: BYDAYに序数を指定した場合", "FREQ=WEEKLY;BYDAY=1MO", errors.New("invalid BYDAY")},
+		{"準正常系: COUNTとUNTILを両方指定した場合", "FREQ=DAILY;COUNT=3;UNTIL=20261231", errors.New("COUNT and UNTIL cannot be used together")},
+		{"準正常系: MONTHLYでBYDAYを指定した場合", "FREQ=MONTHLY;BYDAY=MO", errors.New("BYDAY is only supported with DAILY or WEEKLY")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
+
+func TestRecurrenceRule_Next(tt *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(tt, err, "エラーが発生しないこと")
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(tt, err, "エラーが発生しないこと")
+	// 2026-10-14は水曜日
+	wed := time.Date(2026, 10, 14, 9, 0, 0, 0, tokyo)
+
+	testcases := []struct {
+		title string
+		rule  RecurrenceRule
+		after time.Time
+		exp   time.Time
+		ok    bool
+	}{
+		{"正常系: 毎日の場合", "FREQ=DAILY", wed, time.Date(2026, 10, 15, 9, 0, 0, 0, tokyo), true},
+		{"正常系: 3日ごとの場合", "FREQ=DAILY;INTERVAL=3", wed, time.Date(2026, 10, 17, 9, 0, 0, 0, tokyo), true},
+		{"正常系: 平日のみの場合は週末をスキップすること", "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR", time.Date(2026, 10, 16, 9, 0, 0, 0, tokyo), time.Date(2026, 10, 19, 9, 0, 0, 0, tokyo), true},
+		{"正常系: 毎週の場合", "FREQ=WEEKLY", wed, time.Date(2026, 10, 21, 9, 0, 0, 0, tokyo), true},
+		{"正常系: 同じ週の後の曜日がある場合", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", wed, time.Date(2026, 10, 16, 9, 0, 0, 0, tokyo), true},
+		{"正常系: 同じ週の後の曜日がない場合はINTERVAL週後になること", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TU", wed, time.Date(2026, 10, 26, 9, 0, 0, 0, tokyo), true},
+		{"正常系: 日曜日は週の最後として扱うこと", "FREQ=WEEKLY;BYDAY=SU", wed, time.Date(2026, 10, 18, 9, 0, 0, 0, tokyo), true},
+		{"正常系: 毎月の場合", "FREQ=MONTHLY", wed, time.Date(2026, 11, 14, 9, 0, 0, 0, tokyo), true},
+		{"正常系: 存在しない日付はスキップすること", "FREQ=MONTHLY", time.Date(2026, 1, 31, 9, 0, 0, 0, tokyo), time.Date(2026, 3, 31, 9, 0, 0, 0, tokyo), true},
+		{"正常系: 毎年の場合", "FREQ=YEARLY", wed, time.Date(2027, 10, 14, 9, 0, 0, 0, tokyo), true},
+		{"正常系: 2月29日の場合は次のうるう年になること", "FREQ=YEARLY", time.Date(2028, 2, 29, 9, 0, 0, 0, tokyo), time.Date(2032, 2, 29, 9, 0, 0, 0, tokyo), true},
+		{"正常系: 夏時間の切り替えをまたぐ場合は現地時刻を保つこと", "FREQ=DAILY", time.Date(2026, 10, 31, 9, 0, 0, 0, newYork), time.Date(2026, 11, 1, 9, 0, 0, 0, newYork), true},
+		{"正常系: COUNTが残っている場合", "FREQ=DAILY;COUNT=2", wed, time.Date(2026, 10, 15, 9, 0, 0, 0, tokyo), true},
+		{"正常系: UNTILの日付と同じ日の場合", "FREQ=DAILY;UNTIL=20261015", wed, time.Date(2026, 10, 15, 9, 0, 0, 0, tokyo), true},
+		{"正常系: COUNTが最後の回の場合は終了すること", "FREQ=DAILY;COUNT=1", wed, time.Time{}, false},
+		{"正常系: UNTILの日付を過ぎる場合は終了すること", "FREQ=DAILY;UNTIL=20261014", wed, time.Time{}, false},
+		{"正常系: UNTILの日時を過ぎる場合は終了すること", "FREQ=DAILY;UNTIL=20261014T235959Z", wed, time.Time{}, false},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			ret, ok, err := v.rule.Next(v.after)
+
+			require.NoError(t, err, "エラーが発生しないこと")
+			require.Equal(t, v.ok, ok, "繰り返しの有無が一致すること")
+			require.True(t, v.exp.Equal(ret), "日時が一致すること")
+		})
+	}
+	tt.Run("準正常系: ルールが不正な場合", func(t *testing.T) {
+		_, _, err := RecurrenceRule("FREQ=HOURLY").Next(wed)
+
+		require.EqualError(t, err, "unsupported recurrence frequency", "エラーが一致すること")
+	})
+}
+
+func TestRecurrenceRule_NextRule(tt *testing.T) {
+	testcases := []struct {
+		title string
+		rule  RecurrenceRule
+		exp   RecurrenceRule
+	}{
+		{"正常系: COUNTがない場合は正規化のみ行うこと", "BYDAY=WE,MO;FREQ=WEEKLY;INTERVAL=1", "FREQ=WEEKLY;BYDAY=MO,WE"},
+		{"正常系: COUNTがある場合は1減らすこと", "FREQ=DAILY;INTERVAL=2;COUNT=3", "FREQ=DAILY;INTERVAL=2;COUNT=2"},
+		{"正常系: UNTILがある場合は引き継ぐこと", "FREQ=MONTHLY;UNTIL=20261231", "FREQ=MONTHLY;UNTIL=20261231"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			ret, err := v.rule.NextRule()
+
+			require.NoError(t, err, "エラーが発生しないこと")
+			require.Equal(t, v.exp, ret)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package value
+
+import "github.com/7oh2020/connect-tasklist/backend/domain"
+
+// 共有したタスクやリストに対する権限。値が大きいほど多くの操作ができ、上位の権限は下位の権限の操作を全て含む
+type Role int32
+
+const (
+	// 不明な権限。入力値の変換に失敗した場合に使用する
+	RoleUnknown Role = -1
+	// 権限なし
+	RoleNone Role = 0
+	// 閲覧のみできる
+	RoleViewer Role = 1
+	// タスクの作成と編集ができる
+	RoleEditor Role = 2
+	// タスクの削除と共有の管理ができる
+	RoleAdmin Role = 3
+	// 所有者。共有によって付与することはできない
+	RoleOwner Role = 4
+)
+
+func (r Role) Value() int32 {
+	return int32(r)
+}
+
+// 共有によって付与できる権限か検証する
+func (r Role) Validate() error {
+	if r < RoleViewer || r > RoleAdmin {
+		return &domain.ErrValidationFailed{Msg: "invalid role"}
+	}
+	return nil
+}
+
+// required以上の権限を持つか判定する
+func (r Role) Includes(required Role) bool {
+	return r >= required
+}
@@ -0,0 +1,54 @@
+package value
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRole_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   Role
+		err   error
+	}{
+		{"正常系: 閲覧者の場合", RoleViewer, nil},
+		{"正常系: 編集者の場合", RoleEditor, nil},
+		{"正常系: 管理者の場合", RoleAdmin, nil},
+		{"準正常系: 権限なしの場合", RoleNone, errors.New("invalid role")},
+		{"準正常系: 所有者の場合", RoleOwner, errors.New("invalid role")},
+		{"準正常系: 権限が不明の場合", RoleUnknown, errors.New("invalid role")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
+
+func TestRole_Includes(tt *testing.T) {
+	testcases := []struct {
+		title    string
+		role     Role
+		required Role
+		expected bool
+	}{
+		{"正常系: 同じ権限の場合", RoleEditor, RoleEditor, true},
+		{"正常系: 上位の権限の場合", RoleAdmin, RoleViewer, true},
+		{"正常系: 所有者の場合", RoleOwner, RoleAdmin, true},
+		{"準正常系: 下位の権限の場合", RoleViewer, RoleEditor, false},
+		{"準正常系: 権限なしの場合", RoleNone, RoleViewer, false},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			require.Equal(t, v.expected, v.role.Includes(v.required))
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package value
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+)
+
+// 検索結果のキーセットページングの位置。前のページの最後の要素の順位とIDを保持する
+type SearchCursor struct {
+	Rank float32
+	ID   string
+}
+
+// クライアントに渡すページトークンに変換する
+func (c *SearchCursor) Encode() string {
+	raw := strconv.FormatFloat(float64(c.Rank), 'g', -1, 32) + ":" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ページトークンを位置に変換する。トークンが空の場合は先頭のページとしてnilを返す
+func DecodeSearchCursor(token string) (*SearchCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, &domain.ErrValidationFailed{Msg: "invalid page token"}
+	}
+	rank, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return nil, &domain.ErrValidationFailed{Msg: "invalid page token"}
+	}
+	r, err := strconv.ParseFloat(rank, 32)
+	if err != nil {
+		return nil, &domain.ErrValidationFailed{Msg: "invalid page token"}
+	}
+	return &SearchCursor{Rank: float32(r), ID: id}, nil
+}
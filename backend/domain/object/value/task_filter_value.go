@@ -0,0 +1,64 @@
+package value
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+)
+
+// 完了状態によるタスクの絞り込み
+type TaskCompletion int32
+
+const (
+	// 全てのタスク
+	TaskCompletionAll TaskCompletion = 0
+	// 未着手、進行中、待機中のタスク
+	TaskCompletionOpen TaskCompletion = 1
+	// 完了または中止したタスク
+	TaskCompletionClosed TaskCompletion = 2
+)
+
+func (c TaskCompletion) Value() int32 {
+	return int32(c)
+}
+
+func (c TaskCompletion) Validate() error {
+	if c < TaskCompletionAll || c > TaskCompletionClosed {
+		return &domain.ErrValidationFailed{Msg: "invalid completion"}
+	}
+	return nil
+}
+
+// タスク一覧の絞り込みの条件。期間はFromを含みToを含まない。nilや空の条件では絞り込まない
+type TaskFilter struct {
+	Completion  TaskCompletion
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	UpdatedFrom *time.Time
+	UpdatedTo   *time.Time
+	DueFrom     *time.Time
+	DueTo       *time.Time
+	// trueの場合は期限が設定されていないタスクのみを対象にする
+	NoDueDate bool
+	// 名前に含まれる文字列。大文字と小文字は区別しない
+	NameContains string
+}
+
+func (f *TaskFilter) Validate() error {
+	if err := f.Completion.Validate(); err != nil {
+		return err
+	}
+	if f.CreatedFrom != nil && f.CreatedTo != nil && !f.CreatedFrom.Before(*f.CreatedTo) {
+		return &domain.ErrValidationFailed{Msg: "created_from must be before created_to"}
+	}
+	if f.UpdatedFrom != nil && f.UpdatedTo != nil && !f.UpdatedFrom.Before(*f.UpdatedTo) {
+		return &domain.ErrValidationFailed{Msg: "updated_from must be before updated_to"}
+	}
+	if f.DueFrom != nil && f.DueTo != nil && !f.DueFrom.Before(*f.DueTo) {
+		return &domain.ErrValidationFailed{Msg: "due_from must be before due_to"}
+	}
+	if f.NoDueDate && (f.DueFrom != nil || f.DueTo != nil) {
+		return &domain.ErrValidationFailed{Msg: "no_due_date cannot be combined with a due date range"}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package value
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskFilter_Validate(tt *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	testcases := []struct {
+		title string
+		arg   *TaskFilter
+		err   error
+	}{
+		{"正常系: 条件がない場合", &TaskFilter{}, nil},
+		{"正常系: 全ての条件を指定した場合", &TaskFilter{Completion: TaskCompletionOpen, CreatedFrom: &from, CreatedTo: &to, UpdatedFrom: &from, UpdatedTo: &to, DueFrom: &from, DueTo: &to, NameContains: "milk"}, nil},
+		{"正常系: 期限なしのみを指定した場合", &TaskFilter{NoDueDate: true}, nil},
+		{"正常系: 期間の片方のみ指定した場合", &TaskFilter{Completion: TaskCompletionClosed, CreatedFrom: &from, UpdatedTo: &to}, nil},
+		{"準正常系: 完了状態が範囲外の場合", &TaskFilter{Completion: TaskCompletion(3)}, errors.New("invalid completion")},
+		{"準正常系: 作成日時の期間が逆の場合", &TaskFilter{CreatedFrom: &to, CreatedTo: &from}, errors.New("created_from must be before created_to")},
+		{"準正常系: 作成日時の期間が空の場合", &TaskFilter{CreatedFrom: &from, CreatedTo: &from}, errors.New("created_from must be before created_to")},
+		{"準正常系: 更新日時の期間が逆の場合", &TaskFilter{UpdatedFrom: &to, UpdatedTo: &from}, errors.New("updated_from must be before updated_to")},
+		{"準正常系: 期限の期間が逆の場合", &TaskFilter{DueFrom: &to, DueTo: &from}, errors.New("due_from must be before due_to")},
+		{"準正常系: 期限なしと期限の期間を同時に指定した場合", &TaskFilter{NoDueDate: true, DueTo: &to}, errors.New("no_due_date cannot be combined with a due date range")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
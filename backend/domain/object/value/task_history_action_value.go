@@ -0,0 +1,58 @@
+package value
+
+import "github.com/7oh2020/connect-tasklist/backend/domain"
+
+// タスクの履歴に記録する操作の種類
+type TaskHistoryAction int32
+
+const (
+	TaskHistoryActionCreated TaskHistoryAction = 0
+	// 名前の変更。変更前と変更後の名前を記録する
+	TaskHistoryActionRenamed TaskHistoryAction = 1
+	// 状態の変更。変更前と変更後の状態の名前を記録する
+	TaskHistoryActionStatusChanged TaskHistoryAction = 2
+	// ゴミ箱への移動
+	TaskHistoryActionDeleted TaskHistoryAction = 3
+	// ゴミ箱からの復元
+	TaskHistoryActionRestored TaskHistoryAction = 4
+
+	// 以降の5から10の操作は取り消しまたはやり直しで戻した場合に履歴に記録する
+	// 優先度の変更。変更前と変更後の優先度の値を記録する
+	TaskHistoryActionPriorityChanged TaskHistoryAction = 5
+	// 説明文の変更。変更前と変更後の説明文と変換したHTMLをJSONで記録する
+	TaskHistoryActionDescriptionChanged TaskHistoryAction = 6
+	// 担当者の変更。変更前と変更後の担当者のIDを記録し、担当者がいない場合は空にする
+	TaskHistoryActionAssigneeChanged TaskHistoryAction = 7
+	// 期限の変更。変更前と変更後の期限をRFC 3339で記録し、期限がない場合は空にする
+	TaskHistoryActionDueDateChanged TaskHistoryAction = 8
+	// 繰り返しの変更。変更前と変更後のルールとタイムゾーンをJSONで記録する
+	TaskHistoryActionRecurrenceChanged TaskHistoryAction = 9
+	// 親タスクの変更。変更前と変更後の親タスクのIDを記録し、ルートのタスクの場合は空にする
+	TaskHistoryActionParentChanged TaskHistoryAction = 10
+
+	// 以降の操作は取り消しのスタックのみに記録し、履歴には記録しない
+	// リスト内の並び順の変更。変更前と変更後の位置のキーを記録する
+	TaskHistoryActionPositionChanged TaskHistoryAction = 11
+	// 別のリストへの移動。変更前と変更後のリストのIDと位置のキーをJSONで記録する
+	TaskHistoryActionListChanged TaskHistoryAction = 12
+	// 依存関係の追加。変更後の値に追加したブロッカーのIDを記録する
+	TaskHistoryActionDependencyAdded TaskHistoryAction = 13
+	// 依存関係の削除。変更前の値に削除したブロッカーのIDを記録する
+	TaskHistoryActionDependencyRemoved TaskHistoryAction = 14
+)
+
+func (a TaskHistoryAction) Value() int32 {
+	return int32(a)
+}
+
+func (a TaskHistoryAction) Validate() error {
+	if a < TaskHistoryActionCreated || a > TaskHistoryActionDependencyRemoved {
+		return &domain.ErrValidationFailed{Msg: "invalid history action"}
+	}
+	return nil
+}
+
+// 変更履歴に記録する操作の場合はtrueを返す
+func (a TaskHistoryAction) IsHistory() bool {
+	return a >= TaskHistoryActionCreated && a <= TaskHistoryActionParentChanged
+}
@@ -0,0 +1,57 @@
+package value
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskHistoryAction_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   TaskHistoryAction
+		err   error
+	}{
+		{"正常系: 作成の場合", TaskHistoryActionCreated, nil},
+		{"正常系: 名前の変更の場合", TaskHistoryActionRenamed, nil},
+		{"正常系: 状態の変更の場合", TaskHistoryActionStatusChanged, nil},
+		{"正常系: 削除の場合", TaskHistoryActionDeleted, nil},
+		{"正常系: 復元の場合", TaskHistoryActionRestored, nil},
+		{"準正常系: 負の値の場合", TaskHistoryAction(-1), errors.New("invalid history action")},
+		{"正常系: 優先度の変更の場合", TaskHistoryActionPriorityChanged, nil},
+		{"正常系: 依存関係の削除の場合", TaskHistoryActionDependencyRemoved, nil},
+		{"準正常系: 範囲外の場合", TaskHistoryAction(15), errors.New("invalid history action")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
+
+func TestTaskHistoryAction_IsHistory(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   TaskHistoryAction
+		exp   bool
+	}{
+		{"正常系: 作成の場合", TaskHistoryActionCreated, true},
+		{"正常系: 復元の場合", TaskHistoryActionRestored, true},
+		{"正常系: 優先度の変更の場合", TaskHistoryActionPriorityChanged, true},
+		{"正常系: 親タスクの変更の場合", TaskHistoryActionParentChanged, true},
+		{"正常系: 並び順の変更の場合", TaskHistoryActionPositionChanged, false},
+		{"正常系: 依存関係の削除の場合", TaskHistoryActionDependencyRemoved, false},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			require.Equal(t, v.exp, v.arg.IsHistory(), "結果が一致すること")
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package value
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+)
+
+// タスクをインポート・エクスポートするファイルの形式
+type TaskFileFormat int32
+
+const (
+	TaskFileFormatJSON TaskFileFormat = 0
+	TaskFileFormatCSV  TaskFileFormat = 1
+	// todo.txtの形式。1行が1タスクになる
+	TaskFileFormatTodoTxt TaskFileFormat = 2
+	// GitHub形式のMarkdownのチェックリスト(- [ ] foo)
+	TaskFileFormatMarkdown TaskFileFormat = 3
+)
+
+func (f TaskFileFormat) Value() int32 {
+	return int32(f)
+}
+
+func (f TaskFileFormat) Validate() error {
+	if f < TaskFileFormatJSON || f > TaskFileFormatMarkdown {
+		return &domain.ErrValidationFailed{Msg: "invalid file format"}
+	}
+	return nil
+}
+
+// インポートする1行の結果の状態
+type TaskImportStatus int32
+
+const (
+	TaskImportStatusCreated TaskImportStatus = 0
+	// 同じ名前のタスクがリストにあるため作成しなかった
+	TaskImportStatusDuplicate TaskImportStatus = 1
+	TaskImportStatusFailed    TaskImportStatus = 2
+)
+
+func (s TaskImportStatus) Value() int32 {
+	return int32(s)
+}
+
+// インポートするタスクの1行
+type TaskImportRow struct {
+	// ファイル内の行番号。JSONの場合は配列の何番目かを1から数える
+	Line        int32
+	Name        string
+	Status      TaskStatus
+	Priority    Priority
+	DueAt       *time.Time
+	Description string
+	// 説明文から生成した表示用のHTML
+	DescriptionHTML string
+}
@@ -0,0 +1,32 @@
+package value
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskFileFormat_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   TaskFileFormat
+		err   error
+	}{
+		{"正常系: JSONの場合", TaskFileFormatJSON, nil},
+		{"正常系: Markdownの場合", TaskFileFormatMarkdown, nil},
+		{"準正常系: 負の値の場合", TaskFileFormat(-1), errors.New("invalid file format")},
+		{"準正常系: 範囲外の場合", TaskFileFormat(4), errors.New("invalid file format")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package value
+
+import "github.com/7oh2020/connect-tasklist/backend/domain"
+
+// タスクの一括操作の種類
+type TaskOperationKind int32
+
+const (
+	TaskOperationKindCreate     TaskOperationKind = 0
+	TaskOperationKindRename     TaskOperationKind = 1
+	TaskOperationKindComplete   TaskOperationKind = 2
+	TaskOperationKindUncomplete TaskOperationKind = 3
+	// ゴミ箱に移動する
+	TaskOperationKindDelete TaskOperationKind = 4
+)
+
+func (k TaskOperationKind) Value() int32 {
+	return int32(k)
+}
+
+func (k TaskOperationKind) Validate() error {
+	if k < TaskOperationKindCreate || k > TaskOperationKindDelete {
+		return &domain.ErrValidationFailed{Msg: "invalid operation"}
+	}
+	return nil
+}
+
+// 一括操作の1件の結果の状態
+type TaskOperationStatus int32
+
+const (
+	TaskOperationStatusSucceeded TaskOperationStatus = 0
+	TaskOperationStatusFailed    TaskOperationStatus = 1
+	// 他の操作が失敗したため取り消された、または実行されなかった
+	TaskOperationStatusAborted TaskOperationStatus = 2
+)
+
+func (s TaskOperationStatus) Value() int32 {
+	return int32(s)
+}
+
+// タスクの一括操作の1件
+type TaskOperation struct {
+	Kind TaskOperationKind
+	// 作成以外で操作するタスクのID
+	TaskID string
+	// 作成するリストのID。空の場合はInboxに作成する
+	ListID string
+	// 作成または変更後のタスク名
+	Name string
+}
+
+func (o TaskOperation) Validate() error {
+	if err := o.Kind.Validate(); err != nil {
+		return err
+	}
+	if o.Kind != TaskOperationKindCreate && o.TaskID == "" {
+		return &domain.ErrValidationFailed{Msg: "task_id is empty"}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package value
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskOperationKind_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   TaskOperationKind
+		err   error
+	}{
+		{"正常系: 作成の場合", TaskOperationKindCreate, nil},
+		{"正常系: 削除の場合", TaskOperationKindDelete, nil},
+		{"準正常系: 負の値の場合", TaskOperationKind(-1), errors.New("invalid operation")},
+		{"準正常系: 範囲外の場合", TaskOperationKind(5), errors.New("invalid operation")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
+
+func TestTaskOperation_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   TaskOperation
+		err   error
+	}{
+		{"正常系: タスクIDを指定せずに作成する場合", TaskOperation{Kind: TaskOperationKindCreate, Name: "task"}, nil},
+		{"正常系: タスクIDを指定して完了にする場合", TaskOperation{Kind: TaskOperationKindComplete, TaskID: "tid"}, nil},
+		{"準正常系: 作成以外でタスクIDが空の場合", TaskOperation{Kind: TaskOperationKindRename, Name: "task"}, errors.New("task_id is empty")},
+		{"準正常系: 操作の種類が不正な場合", TaskOperation{Kind: TaskOperationKind(9), TaskID: "tid"}, errors.New("invalid operation")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package value
+
+import "github.com/7oh2020/connect-tasklist/backend/domain"
+
+// タスク一覧の並び順
+type TaskOrder int32
+
+const (
+	// 更新日時の新しい順
+	TaskOrderUpdatedAt TaskOrder = 0
+	// 優先度の高い順、同じ優先度の場合は更新日時の新しい順
+	TaskOrderPriority TaskOrder = 1
+	// ユーザーが手動で並べた順
+	TaskOrderPosition TaskOrder = 2
+)
+
+func (o TaskOrder) Value() int32 {
+	return int32(o)
+}
+
+func (o TaskOrder) Validate() error {
+	if o < TaskOrderUpdatedAt || o > TaskOrderPosition {
+		return &domain.ErrValidationFailed{Msg: "invalid task order"}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package value
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskOrder_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   TaskOrder
+		err   error
+	}{
+		{"正常系: 更新日時順の場合", TaskOrderUpdatedAt, nil},
+		{"正常系: 優先度順の場合", TaskOrderPriority, nil},
+		{"正常系: 手動の並び順の場合", TaskOrderPosition, nil},
+		{"準正常系: 負の値の場合", TaskOrder(-1), errors.New("invalid task order")},
+		{"準正常系: 範囲外の場合", TaskOrder(3), errors.New("invalid task order")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
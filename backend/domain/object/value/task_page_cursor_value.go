@@ -0,0 +1,82 @@
+package value
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+)
+
+// タスク一覧のキーセットページングの位置。前のページの最後のタスクの並び替えの項目の値とIDを保持する
+// 並び替えの項目に応じてTime、Name、Priorityのいずれかを使用する
+type TaskPageCursor struct {
+	Sort     TaskSort
+	Time     time.Time
+	Name     string
+	Priority Priority
+	ID       string
+}
+
+// クライアントに渡すページトークンに変換する。名前に区切り文字が含まれても復元できるように値を最後に置く
+func (c *TaskPageCursor) Encode() string {
+	var key string
+	switch c.Sort.Field {
+	case TaskSortFieldName:
+		key = c.Name
+	case TaskSortFieldPriority:
+		key = strconv.FormatInt(int64(c.Priority), 10)
+	default:
+		key = strconv.FormatInt(c.Time.UnixNano(), 10)
+	}
+	raw := strings.Join([]string{
+		strconv.FormatInt(int64(c.Sort.Field), 10),
+		strconv.FormatBool(c.Sort.Descending),
+		c.ID,
+		key,
+	}, ":")
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ページトークンを位置に変換する。トークンが空の場合は先頭のページとしてnilを返す
+func DecodeTaskPageCursor(token string) (*TaskPageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	errInvalid := &domain.ErrValidationFailed{Msg: "invalid page token"}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalid
+	}
+	parts := strings.SplitN(string(raw), ":", 4)
+	if len(parts) != 4 || parts[2] == "" {
+		return nil, errInvalid
+	}
+	field, err := strconv.ParseInt(parts[0], 10, 32)
+	if err != nil || TaskSortField(field).Validate() != nil {
+		return nil, errInvalid
+	}
+	desc, err := strconv.ParseBool(parts[1])
+	if err != nil {
+		return nil, errInvalid
+	}
+	c := &TaskPageCursor{Sort: TaskSort{Field: TaskSortField(field), Descending: desc}, ID: parts[2]}
+	switch c.Sort.Field {
+	case TaskSortFieldName:
+		c.Name = parts[3]
+	case TaskSortFieldPriority:
+		n, err := strconv.ParseInt(parts[3], 10, 32)
+		if err != nil {
+			return nil, errInvalid
+		}
+		c.Priority = Priority(n)
+	default:
+		n, err := strconv.ParseInt(parts[3], 10, 64)
+		if err != nil {
+			return nil, errInvalid
+		}
+		c.Time = time.Unix(0, n).UTC()
+	}
+	return c, nil
+}
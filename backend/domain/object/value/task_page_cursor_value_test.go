@@ -0,0 +1,64 @@
+package value
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskPageCursor_Encode(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *TaskPageCursor
+	}{
+		{"正常系: 日時で並べた場合", &TaskPageCursor{Sort: TaskSort{Field: TaskSortFieldCreatedAt, Descending: true}, Time: time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC), ID: "id"}},
+		{"正常系: 名前に区切り文字が含まれる場合", &TaskPageCursor{Sort: TaskSort{Field: TaskSortFieldName}, Name: "a:b:c", ID: "id"}},
+		{"正常系: 優先度で並べた場合", &TaskPageCursor{Sort: TaskSort{Field: TaskSortFieldPriority, Descending: true}, Priority: PriorityHigh, ID: "id"}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			res, err := DecodeTaskPageCursor(v.arg.Encode())
+
+			require.NoError(t, err, "エラーが発生しないこと")
+			require.Equal(t, v.arg, res, "位置が一致すること")
+		})
+	}
+}
+
+func TestTaskPageCursor_DecodeTaskPageCursor(tt *testing.T) {
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+	testcases := []struct {
+		title string
+		arg   string
+		exp   *TaskPageCursor
+		err   error
+	}{
+		{"正常系: 空の場合は先頭のページになること", "", nil, nil},
+		{"正常系: 正しいトークンの場合", encode("0:true:id:1000"), &TaskPageCursor{Sort: TaskSort{Field: TaskSortFieldUpdatedAt, Descending: true}, Time: time.Unix(0, 1000).UTC(), ID: "id"}, nil},
+		{"正常系: 名前が空の場合", encode("2:false:id:"), &TaskPageCursor{Sort: TaskSort{Field: TaskSortFieldName}, ID: "id"}, nil},
+		{"準正常系: base64ではない場合", "***", nil, errors.New("invalid page token")},
+		{"準正常系: 要素が足りない場合", encode("0:true:id"), nil, errors.New("invalid page token")},
+		{"準正常系: IDが空の場合", encode("0:true::1000"), nil, errors.New("invalid page token")},
+		{"準正常系: 並び替えの項目が範囲外の場合", encode("9:true:id:1000"), nil, errors.New("invalid page token")},
+		{"準正常系: 向きが真偽値ではない場合", encode("0:yes:id:1000"), nil, errors.New("invalid page token")},
+		{"準正常系: 日時が数値ではない場合", encode("1:true:id:abc"), nil, errors.New("invalid page token")},
+		{"準正常系: 優先度が数値ではない場合", encode("3:true:id:abc"), nil, errors.New("invalid page token")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			res, err := DecodeTaskPageCursor(v.arg)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, v.exp, res, "位置が一致すること")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
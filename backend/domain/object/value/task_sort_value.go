@@ -0,0 +1,35 @@
+package value
+
+import "github.com/7oh2020/connect-tasklist/backend/domain"
+
+// ページ単位で取得するタスク一覧の並び替えの項目。同じ値の場合はIDの順に並べる
+type TaskSortField int32
+
+const (
+	TaskSortFieldUpdatedAt TaskSortField = 0
+	TaskSortFieldCreatedAt TaskSortField = 1
+	TaskSortFieldName      TaskSortField = 2
+	TaskSortFieldPriority  TaskSortField = 3
+)
+
+func (f TaskSortField) Value() int32 {
+	return int32(f)
+}
+
+func (f TaskSortField) Validate() error {
+	if f < TaskSortFieldUpdatedAt || f > TaskSortFieldPriority {
+		return &domain.ErrValidationFailed{Msg: "invalid sort field"}
+	}
+	return nil
+}
+
+// タスク一覧の並び替えの項目と向き
+type TaskSort struct {
+	Field TaskSortField
+	// trueの場合は降順
+	Descending bool
+}
+
+func (s TaskSort) Validate() error {
+	return s.Field.Validate()
+}
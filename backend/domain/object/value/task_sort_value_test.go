@@ -0,0 +1,34 @@
+package value
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskSort_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   TaskSort
+		err   error
+	}{
+		{"正常系: 更新日時の降順の場合", TaskSort{Field: TaskSortFieldUpdatedAt, Descending: true}, nil},
+		{"正常系: 作成日時の昇順の場合", TaskSort{Field: TaskSortFieldCreatedAt}, nil},
+		{"正常系: 名前順の場合", TaskSort{Field: TaskSortFieldName}, nil},
+		{"正常系: 優先度順の場合", TaskSort{Field: TaskSortFieldPriority, Descending: true}, nil},
+		{"準正常系: 負の値の場合", TaskSort{Field: TaskSortField(-1)}, errors.New("invalid sort field")},
+		{"準正常系: 範囲外の場合", TaskSort{Field: TaskSortField(4)}, errors.New("invalid sort field")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
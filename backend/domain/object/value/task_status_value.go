@@ -0,0 +1,61 @@
+package value
+
+import "github.com/7oh2020/connect-tasklist/backend/domain"
+
+// タスクの進捗状況
+type TaskStatus int32
+
+const (
+	// 不明な状態。入力値の変換に失敗した場合に使用する
+	TaskStatusUnknown    TaskStatus = -1
+	TaskStatusTodo       TaskStatus = 0
+	TaskStatusInProgress TaskStatus = 1
+	// 他の人や外部の作業を待っている状態
+	TaskStatusWaiting   TaskStatus = 2
+	TaskStatusDone      TaskStatus = 3
+	TaskStatusCancelled TaskStatus = 4
+)
+
+func (s TaskStatus) Value() int32 {
+	return int32(s)
+}
+
+func (s TaskStatus) Validate() error {
+	if s < TaskStatusTodo || s > TaskStatusCancelled {
+		return &domain.ErrValidationFailed{Msg: "invalid status"}
+	}
+	return nil
+}
+
+// 完了または中止により作業が終了しているか
+func (s TaskStatus) IsClosed() bool {
+	return s == TaskStatusDone || s == TaskStatusCancelled
+}
+
+// 履歴などに記録するための状態の名前
+func (s TaskStatus) String() string {
+	switch s {
+	case TaskStatusTodo:
+		return "todo"
+	case TaskStatusInProgress:
+		return "in_progress"
+	case TaskStatusWaiting:
+		return "waiting"
+	case TaskStatusDone:
+		return "done"
+	case TaskStatusCancelled:
+		return "cancelled"
+	default:
+		return "unknown"
+	}
+}
+
+// 履歴などに記録した状態の名前を状態に変換する。不明な名前の場合はTaskStatusUnknownを返す
+func ParseTaskStatus(name string) TaskStatus {
+	for s := TaskStatusTodo; s <= TaskStatusCancelled; s++ {
+		if s.String() == name {
+			return s
+		}
+	}
+	return TaskStatusUnknown
+}
@@ -0,0 +1,95 @@
+package value
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskStatus_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   TaskStatus
+		err   error
+	}{
+		{"正常系: 未着手の場合", TaskStatusTodo, nil},
+		{"正常系: 進行中の場合", TaskStatusInProgress, nil},
+		{"正常系: 待機中の場合", TaskStatusWaiting, nil},
+		{"正常系: 完了の場合", TaskStatusDone, nil},
+		{"正常系: 中止の場合", TaskStatusCancelled, nil},
+		{"準正常系: 状態が不明の場合", TaskStatusUnknown, errors.New("invalid status")},
+		{"準正常系: 状態が範囲外の場合", TaskStatus(5), errors.New("invalid status")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
+
+func TestTaskStatus_IsClosed(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   TaskStatus
+		exp   bool
+	}{
+		{"正常系: 未着手の場合", TaskStatusTodo, false},
+		{"正常系: 進行中の場合", TaskStatusInProgress, false},
+		{"正常系: 待機中の場合", TaskStatusWaiting, false},
+		{"正常系: 完了の場合", TaskStatusDone, true},
+		{"正常系: 中止の場合", TaskStatusCancelled, true},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			require.Equal(t, v.exp, v.arg.IsClosed())
+		})
+	}
+}
+
+func TestTaskStatus_String(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   TaskStatus
+		exp   string
+	}{
+		{"正常系: 未着手の場合", TaskStatusTodo, "todo"},
+		{"正常系: 進行中の場合", TaskStatusInProgress, "in_progress"},
+		{"正常系: 待機中の場合", TaskStatusWaiting, "waiting"},
+		{"正常系: 完了の場合", TaskStatusDone, "done"},
+		{"正常系: 中止の場合", TaskStatusCancelled, "cancelled"},
+		{"準正常系: 状態が不明の場合", TaskStatusUnknown, "unknown"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			require.Equal(t, v.exp, v.arg.String(), "名前が一致すること")
+		})
+	}
+}
+
+func TestTaskStatus_ParseTaskStatus(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   string
+		exp   TaskStatus
+	}{
+		{"正常系: 未着手の場合", "todo", TaskStatusTodo},
+		{"正常系: 進行中の場合", "in_progress", TaskStatusInProgress},
+		{"正常系: 待機中の場合", "waiting", TaskStatusWaiting},
+		{"正常系: 完了の場合", "done", TaskStatusDone},
+		{"正常系: 中止の場合", "cancelled", TaskStatusCancelled},
+		{"準正常系: 不明な名前の場合", "unknown", TaskStatusUnknown},
+		{"準正常系: 空の場合", "", TaskStatusUnknown},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			require.Equal(t, v.exp, ParseTaskStatus(v.arg), "状態が一致すること")
+		})
+	}
+}
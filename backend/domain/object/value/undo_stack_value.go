@@ -0,0 +1,24 @@
+package value
+
+import "github.com/7oh2020/connect-tasklist/backend/domain"
+
+// 操作を積むスタックの種類
+type UndoStack int32
+
+const (
+	// 取り消しができる操作
+	UndoStackUndo UndoStack = 0
+	// 取り消した操作のうち、やり直しができる操作
+	UndoStackRedo UndoStack = 1
+)
+
+func (s UndoStack) Value() int32 {
+	return int32(s)
+}
+
+func (s UndoStack) Validate() error {
+	if s != UndoStackUndo && s != UndoStackRedo {
+		return &domain.ErrValidationFailed{Msg: "invalid undo stack"}
+	}
+	return nil
+}
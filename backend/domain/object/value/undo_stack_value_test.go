@@ -0,0 +1,32 @@
+package value
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUndoStack_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   UndoStack
+		err   error
+	}{
+		{"正常系: 取り消しの場合", UndoStackUndo, nil},
+		{"正常系: やり直しの場合", UndoStackRedo, nil},
+		{"準正常系: 負の値の場合", UndoStack(-1), errors.New("invalid undo stack")},
+		{"準正常系: 範囲外の場合", UndoStack(2), errors.New("invalid undo stack")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
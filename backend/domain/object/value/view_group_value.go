@@ -0,0 +1,26 @@
+package value
+
+import "github.com/7oh2020/connect-tasklist/backend/domain"
+
+// 保存済みのビューでタスクをまとめる項目
+type ViewGroupBy int32
+
+const (
+	ViewGroupByNone     ViewGroupBy = 0
+	ViewGroupByStatus   ViewGroupBy = 1
+	ViewGroupByPriority ViewGroupBy = 2
+	ViewGroupByList     ViewGroupBy = 3
+	// 期限の日付
+	ViewGroupByDueDate ViewGroupBy = 4
+)
+
+func (g ViewGroupBy) Value() int32 {
+	return int32(g)
+}
+
+func (g ViewGroupBy) Validate() error {
+	if g < ViewGroupByNone || g > ViewGroupByDueDate {
+		return &domain.ErrValidationFailed{Msg: "invalid group by"}
+	}
+	return nil
+}
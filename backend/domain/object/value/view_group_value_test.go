@@ -0,0 +1,32 @@
+package value
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewGroupBy_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   ViewGroupBy
+		err   error
+	}{
+		{"正常系: まとめない場合", ViewGroupByNone, nil},
+		{"正常系: 期限の日付でまとめる場合", ViewGroupByDueDate, nil},
+		{"準正常系: 負の値の場合", ViewGroupBy(-1), errors.New("invalid group by")},
+		{"準正常系: 範囲外の場合", ViewGroupBy(5), errors.New("invalid group by")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
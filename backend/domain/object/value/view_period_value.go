@@ -0,0 +1,54 @@
+package value
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+)
+
+// 保存済みのビューで使用する期間。ビューを実行した日時を基準にする
+type ViewPeriod int32
+
+const (
+	// 絞り込まない
+	ViewPeriodAny ViewPeriod = 0
+	// 今日
+	ViewPeriodToday ViewPeriod = 1
+	// 月曜日から始まる今週
+	ViewPeriodThisWeek ViewPeriod = 2
+	// 今日より前
+	ViewPeriodPast ViewPeriod = 3
+	// 日時が設定されていない
+	ViewPeriodUnset ViewPeriod = 4
+)
+
+func (p ViewPeriod) Value() int32 {
+	return int32(p)
+}
+
+func (p ViewPeriod) Validate() error {
+	if p < ViewPeriodAny || p > ViewPeriodUnset {
+		return &domain.ErrValidationFailed{Msg: "invalid period"}
+	}
+	return nil
+}
+
+// nowのロケーションの暦で期間の範囲を返す。Fromを含みToを含まない。範囲の片方または両方がない場合はnilを返す
+func (p ViewPeriod) Range(now time.Time) (*time.Time, *time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	utc := func(t time.Time) *time.Time {
+		t = t.UTC()
+		return &t
+	}
+	switch p {
+	case ViewPeriodToday:
+		return utc(today), utc(today.AddDate(0, 0, 1))
+	case ViewPeriodThisWeek:
+		monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+		return utc(monday), utc(monday.AddDate(0, 0, 7))
+	case ViewPeriodPast:
+		return nil, utc(today)
+	default:
+		return nil, nil
+	}
+}
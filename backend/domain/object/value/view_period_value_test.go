@@ -0,0 +1,69 @@
+package value
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewPeriod_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   ViewPeriod
+		err   error
+	}{
+		{"正常系: 絞り込まない場合", ViewPeriodAny, nil},
+		{"正常系: 日時が設定されていない場合", ViewPeriodUnset, nil},
+		{"準正常系: 負の値の場合", ViewPeriod(-1), errors.New("invalid period")},
+		{"準正常系: 範囲外の場合", ViewPeriod(5), errors.New("invalid period")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
+
+func TestViewPeriod_Range(tt *testing.T) {
+	loc := time.FixedZone("JST", 9*60*60)
+	// 2024-01-03(水) 23:30 JST
+	now := time.Date(2024, 1, 3, 23, 30, 0, 0, loc)
+	date := func(day int) *time.Time {
+		t := time.Date(2024, 1, day, 0, 0, 0, 0, loc).UTC()
+		return &t
+	}
+	testcases := []struct {
+		title string
+		arg   ViewPeriod
+		from  *time.Time
+		to    *time.Time
+	}{
+		{"正常系: 絞り込まない場合", ViewPeriodAny, nil, nil},
+		{"正常系: 今日の場合はロケーションの暦の1日になること", ViewPeriodToday, date(3), date(4)},
+		{"正常系: 今週の場合は月曜日から7日間になること", ViewPeriodThisWeek, date(1), date(8)},
+		{"正常系: 今日より前の場合", ViewPeriodPast, nil, date(3)},
+		{"正常系: 日時が設定されていない場合", ViewPeriodUnset, nil, nil},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			from, to := v.arg.Range(now)
+
+			require.Equal(t, v.from, from)
+			require.Equal(t, v.to, to)
+		})
+	}
+	tt.Run("正常系: 日曜日の場合は前の月曜日から始まること", func(t *testing.T) {
+		from, to := ViewPeriodThisWeek.Range(time.Date(2024, 1, 7, 12, 0, 0, 0, loc))
+
+		require.Equal(t, date(1), from)
+		require.Equal(t, date(8), to)
+	})
+}
@@ -0,0 +1,50 @@
+package value
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+)
+
+// 保存済みのビューの条件。期間は実行時に日時の範囲に変換する
+type ViewQuery struct {
+	Completion TaskCompletion
+	Due        ViewPeriod
+	Updated    ViewPeriod
+	// 名前に含まれる文字列。大文字と小文字は区別しない
+	NameContains string
+	Sort         TaskSort
+	GroupBy      ViewGroupBy
+}
+
+func (q ViewQuery) Validate() error {
+	if err := q.Completion.Validate(); err != nil {
+		return err
+	}
+	if err := q.Due.Validate(); err != nil {
+		return err
+	}
+	if err := q.Updated.Validate(); err != nil {
+		return err
+	}
+	// 更新日時は必ず設定されている
+	if q.Updated == ViewPeriodUnset {
+		return &domain.ErrValidationFailed{Msg: "updated period cannot be unset"}
+	}
+	if err := q.Sort.Validate(); err != nil {
+		return err
+	}
+	return q.GroupBy.Validate()
+}
+
+// nowのロケーションの暦を基準にタスク一覧の絞り込みの条件に変換する
+func (q ViewQuery) Filter(now time.Time) *TaskFilter {
+	f := &TaskFilter{
+		Completion:   q.Completion,
+		NoDueDate:    q.Due == ViewPeriodUnset,
+		NameContains: q.NameContains,
+	}
+	f.DueFrom, f.DueTo = q.Due.Range(now)
+	f.UpdatedFrom, f.UpdatedTo = q.Updated.Range(now)
+	return f
+}
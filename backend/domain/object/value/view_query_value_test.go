@@ -0,0 +1,59 @@
+package value
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewQuery_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   ViewQuery
+		err   error
+	}{
+		{"正常系: 条件がない場合", ViewQuery{}, nil},
+		{"正常系: 全ての条件を指定した場合", ViewQuery{Completion: TaskCompletionOpen, Due: ViewPeriodUnset, Updated: ViewPeriodThisWeek, NameContains: "milk", Sort: TaskSort{Field: TaskSortFieldName}, GroupBy: ViewGroupByList}, nil},
+		{"準正常系: 完了状態が範囲外の場合", ViewQuery{Completion: TaskCompletion(3)}, errors.New("invalid completion")},
+		{"準正常系: 期限の期間が範囲外の場合", ViewQuery{Due: ViewPeriod(5)}, errors.New("invalid period")},
+		{"準正常系: 更新日時の期間が範囲外の場合", ViewQuery{Updated: ViewPeriod(5)}, errors.New("invalid period")},
+		{"準正常系: 更新日時が設定されていない場合", ViewQuery{Updated: ViewPeriodUnset}, errors.New("updated period cannot be unset")},
+		{"準正常系: 並び替えの項目が範囲外の場合", ViewQuery{Sort: TaskSort{Field: TaskSortField(4)}}, errors.New("invalid sort field")},
+		{"準正常系: まとめる項目が範囲外の場合", ViewQuery{GroupBy: ViewGroupBy(5)}, errors.New("invalid group by")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
+
+func TestViewQuery_Filter(tt *testing.T) {
+	now := time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC)
+	today := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	tomorrow := today.AddDate(0, 0, 1)
+	monday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	nextMonday := monday.AddDate(0, 0, 7)
+
+	tt.Run("正常系: 期限と更新日時の期間が日時の範囲に変換されること", func(t *testing.T) {
+		q := ViewQuery{Completion: TaskCompletionClosed, Due: ViewPeriodToday, Updated: ViewPeriodThisWeek, NameContains: "milk"}
+		f := q.Filter(now)
+
+		require.Equal(t, &TaskFilter{Completion: TaskCompletionClosed, DueFrom: &today, DueTo: &tomorrow, UpdatedFrom: &monday, UpdatedTo: &nextMonday, NameContains: "milk"}, f)
+		require.NoError(t, f.Validate(), "エラーが発生しないこと")
+	})
+	tt.Run("正常系: 期限が設定されていない場合", func(t *testing.T) {
+		f := ViewQuery{Due: ViewPeriodUnset}.Filter(now)
+
+		require.Equal(t, &TaskFilter{NoDueDate: true}, f)
+		require.NoError(t, f.Validate(), "エラーが発生しないこと")
+	})
+}
@@ -0,0 +1,36 @@
+package value
+
+import "github.com/7oh2020/connect-tasklist/backend/domain"
+
+// ワークスペースのメンバーの権限。値が大きいほど多くの操作ができ、上位の権限は下位の権限の操作を全て含む
+type WorkspaceRole int32
+
+const (
+	// 不明な権限。入力値の変換に失敗した場合に使用する
+	WorkspaceRoleUnknown WorkspaceRole = -1
+	// メンバーではない
+	WorkspaceRoleNone WorkspaceRole = 0
+	// ワークスペースのリストとタスクの作成と編集ができる
+	WorkspaceRoleMember WorkspaceRole = 1
+	// メンバーの招待と削除ができる
+	WorkspaceRoleAdmin WorkspaceRole = 2
+	// 所有者。ワークスペースごとに1人だけ存在し、ワークスペースの削除と管理者の任命ができる
+	WorkspaceRoleOwner WorkspaceRole = 3
+)
+
+func (r WorkspaceRole) Value() int32 {
+	return int32(r)
+}
+
+// メンバーの権限として有効か検証する
+func (r WorkspaceRole) Validate() error {
+	if r < WorkspaceRoleMember || r > WorkspaceRoleOwner {
+		return &domain.ErrValidationFailed{Msg: "invalid workspace role"}
+	}
+	return nil
+}
+
+// required以上の権限を持つか判定する
+func (r WorkspaceRole) Includes(required WorkspaceRole) bool {
+	return r >= required
+}
@@ -0,0 +1,52 @@
+package value
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceRole_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   WorkspaceRole
+		err   error
+	}{
+		{"正常系: メンバーの場合", WorkspaceRoleMember, nil},
+		{"正常系: 管理者の場合", WorkspaceRoleAdmin, nil},
+		{"正常系: 所有者の場合", WorkspaceRoleOwner, nil},
+		{"準正常系: メンバーではない場合", WorkspaceRoleNone, errors.New("invalid workspace role")},
+		{"準正常系: 権限が不明の場合", WorkspaceRoleUnknown, errors.New("invalid workspace role")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
+
+func TestWorkspaceRole_Includes(tt *testing.T) {
+	testcases := []struct {
+		title    string
+		role     WorkspaceRole
+		required WorkspaceRole
+		expected bool
+	}{
+		{"正常系: 同じ権限の場合", WorkspaceRoleAdmin, WorkspaceRoleAdmin, true},
+		{"正常系: 所有者の場合", WorkspaceRoleOwner, WorkspaceRoleAdmin, true},
+		{"準正常系: 下位の権限の場合", WorkspaceRoleMember, WorkspaceRoleAdmin, false},
+		{"準正常系: メンバーではない場合", WorkspaceRoleNone, WorkspaceRoleMember, false},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			require.Equal(t, v.expected, v.role.Includes(v.required))
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+)
+
+// AttachmentEntityの永続化を行う。ファイルの本体はIBlobStorageに保存する
+type IAttachmentRepository interface {
+	FindAttachmentByID(ctx context.Context, id string) (*entity.Attachment, error)
+	// タスクの添付ファイルを古い順に取得する
+	FindAttachmentsByTaskID(ctx context.Context, taskID string) ([]*entity.Attachment, error)
+	// ユーザーがアップロードした添付ファイルの合計バイト数を取得する
+	SumAttachmentSizeByUserID(ctx context.Context, userID string) (int64, error)
+	CreateAttachment(ctx context.Context, arg *entity.Attachment) (string, error)
+	// メタデータを削除する。ストレージのキーは削除待ちとして記録される
+	DeleteAttachment(ctx context.Context, id string) error
+	// ストレージから削除する必要のあるキーを古い順に取得する
+	FindBlobDeletions(ctx context.Context, limit int32) ([]string, error)
+	// ストレージからの削除が完了したキーの記録を消す
+	DeleteBlobDeletion(ctx context.Context, storageKey string) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"io"
+)
+
+// ファイルの本体を保存する。keyはストレージ内で一意の名前
+type IBlobStorage interface {
+	// rの内容をkeyに保存する。sizeが不明な場合は-1を指定する
+	PutBlob(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// keyの内容を読み込む。呼び出し側でCloseする
+	GetBlob(ctx context.Context, key string) (io.ReadCloser, error)
+	// keyを削除する。存在しない場合は何もしない
+	DeleteBlob(ctx context.Context, key string) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+)
+
+// CalendarFeedEntityの永続化を行う
+type ICalendarFeedRepository interface {
+	// フィードを作成する。既にフィードがある場合はトークンを置き換える
+	UpsertCalendarFeed(ctx context.Context, arg *entity.CalendarFeed) error
+	FindCalendarFeedByTokenHash(ctx context.Context, tokenHash string) (*entity.CalendarFeed, error)
+	// フィードを削除し、削除した件数を返す
+	DeleteCalendarFeed(ctx context.Context, userID string) (int64, error)
+}
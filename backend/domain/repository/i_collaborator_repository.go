@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+)
+
+// CollaboratorEntityの永続化を行う
+type ICollaboratorRepository interface {
+	// タスク自身、祖先のタスク、タスクが属するリストで共有された権限のうち最も強いものを取得する。共有されていない場合はRoleNoneを返す
+	FindTaskRole(ctx context.Context, taskID string, userID string) (value.Role, error)
+	// リストで共有された権限を取得する。共有されていない場合はRoleNoneを返す
+	FindListRole(ctx context.Context, listID string, userID string) (value.Role, error)
+	// 共有したユーザーを古い順に取得する
+	FindCollaboratorsByTaskID(ctx context.Context, taskID string) ([]*entity.Collaborator, error)
+	FindCollaboratorsByListID(ctx context.Context, listID string) ([]*entity.Collaborator, error)
+	FindTaskCollaborator(ctx context.Context, taskID string, userID string) (*entity.Collaborator, error)
+	FindListCollaborator(ctx context.Context, listID string, userID string) (*entity.Collaborator, error)
+	CreateCollaborator(ctx context.Context, arg *entity.Collaborator) (string, error)
+	UpdateCollaborator(ctx context.Context, arg *entity.Collaborator) error
+	DeleteCollaborator(ctx context.Context, id string) error
+}
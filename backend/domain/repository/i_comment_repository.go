@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+)
+
+// CommentEntityの永続化を行う
+type ICommentRepository interface {
+	FindCommentByID(ctx context.Context, id string) (*entity.Comment, error)
+	// タスクのコメントを古い順に取得する
+	FindCommentsByTaskID(ctx context.Context, taskID string) ([]*entity.Comment, error)
+	CreateComment(ctx context.Context, arg *entity.Comment) (string, error)
+	UpdateComment(ctx context.Context, arg *entity.Comment) error
+	DeleteComment(ctx context.Context, id string) error
+}
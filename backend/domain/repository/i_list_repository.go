@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+)
+
+// ListEntityの永続化を行う
+type IListRepository interface {
+	FindListByID(ctx context.Context, id string) (*entity.List, error)
+	FindInboxByUserID(ctx context.Context, userID string) (*entity.List, error)
+	// ワークスペースのリストを除いた個人のリストを表示順に取得する
+	FindListsByUserID(ctx context.Context, userID string) ([]*entity.List, error)
+	// ワークスペースのリストを表示順に取得する
+	FindListsByWorkspaceID(ctx context.Context, workspaceID string) ([]*entity.List, error)
+	// 個人のリストの表示順の最大値を取得する。リストが存在しない場合は-1を返す
+	FindMaxListPosition(ctx context.Context, userID string) (int32, error)
+	// ワークスペースのリストの表示順の最大値を取得する。リストが存在しない場合は-1を返す
+	FindMaxWorkspaceListPosition(ctx context.Context, workspaceID string) (int32, error)
+	CreateList(ctx context.Context, arg *entity.List) (string, error)
+	UpdateList(ctx context.Context, arg *entity.List) error
+	DeleteList(ctx context.Context, id string) error
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+)
+
+// TagEntityとタスクへのタグ付けの永続化を行う
+type ITagRepository interface {
+	FindTagByID(ctx context.Context, id string) (*entity.Tag, error)
+	FindTagByUserIDAndName(ctx context.Context, userID string, name string) (*entity.Tag, error)
+	// 使用数を集計したタグの一覧を取得する
+	FindTagsByUserID(ctx context.Context, userID string) ([]*entity.Tag, error)
+	CreateTag(ctx context.Context, arg *entity.Tag) (string, error)
+	UpdateTag(ctx context.Context, arg *entity.Tag) error
+	DeleteTag(ctx context.Context, id string) error
+	// タスクにタグを付ける。既に付いている場合は何もしない
+	AttachTag(ctx context.Context, taskID string, tagID string, now time.Time) error
+	DetachTag(ctx context.Context, taskID string, tagID string) error
+}
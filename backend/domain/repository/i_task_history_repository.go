@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+)
+
+// TaskHistoryEntityの永続化を行う
+type ITaskHistoryRepository interface {
+	// 新しい順に最大limit件の履歴を取得する。cursorを指定した場合はその位置より古い履歴を取得する
+	FindTaskHistories(ctx context.Context, taskID string, limit int32, cursor *value.PageCursor) ([]*entity.TaskHistory, error)
+	CreateTaskHistory(ctx context.Context, arg *entity.TaskHistory) error
+}
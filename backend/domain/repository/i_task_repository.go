@@ -2,15 +2,73 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
 )
 
 // TaskEntityの永続化を行う
 type ITaskRepository interface {
 	FindTaskByID(ctx context.Context, id string) (*entity.Task, error)
+	// アーカイブされたリストとワークスペースのリストを除いた個人のタスクを取得する。UserIDで取得する他のメソッドも同様
 	FindTasksByUserID(ctx context.Context, userID string) ([]*entity.Task, error)
+	FindTasksByUserIDOrderByPriority(ctx context.Context, userID string) ([]*entity.Task, error)
+	// リスト、手動の並び順の順にタスクを取得する
+	FindTasksByUserIDOrderByPosition(ctx context.Context, userID string) ([]*entity.Task, error)
+	// 絞り込んだタスクをsortの順に最大limit件取得する。cursorを指定した場合はその位置より後のタスクを取得する
+	FindTaskPage(ctx context.Context, userID string, filter *value.TaskFilter, sort value.TaskSort, limit int32, cursor *value.TaskPageCursor) ([]*entity.Task, error)
+	FindTasksByListID(ctx context.Context, listID string, order value.TaskOrder) ([]*entity.Task, error)
+	// ワークスペースの全てのリストのタスクを取得する。アーカイブされたリストのタスクは除外する
+	FindTasksByWorkspaceID(ctx context.Context, workspaceID string, order value.TaskOrder) ([]*entity.Task, error)
+	// 担当するタスクのうち、閲覧する権限が残っているタスクを全てのリストから取得する。アーカイブされたリストのタスクは除外する
+	FindTasksByAssigneeID(ctx context.Context, assigneeID string, order value.TaskOrder) ([]*entity.Task, error)
+	FindOverdueTasksByUserID(ctx context.Context, userID string, now time.Time) ([]*entity.Task, error)
+	FindTasksDueBetween(ctx context.Context, userID string, from time.Time, to time.Time) ([]*entity.Task, error)
+	// タグで絞り込んだタスクを取得する。matchAllがtrueの場合は全てのタグが付いたタスクのみ取得する。listIDが空の場合はユーザーの全てのリスト、指定した場合はリストの全てのタスクが対象
+	FindTasksByUserIDAndTags(ctx context.Context, userID string, listID string, tagIDs []string, matchAll bool, order value.TaskOrder) ([]*entity.Task, error)
+	// 指定したタスクとその全ての子孫タスクを取得する
+	FindTaskTree(ctx context.Context, id string) ([]*entity.Task, error)
+	// 指定したタスク自身とその全ての祖先タスクのIDを取得する
+	FindAncestorIDs(ctx context.Context, id string) ([]string, error)
+	// 未完了の子孫タスクの数を取得する
+	CountOpenDescendants(ctx context.Context, id string) (int64, error)
+	// 指定したタスクが直接または間接的に依存している全てのタスクのIDを取得する
+	FindBlockerIDs(ctx context.Context, id string) ([]string, error)
+	// 未完了のブロッカーの数を取得する
+	CountOpenBlockers(ctx context.Context, id string) (int64, error)
+	// 指定したタスクのうち未完了のブロッカーが残っているタスクのIDを取得する
+	FindBlockedTaskIDs(ctx context.Context, ids []string) ([]string, error)
+	// リストの先頭のタスクの位置を取得する。タスクが存在しない場合は空のキーを返す
+	FindMinTaskPosition(ctx context.Context, listID string) (value.Rank, error)
+	// 指定した位置の直前のタスクの位置を取得する。idのタスクは除外する。存在しない場合は空のキーを返す
+	FindPrevTaskPosition(ctx context.Context, listID string, id string, position value.Rank) (value.Rank, error)
+	// 指定した位置の直後のタスクの位置を取得する。idのタスクは除外する。存在しない場合は空のキーを返す
+	FindNextTaskPosition(ctx context.Context, listID string, id string, position value.Rank) (value.Rank, error)
+	// 位置のキーがmaxLengthより長い、または重複しているリストのIDを取得する
+	FindListIDsToRebalance(ctx context.Context, maxLength int32) ([]string, error)
+	// リスト内のタスクのIDを並び順に取得し、トランザクションの終了まで行をロックする
+	FindTaskIDsByListIDOrderByPosition(ctx context.Context, listID string) ([]string, error)
 	CreateTask(ctx context.Context, arg *entity.Task) (string, error)
 	UpdateTask(ctx context.Context, arg *entity.Task) error
-	DeleteTask(ctx context.Context, id string) error
+	// 指定したタスクとその全ての子孫タスクを別のリストに移動する
+	UpdateTaskTreeListID(ctx context.Context, id string, listID string, now time.Time) error
+	// タスクの位置のみを更新する。更新日時は変更しない
+	UpdateTaskPosition(ctx context.Context, id string, position value.Rank) error
+	// taskIDのタスクがblockerIDのタスクに依存していることを登録し、登録した件数を返す。既に登録されている場合は何もせず0を返す
+	AddTaskDependency(ctx context.Context, taskID string, blockerID string, now time.Time) (int64, error)
+	// 依存関係を削除し、削除した件数を返す
+	RemoveTaskDependency(ctx context.Context, taskID string, blockerID string) (int64, error)
+	// 指定したタスクとその全ての子孫タスクをゴミ箱に移動する
+	DeleteTask(ctx context.Context, id string, now time.Time) error
+	// ゴミ箱のタスクを取得する
+	FindTrashedTaskByID(ctx context.Context, id string) (*entity.Task, error)
+	// ゴミ箱のタスクを取得する。親と一緒にゴミ箱に移動したサブタスクは除外する
+	FindTrashedTasksByUserID(ctx context.Context, userID string) ([]*entity.Task, error)
+	// 指定したタスクと、deletedAtに一緒にゴミ箱に移動した子孫タスクを復元する
+	RestoreTask(ctx context.Context, id string, deletedAt time.Time) error
+	// ユーザーのゴミ箱のタスクを完全に削除する
+	PurgeTrashedTasksByUserID(ctx context.Context, userID string) error
+	// beforeより前にゴミ箱に移動したタスクを完全に削除し、削除した件数を返す
+	PurgeTasksDeletedBefore(ctx context.Context, before time.Time) (int64, error)
 }
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+)
+
+// タスクの全文検索を行う
+type ITaskSearchRepository interface {
+	// 名前と説明がqueryに一致する個人のタスクを順位の高い順に最大limit件取得する。cursorを指定した場合はその位置より後のタスクを取得する
+	// アーカイブされたリストとワークスペースのリストのタスクは除外する
+	SearchTasksByUserID(ctx context.Context, userID string, query string, limit int32, cursor *value.SearchCursor) ([]*entity.TaskSearchResult, error)
+	// ワークスペースの全てのリストから名前と説明がqueryに一致するタスクを順位の高い順に最大limit件取得する。アーカイブされたリストのタスクは除外する
+	SearchTasksByWorkspaceID(ctx context.Context, workspaceID string, query string, limit int32, cursor *value.SearchCursor) ([]*entity.TaskSearchResult, error)
+}
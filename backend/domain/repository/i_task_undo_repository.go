@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+)
+
+// TaskUndoEntryEntityの永続化を行う
+type ITaskUndoRepository interface {
+	CreateTaskUndoEntry(ctx context.Context, arg *entity.TaskUndoEntry) error
+	// スタックの最新の操作を削除して返す。expiredBefore以前に積んだ操作は取り出さない
+	PopTaskUndoEntry(ctx context.Context, userID string, stack value.UndoStack, expiredBefore time.Time) (*entity.TaskUndoEntry, error)
+	// スタックの全ての操作を削除する
+	DeleteTaskUndoEntries(ctx context.Context, userID string, stack value.UndoStack) error
+	// expiredBefore以前に積んだ操作と、新しい順にmaxEntries件を超えた操作を削除する
+	TrimTaskUndoEntries(ctx context.Context, userID string, stack value.UndoStack, maxEntries int32, expiredBefore time.Time) error
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+)
+
+// TimeEntryEntityの永続化を行う
+type ITimeEntryRepository interface {
+	FindTimeEntryByID(ctx context.Context, id string) (*entity.TimeEntry, error)
+	// 計測中のタイマーを取得する
+	FindRunningTimeEntry(ctx context.Context, userID string) (*entity.TimeEntry, error)
+	// 期間と重なる作業時間をタスク名と共に開始日時の古い順に取得する
+	FindTimeEntriesBetween(ctx context.Context, userID string, from time.Time, to time.Time) ([]*entity.TimeEntry, error)
+	// 計測中のタイマーが既にある場合は一意制約によりエラーになる
+	CreateTimeEntry(ctx context.Context, arg *entity.TimeEntry) (string, error)
+	UpdateTimeEntry(ctx context.Context, arg *entity.TimeEntry) error
+	DeleteTimeEntry(ctx context.Context, id string) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "context"
+
+// 複数の永続化を1つのトランザクションで行う
+type ITransactionManager interface {
+	// fnを1つのトランザクションで実行する。fnの中ではリポジトリに渡されたctxを使用する
+	// fnがエラーを返した場合はロールバックしてそのエラーを返す。既にトランザクション中の場合はそのトランザクションで実行する
+	RunInTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// fnをトランザクション中のセーブポイントで実行する。fnがエラーを返した場合はセーブポイントまで戻し、トランザクションは続行できる
+	// トランザクション外の場合はRunInTxと同じ
+	RunInSavepoint(ctx context.Context, fn func(ctx context.Context) error) error
+}
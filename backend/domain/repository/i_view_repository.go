@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+)
+
+// ViewEntityの永続化を行う。組み込みのビューは永続化しない
+type IViewRepository interface {
+	FindViewByID(ctx context.Context, id string) (*entity.View, error)
+	FindViewByUserIDAndName(ctx context.Context, userID string, name string) (*entity.View, error)
+	FindViewsByUserID(ctx context.Context, userID string) ([]*entity.View, error)
+	CreateView(ctx context.Context, arg *entity.View) (string, error)
+	UpdateView(ctx context.Context, arg *entity.View) error
+	DeleteView(ctx context.Context, id string) error
+}
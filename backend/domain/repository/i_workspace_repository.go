@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+)
+
+// WorkspaceEntityとメンバー、招待の永続化を行う
+type IWorkspaceRepository interface {
+	FindWorkspaceByID(ctx context.Context, id string) (*entity.Workspace, error)
+	// ユーザーがメンバーであるワークスペースを参加した順に取得する。Roleにはユーザーの権限が設定される
+	FindWorkspacesByUserID(ctx context.Context, userID string) ([]*entity.Workspace, error)
+	CreateWorkspace(ctx context.Context, arg *entity.Workspace) (string, error)
+	// ワークスペースを削除する。メンバー、招待、リストとタスクも合わせて削除される
+	DeleteWorkspace(ctx context.Context, id string) error
+	FindWorkspaceMember(ctx context.Context, workspaceID string, userID string) (*entity.WorkspaceMember, error)
+	// メンバーを参加した順に取得する
+	FindWorkspaceMembers(ctx context.Context, workspaceID string) ([]*entity.WorkspaceMember, error)
+	CreateWorkspaceMember(ctx context.Context, arg *entity.WorkspaceMember) error
+	UpdateWorkspaceMember(ctx context.Context, arg *entity.WorkspaceMember) error
+	DeleteWorkspaceMember(ctx context.Context, workspaceID string, userID string) error
+	// メンバーが作成したワークスペースのリストとそのタスクの所有者をtoUserIDに移す
+	TransferWorkspaceOwnership(ctx context.Context, workspaceID string, fromUserID string, toUserID string) error
+	FindWorkspaceInvitationByID(ctx context.Context, id string) (*entity.WorkspaceInvitation, error)
+	// ワークスペースに招待したメールアドレスの招待を取得する
+	FindWorkspaceInvitationByEmail(ctx context.Context, workspaceID string, email string) (*entity.WorkspaceInvitation, error)
+	// 招待を古い順に取得する
+	FindWorkspaceInvitationsByWorkspaceID(ctx context.Context, workspaceID string) ([]*entity.WorkspaceInvitation, error)
+	// メールアドレスへの招待を古い順に取得する。WorkspaceNameにはワークスペースの名前が設定される
+	FindWorkspaceInvitationsByEmail(ctx context.Context, email string) ([]*entity.WorkspaceInvitation, error)
+	CreateWorkspaceInvitation(ctx context.Context, arg *entity.WorkspaceInvitation) (string, error)
+	UpdateWorkspaceInvitation(ctx context.Context, arg *entity.WorkspaceInvitation) error
+	DeleteWorkspaceInvitation(ctx context.Context, id string) error
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/domain/repository"
+	"github.com/7oh2020/connect-tasklist/backend/util/clock"
+	"github.com/7oh2020/connect-tasklist/backend/util/identification"
+)
+
+// 添付ファイルのドメインロジック
+type IAttachmentService interface {
+	FindAttachmentsByTaskID(ctx context.Context, taskID string, userID string) ([]*entity.Attachment, error)
+	UploadAttachment(ctx context.Context, taskID string, userID string, fileName string, r io.Reader) (string, error)
+	OpenAttachment(ctx context.Context, id string, userID string) (*entity.Attachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, id string, userID string) error
+	PurgeDeletedBlobs(ctx context.Context) (int64, error)
+}
+
+// 1ファイルの最大のバイト数
+const maxAttachmentSize = 10 << 20
+
+// MIMEタイプの判定に使用する先頭のバイト数
+const sniffLength = 512
+
+// 1回の実行でストレージから削除するファイルの数
+const blobPurgeBatchSize = 100
+
+// 添付できるMIMEタイプ。クライアントの申告ではなく内容から判定した値で検証する
+var allowedAttachmentTypes = map[string]bool{
+	"image/png":                 true,
+	"image/jpeg":                true,
+	"image/gif":                 true,
+	"image/webp":                true,
+	"application/pdf":           true,
+	"application/zip":           true,
+	"text/plain; charset=utf-8": true,
+}
+
+type AttachmentService struct {
+	repository.IAttachmentRepository
+	repository.ITaskRepository
+	IAuthorizationPolicy
+	repository.IBlobStorage
+	identification.IIDManager
+	clock.IClockManager
+	// ユーザーごとの添付ファイルの合計バイト数の上限
+	quota int64
+}
+
+func NewAttachmentService(attachmentRepo repository.IAttachmentRepository, taskRepo repository.ITaskRepository, policy IAuthorizationPolicy, storage repository.IBlobStorage, idManager identification.IIDManager, clockManager clock.IClockManager, quota int64) *AttachmentService {
+	return &AttachmentService{attachmentRepo, taskRepo, policy, storage, idManager, clockManager, quota}
+}
+
+// タスクの添付ファイルを古い順に取得する
+func (s *AttachmentService) FindAttachmentsByTaskID(ctx context.Context, taskID string, userID string) ([]*entity.Attachment, error) {
+	if err := s.checkTask(ctx, taskID, userID, value.RoleViewer); err != nil {
+		return nil, err
+	}
+	attachments, err := s.IAttachmentRepository.FindAttachmentsByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return attachments, nil
+}
+
+// rの内容をストレージに保存してタスクに添付する。上限を超えた時点で読み込みを中止する
+// 同時にアップロードした場合は使用量の上限をわずかに超える場合がある
+func (s *AttachmentService) UploadAttachment(ctx context.Context, taskID string, userID string, fileName string, r io.Reader) (string, error) {
+	if err := s.checkTask(ctx, taskID, userID, value.RoleEditor); err != nil {
+		return "", err
+	}
+	if fileName == "" {
+		return "", &domain.ErrValidationFailed{Msg: "file name is empty"}
+	}
+	used, err := s.IAttachmentRepository.SumAttachmentSizeByUserID(ctx, userID)
+	if err != nil {
+		return "", &domain.ErrQueryFailed{}
+	}
+	remaining := s.quota - used
+	if remaining <= 0 {
+		return "", &domain.ErrQuotaExceeded{Msg: "attachment quota exceeded"}
+	}
+
+	// 先頭を読んで内容からMIMEタイプを判定する
+	br := bufio.NewReaderSize(r, sniffLength)
+	head, err := br.Peek(sniffLength)
+	if err != nil && err != io.EOF {
+		return "", &domain.ErrValidationFailed{Msg: "failed to read file"}
+	}
+	if len(head) == 0 {
+		return "", &domain.ErrValidationFailed{Msg: "file is empty"}
+	}
+	contentType := http.DetectContentType(head)
+	if !allowedAttachmentTypes[contentType] {
+		return "", &domain.ErrValidationFailed{Msg: "unsupported content type"}
+	}
+
+	// 上限を1バイトでも超えたら超過と判定できるように上限+1まで読み込む
+	limit := min(remaining, maxAttachmentSize)
+	cr := &countingReader{r: io.LimitReader(br, limit+1)}
+	id := s.IIDManager.GenerateID()
+	if err := s.IBlobStorage.PutBlob(ctx, id, cr, -1, contentType); err != nil {
+		return "", &domain.ErrQueryFailed{Msg: "failed to store file"}
+	}
+	if cr.n > limit {
+		// 保存した途中までのファイルは不要なので削除する
+		_ = s.IBlobStorage.DeleteBlob(ctx, id)
+		if limit == maxAttachmentSize {
+			return "", &domain.ErrValidationFailed{Msg: "file must be 10MB or less"}
+		}
+		return "", &domain.ErrQuotaExceeded{Msg: "attachment quota exceeded"}
+	}
+	arg := &entity.Attachment{
+		ID:          value.NewID(id),
+		TaskID:      value.NewID(taskID),
+		UserID:      value.NewID(userID),
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        cr.n,
+		StorageKey:  id,
+		CreatedAt:   s.IClockManager.GetNow(),
+	}
+	createdID, err := s.IAttachmentRepository.CreateAttachment(ctx, arg)
+	if err != nil {
+		_ = s.IBlobStorage.DeleteBlob(ctx, id)
+		return "", &domain.ErrQueryFailed{}
+	}
+	return createdID, nil
+}
+
+// 添付ファイルのメタデータと内容を取得する。呼び出し側で内容をCloseする
+func (s *AttachmentService) OpenAttachment(ctx context.Context, id string, userID string) (*entity.Attachment, io.ReadCloser, error) {
+	attachment, err := s.findAttachment(ctx, id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	rc, err := s.IBlobStorage.GetBlob(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, nil, &domain.ErrQueryFailed{Msg: "failed to read file"}
+	}
+	return attachment, rc, nil
+}
+
+// 添付ファイルを削除する。ストレージのファイルはPurgeDeletedBlobsで削除される
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, id string, userID string) error {
+	attachment, err := s.findAttachment(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if !attachment.UserID.Equal(userID) {
+		return &domain.ErrPermissionDenied{}
+	}
+	if err := s.IAttachmentRepository.DeleteAttachment(ctx, id); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+// メタデータが削除された添付ファイルをストレージから削除し、削除した数を返す
+// 失敗したファイルは次の実行で再試行する
+func (s *AttachmentService) PurgeDeletedBlobs(ctx context.Context) (int64, error) {
+	keys, err := s.IAttachmentRepository.FindBlobDeletions(ctx, blobPurgeBatchSize)
+	if err != nil {
+		return 0, &domain.ErrQueryFailed{}
+	}
+	var n int64
+	var purgeErr error
+	for _, key := range keys {
+		if err := s.IBlobStorage.DeleteBlob(ctx, key); err != nil {
+			purgeErr = &domain.ErrQueryFailed{Msg: "failed to delete file"}
+			continue
+		}
+		if err := s.IAttachmentRepository.DeleteBlobDeletion(ctx, key); err != nil {
+			purgeErr = &domain.ErrQueryFailed{}
+			continue
+		}
+		n++
+	}
+	return n, purgeErr
+}
+
+// タスクに対してrequired以上の権限を持つか検証する。ゴミ箱のタスクは存在しないものとして扱う
+func (s *AttachmentService) checkTask(ctx context.Context, taskID string, userID string, required value.Role) error {
+	if err := value.NewID(taskID).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	task, err := s.ITaskRepository.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "task not found"}
+	}
+	return s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, required)
+}
+
+// 閲覧できるタスクの添付ファイルを取得する
+func (s *AttachmentService) findAttachment(ctx context.Context, id string, userID string) (*entity.Attachment, error) {
+	if err := value.NewID(id).Validate(); err != nil {
+		return nil, err
+	}
+	attachment, err := s.IAttachmentRepository.FindAttachmentByID(ctx, id)
+	if err != nil {
+		return nil, &domain.ErrNotFound{Msg: "attachment not found"}
+	}
+	if err := s.checkTask(ctx, attachment.TaskID.Value(), userID, value.RoleViewer); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// 読み込んだバイト数を数える
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,498 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentService_NewAttachmentService(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ IAttachmentService = (*AttachmentService)(nil)
+	})
+}
+
+func TestAttachmentService_FindAttachmentsByTaskID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	tid := "tid"
+	uid := "uid"
+	task := &entity.Task{ID: value.NewID(tid), UserID: value.NewID(uid), Name: "task", CreatedAt: now, UpdatedAt: now}
+	attachments := []*entity.Attachment{
+		{ID: value.NewID("a1"), TaskID: value.NewID(tid), UserID: value.NewID(uid), FileName: "shot.png", ContentType: "image/png", Size: 10, StorageKey: "a1", CreatedAt: now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("FindAttachmentsByTaskID", ctx, tid).Return(attachments, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), new(mocks.IBlobStorage), new(mocks.IIDManager), new(mocks.IClockManager), 100)
+		ret, err := srv.FindAttachmentsByTaskID(ctx, tid, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, attachments, ret)
+		repo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: タスクが存在しない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		repo := new(mocks.IAttachmentRepository)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(nil, errExp)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), new(mocks.IBlobStorage), new(mocks.IIDManager), new(mocks.IClockManager), 100)
+		_, err := srv.FindAttachmentsByTaskID(ctx, tid, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.IAttachmentRepository)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), new(mocks.IBlobStorage), new(mocks.IIDManager), new(mocks.IClockManager), 100)
+		_, err := srv.FindAttachmentsByTaskID(ctx, tid, "another")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("FindAttachmentsByTaskID", ctx, tid).Return(nil, errExp)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), new(mocks.IBlobStorage), new(mocks.IIDManager), new(mocks.IClockManager), 100)
+		_, err := srv.FindAttachmentsByTaskID(ctx, tid, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+}
+
+func TestAttachmentService_UploadAttachment(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	tid := "tid"
+	uid := "uid"
+	task := &entity.Task{ID: value.NewID(tid), UserID: value.NewID(uid), Name: "task", CreatedAt: now, UpdatedAt: now}
+	png := "\x89PNG\r\n\x1a\n" + strings.Repeat("\x00", 24)
+	// ストレージへの保存を模倣して内容を全て読み込む
+	putBlob := func(storage *mocks.IBlobStorage, contentType string, stored *bytes.Buffer, err error) {
+		storage.On("PutBlob", ctx, id, mock.Anything, int64(-1), contentType).Return(err).Run(func(args mock.Arguments) {
+			_, _ = io.Copy(stored, args.Get(2).(io.Reader))
+		})
+	}
+
+	tt.Run("正常系: 内容から判定したMIMEタイプで保存されること", func(t *testing.T) {
+		var stored bytes.Buffer
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("SumAttachmentSizeByUserID", ctx, uid).Return(int64(0), nil)
+		repo.On("CreateAttachment", ctx, &entity.Attachment{ID: value.NewID(id), TaskID: value.NewID(tid), UserID: value.NewID(uid), FileName: "shot.png", ContentType: "image/png", Size: int64(len(png)), StorageKey: id, CreatedAt: now}).Return(id, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		storage := new(mocks.IBlobStorage)
+		putBlob(storage, "image/png", &stored, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), storage, im, cm, 1000)
+		createdID, err := srv.UploadAttachment(ctx, tid, uid, "shot.png", strings.NewReader(png))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, id, createdID)
+		require.Equal(t, png, stored.String(), "内容が全て保存されること")
+		repo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("正常系: 使用量の上限ちょうどの場合", func(t *testing.T) {
+		var stored bytes.Buffer
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("SumAttachmentSizeByUserID", ctx, uid).Return(int64(90), nil)
+		repo.On("CreateAttachment", ctx, &entity.Attachment{ID: value.NewID(id), TaskID: value.NewID(tid), UserID: value.NewID(uid), FileName: "memo.txt", ContentType: "text/plain; charset=utf-8", Size: 10, StorageKey: id, CreatedAt: now}).Return(id, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		storage := new(mocks.IBlobStorage)
+		putBlob(storage, "text/plain; charset=utf-8", &stored, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), storage, im, cm, 100)
+		_, err := srv.UploadAttachment(ctx, tid, uid, "memo.txt", strings.NewReader("0123456789"))
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+	tt.Run("準正常系: ファイル名が空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "file name is empty"}
+		repo := new(mocks.IAttachmentRepository)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		storage := new(mocks.IBlobStorage)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), storage, new(mocks.IIDManager), new(mocks.IClockManager), 100)
+		_, err := srv.UploadAttachment(ctx, tid, uid, "", strings.NewReader(png))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.IAttachmentRepository)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		storage := new(mocks.IBlobStorage)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), storage, new(mocks.IIDManager), new(mocks.IClockManager), 100)
+		_, err := srv.UploadAttachment(ctx, tid, "another", "shot.png", strings.NewReader(png))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 使用量が上限に達している場合", func(t *testing.T) {
+		errExp := &domain.ErrQuotaExceeded{Msg: "attachment quota exceeded"}
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("SumAttachmentSizeByUserID", ctx, uid).Return(int64(100), nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		storage := new(mocks.IBlobStorage)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), storage, new(mocks.IIDManager), new(mocks.IClockManager), 100)
+		_, err := srv.UploadAttachment(ctx, tid, uid, "shot.png", strings.NewReader(png))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 空のファイルの場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "file is empty"}
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("SumAttachmentSizeByUserID", ctx, uid).Return(int64(0), nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		storage := new(mocks.IBlobStorage)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), storage, new(mocks.IIDManager), new(mocks.IClockManager), 100)
+		_, err := srv.UploadAttachment(ctx, tid, uid, "empty.txt", strings.NewReader(""))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 許可されていないMIMEタイプの場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "unsupported content type"}
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("SumAttachmentSizeByUserID", ctx, uid).Return(int64(0), nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		storage := new(mocks.IBlobStorage)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), storage, new(mocks.IIDManager), new(mocks.IClockManager), 1000)
+		// 拡張子を偽装しても内容で判定すること
+		_, err := srv.UploadAttachment(ctx, tid, uid, "shot.png", strings.NewReader("<html><script>alert(1)</script></html>"))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 読み込み中に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "failed to read file"}
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("SumAttachmentSizeByUserID", ctx, uid).Return(int64(0), nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		storage := new(mocks.IBlobStorage)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), storage, new(mocks.IIDManager), new(mocks.IClockManager), 1000)
+		_, err := srv.UploadAttachment(ctx, tid, uid, "shot.png", io.MultiReader(strings.NewReader("abc"), errorReader{}))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 途中で使用量の上限を超えた場合は保存したファイルを削除すること", func(t *testing.T) {
+		errExp := &domain.ErrQuotaExceeded{Msg: "attachment quota exceeded"}
+		var stored bytes.Buffer
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("SumAttachmentSizeByUserID", ctx, uid).Return(int64(90), nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		storage := new(mocks.IBlobStorage)
+		putBlob(storage, "text/plain; charset=utf-8", &stored, nil)
+		storage.On("DeleteBlob", ctx, id).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), storage, im, new(mocks.IClockManager), 100)
+		_, err := srv.UploadAttachment(ctx, tid, uid, "memo.txt", strings.NewReader(strings.Repeat("a", 1000)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		require.Equal(t, 11, stored.Len(), "上限+1バイトで読み込みを中止すること")
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 1ファイルの上限を超えた場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "file must be 10MB or less"}
+		var stored bytes.Buffer
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("SumAttachmentSizeByUserID", ctx, uid).Return(int64(0), nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		storage := new(mocks.IBlobStorage)
+		putBlob(storage, "text/plain; charset=utf-8", &stored, nil)
+		storage.On("DeleteBlob", ctx, id).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), storage, im, new(mocks.IClockManager), 1<<30)
+		_, err := srv.UploadAttachment(ctx, tid, uid, "memo.txt", strings.NewReader(strings.Repeat("a", maxAttachmentSize+1)))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+	tt.Run("準正常系: ストレージへの保存に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{Msg: "failed to store file"}
+		var stored bytes.Buffer
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("SumAttachmentSizeByUserID", ctx, uid).Return(int64(0), nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		storage := new(mocks.IBlobStorage)
+		putBlob(storage, "image/png", &stored, errors.New("storage error"))
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), storage, im, new(mocks.IClockManager), 1000)
+		_, err := srv.UploadAttachment(ctx, tid, uid, "shot.png", strings.NewReader(png))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+	tt.Run("準正常系: メタデータの保存に失敗した場合は保存したファイルを削除すること", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		var stored bytes.Buffer
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("SumAttachmentSizeByUserID", ctx, uid).Return(int64(0), nil)
+		repo.On("CreateAttachment", ctx, mock.AnythingOfType("*entity.Attachment")).Return("", errExp)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		storage := new(mocks.IBlobStorage)
+		putBlob(storage, "image/png", &stored, nil)
+		storage.On("DeleteBlob", ctx, id).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), storage, im, cm, 1000)
+		_, err := srv.UploadAttachment(ctx, tid, uid, "shot.png", strings.NewReader(png))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+}
+
+func TestAttachmentService_OpenAttachment(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	tid := "tid"
+	uid := "uid"
+	task := &entity.Task{ID: value.NewID(tid), UserID: value.NewID(uid), Name: "task", CreatedAt: now, UpdatedAt: now}
+	attachment := &entity.Attachment{ID: value.NewID(id), TaskID: value.NewID(tid), UserID: value.NewID(uid), FileName: "memo.txt", ContentType: "text/plain; charset=utf-8", Size: 5, StorageKey: "key", CreatedAt: now}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("FindAttachmentByID", ctx, id).Return(attachment, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		storage := new(mocks.IBlobStorage)
+		storage.On("GetBlob", ctx, "key").Return(io.NopCloser(strings.NewReader("hello")), nil)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), storage, new(mocks.IIDManager), new(mocks.IClockManager), 100)
+		ret, rc, err := srv.OpenAttachment(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, attachment, ret)
+		b, err := io.ReadAll(rc)
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, "hello", string(b), "内容が一致すること")
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 添付ファイルが存在しない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "attachment not found"}
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("FindAttachmentByID", ctx, id).Return(nil, errExp)
+		taskRepo := new(mocks.ITaskRepository)
+		storage := new(mocks.IBlobStorage)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), storage, new(mocks.IIDManager), new(mocks.IClockManager), 100)
+		_, _, err := srv.OpenAttachment(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("FindAttachmentByID", ctx, id).Return(attachment, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		storage := new(mocks.IBlobStorage)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), storage, new(mocks.IIDManager), new(mocks.IClockManager), 100)
+		_, _, err := srv.OpenAttachment(ctx, id, "another")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+	tt.Run("準正常系: ストレージの読み込みに失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{Msg: "failed to read file"}
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("FindAttachmentByID", ctx, id).Return(attachment, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		storage := new(mocks.IBlobStorage)
+		storage.On("GetBlob", ctx, "key").Return(nil, errors.New("storage error"))
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), storage, new(mocks.IIDManager), new(mocks.IClockManager), 100)
+		_, _, err := srv.OpenAttachment(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+}
+
+func TestAttachmentService_DeleteAttachment(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	tid := "tid"
+	uid := "uid"
+	task := &entity.Task{ID: value.NewID(tid), UserID: value.NewID(uid), Name: "task", CreatedAt: now, UpdatedAt: now}
+	attachment := &entity.Attachment{ID: value.NewID(id), TaskID: value.NewID(tid), UserID: value.NewID(uid), FileName: "memo.txt", ContentType: "text/plain; charset=utf-8", Size: 5, StorageKey: "key", CreatedAt: now}
+
+	tt.Run("正常系: ストレージのファイルはすぐに削除しないこと", func(t *testing.T) {
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("FindAttachmentByID", ctx, id).Return(attachment, nil)
+		repo.On("DeleteAttachment", ctx, id).Return(nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		storage := new(mocks.IBlobStorage)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), storage, new(mocks.IIDManager), new(mocks.IClockManager), 100)
+		err := srv.DeleteAttachment(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーがアップロードした場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		other := *attachment
+		other.UserID = value.NewID("another")
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("FindAttachmentByID", ctx, id).Return(&other, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), new(mocks.IBlobStorage), new(mocks.IIDManager), new(mocks.IClockManager), 100)
+		err := srv.DeleteAttachment(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: IDが空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		repo := new(mocks.IAttachmentRepository)
+		srv := NewAttachmentService(repo, new(mocks.ITaskRepository), newUnsharedPolicy(), new(mocks.IBlobStorage), new(mocks.IIDManager), new(mocks.IClockManager), 100)
+		err := srv.DeleteAttachment(ctx, "", uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("FindAttachmentByID", ctx, id).Return(attachment, nil)
+		repo.On("DeleteAttachment", ctx, id).Return(errExp)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		srv := NewAttachmentService(repo, taskRepo, newUnsharedPolicy(), new(mocks.IBlobStorage), new(mocks.IIDManager), new(mocks.IClockManager), 100)
+		err := srv.DeleteAttachment(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestAttachmentService_PurgeDeletedBlobs(tt *testing.T) {
+	ctx := context.Background()
+
+	tt.Run("正常系: 削除待ちのファイルが削除されること", func(t *testing.T) {
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("FindBlobDeletions", ctx, int32(blobPurgeBatchSize)).Return([]string{"k1", "k2"}, nil)
+		repo.On("DeleteBlobDeletion", ctx, "k1").Return(nil)
+		repo.On("DeleteBlobDeletion", ctx, "k2").Return(nil)
+		storage := new(mocks.IBlobStorage)
+		storage.On("DeleteBlob", ctx, "k1").Return(nil)
+		storage.On("DeleteBlob", ctx, "k2").Return(nil)
+		srv := NewAttachmentService(repo, new(mocks.ITaskRepository), newUnsharedPolicy(), storage, new(mocks.IIDManager), new(mocks.IClockManager), 100)
+		n, err := srv.PurgeDeletedBlobs(ctx)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, int64(2), n, "削除した件数が一致すること")
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 一部の削除に失敗した場合は残りを続けること", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{Msg: "failed to delete file"}
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("FindBlobDeletions", ctx, int32(blobPurgeBatchSize)).Return([]string{"k1", "k2"}, nil)
+		repo.On("DeleteBlobDeletion", ctx, "k2").Return(nil)
+		storage := new(mocks.IBlobStorage)
+		storage.On("DeleteBlob", ctx, "k1").Return(errors.New("storage error"))
+		storage.On("DeleteBlob", ctx, "k2").Return(nil)
+		srv := NewAttachmentService(repo, new(mocks.ITaskRepository), newUnsharedPolicy(), storage, new(mocks.IIDManager), new(mocks.IClockManager), 100)
+		n, err := srv.PurgeDeletedBlobs(ctx)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		require.Equal(t, int64(1), n, "削除した件数が一致すること")
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.IAttachmentRepository)
+		repo.On("FindBlobDeletions", ctx, int32(blobPurgeBatchSize)).Return(nil, errExp)
+		storage := new(mocks.IBlobStorage)
+		srv := NewAttachmentService(repo, new(mocks.ITaskRepository), newUnsharedPolicy(), storage, new(mocks.IIDManager), new(mocks.IClockManager), 100)
+		_, err := srv.PurgeDeletedBlobs(ctx)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+}
+
+// 常に失敗するReader
+type errorReader struct{}
+
+func (errorReader) Read([]byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
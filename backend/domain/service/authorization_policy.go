@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/domain/repository"
+)
+
+// タスク、リスト、ワークスペースに対する操作の認可。全てのサービスはアクセス権の判定をこのポリシーに委ねる
+// 所有者は全ての操作ができ、それ以外のユーザーは共有された権限とワークスペースの権限に応じた操作ができる
+type IAuthorizationPolicy interface {
+	// ユーザーのタスクに対する権限を取得する。タスクの共有に加えて祖先のタスクとリストの共有も考慮する
+	TaskRole(ctx context.Context, task *entity.Task, userID string) (value.Role, error)
+	// ユーザーのリストに対する権限を取得する
+	ListRole(ctx context.Context, list *entity.List, userID string) (value.Role, error)
+	// ユーザーがタスクに対してrequired以上の権限を持つか検証する
+	AuthorizeTask(ctx context.Context, task *entity.Task, userID string, required value.Role) error
+	// ユーザーがリストに対してrequired以上の権限を持つか検証する
+	AuthorizeList(ctx context.Context, list *entity.List, userID string, required value.Role) error
+	// ユーザーのワークスペースに対する権限を取得する。メンバーではない場合はWorkspaceRoleNoneを返す
+	WorkspaceRole(ctx context.Context, workspaceID string, userID string) (value.WorkspaceRole, error)
+	// ユーザーがワークスペースに対してrequired以上の権限を持つか検証する
+	AuthorizeWorkspace(ctx context.Context, workspaceID string, userID string, required value.WorkspaceRole) error
+}
+
+type AuthorizationPolicy struct {
+	repository.ICollaboratorRepository
+	repository.IWorkspaceRepository
+}
+
+func NewAuthorizationPolicy(collaboratorRepo repository.ICollaboratorRepository, workspaceRepo repository.IWorkspaceRepository) *AuthorizationPolicy {
+	return &AuthorizationPolicy{collaboratorRepo, workspaceRepo}
+}
+
+func (p *AuthorizationPolicy) TaskRole(ctx context.Context, task *entity.Task, userID string) (value.Role, error) {
+	if task.UserID.Equal(userID) {
+		return value.RoleOwner, nil
+	}
+	role, err := p.ICollaboratorRepository.FindTaskRole(ctx, task.ID.Value(), userID)
+	if err != nil {
+		return value.RoleNone, &domain.ErrQueryFailed{}
+	}
+	return role, nil
+}
+
+func (p *AuthorizationPolicy) ListRole(ctx context.Context, list *entity.List, userID string) (value.Role, error) {
+	if list.UserID.Equal(userID) {
+		return value.RoleOwner, nil
+	}
+	role, err := p.ICollaboratorRepository.FindListRole(ctx, list.ID.Value(), userID)
+	if err != nil {
+		return value.RoleNone, &domain.ErrQueryFailed{}
+	}
+	return role, nil
+}
+
+func (p *AuthorizationPolicy) AuthorizeTask(ctx context.Context, task *entity.Task, userID string, required value.Role) error {
+	role, err := p.TaskRole(ctx, task, userID)
+	if err != nil {
+		return err
+	}
+	if !role.Includes(required) {
+		return &domain.ErrPermissionDenied{}
+	}
+	return nil
+}
+
+func (p *AuthorizationPolicy) AuthorizeList(ctx context.Context, list *entity.List, userID string, required value.Role) error {
+	role, err := p.ListRole(ctx, list, userID)
+	if err != nil {
+		return err
+	}
+	if !role.Includes(required) {
+		return &domain.ErrPermissionDenied{}
+	}
+	return nil
+}
+
+func (p *AuthorizationPolicy) WorkspaceRole(ctx context.Context, workspaceID string, userID string) (value.WorkspaceRole, error) {
+	if err := value.NewID(workspaceID).Validate(); err != nil {
+		return value.WorkspaceRoleNone, err
+	}
+	member, err := p.IWorkspaceRepository.FindWorkspaceMember(ctx, workspaceID, userID)
+	if err != nil {
+		return value.WorkspaceRoleNone, nil
+	}
+	return member.Role, nil
+}
+
+func (p *AuthorizationPolicy) AuthorizeWorkspace(ctx context.Context, workspaceID string, userID string, required value.WorkspaceRole) error {
+	role, err := p.WorkspaceRole(ctx, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if !role.Includes(required) {
+		return &domain.ErrPermissionDenied{}
+	}
+	return nil
+}
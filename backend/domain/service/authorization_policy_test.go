@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// 所有者以外には何も共有されていない認可ポリシーを作成する
+func newUnsharedPolicy() *AuthorizationPolicy {
+	return newSharedPolicy(value.RoleNone)
+}
+
+// 所有者以外の全てのユーザーにroleの権限が共有されている認可ポリシーを作成する
+func newSharedPolicy(role value.Role) *AuthorizationPolicy {
+	repo := new(mocks.ICollaboratorRepository)
+	repo.On("FindTaskRole", mock.Anything, mock.Anything, mock.Anything).Return(role, nil).Maybe()
+	repo.On("FindListRole", mock.Anything, mock.Anything, mock.Anything).Return(role, nil).Maybe()
+	return NewAuthorizationPolicy(repo, new(mocks.IWorkspaceRepository))
+}
+
+// 全てのユーザーがワークスペースでroleの権限を持つ認可ポリシーを作成する。WorkspaceRoleNoneの場合はメンバーではない
+func newWorkspacePolicy(role value.WorkspaceRole) *AuthorizationPolicy {
+	repo := new(mocks.IWorkspaceRepository)
+	if role == value.WorkspaceRoleNone {
+		repo.On("FindWorkspaceMember", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("not found")).Maybe()
+	} else {
+		repo.On("FindWorkspaceMember", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, workspaceID string, userID string) (*entity.WorkspaceMember, error) {
+			return &entity.WorkspaceMember{WorkspaceID: value.NewID(workspaceID), UserID: value.NewID(userID), Role: role}, nil
+		}).Maybe()
+	}
+	return NewAuthorizationPolicy(new(mocks.ICollaboratorRepository), repo)
+}
+
+func TestAuthorizationPolicy_NewAuthorizationPolicy(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ IAuthorizationPolicy = (*AuthorizationPolicy)(nil)
+	})
+}
+
+func TestAuthorizationPolicy_AuthorizeTask(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	task := &entity.Task{ID: value.NewID("tid"), UserID: value.NewID("owner"), ListID: value.NewID("lid"), Name: "task", CreatedAt: now, UpdatedAt: now}
+
+	testcases := []struct {
+		title    string
+		userID   string
+		shared   value.Role
+		required value.Role
+		err      error
+	}{
+		{"正常系: 所有者は共有を参照せずに全ての操作ができること", "owner", value.RoleNone, value.RoleOwner, nil},
+		{"正常系: 閲覧者が閲覧する場合", "uid", value.RoleViewer, value.RoleViewer, nil},
+		{"正常系: 管理者が編集する場合", "uid", value.RoleAdmin, value.RoleEditor, nil},
+		{"準正常系: 閲覧者が編集する場合", "uid", value.RoleViewer, value.RoleEditor, &domain.ErrPermissionDenied{}},
+		{"準正常系: 管理者が所有者の操作をする場合", "uid", value.RoleAdmin, value.RoleOwner, &domain.ErrPermissionDenied{}},
+		{"準正常系: 共有されていない場合", "uid", value.RoleNone, value.RoleViewer, &domain.ErrPermissionDenied{}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			repo := new(mocks.ICollaboratorRepository)
+			if v.userID != "owner" {
+				repo.On("FindTaskRole", ctx, "tid", v.userID).Return(v.shared, nil)
+			}
+			policy := NewAuthorizationPolicy(repo, new(mocks.IWorkspaceRepository))
+			err := policy.AuthorizeTask(ctx, task, v.userID, v.required)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ICollaboratorRepository)
+		repo.On("FindTaskRole", ctx, "tid", "uid").Return(value.RoleNone, errors.New("query error"))
+		policy := NewAuthorizationPolicy(repo, new(mocks.IWorkspaceRepository))
+		err := policy.AuthorizeTask(ctx, task, "uid", value.RoleViewer)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestAuthorizationPolicy_AuthorizeList(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	list := &entity.List{ID: value.NewID("lid"), UserID: value.NewID("owner"), Name: "list", CreatedAt: now, UpdatedAt: now}
+
+	testcases := []struct {
+		title    string
+		userID   string
+		shared   value.Role
+		required value.Role
+		err      error
+	}{
+		{"正常系: 所有者は共有を参照せずに全ての操作ができること", "owner", value.RoleNone, value.RoleOwner, nil},
+		{"正常系: 編集者が編集する場合", "uid", value.RoleEditor, value.RoleEditor, nil},
+		{"準正常系: 編集者が管理する場合", "uid", value.RoleEditor, value.RoleAdmin, &domain.ErrPermissionDenied{}},
+		{"準正常系: 共有されていない場合", "uid", value.RoleNone, value.RoleViewer, &domain.ErrPermissionDenied{}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			repo := new(mocks.ICollaboratorRepository)
+			if v.userID != "owner" {
+				repo.On("FindListRole", ctx, "lid", v.userID).Return(v.shared, nil)
+			}
+			policy := NewAuthorizationPolicy(repo, new(mocks.IWorkspaceRepository))
+			err := policy.AuthorizeList(ctx, list, v.userID, v.required)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ICollaboratorRepository)
+		repo.On("FindListRole", ctx, "lid", "uid").Return(value.RoleNone, errors.New("query error"))
+		policy := NewAuthorizationPolicy(repo, new(mocks.IWorkspaceRepository))
+		err := policy.AuthorizeList(ctx, list, "uid", value.RoleViewer)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestAuthorizationPolicy_AuthorizeWorkspace(tt *testing.T) {
+	ctx := context.Background()
+
+	testcases := []struct {
+		title    string
+		member   *entity.WorkspaceMember
+		required value.WorkspaceRole
+		err      error
+	}{
+		{"正常系: メンバーがメンバーの操作をする場合", &entity.WorkspaceMember{WorkspaceID: value.NewID("wid"), UserID: value.NewID("uid"), Role: value.WorkspaceRoleMember}, value.WorkspaceRoleMember, nil},
+		{"正常系: 所有者が管理者の操作をする場合", &entity.WorkspaceMember{WorkspaceID: value.NewID("wid"), UserID: value.NewID("uid"), Role: value.WorkspaceRoleOwner}, value.WorkspaceRoleAdmin, nil},
+		{"準正常系: メンバーが管理者の操作をする場合", &entity.WorkspaceMember{WorkspaceID: value.NewID("wid"), UserID: value.NewID("uid"), Role: value.WorkspaceRoleMember}, value.WorkspaceRoleAdmin, &domain.ErrPermissionDenied{}},
+		{"準正常系: メンバーではない場合", nil, value.WorkspaceRoleMember, &domain.ErrPermissionDenied{}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			repo := new(mocks.IWorkspaceRepository)
+			if v.member != nil {
+				repo.On("FindWorkspaceMember", ctx, "wid", "uid").Return(v.member, nil)
+			} else {
+				repo.On("FindWorkspaceMember", ctx, "wid", "uid").Return(nil, errors.New("not found"))
+			}
+			policy := NewAuthorizationPolicy(new(mocks.ICollaboratorRepository), repo)
+			err := policy.AuthorizeWorkspace(ctx, "wid", "uid", v.required)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+	tt.Run("準正常系: WorkspaceIDが空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		repo := new(mocks.IWorkspaceRepository)
+		policy := NewAuthorizationPolicy(new(mocks.ICollaboratorRepository), repo)
+		err := policy.AuthorizeWorkspace(ctx, "", "uid", value.WorkspaceRoleMember)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
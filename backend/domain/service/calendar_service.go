@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/domain/repository"
+	"github.com/7oh2020/connect-tasklist/backend/util/clock"
+	"github.com/7oh2020/connect-tasklist/backend/util/identification"
+)
+
+// カレンダーのフィードのドメインロジック
+type ICalendarService interface {
+	IssueFeedToken(ctx context.Context, userID string) (string, error)
+	RevokeFeedToken(ctx context.Context, userID string) error
+	FindFeedTasks(ctx context.Context, token string) ([]*entity.Task, error)
+}
+
+type CalendarService struct {
+	repository.ICalendarFeedRepository
+	repository.ITaskRepository
+	identification.ISecretManager
+	clock.IClockManager
+}
+
+func NewCalendarService(feedRepo repository.ICalendarFeedRepository, taskRepo repository.ITaskRepository, secretManager identification.ISecretManager, clockManager clock.IClockManager) *CalendarService {
+	return &CalendarService{feedRepo, taskRepo, secretManager, clockManager}
+}
+
+// フィードのトークンを発行する。既に発行している場合は古いトークンを無効にして再発行する
+// トークンはハッシュのみを保存するため、発行時にしか取得できない
+func (s *CalendarService) IssueFeedToken(ctx context.Context, userID string) (string, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return "", err
+	}
+	token, err := s.ISecretManager.GenerateSecret()
+	if err != nil {
+		return "", &domain.ErrQueryFailed{Msg: "failed to generate token"}
+	}
+	arg := &entity.CalendarFeed{
+		UserID:    value.NewID(userID),
+		TokenHash: s.ISecretManager.HashSecret(token),
+		CreatedAt: s.IClockManager.GetNow(),
+	}
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	if err := s.ICalendarFeedRepository.UpsertCalendarFeed(ctx, arg); err != nil {
+		return "", &domain.ErrQueryFailed{}
+	}
+	return token, nil
+}
+
+// フィードのトークンを無効にする
+func (s *CalendarService) RevokeFeedToken(ctx context.Context, userID string) error {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	n, err := s.ICalendarFeedRepository.DeleteCalendarFeed(ctx, userID)
+	if err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	if n == 0 {
+		return &domain.ErrNotFound{Msg: "feed not found"}
+	}
+	return nil
+}
+
+// トークンのフィードの所有者のタスクを取得する。トークンが無効な場合は存在しないものとして扱う
+func (s *CalendarService) FindFeedTasks(ctx context.Context, token string) ([]*entity.Task, error) {
+	if token == "" {
+		return nil, &domain.ErrNotFound{Msg: "feed not found"}
+	}
+	feed, err := s.ICalendarFeedRepository.FindCalendarFeedByTokenHash(ctx, s.ISecretManager.HashSecret(token))
+	if err != nil {
+		return nil, &domain.ErrNotFound{Msg: "feed not found"}
+	}
+	tasks, err := s.ITaskRepository.FindTasksByUserID(ctx, feed.UserID.Value())
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return tasks, nil
+}
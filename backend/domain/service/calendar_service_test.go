@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarService_NewCalendarService(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ ICalendarService = (*CalendarService)(nil)
+	})
+}
+
+func TestCalendarService_IssueFeedToken(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	uid := "uid"
+	feed := &entity.CalendarFeed{UserID: value.NewID(uid), TokenHash: "hash", CreatedAt: now}
+
+	tt.Run("正常系: トークンのハッシュを保存し、トークンを返すこと", func(t *testing.T) {
+		repo := new(mocks.ICalendarFeedRepository)
+		repo.On("UpsertCalendarFeed", ctx, feed).Return(nil)
+		sm := new(mocks.ISecretManager)
+		sm.On("GenerateSecret").Return("token", nil)
+		sm.On("HashSecret", "token").Return("hash")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewCalendarService(repo, new(mocks.ITaskRepository), sm, cm)
+		ret, err := srv.IssueFeedToken(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, "token", ret)
+		repo.AssertExpectations(t)
+		sm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: トークンを生成できない場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{Msg: "failed to generate token"}
+		repo := new(mocks.ICalendarFeedRepository)
+		sm := new(mocks.ISecretManager)
+		sm.On("GenerateSecret").Return("", errors.New("failed"))
+		srv := NewCalendarService(repo, new(mocks.ITaskRepository), sm, new(mocks.IClockManager))
+		_, err := srv.IssueFeedToken(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		sm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 保存に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ICalendarFeedRepository)
+		repo.On("UpsertCalendarFeed", ctx, feed).Return(errors.New("failed"))
+		sm := new(mocks.ISecretManager)
+		sm.On("GenerateSecret").Return("token", nil)
+		sm.On("HashSecret", "token").Return("hash")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewCalendarService(repo, new(mocks.ITaskRepository), sm, cm)
+		_, err := srv.IssueFeedToken(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: ユーザーIDが空の場合", func(t *testing.T) {
+		repo := new(mocks.ICalendarFeedRepository)
+		sm := new(mocks.ISecretManager)
+		srv := NewCalendarService(repo, new(mocks.ITaskRepository), sm, new(mocks.IClockManager))
+		_, err := srv.IssueFeedToken(ctx, "")
+
+		require.Error(t, err, "エラーが発生すること")
+		repo.AssertExpectations(t)
+		sm.AssertExpectations(t)
+	})
+}
+
+func TestCalendarService_RevokeFeedToken(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+
+	testcases := []struct {
+		title string
+		n     int64
+		err   error
+		exp   error
+	}{
+		{"正常系: 発行済みの場合", 1, nil, nil},
+		{"準正常系: 発行していない場合", 0, nil, &domain.ErrNotFound{Msg: "feed not found"}},
+		{"準正常系: 削除に失敗した場合", 0, errors.New("failed"), &domain.ErrQueryFailed{}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			repo := new(mocks.ICalendarFeedRepository)
+			repo.On("DeleteCalendarFeed", ctx, uid).Return(v.n, v.err)
+			srv := NewCalendarService(repo, new(mocks.ITaskRepository), new(mocks.ISecretManager), new(mocks.IClockManager))
+			err := srv.RevokeFeedToken(ctx, uid)
+
+			if v.exp == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.exp.Error(), "エラーが一致すること")
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCalendarService_FindFeedTasks(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	uid := "uid"
+	feed := &entity.CalendarFeed{UserID: value.NewID(uid), TokenHash: "hash", CreatedAt: now}
+	tasks := []*entity.Task{{ID: value.NewID("tid"), UserID: value.NewID(uid), Name: "a", CreatedAt: now, UpdatedAt: now}}
+
+	tt.Run("正常系: フィードの所有者のタスクを返すこと", func(t *testing.T) {
+		repo := new(mocks.ICalendarFeedRepository)
+		repo.On("FindCalendarFeedByTokenHash", ctx, "hash").Return(feed, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTasksByUserID", ctx, uid).Return(tasks, nil)
+		sm := new(mocks.ISecretManager)
+		sm.On("HashSecret", "token").Return("hash")
+		srv := NewCalendarService(repo, taskRepo, sm, new(mocks.IClockManager))
+		ret, err := srv.FindFeedTasks(ctx, "token")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tasks, ret)
+		repo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: トークンが無効な場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "feed not found"}
+		repo := new(mocks.ICalendarFeedRepository)
+		repo.On("FindCalendarFeedByTokenHash", ctx, "hash").Return(nil, errors.New("no rows"))
+		taskRepo := new(mocks.ITaskRepository)
+		sm := new(mocks.ISecretManager)
+		sm.On("HashSecret", "token").Return("hash")
+		srv := NewCalendarService(repo, taskRepo, sm, new(mocks.IClockManager))
+		_, err := srv.FindFeedTasks(ctx, "token")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: トークンが空の場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "feed not found"}
+		repo := new(mocks.ICalendarFeedRepository)
+		srv := NewCalendarService(repo, new(mocks.ITaskRepository), new(mocks.ISecretManager), new(mocks.IClockManager))
+		_, err := srv.FindFeedTasks(ctx, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: タスクの取得に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ICalendarFeedRepository)
+		repo.On("FindCalendarFeedByTokenHash", ctx, "hash").Return(feed, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTasksByUserID", ctx, uid).Return(nil, errors.New("failed"))
+		sm := new(mocks.ISecretManager)
+		sm.On("HashSecret", "token").Return("hash")
+		srv := NewCalendarService(repo, taskRepo, sm, new(mocks.IClockManager))
+		_, err := srv.FindFeedTasks(ctx, "token")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		taskRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/domain/repository"
+	"github.com/7oh2020/connect-tasklist/backend/util/clock"
+	"github.com/7oh2020/connect-tasklist/backend/util/identification"
+)
+
+// コメントのドメインロジック
+type ICommentService interface {
+	FindCommentsByTaskID(ctx context.Context, taskID string, userID string) ([]*entity.Comment, error)
+	AddComment(ctx context.Context, taskID string, userID string, body string) (string, error)
+	EditComment(ctx context.Context, id string, userID string, body string) error
+	DeleteComment(ctx context.Context, id string, userID string) error
+}
+
+type CommentService struct {
+	repository.ICommentRepository
+	repository.ITaskRepository
+	IAuthorizationPolicy
+	identification.IIDManager
+	clock.IClockManager
+}
+
+func NewCommentService(commentRepo repository.ICommentRepository, taskRepo repository.ITaskRepository, policy IAuthorizationPolicy, idManager identification.IIDManager, clockManager clock.IClockManager) *CommentService {
+	return &CommentService{commentRepo, taskRepo, policy, idManager, clockManager}
+}
+
+// タスクのコメントを古い順に取得する
+func (s *CommentService) FindCommentsByTaskID(ctx context.Context, taskID string, userID string) ([]*entity.Comment, error) {
+	if err := s.checkTask(ctx, taskID, userID, value.RoleViewer); err != nil {
+		return nil, err
+	}
+	comments, err := s.ICommentRepository.FindCommentsByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return comments, nil
+}
+
+func (s *CommentService) AddComment(ctx context.Context, taskID string, userID string, body string) (string, error) {
+	if err := s.checkTask(ctx, taskID, userID, value.RoleEditor); err != nil {
+		return "", err
+	}
+	now := s.IClockManager.GetNow()
+	arg := &entity.Comment{
+		ID:        value.NewID(s.IIDManager.GenerateID()),
+		TaskID:    value.NewID(taskID),
+		UserID:    value.NewID(userID),
+		Body:      body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	createdID, err := s.ICommentRepository.CreateComment(ctx, arg)
+	if err != nil {
+		return "", &domain.ErrQueryFailed{}
+	}
+	return createdID, nil
+}
+
+// コメントの本文を変更する。本文が変わった場合は編集済みになる
+func (s *CommentService) EditComment(ctx context.Context, id string, userID string, body string) error {
+	comment, err := s.findOwnComment(ctx, id, userID, value.RoleEditor)
+	if err != nil {
+		return err
+	}
+	if comment.Body == body {
+		return nil
+	}
+	now := s.IClockManager.GetNow()
+	comment.Body = body
+	comment.UpdatedAt = now
+	comment.EditedAt = &now
+	if err := comment.Validate(); err != nil {
+		return err
+	}
+	if err := s.ICommentRepository.UpdateComment(ctx, comment); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+func (s *CommentService) DeleteComment(ctx context.Context, id string, userID string) error {
+	if _, err := s.findOwnComment(ctx, id, userID, value.RoleViewer); err != nil {
+		return err
+	}
+	if err := s.ICommentRepository.DeleteComment(ctx, id); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+// タスクに対してrequired以上の権限を持つか検証する。ゴミ箱のタスクは存在しないものとして扱う
+func (s *CommentService) checkTask(ctx context.Context, taskID string, userID string, required value.Role) error {
+	if err := value.NewID(taskID).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	task, err := s.ITaskRepository.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "task not found"}
+	}
+	return s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, required)
+}
+
+// 自分が書いたコメントを取得する。コメントが付いたタスクに対してrequired以上の権限を持つことも検証する
+func (s *CommentService) findOwnComment(ctx context.Context, id string, userID string, required value.Role) (*entity.Comment, error) {
+	if err := value.NewID(id).Validate(); err != nil {
+		return nil, err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	comment, err := s.ICommentRepository.FindCommentByID(ctx, id)
+	if err != nil {
+		return nil, &domain.ErrNotFound{Msg: "comment not found"}
+	}
+	if err := s.checkTask(ctx, comment.TaskID.Value(), userID, required); err != nil {
+		return nil, err
+	}
+	if !comment.UserID.Equal(userID) {
+		return nil, &domain.ErrPermissionDenied{}
+	}
+	return comment, nil
+}
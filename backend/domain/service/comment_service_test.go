@@ -0,0 +1,384 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentService_NewCommentService(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ ICommentService = (*CommentService)(nil)
+	})
+}
+
+func TestCommentService_FindCommentsByTaskID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	tid := "tid"
+	uid := "uid"
+	task := &entity.Task{ID: value.NewID(tid), UserID: value.NewID(uid), Name: "task", CreatedAt: now, UpdatedAt: now}
+	comments := []*entity.Comment{
+		{ID: value.NewID("c1"), TaskID: value.NewID(tid), UserID: value.NewID(uid), Body: "first", CreatedAt: now, UpdatedAt: now},
+		{ID: value.NewID("c2"), TaskID: value.NewID(tid), UserID: value.NewID(uid), Body: "second", CreatedAt: now, UpdatedAt: now, EditedAt: &now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		commentRepo := new(mocks.ICommentRepository)
+		commentRepo.On("FindCommentsByTaskID", ctx, tid).Return(comments, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		ret, err := srv.FindCommentsByTaskID(ctx, tid, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, comments, ret)
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: TaskIDが空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		commentRepo := new(mocks.ICommentRepository)
+		taskRepo := new(mocks.ITaskRepository)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindCommentsByTaskID(ctx, "", uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: タスクが存在しない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		commentRepo := new(mocks.ICommentRepository)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(nil, errExp)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindCommentsByTaskID(ctx, tid, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		commentRepo := new(mocks.ICommentRepository)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindCommentsByTaskID(ctx, tid, "another")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		commentRepo := new(mocks.ICommentRepository)
+		commentRepo.On("FindCommentsByTaskID", ctx, tid).Return(nil, errExp)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindCommentsByTaskID(ctx, tid, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+}
+
+func TestCommentService_AddComment(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	tid := "tid"
+	uid := "uid"
+	task := &entity.Task{ID: value.NewID(tid), UserID: value.NewID(uid), Name: "task", CreatedAt: now, UpdatedAt: now}
+	comment := &entity.Comment{ID: value.NewID(id), TaskID: value.NewID(tid), UserID: value.NewID(uid), Body: "comment", CreatedAt: now, UpdatedAt: now}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		commentRepo := new(mocks.ICommentRepository)
+		commentRepo.On("CreateComment", ctx, comment).Return(id, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		createdID, err := srv.AddComment(ctx, tid, uid, "comment")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, id, createdID)
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 本文が空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "body is empty"}
+		commentRepo := new(mocks.ICommentRepository)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		_, err := srv.AddComment(ctx, tid, uid, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: タスクが存在しない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		commentRepo := new(mocks.ICommentRepository)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(nil, errExp)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.AddComment(ctx, tid, uid, "comment")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		commentRepo := new(mocks.ICommentRepository)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.AddComment(ctx, tid, "another", "comment")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		commentRepo := new(mocks.ICommentRepository)
+		commentRepo.On("CreateComment", ctx, comment).Return("", errExp)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		_, err := srv.AddComment(ctx, tid, uid, "comment")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+}
+
+func TestCommentService_EditComment(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	upd := now.Add(time.Minute)
+	id := "id"
+	tid := "tid"
+	uid := "uid"
+	task := &entity.Task{ID: value.NewID(tid), UserID: value.NewID(uid), Name: "task", CreatedAt: now, UpdatedAt: now}
+	// 変更がサブテストをまたいで影響しないように複製を返す
+	newComment := func() *entity.Comment {
+		return &entity.Comment{ID: value.NewID(id), TaskID: value.NewID(tid), UserID: value.NewID(uid), Body: "comment", CreatedAt: now, UpdatedAt: now}
+	}
+	edited := &entity.Comment{ID: value.NewID(id), TaskID: value.NewID(tid), UserID: value.NewID(uid), Body: "edited", CreatedAt: now, UpdatedAt: upd, EditedAt: &upd}
+
+	tt.Run("正常系: 編集済みになること", func(t *testing.T) {
+		commentRepo := new(mocks.ICommentRepository)
+		commentRepo.On("FindCommentByID", ctx, id).Return(newComment(), nil)
+		commentRepo.On("UpdateComment", ctx, edited).Return(nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		err := srv.EditComment(ctx, id, uid, "edited")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("正常系: 本文が変わらない場合は何もしないこと", func(t *testing.T) {
+		commentRepo := new(mocks.ICommentRepository)
+		commentRepo.On("FindCommentByID", ctx, id).Return(newComment(), nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		cm := new(mocks.IClockManager)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		err := srv.EditComment(ctx, id, uid, "comment")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 本文が空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "body is empty"}
+		commentRepo := new(mocks.ICommentRepository)
+		commentRepo.On("FindCommentByID", ctx, id).Return(newComment(), nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		err := srv.EditComment(ctx, id, uid, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: コメントが存在しない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "comment not found"}
+		commentRepo := new(mocks.ICommentRepository)
+		commentRepo.On("FindCommentByID", ctx, id).Return(nil, errExp)
+		taskRepo := new(mocks.ITaskRepository)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.EditComment(ctx, id, uid, "edited")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: タスクがゴミ箱にある場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		commentRepo := new(mocks.ICommentRepository)
+		commentRepo.On("FindCommentByID", ctx, id).Return(newComment(), nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(nil, errExp)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.EditComment(ctx, id, uid, "edited")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		commentRepo := new(mocks.ICommentRepository)
+		commentRepo.On("FindCommentByID", ctx, id).Return(newComment(), nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.EditComment(ctx, id, "another", "edited")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーが書いたコメントの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		comment := newComment()
+		comment.UserID = value.NewID("another")
+		commentRepo := new(mocks.ICommentRepository)
+		commentRepo.On("FindCommentByID", ctx, id).Return(comment, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.EditComment(ctx, id, uid, "edited")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		commentRepo := new(mocks.ICommentRepository)
+		commentRepo.On("FindCommentByID", ctx, id).Return(newComment(), nil)
+		commentRepo.On("UpdateComment", ctx, edited).Return(errExp)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		err := srv.EditComment(ctx, id, uid, "edited")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+}
+
+func TestCommentService_DeleteComment(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	tid := "tid"
+	uid := "uid"
+	task := &entity.Task{ID: value.NewID(tid), UserID: value.NewID(uid), Name: "task", CreatedAt: now, UpdatedAt: now}
+	comment := &entity.Comment{ID: value.NewID(id), TaskID: value.NewID(tid), UserID: value.NewID(uid), Body: "comment", CreatedAt: now, UpdatedAt: now}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		commentRepo := new(mocks.ICommentRepository)
+		commentRepo.On("FindCommentByID", ctx, id).Return(comment, nil)
+		commentRepo.On("DeleteComment", ctx, id).Return(nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.DeleteComment(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: IDが空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		commentRepo := new(mocks.ICommentRepository)
+		taskRepo := new(mocks.ITaskRepository)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.DeleteComment(ctx, "", uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: コメントが存在しない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "comment not found"}
+		commentRepo := new(mocks.ICommentRepository)
+		commentRepo.On("FindCommentByID", ctx, id).Return(nil, errExp)
+		taskRepo := new(mocks.ITaskRepository)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.DeleteComment(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		commentRepo := new(mocks.ICommentRepository)
+		commentRepo.On("FindCommentByID", ctx, id).Return(comment, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.DeleteComment(ctx, id, "another")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		commentRepo := new(mocks.ICommentRepository)
+		commentRepo.On("FindCommentByID", ctx, id).Return(comment, nil)
+		commentRepo.On("DeleteComment", ctx, id).Return(errExp)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		srv := NewCommentService(commentRepo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.DeleteComment(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		commentRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/domain/repository"
+	"github.com/7oh2020/connect-tasklist/backend/util/clock"
+	"github.com/7oh2020/connect-tasklist/backend/util/identification"
+)
+
+// リストのドメインロジック
+type IListService interface {
+	FindListsByUserID(ctx context.Context, userID string) ([]*entity.List, error)
+	FindListsByWorkspaceID(ctx context.Context, workspaceID string, userID string) ([]*entity.List, error)
+	CreateList(ctx context.Context, userID string, name string) (string, error)
+	CreateWorkspaceList(ctx context.Context, workspaceID string, userID string, name string) (string, error)
+	RenameList(ctx context.Context, id string, userID string, name string) error
+	ArchiveList(ctx context.Context, id string, userID string) error
+	UnarchiveList(ctx context.Context, id string, userID string) error
+	DeleteList(ctx context.Context, id string, userID string) error
+	ReorderLists(ctx context.Context, userID string, ids []string) error
+}
+
+type ListService struct {
+	repository.IListRepository
+	IAuthorizationPolicy
+	identification.IIDManager
+	clock.IClockManager
+}
+
+func NewListService(repo repository.IListRepository, policy IAuthorizationPolicy, idManager identification.IIDManager, clockManager clock.IClockManager) *ListService {
+	return &ListService{repo, policy, idManager, clockManager}
+}
+
+// 個人のリストを表示順に取得する。Inboxが存在しない場合は作成する
+func (s *ListService) FindListsByUserID(ctx context.Context, userID string) ([]*entity.List, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := findOrCreateInbox(ctx, s.IListRepository, s.IIDManager, s.IClockManager, userID); err != nil {
+		return nil, err
+	}
+	lists, err := s.IListRepository.FindListsByUserID(ctx, userID)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return lists, nil
+}
+
+// ワークスペースのリストを表示順に取得する。メンバーのみ取得できる
+func (s *ListService) FindListsByWorkspaceID(ctx context.Context, workspaceID string, userID string) ([]*entity.List, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeWorkspace(ctx, workspaceID, userID, value.WorkspaceRoleMember); err != nil {
+		return nil, err
+	}
+	lists, err := s.IListRepository.FindListsByWorkspaceID(ctx, workspaceID)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return lists, nil
+}
+
+// 個人のリストを作成する。作成したリストは末尾に追加される
+func (s *ListService) CreateList(ctx context.Context, userID string, name string) (string, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return "", err
+	}
+	maxPosition, err := s.IListRepository.FindMaxListPosition(ctx, userID)
+	if err != nil {
+		return "", &domain.ErrQueryFailed{}
+	}
+	now := s.IClockManager.GetNow()
+	arg := &entity.List{
+		ID:        value.NewID(s.IIDManager.GenerateID()),
+		UserID:    value.NewID(userID),
+		Name:      name,
+		Position:  maxPosition + 1,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	createdID, err := s.IListRepository.CreateList(ctx, arg)
+	if err != nil {
+		return "", &domain.ErrQueryFailed{}
+	}
+	return createdID, nil
+}
+
+// ワークスペースにリストを作成する。作成したユーザーがリストの所有者になり、他のメンバーはワークスペースの権限に応じて操作できる
+func (s *ListService) CreateWorkspaceList(ctx context.Context, workspaceID string, userID string, name string) (string, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return "", err
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeWorkspace(ctx, workspaceID, userID, value.WorkspaceRoleMember); err != nil {
+		return "", err
+	}
+	maxPosition, err := s.IListRepository.FindMaxWorkspaceListPosition(ctx, workspaceID)
+	if err != nil {
+		return "", &domain.ErrQueryFailed{}
+	}
+	now := s.IClockManager.GetNow()
+	arg := &entity.List{
+		ID:          value.NewID(s.IIDManager.GenerateID()),
+		UserID:      value.NewID(userID),
+		Name:        name,
+		Position:    maxPosition + 1,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		WorkspaceID: value.NewID(workspaceID),
+	}
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	createdID, err := s.IListRepository.CreateList(ctx, arg)
+	if err != nil {
+		return "", &domain.ErrQueryFailed{}
+	}
+	return createdID, nil
+}
+
+func (s *ListService) RenameList(ctx context.Context, id string, userID string, name string) error {
+	list, err := s.findList(ctx, id, userID, value.RoleAdmin)
+	if err != nil {
+		return err
+	}
+	list.Name = name
+	return s.updateList(ctx, list)
+}
+
+// リストをアーカイブする。アーカイブされたリストのタスクは一覧に表示されない
+func (s *ListService) ArchiveList(ctx context.Context, id string, userID string) error {
+	list, err := s.findList(ctx, id, userID, value.RoleAdmin)
+	if err != nil {
+		return err
+	}
+	if list.IsInbox {
+		return &domain.ErrPreconditionFailed{Msg: "inbox cannot be archived"}
+	}
+	list.IsArchived = true
+	return s.updateList(ctx, list)
+}
+
+func (s *ListService) UnarchiveList(ctx context.Context, id string, userID string) error {
+	list, err := s.findList(ctx, id, userID, value.RoleAdmin)
+	if err != nil {
+		return err
+	}
+	list.IsArchived = false
+	return s.updateList(ctx, list)
+}
+
+// リストを削除する。リストのタスクも合わせて削除されるため所有者のみ削除できる
+func (s *ListService) DeleteList(ctx context.Context, id string, userID string) error {
+	list, err := s.findList(ctx, id, userID, value.RoleOwner)
+	if err != nil {
+		return err
+	}
+	if list.IsInbox {
+		return &domain.ErrPreconditionFailed{Msg: "inbox cannot be deleted"}
+	}
+	if err := s.IListRepository.DeleteList(ctx, id); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+// 個人のリストを指定した順に並べ替える。idsにはユーザーの全ての個人のリストを過不足なく指定する
+func (s *ListService) ReorderLists(ctx context.Context, userID string, ids []string) error {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	lists, err := s.IListRepository.FindListsByUserID(ctx, userID)
+	if err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	if len(ids) != len(lists) {
+		return &domain.ErrValidationFailed{Msg: "list ids do not match"}
+	}
+	listMap := make(map[string]*entity.List, len(lists))
+	for _, v := range lists {
+		listMap[v.ID.Value()] = v
+	}
+	sorted := make([]*entity.List, len(ids))
+	for i, id := range ids {
+		list, ok := listMap[id]
+		if !ok {
+			return &domain.ErrValidationFailed{Msg: "list ids do not match"}
+		}
+		// 重複したIDを検出するため一度使用したリストは除外する
+		delete(listMap, id)
+		sorted[i] = list
+	}
+	now := s.IClockManager.GetNow()
+	for i, list := range sorted {
+		if list.Position == int32(i) {
+			continue
+		}
+		list.Position = int32(i)
+		list.UpdatedAt = now
+		if err := s.IListRepository.UpdateList(ctx, list); err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+	}
+	return nil
+}
+
+// 指定したリストを取得し、ユーザーがrequired以上の権限を持つことを検証する
+func (s *ListService) findList(ctx context.Context, id string, userID string, required value.Role) (*entity.List, error) {
+	if err := value.NewID(id).Validate(); err != nil {
+		return nil, err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	list, err := s.IListRepository.FindListByID(ctx, id)
+	if err != nil {
+		return nil, &domain.ErrNotFound{Msg: "list not found"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeList(ctx, list, userID, required); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s *ListService) updateList(ctx context.Context, list *entity.List) error {
+	list.UpdatedAt = s.IClockManager.GetNow()
+	if err := list.Validate(); err != nil {
+		return err
+	}
+	if err := s.IListRepository.UpdateList(ctx, list); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+// ユーザーのInboxを取得する。存在しない場合は作成する
+func findOrCreateInbox(ctx context.Context, repo repository.IListRepository, im identification.IIDManager, cm clock.IClockManager, userID string) (*entity.List, error) {
+	if inbox, err := repo.FindInboxByUserID(ctx, userID); err == nil {
+		return inbox, nil
+	}
+	now := cm.GetNow()
+	inbox := &entity.List{
+		ID:        value.NewID(im.GenerateID()),
+		UserID:    value.NewID(userID),
+		Name:      entity.InboxListName,
+		IsInbox:   true,
+		Position:  0,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := inbox.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := repo.CreateList(ctx, inbox); err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return inbox, nil
+}
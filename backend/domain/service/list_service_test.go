@@ -0,0 +1,546 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListService_NewListService(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ IListService = (*ListService)(nil)
+	})
+}
+
+func TestListService_FindListsByUserID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	inbox := &entity.List{ID: value.NewID("inbox"), UserID: value.NewID(uid), Name: entity.InboxListName, IsInbox: true, CreatedAt: now, UpdatedAt: now}
+	lists := []*entity.List{
+		inbox,
+		{ID: value.NewID("l1"), UserID: value.NewID(uid), Name: "work", Position: 1, CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.IListRepository)
+		repo.On("FindInboxByUserID", ctx, uid).Return(inbox, nil)
+		repo.On("FindListsByUserID", ctx, uid).Return(lists, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		ret, err := srv.FindListsByUserID(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, lists, ret)
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("正常系: Inboxが存在しない場合は作成すること", func(t *testing.T) {
+		repo := new(mocks.IListRepository)
+		repo.On("FindInboxByUserID", ctx, uid).Return(nil, errors.New("no rows"))
+		repo.On("CreateList", ctx, inbox).Return("inbox", nil)
+		repo.On("FindListsByUserID", ctx, uid).Return(lists, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("inbox")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		ret, err := srv.FindListsByUserID(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, lists, ret)
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: UserIDが空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		repo := new(mocks.IListRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		_, err := srv.FindListsByUserID(ctx, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.IListRepository)
+		repo.On("FindInboxByUserID", ctx, uid).Return(inbox, nil)
+		repo.On("FindListsByUserID", ctx, uid).Return(nil, errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		_, err := srv.FindListsByUserID(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestListService_FindListsByWorkspaceID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	wid := "wid"
+	uid := "uid"
+	lists := []*entity.List{
+		{ID: value.NewID("l1"), UserID: value.NewID("other"), Name: "team", CreatedAt: now, UpdatedAt: now, WorkspaceID: value.NewID(wid)},
+	}
+
+	tt.Run("正常系: メンバーの場合", func(t *testing.T) {
+		repo := new(mocks.IListRepository)
+		repo.On("FindListsByWorkspaceID", ctx, wid).Return(lists, nil)
+		srv := NewListService(repo, newWorkspacePolicy(value.WorkspaceRoleMember), new(mocks.IIDManager), new(mocks.IClockManager))
+		ret, err := srv.FindListsByWorkspaceID(ctx, wid, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, lists, ret)
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: メンバーではない場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.IListRepository)
+		srv := NewListService(repo, newWorkspacePolicy(value.WorkspaceRoleNone), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindListsByWorkspaceID(ctx, wid, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.IListRepository)
+		repo.On("FindListsByWorkspaceID", ctx, wid).Return(nil, errors.New("query error"))
+		srv := NewListService(repo, newWorkspacePolicy(value.WorkspaceRoleMember), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindListsByWorkspaceID(ctx, wid, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestListService_CreateList(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	uid := "uid"
+	list := &entity.List{ID: value.NewID(id), UserID: value.NewID(uid), Name: "work", Position: 3, CreatedAt: now, UpdatedAt: now}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.IListRepository)
+		repo.On("FindMaxListPosition", ctx, uid).Return(int32(2), nil)
+		repo.On("CreateList", ctx, list).Return(id, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		ret, err := srv.CreateList(ctx, uid, list.Name)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, id, ret)
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 名前が空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "name is empty"}
+		repo := new(mocks.IListRepository)
+		repo.On("FindMaxListPosition", ctx, uid).Return(int32(2), nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		_, err := srv.CreateList(ctx, uid, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.IListRepository)
+		repo.On("FindMaxListPosition", ctx, uid).Return(int32(2), nil)
+		repo.On("CreateList", ctx, list).Return("", errExp)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		_, err := srv.CreateList(ctx, uid, list.Name)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestListService_CreateWorkspaceList(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	wid := "wid"
+	uid := "uid"
+	list := &entity.List{ID: value.NewID(id), UserID: value.NewID(uid), Name: "team", Position: 1, CreatedAt: now, UpdatedAt: now, WorkspaceID: value.NewID(wid)}
+
+	tt.Run("正常系: メンバーの場合", func(t *testing.T) {
+		repo := new(mocks.IListRepository)
+		repo.On("FindMaxWorkspaceListPosition", ctx, wid).Return(int32(0), nil)
+		repo.On("CreateList", ctx, list).Return(id, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewListService(repo, newWorkspacePolicy(value.WorkspaceRoleMember), im, cm)
+		ret, err := srv.CreateWorkspaceList(ctx, wid, uid, list.Name)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, id, ret)
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: メンバーではない場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.IListRepository)
+		srv := NewListService(repo, newWorkspacePolicy(value.WorkspaceRoleNone), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.CreateWorkspaceList(ctx, wid, uid, list.Name)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: WorkspaceIDが空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		repo := new(mocks.IListRepository)
+		srv := NewListService(repo, newWorkspacePolicy(value.WorkspaceRoleMember), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.CreateWorkspaceList(ctx, "", uid, list.Name)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.IListRepository)
+		repo.On("FindMaxWorkspaceListPosition", ctx, wid).Return(int32(0), nil)
+		repo.On("CreateList", ctx, list).Return("", errors.New("query error"))
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewListService(repo, newWorkspacePolicy(value.WorkspaceRoleMember), im, cm)
+		_, err := srv.CreateWorkspaceList(ctx, wid, uid, list.Name)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestListService_RenameList(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	upd := now.Add(time.Second)
+	id := "id"
+	uid := "uid"
+	newList := func() *entity.List {
+		return &entity.List{ID: value.NewID(id), UserID: value.NewID(uid), Name: "work", Position: 1, CreatedAt: now, UpdatedAt: now}
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		arg := newList()
+		arg.Name = "private"
+		arg.UpdatedAt = upd
+		repo := new(mocks.IListRepository)
+		repo.On("FindListByID", ctx, id).Return(newList(), nil)
+		repo.On("UpdateList", ctx, arg).Return(nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		err := srv.RenameList(ctx, id, uid, "private")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 名前が空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "name is empty"}
+		repo := new(mocks.IListRepository)
+		repo.On("FindListByID", ctx, id).Return(newList(), nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		err := srv.RenameList(ctx, id, uid, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないListIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "list not found"}
+		repo := new(mocks.IListRepository)
+		repo.On("FindListByID", ctx, id).Return(nil, errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		err := srv.RenameList(ctx, id, uid, "private")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのリストの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.IListRepository)
+		repo.On("FindListByID", ctx, id).Return(newList(), nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		err := srv.RenameList(ctx, id, "another", "private")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestListService_ArchiveList(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	upd := now.Add(time.Second)
+	id := "id"
+	uid := "uid"
+	newList := func() *entity.List {
+		return &entity.List{ID: value.NewID(id), UserID: value.NewID(uid), Name: "work", Position: 1, CreatedAt: now, UpdatedAt: now}
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		arg := newList()
+		arg.IsArchived = true
+		arg.UpdatedAt = upd
+		repo := new(mocks.IListRepository)
+		repo.On("FindListByID", ctx, id).Return(newList(), nil)
+		repo.On("UpdateList", ctx, arg).Return(nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		err := srv.ArchiveList(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: Inboxの場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "inbox cannot be archived"}
+		inbox := newList()
+		inbox.IsInbox = true
+		repo := new(mocks.IListRepository)
+		repo.On("FindListByID", ctx, id).Return(inbox, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		err := srv.ArchiveList(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		arg := newList()
+		arg.IsArchived = true
+		arg.UpdatedAt = upd
+		repo := new(mocks.IListRepository)
+		repo.On("FindListByID", ctx, id).Return(newList(), nil)
+		repo.On("UpdateList", ctx, arg).Return(errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		err := srv.ArchiveList(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestListService_UnarchiveList(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	upd := now.Add(time.Second)
+	id := "id"
+	uid := "uid"
+	newList := func() *entity.List {
+		return &entity.List{ID: value.NewID(id), UserID: value.NewID(uid), Name: "work", IsArchived: true, Position: 1, CreatedAt: now, UpdatedAt: now}
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		arg := newList()
+		arg.IsArchived = false
+		arg.UpdatedAt = upd
+		repo := new(mocks.IListRepository)
+		repo.On("FindListByID", ctx, id).Return(newList(), nil)
+		repo.On("UpdateList", ctx, arg).Return(nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		err := srv.UnarchiveList(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのリストの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.IListRepository)
+		repo.On("FindListByID", ctx, id).Return(newList(), nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		err := srv.UnarchiveList(ctx, id, "another")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestListService_DeleteList(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	uid := "uid"
+	list := &entity.List{ID: value.NewID(id), UserID: value.NewID(uid), Name: "work", Position: 1, CreatedAt: now, UpdatedAt: now}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.IListRepository)
+		repo.On("FindListByID", ctx, id).Return(list, nil)
+		repo.On("DeleteList", ctx, id).Return(nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		err := srv.DeleteList(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: Inboxの場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "inbox cannot be deleted"}
+		repo := new(mocks.IListRepository)
+		repo.On("FindListByID", ctx, id).Return(&entity.List{ID: list.ID, UserID: list.UserID, Name: entity.InboxListName, IsInbox: true, CreatedAt: now, UpdatedAt: now}, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		err := srv.DeleteList(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: ListIDが空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		repo := new(mocks.IListRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		err := srv.DeleteList(ctx, "", uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.IListRepository)
+		repo.On("FindListByID", ctx, id).Return(list, nil)
+		repo.On("DeleteList", ctx, id).Return(errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		err := srv.DeleteList(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestListService_ReorderLists(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	upd := now.Add(time.Second)
+	uid := "uid"
+	newLists := func() []*entity.List {
+		return []*entity.List{
+			{ID: value.NewID("inbox"), UserID: value.NewID(uid), Name: entity.InboxListName, IsInbox: true, Position: 0, CreatedAt: now, UpdatedAt: now},
+			{ID: value.NewID("l1"), UserID: value.NewID(uid), Name: "work", Position: 1, CreatedAt: now, UpdatedAt: now},
+			{ID: value.NewID("l2"), UserID: value.NewID(uid), Name: "home", Position: 2, CreatedAt: now, UpdatedAt: now},
+		}
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.IListRepository)
+		repo.On("FindListsByUserID", ctx, uid).Return(newLists(), nil)
+		repo.On("UpdateList", ctx, &entity.List{ID: value.NewID("l2"), UserID: value.NewID(uid), Name: "home", Position: 1, CreatedAt: now, UpdatedAt: upd}).Return(nil)
+		repo.On("UpdateList", ctx, &entity.List{ID: value.NewID("l1"), UserID: value.NewID(uid), Name: "work", Position: 2, CreatedAt: now, UpdatedAt: upd}).Return(nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		err := srv.ReorderLists(ctx, uid, []string{"inbox", "l2", "l1"})
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: リストが不足している場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "list ids do not match"}
+		repo := new(mocks.IListRepository)
+		repo.On("FindListsByUserID", ctx, uid).Return(newLists(), nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		err := srv.ReorderLists(ctx, uid, []string{"inbox", "l2"})
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 重複したIDが含まれる場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "list ids do not match"}
+		repo := new(mocks.IListRepository)
+		repo.On("FindListsByUserID", ctx, uid).Return(newLists(), nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		err := srv.ReorderLists(ctx, uid, []string{"inbox", "l1", "l1"})
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのリストが含まれる場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "list ids do not match"}
+		repo := new(mocks.IListRepository)
+		repo.On("FindListsByUserID", ctx, uid).Return(newLists(), nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		err := srv.ReorderLists(ctx, uid, []string{"inbox", "l1", "another"})
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.IListRepository)
+		repo.On("FindListsByUserID", ctx, uid).Return(nil, errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewListService(repo, newUnsharedPolicy(), im, cm)
+		err := srv.ReorderLists(ctx, uid, []string{"inbox"})
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
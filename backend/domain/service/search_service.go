@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/domain/repository"
+)
+
+// タスクの全文検索のドメインロジック
+type ISearchService interface {
+	SearchTasks(ctx context.Context, userID string, query string, limit int32, cursor *value.SearchCursor) ([]*entity.TaskSearchResult, *value.SearchCursor, error)
+	SearchWorkspaceTasks(ctx context.Context, workspaceID string, userID string, query string, limit int32, cursor *value.SearchCursor) ([]*entity.TaskSearchResult, *value.SearchCursor, error)
+}
+
+type SearchService struct {
+	repository.ITaskSearchRepository
+	repository.ITaskRepository
+	IAuthorizationPolicy
+}
+
+func NewSearchService(searchRepo repository.ITaskSearchRepository, taskRepo repository.ITaskRepository, policy IAuthorizationPolicy) *SearchService {
+	return &SearchService{searchRepo, taskRepo, policy}
+}
+
+// 個人のタスクを検索し、順位の高い順に最大limit件取得する。検索の対象はGetTaskListで取得できるタスクと同じ
+// 続きの結果がある場合は次のページの位置を返す
+func (s *SearchService) SearchTasks(ctx context.Context, userID string, query string, limit int32, cursor *value.SearchCursor) ([]*entity.TaskSearchResult, *value.SearchCursor, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := validateSearch(query, limit); err != nil {
+		return nil, nil, err
+	}
+	// 1件多く取得して続きがあるかを判定する
+	results, err := s.ITaskSearchRepository.SearchTasksByUserID(ctx, userID, query, limit+1, cursor)
+	if err != nil {
+		return nil, nil, &domain.ErrQueryFailed{}
+	}
+	return s.page(ctx, results, limit)
+}
+
+// ワークスペースの全てのリストのタスクを検索する。メンバーのみ検索できる
+func (s *SearchService) SearchWorkspaceTasks(ctx context.Context, workspaceID string, userID string, query string, limit int32, cursor *value.SearchCursor) ([]*entity.TaskSearchResult, *value.SearchCursor, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := validateSearch(query, limit); err != nil {
+		return nil, nil, err
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeWorkspace(ctx, workspaceID, userID, value.WorkspaceRoleMember); err != nil {
+		return nil, nil, err
+	}
+	results, err := s.ITaskSearchRepository.SearchTasksByWorkspaceID(ctx, workspaceID, query, limit+1, cursor)
+	if err != nil {
+		return nil, nil, &domain.ErrQueryFailed{}
+	}
+	return s.page(ctx, results, limit)
+}
+
+// 取得した結果をlimit件に切り詰め、続きがある場合は次のページの位置を返す
+func (s *SearchService) page(ctx context.Context, results []*entity.TaskSearchResult, limit int32) ([]*entity.TaskSearchResult, *value.SearchCursor, error) {
+	var next *value.SearchCursor
+	if int32(len(results)) > limit {
+		results = results[:limit]
+		last := results[limit-1]
+		next = &value.SearchCursor{Rank: last.Rank, ID: last.Task.ID.Value()}
+	}
+	tasks := make([]*entity.Task, len(results))
+	for i, v := range results {
+		tasks[i] = v.Task
+	}
+	if _, err := markBlocked(ctx, s.ITaskRepository, tasks); err != nil {
+		return nil, nil, err
+	}
+	return results, next, nil
+}
+
+func validateSearch(query string, limit int32) error {
+	if strings.TrimSpace(query) == "" {
+		return &domain.ErrValidationFailed{Msg: "query is empty"}
+	}
+	if limit <= 0 {
+		return &domain.ErrValidationFailed{Msg: "limit must be positive"}
+	}
+	return nil
+}
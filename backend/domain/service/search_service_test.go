@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchService_NewSearchService(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ ISearchService = (*SearchService)(nil)
+	})
+}
+
+func newSearchResults(now time.Time) []*entity.TaskSearchResult {
+	return []*entity.TaskSearchResult{
+		{Task: &entity.Task{ID: value.NewID("t1"), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Name: "buy milk", CreatedAt: now, UpdatedAt: now}, Rank: 0.6, NameHeadline: entity.HighlightStart + "buy" + entity.HighlightStop + " milk"},
+		{Task: &entity.Task{ID: value.NewID("t2"), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Name: "milk", Description: "buy at the shop", CreatedAt: now, UpdatedAt: now}, Rank: 0.3, DescriptionHeadline: entity.HighlightStart + "buy" + entity.HighlightStop + " at the shop"},
+		{Task: &entity.Task{ID: value.NewID("t3"), UserID: value.NewID("uid"), ListID: value.NewID("lid"), Name: "buy bread", CreatedAt: now, UpdatedAt: now}, Rank: 0.3},
+	}
+}
+
+func TestSearchService_SearchTasks(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	now := time.Now().UTC()
+
+	tt.Run("正常系: 続きがない場合はカーソルを返さないこと", func(t *testing.T) {
+		results := newSearchResults(now)
+		sr := new(mocks.ITaskSearchRepository)
+		sr.On("SearchTasksByUserID", ctx, uid, "buy", int32(4), (*value.SearchCursor)(nil)).Return(results, nil)
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindBlockedTaskIDs", ctx, []string{"t1", "t2", "t3"}).Return([]string{"t2"}, nil)
+		srv := NewSearchService(sr, repo, newUnsharedPolicy())
+		res, next, err := srv.SearchTasks(ctx, uid, "buy", 3, nil)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, results, res, "検索結果が一致すること")
+		require.Nil(t, next, "カーソルがないこと")
+		require.True(t, res[1].Task.IsBlocked, "ブロックされたタスクに印が付くこと")
+		require.False(t, res[0].Task.IsBlocked, "ブロックされていないタスクには印が付かないこと")
+		sr.AssertExpectations(t)
+		repo.AssertExpectations(t)
+	})
+	tt.Run("正常系: 続きがある場合は最後の結果のカーソルを返すこと", func(t *testing.T) {
+		results := newSearchResults(now)
+		cursor := &value.SearchCursor{Rank: 0.9, ID: "t0"}
+		sr := new(mocks.ITaskSearchRepository)
+		sr.On("SearchTasksByUserID", ctx, uid, "buy", int32(3), cursor).Return(results, nil)
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindBlockedTaskIDs", ctx, []string{"t1", "t2"}).Return([]string{}, nil)
+		srv := NewSearchService(sr, repo, newUnsharedPolicy())
+		res, next, err := srv.SearchTasks(ctx, uid, "buy", 2, cursor)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, results[:2], res, "検索結果が一致すること")
+		require.Equal(t, &value.SearchCursor{Rank: 0.3, ID: "t2"}, next, "カーソルが一致すること")
+		sr.AssertExpectations(t)
+		repo.AssertExpectations(t)
+	})
+	tt.Run("正常系: 一致するタスクがない場合", func(t *testing.T) {
+		sr := new(mocks.ITaskSearchRepository)
+		sr.On("SearchTasksByUserID", ctx, uid, "nothing", int32(4), (*value.SearchCursor)(nil)).Return([]*entity.TaskSearchResult{}, nil)
+		repo := new(mocks.ITaskRepository)
+		srv := NewSearchService(sr, repo, newUnsharedPolicy())
+		res, next, err := srv.SearchTasks(ctx, uid, "nothing", 3, nil)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Empty(t, res, "検索結果が空であること")
+		require.Nil(t, next, "カーソルがないこと")
+		sr.AssertExpectations(t)
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 検索語が空白のみの場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "query is empty"}
+		sr := new(mocks.ITaskSearchRepository)
+		srv := NewSearchService(sr, new(mocks.ITaskRepository), newUnsharedPolicy())
+		_, _, err := srv.SearchTasks(ctx, uid, "  ", 3, nil)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		sr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 件数が0以下の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "limit must be positive"}
+		sr := new(mocks.ITaskSearchRepository)
+		srv := NewSearchService(sr, new(mocks.ITaskRepository), newUnsharedPolicy())
+		_, _, err := srv.SearchTasks(ctx, uid, "buy", 0, nil)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		sr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 検索に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		sr := new(mocks.ITaskSearchRepository)
+		sr.On("SearchTasksByUserID", ctx, uid, "buy", int32(4), (*value.SearchCursor)(nil)).Return(nil, errExp)
+		srv := NewSearchService(sr, new(mocks.ITaskRepository), newUnsharedPolicy())
+		_, _, err := srv.SearchTasks(ctx, uid, "buy", 3, nil)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		sr.AssertExpectations(t)
+	})
+}
+
+func TestSearchService_SearchWorkspaceTasks(tt *testing.T) {
+	ctx := context.Background()
+	wid := "wid"
+	uid := "uid"
+	now := time.Now().UTC()
+
+	tt.Run("正常系: メンバーの場合", func(t *testing.T) {
+		results := newSearchResults(now)
+		sr := new(mocks.ITaskSearchRepository)
+		sr.On("SearchTasksByWorkspaceID", ctx, wid, "buy", int32(4), (*value.SearchCursor)(nil)).Return(results, nil)
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindBlockedTaskIDs", ctx, []string{"t1", "t2", "t3"}).Return([]string{}, nil)
+		srv := NewSearchService(sr, repo, newWorkspacePolicy(value.WorkspaceRoleMember))
+		res, next, err := srv.SearchWorkspaceTasks(ctx, wid, uid, "buy", 3, nil)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, results, res, "検索結果が一致すること")
+		require.Nil(t, next, "カーソルがないこと")
+		sr.AssertExpectations(t)
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: メンバーではない場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		sr := new(mocks.ITaskSearchRepository)
+		srv := NewSearchService(sr, new(mocks.ITaskRepository), newWorkspacePolicy(value.WorkspaceRoleNone))
+		_, _, err := srv.SearchWorkspaceTasks(ctx, wid, uid, "buy", 3, nil)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		sr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 検索語が空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "query is empty"}
+		sr := new(mocks.ITaskSearchRepository)
+		srv := NewSearchService(sr, new(mocks.ITaskRepository), newWorkspacePolicy(value.WorkspaceRoleMember))
+		_, _, err := srv.SearchWorkspaceTasks(ctx, wid, uid, "", 3, nil)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		sr.AssertExpectations(t)
+	})
+}
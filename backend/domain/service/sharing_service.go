@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/domain/repository"
+	"github.com/7oh2020/connect-tasklist/backend/util/clock"
+	"github.com/7oh2020/connect-tasklist/backend/util/identification"
+)
+
+// タスクとリストの共有のドメインロジック
+// 管理者以上は閲覧者と編集者の権限を付与、変更、取り消しでき、管理者の権限は所有者のみが付与、変更、取り消しできる
+type ISharingService interface {
+	FindTaskCollaborators(ctx context.Context, taskID string, userID string) ([]*entity.Collaborator, error)
+	FindListCollaborators(ctx context.Context, listID string, userID string) ([]*entity.Collaborator, error)
+	GrantTaskAccess(ctx context.Context, taskID string, userID string, email string, role value.Role) error
+	GrantListAccess(ctx context.Context, listID string, userID string, email string, role value.Role) error
+	RevokeTaskAccess(ctx context.Context, taskID string, userID string, collaboratorID string) error
+	RevokeListAccess(ctx context.Context, listID string, userID string, collaboratorID string) error
+}
+
+type SharingService struct {
+	repository.ICollaboratorRepository
+	repository.ITaskRepository
+	repository.IListRepository
+	repository.IUserRepository
+	IAuthorizationPolicy
+	identification.IIDManager
+	clock.IClockManager
+}
+
+func NewSharingService(collaboratorRepo repository.ICollaboratorRepository, taskRepo repository.ITaskRepository, listRepo repository.IListRepository, userRepo repository.IUserRepository, policy IAuthorizationPolicy, idManager identification.IIDManager, clockManager clock.IClockManager) *SharingService {
+	return &SharingService{collaboratorRepo, taskRepo, listRepo, userRepo, policy, idManager, clockManager}
+}
+
+// 共有の対象となるタスクまたはリスト
+type sharedResource struct {
+	taskID *value.ID
+	listID *value.ID
+	// 所有者のID
+	ownerID *value.ID
+	// 操作するユーザーの権限
+	role value.Role
+}
+
+// タスクを共有したユーザーを古い順に取得する
+func (s *SharingService) FindTaskCollaborators(ctx context.Context, taskID string, userID string) ([]*entity.Collaborator, error) {
+	res, err := s.findTask(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.findCollaborators(ctx, res)
+}
+
+// リストを共有したユーザーを古い順に取得する
+func (s *SharingService) FindListCollaborators(ctx context.Context, listID string, userID string) ([]*entity.Collaborator, error) {
+	res, err := s.findList(ctx, listID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.findCollaborators(ctx, res)
+}
+
+// メールアドレスのユーザーにタスクを共有する。既に共有している場合は権限を変更する
+func (s *SharingService) GrantTaskAccess(ctx context.Context, taskID string, userID string, email string, role value.Role) error {
+	res, err := s.findTask(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+	return s.grant(ctx, res, userID, email, role)
+}
+
+// メールアドレスのユーザーにリストを共有する。既に共有している場合は権限を変更する
+func (s *SharingService) GrantListAccess(ctx context.Context, listID string, userID string, email string, role value.Role) error {
+	res, err := s.findList(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+	return s.grant(ctx, res, userID, email, role)
+}
+
+// タスクの共有を取り消す。共有されたユーザー自身も取り消すことができる
+func (s *SharingService) RevokeTaskAccess(ctx context.Context, taskID string, userID string, collaboratorID string) error {
+	res, err := s.findTask(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+	return s.revoke(ctx, res, userID, collaboratorID)
+}
+
+// リストの共有を取り消す。共有されたユーザー自身も取り消すことができる
+func (s *SharingService) RevokeListAccess(ctx context.Context, listID string, userID string, collaboratorID string) error {
+	res, err := s.findList(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+	return s.revoke(ctx, res, userID, collaboratorID)
+}
+
+// タスクを取得し、閲覧以上の権限を持つことを検証する。ゴミ箱のタスクは存在しないものとして扱う
+func (s *SharingService) findTask(ctx context.Context, taskID string, userID string) (*sharedResource, error) {
+	if err := value.NewID(taskID).Validate(); err != nil {
+		return nil, err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	task, err := s.ITaskRepository.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, &domain.ErrNotFound{Msg: "task not found"}
+	}
+	role, err := s.IAuthorizationPolicy.TaskRole(ctx, task, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !role.Includes(value.RoleViewer) {
+		return nil, &domain.ErrPermissionDenied{}
+	}
+	return &sharedResource{taskID: task.ID, ownerID: task.UserID, role: role}, nil
+}
+
+// リストを取得し、閲覧以上の権限を持つことを検証する
+func (s *SharingService) findList(ctx context.Context, listID string, userID string) (*sharedResource, error) {
+	if err := value.NewID(listID).Validate(); err != nil {
+		return nil, err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	list, err := s.IListRepository.FindListByID(ctx, listID)
+	if err != nil {
+		return nil, &domain.ErrNotFound{Msg: "list not found"}
+	}
+	role, err := s.IAuthorizationPolicy.ListRole(ctx, list, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !role.Includes(value.RoleViewer) {
+		return nil, &domain.ErrPermissionDenied{}
+	}
+	return &sharedResource{listID: list.ID, ownerID: list.UserID, role: role}, nil
+}
+
+func (s *SharingService) findCollaborators(ctx context.Context, res *sharedResource) ([]*entity.Collaborator, error) {
+	var collaborators []*entity.Collaborator
+	var err error
+	if res.taskID != nil {
+		collaborators, err = s.ICollaboratorRepository.FindCollaboratorsByTaskID(ctx, res.taskID.Value())
+	} else {
+		collaborators, err = s.ICollaboratorRepository.FindCollaboratorsByListID(ctx, res.listID.Value())
+	}
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return collaborators, nil
+}
+
+// 共有されたユーザーを取得する。共有されていない場合はnilを返す
+func (s *SharingService) findCollaborator(ctx context.Context, res *sharedResource, collaboratorID string) *entity.Collaborator {
+	var collaborator *entity.Collaborator
+	var err error
+	if res.taskID != nil {
+		collaborator, err = s.ICollaboratorRepository.FindTaskCollaborator(ctx, res.taskID.Value(), collaboratorID)
+	} else {
+		collaborator, err = s.ICollaboratorRepository.FindListCollaborator(ctx, res.listID.Value(), collaboratorID)
+	}
+	if err != nil {
+		return nil
+	}
+	return collaborator
+}
+
+func (s *SharingService) grant(ctx context.Context, res *sharedResource, userID string, email string, role value.Role) error {
+	if err := role.Validate(); err != nil {
+		return err
+	}
+	if !res.role.Includes(value.RoleAdmin) {
+		return &domain.ErrPermissionDenied{}
+	}
+	// 管理者の権限は所有者のみが付与できる
+	if role == value.RoleAdmin && res.role != value.RoleOwner {
+		return &domain.ErrPermissionDenied{}
+	}
+	if err := value.NewEmail(email).Validate(); err != nil {
+		return err
+	}
+	user, err := s.IUserRepository.FindUserByEmail(ctx, email)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "user not found"}
+	}
+	if res.ownerID.Equal(user.ID.Value()) {
+		return &domain.ErrValidationFailed{Msg: "cannot share with the owner"}
+	}
+	if user.ID.Equal(userID) {
+		return &domain.ErrValidationFailed{Msg: "cannot change own role"}
+	}
+	now := s.IClockManager.GetNow()
+	if current := s.findCollaborator(ctx, res, user.ID.Value()); current != nil {
+		// 管理者の権限は所有者のみが変更できる
+		if current.Role == value.RoleAdmin && res.role != value.RoleOwner {
+			return &domain.ErrPermissionDenied{}
+		}
+		if current.Role == role {
+			return nil
+		}
+		current.Role = role
+		current.GrantedBy = value.NewID(userID)
+		current.UpdatedAt = now
+		if err := current.Validate(); err != nil {
+			return err
+		}
+		if err := s.ICollaboratorRepository.UpdateCollaborator(ctx, current); err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		return nil
+	}
+	arg := &entity.Collaborator{
+		ID:        value.NewID(s.IIDManager.GenerateID()),
+		TaskID:    res.taskID,
+		ListID:    res.listID,
+		UserID:    user.ID,
+		Role:      role,
+		GrantedBy: value.NewID(userID),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := arg.Validate(); err != nil {
+		return err
+	}
+	if _, err := s.ICollaboratorRepository.CreateCollaborator(ctx, arg); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+func (s *SharingService) revoke(ctx context.Context, res *sharedResource, userID string, collaboratorID string) error {
+	if err := value.NewID(collaboratorID).Validate(); err != nil {
+		return err
+	}
+	current := s.findCollaborator(ctx, res, collaboratorID)
+	if current == nil {
+		return &domain.ErrNotFound{Msg: "collaborator not found"}
+	}
+	if collaboratorID != userID {
+		if !res.role.Includes(value.RoleAdmin) {
+			return &domain.ErrPermissionDenied{}
+		}
+		// 管理者の権限は所有者のみが取り消せる
+		if current.Role == value.RoleAdmin && res.role != value.RoleOwner {
+			return &domain.ErrPermissionDenied{}
+		}
+	}
+	if err := s.ICollaboratorRepository.DeleteCollaborator(ctx, current.ID.Value()); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharingService_NewSharingService(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ ISharingService = (*SharingService)(nil)
+	})
+}
+
+func TestSharingService_FindTaskCollaborators(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	task := &entity.Task{ID: value.NewID("tid"), UserID: value.NewID("owner"), ListID: value.NewID("lid"), Name: "task", CreatedAt: now, UpdatedAt: now}
+	collaborators := []*entity.Collaborator{
+		{ID: value.NewID("cid"), TaskID: value.NewID("tid"), UserID: value.NewID("uid"), Email: "uid@example.com", Role: value.RoleViewer, GrantedBy: value.NewID("owner"), CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: 共有されたユーザーは共有相手の一覧を取得できること", func(t *testing.T) {
+		cr := new(mocks.ICollaboratorRepository)
+		cr.On("FindTaskRole", ctx, "tid", "uid").Return(value.RoleViewer, nil)
+		cr.On("FindCollaboratorsByTaskID", ctx, "tid").Return(collaborators, nil)
+		tr := new(mocks.ITaskRepository)
+		tr.On("FindTaskByID", ctx, "tid").Return(task, nil)
+		srv := NewSharingService(cr, tr, new(mocks.IListRepository), new(mocks.IUserRepository), NewAuthorizationPolicy(cr, new(mocks.IWorkspaceRepository)), new(mocks.IIDManager), new(mocks.IClockManager))
+		ret, err := srv.FindTaskCollaborators(ctx, "tid", "uid")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, collaborators, ret)
+		cr.AssertExpectations(t)
+		tr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 共有されていないユーザーは取得できないこと", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		cr := new(mocks.ICollaboratorRepository)
+		cr.On("FindTaskRole", ctx, "tid", "other").Return(value.RoleNone, nil)
+		tr := new(mocks.ITaskRepository)
+		tr.On("FindTaskByID", ctx, "tid").Return(task, nil)
+		srv := NewSharingService(cr, tr, new(mocks.IListRepository), new(mocks.IUserRepository), NewAuthorizationPolicy(cr, new(mocks.IWorkspaceRepository)), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindTaskCollaborators(ctx, "tid", "other")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		cr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: タスクが存在しない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		tr := new(mocks.ITaskRepository)
+		tr.On("FindTaskByID", ctx, "tid").Return(nil, errors.New("not found"))
+		cr := new(mocks.ICollaboratorRepository)
+		srv := NewSharingService(cr, tr, new(mocks.IListRepository), new(mocks.IUserRepository), NewAuthorizationPolicy(cr, new(mocks.IWorkspaceRepository)), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindTaskCollaborators(ctx, "tid", "uid")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		tr.AssertExpectations(t)
+	})
+}
+
+func TestSharingService_FindListCollaborators(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	list := &entity.List{ID: value.NewID("lid"), UserID: value.NewID("owner"), Name: "list", CreatedAt: now, UpdatedAt: now}
+	collaborators := []*entity.Collaborator{
+		{ID: value.NewID("cid"), ListID: value.NewID("lid"), UserID: value.NewID("uid"), Email: "uid@example.com", Role: value.RoleEditor, GrantedBy: value.NewID("owner"), CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: 所有者は共有相手の一覧を取得できること", func(t *testing.T) {
+		cr := new(mocks.ICollaboratorRepository)
+		cr.On("FindCollaboratorsByListID", ctx, "lid").Return(collaborators, nil)
+		lr := new(mocks.IListRepository)
+		lr.On("FindListByID", ctx, "lid").Return(list, nil)
+		srv := NewSharingService(cr, new(mocks.ITaskRepository), lr, new(mocks.IUserRepository), NewAuthorizationPolicy(cr, new(mocks.IWorkspaceRepository)), new(mocks.IIDManager), new(mocks.IClockManager))
+		ret, err := srv.FindListCollaborators(ctx, "lid", "owner")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, collaborators, ret)
+		cr.AssertExpectations(t)
+		lr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 取得に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		cr := new(mocks.ICollaboratorRepository)
+		cr.On("FindCollaboratorsByListID", ctx, "lid").Return(nil, errors.New("error"))
+		lr := new(mocks.IListRepository)
+		lr.On("FindListByID", ctx, "lid").Return(list, nil)
+		srv := NewSharingService(cr, new(mocks.ITaskRepository), lr, new(mocks.IUserRepository), NewAuthorizationPolicy(cr, new(mocks.IWorkspaceRepository)), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindListCollaborators(ctx, "lid", "owner")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		cr.AssertExpectations(t)
+	})
+}
+
+func TestSharingService_GrantTaskAccess(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	upd := now.Add(time.Second)
+	task := &entity.Task{ID: value.NewID("tid"), UserID: value.NewID("owner"), ListID: value.NewID("lid"), Name: "task", CreatedAt: now, UpdatedAt: now}
+	target := &entity.User{ID: value.NewID("target"), Email: value.NewEmail("target@example.com")}
+
+	tt.Run("正常系: 所有者が新しく共有する場合", func(t *testing.T) {
+		cr := new(mocks.ICollaboratorRepository)
+		cr.On("FindTaskCollaborator", ctx, "tid", "target").Return(nil, errors.New("not found"))
+		cr.On("CreateCollaborator", ctx, &entity.Collaborator{ID: value.NewID("cid"), TaskID: task.ID, UserID: target.ID, Role: value.RoleAdmin, GrantedBy: value.NewID("owner"), CreatedAt: upd, UpdatedAt: upd}).Return("cid", nil)
+		tr := new(mocks.ITaskRepository)
+		tr.On("FindTaskByID", ctx, "tid").Return(task, nil)
+		ur := new(mocks.IUserRepository)
+		ur.On("FindUserByEmail", ctx, "target@example.com").Return(target, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("cid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewSharingService(cr, tr, new(mocks.IListRepository), ur, NewAuthorizationPolicy(cr, new(mocks.IWorkspaceRepository)), im, cm)
+		err := srv.GrantTaskAccess(ctx, "tid", "owner", "target@example.com", value.RoleAdmin)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		cr.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 管理者が既存の共有の権限を変更する場合", func(t *testing.T) {
+		current := &entity.Collaborator{ID: value.NewID("cid"), TaskID: task.ID, UserID: target.ID, Role: value.RoleViewer, GrantedBy: value.NewID("owner"), CreatedAt: now, UpdatedAt: now}
+		cr := new(mocks.ICollaboratorRepository)
+		cr.On("FindTaskRole", ctx, "tid", "admin").Return(value.RoleAdmin, nil)
+		cr.On("FindTaskCollaborator", ctx, "tid", "target").Return(current, nil)
+		cr.On("UpdateCollaborator", ctx, &entity.Collaborator{ID: value.NewID("cid"), TaskID: task.ID, UserID: target.ID, Role: value.RoleEditor, GrantedBy: value.NewID("admin"), CreatedAt: now, UpdatedAt: upd}).Return(nil)
+		tr := new(mocks.ITaskRepository)
+		tr.On("FindTaskByID", ctx, "tid").Return(task, nil)
+		ur := new(mocks.IUserRepository)
+		ur.On("FindUserByEmail", ctx, "target@example.com").Return(target, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewSharingService(cr, tr, new(mocks.IListRepository), ur, NewAuthorizationPolicy(cr, new(mocks.IWorkspaceRepository)), new(mocks.IIDManager), cm)
+		err := srv.GrantTaskAccess(ctx, "tid", "admin", "target@example.com", value.RoleEditor)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		cr.AssertExpectations(t)
+	})
+	tt.Run("正常系: 同じ権限で共有している場合は何もしないこと", func(t *testing.T) {
+		current := &entity.Collaborator{ID: value.NewID("cid"), TaskID: task.ID, UserID: target.ID, Role: value.RoleEditor, GrantedBy: value.NewID("owner"), CreatedAt: now, UpdatedAt: now}
+		cr := new(mocks.ICollaboratorRepository)
+		cr.On("FindTaskCollaborator", ctx, "tid", "target").Return(current, nil)
+		tr := new(mocks.ITaskRepository)
+		tr.On("FindTaskByID", ctx, "tid").Return(task, nil)
+		ur := new(mocks.IUserRepository)
+		ur.On("FindUserByEmail", ctx, "target@example.com").Return(target, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewSharingService(cr, tr, new(mocks.IListRepository), ur, NewAuthorizationPolicy(cr, new(mocks.IWorkspaceRepository)), new(mocks.IIDManager), cm)
+		err := srv.GrantTaskAccess(ctx, "tid", "owner", "target@example.com", value.RoleEditor)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		cr.AssertExpectations(t)
+	})
+
+	testcases := []struct {
+		title   string
+		userID  string
+		actor   value.Role
+		email   string
+		role    value.Role
+		user    *entity.User
+		current *entity.Collaborator
+		err     error
+	}{
+		{"準正常系: 権限が不正な場合", "owner", value.RoleNone, "target@example.com", value.RoleOwner, nil, nil, &domain.ErrValidationFailed{Msg: "invalid role"}},
+		{"準正常系: 編集者が共有する場合", "editor", value.RoleEditor, "target@example.com", value.RoleViewer, nil, nil, &domain.ErrPermissionDenied{}},
+		{"準正常系: 管理者が管理者の権限を付与する場合", "admin", value.RoleAdmin, "target@example.com", value.RoleAdmin, nil, nil, &domain.ErrPermissionDenied{}},
+		{"準正常系: メールアドレスが空の場合", "owner", value.RoleNone, "", value.RoleViewer, nil, nil, &domain.ErrValidationFailed{Msg: "email is empty"}},
+		{"準正常系: ユーザーが存在しない場合", "owner", value.RoleNone, "target@example.com", value.RoleViewer, nil, nil, &domain.ErrNotFound{Msg: "user not found"}},
+		{"準正常系: 所有者に共有する場合", "admin", value.RoleAdmin, "owner@example.com", value.RoleViewer, &entity.User{ID: value.NewID("owner"), Email: value.NewEmail("owner@example.com")}, nil, &domain.ErrValidationFailed{Msg: "cannot share with the owner"}},
+		{"準正常系: 自分の権限を変更する場合", "admin", value.RoleAdmin, "admin@example.com", value.RoleViewer, &entity.User{ID: value.NewID("admin"), Email: value.NewEmail("admin@example.com")}, nil, &domain.ErrValidationFailed{Msg: "cannot change own role"}},
+		{"準正常系: 管理者が他の管理者の権限を変更する場合", "admin", value.RoleAdmin, "target@example.com", value.RoleViewer, target, &entity.Collaborator{ID: value.NewID("cid"), TaskID: task.ID, UserID: target.ID, Role: value.RoleAdmin, GrantedBy: value.NewID("owner"), CreatedAt: now, UpdatedAt: now}, &domain.ErrPermissionDenied{}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			cr := new(mocks.ICollaboratorRepository)
+			if v.userID != "owner" {
+				cr.On("FindTaskRole", ctx, "tid", v.userID).Return(v.actor, nil)
+			}
+			if v.current != nil {
+				cr.On("FindTaskCollaborator", ctx, "tid", v.user.ID.Value()).Return(v.current, nil)
+			}
+			tr := new(mocks.ITaskRepository)
+			tr.On("FindTaskByID", ctx, "tid").Return(task, nil)
+			ur := new(mocks.IUserRepository)
+			if v.user != nil {
+				ur.On("FindUserByEmail", ctx, v.email).Return(v.user, nil)
+			} else {
+				ur.On("FindUserByEmail", ctx, v.email).Return(nil, errors.New("not found")).Maybe()
+			}
+			cm := new(mocks.IClockManager)
+			cm.On("GetNow").Return(upd).Maybe()
+			srv := NewSharingService(cr, tr, new(mocks.IListRepository), ur, NewAuthorizationPolicy(cr, new(mocks.IWorkspaceRepository)), new(mocks.IIDManager), cm)
+			err := srv.GrantTaskAccess(ctx, "tid", v.userID, v.email, v.role)
+
+			require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSharingService_GrantListAccess(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	list := &entity.List{ID: value.NewID("lid"), UserID: value.NewID("owner"), Name: "list", CreatedAt: now, UpdatedAt: now}
+	target := &entity.User{ID: value.NewID("target"), Email: value.NewEmail("target@example.com")}
+
+	tt.Run("正常系: リストを共有する場合", func(t *testing.T) {
+		cr := new(mocks.ICollaboratorRepository)
+		cr.On("FindListCollaborator", ctx, "lid", "target").Return(nil, errors.New("not found"))
+		cr.On("CreateCollaborator", ctx, &entity.Collaborator{ID: value.NewID("cid"), ListID: list.ID, UserID: target.ID, Role: value.RoleEditor, GrantedBy: value.NewID("owner"), CreatedAt: now, UpdatedAt: now}).Return("cid", nil)
+		lr := new(mocks.IListRepository)
+		lr.On("FindListByID", ctx, "lid").Return(list, nil)
+		ur := new(mocks.IUserRepository)
+		ur.On("FindUserByEmail", ctx, "target@example.com").Return(target, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("cid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewSharingService(cr, new(mocks.ITaskRepository), lr, ur, NewAuthorizationPolicy(cr, new(mocks.IWorkspaceRepository)), im, cm)
+		err := srv.GrantListAccess(ctx, "lid", "owner", "target@example.com", value.RoleEditor)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		cr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 保存に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		cr := new(mocks.ICollaboratorRepository)
+		cr.On("FindListCollaborator", ctx, "lid", "target").Return(nil, errors.New("not found"))
+		cr.On("CreateCollaborator", ctx, &entity.Collaborator{ID: value.NewID("cid"), ListID: list.ID, UserID: target.ID, Role: value.RoleEditor, GrantedBy: value.NewID("owner"), CreatedAt: now, UpdatedAt: now}).Return("", errors.New("error"))
+		lr := new(mocks.IListRepository)
+		lr.On("FindListByID", ctx, "lid").Return(list, nil)
+		ur := new(mocks.IUserRepository)
+		ur.On("FindUserByEmail", ctx, "target@example.com").Return(target, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("cid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewSharingService(cr, new(mocks.ITaskRepository), lr, ur, NewAuthorizationPolicy(cr, new(mocks.IWorkspaceRepository)), im, cm)
+		err := srv.GrantListAccess(ctx, "lid", "owner", "target@example.com", value.RoleEditor)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		cr.AssertExpectations(t)
+	})
+}
+
+func TestSharingService_RevokeTaskAccess(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	task := &entity.Task{ID: value.NewID("tid"), UserID: value.NewID("owner"), ListID: value.NewID("lid"), Name: "task", CreatedAt: now, UpdatedAt: now}
+	newCollaborator := func(userID string, role value.Role) *entity.Collaborator {
+		return &entity.Collaborator{ID: value.NewID("cid"), TaskID: task.ID, UserID: value.NewID(userID), Role: role, GrantedBy: value.NewID("owner"), CreatedAt: now, UpdatedAt: now}
+	}
+
+	testcases := []struct {
+		title   string
+		userID  string
+		actor   value.Role
+		target  string
+		current *entity.Collaborator
+		err     error
+	}{
+		{"正常系: 所有者が管理者の共有を取り消す場合", "owner", value.RoleNone, "target", newCollaborator("target", value.RoleAdmin), nil},
+		{"正常系: 管理者が編集者の共有を取り消す場合", "admin", value.RoleAdmin, "target", newCollaborator("target", value.RoleEditor), nil},
+		{"正常系: 閲覧者が自分の共有を取り消す場合", "target", value.RoleViewer, "target", newCollaborator("target", value.RoleViewer), nil},
+		{"準正常系: 共有されていない場合", "owner", value.RoleNone, "target", nil, &domain.ErrNotFound{Msg: "collaborator not found"}},
+		{"準正常系: 編集者が他のユーザーの共有を取り消す場合", "editor", value.RoleEditor, "target", newCollaborator("target", value.RoleViewer), &domain.ErrPermissionDenied{}},
+		{"準正常系: 管理者が他の管理者の共有を取り消す場合", "admin", value.RoleAdmin, "target", newCollaborator("target", value.RoleAdmin), &domain.ErrPermissionDenied{}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			cr := new(mocks.ICollaboratorRepository)
+			if v.userID != "owner" {
+				cr.On("FindTaskRole", ctx, "tid", v.userID).Return(v.actor, nil)
+			}
+			if v.current != nil {
+				cr.On("FindTaskCollaborator", ctx, "tid", v.target).Return(v.current, nil)
+			} else {
+				cr.On("FindTaskCollaborator", ctx, "tid", v.target).Return(nil, errors.New("not found"))
+			}
+			if v.err == nil {
+				cr.On("DeleteCollaborator", ctx, "cid").Return(nil)
+			}
+			tr := new(mocks.ITaskRepository)
+			tr.On("FindTaskByID", ctx, "tid").Return(task, nil)
+			srv := NewSharingService(cr, tr, new(mocks.IListRepository), new(mocks.IUserRepository), NewAuthorizationPolicy(cr, new(mocks.IWorkspaceRepository)), new(mocks.IIDManager), new(mocks.IClockManager))
+			err := srv.RevokeTaskAccess(ctx, "tid", v.userID, v.target)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSharingService_RevokeListAccess(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	list := &entity.List{ID: value.NewID("lid"), UserID: value.NewID("owner"), Name: "list", CreatedAt: now, UpdatedAt: now}
+
+	tt.Run("正常系: 所有者がリストの共有を取り消す場合", func(t *testing.T) {
+		cr := new(mocks.ICollaboratorRepository)
+		cr.On("FindListCollaborator", ctx, "lid", "target").Return(&entity.Collaborator{ID: value.NewID("cid"), ListID: list.ID, UserID: value.NewID("target"), Role: value.RoleViewer, GrantedBy: value.NewID("owner"), CreatedAt: now, UpdatedAt: now}, nil)
+		cr.On("DeleteCollaborator", ctx, "cid").Return(nil)
+		lr := new(mocks.IListRepository)
+		lr.On("FindListByID", ctx, "lid").Return(list, nil)
+		srv := NewSharingService(cr, new(mocks.ITaskRepository), lr, new(mocks.IUserRepository), NewAuthorizationPolicy(cr, new(mocks.IWorkspaceRepository)), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.RevokeListAccess(ctx, "lid", "owner", "target")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		cr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 削除に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		cr := new(mocks.ICollaboratorRepository)
+		cr.On("FindListCollaborator", ctx, "lid", "target").Return(&entity.Collaborator{ID: value.NewID("cid"), ListID: list.ID, UserID: value.NewID("target"), Role: value.RoleViewer, GrantedBy: value.NewID("owner"), CreatedAt: now, UpdatedAt: now}, nil)
+		cr.On("DeleteCollaborator", ctx, "cid").Return(errors.New("error"))
+		lr := new(mocks.IListRepository)
+		lr.On("FindListByID", ctx, "lid").Return(list, nil)
+		srv := NewSharingService(cr, new(mocks.ITaskRepository), lr, new(mocks.IUserRepository), NewAuthorizationPolicy(cr, new(mocks.IWorkspaceRepository)), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.RevokeListAccess(ctx, "lid", "owner", "target")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		cr.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/domain/repository"
+	"github.com/7oh2020/connect-tasklist/backend/util/clock"
+	"github.com/7oh2020/connect-tasklist/backend/util/identification"
+)
+
+// タグのドメインロジック
+type ITagService interface {
+	FindTagsByUserID(ctx context.Context, userID string) ([]*entity.Tag, error)
+	CreateTag(ctx context.Context, userID string, name string) (string, error)
+	RenameTag(ctx context.Context, id string, userID string, name string) error
+	DeleteTag(ctx context.Context, id string, userID string) error
+	AttachTag(ctx context.Context, taskID string, tagID string, userID string) error
+	DetachTag(ctx context.Context, taskID string, tagID string, userID string) error
+}
+
+type TagService struct {
+	repository.ITagRepository
+	repository.ITaskRepository
+	IAuthorizationPolicy
+	identification.IIDManager
+	clock.IClockManager
+}
+
+func NewTagService(tagRepo repository.ITagRepository, taskRepo repository.ITaskRepository, policy IAuthorizationPolicy, idManager identification.IIDManager, clockManager clock.IClockManager) *TagService {
+	return &TagService{tagRepo, taskRepo, policy, idManager, clockManager}
+}
+
+// 使用数を集計したタグの一覧を名前順に取得する
+func (s *TagService) FindTagsByUserID(ctx context.Context, userID string) ([]*entity.Tag, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	tags, err := s.ITagRepository.FindTagsByUserID(ctx, userID)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return tags, nil
+}
+
+func (s *TagService) CreateTag(ctx context.Context, userID string, name string) (string, error) {
+	now := s.IClockManager.GetNow()
+	arg := &entity.Tag{
+		ID:        value.NewID(s.IIDManager.GenerateID()),
+		UserID:    value.NewID(userID),
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	// タグ名はユーザーごとに一意
+	if _, err := s.ITagRepository.FindTagByUserIDAndName(ctx, userID, name); err == nil {
+		return "", &domain.ErrAlreadyExists{Msg: "tag already exists"}
+	}
+	createdID, err := s.ITagRepository.CreateTag(ctx, arg)
+	if err != nil {
+		return "", &domain.ErrQueryFailed{}
+	}
+	return createdID, nil
+}
+
+func (s *TagService) RenameTag(ctx context.Context, id string, userID string, name string) error {
+	tag, err := s.findOwnTag(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.Name == name {
+		return nil
+	}
+	tag.Name = name
+	tag.UpdatedAt = s.IClockManager.GetNow()
+	if err := tag.Validate(); err != nil {
+		return err
+	}
+	if _, err := s.ITagRepository.FindTagByUserIDAndName(ctx, userID, name); err == nil {
+		return &domain.ErrAlreadyExists{Msg: "tag already exists"}
+	}
+	if err := s.ITagRepository.UpdateTag(ctx, tag); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+// タグを削除する。タスクへのタグ付けも同時に削除される
+func (s *TagService) DeleteTag(ctx context.Context, id string, userID string) error {
+	if _, err := s.findOwnTag(ctx, id, userID); err != nil {
+		return err
+	}
+	if err := s.ITagRepository.DeleteTag(ctx, id); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+func (s *TagService) AttachTag(ctx context.Context, taskID string, tagID string, userID string) error {
+	if err := s.checkTaskAndTag(ctx, taskID, tagID, userID); err != nil {
+		return err
+	}
+	if err := s.ITagRepository.AttachTag(ctx, taskID, tagID, s.IClockManager.GetNow()); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+func (s *TagService) DetachTag(ctx context.Context, taskID string, tagID string, userID string) error {
+	if err := s.checkTaskAndTag(ctx, taskID, tagID, userID); err != nil {
+		return err
+	}
+	if err := s.ITagRepository.DetachTag(ctx, taskID, tagID); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+// 自分が所有するタグを取得する
+func (s *TagService) findOwnTag(ctx context.Context, id string, userID string) (*entity.Tag, error) {
+	if err := value.NewID(id).Validate(); err != nil {
+		return nil, err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	tag, err := s.ITagRepository.FindTagByID(ctx, id)
+	if err != nil {
+		return nil, &domain.ErrNotFound{Msg: "tag not found"}
+	}
+	if !tag.UserID.Equal(userID) {
+		return nil, &domain.ErrPermissionDenied{}
+	}
+	return tag, nil
+}
+
+// タスクとタグの両方を自分が所有しているか検証する。タグは個人のものであるため共有されたタスクには付けられない
+func (s *TagService) checkTaskAndTag(ctx context.Context, taskID string, tagID string, userID string) error {
+	if err := value.NewID(taskID).Validate(); err != nil {
+		return err
+	}
+	task, err := s.ITaskRepository.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "task not found"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleOwner); err != nil {
+		return err
+	}
+	if _, err := s.findOwnTag(ctx, tagID, userID); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,433 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagService_NewTagService(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ ITagService = (*TagService)(nil)
+	})
+}
+
+func TestTagService_FindTagsByUserID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	tags := []*entity.Tag{
+		{ID: value.NewID("g1"), UserID: value.NewID(uid), Name: "home", CreatedAt: now, UpdatedAt: now, UsageCount: 2},
+		{ID: value.NewID("g2"), UserID: value.NewID(uid), Name: "work", CreatedAt: now, UpdatedAt: now, UsageCount: 0},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		tagRepo := new(mocks.ITagRepository)
+		tagRepo.On("FindTagsByUserID", ctx, uid).Return(tags, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		ret, err := srv.FindTagsByUserID(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tags, ret)
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: UserIDが空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		tagRepo := new(mocks.ITagRepository)
+		taskRepo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		_, err := srv.FindTagsByUserID(ctx, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		tagRepo := new(mocks.ITagRepository)
+		tagRepo.On("FindTagsByUserID", ctx, uid).Return(nil, errExp)
+		taskRepo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		_, err := srv.FindTagsByUserID(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+}
+
+func TestTagService_CreateTag(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	uid := "uid"
+	tag := &entity.Tag{ID: value.NewID(id), UserID: value.NewID(uid), Name: "work", CreatedAt: now, UpdatedAt: now}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		tagRepo := new(mocks.ITagRepository)
+		tagRepo.On("FindTagByUserIDAndName", ctx, uid, tag.Name).Return(nil, errors.New("no rows"))
+		tagRepo.On("CreateTag", ctx, tag).Return(id, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		ret, err := srv.CreateTag(ctx, uid, tag.Name)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, id, ret)
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 名前が空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "name is empty"}
+		tagRepo := new(mocks.ITagRepository)
+		taskRepo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		_, err := srv.CreateTag(ctx, uid, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 同じ名前のタグが存在する場合", func(t *testing.T) {
+		errExp := &domain.ErrAlreadyExists{Msg: "tag already exists"}
+		tagRepo := new(mocks.ITagRepository)
+		tagRepo.On("FindTagByUserIDAndName", ctx, uid, tag.Name).Return(tag, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		_, err := srv.CreateTag(ctx, uid, tag.Name)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		tagRepo := new(mocks.ITagRepository)
+		tagRepo.On("FindTagByUserIDAndName", ctx, uid, tag.Name).Return(nil, errors.New("no rows"))
+		tagRepo.On("CreateTag", ctx, tag).Return("", errExp)
+		taskRepo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		_, err := srv.CreateTag(ctx, uid, tag.Name)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+}
+
+func TestTagService_RenameTag(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	upd := now.Add(time.Second)
+	id := "id"
+	uid := "uid"
+	newTag := func() *entity.Tag {
+		return &entity.Tag{ID: value.NewID(id), UserID: value.NewID(uid), Name: "work", CreatedAt: now, UpdatedAt: now}
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		arg := newTag()
+		arg.Name = "office"
+		arg.UpdatedAt = upd
+		tagRepo := new(mocks.ITagRepository)
+		tagRepo.On("FindTagByID", ctx, id).Return(newTag(), nil)
+		tagRepo.On("FindTagByUserIDAndName", ctx, uid, "office").Return(nil, errors.New("no rows"))
+		tagRepo.On("UpdateTag", ctx, arg).Return(nil)
+		taskRepo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		err := srv.RenameTag(ctx, id, uid, "office")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("正常系: 名前が変わらない場合", func(t *testing.T) {
+		tagRepo := new(mocks.ITagRepository)
+		tagRepo.On("FindTagByID", ctx, id).Return(newTag(), nil)
+		taskRepo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		err := srv.RenameTag(ctx, id, uid, "work")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 同じ名前のタグが存在する場合", func(t *testing.T) {
+		errExp := &domain.ErrAlreadyExists{Msg: "tag already exists"}
+		other := &entity.Tag{ID: value.NewID("other"), UserID: value.NewID(uid), Name: "office", CreatedAt: now, UpdatedAt: now}
+		tagRepo := new(mocks.ITagRepository)
+		tagRepo.On("FindTagByID", ctx, id).Return(newTag(), nil)
+		tagRepo.On("FindTagByUserIDAndName", ctx, uid, "office").Return(other, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		err := srv.RenameTag(ctx, id, uid, "office")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないTagIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "tag not found"}
+		tagRepo := new(mocks.ITagRepository)
+		tagRepo.On("FindTagByID", ctx, "another").Return(nil, errExp)
+		taskRepo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		err := srv.RenameTag(ctx, "another", uid, "office")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: アクセス権がない場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		tagRepo := new(mocks.ITagRepository)
+		tagRepo.On("FindTagByID", ctx, id).Return(newTag(), nil)
+		taskRepo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		err := srv.RenameTag(ctx, id, "another", "office")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+}
+
+func TestTagService_DeleteTag(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	uid := "uid"
+	tag := &entity.Tag{ID: value.NewID(id), UserID: value.NewID(uid), Name: "work", CreatedAt: now, UpdatedAt: now}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		tagRepo := new(mocks.ITagRepository)
+		tagRepo.On("FindTagByID", ctx, id).Return(tag, nil)
+		tagRepo.On("DeleteTag", ctx, id).Return(nil)
+		taskRepo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		err := srv.DeleteTag(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: TagIDが空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		tagRepo := new(mocks.ITagRepository)
+		taskRepo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		err := srv.DeleteTag(ctx, "", uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: アクセス権がない場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		tagRepo := new(mocks.ITagRepository)
+		tagRepo.On("FindTagByID", ctx, id).Return(tag, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		err := srv.DeleteTag(ctx, id, "another")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		tagRepo := new(mocks.ITagRepository)
+		tagRepo.On("FindTagByID", ctx, id).Return(tag, nil)
+		tagRepo.On("DeleteTag", ctx, id).Return(errExp)
+		taskRepo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		err := srv.DeleteTag(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+}
+
+func TestTagService_AttachTag(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	tid := "tid"
+	gid := "gid"
+	uid := "uid"
+	task := &entity.Task{ID: value.NewID(tid), UserID: value.NewID(uid), Name: "task", CreatedAt: now, UpdatedAt: now}
+	tag := &entity.Tag{ID: value.NewID(gid), UserID: value.NewID(uid), Name: "work", CreatedAt: now, UpdatedAt: now}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		tagRepo := new(mocks.ITagRepository)
+		tagRepo.On("FindTagByID", ctx, gid).Return(tag, nil)
+		tagRepo.On("AttachTag", ctx, tid, gid, now).Return(nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		err := srv.AttachTag(ctx, tid, gid, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないTaskIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		tagRepo := new(mocks.ITagRepository)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, "another").Return(nil, errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		err := srv.AttachTag(ctx, "another", gid, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 他人のタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		tagRepo := new(mocks.ITagRepository)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		err := srv.AttachTag(ctx, tid, gid, "another")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 他人のタグの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		another := &entity.Tag{ID: value.NewID(gid), UserID: value.NewID("another"), Name: "work", CreatedAt: now, UpdatedAt: now}
+		tagRepo := new(mocks.ITagRepository)
+		tagRepo.On("FindTagByID", ctx, gid).Return(another, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		err := srv.AttachTag(ctx, tid, gid, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+}
+
+func TestTagService_DetachTag(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	tid := "tid"
+	gid := "gid"
+	uid := "uid"
+	task := &entity.Task{ID: value.NewID(tid), UserID: value.NewID(uid), Name: "task", CreatedAt: now, UpdatedAt: now}
+	tag := &entity.Tag{ID: value.NewID(gid), UserID: value.NewID(uid), Name: "work", CreatedAt: now, UpdatedAt: now}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		tagRepo := new(mocks.ITagRepository)
+		tagRepo.On("FindTagByID", ctx, gid).Return(tag, nil)
+		tagRepo.On("DetachTag", ctx, tid, gid).Return(nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		err := srv.DetachTag(ctx, tid, gid, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないTagIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "tag not found"}
+		tagRepo := new(mocks.ITagRepository)
+		tagRepo.On("FindTagByID", ctx, "another").Return(nil, errExp)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		err := srv.DetachTag(ctx, tid, "another", uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		tagRepo := new(mocks.ITagRepository)
+		tagRepo.On("FindTagByID", ctx, gid).Return(tag, nil)
+		tagRepo.On("DetachTag", ctx, tid, gid).Return(errExp)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTagService(tagRepo, taskRepo, newUnsharedPolicy(), im, cm)
+		err := srv.DetachTag(ctx, tid, gid, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+}
@@ -2,6 +2,10 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
 
 	"github.com/7oh2020/connect-tasklist/backend/domain"
 	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
@@ -15,21 +19,84 @@ import (
 type ITaskService interface {
 	FindTaskByID(ctx context.Context, id string) (*entity.Task, error)
 	FindTasksByUserID(ctx context.Context, userID string) ([]*entity.Task, error)
-	CreateTask(ctx context.Context, userID string, name string) (string, error)
+	FindTasksByUserIDOrderByPriority(ctx context.Context, userID string) ([]*entity.Task, error)
+	FindOverdueTasksByUserID(ctx context.Context, userID string) ([]*entity.Task, error)
+	FindTasksDueBetween(ctx context.Context, userID string, from time.Time, to time.Time) ([]*entity.Task, error)
+	FindTasksByUserIDOrderByPosition(ctx context.Context, userID string) ([]*entity.Task, error)
+	FindTaskPage(ctx context.Context, userID string, filter *value.TaskFilter, sort value.TaskSort, limit int32, cursor *value.TaskPageCursor) ([]*entity.Task, *value.TaskPageCursor, error)
+	FindTasksByListID(ctx context.Context, listID string, userID string, order value.TaskOrder) ([]*entity.Task, error)
+	FindTasksByUserIDAndTags(ctx context.Context, userID string, listID string, tagIDs []string, matchAll bool, order value.TaskOrder) ([]*entity.Task, error)
+	FindTasksByAssigneeID(ctx context.Context, userID string, order value.TaskOrder) ([]*entity.Task, error)
+	FindTasksByWorkspaceID(ctx context.Context, workspaceID string, userID string, order value.TaskOrder) ([]*entity.Task, error)
+	FindTaskTree(ctx context.Context, id string, userID string) ([]*entity.Task, error)
+	CreateTask(ctx context.Context, userID string, listID string, name string) (string, error)
+	CreateSubtask(ctx context.Context, userID string, parentID string, name string) (string, error)
+	MoveSubtask(ctx context.Context, id string, userID string, parentID string) error
+	MoveTaskToList(ctx context.Context, id string, userID string, listID string) error
+	MoveTask(ctx context.Context, id string, userID string, targetID string, after bool) error
+	RebalanceTaskPositions(ctx context.Context) error
 	ChangeTaskName(ctx context.Context, id string, userID string, name string) error
+	ChangeTaskPriority(ctx context.Context, id string, userID string, priority value.Priority) error
+	ChangeTaskDescription(ctx context.Context, id string, userID string, description string, descriptionHTML string) error
+	AssignTask(ctx context.Context, id string, userID string, assigneeID string) error
+	UnassignTask(ctx context.Context, id string, userID string) error
 	CompleteTask(ctx context.Context, id string, userID string) error
 	UncompleteTask(ctx context.Context, id string, userID string) error
+	TransitionTask(ctx context.Context, id string, userID string, status value.TaskStatus) error
+	SetTaskRecurrence(ctx context.Context, id string, userID string, rule value.RecurrenceRule, timezone string) error
+	ClearTaskRecurrence(ctx context.Context, id string, userID string) error
+	SetTaskDueDate(ctx context.Context, id string, userID string, dueAt time.Time) error
+	ClearTaskDueDate(ctx context.Context, id string, userID string) error
+	AddTaskDependency(ctx context.Context, id string, userID string, blockerID string) error
+	RemoveTaskDependency(ctx context.Context, id string, userID string, blockerID string) error
 	DeleteTask(ctx context.Context, id, userID string) error
+	BatchMutateTasks(ctx context.Context, userID string, ops []value.TaskOperation, atomic bool) ([]*entity.TaskOperationResult, error)
+	ImportTasks(ctx context.Context, userID string, listID string, rows []value.TaskImportRow, dryRun bool) ([]*entity.TaskImportResult, error)
+	FindTrashedTasksByUserID(ctx context.Context, userID string) ([]*entity.Task, error)
+	RestoreTask(ctx context.Context, id string, userID string) error
+	EmptyTrash(ctx context.Context, userID string) error
+	PurgeTrashedTasks(ctx context.Context, retention time.Duration) (int64, error)
+	FindTaskHistory(ctx context.Context, id string, userID string, limit int32, cursor *value.PageCursor) ([]*entity.TaskHistory, *value.PageCursor, error)
+	Undo(ctx context.Context, userID string) (*entity.TaskUndoEntry, error)
+	Redo(ctx context.Context, userID string) (*entity.TaskUndoEntry, error)
+}
+
+// 位置のキーがこの長さを超えたリストは再配置の対象になる
+const rebalanceRankLength = 32
+
+// ユーザーごとに取り消しまたはやり直しができる操作の最大数
+const undoStackLimit = 20
+
+// 操作を取り消しまたはやり直しができる期限
+const undoExpiry = time.Hour
+
+// 試行のインポートでトランザクションをロールバックするためのエラー
+var errImportDryRun = errors.New("dry run")
+
+// タスクの状態遷移表。キーの状態から値のいずれかの状態に遷移できる
+var taskStatusTransitions = map[value.TaskStatus][]value.TaskStatus{
+	value.TaskStatusTodo:       {value.TaskStatusInProgress, value.TaskStatusWaiting, value.TaskStatusDone, value.TaskStatusCancelled},
+	value.TaskStatusInProgress: {value.TaskStatusTodo, value.TaskStatusWaiting, value.TaskStatusDone, value.TaskStatusCancelled},
+	value.TaskStatusWaiting:    {value.TaskStatusTodo, value.TaskStatusInProgress, value.TaskStatusDone, value.TaskStatusCancelled},
+	// 完了したタスクは再開のみできる
+	value.TaskStatusDone: {value.TaskStatusTodo, value.TaskStatusInProgress},
+	// 中止したタスクは未着手に戻すことのみできる
+	value.TaskStatusCancelled: {value.TaskStatusTodo},
 }
 
 type TaskService struct {
 	repository.ITaskRepository
+	repository.IListRepository
+	repository.ITaskHistoryRepository
+	repository.ITaskUndoRepository
+	repository.ITransactionManager
+	IAuthorizationPolicy
 	identification.IIDManager
 	clock.IClockManager
 }
 
-func NewTaskService(repo repository.ITaskRepository, idManager identification.IIDManager, clockManager clock.IClockManager) *TaskService {
-	return &TaskService{repo, idManager, clockManager}
+func NewTaskService(repo repository.ITaskRepository, listRepo repository.IListRepository, historyRepo repository.ITaskHistoryRepository, undoRepo repository.ITaskUndoRepository, txManager repository.ITransactionManager, policy IAuthorizationPolicy, idManager identification.IIDManager, clockManager clock.IClockManager) *TaskService {
+	return &TaskService{repo, listRepo, historyRepo, undoRepo, txManager, policy, idManager, clockManager}
 }
 
 func (s *TaskService) FindTaskByID(ctx context.Context, id string) (*entity.Task, error) {
@@ -51,30 +118,276 @@ func (s *TaskService) FindTasksByUserID(ctx context.Context, userID string) ([]*
 	if err != nil {
 		return nil, &domain.ErrQueryFailed{}
 	}
-	return tasks, nil
+	return markBlocked(ctx, s.ITaskRepository, tasks)
+}
+
+// 優先度の高い順、更新日時の新しい順にタスクを取得する
+func (s *TaskService) FindTasksByUserIDOrderByPriority(ctx context.Context, userID string) ([]*entity.Task, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	tasks, err := s.ITaskRepository.FindTasksByUserIDOrderByPriority(ctx, userID)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return markBlocked(ctx, s.ITaskRepository, tasks)
+}
+
+// リストの表示順、リスト内の手動の並び順にタスクを取得する
+func (s *TaskService) FindTasksByUserIDOrderByPosition(ctx context.Context, userID string) ([]*entity.Task, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	tasks, err := s.ITaskRepository.FindTasksByUserIDOrderByPosition(ctx, userID)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return markBlocked(ctx, s.ITaskRepository, tasks)
+}
+
+// 個人のタスクを絞り込み、sortの順に最大limit件取得する。続きのタスクがある場合は次のページの位置を返す
+func (s *TaskService) FindTaskPage(ctx context.Context, userID string, filter *value.TaskFilter, sort value.TaskSort, limit int32, cursor *value.TaskPageCursor) ([]*entity.Task, *value.TaskPageCursor, error) {
+	return findTaskPage(ctx, s.ITaskRepository, userID, filter, sort, limit, cursor)
+}
+
+// 現在時刻の時点で期限切れとなっている未完了のタスクを取得する
+func (s *TaskService) FindOverdueTasksByUserID(ctx context.Context, userID string) ([]*entity.Task, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	tasks, err := s.ITaskRepository.FindOverdueTasksByUserID(ctx, userID, s.IClockManager.GetNow())
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return markBlocked(ctx, s.ITaskRepository, tasks)
+}
+
+// 期限がfrom以上to未満のタスクを取得する
+func (s *TaskService) FindTasksDueBetween(ctx context.Context, userID string, from time.Time, to time.Time) ([]*entity.Task, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	if !from.Before(to) {
+		return nil, &domain.ErrValidationFailed{Msg: "due range is invalid"}
+	}
+	tasks, err := s.ITaskRepository.FindTasksDueBetween(ctx, userID, from, to)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return markBlocked(ctx, s.ITaskRepository, tasks)
+}
+
+// 指定したリストのタスクを取得する
+func (s *TaskService) FindTasksByListID(ctx context.Context, listID string, userID string, order value.TaskOrder) ([]*entity.Task, error) {
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.findList(ctx, listID, userID, value.RoleViewer); err != nil {
+		return nil, err
+	}
+	tasks, err := s.ITaskRepository.FindTasksByListID(ctx, listID, order)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return markBlocked(ctx, s.ITaskRepository, tasks)
+}
+
+// 自分が担当するタスクを閲覧できる全てのリストから取得する
+func (s *TaskService) FindTasksByAssigneeID(ctx context.Context, userID string, order value.TaskOrder) ([]*entity.Task, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+	tasks, err := s.ITaskRepository.FindTasksByAssigneeID(ctx, userID, order)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return markBlocked(ctx, s.ITaskRepository, tasks)
+}
+
+// ワークスペースの全てのリストのタスクを取得する。メンバーのみ取得できる
+func (s *TaskService) FindTasksByWorkspaceID(ctx context.Context, workspaceID string, userID string, order value.TaskOrder) ([]*entity.Task, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeWorkspace(ctx, workspaceID, userID, value.WorkspaceRoleMember); err != nil {
+		return nil, err
+	}
+	tasks, err := s.ITaskRepository.FindTasksByWorkspaceID(ctx, workspaceID, order)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return markBlocked(ctx, s.ITaskRepository, tasks)
+}
+
+// タグで絞り込んだタスクを取得する。matchAllがtrueの場合は全てのタグ、falseの場合はいずれかのタグが付いたタスクを取得する
+// listIDが空の場合は全てのリストから取得する
+func (s *TaskService) FindTasksByUserIDAndTags(ctx context.Context, userID string, listID string, tagIDs []string, matchAll bool, order value.TaskOrder) ([]*entity.Task, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+	if listID != "" {
+		if _, err := s.findList(ctx, listID, userID, value.RoleViewer); err != nil {
+			return nil, err
+		}
+	}
+	if len(tagIDs) == 0 {
+		return nil, &domain.ErrValidationFailed{Msg: "tag ids are empty"}
+	}
+	for _, v := range tagIDs {
+		if err := value.NewID(v).Validate(); err != nil {
+			return nil, err
+		}
+	}
+	tasks, err := s.ITaskRepository.FindTasksByUserIDAndTags(ctx, userID, listID, tagIDs, matchAll, order)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return markBlocked(ctx, s.ITaskRepository, tasks)
 }
 
-func (s *TaskService) CreateTask(ctx context.Context, userID string, name string) (string, error) {
+// タスクを作成する。listIDが空の場合はInboxに作成する
+// 共有されたリストに作成したタスクはリストの所有者が所有する
+func (s *TaskService) CreateTask(ctx context.Context, userID string, listID string, name string) (string, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return "", err
+	}
+	var list *entity.List
+	var err error
+	if listID == "" {
+		list, err = findOrCreateInbox(ctx, s.IListRepository, s.IIDManager, s.IClockManager, userID)
+	} else {
+		list, err = s.findList(ctx, listID, userID, value.RoleEditor)
+	}
+	if err != nil {
+		return "", err
+	}
+	if list.IsArchived {
+		return "", &domain.ErrPreconditionFailed{Msg: "list is archived"}
+	}
+	position, err := s.topPosition(ctx, list.ID.Value())
+	if err != nil {
+		return "", err
+	}
 	now := s.IClockManager.GetNow()
 	arg := &entity.Task{
-		ID:          value.NewID(s.IIDManager.GenerateID()),
-		UserID:      value.NewID(userID),
-		Name:        name,
-		IsCompleted: false,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:        value.NewID(s.IIDManager.GenerateID()),
+		UserID:    list.UserID,
+		Name:      name,
+		Status:    value.TaskStatusTodo,
+		CreatedAt: now,
+		UpdatedAt: now,
+		ListID:    list.ID,
+		Position:  position,
 	}
 	if err := arg.Validate(); err != nil {
 		return "", err
 	}
-	createdID, err := s.ITaskRepository.CreateTask(ctx, arg)
+	var createdID string
+	err = s.runInTx(ctx, func(ctx context.Context) error {
+		id, err := s.ITaskRepository.CreateTask(ctx, arg)
+		if err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		createdID = id
+		if err := s.createHistory(ctx, arg.ID, userID, value.TaskHistoryActionCreated, "", arg.Name, now); err != nil {
+			return err
+		}
+		return s.pushUndo(ctx, userID, arg.ID, value.TaskHistoryActionCreated, "", arg.Name, nil, now)
+	})
 	if err != nil {
-		return "", &domain.ErrQueryFailed{}
+		return "", err
 	}
 	return createdID, nil
 }
 
-func (s *TaskService) ChangeTaskName(ctx context.Context, id string, userID string, name string) error {
+// 指定したタスクとその全ての子孫タスクを取得する
+func (s *TaskService) FindTaskTree(ctx context.Context, id string, userID string) ([]*entity.Task, error) {
+	if err := value.NewID(id).Validate(); err != nil {
+		return nil, err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	tasks, err := s.ITaskRepository.FindTaskTree(ctx, id)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	// サブタスクは常に親タスクと同じユーザーが所有し、親タスクの共有を引き継ぐためルートのみを検証する
+	for _, v := range tasks {
+		if v.ID.Equal(id) {
+			if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, v, userID, value.RoleViewer); err != nil {
+				return nil, err
+			}
+			return tasks, nil
+		}
+	}
+	return nil, &domain.ErrNotFound{Msg: "task not found"}
+}
+
+// サブタスクを作成する。サブタスクは親タスクの所有者が所有する
+func (s *TaskService) CreateSubtask(ctx context.Context, userID string, parentID string, name string) (string, error) {
+	if err := value.NewID(parentID).Validate(); err != nil {
+		return "", err
+	}
+	parent, err := s.ITaskRepository.FindTaskByID(ctx, parentID)
+	if err != nil {
+		return "", &domain.ErrNotFound{Msg: "parent task not found"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, parent, userID, value.RoleEditor); err != nil {
+		return "", err
+	}
+	// 完了または中止したタスクの下に未完了のサブタスクは作成できない
+	if parent.Status.IsClosed() {
+		return "", &domain.ErrPreconditionFailed{Msg: "parent task is completed"}
+	}
+	position, err := s.topPosition(ctx, parent.ListID.Value())
+	if err != nil {
+		return "", err
+	}
+	now := s.IClockManager.GetNow()
+	arg := &entity.Task{
+		ID:        value.NewID(s.IIDManager.GenerateID()),
+		UserID:    parent.UserID,
+		Name:      name,
+		Status:    value.TaskStatusTodo,
+		CreatedAt: now,
+		UpdatedAt: now,
+		ParentID:  value.NewID(parentID),
+		ListID:    parent.ListID,
+		Position:  position,
+	}
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	var createdID string
+	err = s.runInTx(ctx, func(ctx context.Context) error {
+		id, err := s.ITaskRepository.CreateTask(ctx, arg)
+		if err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		createdID = id
+		if err := s.createHistory(ctx, arg.ID, userID, value.TaskHistoryActionCreated, "", arg.Name, now); err != nil {
+			return err
+		}
+		return s.pushUndo(ctx, userID, arg.ID, value.TaskHistoryActionCreated, "", arg.Name, nil, now)
+	})
+	if err != nil {
+		return "", err
+	}
+	return createdID, nil
+}
+
+// タスクを別の親タスクの下に移動する。parentIDが空の場合はルートのタスクにする
+func (s *TaskService) MoveSubtask(ctx context.Context, id string, userID string, parentID string) error {
 	if err := value.NewID(id).Validate(); err != nil {
 		return err
 	}
@@ -85,21 +398,159 @@ func (s *TaskService) ChangeTaskName(ctx context.Context, id string, userID stri
 	if err != nil {
 		return &domain.ErrNotFound{Msg: "task not found"}
 	}
-	if !task.UserID.Equal(userID) {
-		return &domain.ErrPermissionDenied{}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
+	}
+	oldValue := taskUndoValue(task, value.TaskHistoryActionParentChanged)
+	if parentID == "" {
+		task.ParentID = nil
+	} else {
+		if err := s.checkTaskParent(ctx, task, userID, parentID); err != nil {
+			return err
+		}
+		task.ParentID = value.NewID(parentID)
 	}
-	task.Name = name
 	task.UpdatedAt = s.IClockManager.GetNow()
 	if err := task.Validate(); err != nil {
 		return err
 	}
-	if err := s.ITaskRepository.UpdateTask(ctx, task); err != nil {
+	return s.updateTaskField(ctx, userID, task, value.TaskHistoryActionParentChanged, oldValue)
+}
+
+// ルートのタスクをサブタスクごと別のリストに移動する。移動したタスクは移動先のリストの先頭に並ぶ
+func (s *TaskService) MoveTaskToList(ctx context.Context, id string, userID string, listID string) error {
+	if err := value.NewID(id).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	task, err := s.ITaskRepository.FindTaskByID(ctx, id)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "task not found"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleAdmin); err != nil {
+		return err
+	}
+	// サブタスクは親タスクと同じリストに属するため単独では移動できない
+	if task.ParentID != nil {
+		return &domain.ErrPreconditionFailed{Msg: "subtask cannot be moved to another list"}
+	}
+	// リストの移動と先頭の位置の更新は、移動先のリストの確認と合わせて1つのトランザクションで行う
+	return s.runInTx(ctx, func(ctx context.Context) error {
+		if err := s.checkTargetList(ctx, task, userID, listID); err != nil {
+			return err
+		}
+		if task.ListID.Equal(listID) {
+			return nil
+		}
+		position, err := s.topPosition(ctx, listID)
+		if err != nil {
+			return err
+		}
+		now := s.IClockManager.GetNow()
+		if err := s.ITaskRepository.UpdateTaskTreeListID(ctx, id, listID, now); err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		if err := s.ITaskRepository.UpdateTaskPosition(ctx, id, position); err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		oldValue := encodeTaskListUndoValue(task.ListID.Value(), task.Position)
+		newValue := encodeTaskListUndoValue(listID, position)
+		return s.pushUndo(ctx, userID, task.ID, value.TaskHistoryActionListChanged, oldValue, newValue, nil, now)
+	})
+}
+
+// タスクを同じリスト内の別のタスクの前、またはafterがtrueの場合は後ろに移動する
+// 移動するタスクの位置のみを更新し、他のタスクの位置は変更しない
+func (s *TaskService) MoveTask(ctx context.Context, id string, userID string, targetID string, after bool) error {
+	if err := value.NewID(id).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(targetID).Validate(); err != nil {
+		return err
+	}
+	if id == targetID {
+		return &domain.ErrValidationFailed{Msg: "task cannot be moved relative to itself"}
+	}
+	task, err := s.ITaskRepository.FindTaskByID(ctx, id)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "task not found"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
+	}
+	target, err := s.ITaskRepository.FindTaskByID(ctx, targetID)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "target task not found"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, target, userID, value.RoleViewer); err != nil {
+		return err
+	}
+	if !target.ListID.Equal(task.ListID.Value()) {
+		return &domain.ErrValidationFailed{Msg: "target task is in another list"}
+	}
+	// 移動先の前後のタスクの位置を求める。移動するタスク自身は除外する
+	var before, next value.Rank
+	if after {
+		before = target.Position
+		next, err = s.ITaskRepository.FindNextTaskPosition(ctx, target.ListID.Value(), id, target.Position)
+	} else {
+		before, err = s.ITaskRepository.FindPrevTaskPosition(ctx, target.ListID.Value(), id, target.Position)
+		next = target.Position
+	}
+	if err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	position, err := value.RankBetween(before, next)
+	if err != nil {
+		return err
+	}
+	if err := position.Validate(); err != nil {
+		return err
+	}
+	return s.runInTx(ctx, func(ctx context.Context) error {
+		if err := s.ITaskRepository.UpdateTaskPosition(ctx, id, position); err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		return s.pushUndo(ctx, userID, task.ID, value.TaskHistoryActionPositionChanged, task.Position.Value(), position.Value(), nil, s.IClockManager.GetNow())
+	})
+}
+
+// 位置のキーが長くなりすぎた、または重複しているリストのタスクを現在の並び順のまま等間隔に再配置する
+func (s *TaskService) RebalanceTaskPositions(ctx context.Context) error {
+	listIDs, err := s.ITaskRepository.FindListIDsToRebalance(ctx, rebalanceRankLength)
+	if err != nil {
 		return &domain.ErrQueryFailed{}
 	}
+	for _, listID := range listIDs {
+		if err := s.rebalanceList(ctx, listID); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (s *TaskService) CompleteTask(ctx context.Context, id string, userID string) error {
+// リスト内のタスクの行をロックし、1つのトランザクションで等間隔に再配置する
+func (s *TaskService) rebalanceList(ctx context.Context, listID string) error {
+	return s.runInTx(ctx, func(ctx context.Context) error {
+		ids, err := s.ITaskRepository.FindTaskIDsByListIDOrderByPosition(ctx, listID)
+		if err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		for i, position := range value.SpreadRanks(len(ids)) {
+			if err := s.ITaskRepository.UpdateTaskPosition(ctx, ids[i], position); err != nil {
+				return &domain.ErrQueryFailed{}
+			}
+		}
+		return nil
+	})
+}
+
+func (s *TaskService) ChangeTaskName(ctx context.Context, id string, userID string, name string) error {
 	if err := value.NewID(id).Validate(); err != nil {
 		return err
 	}
@@ -110,21 +561,53 @@ func (s *TaskService) CompleteTask(ctx context.Context, id string, userID string
 	if err != nil {
 		return &domain.ErrNotFound{Msg: "task not found"}
 	}
-	if !task.UserID.Equal(userID) {
-		return &domain.ErrPermissionDenied{}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
 	}
-	task.IsCompleted = true
+	oldName := task.Name
+	task.Name = name
 	task.UpdatedAt = s.IClockManager.GetNow()
 	if err := task.Validate(); err != nil {
 		return err
 	}
-	if err := s.ITaskRepository.UpdateTask(ctx, task); err != nil {
-		return &domain.ErrQueryFailed{}
+	return s.runInTx(ctx, func(ctx context.Context) error {
+		if err := s.ITaskRepository.UpdateTask(ctx, task); err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		if err := s.createHistory(ctx, task.ID, userID, value.TaskHistoryActionRenamed, oldName, name, task.UpdatedAt); err != nil {
+			return err
+		}
+		return s.pushUndo(ctx, userID, task.ID, value.TaskHistoryActionRenamed, oldName, name, nil, task.UpdatedAt)
+	})
+}
+
+func (s *TaskService) ChangeTaskPriority(ctx context.Context, id string, userID string, priority value.Priority) error {
+	if err := value.NewID(id).Validate(); err != nil {
+		return err
 	}
-	return nil
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	if err := priority.Validate(); err != nil {
+		return err
+	}
+	task, err := s.ITaskRepository.FindTaskByID(ctx, id)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "task not found"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
+	}
+	oldValue := taskUndoValue(task, value.TaskHistoryActionPriorityChanged)
+	task.Priority = priority
+	task.UpdatedAt = s.IClockManager.GetNow()
+	if err := task.Validate(); err != nil {
+		return err
+	}
+	return s.updateTaskField(ctx, userID, task, value.TaskHistoryActionPriorityChanged, oldValue)
 }
 
-func (s *TaskService) UncompleteTask(ctx context.Context, id string, userID string) error {
+func (s *TaskService) ChangeTaskDescription(ctx context.Context, id string, userID string, description string, descriptionHTML string) error {
 	if err := value.NewID(id).Validate(); err != nil {
 		return err
 	}
@@ -135,21 +618,54 @@ func (s *TaskService) UncompleteTask(ctx context.Context, id string, userID stri
 	if err != nil {
 		return &domain.ErrNotFound{Msg: "task not found"}
 	}
-	if !task.UserID.Equal(userID) {
-		return &domain.ErrPermissionDenied{}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
 	}
-	task.IsCompleted = false
+	oldValue := taskUndoValue(task, value.TaskHistoryActionDescriptionChanged)
+	task.Description = description
+	task.DescriptionHTML = descriptionHTML
 	task.UpdatedAt = s.IClockManager.GetNow()
 	if err := task.Validate(); err != nil {
 		return err
 	}
-	if err := s.ITaskRepository.UpdateTask(ctx, task); err != nil {
-		return &domain.ErrQueryFailed{}
+	return s.updateTaskField(ctx, userID, task, value.TaskHistoryActionDescriptionChanged, oldValue)
+}
+
+// タスクの担当者を設定する。担当者はタスクを閲覧できるユーザーに限る
+func (s *TaskService) AssignTask(ctx context.Context, id string, userID string, assigneeID string) error {
+	if err := value.NewID(id).Validate(); err != nil {
+		return err
 	}
-	return nil
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(assigneeID).Validate(); err != nil {
+		return err
+	}
+	task, err := s.ITaskRepository.FindTaskByID(ctx, id)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "task not found"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
+	}
+	if task.AssigneeID != nil && task.AssigneeID.Equal(assigneeID) {
+		return nil
+	}
+	if err := s.checkTaskAssignee(ctx, task, assigneeID); err != nil {
+		return err
+	}
+	oldValue := taskUndoValue(task, value.TaskHistoryActionAssigneeChanged)
+	task.AssigneeID = value.NewID(assigneeID)
+	task.UpdatedAt = s.IClockManager.GetNow()
+	if err := task.Validate(); err != nil {
+		return err
+	}
+	return s.updateTaskField(ctx, userID, task, value.TaskHistoryActionAssigneeChanged, oldValue)
 }
 
-func (s *TaskService) DeleteTask(ctx context.Context, id string, userID string) error {
+// タスクの担当者を外す。担当者がいない場合は何もしない
+func (s *TaskService) UnassignTask(ctx context.Context, id string, userID string) error {
 	if err := value.NewID(id).Validate(); err != nil {
 		return err
 	}
@@ -160,11 +676,1427 @@ func (s *TaskService) DeleteTask(ctx context.Context, id string, userID string)
 	if err != nil {
 		return &domain.ErrNotFound{Msg: "task not found"}
 	}
-	if !task.UserID.Equal(userID) {
-		return &domain.ErrPermissionDenied{}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
 	}
-	if err := s.ITaskRepository.DeleteTask(ctx, id); err != nil {
-		return &domain.ErrQueryFailed{}
+	if task.AssigneeID == nil {
+		return nil
 	}
-	return nil
+	oldValue := taskUndoValue(task, value.TaskHistoryActionAssigneeChanged)
+	task.AssigneeID = nil
+	task.UpdatedAt = s.IClockManager.GetNow()
+	return s.updateTaskField(ctx, userID, task, value.TaskHistoryActionAssigneeChanged, oldValue)
+}
+
+// タスクを完了にする。TransitionTaskの互換用
+func (s *TaskService) CompleteTask(ctx context.Context, id string, userID string) error {
+	return s.TransitionTask(ctx, id, userID, value.TaskStatusDone)
+}
+
+// タスクを未着手に戻す。TransitionTaskの互換用
+func (s *TaskService) UncompleteTask(ctx context.Context, id string, userID string) error {
+	return s.TransitionTask(ctx, id, userID, value.TaskStatusTodo)
+}
+
+// タスクの状態を変更する。状態遷移表にない遷移はできない。現在と同じ状態の場合は何もしない
+func (s *TaskService) TransitionTask(ctx context.Context, id string, userID string, status value.TaskStatus) error {
+	if err := value.NewID(id).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	if err := status.Validate(); err != nil {
+		return err
+	}
+	task, err := s.ITaskRepository.FindTaskByID(ctx, id)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "task not found"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
+	}
+	if task.Status == status {
+		return nil
+	}
+	if !canTransition(task.Status, status) {
+		return &domain.ErrPreconditionFailed{Msg: "invalid status transition"}
+	}
+	if err := s.checkTaskStatusChange(ctx, task, status); err != nil {
+		return err
+	}
+	// 繰り返しのタスクを完了にした場合は次の回のタスクを作成する。繰り返しのルールは次の回のタスクに引き継ぐ
+	var next *entity.Task
+	if status == value.TaskStatusDone && task.RecurrenceRule != "" {
+		next, err = s.nextOccurrence(ctx, task)
+		if err != nil {
+			return err
+		}
+		task.RecurrenceRule = ""
+		task.RecurrenceTimezone = ""
+	}
+	oldStatus := task.Status
+	task.Status = status
+	task.UpdatedAt = s.IClockManager.GetNow()
+	if err := task.Validate(); err != nil {
+		return err
+	}
+	return s.runInTx(ctx, func(ctx context.Context) error {
+		if err := s.ITaskRepository.UpdateTask(ctx, task); err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		if err := s.createHistory(ctx, task.ID, userID, value.TaskHistoryActionStatusChanged, oldStatus.String(), status.String(), task.UpdatedAt); err != nil {
+			return err
+		}
+		if next == nil {
+			return s.pushUndo(ctx, userID, task.ID, value.TaskHistoryActionStatusChanged, oldStatus.String(), status.String(), nil, task.UpdatedAt)
+		}
+		if _, err := s.ITaskRepository.CreateTask(ctx, next); err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		if err := s.createHistory(ctx, next.ID, userID, value.TaskHistoryActionCreated, "", next.Name, next.CreatedAt); err != nil {
+			return err
+		}
+		// 次の回のタスクの作成は完了と一緒に取り消す
+		return s.pushUndo(ctx, userID, task.ID, value.TaskHistoryActionStatusChanged, oldStatus.String(), status.String(), next.ID, task.UpdatedAt)
+	})
+}
+
+// タスクに繰り返しのルールを設定する。既に設定されている場合は置き換える
+func (s *TaskService) SetTaskRecurrence(ctx context.Context, id string, userID string, rule value.RecurrenceRule, timezone string) error {
+	if err := value.NewID(id).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	if _, err := s.IClockManager.LoadLocation(timezone); err != nil {
+		return &domain.ErrValidationFailed{Msg: "invalid timezone"}
+	}
+	task, err := s.ITaskRepository.FindTaskByID(ctx, id)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "task not found"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
+	}
+	// 完了または中止したタスクは次の回を作成する機会がないため設定できない
+	if task.Status.IsClosed() {
+		return &domain.ErrPreconditionFailed{Msg: "task is closed"}
+	}
+	oldValue := taskUndoValue(task, value.TaskHistoryActionRecurrenceChanged)
+	task.RecurrenceRule = rule
+	task.RecurrenceTimezone = timezone
+	task.UpdatedAt = s.IClockManager.GetNow()
+	if err := task.Validate(); err != nil {
+		return err
+	}
+	return s.updateTaskField(ctx, userID, task, value.TaskHistoryActionRecurrenceChanged, oldValue)
+}
+
+// タスクの繰り返しを停止する。タスク自体は残る
+func (s *TaskService) ClearTaskRecurrence(ctx context.Context, id string, userID string) error {
+	if err := value.NewID(id).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	task, err := s.ITaskRepository.FindTaskByID(ctx, id)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "task not found"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
+	}
+	oldValue := taskUndoValue(task, value.TaskHistoryActionRecurrenceChanged)
+	task.RecurrenceRule = ""
+	task.RecurrenceTimezone = ""
+	task.UpdatedAt = s.IClockManager.GetNow()
+	if err := task.Validate(); err != nil {
+		return err
+	}
+	return s.updateTaskField(ctx, userID, task, value.TaskHistoryActionRecurrenceChanged, oldValue)
+}
+
+func (s *TaskService) SetTaskDueDate(ctx context.Context, id string, userID string, dueAt time.Time) error {
+	if err := value.NewID(id).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	if dueAt.IsZero() {
+		return &domain.ErrValidationFailed{Msg: "due date is empty"}
+	}
+	task, err := s.ITaskRepository.FindTaskByID(ctx, id)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "task not found"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
+	}
+	oldValue := taskUndoValue(task, value.TaskHistoryActionDueDateChanged)
+	due := dueAt.UTC()
+	task.DueAt = &due
+	task.UpdatedAt = s.IClockManager.GetNow()
+	if err := task.Validate(); err != nil {
+		return err
+	}
+	return s.updateTaskField(ctx, userID, task, value.TaskHistoryActionDueDateChanged, oldValue)
+}
+
+func (s *TaskService) ClearTaskDueDate(ctx context.Context, id string, userID string) error {
+	if err := value.NewID(id).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	task, err := s.ITaskRepository.FindTaskByID(ctx, id)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "task not found"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
+	}
+	oldValue := taskUndoValue(task, value.TaskHistoryActionDueDateChanged)
+	task.DueAt = nil
+	task.UpdatedAt = s.IClockManager.GetNow()
+	if err := task.Validate(); err != nil {
+		return err
+	}
+	return s.updateTaskField(ctx, userID, task, value.TaskHistoryActionDueDateChanged, oldValue)
+}
+
+// idのタスクがblockerIDのタスクの完了を待つように依存関係を追加する。依存関係が循環する場合は追加できない
+func (s *TaskService) AddTaskDependency(ctx context.Context, id string, userID string, blockerID string) error {
+	if err := value.NewID(id).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(blockerID).Validate(); err != nil {
+		return err
+	}
+	if id == blockerID {
+		return &domain.ErrValidationFailed{Msg: "task cannot depend on itself"}
+	}
+	task, err := s.ITaskRepository.FindTaskByID(ctx, id)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "task not found"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
+	}
+	blocker, err := s.ITaskRepository.FindTaskByID(ctx, blockerID)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "blocker task not found"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, blocker, userID, value.RoleViewer); err != nil {
+		return err
+	}
+	if err := s.checkTaskDependencyCycle(ctx, id, blockerID); err != nil {
+		return err
+	}
+	return s.runInTx(ctx, func(ctx context.Context) error {
+		now := s.IClockManager.GetNow()
+		n, err := s.ITaskRepository.AddTaskDependency(ctx, id, blockerID, now)
+		if err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		// 既に登録されている場合は何も変わらないため取り消しのスタックに積まない
+		if n == 0 {
+			return nil
+		}
+		return s.pushUndo(ctx, userID, task.ID, value.TaskHistoryActionDependencyAdded, "", blockerID, nil, now)
+	})
+}
+
+func (s *TaskService) RemoveTaskDependency(ctx context.Context, id string, userID string, blockerID string) error {
+	if err := value.NewID(id).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(blockerID).Validate(); err != nil {
+		return err
+	}
+	task, err := s.ITaskRepository.FindTaskByID(ctx, id)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "task not found"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
+	}
+	return s.runInTx(ctx, func(ctx context.Context) error {
+		n, err := s.ITaskRepository.RemoveTaskDependency(ctx, id, blockerID)
+		if err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		// 登録されていない場合は何も変わらないため取り消しのスタックに積まない
+		if n == 0 {
+			return nil
+		}
+		return s.pushUndo(ctx, userID, task.ID, value.TaskHistoryActionDependencyRemoved, blockerID, "", nil, s.IClockManager.GetNow())
+	})
+}
+
+// タスクをゴミ箱に移動する。サブタスクも合わせて移動される
+func (s *TaskService) DeleteTask(ctx context.Context, id string, userID string) error {
+	if err := value.NewID(id).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	task, err := s.ITaskRepository.FindTaskByID(ctx, id)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "task not found"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleAdmin); err != nil {
+		return err
+	}
+	now := s.IClockManager.GetNow()
+	return s.runInTx(ctx, func(ctx context.Context) error {
+		if err := s.ITaskRepository.DeleteTask(ctx, id, now); err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		if err := s.createHistory(ctx, task.ID, userID, value.TaskHistoryActionDeleted, "", "", now); err != nil {
+			return err
+		}
+		return s.pushUndo(ctx, userID, task.ID, value.TaskHistoryActionDeleted, "", "", nil, now)
+	})
+}
+
+// タスクを一括で操作する。全ての操作を1つのトランザクションで順に実行し、操作ごとの結果を返す
+// atomicの場合は1件でも失敗すると全ての操作を取り消し、失敗した操作より後の操作は実行しない
+// atomicでない場合は失敗した操作だけをセーブポイントまで戻し、残りの操作を続ける
+func (s *TaskService) BatchMutateTasks(ctx context.Context, userID string, ops []value.TaskOperation, atomic bool) ([]*entity.TaskOperationResult, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	results := make([]*entity.TaskOperationResult, len(ops))
+	for i, op := range ops {
+		results[i] = &entity.TaskOperationResult{Status: value.TaskOperationStatusAborted, TaskID: op.TaskID}
+	}
+	aborted := false
+	err := s.ITransactionManager.RunInTx(ctx, func(ctx context.Context) error {
+		for i, op := range ops {
+			var taskID string
+			var opErr error
+			if atomic {
+				taskID, opErr = s.applyTaskOperation(ctx, userID, op)
+			} else {
+				opErr = s.ITransactionManager.RunInSavepoint(ctx, func(ctx context.Context) error {
+					var err error
+					taskID, err = s.applyTaskOperation(ctx, userID, op)
+					return err
+				})
+			}
+			if opErr != nil {
+				results[i].Status = value.TaskOperationStatusFailed
+				results[i].Err = opErr
+				if atomic {
+					aborted = true
+					return opErr
+				}
+				continue
+			}
+			results[i].Status = value.TaskOperationStatusSucceeded
+			results[i].TaskID = taskID
+		}
+		return nil
+	})
+	if aborted {
+		// ロールバックされた操作は実行されなかったものとして扱う
+		for i, r := range results {
+			if r.Status == value.TaskOperationStatusSucceeded {
+				r.Status = value.TaskOperationStatusAborted
+				r.TaskID = ops[i].TaskID
+			}
+		}
+		return results, nil
+	}
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return results, nil
+}
+
+// 一括操作の1件を実行し、操作したタスクのIDを返す
+func (s *TaskService) applyTaskOperation(ctx context.Context, userID string, op value.TaskOperation) (string, error) {
+	if err := op.Validate(); err != nil {
+		return "", err
+	}
+	switch op.Kind {
+	case value.TaskOperationKindCreate:
+		return s.CreateTask(ctx, userID, op.ListID, op.Name)
+	case value.TaskOperationKindRename:
+		return op.TaskID, s.ChangeTaskName(ctx, op.TaskID, userID, op.Name)
+	case value.TaskOperationKindComplete:
+		return op.TaskID, s.CompleteTask(ctx, op.TaskID, userID)
+	case value.TaskOperationKindUncomplete:
+		return op.TaskID, s.UncompleteTask(ctx, op.TaskID, userID)
+	default:
+		return op.TaskID, s.DeleteTask(ctx, op.TaskID, userID)
+	}
+}
+
+// ファイルから読み込んだタスクをリストの先頭にファイルの順で作成する。listIDが空の場合はInboxに作成する
+// リストにある、またはファイルの前の行にある名前と同じ名前の行は作成しない。失敗した行だけをセーブポイントまで戻し、残りの行を続ける
+// dryRunの場合は全ての行を作成した後にロールバックし、作成される予定の結果を返す
+// インポートは取り消しの対象にしない
+func (s *TaskService) ImportTasks(ctx context.Context, userID string, listID string, rows []value.TaskImportRow, dryRun bool) ([]*entity.TaskImportResult, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	var results []*entity.TaskImportResult
+	err := s.runInTx(ctx, func(ctx context.Context) error {
+		var list *entity.List
+		var err error
+		if listID == "" {
+			list, err = findOrCreateInbox(ctx, s.IListRepository, s.IIDManager, s.IClockManager, userID)
+		} else {
+			list, err = s.findList(ctx, listID, userID, value.RoleEditor)
+		}
+		if err != nil {
+			return err
+		}
+		if list.IsArchived {
+			return &domain.ErrPreconditionFailed{Msg: "list is archived"}
+		}
+		existing, err := s.ITaskRepository.FindTasksByListID(ctx, list.ID.Value(), value.TaskOrderPosition)
+		if err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		names := make(map[string]bool, len(existing)+len(rows))
+		for _, v := range existing {
+			names[v.Name] = true
+		}
+		first, err := s.ITaskRepository.FindMinTaskPosition(ctx, list.ID.Value())
+		if err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		now := s.IClockManager.GetNow()
+		var prev value.Rank
+		results = make([]*entity.TaskImportResult, len(rows))
+		for i, row := range rows {
+			results[i] = &entity.TaskImportResult{Line: row.Line, Name: row.Name}
+			if names[row.Name] {
+				results[i].Status = value.TaskImportStatusDuplicate
+				continue
+			}
+			position, err := value.RankBetween(prev, first)
+			if err != nil {
+				return err
+			}
+			arg := &entity.Task{
+				ID:              value.NewID(s.IIDManager.GenerateID()),
+				UserID:          list.UserID,
+				Name:            row.Name,
+				Status:          row.Status,
+				CreatedAt:       now,
+				UpdatedAt:       now,
+				DueAt:           row.DueAt,
+				Priority:        row.Priority,
+				Description:     row.Description,
+				DescriptionHTML: row.DescriptionHTML,
+				ListID:          list.ID,
+				Position:        position,
+			}
+			err = arg.Validate()
+			if err == nil {
+				err = s.ITransactionManager.RunInSavepoint(ctx, func(ctx context.Context) error {
+					if _, err := s.ITaskRepository.CreateTask(ctx, arg); err != nil {
+						return &domain.ErrQueryFailed{}
+					}
+					return s.createHistory(ctx, arg.ID, userID, value.TaskHistoryActionCreated, "", arg.Name, now)
+				})
+			}
+			if err != nil {
+				results[i].Status = value.TaskImportStatusFailed
+				results[i].Err = err
+				continue
+			}
+			results[i].Status = value.TaskImportStatusCreated
+			results[i].TaskID = arg.ID.Value()
+			names[row.Name] = true
+			prev = position
+		}
+		if dryRun {
+			return errImportDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errImportDryRun) {
+		// ロールバックしたため作成したタスクは存在しない
+		for _, r := range results {
+			r.TaskID = ""
+		}
+		return results, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (s *TaskService) FindTrashedTasksByUserID(ctx context.Context, userID string) ([]*entity.Task, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	tasks, err := s.ITaskRepository.FindTrashedTasksByUserID(ctx, userID)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return tasks, nil
+}
+
+// ゴミ箱のタスクを復元する。一緒にゴミ箱に移動したサブタスクも合わせて復元される
+func (s *TaskService) RestoreTask(ctx context.Context, id string, userID string) error {
+	if err := value.NewID(id).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	task, err := s.ITaskRepository.FindTrashedTaskByID(ctx, id)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "task not found in trash"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleAdmin); err != nil {
+		return err
+	}
+	// 親タスクがゴミ箱にある場合は先に親タスクを復元する必要がある
+	if task.ParentID != nil {
+		if _, err := s.ITaskRepository.FindTaskByID(ctx, task.ParentID.Value()); err != nil {
+			return &domain.ErrPreconditionFailed{Msg: "parent task is in trash"}
+		}
+	}
+	return s.runInTx(ctx, func(ctx context.Context) error {
+		if err := s.ITaskRepository.RestoreTask(ctx, id, *task.DeletedAt); err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		now := s.IClockManager.GetNow()
+		if err := s.createHistory(ctx, task.ID, userID, value.TaskHistoryActionRestored, "", "", now); err != nil {
+			return err
+		}
+		return s.pushUndo(ctx, userID, task.ID, value.TaskHistoryActionRestored, "", "", nil, now)
+	})
+}
+
+// ゴミ箱のタスクを全て完全に削除する
+func (s *TaskService) EmptyTrash(ctx context.Context, userID string) error {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	if err := s.ITaskRepository.PurgeTrashedTasksByUserID(ctx, userID); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+// ゴミ箱に移動してからretentionを過ぎたタスクを完全に削除し、削除した件数を返す
+func (s *TaskService) PurgeTrashedTasks(ctx context.Context, retention time.Duration) (int64, error) {
+	if retention <= 0 {
+		return 0, &domain.ErrValidationFailed{Msg: "retention must be positive"}
+	}
+	before := s.IClockManager.GetNow().Add(-retention)
+	n, err := s.ITaskRepository.PurgeTasksDeletedBefore(ctx, before)
+	if err != nil {
+		return 0, &domain.ErrQueryFailed{}
+	}
+	return n, nil
+}
+
+// タスクの変更履歴を新しい順に最大limit件取得する。ゴミ箱のタスクの履歴も取得できる
+// 続きの履歴がある場合は次のページの位置を返す
+func (s *TaskService) FindTaskHistory(ctx context.Context, id string, userID string, limit int32, cursor *value.PageCursor) ([]*entity.TaskHistory, *value.PageCursor, error) {
+	if err := value.NewID(id).Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, nil, err
+	}
+	if limit <= 0 {
+		return nil, nil, &domain.ErrValidationFailed{Msg: "limit must be positive"}
+	}
+	task, err := s.ITaskRepository.FindTaskByID(ctx, id)
+	if err != nil {
+		if task, err = s.ITaskRepository.FindTrashedTaskByID(ctx, id); err != nil {
+			return nil, nil, &domain.ErrNotFound{Msg: "task not found"}
+		}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleViewer); err != nil {
+		return nil, nil, err
+	}
+	// 1件多く取得して続きがあるかを判定する
+	histories, err := s.ITaskHistoryRepository.FindTaskHistories(ctx, id, limit+1, cursor)
+	if err != nil {
+		return nil, nil, &domain.ErrQueryFailed{}
+	}
+	if int32(len(histories)) <= limit {
+		return histories, nil, nil
+	}
+	histories = histories[:limit]
+	last := histories[limit-1]
+	return histories, &value.PageCursor{Time: last.CreatedAt, ID: last.ID.Value()}, nil
+}
+
+// 取り消しのスタックの最新の操作を取り消し、やり直しのスタックに積む。取り消した操作を返す
+// 操作した項目が操作後の値から変わっている場合は競合として取り消さず、操作をスタックから取り除く
+func (s *TaskService) Undo(ctx context.Context, userID string) (*entity.TaskUndoEntry, error) {
+	return s.replayUndoEntry(ctx, userID, value.UndoStackUndo)
+}
+
+// やり直しのスタックの最新の操作をやり直し、取り消しのスタックに積む。やり直した操作を返す
+// 取り消した後に項目が変わっている場合は競合としてやり直さず、操作をスタックから取り除く
+func (s *TaskService) Redo(ctx context.Context, userID string) (*entity.TaskUndoEntry, error) {
+	return s.replayUndoEntry(ctx, userID, value.UndoStackRedo)
+}
+
+// stackの最新の操作を取り出して反対向きに反映し、反対のスタックに積む
+func (s *TaskService) replayUndoEntry(ctx context.Context, userID string, stack value.UndoStack) (*entity.TaskUndoEntry, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	undo := stack == value.UndoStackUndo
+	to := value.UndoStackUndo
+	if undo {
+		to = value.UndoStackRedo
+	}
+	now := s.IClockManager.GetNow()
+	var entry *entity.TaskUndoEntry
+	var replayErr error
+	err := s.runInTx(ctx, func(ctx context.Context) error {
+		var err error
+		entry, err = s.ITaskUndoRepository.PopTaskUndoEntry(ctx, userID, stack, now.Add(-undoExpiry))
+		if err != nil {
+			if undo {
+				return &domain.ErrNotFound{Msg: "nothing to undo"}
+			}
+			return &domain.ErrNotFound{Msg: "nothing to redo"}
+		}
+		// 反映できない場合は反映の途中の変更だけを戻し、取り出した操作はスタックから取り除いたままにする
+		err = s.ITransactionManager.RunInSavepoint(ctx, func(ctx context.Context) error {
+			if err := s.revertTaskUndoEntry(ctx, userID, entry, undo, now); err != nil {
+				return err
+			}
+			return s.createUndoEntry(ctx, &entity.TaskUndoEntry{
+				ID:         value.NewID(s.IIDManager.GenerateID()),
+				UserID:     entry.UserID,
+				Stack:      to,
+				TaskID:     entry.TaskID,
+				Action:     entry.Action,
+				OldValue:   entry.OldValue,
+				NewValue:   entry.NewValue,
+				NextTaskID: entry.NextTaskID,
+				CreatedAt:  now,
+			})
+		})
+		switch err.(type) {
+		case nil:
+			return nil
+		case *domain.ErrNotFound, *domain.ErrPermissionDenied, *domain.ErrPreconditionFailed, *domain.ErrValidationFailed:
+			replayErr = err
+			return nil
+		default:
+			return &domain.ErrQueryFailed{}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if replayErr != nil {
+		return nil, replayErr
+	}
+	return entry, nil
+}
+
+// 操作を取り消す(undo)またはやり直す。反映先の項目の現在の値が反映前の値と一致しない場合は競合とする
+func (s *TaskService) revertTaskUndoEntry(ctx context.Context, userID string, entry *entity.TaskUndoEntry, undo bool, now time.Time) error {
+	switch entry.Action {
+	case value.TaskHistoryActionRenamed:
+		from, to := entry.NewValue, entry.OldValue
+		if !undo {
+			from, to = to, from
+		}
+		return s.replayRename(ctx, userID, entry.TaskID.Value(), from, to, now)
+	case value.TaskHistoryActionStatusChanged:
+		from, to := entry.NewValue, entry.OldValue
+		if !undo {
+			from, to = to, from
+		}
+		return s.replayStatusChange(ctx, userID, entry, value.ParseTaskStatus(from), value.ParseTaskStatus(to), undo, now)
+	case value.TaskHistoryActionPriorityChanged, value.TaskHistoryActionDescriptionChanged, value.TaskHistoryActionAssigneeChanged,
+		value.TaskHistoryActionDueDateChanged, value.TaskHistoryActionRecurrenceChanged, value.TaskHistoryActionParentChanged:
+		from, to := entry.NewValue, entry.OldValue
+		if !undo {
+			from, to = to, from
+		}
+		return s.replayTaskField(ctx, userID, entry.TaskID.Value(), entry.Action, from, to, now)
+	case value.TaskHistoryActionPositionChanged:
+		from, to := entry.NewValue, entry.OldValue
+		if !undo {
+			from, to = to, from
+		}
+		return s.replayPosition(ctx, userID, entry.TaskID.Value(), value.Rank(from), value.Rank(to))
+	case value.TaskHistoryActionListChanged:
+		from, to := entry.NewValue, entry.OldValue
+		if !undo {
+			from, to = to, from
+		}
+		return s.replayListChange(ctx, userID, entry.TaskID.Value(), from, to, now)
+	case value.TaskHistoryActionDependencyAdded, value.TaskHistoryActionDependencyRemoved:
+		// 追加の取り消しと削除のやり直しは依存関係の削除、それ以外は依存関係の追加になる
+		blockerID := entry.NewValue
+		if entry.Action == value.TaskHistoryActionDependencyRemoved {
+			blockerID = entry.OldValue
+		}
+		if (entry.Action == value.TaskHistoryActionDependencyAdded) != undo {
+			return s.replayAddDependency(ctx, userID, entry.TaskID.Value(), blockerID, now)
+		}
+		return s.replayRemoveDependency(ctx, userID, entry.TaskID.Value(), blockerID)
+	default:
+		// 作成と復元の取り消しと削除のやり直しはゴミ箱への移動、それ以外はゴミ箱からの復元になる
+		if (entry.Action == value.TaskHistoryActionDeleted) != undo {
+			return s.replayDelete(ctx, userID, entry, now)
+		}
+		return s.replayRestore(ctx, userID, entry.TaskID.Value(), now)
+	}
+}
+
+func (s *TaskService) replayRename(ctx context.Context, userID string, id string, from string, to string, now time.Time) error {
+	task, err := s.ITaskRepository.FindTaskByID(ctx, id)
+	if err != nil {
+		return errTaskChanged()
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
+	}
+	if task.Name != from {
+		return errTaskChanged()
+	}
+	task.Name = to
+	task.UpdatedAt = now
+	if err := task.Validate(); err != nil {
+		return err
+	}
+	if err := s.ITaskRepository.UpdateTask(ctx, task); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return s.createHistory(ctx, task.ID, userID, value.TaskHistoryActionRenamed, from, to, now)
+}
+
+// 状態をfromからtoに戻す。繰り返しのタスクの完了を取り消す場合は次の回のタスクをゴミ箱に移動して繰り返しのルールを戻す
+func (s *TaskService) replayStatusChange(ctx context.Context, userID string, entry *entity.TaskUndoEntry, from value.TaskStatus, to value.TaskStatus, undo bool, now time.Time) error {
+	task, err := s.ITaskRepository.FindTaskByID(ctx, entry.TaskID.Value())
+	if err != nil {
+		return errTaskChanged()
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
+	}
+	if task.Status != from {
+		return errTaskChanged()
+	}
+	if err := to.Validate(); err != nil {
+		return err
+	}
+	if err := s.checkTaskStatusChange(ctx, task, to); err != nil {
+		return err
+	}
+	if entry.NextTaskID != nil {
+		if undo {
+			next, err := s.ITaskRepository.FindTaskByID(ctx, entry.NextTaskID.Value())
+			if err != nil || next.Status != value.TaskStatusTodo {
+				return errTaskChanged()
+			}
+			task.RecurrenceRule = next.RecurrenceRule
+			task.RecurrenceTimezone = next.RecurrenceTimezone
+			if err := s.ITaskRepository.DeleteTask(ctx, next.ID.Value(), now); err != nil {
+				return &domain.ErrQueryFailed{}
+			}
+			if err := s.createHistory(ctx, next.ID, userID, value.TaskHistoryActionDeleted, "", "", now); err != nil {
+				return err
+			}
+		} else {
+			next, err := s.ITaskRepository.FindTrashedTaskByID(ctx, entry.NextTaskID.Value())
+			if err != nil {
+				return errTaskChanged()
+			}
+			task.RecurrenceRule = ""
+			task.RecurrenceTimezone = ""
+			if err := s.ITaskRepository.RestoreTask(ctx, next.ID.Value(), *next.DeletedAt); err != nil {
+				return &domain.ErrQueryFailed{}
+			}
+			if err := s.createHistory(ctx, next.ID, userID, value.TaskHistoryActionRestored, "", "", now); err != nil {
+				return err
+			}
+		}
+	}
+	task.Status = to
+	task.UpdatedAt = now
+	if err := task.Validate(); err != nil {
+		return err
+	}
+	if err := s.ITaskRepository.UpdateTask(ctx, task); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return s.createHistory(ctx, task.ID, userID, value.TaskHistoryActionStatusChanged, from.String(), to.String(), now)
+}
+
+// 優先度などUpdateTaskで保存する項目をfromからtoに戻す
+func (s *TaskService) replayTaskField(ctx context.Context, userID string, id string, action value.TaskHistoryAction, from string, to string, now time.Time) error {
+	task, err := s.ITaskRepository.FindTaskByID(ctx, id)
+	if err != nil {
+		return errTaskChanged()
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
+	}
+	if taskUndoValue(task, action) != from {
+		return errTaskChanged()
+	}
+	if err := setTaskUndoValue(task, action, to); err != nil {
+		return err
+	}
+	// 操作した時点から変わった状態や権限のもとで、戻した値が操作の条件を満たすかを検証する
+	switch action {
+	case value.TaskHistoryActionAssigneeChanged:
+		if task.AssigneeID != nil {
+			if err := s.checkTaskAssignee(ctx, task, task.AssigneeID.Value()); err != nil {
+				return err
+			}
+		}
+	case value.TaskHistoryActionRecurrenceChanged:
+		if task.RecurrenceRule != "" && task.Status.IsClosed() {
+			return &domain.ErrPreconditionFailed{Msg: "task is closed"}
+		}
+	case value.TaskHistoryActionParentChanged:
+		if task.ParentID != nil {
+			if err := s.checkTaskParent(ctx, task, userID, task.ParentID.Value()); err != nil {
+				return err
+			}
+		}
+	}
+	task.UpdatedAt = now
+	if err := task.Validate(); err != nil {
+		return err
+	}
+	if err := s.ITaskRepository.UpdateTask(ctx, task); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return s.createHistory(ctx, task.ID, userID, action, from, to, now)
+}
+
+func (s *TaskService) replayPosition(ctx context.Context, userID string, id string, from value.Rank, to value.Rank) error {
+	task, err := s.ITaskRepository.FindTaskByID(ctx, id)
+	if err != nil {
+		return errTaskChanged()
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
+	}
+	if task.Position != from {
+		return errTaskChanged()
+	}
+	if err := to.Validate(); err != nil {
+		return err
+	}
+	if err := s.ITaskRepository.UpdateTaskPosition(ctx, id, to); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+// タスクをfromのリストと位置からtoのリストと位置に戻す。サブタスクも合わせて移動する
+func (s *TaskService) replayListChange(ctx context.Context, userID string, id string, from string, to string, now time.Time) error {
+	fromListID, fromPosition, err := decodeTaskListUndoValue(from)
+	if err != nil {
+		return err
+	}
+	toListID, toPosition, err := decodeTaskListUndoValue(to)
+	if err != nil {
+		return err
+	}
+	task, err := s.ITaskRepository.FindTaskByID(ctx, id)
+	if err != nil {
+		return errTaskChanged()
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleAdmin); err != nil {
+		return err
+	}
+	if task.ParentID != nil || !task.ListID.Equal(fromListID) || task.Position != fromPosition {
+		return errTaskChanged()
+	}
+	if err := s.checkTargetList(ctx, task, userID, toListID); err != nil {
+		return err
+	}
+	if err := toPosition.Validate(); err != nil {
+		return err
+	}
+	if err := s.ITaskRepository.UpdateTaskTreeListID(ctx, id, toListID, now); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	if err := s.ITaskRepository.UpdateTaskPosition(ctx, id, toPosition); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+func (s *TaskService) replayAddDependency(ctx context.Context, userID string, id string, blockerID string, now time.Time) error {
+	task, err := s.ITaskRepository.FindTaskByID(ctx, id)
+	if err != nil {
+		return errTaskChanged()
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
+	}
+	blocker, err := s.ITaskRepository.FindTaskByID(ctx, blockerID)
+	if err != nil {
+		return errTaskChanged()
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, blocker, userID, value.RoleViewer); err != nil {
+		return err
+	}
+	if err := s.checkTaskDependencyCycle(ctx, id, blockerID); err != nil {
+		return err
+	}
+	n, err := s.ITaskRepository.AddTaskDependency(ctx, id, blockerID, now)
+	if err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	if n == 0 {
+		return errTaskChanged()
+	}
+	return nil
+}
+
+func (s *TaskService) replayRemoveDependency(ctx context.Context, userID string, id string, blockerID string) error {
+	task, err := s.ITaskRepository.FindTaskByID(ctx, id)
+	if err != nil {
+		return errTaskChanged()
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor); err != nil {
+		return err
+	}
+	n, err := s.ITaskRepository.RemoveTaskDependency(ctx, id, blockerID)
+	if err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	if n == 0 {
+		return errTaskChanged()
+	}
+	return nil
+}
+
+// タスクをゴミ箱に移動する。操作した後にタスクが更新されている場合は更新した内容が失われるため競合として扱う
+func (s *TaskService) replayDelete(ctx context.Context, userID string, entry *entity.TaskUndoEntry, now time.Time) error {
+	task, err := s.ITaskRepository.FindTaskByID(ctx, entry.TaskID.Value())
+	if err != nil {
+		return errTaskChanged()
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleAdmin); err != nil {
+		return err
+	}
+	if task.UpdatedAt.After(entry.CreatedAt) {
+		return errTaskChanged()
+	}
+	if err := s.ITaskRepository.DeleteTask(ctx, task.ID.Value(), now); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return s.createHistory(ctx, task.ID, userID, value.TaskHistoryActionDeleted, "", "", now)
+}
+
+func (s *TaskService) replayRestore(ctx context.Context, userID string, id string, now time.Time) error {
+	task, err := s.ITaskRepository.FindTrashedTaskByID(ctx, id)
+	if err != nil {
+		return errTaskChanged()
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleAdmin); err != nil {
+		return err
+	}
+	if task.ParentID != nil {
+		if _, err := s.ITaskRepository.FindTaskByID(ctx, task.ParentID.Value()); err != nil {
+			return &domain.ErrPreconditionFailed{Msg: "parent task is in trash"}
+		}
+	}
+	if err := s.ITaskRepository.RestoreTask(ctx, id, *task.DeletedAt); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return s.createHistory(ctx, task.ID, userID, value.TaskHistoryActionRestored, "", "", now)
+}
+
+// fnを1つのトランザクションで実行する。トランザクションの開始や確定に失敗した場合はクエリエラーを返す
+func (s *TaskService) runInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	var fnErr error
+	err := s.ITransactionManager.RunInTx(ctx, func(ctx context.Context) error {
+		fnErr = fn(ctx)
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+// タスクの項目の変更を保存し、変更前の値と変更後の値を取り消しのスタックに積む
+func (s *TaskService) updateTaskField(ctx context.Context, userID string, task *entity.Task, action value.TaskHistoryAction, oldValue string) error {
+	return s.runInTx(ctx, func(ctx context.Context) error {
+		if err := s.ITaskRepository.UpdateTask(ctx, task); err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		return s.pushUndo(ctx, userID, task.ID, action, oldValue, taskUndoValue(task, action), nil, task.UpdatedAt)
+	})
+}
+
+// parentIDのタスクをタスクの親にできるかを判定する
+func (s *TaskService) checkTaskParent(ctx context.Context, task *entity.Task, userID string, parentID string) error {
+	if task.ID.Equal(parentID) {
+		return &domain.ErrValidationFailed{Msg: "task cannot be its own parent"}
+	}
+	parent, err := s.ITaskRepository.FindTaskByID(ctx, parentID)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "parent task not found"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeTask(ctx, parent, userID, value.RoleEditor); err != nil {
+		return err
+	}
+	// サブタスクは親タスクと同じリストに属する
+	if !parent.ListID.Equal(task.ListID.Value()) {
+		return &domain.ErrValidationFailed{Msg: "parent task is in another list"}
+	}
+	// 移動先の祖先に自分自身が含まれる場合は循環するため移動できない
+	ancestorIDs, err := s.ITaskRepository.FindAncestorIDs(ctx, parentID)
+	if err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	for _, v := range ancestorIDs {
+		if task.ID.Equal(v) {
+			return &domain.ErrValidationFailed{Msg: "task cannot be moved under its own subtree"}
+		}
+	}
+	if parent.Status.IsClosed() && !task.Status.IsClosed() {
+		return &domain.ErrPreconditionFailed{Msg: "parent task is completed"}
+	}
+	return nil
+}
+
+// タスクをlistIDのリストに移動できるかを判定する
+func (s *TaskService) checkTargetList(ctx context.Context, task *entity.Task, userID string, listID string) error {
+	list, err := s.findList(ctx, listID, userID, value.RoleEditor)
+	if err != nil {
+		return err
+	}
+	// タスクはリストの所有者が所有するため、別のユーザーのリストには移動できない
+	if !list.UserID.Equal(task.UserID.Value()) {
+		return &domain.ErrPreconditionFailed{Msg: "list belongs to another user"}
+	}
+	if list.IsArchived {
+		return &domain.ErrPreconditionFailed{Msg: "list is archived"}
+	}
+	return nil
+}
+
+// assigneeIDのユーザーをタスクの担当者にできるかを判定する。担当者はタスクを閲覧できるユーザーに限る
+func (s *TaskService) checkTaskAssignee(ctx context.Context, task *entity.Task, assigneeID string) error {
+	role, err := s.IAuthorizationPolicy.TaskRole(ctx, task, assigneeID)
+	if err != nil {
+		return err
+	}
+	if !role.Includes(value.RoleViewer) {
+		return &domain.ErrPreconditionFailed{Msg: "assignee cannot access the task"}
+	}
+	return nil
+}
+
+// ブロッカーが既に自分自身に依存している場合は循環するため追加できない
+func (s *TaskService) checkTaskDependencyCycle(ctx context.Context, id string, blockerID string) error {
+	blockerIDs, err := s.ITaskRepository.FindBlockerIDs(ctx, blockerID)
+	if err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	for _, v := range blockerIDs {
+		if v == id {
+			return &domain.ErrValidationFailed{Msg: "task dependency would create a cycle"}
+		}
+	}
+	return nil
+}
+
+// タスクをstatusに変更できるかをサブタスク、ブロッカー、親タスクの状態から判定する
+func (s *TaskService) checkTaskStatusChange(ctx context.Context, task *entity.Task, status value.TaskStatus) error {
+	// 未完了のサブタスクが残っている場合は完了または中止にできない
+	if status.IsClosed() {
+		openCount, err := s.ITaskRepository.CountOpenDescendants(ctx, task.ID.Value())
+		if err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		if openCount > 0 {
+			return &domain.ErrPreconditionFailed{Msg: "task has open subtasks"}
+		}
+	}
+	// 未完了のブロッカーが残っている場合は開始または完了にできない
+	if status == value.TaskStatusInProgress || status == value.TaskStatusDone {
+		blockerCount, err := s.ITaskRepository.CountOpenBlockers(ctx, task.ID.Value())
+		if err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		if blockerCount > 0 {
+			return &domain.ErrPreconditionFailed{Msg: "task is blocked by open tasks"}
+		}
+	}
+	// 完了または中止した親タスクの下のサブタスクは再開できない
+	if task.Status.IsClosed() && !status.IsClosed() && task.ParentID != nil {
+		parent, err := s.ITaskRepository.FindTaskByID(ctx, task.ParentID.Value())
+		if err != nil {
+			return &domain.ErrNotFound{Msg: "parent task not found"}
+		}
+		if parent.Status.IsClosed() {
+			return &domain.ErrPreconditionFailed{Msg: "parent task is completed"}
+		}
+	}
+	return nil
+}
+
+// タスクの変更履歴を記録する
+func (s *TaskService) createHistory(ctx context.Context, taskID *value.ID, actorID string, action value.TaskHistoryAction, oldValue string, newValue string, now time.Time) error {
+	history := &entity.TaskHistory{
+		ID:        value.NewID(s.IIDManager.GenerateID()),
+		TaskID:    taskID,
+		ActorID:   value.NewID(actorID),
+		Action:    action,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+		CreatedAt: now,
+	}
+	if err := history.Validate(); err != nil {
+		return err
+	}
+	if err := s.ITaskHistoryRepository.CreateTaskHistory(ctx, history); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+// 操作を取り消しのスタックに積む。新しい操作を積むとやり直しのスタックは空になる
+func (s *TaskService) pushUndo(ctx context.Context, userID string, taskID *value.ID, action value.TaskHistoryAction, oldValue string, newValue string, nextTaskID *value.ID, now time.Time) error {
+	if err := s.ITaskUndoRepository.DeleteTaskUndoEntries(ctx, userID, value.UndoStackRedo); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return s.createUndoEntry(ctx, &entity.TaskUndoEntry{
+		ID:         value.NewID(s.IIDManager.GenerateID()),
+		UserID:     value.NewID(userID),
+		Stack:      value.UndoStackUndo,
+		TaskID:     taskID,
+		Action:     action,
+		OldValue:   oldValue,
+		NewValue:   newValue,
+		NextTaskID: nextTaskID,
+		CreatedAt:  now,
+	})
+}
+
+// 取り消しまたはやり直しの対象の項目が操作の後に変わっている場合のエラー
+func errTaskChanged() error {
+	return &domain.ErrPreconditionFailed{Msg: "task has changed since the operation"}
+}
+
+// 取り消しのスタックに記録する説明文の値
+type taskDescriptionUndoValue struct {
+	Description     string `json:"description"`
+	DescriptionHTML string `json:"description_html"`
+}
+
+// 取り消しのスタックに記録する繰り返しの値
+type taskRecurrenceUndoValue struct {
+	Rule     string `json:"rule"`
+	Timezone string `json:"timezone"`
+}
+
+// 取り消しのスタックに記録するリストと位置の値
+type taskListUndoValue struct {
+	ListID   string `json:"list_id"`
+	Position string `json:"position"`
+}
+
+// actionで変更するタスクの項目の現在の値を、取り消しのスタックに記録する形式で返す
+func taskUndoValue(task *entity.Task, action value.TaskHistoryAction) string {
+	switch action {
+	case value.TaskHistoryActionPriorityChanged:
+		return strconv.Itoa(int(task.Priority.Value()))
+	case value.TaskHistoryActionDescriptionChanged:
+		return encodeUndoValue(taskDescriptionUndoValue{Description: task.Description, DescriptionHTML: task.DescriptionHTML})
+	case value.TaskHistoryActionAssigneeChanged:
+		if task.AssigneeID == nil {
+			return ""
+		}
+		return task.AssigneeID.Value()
+	case value.TaskHistoryActionDueDateChanged:
+		if task.DueAt == nil {
+			return ""
+		}
+		// データベースにはマイクロ秒までしか保存されないため、保存した後の値と一致するように切り捨てる
+		return task.DueAt.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano)
+	case value.TaskHistoryActionRecurrenceChanged:
+		return encodeUndoValue(taskRecurrenceUndoValue{Rule: task.RecurrenceRule.Value(), Timezone: task.RecurrenceTimezone})
+	case value.TaskHistoryActionParentChanged:
+		if task.ParentID == nil {
+			return ""
+		}
+		return task.ParentID.Value()
+	default:
+		return ""
+	}
+}
+
+// 取り消しのスタックに記録した値を、actionで変更するタスクの項目に設定する
+func setTaskUndoValue(task *entity.Task, action value.TaskHistoryAction, v string) error {
+	switch action {
+	case value.TaskHistoryActionPriorityChanged:
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return &domain.ErrValidationFailed{Msg: "invalid undo value"}
+		}
+		task.Priority = value.Priority(n)
+	case value.TaskHistoryActionDescriptionChanged:
+		var d taskDescriptionUndoValue
+		if err := json.Unmarshal([]byte(v), &d); err != nil {
+			return &domain.ErrValidationFailed{Msg: "invalid undo value"}
+		}
+		task.Description = d.Description
+		task.DescriptionHTML = d.DescriptionHTML
+	case value.TaskHistoryActionAssigneeChanged:
+		task.AssigneeID = nil
+		if v != "" {
+			task.AssigneeID = value.NewID(v)
+		}
+	case value.TaskHistoryActionDueDateChanged:
+		task.DueAt = nil
+		if v != "" {
+			due, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				return &domain.ErrValidationFailed{Msg: "invalid undo value"}
+			}
+			task.DueAt = &due
+		}
+	case value.TaskHistoryActionRecurrenceChanged:
+		var r taskRecurrenceUndoValue
+		if err := json.Unmarshal([]byte(v), &r); err != nil {
+			return &domain.ErrValidationFailed{Msg: "invalid undo value"}
+		}
+		task.RecurrenceRule = value.RecurrenceRule(r.Rule)
+		task.RecurrenceTimezone = r.Timezone
+	case value.TaskHistoryActionParentChanged:
+		task.ParentID = nil
+		if v != "" {
+			task.ParentID = value.NewID(v)
+		}
+	}
+	return nil
+}
+
+func encodeTaskListUndoValue(listID string, position value.Rank) string {
+	return encodeUndoValue(taskListUndoValue{ListID: listID, Position: position.Value()})
+}
+
+func decodeTaskListUndoValue(v string) (string, value.Rank, error) {
+	var l taskListUndoValue
+	if err := json.Unmarshal([]byte(v), &l); err != nil {
+		return "", "", &domain.ErrValidationFailed{Msg: "invalid undo value"}
+	}
+	return l.ListID, value.Rank(l.Position), nil
+}
+
+// 取り消しのスタックに記録する複数の項目の値をJSONにする。文字列のみの構造体は変換に失敗しない
+func encodeUndoValue(v any) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// 操作をスタックに積み、上限を超えた古い操作と期限切れの操作を削除する
+func (s *TaskService) createUndoEntry(ctx context.Context, entry *entity.TaskUndoEntry) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+	if err := s.ITaskUndoRepository.CreateTaskUndoEntry(ctx, entry); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	if err := s.ITaskUndoRepository.TrimTaskUndoEntries(ctx, entry.UserID.Value(), entry.Stack, undoStackLimit, entry.CreatedAt.Add(-undoExpiry)); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+// 繰り返しのタスクの次の回を作成する。繰り返しが終了している場合はnilを返す
+// 次の回の期限は現在の期限、期限がない場合は現在時刻をタスクのタイムゾーンで基準にして求める
+func (s *TaskService) nextOccurrence(ctx context.Context, task *entity.Task) (*entity.Task, error) {
+	loc, err := s.IClockManager.LoadLocation(task.RecurrenceTimezone)
+	if err != nil {
+		return nil, &domain.ErrValidationFailed{Msg: "invalid timezone"}
+	}
+	now := s.IClockManager.GetNow()
+	base := now
+	if task.DueAt != nil {
+		base = *task.DueAt
+	}
+	dueAt, ok, err := task.RecurrenceRule.Next(base.In(loc))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	rule, err := task.RecurrenceRule.NextRule()
+	if err != nil {
+		return nil, err
+	}
+	// 次の回は完了したタスクの直後に並べる
+	after, err := s.ITaskRepository.FindNextTaskPosition(ctx, task.ListID.Value(), task.ID.Value(), task.Position)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	position, err := value.RankBetween(task.Position, after)
+	if err != nil {
+		return nil, err
+	}
+	due := dueAt.UTC()
+	next := &entity.Task{
+		ID:                 value.NewID(s.IIDManager.GenerateID()),
+		UserID:             task.UserID,
+		Name:               task.Name,
+		Status:             value.TaskStatusTodo,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+		DueAt:              &due,
+		Priority:           task.Priority,
+		Description:        task.Description,
+		DescriptionHTML:    task.DescriptionHTML,
+		ParentID:           task.ParentID,
+		ListID:             task.ListID,
+		Position:           position,
+		RecurrenceRule:     rule,
+		RecurrenceTimezone: task.RecurrenceTimezone,
+		AssigneeID:         task.AssigneeID,
+	}
+	if err := next.Validate(); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// 状態遷移表に従ってfromからtoに遷移できるか判定する
+func canTransition(from value.TaskStatus, to value.TaskStatus) bool {
+	for _, v := range taskStatusTransitions[from] {
+		if v == to {
+			return true
+		}
+	}
+	return false
+}
+
+// 個人のタスクを絞り込んでページ単位で取得する。タスク一覧と保存済みのビューで共通の処理
+func findTaskPage(ctx context.Context, repo repository.ITaskRepository, userID string, filter *value.TaskFilter, sort value.TaskSort, limit int32, cursor *value.TaskPageCursor) ([]*entity.Task, *value.TaskPageCursor, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := sort.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if limit <= 0 {
+		return nil, nil, &domain.ErrValidationFailed{Msg: "limit must be positive"}
+	}
+	// 別の並び順で作成された位置からは続きを取得できない
+	if cursor != nil && cursor.Sort != sort {
+		return nil, nil, &domain.ErrValidationFailed{Msg: "page token does not match the sort order"}
+	}
+	// 1件多く取得して続きがあるかを判定する
+	tasks, err := repo.FindTaskPage(ctx, userID, filter, sort, limit+1, cursor)
+	if err != nil {
+		return nil, nil, &domain.ErrQueryFailed{}
+	}
+	var next *value.TaskPageCursor
+	if int32(len(tasks)) > limit {
+		tasks = tasks[:limit]
+		last := tasks[limit-1]
+		next = &value.TaskPageCursor{Sort: sort, ID: last.ID.Value()}
+		switch sort.Field {
+		case value.TaskSortFieldCreatedAt:
+			next.Time = last.CreatedAt
+		case value.TaskSortFieldName:
+			next.Name = last.Name
+		case value.TaskSortFieldPriority:
+			next.Priority = last.Priority
+		default:
+			next.Time = last.UpdatedAt
+		}
+	}
+	tasks, err = markBlocked(ctx, repo, tasks)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tasks, next, nil
+}
+
+// 未完了のブロッカーが残っているタスクにIsBlockedを設定する
+func markBlocked(ctx context.Context, repo repository.ITaskRepository, tasks []*entity.Task) ([]*entity.Task, error) {
+	if len(tasks) == 0 {
+		return tasks, nil
+	}
+	ids := make([]string, len(tasks))
+	for i, v := range tasks {
+		ids[i] = v.ID.Value()
+	}
+	blockedIDs, err := repo.FindBlockedTaskIDs(ctx, ids)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	blocked := make(map[string]bool, len(blockedIDs))
+	for _, v := range blockedIDs {
+		blocked[v] = true
+	}
+	for _, v := range tasks {
+		v.IsBlocked = blocked[v.ID.Value()]
+	}
+	return tasks, nil
+}
+
+// リストの先頭に並ぶ位置を求める
+func (s *TaskService) topPosition(ctx context.Context, listID string) (value.Rank, error) {
+	first, err := s.ITaskRepository.FindMinTaskPosition(ctx, listID)
+	if err != nil {
+		return "", &domain.ErrQueryFailed{}
+	}
+	return value.RankBetween("", first)
+}
+
+// 指定したリストを取得し、ユーザーがrequired以上の権限を持つことを検証する
+func (s *TaskService) findList(ctx context.Context, listID string, userID string, required value.Role) (*entity.List, error) {
+	if err := value.NewID(listID).Validate(); err != nil {
+		return nil, err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	list, err := s.IListRepository.FindListByID(ctx, listID)
+	if err != nil {
+		return nil, &domain.ErrNotFound{Msg: "list not found"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeList(ctx, list, userID, required); err != nil {
+		return nil, err
+	}
+	return list, nil
 }
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
 	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
 	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,18 +20,69 @@ func TestTaskService_NewTaskService(tt *testing.T) {
 	})
 }
 
+// fnを同じctxでそのまま実行するトランザクション管理のモックを作成する
+func newTxManagerMock(ctx context.Context) *mocks.ITransactionManager {
+	txm := new(mocks.ITransactionManager)
+	txm.On("RunInTx", ctx, mock.AnythingOfType("func(context.Context) error")).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	return txm
+}
+
+// セーブポイントでもfnを同じctxでそのまま実行するトランザクション管理のモックを作成する
+func newSavepointTxManagerMock(ctx context.Context) *mocks.ITransactionManager {
+	txm := newTxManagerMock(ctx)
+	txm.On("RunInSavepoint", ctx, mock.AnythingOfType("func(context.Context) error")).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	return txm
+}
+
+// 取り消しのスタックへの記録を全て受け付けるリポジトリのモックを作成する
+func newUndoRepoMock(ctx context.Context) *mocks.ITaskUndoRepository {
+	ur := new(mocks.ITaskUndoRepository)
+	ur.On("DeleteTaskUndoEntries", ctx, mock.Anything, value.UndoStackRedo).Return(nil).Maybe()
+	ur.On("CreateTaskUndoEntry", ctx, mock.AnythingOfType("*entity.TaskUndoEntry")).Return(nil).Maybe()
+	ur.On("TrimTaskUndoEntries", ctx, mock.Anything, mock.Anything, int32(undoStackLimit), mock.Anything).Return(nil).Maybe()
+	return ur
+}
+
+// entryを取り消しのスタックに積むことを期待するリポジトリのモックを作成する
+func newPushUndoRepoMock(ctx context.Context, entry *entity.TaskUndoEntry) *mocks.ITaskUndoRepository {
+	ur := new(mocks.ITaskUndoRepository)
+	ur.On("DeleteTaskUndoEntries", ctx, entry.UserID.Value(), value.UndoStackRedo).Return(nil)
+	ur.On("CreateTaskUndoEntry", ctx, entry).Return(nil)
+	ur.On("TrimTaskUndoEntries", ctx, entry.UserID.Value(), value.UndoStackUndo, int32(undoStackLimit), entry.CreatedAt.Add(-undoExpiry)).Return(nil)
+	return ur
+}
+
+// entryを取り出して反映し、反対のスタックに積むことを期待するリポジトリのモックを作成する
+func newReplayUndoRepoMock(ctx context.Context, entry *entity.TaskUndoEntry, now time.Time) *mocks.ITaskUndoRepository {
+	to := value.UndoStackUndo
+	if entry.Stack == value.UndoStackUndo {
+		to = value.UndoStackRedo
+	}
+	ur := new(mocks.ITaskUndoRepository)
+	ur.On("PopTaskUndoEntry", ctx, entry.UserID.Value(), entry.Stack, now.Add(-undoExpiry)).Return(entry, nil)
+	ur.On("CreateTaskUndoEntry", ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: entry.UserID, Stack: to, TaskID: entry.TaskID, Action: entry.Action, OldValue: entry.OldValue, NewValue: entry.NewValue, CreatedAt: now}).Return(nil)
+	ur.On("TrimTaskUndoEntries", ctx, entry.UserID.Value(), to, int32(undoStackLimit), now.Add(-undoExpiry)).Return(nil)
+	return ur
+}
+
 func TestTaskService_FindTaskByID(tt *testing.T) {
 	ctx := context.Background()
 	now := time.Now().UTC()
 	id := "id"
 	uid := "uid"
 	task := &entity.Task{
-		ID:          value.NewID(id),
-		UserID:      value.NewID(uid),
-		Name:        "task",
-		IsCompleted: false,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:        value.NewID(id),
+		UserID:    value.NewID(uid),
+		ListID:    value.NewID("lid"),
+		Position:  "i",
+		Name:      "task",
+		Status:    value.TaskStatusTodo,
+		CreatedAt: now,
+		UpdatedAt: now,
 	}
 
 	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
@@ -37,14 +90,14 @@ func TestTaskService_FindTaskByID(tt *testing.T) {
 		repo.On("FindTaskByID", ctx, id).Return(task, nil)
 		im := new(mocks.IIDManager)
 		cm := new(mocks.IClockManager)
-		srv := NewTaskService(repo, im, cm)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
 		ret, err := srv.FindTaskByID(ctx, id)
 
 		require.NoError(t, err, "エラーが発生しないこと")
 		require.Equal(t, id, ret.ID.Value())
 		require.Equal(t, task.UserID.Value(), ret.UserID.Value())
 		require.Equal(t, task.Name, ret.Name)
-		require.Equal(t, task.IsCompleted, ret.IsCompleted)
+		require.Equal(t, task.Status, ret.Status)
 		require.Equal(t, task.CreatedAt, ret.CreatedAt)
 		require.Equal(t, task.UpdatedAt, ret.UpdatedAt)
 		repo.AssertExpectations(t)
@@ -57,7 +110,7 @@ func TestTaskService_FindTaskByID(tt *testing.T) {
 		repo := new(mocks.ITaskRepository)
 		im := new(mocks.IIDManager)
 		cm := new(mocks.IClockManager)
-		srv := NewTaskService(repo, im, cm)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
 		_, err := srv.FindTaskByID(ctx, id)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
@@ -72,7 +125,7 @@ func TestTaskService_FindTaskByID(tt *testing.T) {
 		repo.On("FindTaskByID", ctx, id).Return(nil, errExp)
 		im := new(mocks.IIDManager)
 		cm := new(mocks.IClockManager)
-		srv := NewTaskService(repo, im, cm)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
 		_, err := srv.FindTaskByID(ctx, id)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
@@ -87,16 +140,17 @@ func TestTaskService_FindTasksByUserID(tt *testing.T) {
 	now := time.Now().UTC()
 	uid := "uid"
 	tasks := []*entity.Task{
-		{ID: value.NewID("t1"), UserID: value.NewID(uid), Name: "task1", IsCompleted: false, CreatedAt: now, UpdatedAt: now},
-		{ID: value.NewID("t2"), UserID: value.NewID(uid), Name: "task2", IsCompleted: false, CreatedAt: now, UpdatedAt: now},
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task1", CreatedAt: now, UpdatedAt: now},
+		{ID: value.NewID("t2"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task2", CreatedAt: now, UpdatedAt: now},
 	}
 
 	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
 		repo := new(mocks.ITaskRepository)
 		repo.On("FindTasksByUserID", ctx, uid).Return(tasks, nil)
+		repo.On("FindBlockedTaskIDs", ctx, []string{"t1", "t2"}).Return([]string{}, nil)
 		im := new(mocks.IIDManager)
 		cm := new(mocks.IClockManager)
-		srv := NewTaskService(repo, im, cm)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
 		ret, err := srv.FindTasksByUserID(ctx, uid)
 
 		require.NoError(t, err, "エラーが発生しないこと")
@@ -105,13 +159,41 @@ func TestTaskService_FindTasksByUserID(tt *testing.T) {
 		im.AssertExpectations(t)
 		cm.AssertExpectations(t)
 	})
+	tt.Run("正常系: 未完了のブロッカーが残っているタスクが設定されること", func(t *testing.T) {
+		tasks := []*entity.Task{
+			{ID: value.NewID("t1"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task1", CreatedAt: now, UpdatedAt: now},
+			{ID: value.NewID("t2"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "r", Name: "task2", CreatedAt: now, UpdatedAt: now},
+		}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTasksByUserID", ctx, uid).Return(tasks, nil)
+		repo.On("FindBlockedTaskIDs", ctx, []string{"t1", "t2"}).Return([]string{"t2"}, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		ret, err := srv.FindTasksByUserID(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Len(t, ret, 2)
+		require.False(t, ret[0].IsBlocked, "ブロックされていないこと")
+		require.True(t, ret[1].IsBlocked, "ブロックされていること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: ブロッカーの取得でクエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTasksByUserID", ctx, uid).Return(tasks, nil)
+		repo.On("FindBlockedTaskIDs", ctx, []string{"t1", "t2"}).Return(nil, errExp)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindTasksByUserID(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
 	tt.Run("準正常系: UserIDが空の場合", func(t *testing.T) {
 		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
 		uid := ""
 		repo := new(mocks.ITaskRepository)
 		im := new(mocks.IIDManager)
 		cm := new(mocks.IClockManager)
-		srv := NewTaskService(repo, im, cm)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
 		_, err := srv.FindTasksByUserID(ctx, uid)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
@@ -126,7 +208,7 @@ func TestTaskService_FindTasksByUserID(tt *testing.T) {
 		repo.On("FindTasksByUserID", ctx, uid).Return(nil, errExp)
 		im := new(mocks.IIDManager)
 		cm := new(mocks.IClockManager)
-		srv := NewTaskService(repo, im, cm)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
 		_, err := srv.FindTasksByUserID(ctx, uid)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
@@ -140,63 +222,240 @@ func TestTaskService_CreateTask(tt *testing.T) {
 	ctx := context.Background()
 	id := "id"
 	uid := "uid"
+	lid := "lid"
 	now := time.Now().UTC()
 	task := &entity.Task{
-		ID:          value.NewID(id),
-		UserID:      value.NewID(uid),
-		Name:        "task",
-		IsCompleted: false,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:        value.NewID(id),
+		UserID:    value.NewID(uid),
+		ListID:    value.NewID(lid),
+		Position:  "i",
+		Name:      "task",
+		Status:    value.TaskStatusTodo,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	list := &entity.List{ID: value.NewID(lid), UserID: value.NewID(uid), Name: "list", CreatedAt: now, UpdatedAt: now}
+	history := &entity.TaskHistory{
+		ID:        value.NewID(id),
+		TaskID:    value.NewID(id),
+		ActorID:   value.NewID(uid),
+		Action:    value.TaskHistoryActionCreated,
+		NewValue:  "task",
+		CreatedAt: now,
 	}
 
 	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
 		repo := new(mocks.ITaskRepository)
+		repo.On("FindMinTaskPosition", ctx, lid).Return(value.Rank(""), nil)
 		repo.On("CreateTask", ctx, task).Return(id, nil)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(list, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, history).Return(nil)
+		srv := NewTaskService(repo, listRepo, hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		ret, err := srv.CreateTask(ctx, uid, lid, task.Name)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, id, ret)
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("正常系: ListIDが空の場合はInboxに作成すること", func(t *testing.T) {
+		inbox := &entity.List{ID: value.NewID("inbox"), UserID: value.NewID(uid), Name: entity.InboxListName, IsInbox: true, CreatedAt: now, UpdatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindMinTaskPosition", ctx, "inbox").Return(value.Rank(""), nil)
+		repo.On("CreateTask", ctx, &entity.Task{ID: task.ID, UserID: task.UserID, ListID: inbox.ID, Position: "i", Name: task.Name, CreatedAt: now, UpdatedAt: now}).Return(id, nil)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindInboxByUserID", ctx, uid).Return(inbox, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, history).Return(nil)
+		srv := NewTaskService(repo, listRepo, hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		ret, err := srv.CreateTask(ctx, uid, "", task.Name)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, id, ret)
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("正常系: Inboxが存在しない場合は作成すること", func(t *testing.T) {
+		inbox := &entity.List{ID: value.NewID("inbox"), UserID: value.NewID(uid), Name: entity.InboxListName, IsInbox: true, CreatedAt: now, UpdatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindMinTaskPosition", ctx, "inbox").Return(value.Rank(""), nil)
+		repo.On("CreateTask", ctx, &entity.Task{ID: task.ID, UserID: task.UserID, ListID: inbox.ID, Position: "i", Name: task.Name, CreatedAt: now, UpdatedAt: now}).Return(id, nil)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindInboxByUserID", ctx, uid).Return(nil, &domain.ErrNotFound{})
+		listRepo.On("CreateList", ctx, inbox).Return("inbox", nil)
 		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("inbox").Once()
 		im.On("GenerateID").Return(id)
 		cm := new(mocks.IClockManager)
 		cm.On("GetNow").Return(now)
-		srv := NewTaskService(repo, im, cm)
-		ret, err := srv.CreateTask(ctx, uid, task.Name)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, history).Return(nil)
+		srv := NewTaskService(repo, listRepo, hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		ret, err := srv.CreateTask(ctx, uid, "", task.Name)
 
 		require.NoError(t, err, "エラーが発生しないこと")
 		require.Equal(t, id, ret)
 		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+		hr.AssertExpectations(t)
 		im.AssertExpectations(t)
 		cm.AssertExpectations(t)
 	})
 	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
 		errExp := &domain.ErrValidationFailed{Msg: "name is empty"}
 		repo := new(mocks.ITaskRepository)
+		repo.On("FindMinTaskPosition", ctx, lid).Return(value.Rank(""), nil)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(list, nil)
 		im := new(mocks.IIDManager)
 		im.On("GenerateID").Return(id)
 		cm := new(mocks.IClockManager)
 		cm.On("GetNow").Return(now)
-		srv := NewTaskService(repo, im, cm)
-		_, err := srv.CreateTask(ctx, uid, "")
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.CreateTask(ctx, uid, lid, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないListIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "list not found"}
+		repo := new(mocks.ITaskRepository)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, "another").Return(nil, errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.CreateTask(ctx, uid, "another", task.Name)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのリストの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(&entity.List{ID: value.NewID(lid), UserID: value.NewID("another"), Name: "list", CreatedAt: now, UpdatedAt: now}, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.CreateTask(ctx, uid, lid, task.Name)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: アーカイブされたリストの場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "list is archived"}
+		repo := new(mocks.ITaskRepository)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(&entity.List{ID: value.NewID(lid), UserID: value.NewID(uid), Name: "list", IsArchived: true, CreatedAt: now, UpdatedAt: now}, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.CreateTask(ctx, uid, lid, task.Name)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
 		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
 		im.AssertExpectations(t)
 		cm.AssertExpectations(t)
 	})
 	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
 		errExp := &domain.ErrQueryFailed{}
 		repo := new(mocks.ITaskRepository)
+		repo.On("FindMinTaskPosition", ctx, lid).Return(value.Rank(""), nil)
 		repo.On("CreateTask", ctx, task).Return("", errExp)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(list, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.CreateTask(ctx, uid, lid, task.Name)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 履歴の作成に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindMinTaskPosition", ctx, lid).Return(value.Rank(""), nil)
+		repo.On("CreateTask", ctx, task).Return(id, nil)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(list, nil)
 		im := new(mocks.IIDManager)
 		im.On("GenerateID").Return(id)
 		cm := new(mocks.IClockManager)
 		cm.On("GetNow").Return(now)
-		srv := NewTaskService(repo, im, cm)
-		_, err := srv.CreateTask(ctx, uid, task.Name)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, history).Return(errors.New("failed"))
+		srv := NewTaskService(repo, listRepo, hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.CreateTask(ctx, uid, lid, task.Name)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
 		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+		hr.AssertExpectations(t)
 		im.AssertExpectations(t)
 		cm.AssertExpectations(t)
 	})
+	tt.Run("準正常系: トランザクションの確定に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindMinTaskPosition", ctx, lid).Return(value.Rank(""), nil)
+		repo.On("CreateTask", ctx, task).Return(id, nil)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(list, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, history).Return(nil)
+		txm := new(mocks.ITransactionManager)
+		txm.On("RunInTx", ctx, mock.AnythingOfType("func(context.Context) error")).Return(func(ctx context.Context, fn func(context.Context) error) error {
+			if err := fn(ctx); err != nil {
+				return err
+			}
+			return errors.New("failed to commit")
+		})
+		srv := NewTaskService(repo, listRepo, hr, newUndoRepoMock(ctx), txm, newUnsharedPolicy(), im, cm)
+		_, err := srv.CreateTask(ctx, uid, lid, task.Name)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		txm.AssertExpectations(t)
+	})
 }
 
 func TestTaskService_ChangeTaskName(tt *testing.T) {
@@ -205,53 +464,63 @@ func TestTaskService_ChangeTaskName(tt *testing.T) {
 	uid := "uid"
 	now := time.Now().UTC()
 	task := &entity.Task{
-		ID:          value.NewID(id),
-		UserID:      value.NewID(uid),
-		Name:        "task",
-		IsCompleted: false,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:        value.NewID(id),
+		UserID:    value.NewID(uid),
+		ListID:    value.NewID("lid"),
+		Position:  "i",
+		Name:      "task",
+		Status:    value.TaskStatusTodo,
+		CreatedAt: now,
+		UpdatedAt: now,
 	}
 	upd := now.Add(time.Second)
 	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
 		arg := &entity.Task{
-			ID:          task.ID,
-			UserID:      task.UserID,
-			Name:        "new task",
-			IsCompleted: false,
-			CreatedAt:   task.CreatedAt,
-			UpdatedAt:   upd,
+			ID:        task.ID,
+			UserID:    task.UserID,
+			ListID:    task.ListID,
+			Position:  task.Position,
+			Name:      "new task",
+			Status:    value.TaskStatusTodo,
+			CreatedAt: task.CreatedAt,
+			UpdatedAt: upd,
 		}
 		repo := new(mocks.ITaskRepository)
 		repo.On("FindTaskByID", ctx, id).Return(task, nil)
 		repo.On("UpdateTask", ctx, arg).Return(nil)
 		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
 		cm := new(mocks.IClockManager)
 		cm.On("GetNow").Return(upd)
-		srv := NewTaskService(repo, im, cm)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: task.ID, ActorID: task.UserID, Action: value.TaskHistoryActionRenamed, OldValue: "task", NewValue: "new task", CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
 		err := srv.ChangeTaskName(ctx, arg.ID.Value(), arg.UserID.Value(), arg.Name)
 
 		require.NoError(t, err, "エラーが発生しないこと")
 		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
 		im.AssertExpectations(t)
 		cm.AssertExpectations(t)
 	})
 	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
 		errExp := &domain.ErrValidationFailed{Msg: "name is empty"}
 		arg := &entity.Task{
-			ID:          task.ID,
-			UserID:      task.UserID,
-			Name:        "",
-			IsCompleted: false,
-			CreatedAt:   task.CreatedAt,
-			UpdatedAt:   upd,
+			ID:        task.ID,
+			UserID:    task.UserID,
+			ListID:    task.ListID,
+			Position:  task.Position,
+			Name:      "",
+			Status:    value.TaskStatusTodo,
+			CreatedAt: task.CreatedAt,
+			UpdatedAt: upd,
 		}
 		repo := new(mocks.ITaskRepository)
 		repo.On("FindTaskByID", ctx, id).Return(task, nil)
 		im := new(mocks.IIDManager)
 		cm := new(mocks.IClockManager)
 		cm.On("GetNow").Return(upd)
-		srv := NewTaskService(repo, im, cm)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
 		err := srv.ChangeTaskName(ctx, arg.ID.Value(), arg.UserID.Value(), arg.Name)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
@@ -262,18 +531,20 @@ func TestTaskService_ChangeTaskName(tt *testing.T) {
 	tt.Run("準正常系: 存在しないTaskIDの場合", func(t *testing.T) {
 		errExp := &domain.ErrNotFound{Msg: "task not found"}
 		arg := &entity.Task{
-			ID:          value.NewID("another"),
-			UserID:      task.UserID,
-			Name:        "new task",
-			IsCompleted: false,
-			CreatedAt:   task.CreatedAt,
-			UpdatedAt:   upd,
+			ID:        value.NewID("another"),
+			UserID:    task.UserID,
+			ListID:    task.ListID,
+			Position:  task.Position,
+			Name:      "new task",
+			Status:    value.TaskStatusTodo,
+			CreatedAt: task.CreatedAt,
+			UpdatedAt: upd,
 		}
 		repo := new(mocks.ITaskRepository)
 		repo.On("FindTaskByID", ctx, arg.ID.Value()).Return(nil, errExp)
 		im := new(mocks.IIDManager)
 		cm := new(mocks.IClockManager)
-		srv := NewTaskService(repo, im, cm)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
 		err := srv.ChangeTaskName(ctx, arg.ID.Value(), arg.UserID.Value(), arg.Name)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
@@ -284,18 +555,20 @@ func TestTaskService_ChangeTaskName(tt *testing.T) {
 	tt.Run("準正常系: アクセス権がない場合", func(t *testing.T) {
 		errExp := &domain.ErrPermissionDenied{}
 		arg := &entity.Task{
-			ID:          task.ID,
-			UserID:      value.NewID("another"),
-			Name:        "new task",
-			IsCompleted: false,
-			CreatedAt:   task.CreatedAt,
-			UpdatedAt:   upd,
+			ID:        task.ID,
+			UserID:    value.NewID("another"),
+			ListID:    value.NewID("lid"),
+			Position:  "i",
+			Name:      "new task",
+			Status:    value.TaskStatusTodo,
+			CreatedAt: task.CreatedAt,
+			UpdatedAt: upd,
 		}
 		repo := new(mocks.ITaskRepository)
 		repo.On("FindTaskByID", ctx, id).Return(task, nil)
 		im := new(mocks.IIDManager)
 		cm := new(mocks.IClockManager)
-		srv := NewTaskService(repo, im, cm)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
 		err := srv.ChangeTaskName(ctx, arg.ID.Value(), arg.UserID.Value(), arg.Name)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
@@ -306,12 +579,14 @@ func TestTaskService_ChangeTaskName(tt *testing.T) {
 	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
 		errExp := &domain.ErrQueryFailed{}
 		arg := &entity.Task{
-			ID:          task.ID,
-			UserID:      task.UserID,
-			Name:        "new task",
-			IsCompleted: false,
-			CreatedAt:   task.CreatedAt,
-			UpdatedAt:   upd,
+			ID:        task.ID,
+			UserID:    task.UserID,
+			ListID:    task.ListID,
+			Position:  task.Position,
+			Name:      "new task",
+			Status:    value.TaskStatusTodo,
+			CreatedAt: task.CreatedAt,
+			UpdatedAt: upd,
 		}
 		repo := new(mocks.ITaskRepository)
 		repo.On("FindTaskByID", ctx, id).Return(task, nil)
@@ -319,7 +594,7 @@ func TestTaskService_ChangeTaskName(tt *testing.T) {
 		im := new(mocks.IIDManager)
 		cm := new(mocks.IClockManager)
 		cm.On("GetNow").Return(upd)
-		srv := NewTaskService(repo, im, cm)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
 		err := srv.ChangeTaskName(ctx, arg.ID.Value(), arg.UserID.Value(), arg.Name)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
@@ -335,25 +610,32 @@ func TestTaskService_DeleteTask(tt *testing.T) {
 	uid := "uid"
 	now := time.Now().UTC()
 	task := &entity.Task{
-		ID:          value.NewID(id),
-		UserID:      value.NewID(uid),
-		Name:        "task",
-		IsCompleted: false,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:        value.NewID(id),
+		UserID:    value.NewID(uid),
+		ListID:    value.NewID("lid"),
+		Position:  "i",
+		Name:      "task",
+		Status:    value.TaskStatusTodo,
+		CreatedAt: now,
+		UpdatedAt: now,
 	}
 
 	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
 		repo := new(mocks.ITaskRepository)
 		repo.On("FindTaskByID", ctx, id).Return(task, nil)
-		repo.On("DeleteTask", ctx, id).Return(nil)
+		repo.On("DeleteTask", ctx, id, now).Return(nil)
 		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
 		cm := new(mocks.IClockManager)
-		srv := NewTaskService(repo, im, cm)
+		cm.On("GetNow").Return(now)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: task.ID, ActorID: task.UserID, Action: value.TaskHistoryActionDeleted, CreatedAt: now}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
 		err := srv.DeleteTask(ctx, id, uid)
 
 		require.NoError(t, err, "エラーが発生しないこと")
 		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
 		im.AssertExpectations(t)
 		cm.AssertExpectations(t)
 	})
@@ -363,7 +645,7 @@ func TestTaskService_DeleteTask(tt *testing.T) {
 		repo := new(mocks.ITaskRepository)
 		im := new(mocks.IIDManager)
 		cm := new(mocks.IClockManager)
-		srv := NewTaskService(repo, im, cm)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
 		err := srv.DeleteTask(ctx, id, uid)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
@@ -377,7 +659,7 @@ func TestTaskService_DeleteTask(tt *testing.T) {
 		repo.On("FindTaskByID", ctx, id).Return(nil, errExp)
 		im := new(mocks.IIDManager)
 		cm := new(mocks.IClockManager)
-		srv := NewTaskService(repo, im, cm)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
 		err := srv.DeleteTask(ctx, id, uid)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
@@ -392,7 +674,7 @@ func TestTaskService_DeleteTask(tt *testing.T) {
 		repo.On("FindTaskByID", ctx, id).Return(task, nil)
 		im := new(mocks.IIDManager)
 		cm := new(mocks.IClockManager)
-		srv := NewTaskService(repo, im, cm)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
 		err := srv.DeleteTask(ctx, id, uid)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
@@ -404,10 +686,11 @@ func TestTaskService_DeleteTask(tt *testing.T) {
 		errExp := &domain.ErrQueryFailed{}
 		repo := new(mocks.ITaskRepository)
 		repo.On("FindTaskByID", ctx, id).Return(task, nil)
-		repo.On("DeleteTask", ctx, id).Return(errExp)
+		repo.On("DeleteTask", ctx, id, now).Return(errExp)
 		im := new(mocks.IIDManager)
 		cm := new(mocks.IClockManager)
-		srv := NewTaskService(repo, im, cm)
+		cm.On("GetNow").Return(now)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
 		err := srv.DeleteTask(ctx, id, uid)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
@@ -417,218 +700,4361 @@ func TestTaskService_DeleteTask(tt *testing.T) {
 	})
 }
 
-func TestTaskService_CompleteTask(tt *testing.T) {
+func TestTaskService_FindTrashedTasksByUserID(tt *testing.T) {
 	ctx := context.Background()
-	id := "id"
 	uid := "uid"
 	now := time.Now().UTC()
-	task := &entity.Task{
-		ID:          value.NewID(id),
-		UserID:      value.NewID(uid),
-		Name:        "task",
-		IsCompleted: false,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+	tasks := []*entity.Task{
+		{
+			ID:        value.NewID("id"),
+			UserID:    value.NewID(uid),
+			ListID:    value.NewID("lid"),
+			Position:  "i",
+			Name:      "task",
+			Status:    value.TaskStatusTodo,
+			CreatedAt: now,
+			UpdatedAt: now,
+			DeletedAt: &now,
+		},
 	}
-	upd := now.Add(time.Second)
 
 	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
-		arg := &entity.Task{
-			ID:          task.ID,
-			UserID:      task.UserID,
-			Name:        task.Name,
-			IsCompleted: true,
-			CreatedAt:   task.CreatedAt,
-			UpdatedAt:   upd,
-		}
 		repo := new(mocks.ITaskRepository)
-		repo.On("FindTaskByID", ctx, id).Return(task, nil)
-		repo.On("UpdateTask", ctx, arg).Return(nil)
-		im := new(mocks.IIDManager)
-		cm := new(mocks.IClockManager)
-		cm.On("GetNow").Return(upd)
-		srv := NewTaskService(repo, im, cm)
-		err := srv.CompleteTask(ctx, id, uid)
+		repo.On("FindTrashedTasksByUserID", ctx, uid).Return(tasks, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		res, err := srv.FindTrashedTasksByUserID(ctx, uid)
 
 		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tasks, res, "ゴミ箱のタスクが取得できること")
 		repo.AssertExpectations(t)
-		im.AssertExpectations(t)
-		cm.AssertExpectations(t)
 	})
 	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
 		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
-		id := ""
 		repo := new(mocks.ITaskRepository)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindTrashedTasksByUserID(ctx, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTrashedTasksByUserID", ctx, uid).Return(nil, errExp)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindTrashedTasksByUserID(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_RestoreTask(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	parentID := "pid"
+	now := time.Now().UTC()
+	deletedAt := now.Add(-time.Hour)
+	newTask := func(parentID *value.ID) *entity.Task {
+		return &entity.Task{
+			ID:        value.NewID(id),
+			UserID:    value.NewID(uid),
+			ListID:    value.NewID("lid"),
+			ParentID:  parentID,
+			Position:  "i",
+			Name:      "task",
+			Status:    value.TaskStatusTodo,
+			CreatedAt: now,
+			UpdatedAt: now,
+			DeletedAt: &deletedAt,
+		}
+	}
+	parent := &entity.Task{
+		ID:        value.NewID(parentID),
+		UserID:    value.NewID(uid),
+		ListID:    value.NewID("lid"),
+		Position:  "a",
+		Name:      "parent",
+		Status:    value.TaskStatusTodo,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	history := &entity.TaskHistory{
+		ID:        value.NewID("hid"),
+		TaskID:    value.NewID(id),
+		ActorID:   value.NewID(uid),
+		Action:    value.TaskHistoryActionRestored,
+		CreatedAt: now,
+	}
+
+	tt.Run("正常系: ルートのタスクの場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTrashedTaskByID", ctx, id).Return(newTask(nil), nil)
+		repo.On("RestoreTask", ctx, id, deletedAt).Return(nil)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, history).Return(nil)
 		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
 		cm := new(mocks.IClockManager)
-		srv := NewTaskService(repo, im, cm)
-		err := srv.CompleteTask(ctx, id, uid)
+		cm.On("GetNow").Return(now)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.RestoreTask(ctx, id, uid)
 
-		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		require.NoError(t, err, "エラーが発生しないこと")
 		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
 		im.AssertExpectations(t)
 		cm.AssertExpectations(t)
 	})
-	tt.Run("準正常系: 存在しないTaskIDの場合", func(t *testing.T) {
-		errExp := &domain.ErrNotFound{Msg: "task not found"}
-		id := "another"
+	tt.Run("正常系: 親タスクがゴミ箱にないサブタスクの場合", func(t *testing.T) {
 		repo := new(mocks.ITaskRepository)
-		repo.On("FindTaskByID", ctx, id).Return(nil, errExp)
+		repo.On("FindTrashedTaskByID", ctx, id).Return(newTask(value.NewID(parentID)), nil)
+		repo.On("FindTaskByID", ctx, parentID).Return(parent, nil)
+		repo.On("RestoreTask", ctx, id, deletedAt).Return(nil)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, history).Return(nil)
 		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
 		cm := new(mocks.IClockManager)
-		srv := NewTaskService(repo, im, cm)
-		err := srv.CompleteTask(ctx, id, uid)
+		cm.On("GetNow").Return(now)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.RestoreTask(ctx, id, uid)
 
-		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		require.NoError(t, err, "エラーが発生しないこと")
 		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
 		im.AssertExpectations(t)
 		cm.AssertExpectations(t)
 	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		repo := new(mocks.ITaskRepository)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.RestoreTask(ctx, "", uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: ゴミ箱に存在しないTaskIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found in trash"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTrashedTaskByID", ctx, id).Return(nil, &domain.ErrNotFound{})
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.RestoreTask(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
 	tt.Run("準正常系: アクセス権がない場合", func(t *testing.T) {
 		errExp := &domain.ErrPermissionDenied{}
-		uid := "another"
 		repo := new(mocks.ITaskRepository)
-		repo.On("FindTaskByID", ctx, id).Return(task, nil)
-		im := new(mocks.IIDManager)
-		cm := new(mocks.IClockManager)
-		srv := NewTaskService(repo, im, cm)
-		err := srv.CompleteTask(ctx, id, uid)
+		repo.On("FindTrashedTaskByID", ctx, id).Return(newTask(nil), nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.RestoreTask(ctx, id, "another")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 親タスクがゴミ箱にある場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "parent task is in trash"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTrashedTaskByID", ctx, id).Return(newTask(value.NewID(parentID)), nil)
+		repo.On("FindTaskByID", ctx, parentID).Return(nil, &domain.ErrNotFound{})
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.RestoreTask(ctx, id, uid)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
 		repo.AssertExpectations(t)
-		im.AssertExpectations(t)
-		cm.AssertExpectations(t)
 	})
 	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
 		errExp := &domain.ErrQueryFailed{}
-		arg := &entity.Task{
-			ID:          task.ID,
-			UserID:      task.UserID,
-			Name:        task.Name,
-			IsCompleted: true,
-			CreatedAt:   task.CreatedAt,
-			UpdatedAt:   upd,
-		}
 		repo := new(mocks.ITaskRepository)
-		repo.On("FindTaskByID", ctx, id).Return(task, nil)
-		repo.On("UpdateTask", ctx, arg).Return(errExp)
-		im := new(mocks.IIDManager)
-		cm := new(mocks.IClockManager)
-		cm.On("GetNow").Return(upd)
-		srv := NewTaskService(repo, im, cm)
-		err := srv.CompleteTask(ctx, id, uid)
+		repo.On("FindTrashedTaskByID", ctx, id).Return(newTask(nil), nil)
+		repo.On("RestoreTask", ctx, id, deletedAt).Return(errExp)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.RestoreTask(ctx, id, uid)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
 		repo.AssertExpectations(t)
-		im.AssertExpectations(t)
-		cm.AssertExpectations(t)
 	})
 }
 
-func TestTaskService_UncompleteTask(tt *testing.T) {
+func TestTaskService_EmptyTrash(tt *testing.T) {
 	ctx := context.Background()
-	id := "id"
 	uid := "uid"
-	now := time.Now().UTC()
-	task := &entity.Task{
-		ID:          value.NewID(id),
-		UserID:      value.NewID(uid),
-		Name:        "task",
-		IsCompleted: true,
-		CreatedAt:   now,
-		UpdatedAt:   now,
-	}
-	upd := now.Add(time.Second)
 
 	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
-		arg := &entity.Task{
-			ID:          task.ID,
-			UserID:      task.UserID,
-			Name:        task.Name,
-			IsCompleted: false,
-			CreatedAt:   task.CreatedAt,
-			UpdatedAt:   upd,
-		}
 		repo := new(mocks.ITaskRepository)
-		repo.On("FindTaskByID", ctx, id).Return(task, nil)
-		repo.On("UpdateTask", ctx, arg).Return(nil)
-		im := new(mocks.IIDManager)
-		cm := new(mocks.IClockManager)
-		cm.On("GetNow").Return(upd)
-		srv := NewTaskService(repo, im, cm)
-		err := srv.UncompleteTask(ctx, id, uid)
+		repo.On("PurgeTrashedTasksByUserID", ctx, uid).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.EmptyTrash(ctx, uid)
 
 		require.NoError(t, err, "エラーが発生しないこと")
 		repo.AssertExpectations(t)
-		im.AssertExpectations(t)
-		cm.AssertExpectations(t)
 	})
 	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
 		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
-		id := ""
 		repo := new(mocks.ITaskRepository)
-		im := new(mocks.IIDManager)
-		cm := new(mocks.IClockManager)
-		srv := NewTaskService(repo, im, cm)
-		err := srv.UncompleteTask(ctx, id, uid)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.EmptyTrash(ctx, "")
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
 		repo.AssertExpectations(t)
-		im.AssertExpectations(t)
-		cm.AssertExpectations(t)
 	})
-	tt.Run("準正常系: 存在しないTaskIDの場合", func(t *testing.T) {
-		errExp := &domain.ErrNotFound{Msg: "task not found"}
-		id := "another"
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
 		repo := new(mocks.ITaskRepository)
-		repo.On("FindTaskByID", ctx, id).Return(nil, errExp)
-		im := new(mocks.IIDManager)
-		cm := new(mocks.IClockManager)
-		srv := NewTaskService(repo, im, cm)
-		err := srv.UncompleteTask(ctx, id, uid)
+		repo.On("PurgeTrashedTasksByUserID", ctx, uid).Return(errExp)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.EmptyTrash(ctx, uid)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
 		repo.AssertExpectations(t)
-		im.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_PurgeTrashedTasks(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	retention := 30 * 24 * time.Hour
+
+	tt.Run("正常系: 保持期間を過ぎたタスクが削除されること", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("PurgeTasksDeletedBefore", ctx, now.Add(-retention)).Return(int64(2), nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		n, err := srv.PurgeTrashedTasks(ctx, retention)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, int64(2), n, "削除した件数が一致すること")
+		repo.AssertExpectations(t)
 		cm.AssertExpectations(t)
 	})
-	tt.Run("準正常系: アクセス権がない場合", func(t *testing.T) {
-		errExp := &domain.ErrPermissionDenied{}
-		uid := "another"
+	tt.Run("準正常系: 保持期間が0以下の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "retention must be positive"}
 		repo := new(mocks.ITaskRepository)
-		repo.On("FindTaskByID", ctx, id).Return(task, nil)
-		im := new(mocks.IIDManager)
 		cm := new(mocks.IClockManager)
-		srv := NewTaskService(repo, im, cm)
-		err := srv.UncompleteTask(ctx, id, uid)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.PurgeTrashedTasks(ctx, 0)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
 		repo.AssertExpectations(t)
-		im.AssertExpectations(t)
 		cm.AssertExpectations(t)
 	})
 	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
 		errExp := &domain.ErrQueryFailed{}
-		arg := &entity.Task{
-			ID:          task.ID,
-			UserID:      task.UserID,
-			Name:        task.Name,
-			IsCompleted: false,
-			CreatedAt:   task.CreatedAt,
-			UpdatedAt:   upd,
-		}
 		repo := new(mocks.ITaskRepository)
-		repo.On("FindTaskByID", ctx, id).Return(task, nil)
-		repo.On("UpdateTask", ctx, arg).Return(errExp)
-		im := new(mocks.IIDManager)
+		repo.On("PurgeTasksDeletedBefore", ctx, now.Add(-retention)).Return(int64(0), errExp)
 		cm := new(mocks.IClockManager)
-		cm.On("GetNow").Return(upd)
-		srv := NewTaskService(repo, im, cm)
-		err := srv.UncompleteTask(ctx, id, uid)
+		cm.On("GetNow").Return(now)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.PurgeTrashedTasks(ctx, retention)
 
 		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
 		repo.AssertExpectations(t)
-		im.AssertExpectations(t)
 		cm.AssertExpectations(t)
 	})
 }
+
+func TestTaskService_FindTaskHistory(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	now := time.Now().UTC()
+	task := &entity.Task{ID: value.NewID(id), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task", Status: value.TaskStatusTodo, CreatedAt: now, UpdatedAt: now}
+	histories := []*entity.TaskHistory{
+		{ID: value.NewID("h3"), TaskID: value.NewID(id), ActorID: value.NewID(uid), Action: value.TaskHistoryActionStatusChanged, OldValue: "todo", NewValue: "done", CreatedAt: now.Add(2 * time.Second)},
+		{ID: value.NewID("h2"), TaskID: value.NewID(id), ActorID: value.NewID(uid), Action: value.TaskHistoryActionRenamed, OldValue: "old", NewValue: "task", CreatedAt: now.Add(time.Second)},
+		{ID: value.NewID("h1"), TaskID: value.NewID(id), ActorID: value.NewID(uid), Action: value.TaskHistoryActionCreated, NewValue: "old", CreatedAt: now},
+	}
+
+	tt.Run("正常系: 続きがない場合はカーソルを返さないこと", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("FindTaskHistories", ctx, id, int32(4), (*value.PageCursor)(nil)).Return(histories, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		result, next, err := srv.FindTaskHistory(ctx, id, uid, 3, nil)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, histories, result, "履歴が一致すること")
+		require.Nil(t, next, "カーソルがないこと")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+	})
+	tt.Run("正常系: 続きがある場合は最後の履歴のカーソルを返すこと", func(t *testing.T) {
+		cursor := &value.PageCursor{Time: now.Add(3 * time.Second), ID: "h4"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("FindTaskHistories", ctx, id, int32(3), cursor).Return(histories, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		result, next, err := srv.FindTaskHistory(ctx, id, uid, 2, cursor)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, histories[:2], result, "履歴が一致すること")
+		require.Equal(t, &value.PageCursor{Time: now.Add(time.Second), ID: "h2"}, next, "カーソルが一致すること")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+	})
+	tt.Run("正常系: ゴミ箱のタスクの履歴を取得できること", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(nil, &domain.ErrNotFound{})
+		repo.On("FindTrashedTaskByID", ctx, id).Return(task, nil)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("FindTaskHistories", ctx, id, int32(4), (*value.PageCursor)(nil)).Return(histories, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		result, _, err := srv.FindTaskHistory(ctx, id, uid, 3, nil)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, histories, result, "履歴が一致すること")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 件数が0以下の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "limit must be positive"}
+		repo := new(mocks.ITaskRepository)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, _, err := srv.FindTaskHistory(ctx, id, uid, 0, nil)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないTaskIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(nil, errExp)
+		repo.On("FindTrashedTaskByID", ctx, id).Return(nil, errExp)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, _, err := srv.FindTaskHistory(ctx, id, uid, 3, nil)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, _, err := srv.FindTaskHistory(ctx, id, "another", 3, nil)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("FindTaskHistories", ctx, id, int32(4), (*value.PageCursor)(nil)).Return(nil, errExp)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, _, err := srv.FindTaskHistory(ctx, id, uid, 3, nil)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_CompleteTask(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	now := time.Now().UTC()
+	task := &entity.Task{
+		ID:        value.NewID(id),
+		UserID:    value.NewID(uid),
+		ListID:    value.NewID("lid"),
+		Position:  "i",
+		Name:      "task",
+		Status:    value.TaskStatusTodo,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	upd := now.Add(time.Second)
+	// 状態の変更がサブテストをまたいで影響しないように複製を返す
+	newTask := func() *entity.Task {
+		v := *task
+		return &v
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		arg := &entity.Task{
+			ID:        task.ID,
+			UserID:    task.UserID,
+			ListID:    task.ListID,
+			Position:  task.Position,
+			Name:      task.Name,
+			Status:    value.TaskStatusDone,
+			CreatedAt: task.CreatedAt,
+			UpdatedAt: upd,
+		}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("CountOpenDescendants", ctx, id).Return(int64(0), nil)
+		repo.On("CountOpenBlockers", ctx, id).Return(int64(0), nil)
+		repo.On("UpdateTask", ctx, arg).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: task.ID, ActorID: task.UserID, Action: value.TaskHistoryActionStatusChanged, OldValue: "todo", NewValue: "done", CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.CompleteTask(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		id := ""
+		repo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.CompleteTask(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないTaskIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		id := "another"
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(nil, errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.CompleteTask(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: アクセス権がない場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		uid := "another"
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.CompleteTask(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		arg := &entity.Task{
+			ID:        task.ID,
+			UserID:    task.UserID,
+			ListID:    task.ListID,
+			Position:  task.Position,
+			Name:      task.Name,
+			Status:    value.TaskStatusDone,
+			CreatedAt: task.CreatedAt,
+			UpdatedAt: upd,
+		}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("CountOpenDescendants", ctx, id).Return(int64(0), nil)
+		repo.On("CountOpenBlockers", ctx, id).Return(int64(0), nil)
+		repo.On("UpdateTask", ctx, arg).Return(errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.CompleteTask(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 未完了のサブタスクが残っている場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "task has open subtasks"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("CountOpenDescendants", ctx, id).Return(int64(1), nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.CompleteTask(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 未完了のブロッカーが残っている場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "task is blocked by open tasks"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("CountOpenDescendants", ctx, id).Return(int64(0), nil)
+		repo.On("CountOpenBlockers", ctx, id).Return(int64(1), nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.CompleteTask(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_UncompleteTask(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	now := time.Now().UTC()
+	task := &entity.Task{
+		ID:        value.NewID(id),
+		UserID:    value.NewID(uid),
+		ListID:    value.NewID("lid"),
+		Position:  "i",
+		Name:      "task",
+		Status:    value.TaskStatusDone,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	upd := now.Add(time.Second)
+	// 状態の変更がサブテストをまたいで影響しないように複製を返す
+	newTask := func() *entity.Task {
+		v := *task
+		return &v
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		arg := &entity.Task{
+			ID:        task.ID,
+			UserID:    task.UserID,
+			ListID:    task.ListID,
+			Position:  task.Position,
+			Name:      task.Name,
+			Status:    value.TaskStatusTodo,
+			CreatedAt: task.CreatedAt,
+			UpdatedAt: upd,
+		}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("UpdateTask", ctx, arg).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: task.ID, ActorID: task.UserID, Action: value.TaskHistoryActionStatusChanged, OldValue: "done", NewValue: "todo", CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.UncompleteTask(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		id := ""
+		repo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.UncompleteTask(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないTaskIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		id := "another"
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(nil, errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.UncompleteTask(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: アクセス権がない場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		uid := "another"
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.UncompleteTask(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		arg := &entity.Task{
+			ID:        task.ID,
+			UserID:    task.UserID,
+			ListID:    task.ListID,
+			Position:  task.Position,
+			Name:      task.Name,
+			Status:    value.TaskStatusTodo,
+			CreatedAt: task.CreatedAt,
+			UpdatedAt: upd,
+		}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("UpdateTask", ctx, arg).Return(errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.UncompleteTask(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 親タスクが完了済みの場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "parent task is completed"}
+		child := &entity.Task{
+			ID:        task.ID,
+			UserID:    task.UserID,
+			ListID:    task.ListID,
+			Position:  task.Position,
+			Name:      task.Name,
+			Status:    value.TaskStatusDone,
+			CreatedAt: task.CreatedAt,
+			UpdatedAt: task.UpdatedAt,
+			ParentID:  value.NewID("pid"),
+		}
+		parent := &entity.Task{ID: value.NewID("pid"), UserID: task.UserID, ListID: task.ListID, Position: task.Position, Name: "parent", Status: value.TaskStatusDone, CreatedAt: now, UpdatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(child, nil)
+		repo.On("FindTaskByID", ctx, "pid").Return(parent, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.UncompleteTask(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_FindOverdueTasksByUserID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	due := now.Add(-time.Hour)
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task1", CreatedAt: now, UpdatedAt: now, DueAt: &due},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindOverdueTasksByUserID", ctx, uid, now).Return(tasks, nil)
+		repo.On("FindBlockedTaskIDs", ctx, []string{"t1"}).Return([]string{"t1"}, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		ret, err := srv.FindOverdueTasksByUserID(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.ElementsMatch(t, tasks, ret)
+		require.True(t, ret[0].IsBlocked, "ブロックされていること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: UserIDが空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		repo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.FindOverdueTasksByUserID(ctx, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindOverdueTasksByUserID", ctx, uid, now).Return(nil, errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.FindOverdueTasksByUserID(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: ブロッカーの取得に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindOverdueTasksByUserID", ctx, uid, now).Return(tasks, nil)
+		repo.On("FindBlockedTaskIDs", ctx, []string{"t1"}).Return(nil, errExp)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.FindOverdueTasksByUserID(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_FindTasksDueBetween(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	from := now
+	to := now.AddDate(0, 0, 7)
+	due := now.AddDate(0, 0, 1)
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task1", CreatedAt: now, UpdatedAt: now, DueAt: &due},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTasksDueBetween", ctx, uid, from, to).Return(tasks, nil)
+		repo.On("FindBlockedTaskIDs", ctx, []string{"t1"}).Return([]string{"t1"}, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		ret, err := srv.FindTasksDueBetween(ctx, uid, from, to)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.ElementsMatch(t, tasks, ret)
+		require.True(t, ret[0].IsBlocked, "ブロックされていること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 期間が不正な場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "due range is invalid"}
+		repo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.FindTasksDueBetween(ctx, uid, to, from)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTasksDueBetween", ctx, uid, from, to).Return(nil, errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.FindTasksDueBetween(ctx, uid, from, to)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: ブロッカーの取得に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTasksDueBetween", ctx, uid, from, to).Return(tasks, nil)
+		repo.On("FindBlockedTaskIDs", ctx, []string{"t1"}).Return(nil, errExp)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.FindTasksDueBetween(ctx, uid, from, to)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_SetTaskDueDate(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	now := time.Now().UTC()
+	task := &entity.Task{
+		ID:        value.NewID(id),
+		UserID:    value.NewID(uid),
+		ListID:    value.NewID("lid"),
+		Position:  "i",
+		Name:      "task",
+		Status:    value.TaskStatusTodo,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	upd := now.Add(time.Second)
+	due := now.AddDate(0, 0, 1)
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		arg := &entity.Task{
+			ID:        task.ID,
+			UserID:    task.UserID,
+			ListID:    task.ListID,
+			Position:  task.Position,
+			Name:      task.Name,
+			Status:    task.Status,
+			CreatedAt: task.CreatedAt,
+			UpdatedAt: upd,
+			DueAt:     &due,
+		}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(&entity.Task{ID: task.ID, UserID: task.UserID, ListID: task.ListID, Position: task.Position, Name: task.Name, CreatedAt: now, UpdatedAt: now}, nil)
+		repo.On("UpdateTask", ctx, arg).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		ur := newPushUndoRepoMock(ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID(id), Action: value.TaskHistoryActionDueDateChanged, OldValue: "", NewValue: due.Truncate(time.Microsecond).Format(time.RFC3339Nano), CreatedAt: upd})
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.SetTaskDueDate(ctx, id, uid, due)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 期限が空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "due date is empty"}
+		repo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.SetTaskDueDate(ctx, id, uid, time.Time{})
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないTaskIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(nil, errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.SetTaskDueDate(ctx, id, uid, due)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: アクセス権がない場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.SetTaskDueDate(ctx, id, "another", due)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_ClearTaskDueDate(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	now := time.Now().UTC()
+	due := now.AddDate(0, 0, 1)
+	upd := now.Add(time.Second)
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		arg := &entity.Task{
+			ID:        value.NewID(id),
+			UserID:    value.NewID(uid),
+			ListID:    value.NewID("lid"),
+			Position:  "i",
+			Name:      "task",
+			Status:    value.TaskStatusTodo,
+			CreatedAt: now,
+			UpdatedAt: upd,
+			DueAt:     nil,
+		}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(&entity.Task{ID: value.NewID(id), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task", CreatedAt: now, UpdatedAt: now, DueAt: &due}, nil)
+		repo.On("UpdateTask", ctx, arg).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		ur := newPushUndoRepoMock(ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID(id), Action: value.TaskHistoryActionDueDateChanged, OldValue: due.Truncate(time.Microsecond).Format(time.RFC3339Nano), NewValue: "", CreatedAt: upd})
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.ClearTaskDueDate(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: アクセス権がない場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(&entity.Task{ID: value.NewID(id), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task", CreatedAt: now, UpdatedAt: now, DueAt: &due}, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.ClearTaskDueDate(ctx, id, "another")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(&entity.Task{ID: value.NewID(id), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task", CreatedAt: now, UpdatedAt: now, DueAt: &due}, nil)
+		repo.On("UpdateTask", ctx, &entity.Task{ID: value.NewID(id), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task", CreatedAt: now, UpdatedAt: upd}).Return(errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.ClearTaskDueDate(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_FindTasksByUserIDOrderByPriority(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task1", CreatedAt: now, UpdatedAt: now, Priority: value.PriorityUrgent},
+		{ID: value.NewID("t2"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task2", CreatedAt: now, UpdatedAt: now, Priority: value.PriorityLow},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTasksByUserIDOrderByPriority", ctx, uid).Return(tasks, nil)
+		repo.On("FindBlockedTaskIDs", ctx, []string{"t1", "t2"}).Return([]string{}, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		ret, err := srv.FindTasksByUserIDOrderByPriority(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tasks, ret)
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: UserIDが空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		repo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.FindTasksByUserIDOrderByPriority(ctx, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTasksByUserIDOrderByPriority", ctx, uid).Return(nil, errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.FindTasksByUserIDOrderByPriority(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_ChangeTaskPriority(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	now := time.Now().UTC()
+	upd := now.Add(time.Second)
+	newTask := func() *entity.Task {
+		return &entity.Task{
+			ID:        value.NewID(id),
+			UserID:    value.NewID(uid),
+			ListID:    value.NewID("lid"),
+			Position:  "i",
+			Name:      "task",
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		arg := newTask()
+		arg.Priority = value.PriorityHigh
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("UpdateTask", ctx, arg).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		ur := newPushUndoRepoMock(ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID(id), Action: value.TaskHistoryActionPriorityChanged, OldValue: "0", NewValue: "3", CreatedAt: upd})
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.ChangeTaskPriority(ctx, id, uid, value.PriorityHigh)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 優先度が不正な場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "invalid priority"}
+		repo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.ChangeTaskPriority(ctx, id, uid, value.PriorityUnknown)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないTaskIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(nil, errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.ChangeTaskPriority(ctx, id, uid, value.PriorityHigh)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: アクセス権がない場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.ChangeTaskPriority(ctx, id, "another", value.PriorityHigh)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		arg := newTask()
+		arg.Priority = value.PriorityHigh
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("UpdateTask", ctx, arg).Return(errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.ChangeTaskPriority(ctx, id, uid, value.PriorityHigh)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_ChangeTaskDescription(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	now := time.Now().UTC()
+	upd := now.Add(time.Second)
+	source := "**memo**"
+	rendered := "<p><strong>memo</strong></p>\n"
+	newTask := func() *entity.Task {
+		return &entity.Task{
+			ID:        value.NewID(id),
+			UserID:    value.NewID(uid),
+			ListID:    value.NewID("lid"),
+			Position:  "i",
+			Name:      "task",
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		arg := newTask()
+		arg.Description = source
+		arg.DescriptionHTML = rendered
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("UpdateTask", ctx, arg).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		ur := newPushUndoRepoMock(ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID(id), Action: value.TaskHistoryActionDescriptionChanged, OldValue: encodeUndoValue(taskDescriptionUndoValue{}), NewValue: encodeUndoValue(taskDescriptionUndoValue{Description: source, DescriptionHTML: rendered}), CreatedAt: upd})
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.ChangeTaskDescription(ctx, id, uid, source, rendered)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないTaskIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(nil, errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.ChangeTaskDescription(ctx, id, uid, source, rendered)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: アクセス権がない場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.ChangeTaskDescription(ctx, id, "another", source, rendered)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		arg := newTask()
+		arg.Description = source
+		arg.DescriptionHTML = rendered
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("UpdateTask", ctx, arg).Return(errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.ChangeTaskDescription(ctx, id, uid, source, rendered)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_FindTaskTree(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID(id), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "parent", CreatedAt: now, UpdatedAt: now},
+		{ID: value.NewID("c1"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "child", CreatedAt: now, UpdatedAt: now, ParentID: value.NewID(id)},
+		{ID: value.NewID("g1"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "grandchild", CreatedAt: now, UpdatedAt: now, ParentID: value.NewID("c1")},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskTree", ctx, id).Return(tasks, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		ret, err := srv.FindTaskTree(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tasks, ret)
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないTaskIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskTree", ctx, "another").Return([]*entity.Task{}, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.FindTaskTree(ctx, "another", uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: アクセス権がない場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskTree", ctx, id).Return(tasks, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.FindTaskTree(ctx, id, "another")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskTree", ctx, id).Return(nil, errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.FindTaskTree(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_CreateSubtask(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	pid := "pid"
+	uid := "uid"
+	now := time.Now().UTC()
+	parent := &entity.Task{ID: value.NewID(pid), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "parent", CreatedAt: now, UpdatedAt: now}
+	task := &entity.Task{
+		ID:        value.NewID(id),
+		UserID:    value.NewID(uid),
+		ListID:    value.NewID("lid"),
+		Position:  "i",
+		Name:      "task",
+		Status:    value.TaskStatusTodo,
+		CreatedAt: now,
+		UpdatedAt: now,
+		ParentID:  value.NewID(pid),
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindMinTaskPosition", ctx, "lid").Return(value.Rank(""), nil)
+		repo.On("FindTaskByID", ctx, pid).Return(parent, nil)
+		repo.On("CreateTask", ctx, task).Return(id, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: task.ID, TaskID: task.ID, ActorID: task.UserID, Action: value.TaskHistoryActionCreated, NewValue: task.Name, CreatedAt: now}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		ret, err := srv.CreateSubtask(ctx, uid, pid, task.Name)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, id, ret)
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 親タスクが存在しない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "parent task not found"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, pid).Return(nil, errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.CreateSubtask(ctx, uid, pid, task.Name)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 親タスクが他人のタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, pid).Return(parent, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.CreateSubtask(ctx, "another", pid, task.Name)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 親タスクが完了済みの場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "parent task is completed"}
+		completed := &entity.Task{ID: value.NewID(pid), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "parent", Status: value.TaskStatusDone, CreatedAt: now, UpdatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, pid).Return(completed, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.CreateSubtask(ctx, uid, pid, task.Name)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "name is empty"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindMinTaskPosition", ctx, "lid").Return(value.Rank(""), nil)
+		repo.On("FindTaskByID", ctx, pid).Return(parent, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.CreateSubtask(ctx, uid, pid, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_MoveSubtask(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	pid := "pid"
+	uid := "uid"
+	now := time.Now().UTC()
+	upd := now.Add(time.Second)
+	newTask := func() *entity.Task {
+		return &entity.Task{ID: value.NewID(id), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task", CreatedAt: now, UpdatedAt: now, ParentID: value.NewID("old")}
+	}
+	parent := &entity.Task{ID: value.NewID(pid), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "parent", CreatedAt: now, UpdatedAt: now}
+
+	tt.Run("正常系: 別の親タスクに移動する場合", func(t *testing.T) {
+		arg := newTask()
+		arg.ParentID = value.NewID(pid)
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("FindTaskByID", ctx, pid).Return(parent, nil)
+		repo.On("FindAncestorIDs", ctx, pid).Return([]string{pid, "root"}, nil)
+		repo.On("UpdateTask", ctx, arg).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		ur := newPushUndoRepoMock(ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID(id), Action: value.TaskHistoryActionParentChanged, OldValue: "old", NewValue: pid, CreatedAt: upd})
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.MoveSubtask(ctx, id, uid, pid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("正常系: ルートに移動する場合", func(t *testing.T) {
+		arg := newTask()
+		arg.ParentID = nil
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("UpdateTask", ctx, arg).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		ur := newPushUndoRepoMock(ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID(id), Action: value.TaskHistoryActionParentChanged, OldValue: "old", NewValue: "", CreatedAt: upd})
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.MoveSubtask(ctx, id, uid, "")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 自分自身を親にする場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "task cannot be its own parent"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.MoveSubtask(ctx, id, uid, id)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 自分の子孫の下に移動する場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "task cannot be moved under its own subtree"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("FindTaskByID", ctx, pid).Return(parent, nil)
+		repo.On("FindAncestorIDs", ctx, pid).Return([]string{pid, id, "old"}, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.MoveSubtask(ctx, id, uid, pid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 移動先が他人のタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		another := &entity.Task{ID: value.NewID(pid), UserID: value.NewID("another"), ListID: value.NewID("lid"), Position: "i", Name: "parent", CreatedAt: now, UpdatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("FindTaskByID", ctx, pid).Return(another, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.MoveSubtask(ctx, id, uid, pid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 移動先が別のリストのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "parent task is in another list"}
+		another := &entity.Task{ID: value.NewID(pid), UserID: value.NewID(uid), ListID: value.NewID("another"), Position: "i", Name: "parent", CreatedAt: now, UpdatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("FindTaskByID", ctx, pid).Return(another, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.MoveSubtask(ctx, id, uid, pid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 移動先が完了済みの場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "parent task is completed"}
+		completed := &entity.Task{ID: value.NewID(pid), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "parent", Status: value.TaskStatusDone, CreatedAt: now, UpdatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("FindTaskByID", ctx, pid).Return(completed, nil)
+		repo.On("FindAncestorIDs", ctx, pid).Return([]string{pid}, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.MoveSubtask(ctx, id, uid, pid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_FindTasksByUserIDAndTags(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	tagIDs := []string{"g1", "g2"}
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task1", CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTasksByUserIDAndTags", ctx, uid, "", tagIDs, true, value.TaskOrderUpdatedAt).Return(tasks, nil)
+		repo.On("FindBlockedTaskIDs", ctx, []string{"t1"}).Return([]string{}, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		ret, err := srv.FindTasksByUserIDAndTags(ctx, uid, "", tagIDs, true, value.TaskOrderUpdatedAt)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tasks, ret)
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: TagIDが指定されていない場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "tag ids are empty"}
+		repo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.FindTasksByUserIDAndTags(ctx, uid, "", []string{}, false, value.TaskOrderUpdatedAt)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 空のTagIDが含まれる場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		repo := new(mocks.ITaskRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.FindTasksByUserIDAndTags(ctx, uid, "", []string{"g1", ""}, false, value.TaskOrderUpdatedAt)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTasksByUserIDAndTags", ctx, uid, "", tagIDs, false, value.TaskOrderPriority).Return(nil, errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.FindTasksByUserIDAndTags(ctx, uid, "", tagIDs, false, value.TaskOrderPriority)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("正常系: ListIDを指定した場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTasksByUserIDAndTags", ctx, uid, "lid", tagIDs, false, value.TaskOrderUpdatedAt).Return(tasks, nil)
+		repo.On("FindBlockedTaskIDs", ctx, []string{"t1"}).Return([]string{}, nil)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, "lid").Return(&entity.List{ID: value.NewID("lid"), UserID: value.NewID(uid), Name: "list", CreatedAt: now, UpdatedAt: now}, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		ret, err := srv.FindTasksByUserIDAndTags(ctx, uid, "lid", tagIDs, false, value.TaskOrderUpdatedAt)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tasks, ret)
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのListIDを指定した場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, "lid").Return(&entity.List{ID: value.NewID("lid"), UserID: value.NewID("another"), Name: "list", CreatedAt: now, UpdatedAt: now}, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.FindTasksByUserIDAndTags(ctx, uid, "lid", tagIDs, false, value.TaskOrderUpdatedAt)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_FindTasksByListID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	lid := "lid"
+	list := &entity.List{ID: value.NewID(lid), UserID: value.NewID(uid), Name: "list", CreatedAt: now, UpdatedAt: now}
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), ListID: value.NewID(lid), Position: "i", Name: "task1", CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTasksByListID", ctx, lid, value.TaskOrderPriority).Return(tasks, nil)
+		repo.On("FindBlockedTaskIDs", ctx, []string{"t1"}).Return([]string{}, nil)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(list, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		ret, err := srv.FindTasksByListID(ctx, lid, uid, value.TaskOrderPriority)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tasks, ret)
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: ListIDが空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		repo := new(mocks.ITaskRepository)
+		listRepo := new(mocks.IListRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.FindTasksByListID(ctx, "", uid, value.TaskOrderUpdatedAt)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないListIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "list not found"}
+		repo := new(mocks.ITaskRepository)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(nil, errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.FindTasksByListID(ctx, lid, uid, value.TaskOrderUpdatedAt)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのリストの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(list, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.FindTasksByListID(ctx, lid, "another", value.TaskOrderUpdatedAt)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTasksByListID", ctx, lid, value.TaskOrderUpdatedAt).Return(nil, errExp)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(list, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		_, err := srv.FindTasksByListID(ctx, lid, uid, value.TaskOrderUpdatedAt)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 並び順が不正な場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "invalid task order"}
+		repo := new(mocks.ITaskRepository)
+		listRepo := new(mocks.IListRepository)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindTasksByListID(ctx, lid, uid, value.TaskOrder(3))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_MoveTaskToList(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	uid := "uid"
+	lid := "lid"
+	task := &entity.Task{ID: value.NewID(id), UserID: value.NewID(uid), ListID: value.NewID("old"), Position: "i", Name: "task", CreatedAt: now, UpdatedAt: now}
+	list := &entity.List{ID: value.NewID(lid), UserID: value.NewID(uid), Name: "list", CreatedAt: now, UpdatedAt: now}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		upd := now.Add(time.Hour)
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("FindMinTaskPosition", ctx, lid).Return(value.Rank("i"), nil)
+		repo.On("UpdateTaskTreeListID", ctx, id, lid, upd).Return(nil)
+		repo.On("UpdateTaskPosition", ctx, id, value.Rank("9")).Return(nil)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(list, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		ur := newPushUndoRepoMock(ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID(id), Action: value.TaskHistoryActionListChanged, OldValue: `{"list_id":"old","position":"i"}`, NewValue: `{"list_id":"lid","position":"9"}`, CreatedAt: upd})
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.MoveTaskToList(ctx, id, uid, lid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("正常系: 同じリストを指定した場合は更新しないこと", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(&entity.Task{ID: task.ID, UserID: task.UserID, ListID: list.ID, Name: task.Name, CreatedAt: now, UpdatedAt: now}, nil)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(list, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.MoveTaskToList(ctx, id, uid, lid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないTaskIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(nil, errExp)
+		listRepo := new(mocks.IListRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.MoveTaskToList(ctx, id, uid, lid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		listRepo := new(mocks.IListRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.MoveTaskToList(ctx, id, "another", lid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: サブタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "subtask cannot be moved to another list"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(&entity.Task{ID: task.ID, UserID: task.UserID, ListID: task.ListID, Position: task.Position, Name: task.Name, CreatedAt: now, UpdatedAt: now, ParentID: value.NewID("pid")}, nil)
+		listRepo := new(mocks.IListRepository)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.MoveTaskToList(ctx, id, uid, lid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: アーカイブされたリストの場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "list is archived"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(&entity.List{ID: list.ID, UserID: list.UserID, Name: list.Name, IsArchived: true, CreatedAt: now, UpdatedAt: now}, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.MoveTaskToList(ctx, id, uid, lid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("FindMinTaskPosition", ctx, lid).Return(value.Rank("i"), nil)
+		repo.On("UpdateTaskTreeListID", ctx, id, lid, now).Return(errExp)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(list, nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.MoveTaskToList(ctx, id, uid, lid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 位置の更新に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("FindMinTaskPosition", ctx, lid).Return(value.Rank("i"), nil)
+		repo.On("UpdateTaskTreeListID", ctx, id, lid, now).Return(nil)
+		repo.On("UpdateTaskPosition", ctx, id, value.Rank("9")).Return(errors.New("failed"))
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(list, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		txm := newTxManagerMock(ctx)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), txm, newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		err := srv.MoveTaskToList(ctx, id, uid, lid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		txm.AssertNumberOfCalls(t, "RunInTx", 1)
+	})
+}
+
+func TestTaskService_FindTasksByUserIDOrderByPosition(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	now := time.Now().UTC()
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "9", Name: "task1", CreatedAt: now, UpdatedAt: now},
+		{ID: value.NewID("t2"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task2", CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTasksByUserIDOrderByPosition", ctx, uid).Return(tasks, nil)
+		repo.On("FindBlockedTaskIDs", ctx, []string{"t1", "t2"}).Return([]string{}, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		ret, err := srv.FindTasksByUserIDOrderByPosition(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tasks, ret)
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: UserIDが空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		repo := new(mocks.ITaskRepository)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindTasksByUserIDOrderByPosition(ctx, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTasksByUserIDOrderByPosition", ctx, uid).Return(nil, errExp)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindTasksByUserIDOrderByPosition(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_FindTaskPage(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	now := time.Now().UTC()
+	filter := &value.TaskFilter{Completion: value.TaskCompletionOpen, NameContains: "task"}
+	newTasks := func() []*entity.Task {
+		return []*entity.Task{
+			{ID: value.NewID("t1"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task1", Priority: value.PriorityHigh, CreatedAt: now.Add(-2 * time.Hour), UpdatedAt: now},
+			{ID: value.NewID("t2"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "j", Name: "task2", Priority: value.PriorityLow, CreatedAt: now.Add(-time.Hour), UpdatedAt: now.Add(-time.Minute)},
+			{ID: value.NewID("t3"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "k", Name: "task3", Priority: value.PriorityNone, CreatedAt: now, UpdatedAt: now.Add(-2 * time.Minute)},
+		}
+	}
+
+	tt.Run("正常系: 続きがない場合はカーソルを返さないこと", func(t *testing.T) {
+		sort := value.TaskSort{Field: value.TaskSortFieldUpdatedAt, Descending: true}
+		tasks := newTasks()
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskPage", ctx, uid, filter, sort, int32(4), (*value.TaskPageCursor)(nil)).Return(tasks, nil)
+		repo.On("FindBlockedTaskIDs", ctx, []string{"t1", "t2", "t3"}).Return([]string{"t3"}, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		ret, next, err := srv.FindTaskPage(ctx, uid, filter, sort, 3, nil)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tasks, ret, "タスクが一致すること")
+		require.Nil(t, next, "カーソルがないこと")
+		require.True(t, ret[2].IsBlocked, "ブロックされたタスクに印が付くこと")
+		repo.AssertExpectations(t)
+	})
+	testcases := []struct {
+		title string
+		sort  value.TaskSort
+		exp   *value.TaskPageCursor
+	}{
+		{"正常系: 更新日時順の場合は更新日時の位置を返すこと", value.TaskSort{Field: value.TaskSortFieldUpdatedAt, Descending: true}, &value.TaskPageCursor{Sort: value.TaskSort{Field: value.TaskSortFieldUpdatedAt, Descending: true}, Time: now.Add(-time.Minute), ID: "t2"}},
+		{"正常系: 作成日時順の場合は作成日時の位置を返すこと", value.TaskSort{Field: value.TaskSortFieldCreatedAt}, &value.TaskPageCursor{Sort: value.TaskSort{Field: value.TaskSortFieldCreatedAt}, Time: now.Add(-time.Hour), ID: "t2"}},
+		{"正常系: 名前順の場合は名前の位置を返すこと", value.TaskSort{Field: value.TaskSortFieldName}, &value.TaskPageCursor{Sort: value.TaskSort{Field: value.TaskSortFieldName}, Name: "task2", ID: "t2"}},
+		{"正常系: 優先度順の場合は優先度の位置を返すこと", value.TaskSort{Field: value.TaskSortFieldPriority, Descending: true}, &value.TaskPageCursor{Sort: value.TaskSort{Field: value.TaskSortFieldPriority, Descending: true}, Priority: value.PriorityLow, ID: "t2"}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			cursor := &value.TaskPageCursor{Sort: v.sort, ID: "t0"}
+			tasks := newTasks()
+			repo := new(mocks.ITaskRepository)
+			repo.On("FindTaskPage", ctx, uid, filter, v.sort, int32(3), cursor).Return(tasks, nil)
+			repo.On("FindBlockedTaskIDs", ctx, []string{"t1", "t2"}).Return([]string{}, nil)
+			srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+			ret, next, err := srv.FindTaskPage(ctx, uid, filter, v.sort, 2, cursor)
+
+			require.NoError(t, err, "エラーが発生しないこと")
+			require.Equal(t, tasks[:2], ret, "タスクが一致すること")
+			require.Equal(t, v.exp, next, "カーソルが一致すること")
+			repo.AssertExpectations(t)
+		})
+	}
+	tt.Run("準正常系: カーソルの並び順が異なる場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "page token does not match the sort order"}
+		cursor := &value.TaskPageCursor{Sort: value.TaskSort{Field: value.TaskSortFieldName}, Name: "task", ID: "t0"}
+		repo := new(mocks.ITaskRepository)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, _, err := srv.FindTaskPage(ctx, uid, filter, value.TaskSort{Field: value.TaskSortFieldName, Descending: true}, 2, cursor)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 絞り込みの条件が不正な場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "created_from must be before created_to"}
+		repo := new(mocks.ITaskRepository)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, _, err := srv.FindTaskPage(ctx, uid, &value.TaskFilter{CreatedFrom: &now, CreatedTo: &now}, value.TaskSort{}, 2, nil)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 並び替えの項目が不正な場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "invalid sort field"}
+		repo := new(mocks.ITaskRepository)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, _, err := srv.FindTaskPage(ctx, uid, filter, value.TaskSort{Field: value.TaskSortField(9)}, 2, nil)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 件数が0以下の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "limit must be positive"}
+		repo := new(mocks.ITaskRepository)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, _, err := srv.FindTaskPage(ctx, uid, filter, value.TaskSort{}, 0, nil)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskPage", ctx, uid, filter, value.TaskSort{}, int32(3), (*value.TaskPageCursor)(nil)).Return(nil, errExp)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, _, err := srv.FindTaskPage(ctx, uid, filter, value.TaskSort{}, 2, nil)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_MoveTask(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	uid := "uid"
+	tid := "tid"
+	lid := "lid"
+	task := &entity.Task{ID: value.NewID(id), UserID: value.NewID(uid), ListID: value.NewID(lid), Position: "r", Name: "task", CreatedAt: now, UpdatedAt: now}
+	target := &entity.Task{ID: value.NewID(tid), UserID: value.NewID(uid), ListID: value.NewID(lid), Position: "i", Name: "target", CreatedAt: now, UpdatedAt: now}
+	upd := now.Add(time.Second)
+
+	tt.Run("正常系: 前のタスクとの間に移動する場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("FindTaskByID", ctx, tid).Return(target, nil)
+		repo.On("FindPrevTaskPosition", ctx, lid, id, value.Rank("i")).Return(value.Rank("a"), nil)
+		repo.On("UpdateTaskPosition", ctx, id, value.Rank("e")).Return(nil)
+		ur := newPushUndoRepoMock(ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID(id), Action: value.TaskHistoryActionPositionChanged, OldValue: "r", NewValue: "e", CreatedAt: upd})
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.MoveTask(ctx, id, uid, tid, false)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 先頭に移動する場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("FindTaskByID", ctx, tid).Return(target, nil)
+		repo.On("FindPrevTaskPosition", ctx, lid, id, value.Rank("i")).Return(value.Rank(""), nil)
+		repo.On("UpdateTaskPosition", ctx, id, value.Rank("9")).Return(nil)
+		ur := newPushUndoRepoMock(ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID(id), Action: value.TaskHistoryActionPositionChanged, OldValue: "r", NewValue: "9", CreatedAt: upd})
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.MoveTask(ctx, id, uid, tid, false)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 後ろのタスクとの間に移動する場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("FindTaskByID", ctx, tid).Return(target, nil)
+		repo.On("FindNextTaskPosition", ctx, lid, id, value.Rank("i")).Return(value.Rank("j"), nil)
+		repo.On("UpdateTaskPosition", ctx, id, value.Rank("ii")).Return(nil)
+		ur := newPushUndoRepoMock(ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID(id), Action: value.TaskHistoryActionPositionChanged, OldValue: "r", NewValue: "ii", CreatedAt: upd})
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.MoveTask(ctx, id, uid, tid, true)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 末尾に移動する場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("FindTaskByID", ctx, tid).Return(target, nil)
+		repo.On("FindNextTaskPosition", ctx, lid, id, value.Rank("i")).Return(value.Rank(""), nil)
+		repo.On("UpdateTaskPosition", ctx, id, value.Rank("r")).Return(nil)
+		ur := newPushUndoRepoMock(ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID(id), Action: value.TaskHistoryActionPositionChanged, OldValue: "r", NewValue: "r", CreatedAt: upd})
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.MoveTask(ctx, id, uid, tid, true)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 自分自身を指定した場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "task cannot be moved relative to itself"}
+		repo := new(mocks.ITaskRepository)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.MoveTask(ctx, id, uid, id, false)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないTaskIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(nil, errExp)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.MoveTask(ctx, id, uid, tid, false)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.MoveTask(ctx, id, "another", tid, false)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しない移動先のタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "target task not found"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("FindTaskByID", ctx, tid).Return(nil, errExp)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.MoveTask(ctx, id, uid, tid, false)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 移動先が別のユーザーのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("FindTaskByID", ctx, tid).Return(&entity.Task{ID: target.ID, UserID: value.NewID("another"), ListID: target.ListID, Position: target.Position, Name: target.Name, CreatedAt: now, UpdatedAt: now}, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.MoveTask(ctx, id, uid, tid, false)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 移動先が別のリストのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "target task is in another list"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("FindTaskByID", ctx, tid).Return(&entity.Task{ID: target.ID, UserID: target.UserID, ListID: value.NewID("another"), Position: target.Position, Name: target.Name, CreatedAt: now, UpdatedAt: now}, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.MoveTask(ctx, id, uid, tid, false)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("FindTaskByID", ctx, tid).Return(target, nil)
+		repo.On("FindPrevTaskPosition", ctx, lid, id, value.Rank("i")).Return(value.Rank("a"), nil)
+		repo.On("UpdateTaskPosition", ctx, id, value.Rank("e")).Return(errExp)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.MoveTask(ctx, id, uid, tid, false)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_RebalanceTaskPositions(tt *testing.T) {
+	ctx := context.Background()
+
+	tt.Run("正常系: 対象のリストのタスクを等間隔に再配置すること", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindListIDsToRebalance", ctx, int32(rebalanceRankLength)).Return([]string{"l1", "l2"}, nil)
+		repo.On("FindTaskIDsByListIDOrderByPosition", ctx, "l1").Return([]string{"t1", "t2"}, nil)
+		repo.On("FindTaskIDsByListIDOrderByPosition", ctx, "l2").Return([]string{"t3"}, nil)
+		ranks := value.SpreadRanks(2)
+		repo.On("UpdateTaskPosition", ctx, "t1", ranks[0]).Return(nil)
+		repo.On("UpdateTaskPosition", ctx, "t2", ranks[1]).Return(nil)
+		repo.On("UpdateTaskPosition", ctx, "t3", value.SpreadRanks(1)[0]).Return(nil)
+		txm := newTxManagerMock(ctx)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), txm, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.RebalanceTaskPositions(ctx)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		txm.AssertNumberOfCalls(t, "RunInTx", 2)
+	})
+	tt.Run("正常系: 対象のリストが存在しない場合は更新しないこと", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindListIDsToRebalance", ctx, int32(rebalanceRankLength)).Return([]string{}, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.RebalanceTaskPositions(ctx)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindListIDsToRebalance", ctx, int32(rebalanceRankLength)).Return(nil, errExp)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.RebalanceTaskPositions(ctx)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 再配置に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindListIDsToRebalance", ctx, int32(rebalanceRankLength)).Return([]string{"l1", "l2"}, nil)
+		repo.On("FindTaskIDsByListIDOrderByPosition", ctx, "l1").Return([]string{"t1", "t2"}, nil)
+		ranks := value.SpreadRanks(2)
+		repo.On("UpdateTaskPosition", ctx, "t1", ranks[0]).Return(nil)
+		repo.On("UpdateTaskPosition", ctx, "t2", ranks[1]).Return(errors.New("failed"))
+		txm := newTxManagerMock(ctx)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), txm, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.RebalanceTaskPositions(ctx)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "FindTaskIDsByListIDOrderByPosition", ctx, "l2")
+		txm.AssertNumberOfCalls(t, "RunInTx", 1)
+	})
+}
+
+func TestTaskService_CompleteTask_Recurrence(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	lid := "lid"
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(tt, err, "エラーが発生しないこと")
+	now := time.Date(2026, 10, 15, 3, 0, 0, 0, time.UTC)
+	// 日本時間の2026-10-14 09:00
+	due := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	newTask := func(rule value.RecurrenceRule, dueAt *time.Time) *entity.Task {
+		return &entity.Task{ID: value.NewID(id), UserID: value.NewID(uid), ListID: value.NewID(lid), Position: "i", Name: "task", Priority: value.PriorityHigh, Description: "memo", DescriptionHTML: "<p>memo</p>\n", CreatedAt: now, UpdatedAt: now, DueAt: dueAt, RecurrenceRule: rule, RecurrenceTimezone: "Asia/Tokyo"}
+	}
+	completed := &entity.Task{ID: value.NewID(id), UserID: value.NewID(uid), ListID: value.NewID(lid), Position: "i", Name: "task", Priority: value.PriorityHigh, Description: "memo", DescriptionHTML: "<p>memo</p>\n", Status: value.TaskStatusDone, CreatedAt: now, UpdatedAt: now, DueAt: &due}
+
+	tt.Run("正常系: 期限がある場合は期限を基準に次の回を作成すること", func(t *testing.T) {
+		nextDue := time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)
+		next := &entity.Task{ID: value.NewID("next"), UserID: value.NewID(uid), ListID: value.NewID(lid), Position: "n", Name: "task", Priority: value.PriorityHigh, Description: "memo", DescriptionHTML: "<p>memo</p>\n", CreatedAt: now, UpdatedAt: now, DueAt: &nextDue, RecurrenceRule: "FREQ=WEEKLY;COUNT=2", RecurrenceTimezone: "Asia/Tokyo"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask("FREQ=WEEKLY;COUNT=3", &due), nil)
+		repo.On("CountOpenDescendants", ctx, id).Return(int64(0), nil)
+		repo.On("CountOpenBlockers", ctx, id).Return(int64(0), nil)
+		repo.On("FindNextTaskPosition", ctx, lid, id, value.Rank("i")).Return(value.Rank("r"), nil)
+		repo.On("UpdateTask", ctx, completed).Return(nil)
+		repo.On("CreateTask", ctx, next).Return("next", nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("next")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		cm.On("LoadLocation", "Asia/Tokyo").Return(tokyo, nil)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("next"), TaskID: value.NewID(id), ActorID: value.NewID(uid), Action: value.TaskHistoryActionStatusChanged, OldValue: "todo", NewValue: "done", CreatedAt: now}).Return(nil)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("next"), TaskID: value.NewID("next"), ActorID: value.NewID(uid), Action: value.TaskHistoryActionCreated, NewValue: "task", CreatedAt: now}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.CompleteTask(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("正常系: 期限がない場合は現在時刻を基準に次の回を作成すること", func(t *testing.T) {
+		nextDue := time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC)
+		next := &entity.Task{ID: value.NewID("next"), UserID: value.NewID(uid), ListID: value.NewID(lid), Position: "r", Name: "task", Priority: value.PriorityHigh, Description: "memo", DescriptionHTML: "<p>memo</p>\n", CreatedAt: now, UpdatedAt: now, DueAt: &nextDue, RecurrenceRule: "FREQ=DAILY", RecurrenceTimezone: "Asia/Tokyo"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask("FREQ=DAILY", nil), nil)
+		repo.On("CountOpenDescendants", ctx, id).Return(int64(0), nil)
+		repo.On("CountOpenBlockers", ctx, id).Return(int64(0), nil)
+		repo.On("FindNextTaskPosition", ctx, lid, id, value.Rank("i")).Return(value.Rank(""), nil)
+		repo.On("UpdateTask", ctx, &entity.Task{ID: completed.ID, UserID: completed.UserID, ListID: completed.ListID, Position: "i", Name: "task", Priority: value.PriorityHigh, Description: "memo", DescriptionHTML: "<p>memo</p>\n", Status: value.TaskStatusDone, CreatedAt: now, UpdatedAt: now}).Return(nil)
+		repo.On("CreateTask", ctx, next).Return("next", nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("next")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		cm.On("LoadLocation", "Asia/Tokyo").Return(tokyo, nil)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("next"), TaskID: value.NewID(id), ActorID: value.NewID(uid), Action: value.TaskHistoryActionStatusChanged, OldValue: "todo", NewValue: "done", CreatedAt: now}).Return(nil)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("next"), TaskID: value.NewID("next"), ActorID: value.NewID(uid), Action: value.TaskHistoryActionCreated, NewValue: "task", CreatedAt: now}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.CompleteTask(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("正常系: 最後の回の場合は次の回を作成しないこと", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask("FREQ=DAILY;COUNT=1", &due), nil)
+		repo.On("CountOpenDescendants", ctx, id).Return(int64(0), nil)
+		repo.On("CountOpenBlockers", ctx, id).Return(int64(0), nil)
+		repo.On("UpdateTask", ctx, completed).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		cm.On("LoadLocation", "Asia/Tokyo").Return(tokyo, nil)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: value.NewID(id), ActorID: value.NewID(uid), Action: value.TaskHistoryActionStatusChanged, OldValue: "todo", NewValue: "done", CreatedAt: now}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.CompleteTask(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: タイムゾーンが不正な場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "invalid timezone"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask("FREQ=DAILY", &due), nil)
+		repo.On("CountOpenDescendants", ctx, id).Return(int64(0), nil)
+		repo.On("CountOpenBlockers", ctx, id).Return(int64(0), nil)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		cm.On("LoadLocation", "Asia/Tokyo").Return(nil, errors.New("unknown time zone"))
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.CompleteTask(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 次の回の作成でクエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask("FREQ=DAILY;COUNT=2", &due), nil)
+		repo.On("CountOpenDescendants", ctx, id).Return(int64(0), nil)
+		repo.On("CountOpenBlockers", ctx, id).Return(int64(0), nil)
+		repo.On("FindNextTaskPosition", ctx, lid, id, value.Rank("i")).Return(value.Rank(""), errExp)
+		im := new(mocks.IIDManager)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		cm.On("LoadLocation", "Asia/Tokyo").Return(tokyo, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), im, cm)
+		err := srv.CompleteTask(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_SetTaskRecurrence(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	now := time.Now().UTC()
+	upd := now.Add(time.Second)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(tt, err, "エラーが発生しないこと")
+	task := &entity.Task{ID: value.NewID(id), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task", CreatedAt: now, UpdatedAt: now}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("UpdateTask", ctx, &entity.Task{ID: task.ID, UserID: task.UserID, ListID: task.ListID, Position: task.Position, Name: task.Name, CreatedAt: now, UpdatedAt: upd, RecurrenceRule: "FREQ=DAILY", RecurrenceTimezone: "Asia/Tokyo"}).Return(nil)
+		cm := new(mocks.IClockManager)
+		cm.On("LoadLocation", "Asia/Tokyo").Return(tokyo, nil)
+		cm.On("GetNow").Return(upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		ur := newPushUndoRepoMock(ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID(id), Action: value.TaskHistoryActionRecurrenceChanged, OldValue: encodeUndoValue(taskRecurrenceUndoValue{}), NewValue: encodeUndoValue(taskRecurrenceUndoValue{Rule: "FREQ=DAILY", Timezone: "Asia/Tokyo"}), CreatedAt: upd})
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.SetTaskRecurrence(ctx, id, uid, "FREQ=DAILY", "Asia/Tokyo")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: ルールが不正な場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "unsupported recurrence frequency"}
+		repo := new(mocks.ITaskRepository)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		err := srv.SetTaskRecurrence(ctx, id, uid, "FREQ=HOURLY", "Asia/Tokyo")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: タイムゾーンが不正な場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "invalid timezone"}
+		repo := new(mocks.ITaskRepository)
+		cm := new(mocks.IClockManager)
+		cm.On("LoadLocation", "Mars/Olympus").Return(nil, errors.New("unknown time zone"))
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		err := srv.SetTaskRecurrence(ctx, id, uid, "FREQ=DAILY", "Mars/Olympus")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないTaskIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(nil, errExp)
+		cm := new(mocks.IClockManager)
+		cm.On("LoadLocation", "UTC").Return(time.UTC, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		err := srv.SetTaskRecurrence(ctx, id, uid, "FREQ=DAILY", "UTC")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("LoadLocation", "UTC").Return(time.UTC, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		err := srv.SetTaskRecurrence(ctx, id, "another", "FREQ=DAILY", "UTC")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 完了済みのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "task is closed"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(&entity.Task{ID: task.ID, UserID: task.UserID, ListID: task.ListID, Position: task.Position, Name: task.Name, Status: value.TaskStatusDone, CreatedAt: now, UpdatedAt: now}, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("LoadLocation", "UTC").Return(time.UTC, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		err := srv.SetTaskRecurrence(ctx, id, uid, "FREQ=DAILY", "UTC")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_ClearTaskRecurrence(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	now := time.Now().UTC()
+	upd := now.Add(time.Second)
+	task := &entity.Task{ID: value.NewID(id), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task", CreatedAt: now, UpdatedAt: now, RecurrenceRule: "FREQ=DAILY", RecurrenceTimezone: "UTC"}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("UpdateTask", ctx, &entity.Task{ID: task.ID, UserID: task.UserID, ListID: task.ListID, Position: task.Position, Name: task.Name, CreatedAt: now, UpdatedAt: upd}).Return(nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		ur := newPushUndoRepoMock(ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID(id), Action: value.TaskHistoryActionRecurrenceChanged, OldValue: encodeUndoValue(taskRecurrenceUndoValue{Rule: "FREQ=DAILY", Timezone: "UTC"}), NewValue: encodeUndoValue(taskRecurrenceUndoValue{}), CreatedAt: upd})
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.ClearTaskRecurrence(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.ClearTaskRecurrence(ctx, id, "another")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("UpdateTask", ctx, &entity.Task{ID: task.ID, UserID: task.UserID, ListID: task.ListID, Position: task.Position, Name: task.Name, CreatedAt: now, UpdatedAt: upd}).Return(errExp)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		err := srv.ClearTaskRecurrence(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_AddTaskDependency(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	bid := "bid"
+	now := time.Now().UTC()
+	task := &entity.Task{ID: value.NewID(id), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task", CreatedAt: now, UpdatedAt: now}
+	blocker := &entity.Task{ID: value.NewID(bid), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "r", Name: "blocker", CreatedAt: now, UpdatedAt: now}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("FindTaskByID", ctx, bid).Return(blocker, nil)
+		repo.On("FindBlockerIDs", ctx, bid).Return([]string{"other"}, nil)
+		repo.On("AddTaskDependency", ctx, id, bid, now).Return(int64(1), nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		ur := newPushUndoRepoMock(ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID(id), Action: value.TaskHistoryActionDependencyAdded, OldValue: "", NewValue: bid, CreatedAt: now})
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.AddTaskDependency(ctx, id, uid, bid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("正常系: 既に登録されている場合は取り消しのスタックに積まないこと", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("FindTaskByID", ctx, bid).Return(blocker, nil)
+		repo.On("FindBlockerIDs", ctx, bid).Return([]string{}, nil)
+		repo.On("AddTaskDependency", ctx, id, bid, now).Return(int64(0), nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		ur := new(mocks.ITaskUndoRepository)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		err := srv.AddTaskDependency(ctx, id, uid, bid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertNotCalled(t, "CreateTaskUndoEntry", ctx, mock.Anything)
+	})
+	tt.Run("準正常系: 自分自身に依存する場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "task cannot depend on itself"}
+		repo := new(mocks.ITaskRepository)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.AddTaskDependency(ctx, id, uid, id)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: BlockerIDが空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		repo := new(mocks.ITaskRepository)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.AddTaskDependency(ctx, id, uid, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないTaskIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(nil, errExp)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.AddTaskDependency(ctx, id, uid, bid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないブロッカーの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "blocker task not found"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("FindTaskByID", ctx, bid).Return(nil, errExp)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.AddTaskDependency(ctx, id, uid, bid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.AddTaskDependency(ctx, id, "another", bid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: ブロッカーが別のユーザーのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("FindTaskByID", ctx, bid).Return(&entity.Task{ID: value.NewID(bid), UserID: value.NewID("another"), ListID: value.NewID("lid2"), Position: "i", Name: "blocker", CreatedAt: now, UpdatedAt: now}, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.AddTaskDependency(ctx, id, uid, bid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 依存関係が循環する場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "task dependency would create a cycle"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("FindTaskByID", ctx, bid).Return(blocker, nil)
+		repo.On("FindBlockerIDs", ctx, bid).Return([]string{"other", id}, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.AddTaskDependency(ctx, id, uid, bid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("FindTaskByID", ctx, bid).Return(blocker, nil)
+		repo.On("FindBlockerIDs", ctx, bid).Return([]string{}, nil)
+		repo.On("AddTaskDependency", ctx, id, bid, now).Return(int64(0), errExp)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		err := srv.AddTaskDependency(ctx, id, uid, bid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_RemoveTaskDependency(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	bid := "bid"
+	now := time.Now().UTC()
+	task := &entity.Task{ID: value.NewID(id), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task", CreatedAt: now, UpdatedAt: now}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("RemoveTaskDependency", ctx, id, bid).Return(int64(1), nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		ur := newPushUndoRepoMock(ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID(id), Action: value.TaskHistoryActionDependencyRemoved, OldValue: bid, NewValue: "", CreatedAt: now})
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.RemoveTaskDependency(ctx, id, uid, bid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 登録されていない場合は取り消しのスタックに積まないこと", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("RemoveTaskDependency", ctx, id, bid).Return(int64(0), nil)
+		ur := new(mocks.ITaskUndoRepository)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.RemoveTaskDependency(ctx, id, uid, bid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertNotCalled(t, "CreateTaskUndoEntry", ctx, mock.Anything)
+	})
+	tt.Run("準正常系: 存在しないTaskIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(nil, errExp)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.RemoveTaskDependency(ctx, id, uid, bid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.RemoveTaskDependency(ctx, id, "another", bid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("RemoveTaskDependency", ctx, id, bid).Return(int64(0), errExp)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.RemoveTaskDependency(ctx, id, uid, bid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_TransitionTask(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	now := time.Now().UTC()
+	upd := now.Add(time.Second)
+	newTask := func(status value.TaskStatus, parentID *value.ID) *entity.Task {
+		return &entity.Task{ID: value.NewID(id), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task", Status: status, ParentID: parentID, CreatedAt: now, UpdatedAt: now}
+	}
+	updated := func(status value.TaskStatus, parentID *value.ID) *entity.Task {
+		v := newTask(status, parentID)
+		v.UpdatedAt = upd
+		return v
+	}
+
+	tt.Run("正常系: 未着手から進行中に変更する場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(value.TaskStatusTodo, nil), nil)
+		repo.On("CountOpenBlockers", ctx, id).Return(int64(0), nil)
+		repo.On("UpdateTask", ctx, updated(value.TaskStatusInProgress, nil)).Return(nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: value.NewID(id), ActorID: value.NewID(uid), Action: value.TaskHistoryActionStatusChanged, OldValue: "todo", NewValue: "in_progress", CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.TransitionTask(ctx, id, uid, value.TaskStatusInProgress)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		im.AssertExpectations(t)
+	})
+	tt.Run("正常系: 進行中から待機中に変更する場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(value.TaskStatusInProgress, nil), nil)
+		repo.On("UpdateTask", ctx, updated(value.TaskStatusWaiting, nil)).Return(nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: value.NewID(id), ActorID: value.NewID(uid), Action: value.TaskHistoryActionStatusChanged, OldValue: "in_progress", NewValue: "waiting", CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.TransitionTask(ctx, id, uid, value.TaskStatusWaiting)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		im.AssertExpectations(t)
+	})
+	tt.Run("正常系: 待機中から中止に変更する場合はブロッカーを確認しないこと", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(value.TaskStatusWaiting, nil), nil)
+		repo.On("CountOpenDescendants", ctx, id).Return(int64(0), nil)
+		repo.On("UpdateTask", ctx, updated(value.TaskStatusCancelled, nil)).Return(nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: value.NewID(id), ActorID: value.NewID(uid), Action: value.TaskHistoryActionStatusChanged, OldValue: "waiting", NewValue: "cancelled", CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.TransitionTask(ctx, id, uid, value.TaskStatusCancelled)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		im.AssertExpectations(t)
+	})
+	tt.Run("正常系: 中止したサブタスクを未着手に戻す場合", func(t *testing.T) {
+		pid := value.NewID("pid")
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(value.TaskStatusCancelled, pid), nil)
+		repo.On("FindTaskByID", ctx, "pid").Return(&entity.Task{ID: pid, UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "a", Name: "parent", Status: value.TaskStatusInProgress, CreatedAt: now, UpdatedAt: now}, nil)
+		repo.On("UpdateTask", ctx, updated(value.TaskStatusTodo, pid)).Return(nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: value.NewID(id), ActorID: value.NewID(uid), Action: value.TaskHistoryActionStatusChanged, OldValue: "cancelled", NewValue: "todo", CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.TransitionTask(ctx, id, uid, value.TaskStatusTodo)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		im.AssertExpectations(t)
+	})
+	tt.Run("正常系: 現在と同じ状態の場合は何もしないこと", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(value.TaskStatusWaiting, nil), nil)
+		cm := new(mocks.IClockManager)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		err := srv.TransitionTask(ctx, id, uid, value.TaskStatusWaiting)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 状態が不正な場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "invalid status"}
+		repo := new(mocks.ITaskRepository)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.TransitionTask(ctx, id, uid, value.TaskStatusUnknown)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないTaskIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(nil, errExp)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.TransitionTask(ctx, id, uid, value.TaskStatusDone)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(value.TaskStatusTodo, nil), nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.TransitionTask(ctx, id, "another", value.TaskStatusDone)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 遷移表にない遷移の場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "invalid status transition"}
+		testcases := []struct {
+			from value.TaskStatus
+			to   value.TaskStatus
+		}{
+			{value.TaskStatusCancelled, value.TaskStatusDone},
+			{value.TaskStatusCancelled, value.TaskStatusInProgress},
+			{value.TaskStatusDone, value.TaskStatusWaiting},
+			{value.TaskStatusDone, value.TaskStatusCancelled},
+		}
+		for _, v := range testcases {
+			repo := new(mocks.ITaskRepository)
+			repo.On("FindTaskByID", ctx, id).Return(newTask(v.from, nil), nil)
+			srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+			err := srv.TransitionTask(ctx, id, uid, v.to)
+
+			require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+			repo.AssertExpectations(t)
+		}
+	})
+	tt.Run("準正常系: 未完了のブロッカーが残っている場合は開始できないこと", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "task is blocked by open tasks"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(value.TaskStatusWaiting, nil), nil)
+		repo.On("CountOpenBlockers", ctx, id).Return(int64(2), nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.TransitionTask(ctx, id, uid, value.TaskStatusInProgress)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 未完了のサブタスクが残っている場合は中止できないこと", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "task has open subtasks"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(value.TaskStatusTodo, nil), nil)
+		repo.On("CountOpenDescendants", ctx, id).Return(int64(1), nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.TransitionTask(ctx, id, uid, value.TaskStatusCancelled)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 親タスクが中止されている場合は再開できないこと", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "parent task is completed"}
+		pid := value.NewID("pid")
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(value.TaskStatusDone, pid), nil)
+		repo.On("FindTaskByID", ctx, "pid").Return(&entity.Task{ID: pid, UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "a", Name: "parent", Status: value.TaskStatusCancelled, CreatedAt: now, UpdatedAt: now}, nil)
+		repo.On("CountOpenBlockers", ctx, id).Return(int64(0), nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.TransitionTask(ctx, id, uid, value.TaskStatusInProgress)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(value.TaskStatusTodo, nil), nil)
+		repo.On("UpdateTask", ctx, updated(value.TaskStatusWaiting, nil)).Return(errExp)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		err := srv.TransitionTask(ctx, id, uid, value.TaskStatusWaiting)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_SharedAccess(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	upd := now.Add(time.Second)
+	id := "id"
+	owner := "owner"
+	uid := "uid"
+	lid := "lid"
+	task := &entity.Task{ID: value.NewID(id), UserID: value.NewID(owner), ListID: value.NewID(lid), Position: "i", Name: "task", Status: value.TaskStatusTodo, CreatedAt: now, UpdatedAt: now}
+	list := &entity.List{ID: value.NewID(lid), UserID: value.NewID(owner), Name: "list", CreatedAt: now, UpdatedAt: now}
+
+	tt.Run("正常系: 編集者はタスクを編集でき、履歴には編集者が記録されること", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("UpdateTask", ctx, &entity.Task{ID: task.ID, UserID: task.UserID, ListID: task.ListID, Position: task.Position, Name: "new task", Status: task.Status, CreatedAt: now, UpdatedAt: upd}).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: task.ID, ActorID: value.NewID(uid), Action: value.TaskHistoryActionRenamed, OldValue: "task", NewValue: "new task", CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newSharedPolicy(value.RoleEditor), im, cm)
+		err := srv.ChangeTaskName(ctx, id, uid, "new task")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 閲覧者はタスクを編集できないこと", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newSharedPolicy(value.RoleViewer), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.ChangeTaskName(ctx, id, uid, "new task")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 編集者はタスクを削除できないこと", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newSharedPolicy(value.RoleEditor), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.DeleteTask(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("正常系: 管理者はタスクを削除できること", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		repo.On("DeleteTask", ctx, id, upd).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: task.ID, ActorID: value.NewID(uid), Action: value.TaskHistoryActionDeleted, CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newSharedPolicy(value.RoleAdmin), im, cm)
+		err := srv.DeleteTask(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+	})
+	tt.Run("正常系: 共有されたリストに作成したタスクはリストの所有者が所有すること", func(t *testing.T) {
+		created := &entity.Task{ID: value.NewID("new"), UserID: value.NewID(owner), ListID: list.ID, Position: "i", Name: "shared", Status: value.TaskStatusTodo, CreatedAt: now, UpdatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindMinTaskPosition", ctx, lid).Return(value.Rank(""), nil)
+		repo.On("CreateTask", ctx, created).Return("new", nil)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(list, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("new")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("new"), TaskID: created.ID, ActorID: value.NewID(uid), Action: value.TaskHistoryActionCreated, NewValue: "shared", CreatedAt: now}).Return(nil)
+		srv := NewTaskService(repo, listRepo, hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newSharedPolicy(value.RoleEditor), im, cm)
+		ret, err := srv.CreateTask(ctx, uid, lid, "shared")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, "new", ret)
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 閲覧者は共有されたリストにタスクを作成できないこと", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(list, nil)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newSharedPolicy(value.RoleViewer), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.CreateTask(ctx, uid, lid, "shared")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 管理者でも別のユーザーのリストには移動できないこと", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "list belongs to another user"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, "own").Return(&entity.List{ID: value.NewID("own"), UserID: value.NewID(uid), Name: "own", CreatedAt: now, UpdatedAt: now}, nil)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newSharedPolicy(value.RoleAdmin), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.MoveTaskToList(ctx, id, uid, "own")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_FindTasksByAssigneeID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID("owner"), ListID: value.NewID("lid"), Position: "i", Name: "task1", CreatedAt: now, UpdatedAt: now, AssigneeID: value.NewID(uid)},
+		{ID: value.NewID("t2"), UserID: value.NewID(uid), ListID: value.NewID("l2"), Position: "i", Name: "task2", CreatedAt: now, UpdatedAt: now, AssigneeID: value.NewID(uid)},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTasksByAssigneeID", ctx, uid, value.TaskOrderPriority).Return(tasks, nil)
+		repo.On("FindBlockedTaskIDs", ctx, []string{"t1", "t2"}).Return([]string{"t2"}, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		ret, err := srv.FindTasksByAssigneeID(ctx, uid, value.TaskOrderPriority)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Len(t, ret, 2)
+		require.False(t, ret[0].IsBlocked)
+		require.True(t, ret[1].IsBlocked, "ブロックされていること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 並び順が不正な場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "invalid task order"}
+		repo := new(mocks.ITaskRepository)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindTasksByAssigneeID(ctx, uid, value.TaskOrder(9))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 取得に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTasksByAssigneeID", ctx, uid, value.TaskOrderUpdatedAt).Return(nil, errors.New("error"))
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindTasksByAssigneeID(ctx, uid, value.TaskOrderUpdatedAt)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_FindTasksByWorkspaceID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	wid := "wid"
+	uid := "uid"
+	tasks := []*entity.Task{
+		{ID: value.NewID("t1"), UserID: value.NewID("other"), ListID: value.NewID("lid"), Position: "i", Name: "task1", CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: メンバーの場合", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTasksByWorkspaceID", ctx, wid, value.TaskOrderPosition).Return(tasks, nil)
+		repo.On("FindBlockedTaskIDs", ctx, []string{"t1"}).Return([]string{}, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newWorkspacePolicy(value.WorkspaceRoleMember), new(mocks.IIDManager), new(mocks.IClockManager))
+		ret, err := srv.FindTasksByWorkspaceID(ctx, wid, uid, value.TaskOrderPosition)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, tasks, ret)
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: メンバーではない場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newWorkspacePolicy(value.WorkspaceRoleNone), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindTasksByWorkspaceID(ctx, wid, uid, value.TaskOrderPosition)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 並び順が不正な場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "invalid task order"}
+		repo := new(mocks.ITaskRepository)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newWorkspacePolicy(value.WorkspaceRoleMember), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindTasksByWorkspaceID(ctx, wid, uid, value.TaskOrder(9))
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 取得に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTasksByWorkspaceID", ctx, wid, value.TaskOrderUpdatedAt).Return(nil, errors.New("error"))
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newWorkspacePolicy(value.WorkspaceRoleMember), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindTasksByWorkspaceID(ctx, wid, uid, value.TaskOrderUpdatedAt)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_AssignTask(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	aid := "aid"
+	now := time.Now().UTC()
+	upd := now.Add(time.Second)
+	newTask := func() *entity.Task {
+		return &entity.Task{ID: value.NewID(id), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task", CreatedAt: now, UpdatedAt: now}
+	}
+	// 担当者に対してroleの権限が共有されている認可ポリシーを作成する
+	newAssigneePolicy := func(role value.Role) *AuthorizationPolicy {
+		cr := new(mocks.ICollaboratorRepository)
+		cr.On("FindTaskRole", ctx, id, aid).Return(role, nil)
+		return NewAuthorizationPolicy(cr, new(mocks.IWorkspaceRepository))
+	}
+
+	tt.Run("正常系: 共有された閲覧者を担当者にする場合", func(t *testing.T) {
+		arg := newTask()
+		arg.AssigneeID = value.NewID(aid)
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("UpdateTask", ctx, arg).Return(nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		ur := newPushUndoRepoMock(ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID(id), Action: value.TaskHistoryActionAssigneeChanged, OldValue: "", NewValue: aid, CreatedAt: upd})
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newAssigneePolicy(value.RoleViewer), im, cm)
+		err := srv.AssignTask(ctx, id, uid, aid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("正常系: 所有者が自分を担当者にする場合", func(t *testing.T) {
+		arg := newTask()
+		arg.AssigneeID = value.NewID(uid)
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("UpdateTask", ctx, arg).Return(nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		ur := newPushUndoRepoMock(ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID(id), Action: value.TaskHistoryActionAssigneeChanged, OldValue: "", NewValue: uid, CreatedAt: upd})
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.AssignTask(ctx, id, uid, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 既に同じ担当者の場合は何もしないこと", func(t *testing.T) {
+		task := newTask()
+		task.AssigneeID = value.NewID(aid)
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.AssignTask(ctx, id, uid, aid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 担当者のIDが空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		repo := new(mocks.ITaskRepository)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.AssignTask(ctx, id, uid, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 担当者がタスクを閲覧できない場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "assignee cannot access the task"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newAssigneePolicy(value.RoleNone), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.AssignTask(ctx, id, uid, aid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 閲覧者が担当者を設定する場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newSharedPolicy(value.RoleViewer), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.AssignTask(ctx, id, "other", aid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 存在しないTaskIDの場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(nil, errors.New("not found"))
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.AssignTask(ctx, id, uid, aid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 更新に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		arg := newTask()
+		arg.AssigneeID = value.NewID(aid)
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("UpdateTask", ctx, arg).Return(errors.New("error"))
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newAssigneePolicy(value.RoleEditor), new(mocks.IIDManager), cm)
+		err := srv.AssignTask(ctx, id, uid, aid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_UnassignTask(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	now := time.Now().UTC()
+	upd := now.Add(time.Second)
+	newTask := func() *entity.Task {
+		return &entity.Task{ID: value.NewID(id), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task", CreatedAt: now, UpdatedAt: now, AssigneeID: value.NewID("aid")}
+	}
+
+	tt.Run("正常系: 担当者を外す場合", func(t *testing.T) {
+		arg := newTask()
+		arg.AssigneeID = nil
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		repo.On("UpdateTask", ctx, arg).Return(nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		ur := newPushUndoRepoMock(ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID(id), Action: value.TaskHistoryActionAssigneeChanged, OldValue: "aid", NewValue: "", CreatedAt: upd})
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		err := srv.UnassignTask(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("正常系: 担当者がいない場合は何もしないこと", func(t *testing.T) {
+		task := newTask()
+		task.AssigneeID = nil
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(task, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.UnassignTask(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 他のユーザーのタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, id).Return(newTask(), nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.UnassignTask(ctx, id, "other")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_BatchMutateTasks(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	now := time.Now().UTC()
+	newTask := func() *entity.Task {
+		return &entity.Task{ID: value.NewID("id"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task", Status: value.TaskStatusTodo, CreatedAt: now, UpdatedAt: now}
+	}
+	rename := value.TaskOperation{Kind: value.TaskOperationKindRename, TaskID: "id", Name: "new task"}
+	deleteMissing := value.TaskOperation{Kind: value.TaskOperationKindDelete, TaskID: "missing"}
+
+	tt.Run("正常系: ベストエフォートの場合は失敗した操作以外を実行すること", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask(), nil)
+		repo.On("FindTaskByID", ctx, "missing").Return(nil, errors.New("no rows"))
+		repo.On("UpdateTask", ctx, mock.AnythingOfType("*entity.Task")).Return(nil)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, mock.AnythingOfType("*entity.TaskHistory")).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		txm := newSavepointTxManagerMock(ctx)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), txm, newUnsharedPolicy(), im, cm)
+		ret, err := srv.BatchMutateTasks(ctx, uid, []value.TaskOperation{deleteMissing, rename, {Kind: value.TaskOperationKind(9), TaskID: "id"}}, false)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, []*entity.TaskOperationResult{
+			{Status: value.TaskOperationStatusFailed, TaskID: "missing", Err: &domain.ErrNotFound{Msg: "task not found"}},
+			{Status: value.TaskOperationStatusSucceeded, TaskID: "id"},
+			{Status: value.TaskOperationStatusFailed, TaskID: "id", Err: &domain.ErrValidationFailed{Msg: "invalid operation"}},
+		}, ret)
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		txm.AssertNumberOfCalls(t, "RunInSavepoint", 3)
+	})
+	tt.Run("正常系: 全て成功した場合は作成したタスクのIDを返すこと", func(t *testing.T) {
+		list := &entity.List{ID: value.NewID("lid"), UserID: value.NewID(uid), Name: "list", CreatedAt: now, UpdatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask(), nil)
+		repo.On("UpdateTask", ctx, mock.AnythingOfType("*entity.Task")).Return(nil)
+		repo.On("FindMinTaskPosition", ctx, "lid").Return(value.Rank(""), nil)
+		repo.On("CreateTask", ctx, mock.AnythingOfType("*entity.Task")).Return("created", nil)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, "lid").Return(list, nil)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, mock.AnythingOfType("*entity.TaskHistory")).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("gid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		txm := newTxManagerMock(ctx)
+		srv := NewTaskService(repo, listRepo, hr, newUndoRepoMock(ctx), txm, newUnsharedPolicy(), im, cm)
+		ret, err := srv.BatchMutateTasks(ctx, uid, []value.TaskOperation{{Kind: value.TaskOperationKindCreate, ListID: "lid", Name: "created"}, rename}, true)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, []*entity.TaskOperationResult{
+			{Status: value.TaskOperationStatusSucceeded, TaskID: "created"},
+			{Status: value.TaskOperationStatusSucceeded, TaskID: "id"},
+		}, ret)
+		repo.AssertExpectations(t)
+		txm.AssertNotCalled(t, "RunInSavepoint", ctx, mock.Anything)
+	})
+	tt.Run("準正常系: 全てか無しの場合は1件の失敗で全て取り消すこと", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask(), nil)
+		repo.On("FindTaskByID", ctx, "missing").Return(nil, errors.New("no rows"))
+		repo.On("UpdateTask", ctx, mock.AnythingOfType("*entity.Task")).Return(nil)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, mock.AnythingOfType("*entity.TaskHistory")).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, newUndoRepoMock(ctx), newTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		ret, err := srv.BatchMutateTasks(ctx, uid, []value.TaskOperation{rename, deleteMissing, rename}, true)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, []*entity.TaskOperationResult{
+			{Status: value.TaskOperationStatusAborted, TaskID: "id"},
+			{Status: value.TaskOperationStatusFailed, TaskID: "missing", Err: &domain.ErrNotFound{Msg: "task not found"}},
+			{Status: value.TaskOperationStatusAborted, TaskID: "id"},
+		}, ret)
+		repo.AssertNumberOfCalls(t, "UpdateTask", 1)
+	})
+	tt.Run("準正常系: トランザクションの確定に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "missing").Return(nil, errors.New("no rows"))
+		txm := new(mocks.ITransactionManager)
+		txm.On("RunInTx", ctx, mock.AnythingOfType("func(context.Context) error")).Return(func(ctx context.Context, fn func(context.Context) error) error {
+			if err := fn(ctx); err != nil {
+				return err
+			}
+			return errors.New("failed to commit")
+		})
+		txm.On("RunInSavepoint", ctx, mock.AnythingOfType("func(context.Context) error")).Return(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), txm, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.BatchMutateTasks(ctx, uid, []value.TaskOperation{deleteMissing}, false)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_ImportTasks(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	lid := "lid"
+	now := time.Now().UTC()
+	list := &entity.List{ID: value.NewID(lid), UserID: value.NewID(uid), Name: "list", CreatedAt: now, UpdatedAt: now}
+	existing := []*entity.Task{
+		{ID: value.NewID("id"), UserID: value.NewID(uid), ListID: value.NewID(lid), Position: "i", Name: "a", Status: value.TaskStatusTodo, CreatedAt: now, UpdatedAt: now},
+	}
+	rows := []value.TaskImportRow{
+		{Line: 1, Name: "a", Status: value.TaskStatusTodo},
+		{Line: 2, Name: "b", Status: value.TaskStatusTodo, Priority: value.PriorityHigh},
+		{Line: 3, Name: "b", Status: value.TaskStatusDone},
+		{Line: 4, Name: "broken", Status: value.TaskStatusTodo},
+		{Line: 5, Name: "c", Status: value.TaskStatusDone},
+	}
+	newRepo := func() *mocks.ITaskRepository {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTasksByListID", ctx, lid, value.TaskOrderPosition).Return(existing, nil)
+		repo.On("FindMinTaskPosition", ctx, lid).Return(value.Rank("i"), nil)
+		repo.On("CreateTask", ctx, mock.MatchedBy(func(v *entity.Task) bool { return v.Name == "broken" })).Return("", errors.New("failed"))
+		repo.On("CreateTask", ctx, mock.AnythingOfType("*entity.Task")).Return("gid", nil)
+		return repo
+	}
+	newListRepo := func(list *entity.List) *mocks.IListRepository {
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(list, nil)
+		return listRepo
+	}
+	newHistoryRepo := func() *mocks.ITaskHistoryRepository {
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, mock.AnythingOfType("*entity.TaskHistory")).Return(nil)
+		return hr
+	}
+	newIDManager := func() *mocks.IIDManager {
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("gid")
+		return im
+	}
+	newClockManager := func() *mocks.IClockManager {
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		return cm
+	}
+
+	tt.Run("正常系: 重複と失敗以外の行をファイルの順にリストの先頭に作成すること", func(t *testing.T) {
+		repo := newRepo()
+		ur := new(mocks.ITaskUndoRepository)
+		srv := NewTaskService(repo, newListRepo(list), newHistoryRepo(), ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), newIDManager(), newClockManager())
+		ret, err := srv.ImportTasks(ctx, uid, lid, rows, false)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, []*entity.TaskImportResult{
+			{Line: 1, Name: "a", Status: value.TaskImportStatusDuplicate},
+			{Line: 2, Name: "b", Status: value.TaskImportStatusCreated, TaskID: "gid"},
+			{Line: 3, Name: "b", Status: value.TaskImportStatusDuplicate},
+			{Line: 4, Name: "broken", Status: value.TaskImportStatusFailed, Err: &domain.ErrQueryFailed{}},
+			{Line: 5, Name: "c", Status: value.TaskImportStatusCreated, TaskID: "gid"},
+		}, ret)
+		var created []*entity.Task
+		for _, c := range repo.Calls {
+			if c.Method == "CreateTask" {
+				created = append(created, c.Arguments.Get(1).(*entity.Task))
+			}
+		}
+		require.Len(t, created, 3)
+		require.Equal(t, value.PriorityHigh, created[0].Priority, "行の内容で作成されること")
+		require.Equal(t, value.TaskStatusDone, created[2].Status, "行の内容で作成されること")
+		require.Less(t, created[0].Position, created[2].Position, "ファイルの順に並ぶこと")
+		require.Less(t, created[2].Position, value.Rank("i"), "既存のタスクより前に並ぶこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 試行の場合はロールバックしてタスクのIDを返さないこと", func(t *testing.T) {
+		txm := newSavepointTxManagerMock(ctx)
+		srv := NewTaskService(newRepo(), newListRepo(list), newHistoryRepo(), new(mocks.ITaskUndoRepository), txm, newUnsharedPolicy(), newIDManager(), newClockManager())
+		ret, err := srv.ImportTasks(ctx, uid, lid, rows[1:2], true)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, []*entity.TaskImportResult{
+			{Line: 2, Name: "b", Status: value.TaskImportStatusCreated},
+		}, ret)
+		txm.AssertNumberOfCalls(t, "RunInSavepoint", 1)
+	})
+	tt.Run("準正常系: アーカイブされたリストの場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "list is archived"}
+		archived := *list
+		archived.IsArchived = true
+		repo := new(mocks.ITaskRepository)
+		srv := NewTaskService(repo, newListRepo(&archived), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.ImportTasks(ctx, uid, lid, rows, false)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: リストが存在しない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "list not found"}
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, lid).Return(nil, errors.New("no rows"))
+		srv := NewTaskService(new(mocks.ITaskRepository), listRepo, new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), newTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.ImportTasks(ctx, uid, lid, rows, false)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		listRepo.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_pushUndo(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	now := time.Now().UTC()
+
+	tt.Run("正常系: やり直しのスタックを空にして取り消しのスタックに積むこと", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("uid1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionRenamed, OldValue: "task", NewValue: "new task", CreatedAt: now}
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("DeleteTaskUndoEntries", ctx, uid, value.UndoStackRedo).Return(nil)
+		ur.On("CreateTaskUndoEntry", ctx, entry).Return(nil)
+		ur.On("TrimTaskUndoEntries", ctx, uid, value.UndoStackUndo, int32(undoStackLimit), now.Add(-undoExpiry)).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("uid1")
+		srv := NewTaskService(new(mocks.ITaskRepository), new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, new(mocks.ITransactionManager), newUnsharedPolicy(), im, new(mocks.IClockManager))
+		err := srv.pushUndo(ctx, uid, entry.TaskID, entry.Action, entry.OldValue, entry.NewValue, nil, now)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		ur.AssertExpectations(t)
+		im.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("DeleteTaskUndoEntries", ctx, uid, value.UndoStackRedo).Return(nil)
+		ur.On("CreateTaskUndoEntry", ctx, mock.AnythingOfType("*entity.TaskUndoEntry")).Return(errors.New("failed"))
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("uid1")
+		srv := NewTaskService(new(mocks.ITaskRepository), new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, new(mocks.ITransactionManager), newUnsharedPolicy(), im, new(mocks.IClockManager))
+		err := srv.pushUndo(ctx, uid, value.NewID("id"), value.TaskHistoryActionDeleted, "", "", nil, now)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		ur.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_Undo(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	now := time.Now().UTC()
+	upd := now.Add(time.Minute)
+	newTask := func(name string, status value.TaskStatus) *entity.Task {
+		return &entity.Task{ID: value.NewID("id"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: name, Status: status, CreatedAt: now, UpdatedAt: now}
+	}
+	rename := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionRenamed, OldValue: "task", NewValue: "new task", CreatedAt: now}
+
+	tt.Run("正常系: 名前の変更を取り消してやり直しのスタックに積むこと", func(t *testing.T) {
+		arg := newTask("task", value.TaskStatusTodo)
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask("new task", value.TaskStatusTodo), nil)
+		repo.On("UpdateTask", ctx, arg).Return(nil)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: arg.ID, ActorID: value.NewID(uid), Action: value.TaskHistoryActionRenamed, OldValue: "new task", NewValue: "task", CreatedAt: upd}).Return(nil)
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("PopTaskUndoEntry", ctx, uid, value.UndoStackUndo, upd.Add(-undoExpiry)).Return(rename, nil)
+		ur.On("CreateTaskUndoEntry", ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: rename.UserID, Stack: value.UndoStackRedo, TaskID: rename.TaskID, Action: rename.Action, OldValue: "task", NewValue: "new task", CreatedAt: upd}).Return(nil)
+		ur.On("TrimTaskUndoEntries", ctx, uid, value.UndoStackRedo, int32(undoStackLimit), upd.Add(-undoExpiry)).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		ret, err := srv.Undo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, rename, ret, "取り消した操作が返されること")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 繰り返しのタスクの完了を取り消す場合は次の回のタスクをゴミ箱に移動すること", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), TaskID: value.NewID("id"), Action: value.TaskHistoryActionStatusChanged, OldValue: "todo", NewValue: "done", NextTaskID: value.NewID("next"), CreatedAt: now}
+		next := &entity.Task{ID: value.NewID("next"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "h", Name: "task", Status: value.TaskStatusTodo, CreatedAt: now, UpdatedAt: now, RecurrenceRule: "FREQ=DAILY", RecurrenceTimezone: "Asia/Tokyo"}
+		arg := newTask("task", value.TaskStatusTodo)
+		arg.UpdatedAt = upd
+		arg.RecurrenceRule = "FREQ=DAILY"
+		arg.RecurrenceTimezone = "Asia/Tokyo"
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask("task", value.TaskStatusDone), nil)
+		repo.On("FindTaskByID", ctx, "next").Return(next, nil)
+		repo.On("DeleteTask", ctx, "next", upd).Return(nil)
+		repo.On("UpdateTask", ctx, arg).Return(nil)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, mock.AnythingOfType("*entity.TaskHistory")).Return(nil).Twice()
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("PopTaskUndoEntry", ctx, uid, value.UndoStackUndo, upd.Add(-undoExpiry)).Return(entry, nil)
+		ur.On("CreateTaskUndoEntry", ctx, mock.AnythingOfType("*entity.TaskUndoEntry")).Return(nil)
+		ur.On("TrimTaskUndoEntries", ctx, uid, value.UndoStackRedo, int32(undoStackLimit), upd.Add(-undoExpiry)).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 操作後にタスクが変更されている場合は競合として操作を取り除くこと", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "task has changed since the operation"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask("another name", value.TaskStatusTodo), nil)
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("PopTaskUndoEntry", ctx, uid, value.UndoStackUndo, upd.Add(-undoExpiry)).Return(rename, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		txm := newSavepointTxManagerMock(ctx)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, txm, newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertNotCalled(t, "UpdateTask", ctx, mock.Anything)
+		ur.AssertNotCalled(t, "CreateTaskUndoEntry", ctx, mock.Anything)
+		ur.AssertExpectations(t)
+		txm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 繰り返しの次の回のタスクが開始されている場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "task has changed since the operation"}
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), TaskID: value.NewID("id"), Action: value.TaskHistoryActionStatusChanged, OldValue: "todo", NewValue: "done", NextTaskID: value.NewID("next"), CreatedAt: now}
+		next := &entity.Task{ID: value.NewID("next"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "h", Name: "task", Status: value.TaskStatusInProgress, CreatedAt: now, UpdatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask("task", value.TaskStatusDone), nil)
+		repo.On("FindTaskByID", ctx, "next").Return(next, nil)
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("PopTaskUndoEntry", ctx, uid, value.UndoStackUndo, upd.Add(-undoExpiry)).Return(entry, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertNotCalled(t, "DeleteTask", ctx, "next", mock.Anything)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 優先度の変更を取り消すこと", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionPriorityChanged, OldValue: "1", NewValue: "3", CreatedAt: now}
+		task := newTask("task", value.TaskStatusTodo)
+		task.Priority = value.PriorityHigh
+		arg := newTask("task", value.TaskStatusTodo)
+		arg.Priority = value.PriorityLow
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(task, nil)
+		repo.On("UpdateTask", ctx, arg).Return(nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: entry.TaskID, ActorID: value.NewID(uid), Action: entry.Action, OldValue: entry.NewValue, NewValue: entry.OldValue, CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		ret, err := srv.Undo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, entry, ret, "取り消した操作が返されること")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 操作後に優先度が変更されている場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "task has changed since the operation"}
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionPriorityChanged, OldValue: "1", NewValue: "3", CreatedAt: now}
+		task := newTask("task", value.TaskStatusTodo)
+		task.Priority = value.PriorityMedium
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(task, nil)
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("PopTaskUndoEntry", ctx, uid, value.UndoStackUndo, upd.Add(-undoExpiry)).Return(entry, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertNotCalled(t, "UpdateTask", ctx, mock.Anything)
+		ur.AssertNotCalled(t, "CreateTaskUndoEntry", ctx, mock.Anything)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 説明文の変更を取り消すこと", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionDescriptionChanged, OldValue: encodeUndoValue(taskDescriptionUndoValue{Description: "old", DescriptionHTML: "<p>old</p>\n"}), NewValue: encodeUndoValue(taskDescriptionUndoValue{Description: "new", DescriptionHTML: "<p>new</p>\n"}), CreatedAt: now}
+		task := newTask("task", value.TaskStatusTodo)
+		task.Description = "new"
+		task.DescriptionHTML = "<p>new</p>\n"
+		arg := newTask("task", value.TaskStatusTodo)
+		arg.Description = "old"
+		arg.DescriptionHTML = "<p>old</p>\n"
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(task, nil)
+		repo.On("UpdateTask", ctx, arg).Return(nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: entry.TaskID, ActorID: value.NewID(uid), Action: entry.Action, OldValue: entry.NewValue, NewValue: entry.OldValue, CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 担当者の設定を取り消すこと", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionAssigneeChanged, OldValue: "", NewValue: "aid", CreatedAt: now}
+		task := newTask("task", value.TaskStatusTodo)
+		task.AssigneeID = value.NewID("aid")
+		arg := newTask("task", value.TaskStatusTodo)
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(task, nil)
+		repo.On("UpdateTask", ctx, arg).Return(nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: entry.TaskID, ActorID: value.NewID(uid), Action: entry.Action, OldValue: entry.NewValue, NewValue: entry.OldValue, CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 外した担当者がタスクを閲覧できなくなっている場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "assignee cannot access the task"}
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionAssigneeChanged, OldValue: "aid", NewValue: "", CreatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask("task", value.TaskStatusTodo), nil)
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("PopTaskUndoEntry", ctx, uid, value.UndoStackUndo, upd.Add(-undoExpiry)).Return(entry, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		cr := new(mocks.ICollaboratorRepository)
+		cr.On("FindTaskRole", ctx, "id", "aid").Return(value.RoleNone, nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), NewAuthorizationPolicy(cr, new(mocks.IWorkspaceRepository)), new(mocks.IIDManager), cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertNotCalled(t, "UpdateTask", ctx, mock.Anything)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 期限の設定を取り消すこと", func(t *testing.T) {
+		due := now.AddDate(0, 0, 1).Truncate(time.Microsecond)
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionDueDateChanged, OldValue: "", NewValue: due.Format(time.RFC3339Nano), CreatedAt: now}
+		task := newTask("task", value.TaskStatusTodo)
+		task.DueAt = &due
+		arg := newTask("task", value.TaskStatusTodo)
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(task, nil)
+		repo.On("UpdateTask", ctx, arg).Return(nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: entry.TaskID, ActorID: value.NewID(uid), Action: entry.Action, OldValue: entry.NewValue, NewValue: entry.OldValue, CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 繰り返しの設定を取り消すこと", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionRecurrenceChanged, OldValue: encodeUndoValue(taskRecurrenceUndoValue{}), NewValue: encodeUndoValue(taskRecurrenceUndoValue{Rule: "FREQ=DAILY", Timezone: "Asia/Tokyo"}), CreatedAt: now}
+		task := newTask("task", value.TaskStatusTodo)
+		task.RecurrenceRule = "FREQ=DAILY"
+		task.RecurrenceTimezone = "Asia/Tokyo"
+		arg := newTask("task", value.TaskStatusTodo)
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(task, nil)
+		repo.On("UpdateTask", ctx, arg).Return(nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: entry.TaskID, ActorID: value.NewID(uid), Action: entry.Action, OldValue: entry.NewValue, NewValue: entry.OldValue, CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 繰り返しを戻すタスクが完了している場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "task is closed"}
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionRecurrenceChanged, OldValue: encodeUndoValue(taskRecurrenceUndoValue{Rule: "FREQ=DAILY", Timezone: "UTC"}), NewValue: encodeUndoValue(taskRecurrenceUndoValue{}), CreatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask("task", value.TaskStatusDone), nil)
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("PopTaskUndoEntry", ctx, uid, value.UndoStackUndo, upd.Add(-undoExpiry)).Return(entry, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertNotCalled(t, "UpdateTask", ctx, mock.Anything)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 親タスクの変更を取り消すこと", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionParentChanged, OldValue: "pid", NewValue: "", CreatedAt: now}
+		parent := &entity.Task{ID: value.NewID("pid"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "a", Name: "parent", Status: value.TaskStatusTodo, CreatedAt: now, UpdatedAt: now}
+		arg := newTask("task", value.TaskStatusTodo)
+		arg.ParentID = value.NewID("pid")
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask("task", value.TaskStatusTodo), nil)
+		repo.On("FindTaskByID", ctx, "pid").Return(parent, nil)
+		repo.On("FindAncestorIDs", ctx, "pid").Return([]string{"pid"}, nil)
+		repo.On("UpdateTask", ctx, arg).Return(nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: entry.TaskID, ActorID: value.NewID(uid), Action: entry.Action, OldValue: entry.NewValue, NewValue: entry.OldValue, CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 並び順の変更を取り消すこと", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionPositionChanged, OldValue: "r", NewValue: "i", CreatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask("task", value.TaskStatusTodo), nil)
+		repo.On("UpdateTaskPosition", ctx, "id", value.Rank("r")).Return(nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 操作後に並び順が変更されている場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "task has changed since the operation"}
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionPositionChanged, OldValue: "r", NewValue: "e", CreatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask("task", value.TaskStatusTodo), nil)
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("PopTaskUndoEntry", ctx, uid, value.UndoStackUndo, upd.Add(-undoExpiry)).Return(entry, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertNotCalled(t, "UpdateTaskPosition", ctx, mock.Anything, mock.Anything)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: リストの移動を取り消すこと", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionListChanged, OldValue: encodeTaskListUndoValue("old", "r"), NewValue: encodeTaskListUndoValue("lid", "i"), CreatedAt: now}
+		list := &entity.List{ID: value.NewID("old"), UserID: value.NewID(uid), Name: "list", CreatedAt: now, UpdatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask("task", value.TaskStatusTodo), nil)
+		repo.On("UpdateTaskTreeListID", ctx, "id", "old", upd).Return(nil)
+		repo.On("UpdateTaskPosition", ctx, "id", value.Rank("r")).Return(nil)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, "old").Return(list, nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 移動前のリストがアーカイブされている場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "list is archived"}
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionListChanged, OldValue: encodeTaskListUndoValue("old", "r"), NewValue: encodeTaskListUndoValue("lid", "i"), CreatedAt: now}
+		list := &entity.List{ID: value.NewID("old"), UserID: value.NewID(uid), Name: "list", IsArchived: true, CreatedAt: now, UpdatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask("task", value.TaskStatusTodo), nil)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, "old").Return(list, nil)
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("PopTaskUndoEntry", ctx, uid, value.UndoStackUndo, upd.Add(-undoExpiry)).Return(entry, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertNotCalled(t, "UpdateTaskTreeListID", ctx, mock.Anything, mock.Anything, mock.Anything)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 依存関係の追加を取り消すこと", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionDependencyAdded, NewValue: "bid", CreatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask("task", value.TaskStatusTodo), nil)
+		repo.On("RemoveTaskDependency", ctx, "id", "bid").Return(int64(1), nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 追加した依存関係が既に削除されている場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "task has changed since the operation"}
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionDependencyAdded, NewValue: "bid", CreatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask("task", value.TaskStatusTodo), nil)
+		repo.On("RemoveTaskDependency", ctx, "id", "bid").Return(int64(0), nil)
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("PopTaskUndoEntry", ctx, uid, value.UndoStackUndo, upd.Add(-undoExpiry)).Return(entry, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		ur.AssertNotCalled(t, "CreateTaskUndoEntry", ctx, mock.Anything)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 依存関係の削除を取り消すこと", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionDependencyRemoved, OldValue: "bid", CreatedAt: now}
+		blocker := &entity.Task{ID: value.NewID("bid"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "r", Name: "blocker", Status: value.TaskStatusTodo, CreatedAt: now, UpdatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask("task", value.TaskStatusTodo), nil)
+		repo.On("FindTaskByID", ctx, "bid").Return(blocker, nil)
+		repo.On("FindBlockerIDs", ctx, "bid").Return([]string{}, nil)
+		repo.On("AddTaskDependency", ctx, "id", "bid", upd).Return(int64(1), nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 作成を取り消してタスクをゴミ箱に移動すること", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionCreated, CreatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask("task", value.TaskStatusTodo), nil)
+		repo.On("DeleteTask", ctx, "id", upd).Return(nil)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: entry.TaskID, ActorID: value.NewID(uid), Action: value.TaskHistoryActionDeleted, CreatedAt: upd}).Return(nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 作成した後にタスクが更新されている場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "task has changed since the operation"}
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackUndo, TaskID: value.NewID("id"), Action: value.TaskHistoryActionCreated, CreatedAt: now}
+		task := newTask("updated", value.TaskStatusTodo)
+		task.UpdatedAt = now.Add(time.Second)
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(task, nil)
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("PopTaskUndoEntry", ctx, uid, value.UndoStackUndo, upd.Add(-undoExpiry)).Return(entry, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertNotCalled(t, "DeleteTask", ctx, "id", mock.Anything)
+		ur.AssertNotCalled(t, "CreateTaskUndoEntry", ctx, mock.Anything)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 取り消す操作がない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "nothing to undo"}
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("PopTaskUndoEntry", ctx, uid, value.UndoStackUndo, upd.Add(-undoExpiry)).Return(nil, errors.New("no rows"))
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(new(mocks.ITaskRepository), new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		ur.AssertExpectations(t)
+	})
+	tt.Run("準正常系: アクセス権がなくなった場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{Msg: "permission denied"}
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID("other"), TaskID: value.NewID("id"), Action: value.TaskHistoryActionRenamed, OldValue: "task", NewValue: "new task", CreatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask("new task", value.TaskStatusTodo), nil)
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("PopTaskUndoEntry", ctx, "other", value.UndoStackUndo, upd.Add(-undoExpiry)).Return(entry, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), newSharedPolicy(value.RoleViewer), new(mocks.IIDManager), cm)
+		_, err := srv.Undo(ctx, "other")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		ur.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 反映中にクエリエラーが発生した場合は全て戻すこと", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(newTask("new task", value.TaskStatusTodo), nil)
+		repo.On("UpdateTask", ctx, mock.AnythingOfType("*entity.Task")).Return(errors.New("failed"))
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("PopTaskUndoEntry", ctx, uid, value.UndoStackUndo, upd.Add(-undoExpiry)).Return(rename, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		txm := new(mocks.ITransactionManager)
+		txm.On("RunInTx", ctx, mock.AnythingOfType("func(context.Context) error")).Return(func(ctx context.Context, fn func(context.Context) error) error {
+			err := fn(ctx)
+			require.EqualError(t, err, errExp.Error(), "トランザクションがロールバックされること")
+			return err
+		})
+		txm.On("RunInSavepoint", ctx, mock.AnythingOfType("func(context.Context) error")).Return(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, txm, newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.Undo(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		txm.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_Redo(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	now := time.Now().UTC()
+	upd := now.Add(time.Minute)
+	task := &entity.Task{ID: value.NewID("id"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "i", Name: "task", Status: value.TaskStatusTodo, CreatedAt: now, UpdatedAt: now}
+	deleted := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackRedo, TaskID: task.ID, Action: value.TaskHistoryActionDeleted, CreatedAt: now}
+
+	tt.Run("正常系: 削除をやり直して取り消しのスタックに積むこと", func(t *testing.T) {
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(task, nil)
+		repo.On("DeleteTask", ctx, "id", upd).Return(nil)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: task.ID, ActorID: value.NewID(uid), Action: value.TaskHistoryActionDeleted, CreatedAt: upd}).Return(nil)
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("PopTaskUndoEntry", ctx, uid, value.UndoStackRedo, upd.Add(-undoExpiry)).Return(deleted, nil)
+		ur.On("CreateTaskUndoEntry", ctx, &entity.TaskUndoEntry{ID: value.NewID("hid"), UserID: deleted.UserID, Stack: value.UndoStackUndo, TaskID: task.ID, Action: value.TaskHistoryActionDeleted, CreatedAt: upd}).Return(nil)
+		ur.On("TrimTaskUndoEntries", ctx, uid, value.UndoStackUndo, int32(undoStackLimit), upd.Add(-undoExpiry)).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		ret, err := srv.Redo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, deleted, ret, "やり直した操作が返されること")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 取り消した後にタスクが削除されている場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "task has changed since the operation"}
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(nil, errors.New("no rows"))
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("PopTaskUndoEntry", ctx, uid, value.UndoStackRedo, upd.Add(-undoExpiry)).Return(deleted, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.Redo(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		ur.AssertExpectations(t)
+	})
+	tt.Run("準正常系: やり直す操作がない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "nothing to redo"}
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("PopTaskUndoEntry", ctx, uid, value.UndoStackRedo, upd.Add(-undoExpiry)).Return(nil, errors.New("no rows"))
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(new(mocks.ITaskRepository), new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.Redo(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 優先度の変更をやり直すこと", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackRedo, TaskID: task.ID, Action: value.TaskHistoryActionPriorityChanged, OldValue: "1", NewValue: "3", CreatedAt: now}
+		cur := *task
+		cur.Priority = value.PriorityLow
+		arg := *task
+		arg.Priority = value.PriorityHigh
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		repo.On("FindTaskByID", ctx, "id").Return(&cur, nil)
+		repo.On("UpdateTask", ctx, &arg).Return(nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: entry.TaskID, ActorID: value.NewID(uid), Action: entry.Action, OldValue: entry.OldValue, NewValue: entry.NewValue, CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		ret, err := srv.Redo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, entry, ret, "やり直した操作が返されること")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 説明文の変更をやり直すこと", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackRedo, TaskID: task.ID, Action: value.TaskHistoryActionDescriptionChanged, OldValue: encodeUndoValue(taskDescriptionUndoValue{}), NewValue: encodeUndoValue(taskDescriptionUndoValue{Description: "new", DescriptionHTML: "<p>new</p>\n"}), CreatedAt: now}
+		arg := *task
+		arg.Description = "new"
+		arg.DescriptionHTML = "<p>new</p>\n"
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		cur := *task
+		repo.On("FindTaskByID", ctx, "id").Return(&cur, nil)
+		repo.On("UpdateTask", ctx, &arg).Return(nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: entry.TaskID, ActorID: value.NewID(uid), Action: entry.Action, OldValue: entry.OldValue, NewValue: entry.NewValue, CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.Redo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 担当者の設定をやり直すこと", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackRedo, TaskID: task.ID, Action: value.TaskHistoryActionAssigneeChanged, OldValue: "", NewValue: "aid", CreatedAt: now}
+		arg := *task
+		arg.AssigneeID = value.NewID("aid")
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		cur := *task
+		repo.On("FindTaskByID", ctx, "id").Return(&cur, nil)
+		repo.On("UpdateTask", ctx, &arg).Return(nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		cr := new(mocks.ICollaboratorRepository)
+		cr.On("FindTaskRole", ctx, "id", "aid").Return(value.RoleViewer, nil)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: entry.TaskID, ActorID: value.NewID(uid), Action: entry.Action, OldValue: entry.OldValue, NewValue: entry.NewValue, CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, ur, newSavepointTxManagerMock(ctx), NewAuthorizationPolicy(cr, new(mocks.IWorkspaceRepository)), im, cm)
+		_, err := srv.Redo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		ur.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+	tt.Run("正常系: 期限の設定をやり直すこと", func(t *testing.T) {
+		due := now.AddDate(0, 0, 1).Truncate(time.Microsecond)
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackRedo, TaskID: task.ID, Action: value.TaskHistoryActionDueDateChanged, OldValue: "", NewValue: due.Format(time.RFC3339Nano), CreatedAt: now}
+		arg := *task
+		arg.DueAt = &due
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		cur := *task
+		repo.On("FindTaskByID", ctx, "id").Return(&cur, nil)
+		repo.On("UpdateTask", ctx, &arg).Return(nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: entry.TaskID, ActorID: value.NewID(uid), Action: entry.Action, OldValue: entry.OldValue, NewValue: entry.NewValue, CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.Redo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 繰り返しの設定をやり直すこと", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackRedo, TaskID: task.ID, Action: value.TaskHistoryActionRecurrenceChanged, OldValue: encodeUndoValue(taskRecurrenceUndoValue{}), NewValue: encodeUndoValue(taskRecurrenceUndoValue{Rule: "FREQ=DAILY", Timezone: "Asia/Tokyo"}), CreatedAt: now}
+		arg := *task
+		arg.RecurrenceRule = "FREQ=DAILY"
+		arg.RecurrenceTimezone = "Asia/Tokyo"
+		arg.UpdatedAt = upd
+		repo := new(mocks.ITaskRepository)
+		cur := *task
+		repo.On("FindTaskByID", ctx, "id").Return(&cur, nil)
+		repo.On("UpdateTask", ctx, &arg).Return(nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		hr := new(mocks.ITaskHistoryRepository)
+		hr.On("CreateTaskHistory", ctx, &entity.TaskHistory{ID: value.NewID("hid"), TaskID: entry.TaskID, ActorID: value.NewID(uid), Action: entry.Action, OldValue: entry.OldValue, NewValue: entry.NewValue, CreatedAt: upd}).Return(nil)
+		srv := NewTaskService(repo, new(mocks.IListRepository), hr, ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.Redo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		hr.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 親タスクの変更をやり直す場合に親タスクが完了している場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "parent task is completed"}
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackRedo, TaskID: task.ID, Action: value.TaskHistoryActionParentChanged, OldValue: "", NewValue: "pid", CreatedAt: now}
+		parent := &entity.Task{ID: value.NewID("pid"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "a", Name: "parent", Status: value.TaskStatusDone, CreatedAt: now, UpdatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		cur := *task
+		repo.On("FindTaskByID", ctx, "id").Return(&cur, nil)
+		repo.On("FindTaskByID", ctx, "pid").Return(parent, nil)
+		repo.On("FindAncestorIDs", ctx, "pid").Return([]string{"pid"}, nil)
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("PopTaskUndoEntry", ctx, uid, value.UndoStackRedo, upd.Add(-undoExpiry)).Return(entry, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.Redo(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertNotCalled(t, "UpdateTask", ctx, mock.Anything)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 並び順の変更をやり直すこと", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackRedo, TaskID: task.ID, Action: value.TaskHistoryActionPositionChanged, OldValue: "i", NewValue: "r", CreatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		cur := *task
+		repo.On("FindTaskByID", ctx, "id").Return(&cur, nil)
+		repo.On("UpdateTaskPosition", ctx, "id", value.Rank("r")).Return(nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.Redo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: リストの移動をやり直すこと", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackRedo, TaskID: task.ID, Action: value.TaskHistoryActionListChanged, OldValue: encodeTaskListUndoValue("lid", "i"), NewValue: encodeTaskListUndoValue("new", "9"), CreatedAt: now}
+		list := &entity.List{ID: value.NewID("new"), UserID: value.NewID(uid), Name: "list", CreatedAt: now, UpdatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		cur := *task
+		repo.On("FindTaskByID", ctx, "id").Return(&cur, nil)
+		repo.On("UpdateTaskTreeListID", ctx, "id", "new", upd).Return(nil)
+		repo.On("UpdateTaskPosition", ctx, "id", value.Rank("9")).Return(nil)
+		listRepo := new(mocks.IListRepository)
+		listRepo.On("FindListByID", ctx, "new").Return(list, nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, listRepo, new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.Redo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		listRepo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 依存関係の追加をやり直すこと", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackRedo, TaskID: task.ID, Action: value.TaskHistoryActionDependencyAdded, NewValue: "bid", CreatedAt: now}
+		blocker := &entity.Task{ID: value.NewID("bid"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "r", Name: "blocker", Status: value.TaskStatusTodo, CreatedAt: now, UpdatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		cur := *task
+		repo.On("FindTaskByID", ctx, "id").Return(&cur, nil)
+		repo.On("FindTaskByID", ctx, "bid").Return(blocker, nil)
+		repo.On("FindBlockerIDs", ctx, "bid").Return([]string{}, nil)
+		repo.On("AddTaskDependency", ctx, "id", "bid", upd).Return(int64(1), nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.Redo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 依存関係の追加をやり直すと循環する場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "task dependency would create a cycle"}
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackRedo, TaskID: task.ID, Action: value.TaskHistoryActionDependencyAdded, NewValue: "bid", CreatedAt: now}
+		blocker := &entity.Task{ID: value.NewID("bid"), UserID: value.NewID(uid), ListID: value.NewID("lid"), Position: "r", Name: "blocker", Status: value.TaskStatusTodo, CreatedAt: now, UpdatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		cur := *task
+		repo.On("FindTaskByID", ctx, "id").Return(&cur, nil)
+		repo.On("FindTaskByID", ctx, "bid").Return(blocker, nil)
+		repo.On("FindBlockerIDs", ctx, "bid").Return([]string{"id"}, nil)
+		ur := new(mocks.ITaskUndoRepository)
+		ur.On("PopTaskUndoEntry", ctx, uid, value.UndoStackRedo, upd.Add(-undoExpiry)).Return(entry, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.Redo(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertNotCalled(t, "AddTaskDependency", ctx, mock.Anything, mock.Anything, mock.Anything)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 依存関係の削除をやり直すこと", func(t *testing.T) {
+		entry := &entity.TaskUndoEntry{ID: value.NewID("e1"), UserID: value.NewID(uid), Stack: value.UndoStackRedo, TaskID: task.ID, Action: value.TaskHistoryActionDependencyRemoved, OldValue: "bid", CreatedAt: now}
+		repo := new(mocks.ITaskRepository)
+		cur := *task
+		repo.On("FindTaskByID", ctx, "id").Return(&cur, nil)
+		repo.On("RemoveTaskDependency", ctx, "id", "bid").Return(int64(1), nil)
+		ur := newReplayUndoRepoMock(ctx, entry, upd)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("hid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(upd)
+		srv := NewTaskService(repo, new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), ur, newSavepointTxManagerMock(ctx), newUnsharedPolicy(), im, cm)
+		_, err := srv.Redo(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 不正な入力の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		srv := NewTaskService(new(mocks.ITaskRepository), new(mocks.IListRepository), new(mocks.ITaskHistoryRepository), new(mocks.ITaskUndoRepository), new(mocks.ITransactionManager), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.Redo(ctx, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+	})
+}
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/domain/repository"
+	"github.com/7oh2020/connect-tasklist/backend/util/clock"
+	"github.com/7oh2020/connect-tasklist/backend/util/identification"
+)
+
+// 作業時間のドメインロジック
+type ITimeEntryService interface {
+	FindRunningTimer(ctx context.Context, userID string) (*entity.TimeEntry, error)
+	StartTimer(ctx context.Context, taskID string, userID string, note string) (string, error)
+	StopTimer(ctx context.Context, userID string) (*entity.TimeEntry, error)
+	FindTimeEntries(ctx context.Context, userID string, from time.Time, to time.Time) ([]*entity.TimeEntry, error)
+	SummarizeTimeEntries(ctx context.Context, userID string, from time.Time, to time.Time, timezone string) (*entity.TimeSummary, error)
+	CreateTimeEntry(ctx context.Context, taskID string, userID string, startedAt time.Time, endedAt time.Time, note string) (string, error)
+	UpdateTimeEntry(ctx context.Context, id string, userID string, startedAt time.Time, endedAt *time.Time, note string) error
+	DeleteTimeEntry(ctx context.Context, id string, userID string) error
+}
+
+type TimeEntryService struct {
+	repository.ITimeEntryRepository
+	repository.ITaskRepository
+	IAuthorizationPolicy
+	identification.IIDManager
+	clock.IClockManager
+}
+
+func NewTimeEntryService(timeEntryRepo repository.ITimeEntryRepository, taskRepo repository.ITaskRepository, policy IAuthorizationPolicy, idManager identification.IIDManager, clockManager clock.IClockManager) *TimeEntryService {
+	return &TimeEntryService{timeEntryRepo, taskRepo, policy, idManager, clockManager}
+}
+
+// 計測中のタイマーを取得する。計測していない場合はnil
+func (s *TimeEntryService) FindRunningTimer(ctx context.Context, userID string) (*entity.TimeEntry, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	running, err := s.ITimeEntryRepository.FindRunningTimeEntry(ctx, userID)
+	if err != nil {
+		return nil, nil
+	}
+	return running, nil
+}
+
+// タスクの作業時間の計測を開始する。計測中のタイマーはユーザーごとに1つまで
+func (s *TimeEntryService) StartTimer(ctx context.Context, taskID string, userID string, note string) (string, error) {
+	if err := s.checkTask(ctx, taskID, userID); err != nil {
+		return "", err
+	}
+	if _, err := s.ITimeEntryRepository.FindRunningTimeEntry(ctx, userID); err == nil {
+		return "", &domain.ErrAlreadyExists{Msg: "timer is already running"}
+	}
+	now := s.IClockManager.GetNow()
+	arg := &entity.TimeEntry{
+		ID:        value.NewID(s.IIDManager.GenerateID()),
+		UserID:    value.NewID(userID),
+		TaskID:    value.NewID(taskID),
+		StartedAt: now,
+		Note:      note,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	// 同時に開始した場合はDBの一意制約により後から開始した方が失敗する
+	createdID, err := s.ITimeEntryRepository.CreateTimeEntry(ctx, arg)
+	if err != nil {
+		return "", &domain.ErrQueryFailed{}
+	}
+	return createdID, nil
+}
+
+// 計測中のタイマーを現在日時で停止する
+func (s *TimeEntryService) StopTimer(ctx context.Context, userID string) (*entity.TimeEntry, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	running, err := s.ITimeEntryRepository.FindRunningTimeEntry(ctx, userID)
+	if err != nil {
+		return nil, &domain.ErrNotFound{Msg: "timer is not running"}
+	}
+	now := s.IClockManager.GetNow()
+	// 時計が戻った場合でも開始日時より前にはしない
+	endedAt := now
+	if endedAt.Before(running.StartedAt) {
+		endedAt = running.StartedAt
+	}
+	running.EndedAt = &endedAt
+	running.UpdatedAt = now
+	if err := running.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.ITimeEntryRepository.UpdateTimeEntry(ctx, running); err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return running, nil
+}
+
+// 期間と重なる自分の作業時間を開始日時の古い順に取得する
+func (s *TimeEntryService) FindTimeEntries(ctx context.Context, userID string, from time.Time, to time.Time) ([]*entity.TimeEntry, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	if !from.Before(to) {
+		return nil, &domain.ErrValidationFailed{Msg: "from must be before to"}
+	}
+	entries, err := s.ITimeEntryRepository.FindTimeEntriesBetween(ctx, userID, from, to)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return entries, nil
+}
+
+// 期間内の作業時間をタスクごとと日ごとに集計する
+// 期間をまたぐ作業時間は期間内の部分だけを数え、日をまたぐ作業時間はtimezoneの暦で日ごとに分ける
+// 計測中のタイマーは現在日時までを数える
+func (s *TimeEntryService) SummarizeTimeEntries(ctx context.Context, userID string, from time.Time, to time.Time, timezone string) (*entity.TimeSummary, error) {
+	loc, err := s.IClockManager.LoadLocation(timezone)
+	if err != nil {
+		return nil, &domain.ErrValidationFailed{Msg: "invalid timezone"}
+	}
+	entries, err := s.FindTimeEntries(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeTimeEntries(entries, from, to, s.IClockManager.GetNow(), loc), nil
+}
+
+// 作業時間を手動で記録する。未来の日時は記録できない
+func (s *TimeEntryService) CreateTimeEntry(ctx context.Context, taskID string, userID string, startedAt time.Time, endedAt time.Time, note string) (string, error) {
+	if err := s.checkTask(ctx, taskID, userID); err != nil {
+		return "", err
+	}
+	now := s.IClockManager.GetNow()
+	arg := &entity.TimeEntry{
+		ID:        value.NewID(s.IIDManager.GenerateID()),
+		UserID:    value.NewID(userID),
+		TaskID:    value.NewID(taskID),
+		StartedAt: startedAt,
+		EndedAt:   &endedAt,
+		Note:      note,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := validateTimeEntry(arg, now); err != nil {
+		return "", err
+	}
+	createdID, err := s.ITimeEntryRepository.CreateTimeEntry(ctx, arg)
+	if err != nil {
+		return "", &domain.ErrQueryFailed{}
+	}
+	return createdID, nil
+}
+
+// 作業時間の日時とメモを変更する。計測中のタイマーはendedAtをnilにすると計測を続ける
+func (s *TimeEntryService) UpdateTimeEntry(ctx context.Context, id string, userID string, startedAt time.Time, endedAt *time.Time, note string) error {
+	entry, err := s.findOwnTimeEntry(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	// 停止した作業時間を計測中に戻すと、計測中のタイマーが複数になりうる
+	if !entry.IsRunning() && endedAt == nil {
+		return &domain.ErrValidationFailed{Msg: "ended_at is empty"}
+	}
+	now := s.IClockManager.GetNow()
+	entry.StartedAt = startedAt
+	entry.EndedAt = endedAt
+	entry.Note = note
+	entry.UpdatedAt = now
+	if err := validateTimeEntry(entry, now); err != nil {
+		return err
+	}
+	if err := s.ITimeEntryRepository.UpdateTimeEntry(ctx, entry); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+func (s *TimeEntryService) DeleteTimeEntry(ctx context.Context, id string, userID string) error {
+	if _, err := s.findOwnTimeEntry(ctx, id, userID); err != nil {
+		return err
+	}
+	if err := s.ITimeEntryRepository.DeleteTimeEntry(ctx, id); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+// タスクに対して編集者以上の権限を持つか検証する
+func (s *TimeEntryService) checkTask(ctx context.Context, taskID string, userID string) error {
+	if err := value.NewID(taskID).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	task, err := s.ITaskRepository.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "task not found"}
+	}
+	return s.IAuthorizationPolicy.AuthorizeTask(ctx, task, userID, value.RoleEditor)
+}
+
+// 自分の作業時間を取得する。作業時間は記録したユーザーだけが変更できる
+func (s *TimeEntryService) findOwnTimeEntry(ctx context.Context, id string, userID string) (*entity.TimeEntry, error) {
+	if err := value.NewID(id).Validate(); err != nil {
+		return nil, err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	entry, err := s.ITimeEntryRepository.FindTimeEntryByID(ctx, id)
+	if err != nil {
+		return nil, &domain.ErrNotFound{Msg: "time entry not found"}
+	}
+	if !entry.UserID.Equal(userID) {
+		return nil, &domain.ErrPermissionDenied{}
+	}
+	return entry, nil
+}
+
+// 作業時間の妥当性と、日時が未来でないことを検証する
+func validateTimeEntry(e *entity.TimeEntry, now time.Time) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	if e.StartedAt.After(now) {
+		return &domain.ErrValidationFailed{Msg: "started_at must not be in the future"}
+	}
+	if e.EndedAt != nil && e.EndedAt.After(now) {
+		return &domain.ErrValidationFailed{Msg: "ended_at must not be in the future"}
+	}
+	return nil
+}
+
+// 作業時間を期間[from, to)で切り取って集計する
+func summarizeTimeEntries(entries []*entity.TimeEntry, from time.Time, to time.Time, now time.Time, loc *time.Location) *entity.TimeSummary {
+	summary := &entity.TimeSummary{ByTask: []*entity.TaskTimeTotal{}, ByDay: []*entity.DayTimeTotal{}}
+	tasks := map[string]*entity.TaskTimeTotal{}
+	days := map[string]*entity.DayTimeTotal{}
+	for _, e := range entries {
+		start := e.StartedAt
+		if start.Before(from) {
+			start = from
+		}
+		end := now
+		if e.EndedAt != nil {
+			end = *e.EndedAt
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !start.Before(end) {
+			continue
+		}
+
+		d := end.Sub(start)
+		summary.Total += d
+		task, ok := tasks[e.TaskID.Value()]
+		if !ok {
+			task = &entity.TaskTimeTotal{TaskID: e.TaskID, TaskName: e.TaskName}
+			tasks[e.TaskID.Value()] = task
+			summary.ByTask = append(summary.ByTask, task)
+		}
+		task.Duration += d
+
+		// 日付が変わる時刻で区切って日ごとに加算する。夏時間で1日の長さが変わる場合も暦の日付で区切る
+		for cur := start; cur.Before(end); {
+			local := cur.In(loc)
+			next := time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, loc)
+			if next.After(end) {
+				next = end
+			}
+			date := local.Format(time.DateOnly)
+			day, ok := days[date]
+			if !ok {
+				day = &entity.DayTimeTotal{Date: date}
+				days[date] = day
+				summary.ByDay = append(summary.ByDay, day)
+			}
+			day.Duration += next.Sub(cur)
+			cur = next
+		}
+	}
+	sort.SliceStable(summary.ByTask, func(i, j int) bool {
+		return summary.ByTask[i].Duration > summary.ByTask[j].Duration
+	})
+	sort.Slice(summary.ByDay, func(i, j int) bool {
+		return summary.ByDay[i].Date < summary.ByDay[j].Date
+	})
+	return summary
+}
@@ -0,0 +1,412 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeEntryService_NewTimeEntryService(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ ITimeEntryService = (*TimeEntryService)(nil)
+	})
+}
+
+func TestTimeEntryService_FindRunningTimer(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	uid := "uid"
+	running := &entity.TimeEntry{ID: value.NewID("id"), UserID: value.NewID(uid), TaskID: value.NewID("tid"), StartedAt: now}
+
+	tt.Run("正常系: 計測中の場合", func(t *testing.T) {
+		repo := new(mocks.ITimeEntryRepository)
+		repo.On("FindRunningTimeEntry", ctx, uid).Return(running, nil)
+		srv := NewTimeEntryService(repo, new(mocks.ITaskRepository), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		ret, err := srv.FindRunningTimer(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, running, ret)
+		repo.AssertExpectations(t)
+	})
+	tt.Run("正常系: 計測していない場合はnil", func(t *testing.T) {
+		repo := new(mocks.ITimeEntryRepository)
+		repo.On("FindRunningTimeEntry", ctx, uid).Return(nil, errors.New("no rows"))
+		srv := NewTimeEntryService(repo, new(mocks.ITaskRepository), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		ret, err := srv.FindRunningTimer(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Nil(t, ret)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestTimeEntryService_StartTimer(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	id := "id"
+	tid := "tid"
+	uid := "uid"
+	task := &entity.Task{ID: value.NewID(tid), UserID: value.NewID(uid), Name: "task", CreatedAt: now, UpdatedAt: now}
+	entry := &entity.TimeEntry{ID: value.NewID(id), UserID: value.NewID(uid), TaskID: value.NewID(tid), StartedAt: now, Note: "note", CreatedAt: now, UpdatedAt: now}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.ITimeEntryRepository)
+		repo.On("FindRunningTimeEntry", ctx, uid).Return(nil, errors.New("no rows"))
+		repo.On("CreateTimeEntry", ctx, entry).Return(id, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTimeEntryService(repo, taskRepo, newUnsharedPolicy(), im, cm)
+		createdID, err := srv.StartTimer(ctx, tid, uid, "note")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, id, createdID)
+		repo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+		im.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 計測中のタイマーがある場合", func(t *testing.T) {
+		errExp := &domain.ErrAlreadyExists{Msg: "timer is already running"}
+		repo := new(mocks.ITimeEntryRepository)
+		repo.On("FindRunningTimeEntry", ctx, uid).Return(entry, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		srv := NewTimeEntryService(repo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.StartTimer(ctx, tid, uid, "note")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 閲覧者として共有されたタスクの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITimeEntryRepository)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		srv := NewTimeEntryService(repo, taskRepo, newSharedPolicy(value.RoleViewer), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.StartTimer(ctx, tid, "another", "note")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: タスクが存在しない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "task not found"}
+		repo := new(mocks.ITimeEntryRepository)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(nil, errExp)
+		srv := NewTimeEntryService(repo, taskRepo, newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.StartTimer(ctx, tid, uid, "note")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 同時に開始して一意制約に違反した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITimeEntryRepository)
+		repo.On("FindRunningTimeEntry", ctx, uid).Return(nil, errors.New("no rows"))
+		repo.On("CreateTimeEntry", ctx, entry).Return("", errors.New("unique violation"))
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTimeEntryService(repo, taskRepo, newUnsharedPolicy(), im, cm)
+		_, err := srv.StartTimer(ctx, tid, uid, "note")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestTimeEntryService_StopTimer(tt *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	now := start.Add(30 * time.Minute)
+	uid := "uid"
+
+	tt.Run("正常系: 現在日時で停止する場合", func(t *testing.T) {
+		running := &entity.TimeEntry{ID: value.NewID("id"), UserID: value.NewID(uid), TaskID: value.NewID("tid"), StartedAt: start, CreatedAt: start, UpdatedAt: start}
+		stopped := &entity.TimeEntry{ID: value.NewID("id"), UserID: value.NewID(uid), TaskID: value.NewID("tid"), StartedAt: start, EndedAt: &now, CreatedAt: start, UpdatedAt: now}
+		repo := new(mocks.ITimeEntryRepository)
+		repo.On("FindRunningTimeEntry", ctx, uid).Return(running, nil)
+		repo.On("UpdateTimeEntry", ctx, stopped).Return(nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTimeEntryService(repo, new(mocks.ITaskRepository), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		ret, err := srv.StopTimer(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, stopped, ret)
+		require.Equal(t, 30*time.Minute, ret.Duration(now))
+		repo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("正常系: 現在日時が開始日時より前の場合は開始日時で停止する", func(t *testing.T) {
+		before := start.Add(-time.Minute)
+		running := &entity.TimeEntry{ID: value.NewID("id"), UserID: value.NewID(uid), TaskID: value.NewID("tid"), StartedAt: start, CreatedAt: start, UpdatedAt: start}
+		stopped := &entity.TimeEntry{ID: value.NewID("id"), UserID: value.NewID(uid), TaskID: value.NewID("tid"), StartedAt: start, EndedAt: &start, CreatedAt: start, UpdatedAt: before}
+		repo := new(mocks.ITimeEntryRepository)
+		repo.On("FindRunningTimeEntry", ctx, uid).Return(running, nil)
+		repo.On("UpdateTimeEntry", ctx, stopped).Return(nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(before)
+		srv := NewTimeEntryService(repo, new(mocks.ITaskRepository), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		ret, err := srv.StopTimer(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, stopped, ret)
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 計測していない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "timer is not running"}
+		repo := new(mocks.ITimeEntryRepository)
+		repo.On("FindRunningTimeEntry", ctx, uid).Return(nil, errors.New("no rows"))
+		srv := NewTimeEntryService(repo, new(mocks.ITaskRepository), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.StopTimer(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestTimeEntryService_CreateTimeEntry(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 2, 18, 0, 0, 0, time.UTC)
+	start := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	future := now.Add(time.Minute)
+	id := "id"
+	tid := "tid"
+	uid := "uid"
+	task := &entity.Task{ID: value.NewID(tid), UserID: value.NewID(uid), Name: "task", CreatedAt: now, UpdatedAt: now}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		entry := &entity.TimeEntry{ID: value.NewID(id), UserID: value.NewID(uid), TaskID: value.NewID(tid), StartedAt: start, EndedAt: &end, Note: "note", CreatedAt: now, UpdatedAt: now}
+		repo := new(mocks.ITimeEntryRepository)
+		repo.On("CreateTimeEntry", ctx, entry).Return(id, nil)
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return(id)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTimeEntryService(repo, taskRepo, newUnsharedPolicy(), im, cm)
+		createdID, err := srv.CreateTimeEntry(ctx, tid, uid, start, end, "note")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, id, createdID)
+		repo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+
+	testcases := []struct {
+		title string
+		start time.Time
+		end   time.Time
+		err   error
+	}{
+		{"準正常系: 終了日時が開始日時より前の場合", end, start, &domain.ErrValidationFailed{Msg: "ended_at must not be before started_at"}},
+		{"準正常系: 終了日時が未来の場合", start, future, &domain.ErrValidationFailed{Msg: "ended_at must not be in the future"}},
+		{"準正常系: 開始日時が未来の場合", future, future.Add(time.Hour), &domain.ErrValidationFailed{Msg: "started_at must not be in the future"}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			repo := new(mocks.ITimeEntryRepository)
+			taskRepo := new(mocks.ITaskRepository)
+			taskRepo.On("FindTaskByID", ctx, tid).Return(task, nil)
+			im := new(mocks.IIDManager)
+			im.On("GenerateID").Return(id)
+			cm := new(mocks.IClockManager)
+			cm.On("GetNow").Return(now)
+			srv := NewTimeEntryService(repo, taskRepo, newUnsharedPolicy(), im, cm)
+			_, err := srv.CreateTimeEntry(ctx, tid, uid, v.start, v.end, "note")
+
+			require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTimeEntryService_UpdateTimeEntry(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 2, 18, 0, 0, 0, time.UTC)
+	start := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	id := "id"
+	uid := "uid"
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		entry := &entity.TimeEntry{ID: value.NewID(id), UserID: value.NewID(uid), TaskID: value.NewID("tid"), StartedAt: start, EndedAt: &end, CreatedAt: start, UpdatedAt: start}
+		newStart := start.Add(-time.Hour)
+		updated := &entity.TimeEntry{ID: value.NewID(id), UserID: value.NewID(uid), TaskID: value.NewID("tid"), StartedAt: newStart, EndedAt: &end, Note: "fixed", CreatedAt: start, UpdatedAt: now}
+		repo := new(mocks.ITimeEntryRepository)
+		repo.On("FindTimeEntryByID", ctx, id).Return(entry, nil)
+		repo.On("UpdateTimeEntry", ctx, updated).Return(nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTimeEntryService(repo, new(mocks.ITaskRepository), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		err := srv.UpdateTimeEntry(ctx, id, uid, newStart, &end, "fixed")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("正常系: 計測中のタイマーの開始日時を変更する場合", func(t *testing.T) {
+		entry := &entity.TimeEntry{ID: value.NewID(id), UserID: value.NewID(uid), TaskID: value.NewID("tid"), StartedAt: start, CreatedAt: start, UpdatedAt: start}
+		updated := &entity.TimeEntry{ID: value.NewID(id), UserID: value.NewID(uid), TaskID: value.NewID("tid"), StartedAt: end, CreatedAt: start, UpdatedAt: now}
+		repo := new(mocks.ITimeEntryRepository)
+		repo.On("FindTimeEntryByID", ctx, id).Return(entry, nil)
+		repo.On("UpdateTimeEntry", ctx, updated).Return(nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewTimeEntryService(repo, new(mocks.ITaskRepository), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		err := srv.UpdateTimeEntry(ctx, id, uid, end, nil, "")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 停止した作業時間の終了日時を空にする場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "ended_at is empty"}
+		entry := &entity.TimeEntry{ID: value.NewID(id), UserID: value.NewID(uid), TaskID: value.NewID("tid"), StartedAt: start, EndedAt: &end, CreatedAt: start, UpdatedAt: start}
+		repo := new(mocks.ITimeEntryRepository)
+		repo.On("FindTimeEntryByID", ctx, id).Return(entry, nil)
+		srv := NewTimeEntryService(repo, new(mocks.ITaskRepository), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.UpdateTimeEntry(ctx, id, uid, start, nil, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーの作業時間の場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		entry := &entity.TimeEntry{ID: value.NewID(id), UserID: value.NewID(uid), TaskID: value.NewID("tid"), StartedAt: start, EndedAt: &end, CreatedAt: start, UpdatedAt: start}
+		repo := new(mocks.ITimeEntryRepository)
+		repo.On("FindTimeEntryByID", ctx, id).Return(entry, nil)
+		srv := NewTimeEntryService(repo, new(mocks.ITaskRepository), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.UpdateTimeEntry(ctx, id, "another", start, &end, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 作業時間が存在しない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "time entry not found"}
+		repo := new(mocks.ITimeEntryRepository)
+		repo.On("FindTimeEntryByID", ctx, id).Return(nil, errors.New("no rows"))
+		srv := NewTimeEntryService(repo, new(mocks.ITaskRepository), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.UpdateTimeEntry(ctx, id, uid, start, &end, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestTimeEntryService_DeleteTimeEntry(tt *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	id := "id"
+	uid := "uid"
+	entry := &entity.TimeEntry{ID: value.NewID(id), UserID: value.NewID(uid), TaskID: value.NewID("tid"), StartedAt: start, CreatedAt: start, UpdatedAt: start}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		repo := new(mocks.ITimeEntryRepository)
+		repo.On("FindTimeEntryByID", ctx, id).Return(entry, nil)
+		repo.On("DeleteTimeEntry", ctx, id).Return(nil)
+		srv := NewTimeEntryService(repo, new(mocks.ITaskRepository), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.DeleteTimeEntry(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 別のユーザーの作業時間の場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		repo := new(mocks.ITimeEntryRepository)
+		repo.On("FindTimeEntryByID", ctx, id).Return(entry, nil)
+		srv := NewTimeEntryService(repo, new(mocks.ITaskRepository), newUnsharedPolicy(), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.DeleteTimeEntry(ctx, id, "another")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestTimeEntryService_SummarizeTimeEntries(tt *testing.T) {
+	ctx := context.Background()
+	loc, _ := time.LoadLocation("Asia/Tokyo")
+	uid := "uid"
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, loc)
+	to := time.Date(2024, 1, 8, 0, 0, 0, 0, loc)
+	now := time.Date(2024, 1, 3, 12, 0, 0, 0, loc)
+	end1 := time.Date(2024, 1, 1, 10, 0, 0, 0, loc)
+	end2 := time.Date(2024, 1, 2, 1, 0, 0, 0, loc)
+	end3 := time.Date(2024, 1, 1, 0, 30, 0, 0, loc)
+	entries := []*entity.TimeEntry{
+		// 期間の開始前から始まった作業時間は期間内の部分だけを数える
+		{ID: value.NewID("e0"), UserID: value.NewID(uid), TaskID: value.NewID("t2"), TaskName: "task2", StartedAt: time.Date(2023, 12, 31, 23, 0, 0, 0, loc), EndedAt: &end3},
+		{ID: value.NewID("e1"), UserID: value.NewID(uid), TaskID: value.NewID("t1"), TaskName: "task1", StartedAt: time.Date(2024, 1, 1, 9, 0, 0, 0, loc), EndedAt: &end1},
+		// 日をまたぐ作業時間は日ごとに分ける
+		{ID: value.NewID("e2"), UserID: value.NewID(uid), TaskID: value.NewID("t2"), TaskName: "task2", StartedAt: time.Date(2024, 1, 1, 23, 0, 0, 0, loc), EndedAt: &end2},
+		// 計測中のタイマーは現在日時までを数える
+		{ID: value.NewID("e3"), UserID: value.NewID(uid), TaskID: value.NewID("t1"), TaskName: "task1", StartedAt: time.Date(2024, 1, 3, 9, 0, 0, 0, loc)},
+	}
+
+	tt.Run("正常系: タスクごとと日ごとに集計する場合", func(t *testing.T) {
+		repo := new(mocks.ITimeEntryRepository)
+		repo.On("FindTimeEntriesBetween", ctx, uid, from, to).Return(entries, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("LoadLocation", "Asia/Tokyo").Return(loc, nil)
+		cm.On("GetNow").Return(now)
+		srv := NewTimeEntryService(repo, new(mocks.ITaskRepository), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		ret, err := srv.SummarizeTimeEntries(ctx, uid, from, to, "Asia/Tokyo")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, &entity.TimeSummary{
+			Total: 6*time.Hour + 30*time.Minute,
+			ByTask: []*entity.TaskTimeTotal{
+				{TaskID: value.NewID("t1"), TaskName: "task1", Duration: 4 * time.Hour},
+				{TaskID: value.NewID("t2"), TaskName: "task2", Duration: 2*time.Hour + 30*time.Minute},
+			},
+			ByDay: []*entity.DayTimeTotal{
+				{Date: "2024-01-01", Duration: 2*time.Hour + 30*time.Minute},
+				{Date: "2024-01-02", Duration: time.Hour},
+				{Date: "2024-01-03", Duration: 3 * time.Hour},
+			},
+		}, ret)
+		repo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: タイムゾーンが不正な場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "invalid timezone"}
+		repo := new(mocks.ITimeEntryRepository)
+		cm := new(mocks.IClockManager)
+		cm.On("LoadLocation", "Invalid/Zone").Return(nil, errors.New("unknown time zone"))
+		srv := NewTimeEntryService(repo, new(mocks.ITaskRepository), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.SummarizeTimeEntries(ctx, uid, from, to, "Invalid/Zone")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		repo := new(mocks.ITimeEntryRepository)
+		repo.On("FindTimeEntriesBetween", ctx, uid, from, to).Return(nil, errExp)
+		cm := new(mocks.IClockManager)
+		cm.On("LoadLocation", "Asia/Tokyo").Return(loc, nil)
+		srv := NewTimeEntryService(repo, new(mocks.ITaskRepository), newUnsharedPolicy(), new(mocks.IIDManager), cm)
+		_, err := srv.SummarizeTimeEntries(ctx, uid, from, to, "Asia/Tokyo")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		repo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/domain/repository"
+	"github.com/7oh2020/connect-tasklist/backend/util/clock"
+	"github.com/7oh2020/connect-tasklist/backend/util/identification"
+)
+
+// 保存済みのビューのドメインロジック
+type IViewService interface {
+	FindViewsByUserID(ctx context.Context, userID string) ([]*entity.View, error)
+	CreateView(ctx context.Context, userID string, name string, query value.ViewQuery) (string, error)
+	UpdateView(ctx context.Context, id string, userID string, name string, query value.ViewQuery) error
+	DeleteView(ctx context.Context, id string, userID string) error
+	ExecuteView(ctx context.Context, id string, userID string, timezone string, limit int32, cursor *value.TaskPageCursor) ([]*entity.TaskGroup, *value.TaskPageCursor, error)
+}
+
+// 組み込みのビュー。IDはUUIDと重複しない固定の値にする
+var builtinViews = []*entity.View{
+	{
+		ID:   value.NewID("builtin-today"),
+		Name: "Today",
+		Query: value.ViewQuery{
+			Completion: value.TaskCompletionOpen,
+			Due:        value.ViewPeriodToday,
+			Sort:       value.TaskSort{Field: value.TaskSortFieldPriority, Descending: true},
+		},
+	},
+	{
+		// 完了日時は記録していないため、完了したタスクの更新日時で判定する
+		ID:   value.NewID("builtin-completed-this-week"),
+		Name: "Completed this week",
+		Query: value.ViewQuery{
+			Completion: value.TaskCompletionClosed,
+			Updated:    value.ViewPeriodThisWeek,
+			Sort:       value.TaskSort{Field: value.TaskSortFieldUpdatedAt, Descending: true},
+		},
+	},
+	{
+		ID:   value.NewID("builtin-no-due-date"),
+		Name: "No due date",
+		Query: value.ViewQuery{
+			Completion: value.TaskCompletionOpen,
+			Due:        value.ViewPeriodUnset,
+			Sort:       value.TaskSort{Field: value.TaskSortFieldUpdatedAt, Descending: true},
+		},
+	},
+}
+
+type ViewService struct {
+	repository.IViewRepository
+	repository.ITaskRepository
+	identification.IIDManager
+	clock.IClockManager
+}
+
+func NewViewService(viewRepo repository.IViewRepository, taskRepo repository.ITaskRepository, idManager identification.IIDManager, clockManager clock.IClockManager) *ViewService {
+	return &ViewService{viewRepo, taskRepo, idManager, clockManager}
+}
+
+// 組み込みのビューと、名前順に並べた自分のビューを取得する
+func (s *ViewService) FindViewsByUserID(ctx context.Context, userID string) ([]*entity.View, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	views, err := s.IViewRepository.FindViewsByUserID(ctx, userID)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	ret := make([]*entity.View, 0, len(builtinViews)+len(views))
+	for _, v := range builtinViews {
+		ret = append(ret, builtinView(v, userID))
+	}
+	return append(ret, views...), nil
+}
+
+func (s *ViewService) CreateView(ctx context.Context, userID string, name string, query value.ViewQuery) (string, error) {
+	now := s.IClockManager.GetNow()
+	arg := &entity.View{
+		ID:        value.NewID(s.IIDManager.GenerateID()),
+		UserID:    value.NewID(userID),
+		Name:      name,
+		Query:     query,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	// ビュー名はユーザーごとに一意
+	if _, err := s.IViewRepository.FindViewByUserIDAndName(ctx, userID, name); err == nil {
+		return "", &domain.ErrAlreadyExists{Msg: "view already exists"}
+	}
+	createdID, err := s.IViewRepository.CreateView(ctx, arg)
+	if err != nil {
+		return "", &domain.ErrQueryFailed{}
+	}
+	return createdID, nil
+}
+
+// ビューの名前と条件を変更する
+func (s *ViewService) UpdateView(ctx context.Context, id string, userID string, name string, query value.ViewQuery) error {
+	view, err := s.findOwnView(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if view.IsBuiltin {
+		return &domain.ErrPreconditionFailed{Msg: "built-in view cannot be modified"}
+	}
+	renamed := view.Name != name
+	view.Name = name
+	view.Query = query
+	view.UpdatedAt = s.IClockManager.GetNow()
+	if err := view.Validate(); err != nil {
+		return err
+	}
+	if renamed {
+		if _, err := s.IViewRepository.FindViewByUserIDAndName(ctx, userID, name); err == nil {
+			return &domain.ErrAlreadyExists{Msg: "view already exists"}
+		}
+	}
+	if err := s.IViewRepository.UpdateView(ctx, view); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+func (s *ViewService) DeleteView(ctx context.Context, id string, userID string) error {
+	view, err := s.findOwnView(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if view.IsBuiltin {
+		return &domain.ErrPreconditionFailed{Msg: "built-in view cannot be modified"}
+	}
+	if err := s.IViewRepository.DeleteView(ctx, id); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+// ビューの条件でタスク一覧と同じようにページ単位で取得し、ページ内のタスクをまとめる
+// 今日や今週などの期間はtimezoneの暦で判定する
+func (s *ViewService) ExecuteView(ctx context.Context, id string, userID string, timezone string, limit int32, cursor *value.TaskPageCursor) ([]*entity.TaskGroup, *value.TaskPageCursor, error) {
+	loc, err := s.IClockManager.LoadLocation(timezone)
+	if err != nil {
+		return nil, nil, &domain.ErrValidationFailed{Msg: "invalid timezone"}
+	}
+	view, err := s.findOwnView(ctx, id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	filter := view.Query.Filter(s.IClockManager.GetNow().In(loc))
+	tasks, next, err := findTaskPage(ctx, s.ITaskRepository, userID, filter, view.Query.Sort, limit, cursor)
+	if err != nil {
+		return nil, nil, err
+	}
+	return groupTasks(tasks, view.Query.GroupBy, loc), next, nil
+}
+
+// 自分のビューまたは組み込みのビューを取得する
+func (s *ViewService) findOwnView(ctx context.Context, id string, userID string) (*entity.View, error) {
+	if err := value.NewID(id).Validate(); err != nil {
+		return nil, err
+	}
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	for _, v := range builtinViews {
+		if v.ID.Equal(id) {
+			return builtinView(v, userID), nil
+		}
+	}
+	view, err := s.IViewRepository.FindViewByID(ctx, id)
+	if err != nil {
+		return nil, &domain.ErrNotFound{Msg: "view not found"}
+	}
+	if !view.UserID.Equal(userID) {
+		return nil, &domain.ErrPermissionDenied{}
+	}
+	return view, nil
+}
+
+// 組み込みのビューをユーザーのビューとして複製する
+func builtinView(v *entity.View, userID string) *entity.View {
+	return &entity.View{
+		ID:        v.ID,
+		UserID:    value.NewID(userID),
+		Name:      v.Name,
+		Query:     v.Query,
+		IsBuiltin: true,
+	}
+}
+
+// 並び順を保ったまま、最初に現れた順にタスクをまとめる
+func groupTasks(tasks []*entity.Task, groupBy value.ViewGroupBy, loc *time.Location) []*entity.TaskGroup {
+	groups := []*entity.TaskGroup{}
+	index := map[string]int{}
+	for _, t := range tasks {
+		key := groupKey(t, groupBy, loc)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, &entity.TaskGroup{Key: key})
+		}
+		groups[i].Tasks = append(groups[i].Tasks, t)
+	}
+	return groups
+}
+
+// タスクが属するグループのキー。値がない場合は空文字
+func groupKey(t *entity.Task, groupBy value.ViewGroupBy, loc *time.Location) string {
+	switch groupBy {
+	case value.ViewGroupByStatus:
+		return t.Status.String()
+	case value.ViewGroupByPriority:
+		return strconv.Itoa(int(t.Priority.Value()))
+	case value.ViewGroupByList:
+		if t.ListID == nil {
+			return ""
+		}
+		return t.ListID.Value()
+	case value.ViewGroupByDueDate:
+		if t.DueAt == nil {
+			return ""
+		}
+		return t.DueAt.In(loc).Format(time.DateOnly)
+	default:
+		return ""
+	}
+}
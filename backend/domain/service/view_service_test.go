@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewService_NewViewService(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ IViewService = (*ViewService)(nil)
+	})
+}
+
+func TestViewService_FindViewsByUserID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	uid := "uid"
+	views := []*entity.View{
+		{ID: value.NewID("v1"), UserID: value.NewID(uid), Name: "work", CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: 組み込みのビューの後に自分のビューが続くこと", func(t *testing.T) {
+		viewRepo := new(mocks.IViewRepository)
+		viewRepo.On("FindViewsByUserID", ctx, uid).Return(views, nil)
+		srv := NewViewService(viewRepo, new(mocks.ITaskRepository), new(mocks.IIDManager), new(mocks.IClockManager))
+		ret, err := srv.FindViewsByUserID(ctx, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Len(t, ret, len(builtinViews)+1)
+		for i, v := range builtinViews {
+			require.Equal(t, v.ID.Value(), ret[i].ID.Value())
+			require.True(t, ret[i].IsBuiltin, "組み込みのビューであること")
+			require.True(t, ret[i].UserID.Equal(uid), "UserIDが設定されること")
+		}
+		require.Equal(t, views[0], ret[len(builtinViews)])
+		viewRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: UserIDが空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		viewRepo := new(mocks.IViewRepository)
+		srv := NewViewService(viewRepo, new(mocks.ITaskRepository), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindViewsByUserID(ctx, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		viewRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		viewRepo := new(mocks.IViewRepository)
+		viewRepo.On("FindViewsByUserID", ctx, uid).Return(nil, errExp)
+		srv := NewViewService(viewRepo, new(mocks.ITaskRepository), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindViewsByUserID(ctx, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		viewRepo.AssertExpectations(t)
+	})
+}
+
+func TestViewService_CreateView(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	uid := "uid"
+	query := value.ViewQuery{Completion: value.TaskCompletionOpen, Due: value.ViewPeriodThisWeek, GroupBy: value.ViewGroupByDueDate}
+	view := &entity.View{ID: value.NewID(id), UserID: value.NewID(uid), Name: "this week", Query: query, CreatedAt: now, UpdatedAt: now}
+
+	testcases := []struct {
+		title  string
+		name   string
+		query  value.ViewQuery
+		exists bool
+		errRes error
+		err    error
+	}{
+		{"正常系: 正しい入力の場合", view.Name, query, false, nil, nil},
+		{"準正常系: 名前が空の場合", "", query, false, nil, &domain.ErrValidationFailed{Msg: "name is empty"}},
+		{"準正常系: 条件が不正な場合", view.Name, value.ViewQuery{Updated: value.ViewPeriodUnset}, false, nil, &domain.ErrValidationFailed{Msg: "updated period cannot be unset"}},
+		{"準正常系: 同じ名前のビューが存在する場合", view.Name, query, true, nil, &domain.ErrAlreadyExists{Msg: "view already exists"}},
+		{"準正常系: クエリエラーの場合", view.Name, query, false, errors.New("error"), &domain.ErrQueryFailed{}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			viewRepo := new(mocks.IViewRepository)
+			arg := &entity.View{ID: value.NewID(id), UserID: value.NewID(uid), Name: v.name, Query: v.query, CreatedAt: now, UpdatedAt: now}
+			if arg.Validate() == nil {
+				if v.exists {
+					viewRepo.On("FindViewByUserIDAndName", ctx, uid, v.name).Return(view, nil)
+				} else {
+					viewRepo.On("FindViewByUserIDAndName", ctx, uid, v.name).Return(nil, errors.New("no rows"))
+					viewRepo.On("CreateView", ctx, arg).Return(id, v.errRes)
+				}
+			}
+			im := new(mocks.IIDManager)
+			im.On("GenerateID").Return(id)
+			cm := new(mocks.IClockManager)
+			cm.On("GetNow").Return(now)
+			srv := NewViewService(viewRepo, new(mocks.ITaskRepository), im, cm)
+			ret, err := srv.CreateView(ctx, uid, v.name, v.query)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, id, ret)
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+			viewRepo.AssertExpectations(t)
+			im.AssertExpectations(t)
+			cm.AssertExpectations(t)
+		})
+	}
+}
+
+func TestViewService_UpdateView(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	id := "id"
+	uid := "uid"
+	query := value.ViewQuery{Completion: value.TaskCompletionClosed, Sort: value.TaskSort{Field: value.TaskSortFieldName}}
+	newView := func() *entity.View {
+		return &entity.View{ID: value.NewID(id), UserID: value.NewID(uid), Name: "view", CreatedAt: now, UpdatedAt: now}
+	}
+
+	tt.Run("正常系: 名前と条件を変更する場合", func(t *testing.T) {
+		viewRepo := new(mocks.IViewRepository)
+		viewRepo.On("FindViewByID", ctx, id).Return(newView(), nil)
+		viewRepo.On("FindViewByUserIDAndName", ctx, uid, "renamed").Return(nil, errors.New("no rows"))
+		viewRepo.On("UpdateView", ctx, &entity.View{ID: value.NewID(id), UserID: value.NewID(uid), Name: "renamed", Query: query, CreatedAt: now, UpdatedAt: now}).Return(nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewViewService(viewRepo, new(mocks.ITaskRepository), new(mocks.IIDManager), cm)
+		err := srv.UpdateView(ctx, id, uid, "renamed", query)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		viewRepo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	tt.Run("正常系: 名前を変更しない場合は重複を確認しないこと", func(t *testing.T) {
+		viewRepo := new(mocks.IViewRepository)
+		viewRepo.On("FindViewByID", ctx, id).Return(newView(), nil)
+		viewRepo.On("UpdateView", ctx, &entity.View{ID: value.NewID(id), UserID: value.NewID(uid), Name: "view", Query: query, CreatedAt: now, UpdatedAt: now}).Return(nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewViewService(viewRepo, new(mocks.ITaskRepository), new(mocks.IIDManager), cm)
+		err := srv.UpdateView(ctx, id, uid, "view", query)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		viewRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 組み込みのビューの場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "built-in view cannot be modified"}
+		viewRepo := new(mocks.IViewRepository)
+		srv := NewViewService(viewRepo, new(mocks.ITaskRepository), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.UpdateView(ctx, "builtin-today", uid, "renamed", query)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		viewRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 同じ名前のビューが存在する場合", func(t *testing.T) {
+		errExp := &domain.ErrAlreadyExists{Msg: "view already exists"}
+		viewRepo := new(mocks.IViewRepository)
+		viewRepo.On("FindViewByID", ctx, id).Return(newView(), nil)
+		viewRepo.On("FindViewByUserIDAndName", ctx, uid, "renamed").Return(&entity.View{ID: value.NewID("other")}, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := NewViewService(viewRepo, new(mocks.ITaskRepository), new(mocks.IIDManager), cm)
+		err := srv.UpdateView(ctx, id, uid, "renamed", query)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		viewRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 他人のビューの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		viewRepo := new(mocks.IViewRepository)
+		viewRepo.On("FindViewByID", ctx, id).Return(&entity.View{ID: value.NewID(id), UserID: value.NewID("another"), Name: "view"}, nil)
+		srv := NewViewService(viewRepo, new(mocks.ITaskRepository), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.UpdateView(ctx, id, uid, "renamed", query)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		viewRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: ビューが存在しない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "view not found"}
+		viewRepo := new(mocks.IViewRepository)
+		viewRepo.On("FindViewByID", ctx, id).Return(nil, errors.New("no rows"))
+		srv := NewViewService(viewRepo, new(mocks.ITaskRepository), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.UpdateView(ctx, id, uid, "renamed", query)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		viewRepo.AssertExpectations(t)
+	})
+}
+
+func TestViewService_DeleteView(tt *testing.T) {
+	ctx := context.Background()
+	id := "id"
+	uid := "uid"
+	view := &entity.View{ID: value.NewID(id), UserID: value.NewID(uid), Name: "view"}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		viewRepo := new(mocks.IViewRepository)
+		viewRepo.On("FindViewByID", ctx, id).Return(view, nil)
+		viewRepo.On("DeleteView", ctx, id).Return(nil)
+		srv := NewViewService(viewRepo, new(mocks.ITaskRepository), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.DeleteView(ctx, id, uid)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		viewRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 組み込みのビューの場合", func(t *testing.T) {
+		errExp := &domain.ErrPreconditionFailed{Msg: "built-in view cannot be modified"}
+		viewRepo := new(mocks.IViewRepository)
+		srv := NewViewService(viewRepo, new(mocks.ITaskRepository), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.DeleteView(ctx, "builtin-no-due-date", uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		viewRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		viewRepo := new(mocks.IViewRepository)
+		viewRepo.On("FindViewByID", ctx, id).Return(view, nil)
+		viewRepo.On("DeleteView", ctx, id).Return(errors.New("error"))
+		srv := NewViewService(viewRepo, new(mocks.ITaskRepository), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.DeleteView(ctx, id, uid)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		viewRepo.AssertExpectations(t)
+	})
+}
+
+func TestViewService_ExecuteView(tt *testing.T) {
+	ctx := context.Background()
+	uid := "uid"
+	loc := time.FixedZone("JST", 9*60*60)
+	// JSTでは2024-01-03(水)になる
+	now := time.Date(2024, 1, 2, 16, 0, 0, 0, time.UTC)
+	today := time.Date(2024, 1, 3, 0, 0, 0, 0, loc).UTC()
+	tomorrow := time.Date(2024, 1, 4, 0, 0, 0, 0, loc).UTC()
+	due1 := time.Date(2024, 1, 3, 10, 0, 0, 0, loc)
+	due2 := time.Date(2024, 1, 4, 10, 0, 0, 0, loc)
+	newTasks := func() []*entity.Task {
+		return []*entity.Task{
+			{ID: value.NewID("t1"), UserID: value.NewID(uid), ListID: value.NewID("l1"), Name: "task1", Status: value.TaskStatusTodo, Priority: value.PriorityHigh, DueAt: &due1},
+			{ID: value.NewID("t2"), UserID: value.NewID(uid), ListID: value.NewID("l2"), Name: "task2", Status: value.TaskStatusDone, Priority: value.PriorityLow, DueAt: &due2},
+			{ID: value.NewID("t3"), UserID: value.NewID(uid), ListID: value.NewID("l1"), Name: "task3", Status: value.TaskStatusTodo, Priority: value.PriorityHigh},
+		}
+	}
+
+	tt.Run("正常系: 組み込みのビューの期間がタイムゾーンの暦で判定されること", func(t *testing.T) {
+		tasks := newTasks()[:1]
+		sort := value.TaskSort{Field: value.TaskSortFieldPriority, Descending: true}
+		filter := &value.TaskFilter{Completion: value.TaskCompletionOpen, DueFrom: &today, DueTo: &tomorrow}
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskPage", ctx, uid, filter, sort, int32(11), (*value.TaskPageCursor)(nil)).Return(tasks, nil)
+		taskRepo.On("FindBlockedTaskIDs", ctx, []string{"t1"}).Return([]string{}, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("LoadLocation", "Asia/Tokyo").Return(loc, nil)
+		cm.On("GetNow").Return(now)
+		srv := NewViewService(new(mocks.IViewRepository), taskRepo, new(mocks.IIDManager), cm)
+		ret, next, err := srv.ExecuteView(ctx, "builtin-today", uid, "Asia/Tokyo", 10, nil)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, []*entity.TaskGroup{{Key: "", Tasks: tasks}}, ret, "1つのグループにまとめられること")
+		require.Nil(t, next, "カーソルがないこと")
+		taskRepo.AssertExpectations(t)
+		cm.AssertExpectations(t)
+	})
+	testcases := []struct {
+		title   string
+		groupBy value.ViewGroupBy
+		keys    []string
+		ids     [][]string
+	}{
+		{"正常系: 状態でまとめる場合", value.ViewGroupByStatus, []string{"todo", "done"}, [][]string{{"t1", "t3"}, {"t2"}}},
+		{"正常系: 優先度でまとめる場合", value.ViewGroupByPriority, []string{"3", "1"}, [][]string{{"t1", "t3"}, {"t2"}}},
+		{"正常系: リストでまとめる場合", value.ViewGroupByList, []string{"l1", "l2"}, [][]string{{"t1", "t3"}, {"t2"}}},
+		{"正常系: 期限の日付でまとめる場合", value.ViewGroupByDueDate, []string{"2024-01-03", "2024-01-04", ""}, [][]string{{"t1"}, {"t2"}, {"t3"}}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			tasks := newTasks()
+			view := &entity.View{ID: value.NewID("vid"), UserID: value.NewID(uid), Name: "view", Query: value.ViewQuery{GroupBy: v.groupBy}}
+			viewRepo := new(mocks.IViewRepository)
+			viewRepo.On("FindViewByID", ctx, "vid").Return(view, nil)
+			taskRepo := new(mocks.ITaskRepository)
+			taskRepo.On("FindTaskPage", ctx, uid, &value.TaskFilter{}, value.TaskSort{}, int32(11), (*value.TaskPageCursor)(nil)).Return(tasks, nil)
+			taskRepo.On("FindBlockedTaskIDs", ctx, []string{"t1", "t2", "t3"}).Return([]string{}, nil)
+			cm := new(mocks.IClockManager)
+			cm.On("LoadLocation", "Asia/Tokyo").Return(loc, nil)
+			cm.On("GetNow").Return(now)
+			srv := NewViewService(viewRepo, taskRepo, new(mocks.IIDManager), cm)
+			ret, _, err := srv.ExecuteView(ctx, "vid", uid, "Asia/Tokyo", 10, nil)
+
+			require.NoError(t, err, "エラーが発生しないこと")
+			require.Len(t, ret, len(v.keys))
+			for i, g := range ret {
+				require.Equal(t, v.keys[i], g.Key, "最初に現れた順に並ぶこと")
+				ids := make([]string, 0, len(g.Tasks))
+				for _, task := range g.Tasks {
+					ids = append(ids, task.ID.Value())
+				}
+				require.Equal(t, v.ids[i], ids, "タスクの並び順が保たれること")
+			}
+			viewRepo.AssertExpectations(t)
+			taskRepo.AssertExpectations(t)
+		})
+	}
+	tt.Run("正常系: タスクがない場合はグループが空になること", func(t *testing.T) {
+		taskRepo := new(mocks.ITaskRepository)
+		taskRepo.On("FindTaskPage", ctx, uid, &value.TaskFilter{Completion: value.TaskCompletionOpen, NoDueDate: true}, value.TaskSort{Field: value.TaskSortFieldUpdatedAt, Descending: true}, int32(11), (*value.TaskPageCursor)(nil)).Return([]*entity.Task{}, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("LoadLocation", "UTC").Return(time.UTC, nil)
+		cm.On("GetNow").Return(now)
+		srv := NewViewService(new(mocks.IViewRepository), taskRepo, new(mocks.IIDManager), cm)
+		ret, _, err := srv.ExecuteView(ctx, "builtin-no-due-date", uid, "UTC", 10, nil)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Empty(t, ret)
+		taskRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: タイムゾーンが不正な場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "invalid timezone"}
+		cm := new(mocks.IClockManager)
+		cm.On("LoadLocation", "Mars/Olympus").Return(nil, errors.New("unknown time zone"))
+		srv := NewViewService(new(mocks.IViewRepository), new(mocks.ITaskRepository), new(mocks.IIDManager), cm)
+		_, _, err := srv.ExecuteView(ctx, "builtin-today", uid, "Mars/Olympus", 10, nil)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		cm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 他人のビューの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		viewRepo := new(mocks.IViewRepository)
+		viewRepo.On("FindViewByID", ctx, "vid").Return(&entity.View{ID: value.NewID("vid"), UserID: value.NewID("another"), Name: "view"}, nil)
+		cm := new(mocks.IClockManager)
+		cm.On("LoadLocation", "UTC").Return(time.UTC, nil)
+		srv := NewViewService(viewRepo, new(mocks.ITaskRepository), new(mocks.IIDManager), cm)
+		_, _, err := srv.ExecuteView(ctx, "vid", uid, "UTC", 10, nil)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		viewRepo.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 並び順の異なるカーソルの場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "page token does not match the sort order"}
+		cm := new(mocks.IClockManager)
+		cm.On("LoadLocation", "UTC").Return(time.UTC, nil)
+		cm.On("GetNow").Return(now)
+		srv := NewViewService(new(mocks.IViewRepository), new(mocks.ITaskRepository), new(mocks.IIDManager), cm)
+		_, _, err := srv.ExecuteView(ctx, "builtin-today", uid, "UTC", 10, &value.TaskPageCursor{Sort: value.TaskSort{Field: value.TaskSortFieldName}, ID: "t1"})
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+	})
+}
@@ -0,0 +1,382 @@
+package service
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/domain/repository"
+	"github.com/7oh2020/connect-tasklist/backend/util/clock"
+	"github.com/7oh2020/connect-tasklist/backend/util/identification"
+)
+
+// ワークスペースとメンバー、招待のドメインロジック
+// 管理者以上はメンバーの招待と削除ができ、管理者の任命と削除、ワークスペースの削除は所有者のみができる
+type IWorkspaceService interface {
+	FindWorkspacesByUserID(ctx context.Context, userID string) ([]*entity.Workspace, error)
+	CreateWorkspace(ctx context.Context, userID string, name string) (string, error)
+	DeleteWorkspace(ctx context.Context, id string, userID string) error
+	FindWorkspaceMembers(ctx context.Context, workspaceID string, userID string) ([]*entity.WorkspaceMember, error)
+	ChangeMemberRole(ctx context.Context, workspaceID string, userID string, memberID string, role value.WorkspaceRole) error
+	RemoveMember(ctx context.Context, workspaceID string, userID string, memberID string) error
+	FindWorkspaceInvitations(ctx context.Context, workspaceID string, userID string) ([]*entity.WorkspaceInvitation, error)
+	FindInvitationsByUserID(ctx context.Context, userID string) ([]*entity.WorkspaceInvitation, error)
+	InviteMember(ctx context.Context, workspaceID string, userID string, email string, role value.WorkspaceRole) (string, error)
+	AcceptInvitation(ctx context.Context, id string, userID string) error
+	DeleteInvitation(ctx context.Context, id string, userID string) error
+}
+
+type WorkspaceService struct {
+	repository.IWorkspaceRepository
+	repository.IUserRepository
+	repository.ITransactionManager
+	IAuthorizationPolicy
+	identification.IIDManager
+	clock.IClockManager
+}
+
+func NewWorkspaceService(workspaceRepo repository.IWorkspaceRepository, userRepo repository.IUserRepository, txManager repository.ITransactionManager, policy IAuthorizationPolicy, idManager identification.IIDManager, clockManager clock.IClockManager) *WorkspaceService {
+	return &WorkspaceService{workspaceRepo, userRepo, txManager, policy, idManager, clockManager}
+}
+
+// ユーザーがメンバーであるワークスペースを参加した順に取得する
+func (s *WorkspaceService) FindWorkspacesByUserID(ctx context.Context, userID string) ([]*entity.Workspace, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	workspaces, err := s.IWorkspaceRepository.FindWorkspacesByUserID(ctx, userID)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return workspaces, nil
+}
+
+// ワークスペースを作成する。作成したユーザーが所有者になる
+func (s *WorkspaceService) CreateWorkspace(ctx context.Context, userID string, name string) (string, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return "", err
+	}
+	now := s.IClockManager.GetNow()
+	workspace := &entity.Workspace{
+		ID:        value.NewID(s.IIDManager.GenerateID()),
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := workspace.Validate(); err != nil {
+		return "", err
+	}
+	owner := &entity.WorkspaceMember{
+		WorkspaceID: workspace.ID,
+		UserID:      value.NewID(userID),
+		Role:        value.WorkspaceRoleOwner,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	var createdID string
+	err := s.ITransactionManager.RunInTx(ctx, func(ctx context.Context) error {
+		var err error
+		if createdID, err = s.IWorkspaceRepository.CreateWorkspace(ctx, workspace); err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		if err := s.IWorkspaceRepository.CreateWorkspaceMember(ctx, owner); err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return createdID, nil
+}
+
+// ワークスペースを削除する。ワークスペースのリストとタスクも合わせて削除されるため所有者のみ削除できる
+func (s *WorkspaceService) DeleteWorkspace(ctx context.Context, id string, userID string) error {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeWorkspace(ctx, id, userID, value.WorkspaceRoleOwner); err != nil {
+		return err
+	}
+	if err := s.IWorkspaceRepository.DeleteWorkspace(ctx, id); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+// メンバーを参加した順に取得する
+func (s *WorkspaceService) FindWorkspaceMembers(ctx context.Context, workspaceID string, userID string) ([]*entity.WorkspaceMember, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeWorkspace(ctx, workspaceID, userID, value.WorkspaceRoleMember); err != nil {
+		return nil, err
+	}
+	members, err := s.IWorkspaceRepository.FindWorkspaceMembers(ctx, workspaceID)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return members, nil
+}
+
+// メンバーの権限をメンバーまたは管理者に変更する。所有者のみ変更でき、所有者自身の権限は変更できない
+func (s *WorkspaceService) ChangeMemberRole(ctx context.Context, workspaceID string, userID string, memberID string, role value.WorkspaceRole) error {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(memberID).Validate(); err != nil {
+		return err
+	}
+	if err := role.Validate(); err != nil {
+		return err
+	}
+	if role == value.WorkspaceRoleOwner {
+		return &domain.ErrValidationFailed{Msg: "owner role cannot be granted"}
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeWorkspace(ctx, workspaceID, userID, value.WorkspaceRoleOwner); err != nil {
+		return err
+	}
+	if memberID == userID {
+		return &domain.ErrValidationFailed{Msg: "cannot change own role"}
+	}
+	member, err := s.IWorkspaceRepository.FindWorkspaceMember(ctx, workspaceID, memberID)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "member not found"}
+	}
+	if member.Role == role {
+		return nil
+	}
+	member.Role = role
+	member.UpdatedAt = s.IClockManager.GetNow()
+	if err := member.Validate(); err != nil {
+		return err
+	}
+	if err := s.IWorkspaceRepository.UpdateWorkspaceMember(ctx, member); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+// メンバーをワークスペースから削除する。メンバー自身も脱退できるが、所有者は脱退できない
+// 作成したリストとタスクはワークスペースに残り、所有者はワークスペースの所有者に移る
+func (s *WorkspaceService) RemoveMember(ctx context.Context, workspaceID string, userID string, memberID string) error {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return err
+	}
+	if err := value.NewID(memberID).Validate(); err != nil {
+		return err
+	}
+	role, err := s.IAuthorizationPolicy.WorkspaceRole(ctx, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if !role.Includes(value.WorkspaceRoleMember) {
+		return &domain.ErrPermissionDenied{}
+	}
+	member, err := s.IWorkspaceRepository.FindWorkspaceMember(ctx, workspaceID, memberID)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "member not found"}
+	}
+	if member.Role == value.WorkspaceRoleOwner {
+		return &domain.ErrPreconditionFailed{Msg: "owner cannot be removed"}
+	}
+	if memberID != userID {
+		if !role.Includes(value.WorkspaceRoleAdmin) {
+			return &domain.ErrPermissionDenied{}
+		}
+		// 管理者は所有者のみが削除できる
+		if member.Role == value.WorkspaceRoleAdmin && role != value.WorkspaceRoleOwner {
+			return &domain.ErrPermissionDenied{}
+		}
+	}
+	members, err := s.IWorkspaceRepository.FindWorkspaceMembers(ctx, workspaceID)
+	if err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	var ownerID string
+	for _, v := range members {
+		if v.Role == value.WorkspaceRoleOwner {
+			ownerID = v.UserID.Value()
+		}
+	}
+	if ownerID == "" {
+		return &domain.ErrNotFound{Msg: "owner not found"}
+	}
+	// 所有者は無条件に全ての操作ができるため、所有者を移さないと削除後もリストとタスクを操作できてしまう
+	return s.ITransactionManager.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.IWorkspaceRepository.TransferWorkspaceOwnership(ctx, workspaceID, memberID, ownerID); err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		if err := s.IWorkspaceRepository.DeleteWorkspaceMember(ctx, workspaceID, memberID); err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		return nil
+	})
+}
+
+// ワークスペースの招待を古い順に取得する。管理者以上のみ取得できる
+func (s *WorkspaceService) FindWorkspaceInvitations(ctx context.Context, workspaceID string, userID string) ([]*entity.WorkspaceInvitation, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.IAuthorizationPolicy.AuthorizeWorkspace(ctx, workspaceID, userID, value.WorkspaceRoleAdmin); err != nil {
+		return nil, err
+	}
+	invitations, err := s.IWorkspaceRepository.FindWorkspaceInvitationsByWorkspaceID(ctx, workspaceID)
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return invitations, nil
+}
+
+// ユーザーのメールアドレスへの招待を古い順に取得する
+func (s *WorkspaceService) FindInvitationsByUserID(ctx context.Context, userID string) ([]*entity.WorkspaceInvitation, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	invitations, err := s.IWorkspaceRepository.FindWorkspaceInvitationsByEmail(ctx, user.Email.Value())
+	if err != nil {
+		return nil, &domain.ErrQueryFailed{}
+	}
+	return invitations, nil
+}
+
+// メールアドレスをワークスペースに招待する。既に招待している場合は権限を変更する
+// 管理者以上が招待でき、管理者としての招待は所有者のみができる
+func (s *WorkspaceService) InviteMember(ctx context.Context, workspaceID string, userID string, email string, role value.WorkspaceRole) (string, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return "", err
+	}
+	if err := value.NewEmail(email).Validate(); err != nil {
+		return "", err
+	}
+	current, err := s.IAuthorizationPolicy.WorkspaceRole(ctx, workspaceID, userID)
+	if err != nil {
+		return "", err
+	}
+	if !current.Includes(value.WorkspaceRoleAdmin) {
+		return "", &domain.ErrPermissionDenied{}
+	}
+	if role == value.WorkspaceRoleAdmin && current != value.WorkspaceRoleOwner {
+		return "", &domain.ErrPermissionDenied{}
+	}
+	if user, err := s.IUserRepository.FindUserByEmail(ctx, email); err == nil {
+		if _, err := s.IWorkspaceRepository.FindWorkspaceMember(ctx, workspaceID, user.ID.Value()); err == nil {
+			return "", &domain.ErrAlreadyExists{Msg: "already a member"}
+		}
+	}
+	if invitation, err := s.IWorkspaceRepository.FindWorkspaceInvitationByEmail(ctx, workspaceID, email); err == nil {
+		if invitation.Role == role {
+			return invitation.ID.Value(), nil
+		}
+		invitation.Role = role
+		invitation.InvitedBy = value.NewID(userID)
+		if err := invitation.Validate(); err != nil {
+			return "", err
+		}
+		if err := s.IWorkspaceRepository.UpdateWorkspaceInvitation(ctx, invitation); err != nil {
+			return "", &domain.ErrQueryFailed{}
+		}
+		return invitation.ID.Value(), nil
+	}
+	arg := &entity.WorkspaceInvitation{
+		ID:          value.NewID(s.IIDManager.GenerateID()),
+		WorkspaceID: value.NewID(workspaceID),
+		Email:       value.NewEmail(email),
+		Role:        role,
+		InvitedBy:   value.NewID(userID),
+		CreatedAt:   s.IClockManager.GetNow(),
+	}
+	if err := arg.Validate(); err != nil {
+		return "", err
+	}
+	createdID, err := s.IWorkspaceRepository.CreateWorkspaceInvitation(ctx, arg)
+	if err != nil {
+		return "", &domain.ErrQueryFailed{}
+	}
+	return createdID, nil
+}
+
+// 自分のメールアドレスへの招待を承諾してメンバーになる。承諾した招待は削除される
+func (s *WorkspaceService) AcceptInvitation(ctx context.Context, id string, userID string) error {
+	invitation, user, err := s.findOwnInvitation(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.IWorkspaceRepository.FindWorkspaceMember(ctx, invitation.WorkspaceID.Value(), userID); err == nil {
+		return &domain.ErrAlreadyExists{Msg: "already a member"}
+	}
+	now := s.IClockManager.GetNow()
+	member := &entity.WorkspaceMember{
+		WorkspaceID: invitation.WorkspaceID,
+		UserID:      user.ID,
+		Role:        invitation.Role,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := member.Validate(); err != nil {
+		return err
+	}
+	return s.ITransactionManager.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.IWorkspaceRepository.CreateWorkspaceMember(ctx, member); err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		if err := s.IWorkspaceRepository.DeleteWorkspaceInvitation(ctx, id); err != nil {
+			return &domain.ErrQueryFailed{}
+		}
+		return nil
+	})
+}
+
+// 招待を削除する。招待されたユーザーは辞退として、管理者以上は取り消しとして削除できる
+func (s *WorkspaceService) DeleteInvitation(ctx context.Context, id string, userID string) error {
+	if err := value.NewID(id).Validate(); err != nil {
+		return err
+	}
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	invitation, err := s.IWorkspaceRepository.FindWorkspaceInvitationByID(ctx, id)
+	if err != nil {
+		return &domain.ErrNotFound{Msg: "invitation not found"}
+	}
+	if invitation.Email.Value() != user.Email.Value() {
+		if err := s.IAuthorizationPolicy.AuthorizeWorkspace(ctx, invitation.WorkspaceID.Value(), userID, value.WorkspaceRoleAdmin); err != nil {
+			return err
+		}
+	}
+	if err := s.IWorkspaceRepository.DeleteWorkspaceInvitation(ctx, id); err != nil {
+		return &domain.ErrQueryFailed{}
+	}
+	return nil
+}
+
+func (s *WorkspaceService) findUser(ctx context.Context, userID string) (*entity.User, error) {
+	if err := value.NewID(userID).Validate(); err != nil {
+		return nil, err
+	}
+	user, err := s.IUserRepository.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, &domain.ErrNotFound{Msg: "user not found"}
+	}
+	return user, nil
+}
+
+// 自分のメールアドレスへの招待を取得する。他のユーザーへの招待は存在しないものとして扱う
+func (s *WorkspaceService) findOwnInvitation(ctx context.Context, id string, userID string) (*entity.WorkspaceInvitation, *entity.User, error) {
+	if err := value.NewID(id).Validate(); err != nil {
+		return nil, nil, err
+	}
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	invitation, err := s.IWorkspaceRepository.FindWorkspaceInvitationByID(ctx, id)
+	if err != nil || invitation.Email.Value() != user.Email.Value() {
+		return nil, nil, &domain.ErrNotFound{Msg: "invitation not found"}
+	}
+	return invitation, user, nil
+}
@@ -0,0 +1,657 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// ワークスペースのメンバーを作成する
+func newWorkspaceMember(userID string, role value.WorkspaceRole) *entity.WorkspaceMember {
+	return &entity.WorkspaceMember{WorkspaceID: value.NewID("wid"), UserID: value.NewID(userID), Role: role}
+}
+
+// wrのメンバーで認可するワークスペースのサービスを作成する
+func newWorkspaceService(wr *mocks.IWorkspaceRepository, ur *mocks.IUserRepository, txm *mocks.ITransactionManager, im *mocks.IIDManager, cm *mocks.IClockManager) *WorkspaceService {
+	return NewWorkspaceService(wr, ur, txm, NewAuthorizationPolicy(new(mocks.ICollaboratorRepository), wr), im, cm)
+}
+
+func TestWorkspaceService_NewWorkspaceService(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ IWorkspaceService = (*WorkspaceService)(nil)
+	})
+}
+
+func TestWorkspaceService_FindWorkspacesByUserID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	workspaces := []*entity.Workspace{
+		{ID: value.NewID("wid"), Name: "team", Role: value.WorkspaceRoleMember, CreatedAt: now, UpdatedAt: now},
+	}
+
+	tt.Run("正常系: 正しい入力の場合", func(t *testing.T) {
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspacesByUserID", ctx, "uid").Return(workspaces, nil)
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		ret, err := srv.FindWorkspacesByUserID(ctx, "uid")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, workspaces, ret)
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: UserIDが空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "id is empty"}
+		wr := new(mocks.IWorkspaceRepository)
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindWorkspacesByUserID(ctx, "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspacesByUserID", ctx, "uid").Return(nil, errors.New("query error"))
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindWorkspacesByUserID(ctx, "uid")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceService_CreateWorkspace(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	workspace := &entity.Workspace{ID: value.NewID("wid"), Name: "team", CreatedAt: now, UpdatedAt: now}
+	owner := &entity.WorkspaceMember{WorkspaceID: value.NewID("wid"), UserID: value.NewID("uid"), Role: value.WorkspaceRoleOwner, CreatedAt: now, UpdatedAt: now}
+
+	tt.Run("正常系: 作成したユーザーが所有者になること", func(t *testing.T) {
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("CreateWorkspace", ctx, workspace).Return("wid", nil)
+		wr.On("CreateWorkspaceMember", ctx, owner).Return(nil)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("wid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		txm := newTxManagerMock(ctx)
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), txm, im, cm)
+		ret, err := srv.CreateWorkspace(ctx, "uid", "team")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, "wid", ret)
+		wr.AssertExpectations(t)
+		txm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 名前が空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "name is empty"}
+		wr := new(mocks.IWorkspaceRepository)
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("wid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), im, cm)
+		_, err := srv.CreateWorkspace(ctx, "uid", "")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 所有者の追加に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("CreateWorkspace", ctx, workspace).Return("wid", nil)
+		wr.On("CreateWorkspaceMember", ctx, owner).Return(errors.New("query error"))
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("wid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), newTxManagerMock(ctx), im, cm)
+		_, err := srv.CreateWorkspace(ctx, "uid", "team")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceService_DeleteWorkspace(tt *testing.T) {
+	ctx := context.Background()
+
+	tt.Run("正常系: 所有者の場合", func(t *testing.T) {
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "uid").Return(newWorkspaceMember("uid", value.WorkspaceRoleOwner), nil)
+		wr.On("DeleteWorkspace", ctx, "wid").Return(nil)
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.DeleteWorkspace(ctx, "wid", "uid")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 管理者の場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "uid").Return(newWorkspaceMember("uid", value.WorkspaceRoleAdmin), nil)
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.DeleteWorkspace(ctx, "wid", "uid")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: クエリエラーの場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "uid").Return(newWorkspaceMember("uid", value.WorkspaceRoleOwner), nil)
+		wr.On("DeleteWorkspace", ctx, "wid").Return(errors.New("query error"))
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.DeleteWorkspace(ctx, "wid", "uid")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceService_FindWorkspaceMembers(tt *testing.T) {
+	ctx := context.Background()
+	members := []*entity.WorkspaceMember{newWorkspaceMember("owner", value.WorkspaceRoleOwner), newWorkspaceMember("uid", value.WorkspaceRoleMember)}
+
+	tt.Run("正常系: メンバーの場合", func(t *testing.T) {
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "uid").Return(members[1], nil)
+		wr.On("FindWorkspaceMembers", ctx, "wid").Return(members, nil)
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		ret, err := srv.FindWorkspaceMembers(ctx, "wid", "uid")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, members, ret)
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: メンバーではない場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "other").Return(nil, errors.New("not found"))
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindWorkspaceMembers(ctx, "wid", "other")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceService_ChangeMemberRole(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	tt.Run("正常系: 所有者がメンバーを管理者にする場合", func(t *testing.T) {
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "owner").Return(newWorkspaceMember("owner", value.WorkspaceRoleOwner), nil)
+		wr.On("FindWorkspaceMember", ctx, "wid", "uid").Return(newWorkspaceMember("uid", value.WorkspaceRoleMember), nil)
+		wr.On("UpdateWorkspaceMember", ctx, &entity.WorkspaceMember{WorkspaceID: value.NewID("wid"), UserID: value.NewID("uid"), Role: value.WorkspaceRoleAdmin, UpdatedAt: now}).Return(nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), cm)
+		err := srv.ChangeMemberRole(ctx, "wid", "owner", "uid", value.WorkspaceRoleAdmin)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		wr.AssertExpectations(t)
+	})
+	tt.Run("正常系: 同じ権限の場合は変更しないこと", func(t *testing.T) {
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "owner").Return(newWorkspaceMember("owner", value.WorkspaceRoleOwner), nil)
+		wr.On("FindWorkspaceMember", ctx, "wid", "uid").Return(newWorkspaceMember("uid", value.WorkspaceRoleMember), nil)
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.ChangeMemberRole(ctx, "wid", "owner", "uid", value.WorkspaceRoleMember)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 管理者が変更する場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "admin").Return(newWorkspaceMember("admin", value.WorkspaceRoleAdmin), nil)
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.ChangeMemberRole(ctx, "wid", "admin", "uid", value.WorkspaceRoleAdmin)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 所有者の権限を付与する場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "owner role cannot be granted"}
+		wr := new(mocks.IWorkspaceRepository)
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.ChangeMemberRole(ctx, "wid", "owner", "uid", value.WorkspaceRoleOwner)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 自分の権限を変更する場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "cannot change own role"}
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "owner").Return(newWorkspaceMember("owner", value.WorkspaceRoleOwner), nil)
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.ChangeMemberRole(ctx, "wid", "owner", "owner", value.WorkspaceRoleMember)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: メンバーが存在しない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "member not found"}
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "owner").Return(newWorkspaceMember("owner", value.WorkspaceRoleOwner), nil)
+		wr.On("FindWorkspaceMember", ctx, "wid", "uid").Return(nil, errors.New("not found"))
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.ChangeMemberRole(ctx, "wid", "owner", "uid", value.WorkspaceRoleAdmin)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceService_RemoveMember(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	members := []*entity.WorkspaceMember{
+		newWorkspaceMember("owner", value.WorkspaceRoleOwner),
+		newWorkspaceMember("admin", value.WorkspaceRoleAdmin),
+		newWorkspaceMember("uid", value.WorkspaceRoleMember),
+	}
+
+	testcases := []struct {
+		title    string
+		userID   string
+		role     value.WorkspaceRole
+		memberID string
+		target   value.WorkspaceRole
+		err      error
+	}{
+		{"正常系: 管理者がメンバーを削除する場合", "admin", value.WorkspaceRoleAdmin, "uid", value.WorkspaceRoleMember, nil},
+		{"正常系: 所有者が管理者を削除する場合", "owner", value.WorkspaceRoleOwner, "admin", value.WorkspaceRoleAdmin, nil},
+		{"正常系: メンバーが脱退する場合", "uid", value.WorkspaceRoleMember, "uid", value.WorkspaceRoleMember, nil},
+		{"準正常系: メンバーが他のメンバーを削除する場合", "uid", value.WorkspaceRoleMember, "other", value.WorkspaceRoleMember, &domain.ErrPermissionDenied{}},
+		{"準正常系: 管理者が管理者を削除する場合", "admin", value.WorkspaceRoleAdmin, "other", value.WorkspaceRoleAdmin, &domain.ErrPermissionDenied{}},
+		{"準正常系: 所有者が脱退する場合", "owner", value.WorkspaceRoleOwner, "owner", value.WorkspaceRoleOwner, &domain.ErrPreconditionFailed{Msg: "owner cannot be removed"}},
+		{"準正常系: 管理者が所有者を削除する場合", "admin", value.WorkspaceRoleAdmin, "owner", value.WorkspaceRoleOwner, &domain.ErrPreconditionFailed{Msg: "owner cannot be removed"}},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			wr := new(mocks.IWorkspaceRepository)
+			wr.On("FindWorkspaceMember", ctx, "wid", v.userID).Return(newWorkspaceMember(v.userID, v.role), nil)
+			wr.On("FindWorkspaceMember", ctx, "wid", v.memberID).Return(newWorkspaceMember(v.memberID, v.target), nil)
+			if v.err == nil {
+				wr.On("FindWorkspaceMembers", ctx, "wid").Return(members, nil)
+				wr.On("TransferWorkspaceOwnership", ctx, "wid", v.memberID, "owner").Return(nil)
+				wr.On("DeleteWorkspaceMember", ctx, "wid", v.memberID).Return(nil)
+			}
+			srv := newWorkspaceService(wr, new(mocks.IUserRepository), newTxManagerMock(ctx), new(mocks.IIDManager), new(mocks.IClockManager))
+			err := srv.RemoveMember(ctx, "wid", v.userID, v.memberID)
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+			wr.AssertExpectations(t)
+		})
+	}
+	tt.Run("準正常系: メンバーではない場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "other").Return(nil, errors.New("not found"))
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.RemoveMember(ctx, "wid", "other", "uid")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 削除するメンバーが存在しない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "member not found"}
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "admin").Return(newWorkspaceMember("admin", value.WorkspaceRoleAdmin), nil)
+		wr.On("FindWorkspaceMember", ctx, "wid", "uid").Return(nil, errors.New("not found"))
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.RemoveMember(ctx, "wid", "admin", "uid")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+	tt.Run("正常系: 削除したメンバーは作成したリストとタスクを操作できなくなること", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		list := &entity.List{ID: value.NewID("lid"), UserID: value.NewID("uid"), WorkspaceID: value.NewID("wid"), Name: "list", CreatedAt: now, UpdatedAt: now}
+		task := &entity.Task{ID: value.NewID("tid"), UserID: value.NewID("uid"), ListID: list.ID, Position: "i", Name: "task", Status: value.TaskStatusTodo, CreatedAt: now, UpdatedAt: now}
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "admin").Return(newWorkspaceMember("admin", value.WorkspaceRoleAdmin), nil)
+		wr.On("FindWorkspaceMember", ctx, "wid", "uid").Return(newWorkspaceMember("uid", value.WorkspaceRoleMember), nil)
+		wr.On("FindWorkspaceMembers", ctx, "wid").Return(members, nil)
+		wr.On("TransferWorkspaceOwnership", ctx, "wid", "uid", "owner").Return(nil).Run(func(mock.Arguments) {
+			list.UserID = value.NewID("owner")
+			task.UserID = value.NewID("owner")
+		})
+		wr.On("DeleteWorkspaceMember", ctx, "wid", "uid").Return(nil)
+		// 削除後はワークスペースのメンバーの権限も共有もない
+		cr := new(mocks.ICollaboratorRepository)
+		cr.On("FindListRole", ctx, "lid", "uid").Return(value.RoleNone, nil)
+		cr.On("FindTaskRole", ctx, "tid", "uid").Return(value.RoleNone, nil)
+		policy := NewAuthorizationPolicy(cr, wr)
+		srv := NewWorkspaceService(wr, new(mocks.IUserRepository), newTxManagerMock(ctx), policy, new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.RemoveMember(ctx, "wid", "admin", "uid")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.EqualError(t, policy.AuthorizeList(ctx, list, "uid", value.RoleViewer), errExp.Error(), "リストを閲覧できないこと")
+		require.EqualError(t, policy.AuthorizeTask(ctx, task, "uid", value.RoleViewer), errExp.Error(), "タスクを閲覧できないこと")
+		require.NoError(t, policy.AuthorizeList(ctx, list, "owner", value.RoleOwner), "ワークスペースの所有者が所有すること")
+		wr.AssertExpectations(t)
+		cr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 所有者の移動に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "admin").Return(newWorkspaceMember("admin", value.WorkspaceRoleAdmin), nil)
+		wr.On("FindWorkspaceMember", ctx, "wid", "uid").Return(newWorkspaceMember("uid", value.WorkspaceRoleMember), nil)
+		wr.On("FindWorkspaceMembers", ctx, "wid").Return(members, nil)
+		wr.On("TransferWorkspaceOwnership", ctx, "wid", "uid", "owner").Return(errors.New("failed"))
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), newTxManagerMock(ctx), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.RemoveMember(ctx, "wid", "admin", "uid")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+		wr.AssertNotCalled(t, "DeleteWorkspaceMember", ctx, "wid", "uid")
+	})
+}
+
+func TestWorkspaceService_FindWorkspaceInvitations(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	invitations := []*entity.WorkspaceInvitation{
+		{ID: value.NewID("iid"), WorkspaceID: value.NewID("wid"), Email: value.NewEmail("new@example.com"), Role: value.WorkspaceRoleMember, InvitedBy: value.NewID("admin"), CreatedAt: now},
+	}
+
+	tt.Run("正常系: 管理者の場合", func(t *testing.T) {
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "admin").Return(newWorkspaceMember("admin", value.WorkspaceRoleAdmin), nil)
+		wr.On("FindWorkspaceInvitationsByWorkspaceID", ctx, "wid").Return(invitations, nil)
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		ret, err := srv.FindWorkspaceInvitations(ctx, "wid", "admin")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, invitations, ret)
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: メンバーの場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "uid").Return(newWorkspaceMember("uid", value.WorkspaceRoleMember), nil)
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindWorkspaceInvitations(ctx, "wid", "uid")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceService_FindInvitationsByUserID(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	user := &entity.User{ID: value.NewID("uid"), Email: value.NewEmail("uid@example.com")}
+	invitations := []*entity.WorkspaceInvitation{
+		{ID: value.NewID("iid"), WorkspaceID: value.NewID("wid"), WorkspaceName: "team", Email: value.NewEmail("uid@example.com"), Role: value.WorkspaceRoleMember, InvitedBy: value.NewID("admin"), CreatedAt: now},
+	}
+
+	tt.Run("正常系: 自分のメールアドレスへの招待を取得すること", func(t *testing.T) {
+		ur := new(mocks.IUserRepository)
+		ur.On("FindUserByID", ctx, "uid").Return(user, nil)
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceInvitationsByEmail", ctx, "uid@example.com").Return(invitations, nil)
+		srv := newWorkspaceService(wr, ur, new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		ret, err := srv.FindInvitationsByUserID(ctx, "uid")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, invitations, ret)
+		ur.AssertExpectations(t)
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: ユーザーが存在しない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "user not found"}
+		ur := new(mocks.IUserRepository)
+		ur.On("FindUserByID", ctx, "uid").Return(nil, errors.New("not found"))
+		wr := new(mocks.IWorkspaceRepository)
+		srv := newWorkspaceService(wr, ur, new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.FindInvitationsByUserID(ctx, "uid")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		ur.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceService_InviteMember(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	email := "new@example.com"
+	newInvitation := func(role value.WorkspaceRole, invitedBy string) *entity.WorkspaceInvitation {
+		return &entity.WorkspaceInvitation{ID: value.NewID("iid"), WorkspaceID: value.NewID("wid"), Email: value.NewEmail(email), Role: role, InvitedBy: value.NewID(invitedBy), CreatedAt: now}
+	}
+
+	tt.Run("正常系: 管理者がメンバーとして招待する場合", func(t *testing.T) {
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "admin").Return(newWorkspaceMember("admin", value.WorkspaceRoleAdmin), nil)
+		wr.On("FindWorkspaceInvitationByEmail", ctx, "wid", email).Return(nil, errors.New("not found"))
+		wr.On("CreateWorkspaceInvitation", ctx, newInvitation(value.WorkspaceRoleMember, "admin")).Return("iid", nil)
+		ur := new(mocks.IUserRepository)
+		ur.On("FindUserByEmail", ctx, email).Return(nil, errors.New("not found"))
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("iid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := newWorkspaceService(wr, ur, new(mocks.ITransactionManager), im, cm)
+		ret, err := srv.InviteMember(ctx, "wid", "admin", email, value.WorkspaceRoleMember)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, "iid", ret)
+		wr.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("正常系: 既に招待している場合は権限を変更すること", func(t *testing.T) {
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "owner").Return(newWorkspaceMember("owner", value.WorkspaceRoleOwner), nil)
+		wr.On("FindWorkspaceInvitationByEmail", ctx, "wid", email).Return(newInvitation(value.WorkspaceRoleMember, "admin"), nil)
+		wr.On("UpdateWorkspaceInvitation", ctx, newInvitation(value.WorkspaceRoleAdmin, "owner")).Return(nil)
+		ur := new(mocks.IUserRepository)
+		ur.On("FindUserByEmail", ctx, email).Return(nil, errors.New("not found"))
+		srv := newWorkspaceService(wr, ur, new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		ret, err := srv.InviteMember(ctx, "wid", "owner", email, value.WorkspaceRoleAdmin)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, "iid", ret)
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: メンバーが招待する場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "uid").Return(newWorkspaceMember("uid", value.WorkspaceRoleMember), nil)
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.InviteMember(ctx, "wid", "uid", email, value.WorkspaceRoleMember)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 管理者が管理者として招待する場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "admin").Return(newWorkspaceMember("admin", value.WorkspaceRoleAdmin), nil)
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.InviteMember(ctx, "wid", "admin", email, value.WorkspaceRoleAdmin)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 既にメンバーの場合", func(t *testing.T) {
+		errExp := &domain.ErrAlreadyExists{Msg: "already a member"}
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "admin").Return(newWorkspaceMember("admin", value.WorkspaceRoleAdmin), nil)
+		wr.On("FindWorkspaceMember", ctx, "wid", "uid").Return(newWorkspaceMember("uid", value.WorkspaceRoleMember), nil)
+		ur := new(mocks.IUserRepository)
+		ur.On("FindUserByEmail", ctx, email).Return(&entity.User{ID: value.NewID("uid"), Email: value.NewEmail(email)}, nil)
+		srv := newWorkspaceService(wr, ur, new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.InviteMember(ctx, "wid", "admin", email, value.WorkspaceRoleMember)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+		ur.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 所有者として招待する場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "owner cannot be invited"}
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceMember", ctx, "wid", "owner").Return(newWorkspaceMember("owner", value.WorkspaceRoleOwner), nil)
+		wr.On("FindWorkspaceInvitationByEmail", ctx, "wid", email).Return(nil, errors.New("not found"))
+		ur := new(mocks.IUserRepository)
+		ur.On("FindUserByEmail", ctx, email).Return(nil, errors.New("not found"))
+		im := new(mocks.IIDManager)
+		im.On("GenerateID").Return("iid")
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := newWorkspaceService(wr, ur, new(mocks.ITransactionManager), im, cm)
+		_, err := srv.InviteMember(ctx, "wid", "owner", email, value.WorkspaceRoleOwner)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: メールアドレスが空の場合", func(t *testing.T) {
+		errExp := &domain.ErrValidationFailed{Msg: "email is empty"}
+		wr := new(mocks.IWorkspaceRepository)
+		srv := newWorkspaceService(wr, new(mocks.IUserRepository), new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		_, err := srv.InviteMember(ctx, "wid", "admin", "", value.WorkspaceRoleMember)
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceService_AcceptInvitation(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	user := &entity.User{ID: value.NewID("uid"), Email: value.NewEmail("uid@example.com")}
+	invitation := &entity.WorkspaceInvitation{ID: value.NewID("iid"), WorkspaceID: value.NewID("wid"), Email: value.NewEmail("uid@example.com"), Role: value.WorkspaceRoleAdmin, InvitedBy: value.NewID("owner"), CreatedAt: now}
+
+	tt.Run("正常系: 招待された権限でメンバーになること", func(t *testing.T) {
+		ur := new(mocks.IUserRepository)
+		ur.On("FindUserByID", ctx, "uid").Return(user, nil)
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceInvitationByID", ctx, "iid").Return(invitation, nil)
+		wr.On("FindWorkspaceMember", ctx, "wid", "uid").Return(nil, errors.New("not found"))
+		wr.On("CreateWorkspaceMember", ctx, &entity.WorkspaceMember{WorkspaceID: value.NewID("wid"), UserID: value.NewID("uid"), Role: value.WorkspaceRoleAdmin, CreatedAt: now, UpdatedAt: now}).Return(nil)
+		wr.On("DeleteWorkspaceInvitation", ctx, "iid").Return(nil)
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		txm := newTxManagerMock(ctx)
+		srv := newWorkspaceService(wr, ur, txm, new(mocks.IIDManager), cm)
+		err := srv.AcceptInvitation(ctx, "iid", "uid")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		wr.AssertExpectations(t)
+		txm.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 他のユーザーへの招待の場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "invitation not found"}
+		ur := new(mocks.IUserRepository)
+		ur.On("FindUserByID", ctx, "other").Return(&entity.User{ID: value.NewID("other"), Email: value.NewEmail("other@example.com")}, nil)
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceInvitationByID", ctx, "iid").Return(invitation, nil)
+		srv := newWorkspaceService(wr, ur, new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.AcceptInvitation(ctx, "iid", "other")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 既にメンバーの場合", func(t *testing.T) {
+		errExp := &domain.ErrAlreadyExists{Msg: "already a member"}
+		ur := new(mocks.IUserRepository)
+		ur.On("FindUserByID", ctx, "uid").Return(user, nil)
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceInvitationByID", ctx, "iid").Return(invitation, nil)
+		wr.On("FindWorkspaceMember", ctx, "wid", "uid").Return(newWorkspaceMember("uid", value.WorkspaceRoleMember), nil)
+		srv := newWorkspaceService(wr, ur, new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.AcceptInvitation(ctx, "iid", "uid")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 招待の削除に失敗した場合", func(t *testing.T) {
+		errExp := &domain.ErrQueryFailed{}
+		ur := new(mocks.IUserRepository)
+		ur.On("FindUserByID", ctx, "uid").Return(user, nil)
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceInvitationByID", ctx, "iid").Return(invitation, nil)
+		wr.On("FindWorkspaceMember", ctx, "wid", "uid").Return(nil, errors.New("not found"))
+		wr.On("CreateWorkspaceMember", ctx, &entity.WorkspaceMember{WorkspaceID: value.NewID("wid"), UserID: value.NewID("uid"), Role: value.WorkspaceRoleAdmin, CreatedAt: now, UpdatedAt: now}).Return(nil)
+		wr.On("DeleteWorkspaceInvitation", ctx, "iid").Return(errors.New("query error"))
+		cm := new(mocks.IClockManager)
+		cm.On("GetNow").Return(now)
+		srv := newWorkspaceService(wr, ur, newTxManagerMock(ctx), new(mocks.IIDManager), cm)
+		err := srv.AcceptInvitation(ctx, "iid", "uid")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceService_DeleteInvitation(tt *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	invitation := &entity.WorkspaceInvitation{ID: value.NewID("iid"), WorkspaceID: value.NewID("wid"), Email: value.NewEmail("uid@example.com"), Role: value.WorkspaceRoleMember, InvitedBy: value.NewID("admin"), CreatedAt: now}
+
+	tt.Run("正常系: 招待されたユーザーが辞退する場合", func(t *testing.T) {
+		ur := new(mocks.IUserRepository)
+		ur.On("FindUserByID", ctx, "uid").Return(&entity.User{ID: value.NewID("uid"), Email: value.NewEmail("uid@example.com")}, nil)
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceInvitationByID", ctx, "iid").Return(invitation, nil)
+		wr.On("DeleteWorkspaceInvitation", ctx, "iid").Return(nil)
+		srv := newWorkspaceService(wr, ur, new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.DeleteInvitation(ctx, "iid", "uid")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		wr.AssertExpectations(t)
+	})
+	tt.Run("正常系: 管理者が取り消す場合", func(t *testing.T) {
+		ur := new(mocks.IUserRepository)
+		ur.On("FindUserByID", ctx, "admin").Return(&entity.User{ID: value.NewID("admin"), Email: value.NewEmail("admin@example.com")}, nil)
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceInvitationByID", ctx, "iid").Return(invitation, nil)
+		wr.On("FindWorkspaceMember", ctx, "wid", "admin").Return(newWorkspaceMember("admin", value.WorkspaceRoleAdmin), nil)
+		wr.On("DeleteWorkspaceInvitation", ctx, "iid").Return(nil)
+		srv := newWorkspaceService(wr, ur, new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.DeleteInvitation(ctx, "iid", "admin")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 招待されていないメンバーが削除する場合", func(t *testing.T) {
+		errExp := &domain.ErrPermissionDenied{}
+		ur := new(mocks.IUserRepository)
+		ur.On("FindUserByID", ctx, "other").Return(&entity.User{ID: value.NewID("other"), Email: value.NewEmail("other@example.com")}, nil)
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceInvitationByID", ctx, "iid").Return(invitation, nil)
+		wr.On("FindWorkspaceMember", ctx, "wid", "other").Return(newWorkspaceMember("other", value.WorkspaceRoleMember), nil)
+		srv := newWorkspaceService(wr, ur, new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.DeleteInvitation(ctx, "iid", "other")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+	tt.Run("準正常系: 招待が存在しない場合", func(t *testing.T) {
+		errExp := &domain.ErrNotFound{Msg: "invitation not found"}
+		ur := new(mocks.IUserRepository)
+		ur.On("FindUserByID", ctx, "uid").Return(&entity.User{ID: value.NewID("uid"), Email: value.NewEmail("uid@example.com")}, nil)
+		wr := new(mocks.IWorkspaceRepository)
+		wr.On("FindWorkspaceInvitationByID", ctx, "iid").Return(nil, errors.New("not found"))
+		srv := newWorkspaceService(wr, ur, new(mocks.ITransactionManager), new(mocks.IIDManager), new(mocks.IClockManager))
+		err := srv.DeleteInvitation(ctx, "iid", "uid")
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		wr.AssertExpectations(t)
+	})
+}
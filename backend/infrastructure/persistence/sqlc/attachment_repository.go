@@ -0,0 +1,81 @@
+package sqlc
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/infrastructure/persistence/model/db"
+)
+
+// 添付ファイルのメタデータの永続化のSQLC実装
+type SQLCAttachmentRepository struct {
+	db.Querier
+}
+
+func NewSQLCAttachmentRepository(qry db.Querier) *SQLCAttachmentRepository {
+	return &SQLCAttachmentRepository{qry}
+}
+
+func (r *SQLCAttachmentRepository) FindAttachmentByID(ctx context.Context, id string) (*entity.Attachment, error) {
+	res, err := withTx(ctx, r.Querier).FindAttachmentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toAttachmentEntity(res), nil
+}
+
+func (r *SQLCAttachmentRepository) FindAttachmentsByTaskID(ctx context.Context, taskID string) ([]*entity.Attachment, error) {
+	res, err := withTx(ctx, r.Querier).FindAttachmentsByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	attachments := make([]*entity.Attachment, len(res))
+	for i, v := range res {
+		attachments[i] = toAttachmentEntity(v)
+	}
+	return attachments, nil
+}
+
+func (r *SQLCAttachmentRepository) SumAttachmentSizeByUserID(ctx context.Context, userID string) (int64, error) {
+	return withTx(ctx, r.Querier).SumAttachmentSizeByUserID(ctx, userID)
+}
+
+func (r *SQLCAttachmentRepository) CreateAttachment(ctx context.Context, arg *entity.Attachment) (string, error) {
+	return withTx(ctx, r.Querier).CreateAttachment(ctx, db.CreateAttachmentParams{
+		ID:          arg.ID.Value(),
+		TaskID:      arg.TaskID.Value(),
+		UserID:      arg.UserID.Value(),
+		FileName:    arg.FileName,
+		ContentType: arg.ContentType,
+		Size:        arg.Size,
+		StorageKey:  arg.StorageKey,
+		CreatedAt:   arg.CreatedAt,
+	})
+}
+
+func (r *SQLCAttachmentRepository) DeleteAttachment(ctx context.Context, id string) error {
+	return withTx(ctx, r.Querier).DeleteAttachment(ctx, id)
+}
+
+func (r *SQLCAttachmentRepository) FindBlobDeletions(ctx context.Context, limit int32) ([]string, error) {
+	return withTx(ctx, r.Querier).FindBlobDeletions(ctx, limit)
+}
+
+func (r *SQLCAttachmentRepository) DeleteBlobDeletion(ctx context.Context, storageKey string) error {
+	return withTx(ctx, r.Querier).DeleteBlobDeletion(ctx, storageKey)
+}
+
+// DBのモデルをAttachmentEntityに変換する
+func toAttachmentEntity(v db.Attachment) *entity.Attachment {
+	return &entity.Attachment{
+		ID:          value.NewID(v.ID),
+		TaskID:      value.NewID(v.TaskID),
+		UserID:      value.NewID(v.UserID),
+		FileName:    v.FileName,
+		ContentType: v.ContentType,
+		Size:        v.Size,
+		StorageKey:  v.StorageKey,
+		CreatedAt:   v.CreatedAt,
+	}
+}
@@ -0,0 +1,42 @@
+package sqlc
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/infrastructure/persistence/model/db"
+)
+
+// カレンダーのフィードの永続化のSQLC実装
+type SQLCCalendarFeedRepository struct {
+	db.Querier
+}
+
+func NewSQLCCalendarFeedRepository(qry db.Querier) *SQLCCalendarFeedRepository {
+	return &SQLCCalendarFeedRepository{qry}
+}
+
+func (r *SQLCCalendarFeedRepository) UpsertCalendarFeed(ctx context.Context, arg *entity.CalendarFeed) error {
+	return withTx(ctx, r.Querier).UpsertCalendarFeed(ctx, db.UpsertCalendarFeedParams{
+		UserID:    arg.UserID.Value(),
+		TokenHash: arg.TokenHash,
+		CreatedAt: arg.CreatedAt,
+	})
+}
+
+func (r *SQLCCalendarFeedRepository) FindCalendarFeedByTokenHash(ctx context.Context, tokenHash string) (*entity.CalendarFeed, error) {
+	res, err := withTx(ctx, r.Querier).FindCalendarFeedByTokenHash(ctx, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.CalendarFeed{
+		UserID:    value.NewID(res.UserID),
+		TokenHash: res.TokenHash,
+		CreatedAt: res.CreatedAt,
+	}, nil
+}
+
+func (r *SQLCCalendarFeedRepository) DeleteCalendarFeed(ctx context.Context, userID string) (int64, error) {
+	return withTx(ctx, r.Querier).DeleteCalendarFeed(ctx, userID)
+}
@@ -0,0 +1,127 @@
+package sqlc
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/infrastructure/persistence/model/db"
+)
+
+// 共有永続化のSQLC実装
+type SQLCCollaboratorRepository struct {
+	db.Querier
+}
+
+func NewSQLCCollaboratorRepository(qry db.Querier) *SQLCCollaboratorRepository {
+	return &SQLCCollaboratorRepository{qry}
+}
+
+func (r *SQLCCollaboratorRepository) FindTaskRole(ctx context.Context, taskID string, userID string) (value.Role, error) {
+	role, err := withTx(ctx, r.Querier).FindTaskRole(ctx, db.FindTaskRoleParams{
+		TaskID: taskID,
+		UserID: userID,
+	})
+	if err != nil {
+		return value.RoleNone, err
+	}
+	return value.Role(role), nil
+}
+
+func (r *SQLCCollaboratorRepository) FindListRole(ctx context.Context, listID string, userID string) (value.Role, error) {
+	role, err := withTx(ctx, r.Querier).FindListRole(ctx, db.FindListRoleParams{
+		ListID: &listID,
+		UserID: userID,
+	})
+	if err != nil {
+		return value.RoleNone, err
+	}
+	return value.Role(role), nil
+}
+
+func (r *SQLCCollaboratorRepository) FindCollaboratorsByTaskID(ctx context.Context, taskID string) ([]*entity.Collaborator, error) {
+	res, err := withTx(ctx, r.Querier).FindCollaboratorsByTaskID(ctx, &taskID)
+	if err != nil {
+		return nil, err
+	}
+	collaborators := make([]*entity.Collaborator, len(res))
+	for i, v := range res {
+		collaborators[i] = toCollaboratorEntity(db.Collaborator{ID: v.ID, TaskID: v.TaskID, ListID: v.ListID, UserID: v.UserID, Role: v.Role, GrantedBy: v.GrantedBy, CreatedAt: v.CreatedAt, UpdatedAt: v.UpdatedAt})
+		collaborators[i].Email = v.Email
+	}
+	return collaborators, nil
+}
+
+func (r *SQLCCollaboratorRepository) FindCollaboratorsByListID(ctx context.Context, listID string) ([]*entity.Collaborator, error) {
+	res, err := withTx(ctx, r.Querier).FindCollaboratorsByListID(ctx, &listID)
+	if err != nil {
+		return nil, err
+	}
+	collaborators := make([]*entity.Collaborator, len(res))
+	for i, v := range res {
+		collaborators[i] = toCollaboratorEntity(db.Collaborator{ID: v.ID, TaskID: v.TaskID, ListID: v.ListID, UserID: v.UserID, Role: v.Role, GrantedBy: v.GrantedBy, CreatedAt: v.CreatedAt, UpdatedAt: v.UpdatedAt})
+		collaborators[i].Email = v.Email
+	}
+	return collaborators, nil
+}
+
+func (r *SQLCCollaboratorRepository) FindTaskCollaborator(ctx context.Context, taskID string, userID string) (*entity.Collaborator, error) {
+	res, err := withTx(ctx, r.Querier).FindTaskCollaborator(ctx, db.FindTaskCollaboratorParams{
+		TaskID: &taskID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toCollaboratorEntity(res), nil
+}
+
+func (r *SQLCCollaboratorRepository) FindListCollaborator(ctx context.Context, listID string, userID string) (*entity.Collaborator, error) {
+	res, err := withTx(ctx, r.Querier).FindListCollaborator(ctx, db.FindListCollaboratorParams{
+		ListID: &listID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toCollaboratorEntity(res), nil
+}
+
+func (r *SQLCCollaboratorRepository) CreateCollaborator(ctx context.Context, arg *entity.Collaborator) (string, error) {
+	return withTx(ctx, r.Querier).CreateCollaborator(ctx, db.CreateCollaboratorParams{
+		ID:        arg.ID.Value(),
+		TaskID:    toNullableID(arg.TaskID),
+		ListID:    toNullableID(arg.ListID),
+		UserID:    arg.UserID.Value(),
+		Role:      int16(arg.Role.Value()),
+		GrantedBy: arg.GrantedBy.Value(),
+		CreatedAt: arg.CreatedAt,
+		UpdatedAt: arg.UpdatedAt,
+	})
+}
+
+func (r *SQLCCollaboratorRepository) UpdateCollaborator(ctx context.Context, arg *entity.Collaborator) error {
+	return withTx(ctx, r.Querier).UpdateCollaborator(ctx, db.UpdateCollaboratorParams{
+		ID:        arg.ID.Value(),
+		Role:      int16(arg.Role.Value()),
+		GrantedBy: arg.GrantedBy.Value(),
+		UpdatedAt: arg.UpdatedAt,
+	})
+}
+
+func (r *SQLCCollaboratorRepository) DeleteCollaborator(ctx context.Context, id string) error {
+	return withTx(ctx, r.Querier).DeleteCollaborator(ctx, id)
+}
+
+func toCollaboratorEntity(v db.Collaborator) *entity.Collaborator {
+	return &entity.Collaborator{
+		ID:        value.NewID(v.ID),
+		TaskID:    toIDValue(v.TaskID),
+		ListID:    toIDValue(v.ListID),
+		UserID:    value.NewID(v.UserID),
+		Role:      value.Role(v.Role),
+		GrantedBy: value.NewID(v.GrantedBy),
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
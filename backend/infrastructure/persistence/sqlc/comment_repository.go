@@ -0,0 +1,75 @@
+package sqlc
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/infrastructure/persistence/model/db"
+)
+
+// コメント永続化のSQLC実装
+type SQLCCommentRepository struct {
+	db.Querier
+}
+
+func NewSQLCCommentRepository(qry db.Querier) *SQLCCommentRepository {
+	return &SQLCCommentRepository{qry}
+}
+
+func (r *SQLCCommentRepository) FindCommentByID(ctx context.Context, id string) (*entity.Comment, error) {
+	res, err := withTx(ctx, r.Querier).FindCommentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toCommentEntity(res), nil
+}
+
+func (r *SQLCCommentRepository) FindCommentsByTaskID(ctx context.Context, taskID string) ([]*entity.Comment, error) {
+	res, err := withTx(ctx, r.Querier).FindCommentsByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	comments := make([]*entity.Comment, len(res))
+	for i, v := range res {
+		comments[i] = toCommentEntity(v)
+	}
+	return comments, nil
+}
+
+func (r *SQLCCommentRepository) CreateComment(ctx context.Context, arg *entity.Comment) (string, error) {
+	return withTx(ctx, r.Querier).CreateComment(ctx, db.CreateCommentParams{
+		ID:        arg.ID.Value(),
+		TaskID:    arg.TaskID.Value(),
+		UserID:    arg.UserID.Value(),
+		Body:      arg.Body,
+		CreatedAt: arg.CreatedAt,
+		UpdatedAt: arg.UpdatedAt,
+	})
+}
+
+func (r *SQLCCommentRepository) UpdateComment(ctx context.Context, arg *entity.Comment) error {
+	return withTx(ctx, r.Querier).UpdateComment(ctx, db.UpdateCommentParams{
+		ID:        arg.ID.Value(),
+		Body:      arg.Body,
+		UpdatedAt: arg.UpdatedAt,
+		EditedAt:  arg.EditedAt,
+	})
+}
+
+func (r *SQLCCommentRepository) DeleteComment(ctx context.Context, id string) error {
+	return withTx(ctx, r.Querier).DeleteComment(ctx, id)
+}
+
+// DBのモデルをCommentEntityに変換する
+func toCommentEntity(v db.Comment) *entity.Comment {
+	return &entity.Comment{
+		ID:        value.NewID(v.ID),
+		TaskID:    value.NewID(v.TaskID),
+		UserID:    value.NewID(v.UserID),
+		Body:      v.Body,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+		EditedAt:  v.EditedAt,
+	}
+}
@@ -0,0 +1,109 @@
+package sqlc
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/infrastructure/persistence/model/db"
+)
+
+// リスト永続化のSQLC実装
+type SQLCListRepository struct {
+	db.Querier
+}
+
+func NewSQLCListRepository(qry db.Querier) *SQLCListRepository {
+	return &SQLCListRepository{qry}
+}
+
+func (r *SQLCListRepository) FindListByID(ctx context.Context, id string) (*entity.List, error) {
+	res, err := withTx(ctx, r.Querier).FindListByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toListEntity(res), nil
+}
+
+func (r *SQLCListRepository) FindInboxByUserID(ctx context.Context, userID string) (*entity.List, error) {
+	res, err := withTx(ctx, r.Querier).FindInboxByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toListEntity(res), nil
+}
+
+func (r *SQLCListRepository) FindListsByUserID(ctx context.Context, userID string) ([]*entity.List, error) {
+	res, err := withTx(ctx, r.Querier).FindListsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	lists := make([]*entity.List, len(res))
+	for i, v := range res {
+		lists[i] = toListEntity(v)
+	}
+	return lists, nil
+}
+
+func (r *SQLCListRepository) FindListsByWorkspaceID(ctx context.Context, workspaceID string) ([]*entity.List, error) {
+	res, err := withTx(ctx, r.Querier).FindListsByWorkspaceID(ctx, &workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	lists := make([]*entity.List, len(res))
+	for i, v := range res {
+		lists[i] = toListEntity(v)
+	}
+	return lists, nil
+}
+
+func (r *SQLCListRepository) FindMaxListPosition(ctx context.Context, userID string) (int32, error) {
+	return withTx(ctx, r.Querier).FindMaxListPosition(ctx, userID)
+}
+
+func (r *SQLCListRepository) FindMaxWorkspaceListPosition(ctx context.Context, workspaceID string) (int32, error) {
+	return withTx(ctx, r.Querier).FindMaxWorkspaceListPosition(ctx, &workspaceID)
+}
+
+func (r *SQLCListRepository) CreateList(ctx context.Context, arg *entity.List) (string, error) {
+	return withTx(ctx, r.Querier).CreateList(ctx, db.CreateListParams{
+		ID:          arg.ID.Value(),
+		UserID:      arg.UserID.Value(),
+		Name:        arg.Name,
+		IsInbox:     arg.IsInbox,
+		IsArchived:  arg.IsArchived,
+		Position:    arg.Position,
+		CreatedAt:   arg.CreatedAt,
+		UpdatedAt:   arg.UpdatedAt,
+		WorkspaceID: toNullableID(arg.WorkspaceID),
+	})
+}
+
+func (r *SQLCListRepository) UpdateList(ctx context.Context, arg *entity.List) error {
+	return withTx(ctx, r.Querier).UpdateList(ctx, db.UpdateListParams{
+		ID:         arg.ID.Value(),
+		Name:       arg.Name,
+		IsArchived: arg.IsArchived,
+		Position:   arg.Position,
+		UpdatedAt:  arg.UpdatedAt,
+	})
+}
+
+func (r *SQLCListRepository) DeleteList(ctx context.Context, id string) error {
+	return withTx(ctx, r.Querier).DeleteList(ctx, id)
+}
+
+// DBのモデルをListEntityに変換する
+func toListEntity(v db.List) *entity.List {
+	return &entity.List{
+		ID:          value.NewID(v.ID),
+		UserID:      value.NewID(v.UserID),
+		Name:        v.Name,
+		IsInbox:     v.IsInbox,
+		IsArchived:  v.IsArchived,
+		Position:    v.Position,
+		CreatedAt:   v.CreatedAt,
+		UpdatedAt:   v.UpdatedAt,
+		WorkspaceID: toIDValue(v.WorkspaceID),
+	}
+}
@@ -0,0 +1,105 @@
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/infrastructure/persistence/model/db"
+)
+
+// タグ永続化のSQLC実装
+type SQLCTagRepository struct {
+	db.Querier
+}
+
+func NewSQLCTagRepository(qry db.Querier) *SQLCTagRepository {
+	return &SQLCTagRepository{qry}
+}
+
+func (r *SQLCTagRepository) FindTagByID(ctx context.Context, id string) (*entity.Tag, error) {
+	res, err := withTx(ctx, r.Querier).FindTagByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toTagEntity(res), nil
+}
+
+func (r *SQLCTagRepository) FindTagByUserIDAndName(ctx context.Context, userID string, name string) (*entity.Tag, error) {
+	res, err := withTx(ctx, r.Querier).FindTagByUserIDAndName(ctx, db.FindTagByUserIDAndNameParams{
+		UserID: userID,
+		Name:   name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTagEntity(res), nil
+}
+
+func (r *SQLCTagRepository) FindTagsByUserID(ctx context.Context, userID string) ([]*entity.Tag, error) {
+	res, err := withTx(ctx, r.Querier).FindTagsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]*entity.Tag, len(res))
+	for i, v := range res {
+		tags[i] = &entity.Tag{
+			ID:         value.NewID(v.ID),
+			UserID:     value.NewID(v.UserID),
+			Name:       v.Name,
+			CreatedAt:  v.CreatedAt,
+			UpdatedAt:  v.UpdatedAt,
+			UsageCount: v.UsageCount,
+		}
+	}
+	return tags, nil
+}
+
+func (r *SQLCTagRepository) CreateTag(ctx context.Context, arg *entity.Tag) (string, error) {
+	return withTx(ctx, r.Querier).CreateTag(ctx, db.CreateTagParams{
+		ID:        arg.ID.Value(),
+		UserID:    arg.UserID.Value(),
+		Name:      arg.Name,
+		CreatedAt: arg.CreatedAt,
+		UpdatedAt: arg.UpdatedAt,
+	})
+}
+
+func (r *SQLCTagRepository) UpdateTag(ctx context.Context, arg *entity.Tag) error {
+	return withTx(ctx, r.Querier).UpdateTag(ctx, db.UpdateTagParams{
+		ID:        arg.ID.Value(),
+		Name:      arg.Name,
+		UpdatedAt: arg.UpdatedAt,
+	})
+}
+
+func (r *SQLCTagRepository) DeleteTag(ctx context.Context, id string) error {
+	return withTx(ctx, r.Querier).DeleteTag(ctx, id)
+}
+
+func (r *SQLCTagRepository) AttachTag(ctx context.Context, taskID string, tagID string, now time.Time) error {
+	return withTx(ctx, r.Querier).AttachTag(ctx, db.AttachTagParams{
+		TaskID:    taskID,
+		TagID:     tagID,
+		CreatedAt: now,
+	})
+}
+
+func (r *SQLCTagRepository) DetachTag(ctx context.Context, taskID string, tagID string) error {
+	return withTx(ctx, r.Querier).DetachTag(ctx, db.DetachTagParams{
+		TaskID: taskID,
+		TagID:  tagID,
+	})
+}
+
+// DBのモデルをTagEntityに変換する
+func toTagEntity(v db.Tag) *entity.Tag {
+	return &entity.Tag{
+		ID:        value.NewID(v.ID),
+		UserID:    value.NewID(v.UserID),
+		Name:      v.Name,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
@@ -0,0 +1,58 @@
+package sqlc
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/infrastructure/persistence/model/db"
+)
+
+// タスクの履歴の永続化のSQLC実装
+type SQLCTaskHistoryRepository struct {
+	db.Querier
+}
+
+func NewSQLCTaskHistoryRepository(qry db.Querier) *SQLCTaskHistoryRepository {
+	return &SQLCTaskHistoryRepository{qry}
+}
+
+func (r *SQLCTaskHistoryRepository) FindTaskHistories(ctx context.Context, taskID string, limit int32, cursor *value.PageCursor) ([]*entity.TaskHistory, error) {
+	arg := db.FindTaskHistoriesParams{
+		TaskID:  taskID,
+		MaxRows: limit,
+	}
+	if cursor != nil {
+		arg.CursorTime = &cursor.Time
+		arg.CursorID = &cursor.ID
+	}
+	res, err := withTx(ctx, r.Querier).FindTaskHistories(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	histories := make([]*entity.TaskHistory, len(res))
+	for i, v := range res {
+		histories[i] = &entity.TaskHistory{
+			ID:        value.NewID(v.ID),
+			TaskID:    value.NewID(v.TaskID),
+			ActorID:   toIDValue(v.ActorID),
+			Action:    value.TaskHistoryAction(v.Action),
+			OldValue:  v.OldValue,
+			NewValue:  v.NewValue,
+			CreatedAt: v.CreatedAt,
+		}
+	}
+	return histories, nil
+}
+
+func (r *SQLCTaskHistoryRepository) CreateTaskHistory(ctx context.Context, arg *entity.TaskHistory) error {
+	return withTx(ctx, r.Querier).CreateTaskHistory(ctx, db.CreateTaskHistoryParams{
+		ID:        arg.ID.Value(),
+		TaskID:    arg.TaskID.Value(),
+		ActorID:   toNullableID(arg.ActorID),
+		Action:    int16(arg.Action.Value()),
+		OldValue:  arg.OldValue,
+		NewValue:  arg.NewValue,
+		CreatedAt: arg.CreatedAt,
+	})
+}
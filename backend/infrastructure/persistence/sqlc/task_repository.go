@@ -2,12 +2,17 @@ package sqlc
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
 	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
 	"github.com/7oh2020/connect-tasklist/backend/infrastructure/persistence/model/db"
 )
 
+// LIKEのパターンで特別な意味を持つ文字をエスケープする
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 // タスク永続化のSQLC実装
 type SQLCTaskRepository struct {
 	db.Querier
@@ -18,59 +23,425 @@ func NewSQLCTaskRepository(qry db.Querier) *SQLCTaskRepository {
 }
 
 func (r *SQLCTaskRepository) FindTaskByID(ctx context.Context, id string) (*entity.Task, error) {
-	res, err := r.Querier.FindTaskByID(ctx, id)
+	res, err := withTx(ctx, r.Querier).FindTaskByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	return &entity.Task{
-		ID:          value.NewID(res.ID),
-		UserID:      value.NewID(res.UserID),
-		Name:        res.Name,
-		IsCompleted: res.IsCompleted,
-		CreatedAt:   res.CreatedAt,
-		UpdatedAt:   res.UpdatedAt,
-	}, nil
+	return toTaskEntity(res), nil
 }
 
 func (r *SQLCTaskRepository) FindTasksByUserID(ctx context.Context, userID string) ([]*entity.Task, error) {
-	res, err := r.Querier.FindTasksByUserID(ctx, userID)
+	res, err := withTx(ctx, r.Querier).FindTasksByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
-	tasks := make([]*entity.Task, len(res))
-	for i, v := range res {
-		tasks[i] = &entity.Task{
-			ID:          value.NewID(v.ID),
-			UserID:      value.NewID(v.UserID),
-			Name:        v.Name,
-			IsCompleted: v.IsCompleted,
-			CreatedAt:   v.CreatedAt,
-			UpdatedAt:   v.UpdatedAt,
-		}
+	return toTaskEntities(res), nil
+}
+
+func (r *SQLCTaskRepository) FindTasksByUserIDOrderByPriority(ctx context.Context, userID string) ([]*entity.Task, error) {
+	res, err := withTx(ctx, r.Querier).FindTasksByUserIDOrderByPriority(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toTaskEntities(res), nil
+}
+
+func (r *SQLCTaskRepository) FindTasksByUserIDOrderByPosition(ctx context.Context, userID string) ([]*entity.Task, error) {
+	res, err := withTx(ctx, r.Querier).FindTasksByUserIDOrderByPosition(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toTaskEntities(res), nil
+}
+
+func (r *SQLCTaskRepository) FindTaskPage(ctx context.Context, userID string, filter *value.TaskFilter, sort value.TaskSort, limit int32, cursor *value.TaskPageCursor) ([]*entity.Task, error) {
+	q := withTx(ctx, r.Querier)
+	// 並び替えの項目と向きごとにインデックスを使用できる別々のクエリを実行する。位置の値の型が同じクエリは引数の型も同じになる
+	arg := db.FindTaskPageOrderByUpdatedAtAscParams{
+		UserID:      userID,
+		Completion:  filter.Completion.Value(),
+		CreatedFrom: filter.CreatedFrom,
+		CreatedTo:   filter.CreatedTo,
+		UpdatedFrom: filter.UpdatedFrom,
+		UpdatedTo:   filter.UpdatedTo,
+		DueFrom:     filter.DueFrom,
+		DueTo:       filter.DueTo,
+		NoDueDate:   filter.NoDueDate,
+		NamePattern: "%" + likeEscaper.Replace(filter.NameContains) + "%",
+		MaxRows:     limit,
+	}
+	if cursor != nil {
+		arg.CursorID = &cursor.ID
+		arg.CursorTime = &cursor.Time
+	}
+	nameArg := db.FindTaskPageOrderByNameAscParams{
+		UserID:      arg.UserID,
+		Completion:  arg.Completion,
+		CreatedFrom: arg.CreatedFrom,
+		CreatedTo:   arg.CreatedTo,
+		UpdatedFrom: arg.UpdatedFrom,
+		UpdatedTo:   arg.UpdatedTo,
+		DueFrom:     arg.DueFrom,
+		DueTo:       arg.DueTo,
+		NoDueDate:   arg.NoDueDate,
+		NamePattern: arg.NamePattern,
+		CursorID:    arg.CursorID,
+		MaxRows:     arg.MaxRows,
+	}
+	priorityArg := db.FindTaskPageOrderByPriorityAscParams{
+		UserID:      arg.UserID,
+		Completion:  arg.Completion,
+		CreatedFrom: arg.CreatedFrom,
+		CreatedTo:   arg.CreatedTo,
+		UpdatedFrom: arg.UpdatedFrom,
+		UpdatedTo:   arg.UpdatedTo,
+		DueFrom:     arg.DueFrom,
+		DueTo:       arg.DueTo,
+		NoDueDate:   arg.NoDueDate,
+		NamePattern: arg.NamePattern,
+		CursorID:    arg.CursorID,
+		MaxRows:     arg.MaxRows,
+	}
+	if cursor != nil {
+		nameArg.CursorName = &cursor.Name
+		p := int16(cursor.Priority)
+		priorityArg.CursorPriority = &p
+	}
+
+	switch {
+	case sort.Field == value.TaskSortFieldUpdatedAt && !sort.Descending:
+		return toTaskPage(q.FindTaskPageOrderByUpdatedAtAsc(ctx, arg))
+	case sort.Field == value.TaskSortFieldUpdatedAt:
+		return toTaskPage(q.FindTaskPageOrderByUpdatedAtDesc(ctx, db.FindTaskPageOrderByUpdatedAtDescParams(arg)))
+	case sort.Field == value.TaskSortFieldCreatedAt && !sort.Descending:
+		return toTaskPage(q.FindTaskPageOrderByCreatedAtAsc(ctx, db.FindTaskPageOrderByCreatedAtAscParams(arg)))
+	case sort.Field == value.TaskSortFieldCreatedAt:
+		return toTaskPage(q.FindTaskPageOrderByCreatedAtDesc(ctx, db.FindTaskPageOrderByCreatedAtDescParams(arg)))
+	case sort.Field == value.TaskSortFieldName && !sort.Descending:
+		return toTaskPage(q.FindTaskPageOrderByNameAsc(ctx, nameArg))
+	case sort.Field == value.TaskSortFieldName:
+		return toTaskPage(q.FindTaskPageOrderByNameDesc(ctx, db.FindTaskPageOrderByNameDescParams(nameArg)))
+	case sort.Field == value.TaskSortFieldPriority && !sort.Descending:
+		return toTaskPage(q.FindTaskPageOrderByPriorityAsc(ctx, priorityArg))
+	default:
+		return toTaskPage(q.FindTaskPageOrderByPriorityDesc(ctx, db.FindTaskPageOrderByPriorityDescParams(priorityArg)))
+	}
+}
+
+func (r *SQLCTaskRepository) FindTasksByListID(ctx context.Context, listID string, order value.TaskOrder) ([]*entity.Task, error) {
+	res, err := withTx(ctx, r.Querier).FindTasksByListID(ctx, db.FindTasksByListIDParams{
+		ListID:    listID,
+		SortOrder: order.Value(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTaskEntities(res), nil
+}
+
+func (r *SQLCTaskRepository) FindTasksByAssigneeID(ctx context.Context, assigneeID string, order value.TaskOrder) ([]*entity.Task, error) {
+	res, err := withTx(ctx, r.Querier).FindTasksByAssigneeID(ctx, db.FindTasksByAssigneeIDParams{
+		AssigneeID: &assigneeID,
+		SortOrder:  order.Value(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTaskEntities(res), nil
+}
+
+func (r *SQLCTaskRepository) FindTasksByWorkspaceID(ctx context.Context, workspaceID string, order value.TaskOrder) ([]*entity.Task, error) {
+	res, err := withTx(ctx, r.Querier).FindTasksByWorkspaceID(ctx, db.FindTasksByWorkspaceIDParams{
+		WorkspaceID: &workspaceID,
+		SortOrder:   order.Value(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTaskEntities(res), nil
+}
+
+func (r *SQLCTaskRepository) FindOverdueTasksByUserID(ctx context.Context, userID string, now time.Time) ([]*entity.Task, error) {
+	res, err := withTx(ctx, r.Querier).FindOverdueTasksByUserID(ctx, db.FindOverdueTasksByUserIDParams{
+		UserID: userID,
+		Now:    &now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTaskEntities(res), nil
+}
+
+func (r *SQLCTaskRepository) FindTasksDueBetween(ctx context.Context, userID string, from time.Time, to time.Time) ([]*entity.Task, error) {
+	res, err := withTx(ctx, r.Querier).FindTasksDueBetween(ctx, db.FindTasksDueBetweenParams{
+		UserID:  userID,
+		DueFrom: &from,
+		DueTo:   &to,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTaskEntities(res), nil
+}
+
+func (r *SQLCTaskRepository) FindTasksByUserIDAndTags(ctx context.Context, userID string, listID string, tagIDs []string, matchAll bool, order value.TaskOrder) ([]*entity.Task, error) {
+	arg := db.FindTasksByUserIDAndTagsParams{
+		UserID:    userID,
+		TagIds:    tagIDs,
+		MatchAll:  matchAll,
+		SortOrder: order.Value(),
+	}
+	if listID != "" {
+		arg.ListID = &listID
+	}
+	res, err := withTx(ctx, r.Querier).FindTasksByUserIDAndTags(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	return toTaskEntities(res), nil
+}
+
+func (r *SQLCTaskRepository) FindTaskTree(ctx context.Context, id string) ([]*entity.Task, error) {
+	res, err := withTx(ctx, r.Querier).FindTaskTree(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toTaskEntities(res), nil
+}
+
+func (r *SQLCTaskRepository) FindAncestorIDs(ctx context.Context, id string) ([]string, error) {
+	return withTx(ctx, r.Querier).FindAncestorIDs(ctx, id)
+}
+
+func (r *SQLCTaskRepository) CountOpenDescendants(ctx context.Context, id string) (int64, error) {
+	return withTx(ctx, r.Querier).CountOpenDescendants(ctx, &id)
+}
+
+func (r *SQLCTaskRepository) FindBlockerIDs(ctx context.Context, id string) ([]string, error) {
+	return withTx(ctx, r.Querier).FindBlockerIDs(ctx, id)
+}
+
+func (r *SQLCTaskRepository) CountOpenBlockers(ctx context.Context, id string) (int64, error) {
+	return withTx(ctx, r.Querier).CountOpenBlockers(ctx, id)
+}
+
+func (r *SQLCTaskRepository) FindBlockedTaskIDs(ctx context.Context, ids []string) ([]string, error) {
+	return withTx(ctx, r.Querier).FindBlockedTaskIDs(ctx, ids)
+}
+
+func (r *SQLCTaskRepository) FindMinTaskPosition(ctx context.Context, listID string) (value.Rank, error) {
+	res, err := withTx(ctx, r.Querier).FindMinTaskPosition(ctx, listID)
+	if err != nil {
+		return "", err
+	}
+	return value.Rank(res), nil
+}
+
+func (r *SQLCTaskRepository) FindPrevTaskPosition(ctx context.Context, listID string, id string, position value.Rank) (value.Rank, error) {
+	res, err := withTx(ctx, r.Querier).FindPrevTaskPosition(ctx, db.FindPrevTaskPositionParams{
+		ListID:   listID,
+		Position: position.Value(),
+		ID:       id,
+	})
+	if err != nil {
+		return "", err
 	}
-	return tasks, nil
+	return value.Rank(res), nil
+}
+
+func (r *SQLCTaskRepository) FindNextTaskPosition(ctx context.Context, listID string, id string, position value.Rank) (value.Rank, error) {
+	res, err := withTx(ctx, r.Querier).FindNextTaskPosition(ctx, db.FindNextTaskPositionParams{
+		ListID:   listID,
+		Position: position.Value(),
+		ID:       id,
+	})
+	if err != nil {
+		return "", err
+	}
+	return value.Rank(res), nil
+}
+
+func (r *SQLCTaskRepository) FindListIDsToRebalance(ctx context.Context, maxLength int32) ([]string, error) {
+	return withTx(ctx, r.Querier).FindListIDsToRebalance(ctx, maxLength)
+}
+
+func (r *SQLCTaskRepository) FindTaskIDsByListIDOrderByPosition(ctx context.Context, listID string) ([]string, error) {
+	return withTx(ctx, r.Querier).FindTaskIDsByListIDOrderByPosition(ctx, listID)
 }
 
 func (r *SQLCTaskRepository) CreateTask(ctx context.Context, arg *entity.Task) (string, error) {
-	return r.Querier.CreateTask(ctx, db.CreateTaskParams{
-		ID:          arg.ID.Value(),
-		UserID:      arg.UserID.Value(),
-		Name:        arg.Name,
-		IsCompleted: arg.IsCompleted,
-		CreatedAt:   arg.CreatedAt,
-		UpdatedAt:   arg.UpdatedAt,
+	return withTx(ctx, r.Querier).CreateTask(ctx, db.CreateTaskParams{
+		ID:                 arg.ID.Value(),
+		UserID:             arg.UserID.Value(),
+		Name:               arg.Name,
+		Status:             int16(arg.Status.Value()),
+		CreatedAt:          arg.CreatedAt,
+		UpdatedAt:          arg.UpdatedAt,
+		DueAt:              arg.DueAt,
+		Priority:           int16(arg.Priority.Value()),
+		Description:        arg.Description,
+		DescriptionHtml:    arg.DescriptionHTML,
+		ParentID:           toNullableID(arg.ParentID),
+		ListID:             arg.ListID.Value(),
+		Position:           arg.Position.Value(),
+		RecurrenceRule:     arg.RecurrenceRule.Value(),
+		RecurrenceTimezone: arg.RecurrenceTimezone,
+		AssigneeID:         toNullableID(arg.AssigneeID),
 	})
 }
 
 func (r *SQLCTaskRepository) UpdateTask(ctx context.Context, arg *entity.Task) error {
-	return r.Querier.UpdateTask(ctx, db.UpdateTaskParams{
-		ID:          arg.ID.Value(),
-		Name:        arg.Name,
-		IsCompleted: arg.IsCompleted,
-		UpdatedAt:   arg.UpdatedAt,
+	return withTx(ctx, r.Querier).UpdateTask(ctx, db.UpdateTaskParams{
+		ID:                 arg.ID.Value(),
+		Name:               arg.Name,
+		Status:             int16(arg.Status.Value()),
+		UpdatedAt:          arg.UpdatedAt,
+		DueAt:              arg.DueAt,
+		Priority:           int16(arg.Priority.Value()),
+		Description:        arg.Description,
+		DescriptionHtml:    arg.DescriptionHTML,
+		ParentID:           toNullableID(arg.ParentID),
+		ListID:             arg.ListID.Value(),
+		RecurrenceRule:     arg.RecurrenceRule.Value(),
+		RecurrenceTimezone: arg.RecurrenceTimezone,
+		AssigneeID:         toNullableID(arg.AssigneeID),
+	})
+}
+
+func (r *SQLCTaskRepository) UpdateTaskTreeListID(ctx context.Context, id string, listID string, now time.Time) error {
+	return withTx(ctx, r.Querier).UpdateTaskTreeListID(ctx, db.UpdateTaskTreeListIDParams{
+		ID:        id,
+		ListID:    listID,
+		UpdatedAt: now,
+	})
+}
+
+func (r *SQLCTaskRepository) UpdateTaskPosition(ctx context.Context, id string, position value.Rank) error {
+	return withTx(ctx, r.Querier).UpdateTaskPosition(ctx, db.UpdateTaskPositionParams{
+		ID:       id,
+		Position: position.Value(),
+	})
+}
+
+func (r *SQLCTaskRepository) AddTaskDependency(ctx context.Context, taskID string, blockerID string, now time.Time) (int64, error) {
+	return withTx(ctx, r.Querier).AddTaskDependency(ctx, db.AddTaskDependencyParams{
+		TaskID:    taskID,
+		BlockerID: blockerID,
+		CreatedAt: now,
+	})
+}
+
+func (r *SQLCTaskRepository) RemoveTaskDependency(ctx context.Context, taskID string, blockerID string) (int64, error) {
+	return withTx(ctx, r.Querier).RemoveTaskDependency(ctx, db.RemoveTaskDependencyParams{
+		TaskID:    taskID,
+		BlockerID: blockerID,
+	})
+}
+
+func (r *SQLCTaskRepository) DeleteTask(ctx context.Context, id string, now time.Time) error {
+	return withTx(ctx, r.Querier).DeleteTask(ctx, db.DeleteTaskParams{
+		ID:        id,
+		DeletedAt: &now,
+	})
+}
+
+func (r *SQLCTaskRepository) FindTrashedTaskByID(ctx context.Context, id string) (*entity.Task, error) {
+	res, err := withTx(ctx, r.Querier).FindTrashedTaskByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toTaskEntity(res), nil
+}
+
+func (r *SQLCTaskRepository) FindTrashedTasksByUserID(ctx context.Context, userID string) ([]*entity.Task, error) {
+	res, err := withTx(ctx, r.Querier).FindTrashedTasksByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toTaskEntities(res), nil
+}
+
+func (r *SQLCTaskRepository) RestoreTask(ctx context.Context, id string, deletedAt time.Time) error {
+	return withTx(ctx, r.Querier).RestoreTask(ctx, db.RestoreTaskParams{
+		ID:        id,
+		DeletedAt: &deletedAt,
 	})
 }
 
-func (r *SQLCTaskRepository) DeleteTask(ctx context.Context, id string) error {
-	return r.Querier.DeleteTask(ctx, id)
+func (r *SQLCTaskRepository) PurgeTrashedTasksByUserID(ctx context.Context, userID string) error {
+	return withTx(ctx, r.Querier).PurgeTrashedTasksByUserID(ctx, userID)
+}
+
+func (r *SQLCTaskRepository) PurgeTasksDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	return withTx(ctx, r.Querier).PurgeTasksDeletedBefore(ctx, &before)
+}
+
+// タスクを取得するクエリの行の型
+// 全文検索用の列を除いて取得するため、sqlcはクエリごとに同じ構造の型を生成する
+type taskRow interface {
+	db.FindTaskByIDRow | db.FindTasksByUserIDRow | db.FindTasksByUserIDOrderByPriorityRow |
+		db.FindTasksByUserIDOrderByPositionRow | db.FindTasksByListIDRow | db.FindTasksByAssigneeIDRow |
+		db.FindTasksByWorkspaceIDRow | db.FindOverdueTasksByUserIDRow | db.FindTasksDueBetweenRow |
+		db.FindTasksByUserIDAndTagsRow | db.FindTaskTreeRow | db.FindTrashedTaskByIDRow |
+		db.FindTrashedTasksByUserIDRow | db.FindTaskPageOrderByUpdatedAtAscRow | db.FindTaskPageOrderByUpdatedAtDescRow |
+		db.FindTaskPageOrderByCreatedAtAscRow | db.FindTaskPageOrderByCreatedAtDescRow | db.FindTaskPageOrderByNameAscRow |
+		db.FindTaskPageOrderByNameDescRow | db.FindTaskPageOrderByPriorityAscRow | db.FindTaskPageOrderByPriorityDescRow
+}
+
+// DBのモデルをTaskEntityに変換する
+func toTaskEntity[T taskRow](row T) *entity.Task {
+	v := db.FindTaskByIDRow(row)
+	return &entity.Task{
+		ID:                 value.NewID(v.ID),
+		UserID:             value.NewID(v.UserID),
+		Name:               v.Name,
+		Status:             value.TaskStatus(v.Status),
+		CreatedAt:          v.CreatedAt,
+		UpdatedAt:          v.UpdatedAt,
+		DueAt:              v.DueAt,
+		Priority:           value.Priority(v.Priority),
+		Description:        v.Description,
+		DescriptionHTML:    v.DescriptionHtml,
+		ParentID:           toIDValue(v.ParentID),
+		ListID:             value.NewID(v.ListID),
+		Position:           value.Rank(v.Position),
+		RecurrenceRule:     value.RecurrenceRule(v.RecurrenceRule),
+		RecurrenceTimezone: v.RecurrenceTimezone,
+		DeletedAt:          v.DeletedAt,
+		AssigneeID:         toIDValue(v.AssigneeID),
+	}
+}
+
+// DBのモデルのスライスをTaskEntityのスライスに変換する
+func toTaskEntities[T taskRow](res []T) []*entity.Task {
+	tasks := make([]*entity.Task, len(res))
+	for i, v := range res {
+		tasks[i] = toTaskEntity(v)
+	}
+	return tasks
+}
+
+// クエリの結果をTaskEntityのスライスに変換する
+func toTaskPage[T taskRow](res []T, err error) ([]*entity.Task, error) {
+	if err != nil {
+		return nil, err
+	}
+	return toTaskEntities(res), nil
+}
+
+// NULL許容のIDを値オブジェクトに変換する
+func toIDValue(id *string) *value.ID {
+	if id == nil {
+		return nil
+	}
+	return value.NewID(*id)
+}
+
+// 値オブジェクトをNULL許容のIDに変換する
+func toNullableID(id *value.ID) *string {
+	if id == nil {
+		return nil
+	}
+	v := id.Value()
+	return &v
 }
@@ -0,0 +1,119 @@
+package sqlc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/infrastructure/persistence/model/db"
+)
+
+// ts_headlineのオプション。名前は全体を、説明は一致した部分の周辺を抜粋して返す
+const (
+	nameHeadlineOptions        = `HighlightAll=true, StartSel="` + entity.HighlightStart + `", StopSel="` + entity.HighlightStop + `"`
+	descriptionHeadlineOptions = `MaxFragments=2, MaxWords=20, MinWords=5, StartSel="` + entity.HighlightStart + `", StopSel="` + entity.HighlightStop + `"`
+)
+
+// タスクの全文検索のSQLC実装
+// configはPostgreSQLのテキスト検索設定で、tasks.search_configの既定値と一致させる
+type SQLCTaskSearchRepository struct {
+	db.Querier
+	config string
+}
+
+func NewSQLCTaskSearchRepository(qry db.Querier, config string) *SQLCTaskSearchRepository {
+	return &SQLCTaskSearchRepository{qry, config}
+}
+
+// configがtasks.search_configの既定値と一致するか検証する
+// 一致しない場合は検索用の列と異なる設定で検索され、エラーにならずに一致や順位が崩れるため起動時に確認する
+func (r *SQLCTaskSearchRepository) VerifyConfig(ctx context.Context) error {
+	matched, err := r.Querier.IsDefaultSearchConfig(ctx, r.config)
+	if err != nil {
+		return fmt.Errorf("invalid search-config: %s", r.config)
+	}
+	if !matched {
+		return fmt.Errorf("search-config does not match the default of tasks.search_config: %s", r.config)
+	}
+	return nil
+}
+
+func (r *SQLCTaskSearchRepository) SearchTasksByUserID(ctx context.Context, userID string, query string, limit int32, cursor *value.SearchCursor) ([]*entity.TaskSearchResult, error) {
+	arg := db.SearchTasksByUserIDParams{
+		Config:             r.config,
+		Query:              query,
+		UserID:             userID,
+		NameOptions:        nameHeadlineOptions,
+		DescriptionOptions: descriptionHeadlineOptions,
+		MaxRows:            limit,
+	}
+	if cursor != nil {
+		arg.CursorRank = &cursor.Rank
+		arg.CursorID = &cursor.ID
+	}
+	res, err := withTx(ctx, r.Querier).SearchTasksByUserID(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	return toTaskSearchResults(res), nil
+}
+
+func (r *SQLCTaskSearchRepository) SearchTasksByWorkspaceID(ctx context.Context, workspaceID string, query string, limit int32, cursor *value.SearchCursor) ([]*entity.TaskSearchResult, error) {
+	arg := db.SearchTasksByWorkspaceIDParams{
+		Config:             r.config,
+		Query:              query,
+		WorkspaceID:        &workspaceID,
+		NameOptions:        nameHeadlineOptions,
+		DescriptionOptions: descriptionHeadlineOptions,
+		MaxRows:            limit,
+	}
+	if cursor != nil {
+		arg.CursorRank = &cursor.Rank
+		arg.CursorID = &cursor.ID
+	}
+	res, err := withTx(ctx, r.Querier).SearchTasksByWorkspaceID(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	return toTaskSearchResults(res), nil
+}
+
+// 全文検索のクエリの行の型。同じ列を取得するため構造は同じになる
+type taskSearchRow interface {
+	db.SearchTasksByUserIDRow | db.SearchTasksByWorkspaceIDRow
+}
+
+// 検索結果のDBのモデルをTaskSearchResultのスライスに変換する
+func toTaskSearchResults[T taskSearchRow](res []T) []*entity.TaskSearchResult {
+	results := make([]*entity.TaskSearchResult, len(res))
+	for i, row := range res {
+		v := db.SearchTasksByUserIDRow(row)
+		task := db.FindTaskByIDRow{
+			ID:                 v.ID,
+			UserID:             v.UserID,
+			Name:               v.Name,
+			CreatedAt:          v.CreatedAt,
+			UpdatedAt:          v.UpdatedAt,
+			DueAt:              v.DueAt,
+			Priority:           v.Priority,
+			Description:        v.Description,
+			DescriptionHtml:    v.DescriptionHtml,
+			ParentID:           v.ParentID,
+			ListID:             v.ListID,
+			Position:           v.Position,
+			RecurrenceRule:     v.RecurrenceRule,
+			RecurrenceTimezone: v.RecurrenceTimezone,
+			Status:             v.Status,
+			DeletedAt:          v.DeletedAt,
+			AssigneeID:         v.AssigneeID,
+		}
+		results[i] = &entity.TaskSearchResult{
+			Task:                toTaskEntity(task),
+			Rank:                v.Rank,
+			NameHeadline:        v.NameHeadline,
+			DescriptionHeadline: v.DescriptionHeadline,
+		}
+	}
+	return results
+}
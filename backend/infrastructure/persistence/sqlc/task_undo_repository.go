@@ -0,0 +1,71 @@
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/infrastructure/persistence/model/db"
+)
+
+// タスクの取り消しとやり直しのスタックの永続化のSQLC実装
+type SQLCTaskUndoRepository struct {
+	db.Querier
+}
+
+func NewSQLCTaskUndoRepository(qry db.Querier) *SQLCTaskUndoRepository {
+	return &SQLCTaskUndoRepository{qry}
+}
+
+func (r *SQLCTaskUndoRepository) CreateTaskUndoEntry(ctx context.Context, arg *entity.TaskUndoEntry) error {
+	return withTx(ctx, r.Querier).CreateTaskUndoEntry(ctx, db.CreateTaskUndoEntryParams{
+		ID:         arg.ID.Value(),
+		UserID:     arg.UserID.Value(),
+		Stack:      int16(arg.Stack.Value()),
+		TaskID:     arg.TaskID.Value(),
+		Action:     int16(arg.Action.Value()),
+		OldValue:   arg.OldValue,
+		NewValue:   arg.NewValue,
+		NextTaskID: toNullableID(arg.NextTaskID),
+		CreatedAt:  arg.CreatedAt,
+	})
+}
+
+func (r *SQLCTaskUndoRepository) PopTaskUndoEntry(ctx context.Context, userID string, stack value.UndoStack, expiredBefore time.Time) (*entity.TaskUndoEntry, error) {
+	res, err := withTx(ctx, r.Querier).PopTaskUndoEntry(ctx, db.PopTaskUndoEntryParams{
+		UserID:        userID,
+		Stack:         int16(stack.Value()),
+		ExpiredBefore: expiredBefore,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entity.TaskUndoEntry{
+		ID:         value.NewID(res.ID),
+		UserID:     value.NewID(res.UserID),
+		Stack:      value.UndoStack(res.Stack),
+		TaskID:     value.NewID(res.TaskID),
+		Action:     value.TaskHistoryAction(res.Action),
+		OldValue:   res.OldValue,
+		NewValue:   res.NewValue,
+		NextTaskID: toIDValue(res.NextTaskID),
+		CreatedAt:  res.CreatedAt,
+	}, nil
+}
+
+func (r *SQLCTaskUndoRepository) DeleteTaskUndoEntries(ctx context.Context, userID string, stack value.UndoStack) error {
+	return withTx(ctx, r.Querier).DeleteTaskUndoEntries(ctx, db.DeleteTaskUndoEntriesParams{
+		UserID: userID,
+		Stack:  int16(stack.Value()),
+	})
+}
+
+func (r *SQLCTaskUndoRepository) TrimTaskUndoEntries(ctx context.Context, userID string, stack value.UndoStack, maxEntries int32, expiredBefore time.Time) error {
+	return withTx(ctx, r.Querier).TrimTaskUndoEntries(ctx, db.TrimTaskUndoEntriesParams{
+		UserID:        userID,
+		Stack:         int16(stack.Value()),
+		ExpiredBefore: expiredBefore,
+		MaxEntries:    maxEntries,
+	})
+}
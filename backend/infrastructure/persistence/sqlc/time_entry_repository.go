@@ -0,0 +1,102 @@
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/infrastructure/persistence/model/db"
+)
+
+// 作業時間永続化のSQLC実装
+type SQLCTimeEntryRepository struct {
+	db.Querier
+}
+
+func NewSQLCTimeEntryRepository(qry db.Querier) *SQLCTimeEntryRepository {
+	return &SQLCTimeEntryRepository{qry}
+}
+
+func (r *SQLCTimeEntryRepository) FindTimeEntryByID(ctx context.Context, id string) (*entity.TimeEntry, error) {
+	res, err := withTx(ctx, r.Querier).FindTimeEntryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toTimeEntryEntity(res), nil
+}
+
+func (r *SQLCTimeEntryRepository) FindRunningTimeEntry(ctx context.Context, userID string) (*entity.TimeEntry, error) {
+	res, err := withTx(ctx, r.Querier).FindRunningTimeEntryByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toTimeEntryEntity(res), nil
+}
+
+func (r *SQLCTimeEntryRepository) FindTimeEntriesBetween(ctx context.Context, userID string, from time.Time, to time.Time) ([]*entity.TimeEntry, error) {
+	res, err := withTx(ctx, r.Querier).FindTimeEntriesByUserIDBetween(ctx, db.FindTimeEntriesByUserIDBetweenParams{
+		UserID:    userID,
+		RangeFrom: from,
+		RangeTo:   to,
+	})
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*entity.TimeEntry, len(res))
+	for i, v := range res {
+		entries[i] = toTimeEntryEntity(db.TimeEntry{
+			ID:        v.ID,
+			UserID:    v.UserID,
+			TaskID:    v.TaskID,
+			StartedAt: v.StartedAt,
+			EndedAt:   v.EndedAt,
+			Note:      v.Note,
+			CreatedAt: v.CreatedAt,
+			UpdatedAt: v.UpdatedAt,
+		})
+		entries[i].TaskName = v.TaskName
+	}
+	return entries, nil
+}
+
+func (r *SQLCTimeEntryRepository) CreateTimeEntry(ctx context.Context, arg *entity.TimeEntry) (string, error) {
+	return withTx(ctx, r.Querier).CreateTimeEntry(ctx, db.CreateTimeEntryParams{
+		ID:        arg.ID.Value(),
+		UserID:    arg.UserID.Value(),
+		TaskID:    arg.TaskID.Value(),
+		StartedAt: arg.StartedAt,
+		EndedAt:   arg.EndedAt,
+		Note:      arg.Note,
+		CreatedAt: arg.CreatedAt,
+		UpdatedAt: arg.UpdatedAt,
+	})
+}
+
+func (r *SQLCTimeEntryRepository) UpdateTimeEntry(ctx context.Context, arg *entity.TimeEntry) error {
+	return withTx(ctx, r.Querier).UpdateTimeEntry(ctx, db.UpdateTimeEntryParams{
+		ID:        arg.ID.Value(),
+		StartedAt: arg.StartedAt,
+		EndedAt:   arg.EndedAt,
+		Note:      arg.Note,
+		UpdatedAt: arg.UpdatedAt,
+	})
+}
+
+func (r *SQLCTimeEntryRepository) DeleteTimeEntry(ctx context.Context, id string) error {
+	return withTx(ctx, r.Querier).DeleteTimeEntry(ctx, id)
+}
+
+// DBのモデルをTimeEntryEntityに変換する
+func toTimeEntryEntity(v db.TimeEntry) *entity.TimeEntry {
+	return &entity.TimeEntry{
+		ID:        value.NewID(v.ID),
+		UserID:    value.NewID(v.UserID),
+		TaskID:    value.NewID(v.TaskID),
+		StartedAt: v.StartedAt,
+		EndedAt:   v.EndedAt,
+		Note:      v.Note,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
@@ -0,0 +1,77 @@
+package sqlc
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/infrastructure/persistence/model/db"
+	"github.com/jackc/pgx/v4"
+)
+
+// トランザクションを開始できるDBの接続。pgxpool.Poolが実装する
+type TxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// トランザクション中のクエリをctxに保持するためのキー
+type txQuerierKey struct{}
+
+// トランザクション中のセーブポイントを作成するためにpgx.Txをctxに保持するためのキー
+type txKey struct{}
+
+// トランザクション管理のSQLC実装
+type SQLCTransactionManager struct {
+	conn TxBeginner
+}
+
+func NewSQLCTransactionManager(conn TxBeginner) *SQLCTransactionManager {
+	return &SQLCTransactionManager{conn}
+}
+
+func (m *SQLCTransactionManager) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txQuerierKey{}).(db.Querier); ok {
+		return fn(ctx)
+	}
+	tx, err := m.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	// fnがpanicした場合もロールバックする。コミット済みの場合は何もしない
+	defer tx.Rollback(ctx)
+
+	if err := fn(withTxContext(ctx, tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (m *SQLCTransactionManager) RunInSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, ok := ctx.Value(txKey{}).(pgx.Tx)
+	if !ok {
+		return m.RunInTx(ctx, fn)
+	}
+	// トランザクション中のBeginはセーブポイントを作成し、Commitはセーブポイントを解放する
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer sp.Rollback(ctx)
+
+	if err := fn(withTxContext(ctx, sp)); err != nil {
+		return err
+	}
+	return sp.Commit(ctx)
+}
+
+// txをトランザクションとして保持したctxを返す
+func withTxContext(ctx context.Context, tx pgx.Tx) context.Context {
+	ctx = context.WithValue(ctx, txKey{}, tx)
+	return context.WithValue(ctx, txQuerierKey{}, db.New(tx))
+}
+
+// ctxがトランザクション中の場合はトランザクションのクエリを返す。それ以外の場合はqryを返す
+func withTx(ctx context.Context, qry db.Querier) db.Querier {
+	if tx, ok := ctx.Value(txQuerierKey{}).(db.Querier); ok {
+		return tx
+	}
+	return qry
+}
@@ -0,0 +1,76 @@
+package sqlc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/repository"
+	"github.com/7oh2020/connect-tasklist/backend/infrastructure/persistence/model/db"
+	"github.com/7oh2020/connect-tasklist/backend/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionManager_NewTransactionManager(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ repository.ITransactionManager = (*SQLCTransactionManager)(nil)
+	})
+}
+
+func TestTransactionManager_RunInTx(tt *testing.T) {
+	tt.Run("正常系: トランザクション中の場合は同じトランザクションで実行すること", func(t *testing.T) {
+		conn := new(mocks.TxBeginner)
+		txQry := db.New(nil)
+		ctx := context.WithValue(context.Background(), txQuerierKey{}, db.Querier(txQry))
+		m := NewSQLCTransactionManager(conn)
+		err := m.RunInTx(ctx, func(ctx context.Context) error {
+			require.Same(t, txQry, withTx(ctx, nil), "トランザクションのクエリが使用されること")
+			return nil
+		})
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		conn.AssertExpectations(t)
+	})
+	tt.Run("準正常系: トランザクションの開始に失敗した場合", func(t *testing.T) {
+		ctx := context.Background()
+		errExp := errors.New("failed to begin")
+		conn := new(mocks.TxBeginner)
+		conn.On("Begin", ctx).Return(nil, errExp)
+		m := NewSQLCTransactionManager(conn)
+		called := false
+		err := m.RunInTx(ctx, func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		require.False(t, called, "fnが実行されないこと")
+		conn.AssertExpectations(t)
+	})
+}
+
+func TestTransactionManager_RunInSavepoint(tt *testing.T) {
+	tt.Run("準正常系: トランザクション外の場合はトランザクションを開始すること", func(t *testing.T) {
+		ctx := context.Background()
+		errExp := errors.New("failed to begin")
+		conn := new(mocks.TxBeginner)
+		conn.On("Begin", ctx).Return(nil, errExp)
+		m := NewSQLCTransactionManager(conn)
+		called := false
+		err := m.RunInSavepoint(ctx, func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+
+		require.EqualError(t, err, errExp.Error(), "エラーが一致すること")
+		require.False(t, called, "fnが実行されないこと")
+		conn.AssertExpectations(t)
+	})
+}
+
+func TestTransactionManager_withTx(tt *testing.T) {
+	tt.Run("正常系: トランザクション外の場合は元のクエリを返すこと", func(t *testing.T) {
+		qry := db.New(nil)
+		require.Same(t, qry, withTx(context.Background(), qry), "元のクエリが使用されること")
+	})
+}
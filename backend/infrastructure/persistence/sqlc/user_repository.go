@@ -18,7 +18,7 @@ func NewSQLCUserRepository(qry db.Querier) *SQLCUserRepository {
 }
 
 func (r *SQLCUserRepository) FindUserByID(ctx context.Context, id string) (*entity.User, error) {
-	res, err := r.Querier.FindUserByID(ctx, id)
+	res, err := withTx(ctx, r.Querier).FindUserByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -32,7 +32,7 @@ func (r *SQLCUserRepository) FindUserByID(ctx context.Context, id string) (*enti
 }
 
 func (r *SQLCUserRepository) FindUserByEmail(ctx context.Context, email string) (*entity.User, error) {
-	res, err := r.Querier.FindUserByEmail(ctx, email)
+	res, err := withTx(ctx, r.Querier).FindUserByEmail(ctx, email)
 	if err != nil {
 		return nil, err
 	}
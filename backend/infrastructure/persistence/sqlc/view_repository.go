@@ -0,0 +1,104 @@
+package sqlc
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/infrastructure/persistence/model/db"
+)
+
+// ビュー永続化のSQLC実装
+type SQLCViewRepository struct {
+	db.Querier
+}
+
+func NewSQLCViewRepository(qry db.Querier) *SQLCViewRepository {
+	return &SQLCViewRepository{qry}
+}
+
+func (r *SQLCViewRepository) FindViewByID(ctx context.Context, id string) (*entity.View, error) {
+	res, err := withTx(ctx, r.Querier).FindViewByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toViewEntity(res), nil
+}
+
+func (r *SQLCViewRepository) FindViewByUserIDAndName(ctx context.Context, userID string, name string) (*entity.View, error) {
+	res, err := withTx(ctx, r.Querier).FindViewByUserIDAndName(ctx, db.FindViewByUserIDAndNameParams{
+		UserID: userID,
+		Name:   name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toViewEntity(res), nil
+}
+
+func (r *SQLCViewRepository) FindViewsByUserID(ctx context.Context, userID string) ([]*entity.View, error) {
+	res, err := withTx(ctx, r.Querier).FindViewsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	views := make([]*entity.View, len(res))
+	for i, v := range res {
+		views[i] = toViewEntity(v)
+	}
+	return views, nil
+}
+
+func (r *SQLCViewRepository) CreateView(ctx context.Context, arg *entity.View) (string, error) {
+	return withTx(ctx, r.Querier).CreateView(ctx, db.CreateViewParams{
+		ID:             arg.ID.Value(),
+		UserID:         arg.UserID.Value(),
+		Name:           arg.Name,
+		Completion:     int16(arg.Query.Completion),
+		DuePeriod:      int16(arg.Query.Due),
+		UpdatedPeriod:  int16(arg.Query.Updated),
+		NameContains:   arg.Query.NameContains,
+		SortField:      int16(arg.Query.Sort.Field),
+		SortDescending: arg.Query.Sort.Descending,
+		GroupBy:        int16(arg.Query.GroupBy),
+		CreatedAt:      arg.CreatedAt,
+		UpdatedAt:      arg.UpdatedAt,
+	})
+}
+
+func (r *SQLCViewRepository) UpdateView(ctx context.Context, arg *entity.View) error {
+	return withTx(ctx, r.Querier).UpdateView(ctx, db.UpdateViewParams{
+		ID:             arg.ID.Value(),
+		Name:           arg.Name,
+		Completion:     int16(arg.Query.Completion),
+		DuePeriod:      int16(arg.Query.Due),
+		UpdatedPeriod:  int16(arg.Query.Updated),
+		NameContains:   arg.Query.NameContains,
+		SortField:      int16(arg.Query.Sort.Field),
+		SortDescending: arg.Query.Sort.Descending,
+		GroupBy:        int16(arg.Query.GroupBy),
+		UpdatedAt:      arg.UpdatedAt,
+	})
+}
+
+func (r *SQLCViewRepository) DeleteView(ctx context.Context, id string) error {
+	return withTx(ctx, r.Querier).DeleteView(ctx, id)
+}
+
+// DBのモデルをViewEntityに変換する
+func toViewEntity(v db.View) *entity.View {
+	return &entity.View{
+		ID:     value.NewID(v.ID),
+		UserID: value.NewID(v.UserID),
+		Name:   v.Name,
+		Query: value.ViewQuery{
+			Completion:   value.TaskCompletion(v.Completion),
+			Due:          value.ViewPeriod(v.DuePeriod),
+			Updated:      value.ViewPeriod(v.UpdatedPeriod),
+			NameContains: v.NameContains,
+			Sort:         value.TaskSort{Field: value.TaskSortField(v.SortField), Descending: v.SortDescending},
+			GroupBy:      value.ViewGroupBy(v.GroupBy),
+		},
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
@@ -0,0 +1,215 @@
+package sqlc
+
+import (
+	"context"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/entity"
+	"github.com/7oh2020/connect-tasklist/backend/domain/object/value"
+	"github.com/7oh2020/connect-tasklist/backend/infrastructure/persistence/model/db"
+)
+
+// ワークスペース永続化のSQLC実装
+type SQLCWorkspaceRepository struct {
+	db.Querier
+}
+
+func NewSQLCWorkspaceRepository(qry db.Querier) *SQLCWorkspaceRepository {
+	return &SQLCWorkspaceRepository{qry}
+}
+
+func (r *SQLCWorkspaceRepository) FindWorkspaceByID(ctx context.Context, id string) (*entity.Workspace, error) {
+	res, err := withTx(ctx, r.Querier).FindWorkspaceByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toWorkspaceEntity(res), nil
+}
+
+func (r *SQLCWorkspaceRepository) FindWorkspacesByUserID(ctx context.Context, userID string) ([]*entity.Workspace, error) {
+	res, err := withTx(ctx, r.Querier).FindWorkspacesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	workspaces := make([]*entity.Workspace, len(res))
+	for i, v := range res {
+		workspaces[i] = toWorkspaceEntity(db.Workspace{ID: v.ID, Name: v.Name, CreatedAt: v.CreatedAt, UpdatedAt: v.UpdatedAt})
+		workspaces[i].Role = value.WorkspaceRole(v.Role)
+	}
+	return workspaces, nil
+}
+
+func (r *SQLCWorkspaceRepository) CreateWorkspace(ctx context.Context, arg *entity.Workspace) (string, error) {
+	return withTx(ctx, r.Querier).CreateWorkspace(ctx, db.CreateWorkspaceParams{
+		ID:        arg.ID.Value(),
+		Name:      arg.Name,
+		CreatedAt: arg.CreatedAt,
+		UpdatedAt: arg.UpdatedAt,
+	})
+}
+
+func (r *SQLCWorkspaceRepository) DeleteWorkspace(ctx context.Context, id string) error {
+	return withTx(ctx, r.Querier).DeleteWorkspace(ctx, id)
+}
+
+func (r *SQLCWorkspaceRepository) FindWorkspaceMember(ctx context.Context, workspaceID string, userID string) (*entity.WorkspaceMember, error) {
+	res, err := withTx(ctx, r.Querier).FindWorkspaceMember(ctx, db.FindWorkspaceMemberParams{
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toWorkspaceMemberEntity(res), nil
+}
+
+func (r *SQLCWorkspaceRepository) FindWorkspaceMembers(ctx context.Context, workspaceID string) ([]*entity.WorkspaceMember, error) {
+	res, err := withTx(ctx, r.Querier).FindWorkspaceMembers(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]*entity.WorkspaceMember, len(res))
+	for i, v := range res {
+		members[i] = toWorkspaceMemberEntity(db.WorkspaceMember{WorkspaceID: v.WorkspaceID, UserID: v.UserID, Role: v.Role, CreatedAt: v.CreatedAt, UpdatedAt: v.UpdatedAt})
+		members[i].Email = v.Email
+	}
+	return members, nil
+}
+
+func (r *SQLCWorkspaceRepository) CreateWorkspaceMember(ctx context.Context, arg *entity.WorkspaceMember) error {
+	return withTx(ctx, r.Querier).CreateWorkspaceMember(ctx, db.CreateWorkspaceMemberParams{
+		WorkspaceID: arg.WorkspaceID.Value(),
+		UserID:      arg.UserID.Value(),
+		Role:        int16(arg.Role.Value()),
+		CreatedAt:   arg.CreatedAt,
+		UpdatedAt:   arg.UpdatedAt,
+	})
+}
+
+func (r *SQLCWorkspaceRepository) UpdateWorkspaceMember(ctx context.Context, arg *entity.WorkspaceMember) error {
+	return withTx(ctx, r.Querier).UpdateWorkspaceMember(ctx, db.UpdateWorkspaceMemberParams{
+		WorkspaceID: arg.WorkspaceID.Value(),
+		UserID:      arg.UserID.Value(),
+		Role:        int16(arg.Role.Value()),
+		UpdatedAt:   arg.UpdatedAt,
+	})
+}
+
+func (r *SQLCWorkspaceRepository) DeleteWorkspaceMember(ctx context.Context, workspaceID string, userID string) error {
+	return withTx(ctx, r.Querier).DeleteWorkspaceMember(ctx, db.DeleteWorkspaceMemberParams{
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+	})
+}
+
+func (r *SQLCWorkspaceRepository) TransferWorkspaceOwnership(ctx context.Context, workspaceID string, fromUserID string, toUserID string) error {
+	q := withTx(ctx, r.Querier)
+	if err := q.UpdateWorkspaceTasksOwner(ctx, db.UpdateWorkspaceTasksOwnerParams{
+		WorkspaceID: workspaceID,
+		FromUserID:  fromUserID,
+		ToUserID:    toUserID,
+	}); err != nil {
+		return err
+	}
+	return q.UpdateWorkspaceListsOwner(ctx, db.UpdateWorkspaceListsOwnerParams{
+		WorkspaceID: workspaceID,
+		FromUserID:  fromUserID,
+		ToUserID:    toUserID,
+	})
+}
+
+func (r *SQLCWorkspaceRepository) FindWorkspaceInvitationByID(ctx context.Context, id string) (*entity.WorkspaceInvitation, error) {
+	res, err := withTx(ctx, r.Querier).FindWorkspaceInvitationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toWorkspaceInvitationEntity(res), nil
+}
+
+func (r *SQLCWorkspaceRepository) FindWorkspaceInvitationByEmail(ctx context.Context, workspaceID string, email string) (*entity.WorkspaceInvitation, error) {
+	res, err := withTx(ctx, r.Querier).FindWorkspaceInvitationByEmail(ctx, db.FindWorkspaceInvitationByEmailParams{
+		WorkspaceID: workspaceID,
+		Email:       email,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toWorkspaceInvitationEntity(res), nil
+}
+
+func (r *SQLCWorkspaceRepository) FindWorkspaceInvitationsByWorkspaceID(ctx context.Context, workspaceID string) ([]*entity.WorkspaceInvitation, error) {
+	res, err := withTx(ctx, r.Querier).FindWorkspaceInvitationsByWorkspaceID(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	invitations := make([]*entity.WorkspaceInvitation, len(res))
+	for i, v := range res {
+		invitations[i] = toWorkspaceInvitationEntity(v)
+	}
+	return invitations, nil
+}
+
+func (r *SQLCWorkspaceRepository) FindWorkspaceInvitationsByEmail(ctx context.Context, email string) ([]*entity.WorkspaceInvitation, error) {
+	res, err := withTx(ctx, r.Querier).FindWorkspaceInvitationsByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	invitations := make([]*entity.WorkspaceInvitation, len(res))
+	for i, v := range res {
+		invitations[i] = toWorkspaceInvitationEntity(db.WorkspaceInvitation{ID: v.ID, WorkspaceID: v.WorkspaceID, Email: v.Email, Role: v.Role, InvitedBy: v.InvitedBy, CreatedAt: v.CreatedAt})
+		invitations[i].WorkspaceName = v.WorkspaceName
+	}
+	return invitations, nil
+}
+
+func (r *SQLCWorkspaceRepository) CreateWorkspaceInvitation(ctx context.Context, arg *entity.WorkspaceInvitation) (string, error) {
+	return withTx(ctx, r.Querier).CreateWorkspaceInvitation(ctx, db.CreateWorkspaceInvitationParams{
+		ID:          arg.ID.Value(),
+		WorkspaceID: arg.WorkspaceID.Value(),
+		Email:       arg.Email.Value(),
+		Role:        int16(arg.Role.Value()),
+		InvitedBy:   arg.InvitedBy.Value(),
+		CreatedAt:   arg.CreatedAt,
+	})
+}
+
+func (r *SQLCWorkspaceRepository) UpdateWorkspaceInvitation(ctx context.Context, arg *entity.WorkspaceInvitation) error {
+	return withTx(ctx, r.Querier).UpdateWorkspaceInvitation(ctx, db.UpdateWorkspaceInvitationParams{
+		ID:        arg.ID.Value(),
+		Role:      int16(arg.Role.Value()),
+		InvitedBy: arg.InvitedBy.Value(),
+	})
+}
+
+func (r *SQLCWorkspaceRepository) DeleteWorkspaceInvitation(ctx context.Context, id string) error {
+	return withTx(ctx, r.Querier).DeleteWorkspaceInvitation(ctx, id)
+}
+
+func toWorkspaceEntity(v db.Workspace) *entity.Workspace {
+	return &entity.Workspace{
+		ID:        value.NewID(v.ID),
+		Name:      v.Name,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
+
+func toWorkspaceMemberEntity(v db.WorkspaceMember) *entity.WorkspaceMember {
+	return &entity.WorkspaceMember{
+		WorkspaceID: value.NewID(v.WorkspaceID),
+		UserID:      value.NewID(v.UserID),
+		Role:        value.WorkspaceRole(v.Role),
+		CreatedAt:   v.CreatedAt,
+		UpdatedAt:   v.UpdatedAt,
+	}
+}
+
+func toWorkspaceInvitationEntity(v db.WorkspaceInvitation) *entity.WorkspaceInvitation {
+	return &entity.WorkspaceInvitation{
+		ID:          value.NewID(v.ID),
+		WorkspaceID: value.NewID(v.WorkspaceID),
+		Email:       value.NewEmail(v.Email),
+		Role:        value.WorkspaceRole(v.Role),
+		InvitedBy:   value.NewID(v.InvitedBy),
+		CreatedAt:   v.CreatedAt,
+	}
+}
@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"errors"
+	"regexp"
+)
+
+// ストレージのキーに使用できる文字。パスの区切り文字を含むキーで保存先の外に書き込めないようにする
+var blobKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9._-]{0,254}$`)
+
+// キーの妥当性を検証する
+func validateKey(key string) error {
+	if !blobKeyPattern.MatchString(key) {
+		return errors.New("invalid blob key")
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFSBlobStorage_NewFSBlobStorage(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ repository.IBlobStorage = (*FSBlobStorage)(nil)
+	})
+	tt.Run("正常系: ディレクトリが作成されること", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "blobs")
+		_, err := NewFSBlobStorage(dir)
+
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.DirExists(t, dir, "ディレクトリが存在すること")
+	})
+}
+
+func TestFSBlobStorage_PutBlob(tt *testing.T) {
+	ctx := context.Background()
+
+	tt.Run("正常系: 保存した内容を読み込めること", func(t *testing.T) {
+		s, err := NewFSBlobStorage(t.TempDir())
+		require.NoError(t, err, "エラーが発生しないこと")
+		err = s.PutBlob(ctx, "key", strings.NewReader("hello"), -1, "text/plain")
+		require.NoError(t, err, "エラーが発生しないこと")
+
+		r, err := s.GetBlob(ctx, "key")
+		require.NoError(t, err, "エラーが発生しないこと")
+		defer r.Close()
+		b, err := io.ReadAll(r)
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, "hello", string(b), "内容が一致すること")
+	})
+	tt.Run("準正常系: 読み込みに失敗した場合は一時ファイルが残らないこと", func(t *testing.T) {
+		dir := t.TempDir()
+		s, err := NewFSBlobStorage(dir)
+		require.NoError(t, err, "エラーが発生しないこと")
+		err = s.PutBlob(ctx, "key", io.MultiReader(strings.NewReader("he"), errReader{}), -1, "text/plain")
+
+		require.Error(t, err, "エラーが発生すること")
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Empty(t, entries, "ファイルが残らないこと")
+	})
+	tt.Run("準正常系: キーにパスの区切り文字が含まれる場合", func(t *testing.T) {
+		s, err := NewFSBlobStorage(t.TempDir())
+		require.NoError(t, err, "エラーが発生しないこと")
+		for _, key := range []string{"", "../key", "a/b", ".hidden"} {
+			err = s.PutBlob(ctx, key, strings.NewReader("hello"), -1, "text/plain")
+			require.EqualError(t, err, "invalid blob key", "エラーが一致すること")
+		}
+	})
+}
+
+func TestFSBlobStorage_DeleteBlob(tt *testing.T) {
+	ctx := context.Background()
+
+	tt.Run("正常系: 削除したキーは読み込めないこと", func(t *testing.T) {
+		s, err := NewFSBlobStorage(t.TempDir())
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.NoError(t, s.PutBlob(ctx, "key", strings.NewReader("hello"), 5, "text/plain"))
+
+		err = s.DeleteBlob(ctx, "key")
+		require.NoError(t, err, "エラーが発生しないこと")
+		_, err = s.GetBlob(ctx, "key")
+		require.Error(t, err, "エラーが発生すること")
+	})
+	tt.Run("正常系: 存在しないキーの場合は何もしないこと", func(t *testing.T) {
+		s, err := NewFSBlobStorage(t.TempDir())
+		require.NoError(t, err, "エラーが発生しないこと")
+		err = s.DeleteBlob(ctx, "missing")
+
+		require.NoError(t, err, "エラーが発生しないこと")
+	})
+}
+
+// 常に失敗するReader
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
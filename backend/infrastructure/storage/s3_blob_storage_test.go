@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/7oh2020/connect-tasklist/backend/domain/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3BlobStorage_NewS3BlobStorage(tt *testing.T) {
+	tt.Run("異常系: structがinterfaceを実装しているか", func(t *testing.T) {
+		var _ repository.IBlobStorage = (*S3BlobStorage)(nil)
+	})
+	tt.Run("準正常系: キーが不正な場合は送信しないこと", func(t *testing.T) {
+		s, err := NewS3BlobStorage("localhost:1", "key", "secret", "bucket", false)
+		require.NoError(t, err, "エラーが発生しないこと")
+		err = s.PutBlob(context.Background(), "../key", strings.NewReader("hello"), 5, "text/plain")
+
+		require.EqualError(t, err, "invalid blob key", "エラーが一致すること")
+	})
+}
+
+// S3_ENDPOINTが設定されている場合のみMinIOなどのS3互換サーバーに接続して実行する
+func TestS3BlobStorage_Scenario(t *testing.T) {
+	endpoint := os.Getenv("S3_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_ENDPOINT not set")
+	}
+	ctx := context.Background()
+	s, err := NewS3BlobStorage(endpoint, os.Getenv("S3_ACCESS_KEY"), os.Getenv("S3_SECRET_KEY"), os.Getenv("S3_BUCKET"), os.Getenv("S3_USE_SSL") == "true")
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.NoError(t, s.EnsureBucket(ctx), "バケットが作成できること")
+
+	// サイズが不明な場合も保存できること
+	err = s.PutBlob(ctx, "scenario-key", strings.NewReader("hello"), -1, "text/plain")
+	require.NoError(t, err, "エラーが発生しないこと")
+
+	r, err := s.GetBlob(ctx, "scenario-key")
+	require.NoError(t, err, "エラーが発生しないこと")
+	b, err := io.ReadAll(r)
+	r.Close()
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, "hello", string(b), "内容が一致すること")
+
+	require.NoError(t, s.DeleteBlob(ctx, "scenario-key"), "削除できること")
+	_, err = s.GetBlob(ctx, "scenario-key")
+	require.Error(t, err, "削除したキーは読み込めないこと")
+}
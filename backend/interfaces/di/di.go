@@ -1,17 +1,22 @@
 package di
 
 import (
+	"context"
 	"time"
 
 	"github.com/7oh2020/connect-tasklist/backend/app/handler"
 	"github.com/7oh2020/connect-tasklist/backend/app/usecase"
+	"github.com/7oh2020/connect-tasklist/backend/app/worker"
+	"github.com/7oh2020/connect-tasklist/backend/domain/repository"
 	"github.com/7oh2020/connect-tasklist/backend/domain/service"
 	"github.com/7oh2020/connect-tasklist/backend/infrastructure/persistence/model/db"
 	"github.com/7oh2020/connect-tasklist/backend/infrastructure/persistence/sqlc"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/interceptor"
 	"github.com/7oh2020/connect-tasklist/backend/util/auth"
 	"github.com/7oh2020/connect-tasklist/backend/util/clock"
 	"github.com/7oh2020/connect-tasklist/backend/util/contextkey"
 	"github.com/7oh2020/connect-tasklist/backend/util/identification"
+	"github.com/7oh2020/connect-tasklist/backend/util/markdown"
 )
 
 func InitUser(qry db.Querier) *handler.UserHandler {
@@ -21,16 +26,217 @@ func InitUser(qry db.Querier) *handler.UserHandler {
 	return handler.NewUserHandler(uc)
 }
 
-func InitTask(qry db.Querier) *handler.TaskHandler {
+func InitTask(qry db.Querier, conn sqlc.TxBeginner) *handler.TaskHandler {
 	im := identification.NewUUIDManager()
 	cm := clock.NewClockManager()
 	cr := contextkey.NewContextReader()
+	mr := markdown.NewMarkdownRenderer()
 	repo := sqlc.NewSQLCTaskRepository(qry)
-	srv := service.NewTaskService(repo, im, cm)
-	uc := usecase.NewTaskUsecase(srv)
+	listRepo := sqlc.NewSQLCListRepository(qry)
+	historyRepo := sqlc.NewSQLCTaskHistoryRepository(qry)
+	undoRepo := sqlc.NewSQLCTaskUndoRepository(qry)
+	txm := sqlc.NewSQLCTransactionManager(conn)
+	policy := service.NewAuthorizationPolicy(sqlc.NewSQLCCollaboratorRepository(qry), sqlc.NewSQLCWorkspaceRepository(qry))
+	srv := service.NewTaskService(repo, listRepo, historyRepo, undoRepo, txm, policy, im, cm)
+	uc := usecase.NewTaskUsecase(srv, mr)
 	return handler.NewTaskHandler(uc, cr)
 }
 
+func InitTransfer(qry db.Querier, conn sqlc.TxBeginner) *handler.TransferHandler {
+	im := identification.NewUUIDManager()
+	cm := clock.NewClockManager()
+	cr := contextkey.NewContextReader()
+	mr := markdown.NewMarkdownRenderer()
+	repo := sqlc.NewSQLCTaskRepository(qry)
+	listRepo := sqlc.NewSQLCListRepository(qry)
+	historyRepo := sqlc.NewSQLCTaskHistoryRepository(qry)
+	undoRepo := sqlc.NewSQLCTaskUndoRepository(qry)
+	txm := sqlc.NewSQLCTransactionManager(conn)
+	policy := service.NewAuthorizationPolicy(sqlc.NewSQLCCollaboratorRepository(qry), sqlc.NewSQLCWorkspaceRepository(qry))
+	srv := service.NewTaskService(repo, listRepo, historyRepo, undoRepo, txm, policy, im, cm)
+	uc := usecase.NewTransferUsecase(srv, mr)
+	return handler.NewTransferHandler(uc, cr)
+}
+
+func InitRebalanceWorker(qry db.Querier, conn sqlc.TxBeginner, interval time.Duration) *worker.RebalanceWorker {
+	im := identification.NewUUIDManager()
+	cm := clock.NewClockManager()
+	mr := markdown.NewMarkdownRenderer()
+	repo := sqlc.NewSQLCTaskRepository(qry)
+	listRepo := sqlc.NewSQLCListRepository(qry)
+	historyRepo := sqlc.NewSQLCTaskHistoryRepository(qry)
+	undoRepo := sqlc.NewSQLCTaskUndoRepository(qry)
+	txm := sqlc.NewSQLCTransactionManager(conn)
+	policy := service.NewAuthorizationPolicy(sqlc.NewSQLCCollaboratorRepository(qry), sqlc.NewSQLCWorkspaceRepository(qry))
+	srv := service.NewTaskService(repo, listRepo, historyRepo, undoRepo, txm, policy, im, cm)
+	uc := usecase.NewTaskUsecase(srv, mr)
+	return worker.NewRebalanceWorker(uc, interval)
+}
+
+func InitPurgeWorker(qry db.Querier, conn sqlc.TxBeginner, interval time.Duration, retention time.Duration) *worker.PurgeWorker {
+	im := identification.NewUUIDManager()
+	cm := clock.NewClockManager()
+	mr := markdown.NewMarkdownRenderer()
+	repo := sqlc.NewSQLCTaskRepository(qry)
+	listRepo := sqlc.NewSQLCListRepository(qry)
+	historyRepo := sqlc.NewSQLCTaskHistoryRepository(qry)
+	undoRepo := sqlc.NewSQLCTaskUndoRepository(qry)
+	txm := sqlc.NewSQLCTransactionManager(conn)
+	policy := service.NewAuthorizationPolicy(sqlc.NewSQLCCollaboratorRepository(qry), sqlc.NewSQLCWorkspaceRepository(qry))
+	srv := service.NewTaskService(repo, listRepo, historyRepo, undoRepo, txm, policy, im, cm)
+	uc := usecase.NewTaskUsecase(srv, mr)
+	return worker.NewPurgeWorker(uc, interval, retention)
+}
+
+func InitTag(qry db.Querier) *handler.TagHandler {
+	im := identification.NewUUIDManager()
+	cm := clock.NewClockManager()
+	cr := contextkey.NewContextReader()
+	tagRepo := sqlc.NewSQLCTagRepository(qry)
+	taskRepo := sqlc.NewSQLCTaskRepository(qry)
+	policy := service.NewAuthorizationPolicy(sqlc.NewSQLCCollaboratorRepository(qry), sqlc.NewSQLCWorkspaceRepository(qry))
+	srv := service.NewTagService(tagRepo, taskRepo, policy, im, cm)
+	uc := usecase.NewTagUsecase(srv)
+	return handler.NewTagHandler(uc, cr)
+}
+
+func InitComment(qry db.Querier) *handler.CommentHandler {
+	im := identification.NewUUIDManager()
+	cm := clock.NewClockManager()
+	cr := contextkey.NewContextReader()
+	commentRepo := sqlc.NewSQLCCommentRepository(qry)
+	taskRepo := sqlc.NewSQLCTaskRepository(qry)
+	policy := service.NewAuthorizationPolicy(sqlc.NewSQLCCollaboratorRepository(qry), sqlc.NewSQLCWorkspaceRepository(qry))
+	srv := service.NewCommentService(commentRepo, taskRepo, policy, im, cm)
+	uc := usecase.NewCommentUsecase(srv)
+	return handler.NewCommentHandler(uc, cr)
+}
+
+func InitAttachment(qry db.Querier, storage repository.IBlobStorage, quota int64) *handler.AttachmentHandler {
+	im := identification.NewUUIDManager()
+	cm := clock.NewClockManager()
+	cr := contextkey.NewContextReader()
+	attachmentRepo := sqlc.NewSQLCAttachmentRepository(qry)
+	taskRepo := sqlc.NewSQLCTaskRepository(qry)
+	policy := service.NewAuthorizationPolicy(sqlc.NewSQLCCollaboratorRepository(qry), sqlc.NewSQLCWorkspaceRepository(qry))
+	srv := service.NewAttachmentService(attachmentRepo, taskRepo, policy, storage, im, cm, quota)
+	uc := usecase.NewAttachmentUsecase(srv)
+	return handler.NewAttachmentHandler(uc, cr)
+}
+
+// configはPostgreSQLのテキスト検索設定
+func InitSearch(qry db.Querier, config string) (*handler.SearchHandler, error) {
+	cr := contextkey.NewContextReader()
+	searchRepo := sqlc.NewSQLCTaskSearchRepository(qry, config)
+	if err := searchRepo.VerifyConfig(context.Background()); err != nil {
+		return nil, err
+	}
+	taskRepo := sqlc.NewSQLCTaskRepository(qry)
+	policy := service.NewAuthorizationPolicy(sqlc.NewSQLCCollaboratorRepository(qry), sqlc.NewSQLCWorkspaceRepository(qry))
+	srv := service.NewSearchService(searchRepo, taskRepo, policy)
+	uc := usecase.NewSearchUsecase(srv)
+	return handler.NewSearchHandler(uc, cr), nil
+}
+
+func InitView(qry db.Querier) *handler.ViewHandler {
+	im := identification.NewUUIDManager()
+	cm := clock.NewClockManager()
+	cr := contextkey.NewContextReader()
+	viewRepo := sqlc.NewSQLCViewRepository(qry)
+	taskRepo := sqlc.NewSQLCTaskRepository(qry)
+	srv := service.NewViewService(viewRepo, taskRepo, im, cm)
+	uc := usecase.NewViewUsecase(srv)
+	return handler.NewViewHandler(uc, cr)
+}
+
+func InitTimeEntry(qry db.Querier) *handler.TimeEntryHandler {
+	im := identification.NewUUIDManager()
+	cm := clock.NewClockManager()
+	cr := contextkey.NewContextReader()
+	timeEntryRepo := sqlc.NewSQLCTimeEntryRepository(qry)
+	taskRepo := sqlc.NewSQLCTaskRepository(qry)
+	policy := service.NewAuthorizationPolicy(sqlc.NewSQLCCollaboratorRepository(qry), sqlc.NewSQLCWorkspaceRepository(qry))
+	srv := service.NewTimeEntryService(timeEntryRepo, taskRepo, policy, im, cm)
+	uc := usecase.NewTimeEntryUsecase(srv, cm)
+	return handler.NewTimeEntryHandler(uc, cr)
+}
+
+func InitCalendar(qry db.Querier) *handler.CalendarHandler {
+	cr := contextkey.NewContextReader()
+	sm := identification.NewRandomSecretManager()
+	cm := clock.NewClockManager()
+	repo := sqlc.NewSQLCCalendarFeedRepository(qry)
+	taskRepo := sqlc.NewSQLCTaskRepository(qry)
+	srv := service.NewCalendarService(repo, taskRepo, sm, cm)
+	uc := usecase.NewCalendarUsecase(srv)
+	return handler.NewCalendarHandler(uc, cr)
+}
+
+func InitCalendarFeed(qry db.Querier) *handler.CalendarFeedHandler {
+	sm := identification.NewRandomSecretManager()
+	cm := clock.NewClockManager()
+	repo := sqlc.NewSQLCCalendarFeedRepository(qry)
+	taskRepo := sqlc.NewSQLCTaskRepository(qry)
+	srv := service.NewCalendarService(repo, taskRepo, sm, cm)
+	uc := usecase.NewCalendarUsecase(srv)
+	return handler.NewCalendarFeedHandler(uc)
+}
+
+func InitBlobPurgeWorker(qry db.Querier, storage repository.IBlobStorage, interval time.Duration) *worker.BlobPurgeWorker {
+	im := identification.NewUUIDManager()
+	cm := clock.NewClockManager()
+	attachmentRepo := sqlc.NewSQLCAttachmentRepository(qry)
+	taskRepo := sqlc.NewSQLCTaskRepository(qry)
+	policy := service.NewAuthorizationPolicy(sqlc.NewSQLCCollaboratorRepository(qry), sqlc.NewSQLCWorkspaceRepository(qry))
+	// ストレージからの削除のみ行うため使用量の上限は使用しない
+	srv := service.NewAttachmentService(attachmentRepo, taskRepo, policy, storage, im, cm, 0)
+	uc := usecase.NewAttachmentUsecase(srv)
+	return worker.NewBlobPurgeWorker(uc, interval)
+}
+
+func InitList(qry db.Querier) *handler.ListHandler {
+	im := identification.NewUUIDManager()
+	cm := clock.NewClockManager()
+	cr := contextkey.NewContextReader()
+	repo := sqlc.NewSQLCListRepository(qry)
+	policy := service.NewAuthorizationPolicy(sqlc.NewSQLCCollaboratorRepository(qry), sqlc.NewSQLCWorkspaceRepository(qry))
+	srv := service.NewListService(repo, policy, im, cm)
+	uc := usecase.NewListUsecase(srv)
+	return handler.NewListHandler(uc, cr)
+}
+
+func InitSharing(qry db.Querier) *handler.SharingHandler {
+	im := identification.NewUUIDManager()
+	cm := clock.NewClockManager()
+	cr := contextkey.NewContextReader()
+	collaboratorRepo := sqlc.NewSQLCCollaboratorRepository(qry)
+	taskRepo := sqlc.NewSQLCTaskRepository(qry)
+	listRepo := sqlc.NewSQLCListRepository(qry)
+	userRepo := sqlc.NewSQLCUserRepository(qry)
+	policy := service.NewAuthorizationPolicy(collaboratorRepo, sqlc.NewSQLCWorkspaceRepository(qry))
+	srv := service.NewSharingService(collaboratorRepo, taskRepo, listRepo, userRepo, policy, im, cm)
+	uc := usecase.NewSharingUsecase(srv)
+	return handler.NewSharingHandler(uc, cr)
+}
+
+func InitWorkspace(qry db.Querier, conn sqlc.TxBeginner) *handler.WorkspaceHandler {
+	im := identification.NewUUIDManager()
+	cm := clock.NewClockManager()
+	cr := contextkey.NewContextReader()
+	workspaceRepo := sqlc.NewSQLCWorkspaceRepository(qry)
+	userRepo := sqlc.NewSQLCUserRepository(qry)
+	txm := sqlc.NewSQLCTransactionManager(conn)
+	policy := service.NewAuthorizationPolicy(sqlc.NewSQLCCollaboratorRepository(qry), workspaceRepo)
+	srv := service.NewWorkspaceService(workspaceRepo, userRepo, txm, policy, im, cm)
+	uc := usecase.NewWorkspaceUsecase(srv)
+	return handler.NewWorkspaceHandler(uc, cr)
+}
+
+func InitAuthInterceptor(issuer string, keyPath string, qry db.Querier) *interceptor.AuthInterceptor {
+	workspaceRepo := sqlc.NewSQLCWorkspaceRepository(qry)
+	return interceptor.NewAuthInterceptor(issuer, keyPath, workspaceRepo)
+}
+
 func InitAuth(issuer string, keyPath string, qry db.Querier, timeout time.Duration) (*handler.AuthHandler, error) {
 	tm, err := auth.NewTokenManager(issuer, keyPath)
 	if err != nil {
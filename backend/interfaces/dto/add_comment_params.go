@@ -0,0 +1,53 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type AddCommentParams struct {
+	taskID IDParam
+	userID IDParam
+	body   string
+}
+
+func NewAddCommentParams(taskID string, userID string, body string) *AddCommentParams {
+	return &AddCommentParams{
+		taskID: *NewIDParam(taskID),
+		userID: *NewIDParam(userID),
+		body:   body,
+	}
+}
+
+func (f *AddCommentParams) TaskID() string {
+	return f.taskID.Value()
+}
+
+func (f *AddCommentParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *AddCommentParams) Body() string {
+	return f.body
+}
+
+func (f *AddCommentParams) Validate() error {
+	if err := f.taskID.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if err := validateCommentBody(f.body); err != nil {
+		return err
+	}
+	return nil
+}
+
+func validateCommentBody(body string) error {
+	if len([]rune(body)) > 5000 {
+		return &app.ErrInputValidationFailed{Msg: "body must be 5000 characters or less"}
+	}
+	// マルチバイト文字のみで構成された場合でも保存サイズが過大にならないようにする
+	if len(body) > 16*1024 {
+		return &app.ErrInputValidationFailed{Msg: "body must be 16KB or less"}
+	}
+	return nil
+}
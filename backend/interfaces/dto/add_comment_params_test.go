@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddCommentParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *AddCommentParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewAddCommentParams("id", "uid", "comment"), nil},
+		{"正常系: 半角5000文字の場合", NewAddCommentParams("id", "uid", strings.Repeat("*", 5000)), nil},
+		{"準正常系: IDが半角50文字を超える場合", NewAddCommentParams(strings.Repeat("*", 51), "uid", "comment"), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが半角50文字を超える場合", NewAddCommentParams("id", strings.Repeat("*", 51), "comment"), errors.New("id must be 50 characters or less")},
+		{"準正常系: 半角5000文字を超える場合", NewAddCommentParams("id", "uid", strings.Repeat("*", 5001)), errors.New("body must be 5000 characters or less")},
+		{"準正常系: 全角5000文字を超える場合", NewAddCommentParams("id", "uid", strings.Repeat("あ", 5001)), errors.New("body must be 5000 characters or less")},
+		{"準正常系: 16KBを超える場合", NewAddCommentParams("id", "uid", strings.Repeat("🍣", 4097)), errors.New("body must be 16KB or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package dto
+
+type AssignTaskParams struct {
+	id         IDParam
+	userID     IDParam
+	assigneeID IDParam
+}
+
+func NewAssignTaskParams(id string, userID string, assigneeID string) *AssignTaskParams {
+	return &AssignTaskParams{
+		id:         *NewIDParam(id),
+		userID:     *NewIDParam(userID),
+		assigneeID: *NewIDParam(assigneeID),
+	}
+}
+
+func (f *AssignTaskParams) ID() string {
+	return f.id.Value()
+}
+
+func (f *AssignTaskParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *AssignTaskParams) AssigneeID() string {
+	return f.assigneeID.Value()
+}
+
+func (f *AssignTaskParams) Validate() error {
+	if err := f.id.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if err := f.assigneeID.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package dto
+
+// 自分が担当するタスクの一覧の条件
+type AssignedFilterParams struct {
+	userID IDParam
+	order  int32
+}
+
+func NewAssignedFilterParams(userID string, order int32) *AssignedFilterParams {
+	return &AssignedFilterParams{
+		userID: *NewIDParam(userID),
+		order:  order,
+	}
+}
+
+func (f *AssignedFilterParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *AssignedFilterParams) Order() int32 {
+	return f.order
+}
+
+func (f *AssignedFilterParams) Validate() error {
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if err := validateOrder(f.order); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignedFilterParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *AssignedFilterParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewAssignedFilterParams("uid", 2), nil},
+		{"準正常系: UserIDが半角50文字を超える場合", NewAssignedFilterParams(strings.Repeat("*", 51), 0), errors.New("id must be 50 characters or less")},
+		{"準正常系: 並び順が不正な場合", NewAssignedFilterParams("uid", 3), errors.New("invalid order")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package dto
+
+import (
+	"fmt"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+)
+
+// 一度に実行できる操作の上限
+const maxTaskOperations = 100
+
+// タスクの一括操作の1件
+type TaskOperationParams struct {
+	kind   int32
+	taskID IDParam
+	listID IDParam
+	name   string
+}
+
+// 作成の場合はtaskIDを、作成以外の場合はlistIDを使用しない
+func NewTaskOperationParams(kind int32, taskID string, listID string, name string) *TaskOperationParams {
+	return &TaskOperationParams{
+		kind:   kind,
+		taskID: *NewIDParam(taskID),
+		listID: *NewIDParam(listID),
+		name:   name,
+	}
+}
+
+func (f *TaskOperationParams) Kind() int32 {
+	return f.kind
+}
+
+func (f *TaskOperationParams) TaskID() string {
+	return f.taskID.Value()
+}
+
+func (f *TaskOperationParams) ListID() string {
+	return f.listID.Value()
+}
+
+func (f *TaskOperationParams) Name() string {
+	return f.name
+}
+
+func (f *TaskOperationParams) Validate() error {
+	if f.kind < 0 || f.kind > 4 {
+		return &app.ErrInputValidationFailed{Msg: "invalid operation"}
+	}
+	if err := f.taskID.Validate(); err != nil {
+		return err
+	}
+	if err := f.listID.Validate(); err != nil {
+		return err
+	}
+	if len([]rune(f.name)) > 100 {
+		return &app.ErrInputValidationFailed{Msg: "name must be 100 characters or less"}
+	}
+	return nil
+}
+
+type BatchMutateTasksParams struct {
+	userID     IDParam
+	operations []*TaskOperationParams
+	atomic     bool
+}
+
+// atomicがtrueの場合は1件でも失敗すると全ての操作を取り消す
+func NewBatchMutateTasksParams(userID string, operations []*TaskOperationParams, atomic bool) *BatchMutateTasksParams {
+	return &BatchMutateTasksParams{
+		userID:     *NewIDParam(userID),
+		operations: operations,
+		atomic:     atomic,
+	}
+}
+
+func (f *BatchMutateTasksParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *BatchMutateTasksParams) Operations() []*TaskOperationParams {
+	return f.operations
+}
+
+func (f *BatchMutateTasksParams) Atomic() bool {
+	return f.atomic
+}
+
+// 操作の入力エラーは何番目の操作かをメッセージに含める
+func (f *BatchMutateTasksParams) Validate() error {
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if len(f.operations) == 0 {
+		return &app.ErrInputValidationFailed{Msg: "operations is empty"}
+	}
+	if len(f.operations) > maxTaskOperations {
+		return &app.ErrInputValidationFailed{Msg: "operations must be 100 or less"}
+	}
+	for i, op := range f.operations {
+		if err := op.Validate(); err != nil {
+			return &app.ErrInputValidationFailed{Msg: fmt.Sprintf("operations[%d]: %s", i, err.Error())}
+		}
+	}
+	return nil
+}
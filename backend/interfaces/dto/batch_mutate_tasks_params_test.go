@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskOperationParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *TaskOperationParams
+		err   error
+	}{
+		{"正常系: 作成の場合", NewTaskOperationParams(0, "", "lid", "task"), nil},
+		{"正常系: 削除の場合", NewTaskOperationParams(4, "tid", "", ""), nil},
+		{"準正常系: 操作の種類が負の値の場合", NewTaskOperationParams(-1, "tid", "", ""), errors.New("invalid operation")},
+		{"準正常系: 操作の種類が範囲外の場合", NewTaskOperationParams(5, "tid", "", ""), errors.New("invalid operation")},
+		{"準正常系: TaskIDが50文字を超える場合", NewTaskOperationParams(1, strings.Repeat("*", 51), "", "task"), errors.New("id must be 50 characters or less")},
+		{"準正常系: ListIDが50文字を超える場合", NewTaskOperationParams(0, "", strings.Repeat("*", 51), "task"), errors.New("id must be 50 characters or less")},
+		{"準正常系: タスク名が100文字を超える場合", NewTaskOperationParams(1, "tid", "", strings.Repeat("a", 101)), errors.New("name must be 100 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
+
+func TestBatchMutateTasksParams_Validate(tt *testing.T) {
+	op := NewTaskOperationParams(2, "tid", "", "")
+	ops := make([]*TaskOperationParams, 101)
+	for i := range ops {
+		ops[i] = op
+	}
+
+	testcases := []struct {
+		title string
+		arg   *BatchMutateTasksParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewBatchMutateTasksParams("uid", []*TaskOperationParams{op}, true), nil},
+		{"正常系: 操作が100件の場合", NewBatchMutateTasksParams("uid", ops[:100], false), nil},
+		{"準正常系: UserIDが50文字を超える場合", NewBatchMutateTasksParams(strings.Repeat("*", 51), []*TaskOperationParams{op}, true), errors.New("id must be 50 characters or less")},
+		{"準正常系: 操作が空の場合", NewBatchMutateTasksParams("uid", nil, true), errors.New("operations is empty")},
+		{"準正常系: 操作が100件を超える場合", NewBatchMutateTasksParams("uid", ops, true), errors.New("operations must be 100 or less")},
+		{"準正常系: 操作が不正な場合は何番目かを含めること", NewBatchMutateTasksParams("uid", []*TaskOperationParams{op, NewTaskOperationParams(9, "tid", "", "")}, true), errors.New("operations[1]: invalid operation")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
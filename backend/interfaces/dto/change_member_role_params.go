@@ -0,0 +1,34 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type ChangeMemberRoleParams struct {
+	member WorkspaceMemberParams
+	role   int32
+}
+
+func NewChangeMemberRoleParams(workspaceID string, userID string, memberID string, role int32) *ChangeMemberRoleParams {
+	return &ChangeMemberRoleParams{
+		member: *NewWorkspaceMemberParams(workspaceID, userID, memberID),
+		role:   role,
+	}
+}
+
+func (f *ChangeMemberRoleParams) Member() *WorkspaceMemberParams {
+	return &f.member
+}
+
+func (f *ChangeMemberRoleParams) Role() int32 {
+	return f.role
+}
+
+func (f *ChangeMemberRoleParams) Validate() error {
+	if err := f.member.Validate(); err != nil {
+		return err
+	}
+	// 1(メンバー)と2(管理者)の2種類。所有者は変更できない
+	if f.role < 1 || f.role > 2 {
+		return &app.ErrInputValidationFailed{Msg: "invalid role"}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeMemberRoleParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *ChangeMemberRoleParams
+		err   error
+	}{
+		{"正常系: メンバーを指定した場合", NewChangeMemberRoleParams("wid", "uid", "mid", 1), nil},
+		{"正常系: 管理者を指定した場合", NewChangeMemberRoleParams("wid", "uid", "mid", 2), nil},
+		{"準正常系: MemberIDが50文字を超える場合", NewChangeMemberRoleParams("wid", "uid", strings.Repeat("*", 51), 1), errors.New("id must be 50 characters or less")},
+		{"準正常系: 権限が未指定の場合", NewChangeMemberRoleParams("wid", "uid", "mid", 0), errors.New("invalid role")},
+		{"準正常系: 所有者を指定した場合", NewChangeMemberRoleParams("wid", "uid", "mid", 3), errors.New("invalid role")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type ChangeTaskDescriptionParams struct {
+	id          IDParam
+	userID      IDParam
+	description string
+}
+
+func NewChangeTaskDescriptionParams(id string, userID string, description string) *ChangeTaskDescriptionParams {
+	return &ChangeTaskDescriptionParams{
+		id:          *NewIDParam(id),
+		userID:      *NewIDParam(userID),
+		description: description,
+	}
+}
+
+func (f *ChangeTaskDescriptionParams) ID() string {
+	return f.id.Value()
+}
+
+func (f *ChangeTaskDescriptionParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *ChangeTaskDescriptionParams) Description() string {
+	return f.description
+}
+
+func (f *ChangeTaskDescriptionParams) Validate() error {
+	if err := f.id.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if len([]rune(f.description)) > 10000 {
+		return &app.ErrInputValidationFailed{Msg: "description must be 10000 characters or less"}
+	}
+	// マルチバイト文字のみで構成された場合でも保存サイズが過大にならないようにする
+	if len(f.description) > 32*1024 {
+		return &app.ErrInputValidationFailed{Msg: "description must be 32KB or less"}
+	}
+	return nil
+}
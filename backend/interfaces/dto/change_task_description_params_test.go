@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeTaskDescriptionParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *ChangeTaskDescriptionParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewChangeTaskDescriptionParams("id", "uid", "# memo"), nil},
+		{"正常系: 空の場合", NewChangeTaskDescriptionParams("id", "uid", ""), nil},
+		{"正常系: 半角10000文字の場合", NewChangeTaskDescriptionParams("id", "uid", strings.Repeat("*", 10000)), nil},
+		{"準正常系: IDが半角50文字を超える場合", NewChangeTaskDescriptionParams(strings.Repeat("*", 51), "uid", "memo"), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが半角50文字を超える場合", NewChangeTaskDescriptionParams("id", strings.Repeat("*", 51), "memo"), errors.New("id must be 50 characters or less")},
+		{"準正常系: 半角10000文字を超える場合", NewChangeTaskDescriptionParams("id", "uid", strings.Repeat("*", 10001)), errors.New("description must be 10000 characters or less")},
+		{"準正常系: 全角10000文字を超える場合", NewChangeTaskDescriptionParams("id", "uid", strings.Repeat("あ", 10001)), errors.New("description must be 10000 characters or less")},
+		{"準正常系: 32KBを超える場合", NewChangeTaskDescriptionParams("id", "uid", strings.Repeat("🍣", 8193)), errors.New("description must be 32KB or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
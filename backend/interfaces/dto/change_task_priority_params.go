@@ -0,0 +1,43 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type ChangeTaskPriorityParams struct {
+	id       IDParam
+	userID   IDParam
+	priority int32
+}
+
+func NewChangeTaskPriorityParams(id string, userID string, priority int32) *ChangeTaskPriorityParams {
+	return &ChangeTaskPriorityParams{
+		id:       *NewIDParam(id),
+		userID:   *NewIDParam(userID),
+		priority: priority,
+	}
+}
+
+func (f *ChangeTaskPriorityParams) ID() string {
+	return f.id.Value()
+}
+
+func (f *ChangeTaskPriorityParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *ChangeTaskPriorityParams) Priority() int32 {
+	return f.priority
+}
+
+func (f *ChangeTaskPriorityParams) Validate() error {
+	if err := f.id.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	// 0(なし)から4(緊急)までの5段階
+	if f.priority < 0 || f.priority > 4 {
+		return &app.ErrInputValidationFailed{Msg: "invalid priority"}
+	}
+	return nil
+}
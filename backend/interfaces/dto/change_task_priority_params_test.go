@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeTaskPriorityParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *ChangeTaskPriorityParams
+		err   error
+	}{
+		{"正常系: 優先度なしの場合", NewChangeTaskPriorityParams("id", "uid", 0), nil},
+		{"正常系: 優先度が緊急の場合", NewChangeTaskPriorityParams("id", "uid", 4), nil},
+		{"準正常系: IDが半角50文字を超える場合", NewChangeTaskPriorityParams(strings.Repeat("*", 51), "uid", 1), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが半角50文字を超える場合", NewChangeTaskPriorityParams("id", strings.Repeat("*", 51), 1), errors.New("id must be 50 characters or less")},
+		{"準正常系: 優先度が負の場合", NewChangeTaskPriorityParams("id", "uid", -1), errors.New("invalid priority")},
+		{"準正常系: 優先度が範囲外の場合", NewChangeTaskPriorityParams("id", "uid", 5), errors.New("invalid priority")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type CreateListParams struct {
+	userID IDParam
+	name   string
+}
+
+func NewCreateListParams(userID string, name string) *CreateListParams {
+	return &CreateListParams{
+		userID: *NewIDParam(userID),
+		name:   name,
+	}
+}
+
+func (f *CreateListParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *CreateListParams) Name() string {
+	return f.name
+}
+
+func (f *CreateListParams) Validate() error {
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if len([]rune(f.name)) > 100 {
+		return &app.ErrInputValidationFailed{Msg: "name must be 100 characters or less"}
+	}
+	return nil
+}
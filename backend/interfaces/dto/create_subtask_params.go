@@ -0,0 +1,42 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type CreateSubtaskParams struct {
+	userID   IDParam
+	parentID IDParam
+	name     string
+}
+
+func NewCreateSubtaskParams(userID string, parentID string, name string) *CreateSubtaskParams {
+	return &CreateSubtaskParams{
+		userID:   *NewIDParam(userID),
+		parentID: *NewIDParam(parentID),
+		name:     name,
+	}
+}
+
+func (f *CreateSubtaskParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *CreateSubtaskParams) ParentID() string {
+	return f.parentID.Value()
+}
+
+func (f *CreateSubtaskParams) Name() string {
+	return f.name
+}
+
+func (f *CreateSubtaskParams) Validate() error {
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if err := f.parentID.Validate(); err != nil {
+		return err
+	}
+	if len([]rune(f.name)) > 100 {
+		return &app.ErrInputValidationFailed{Msg: "name must be 100 characters or less"}
+	}
+	return nil
+}
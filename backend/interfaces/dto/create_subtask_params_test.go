@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSubtaskParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *CreateSubtaskParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewCreateSubtaskParams("uid", "pid", "task"), nil},
+		{"準正常系: UserIDが50文字を超える場合", NewCreateSubtaskParams(strings.Repeat("*", 51), "pid", "task"), errors.New("id must be 50 characters or less")},
+		{"準正常系: ParentIDが50文字を超える場合", NewCreateSubtaskParams("uid", strings.Repeat("*", 51), "task"), errors.New("id must be 50 characters or less")},
+		{"準正常系: Nameが半角100文字を超える場合", NewCreateSubtaskParams("uid", "pid", strings.Repeat("*", 101)), errors.New("name must be 100 characters or less")},
+		{"準正常系: Nameが全角100文字を超える場合", NewCreateSubtaskParams("uid", "pid", strings.Repeat("あ", 101)), errors.New("name must be 100 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
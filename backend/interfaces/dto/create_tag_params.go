@@ -0,0 +1,33 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type CreateTagParams struct {
+	userID IDParam
+	name   string
+}
+
+func NewCreateTagParams(userID string, name string) *CreateTagParams {
+	return &CreateTagParams{
+		userID: *NewIDParam(userID),
+		name:   name,
+	}
+}
+
+func (f *CreateTagParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *CreateTagParams) Name() string {
+	return f.name
+}
+
+func (f *CreateTagParams) Validate() error {
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if len([]rune(f.name)) > 50 {
+		return &app.ErrInputValidationFailed{Msg: "name must be 50 characters or less"}
+	}
+	return nil
+}
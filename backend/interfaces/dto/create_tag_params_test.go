@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTagParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *CreateTagParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewCreateTagParams("uid", "work"), nil},
+		{"準正常系: UserIDが50文字を超える場合", NewCreateTagParams(strings.Repeat("*", 51), "work"), errors.New("id must be 50 characters or less")},
+		{"準正常系: Nameが半角50文字を超える場合", NewCreateTagParams("uid", strings.Repeat("*", 51)), errors.New("name must be 50 characters or less")},
+		{"準正常系: Nameが全角50文字を超える場合", NewCreateTagParams("uid", strings.Repeat("あ", 51)), errors.New("name must be 50 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -4,12 +4,15 @@ import "github.com/7oh2020/connect-tasklist/backend/app"
 
 type CreateTaskParams struct {
 	userID IDParam
+	listID IDParam
 	name   string
 }
 
-func NewCreateTaskParams(userID string, name string) *CreateTaskParams {
+// listIDが空の場合はInboxに作成する
+func NewCreateTaskParams(userID string, listID string, name string) *CreateTaskParams {
 	return &CreateTaskParams{
 		userID: *NewIDParam(userID),
+		listID: *NewIDParam(listID),
 		name:   name,
 	}
 }
@@ -18,6 +21,10 @@ func (f *CreateTaskParams) UserID() string {
 	return f.userID.Value()
 }
 
+func (f *CreateTaskParams) ListID() string {
+	return f.listID.Value()
+}
+
 func (f *CreateTaskParams) Name() string {
 	return f.name
 }
@@ -26,6 +33,9 @@ func (f *CreateTaskParams) Validate() error {
 	if err := f.userID.Validate(); err != nil {
 		return err
 	}
+	if err := f.listID.Validate(); err != nil {
+		return err
+	}
 	if len([]rune(f.name)) > 100 {
 		return &app.ErrInputValidationFailed{Msg: "name must be 100 characters or less"}
 	}
@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+)
+
+type CreateTimeEntryParams struct {
+	taskID    IDParam
+	userID    IDParam
+	startedAt time.Time
+	endedAt   time.Time
+	note      string
+}
+
+func NewCreateTimeEntryParams(taskID string, userID string, startedAt time.Time, endedAt time.Time, note string) *CreateTimeEntryParams {
+	return &CreateTimeEntryParams{
+		taskID:    *NewIDParam(taskID),
+		userID:    *NewIDParam(userID),
+		startedAt: startedAt,
+		endedAt:   endedAt,
+		note:      note,
+	}
+}
+
+func (f *CreateTimeEntryParams) TaskID() string {
+	return f.taskID.Value()
+}
+
+func (f *CreateTimeEntryParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *CreateTimeEntryParams) StartedAt() time.Time {
+	return f.startedAt
+}
+
+func (f *CreateTimeEntryParams) EndedAt() time.Time {
+	return f.endedAt
+}
+
+func (f *CreateTimeEntryParams) Note() string {
+	return f.note
+}
+
+func (f *CreateTimeEntryParams) Validate() error {
+	if err := f.taskID.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if f.startedAt.IsZero() {
+		return &app.ErrInputValidationFailed{Msg: "started_at is empty"}
+	}
+	if f.endedAt.IsZero() {
+		return &app.ErrInputValidationFailed{Msg: "ended_at is empty"}
+	}
+	if err := validateTimeEntryNote(f.note); err != nil {
+		return err
+	}
+	return nil
+}
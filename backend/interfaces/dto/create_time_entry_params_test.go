@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTimeEntryParams_Validate(tt *testing.T) {
+	start := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	testcases := []struct {
+		title string
+		arg   *CreateTimeEntryParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewCreateTimeEntryParams("tid", "uid", start, end, "note"), nil},
+		{"準正常系: TaskIDが50文字を超える場合", NewCreateTimeEntryParams(strings.Repeat("*", 51), "uid", start, end, ""), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが50文字を超える場合", NewCreateTimeEntryParams("tid", strings.Repeat("*", 51), start, end, ""), errors.New("id must be 50 characters or less")},
+		{"準正常系: 開始日時が空の場合", NewCreateTimeEntryParams("tid", "uid", time.Time{}, end, ""), errors.New("started_at is empty")},
+		{"準正常系: 終了日時が空の場合", NewCreateTimeEntryParams("tid", "uid", start, time.Time{}, ""), errors.New("ended_at is empty")},
+		{"準正常系: メモが255文字を超える場合", NewCreateTimeEntryParams("tid", "uid", start, end, strings.Repeat("a", 256)), errors.New("note must be 255 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
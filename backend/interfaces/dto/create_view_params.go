@@ -0,0 +1,39 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type CreateViewParams struct {
+	userID IDParam
+	name   string
+	query  ViewQueryParams
+}
+
+func NewCreateViewParams(userID string, name string, query *ViewQueryParams) *CreateViewParams {
+	return &CreateViewParams{
+		userID: *NewIDParam(userID),
+		name:   name,
+		query:  *query,
+	}
+}
+
+func (f *CreateViewParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *CreateViewParams) Name() string {
+	return f.name
+}
+
+func (f *CreateViewParams) Query() *ViewQueryParams {
+	return &f.query
+}
+
+func (f *CreateViewParams) Validate() error {
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if len([]rune(f.name)) > 50 {
+		return &app.ErrInputValidationFailed{Msg: "name must be 50 characters or less"}
+	}
+	return f.query.Validate()
+}
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateViewParams_Validate(tt *testing.T) {
+	query := NewViewQueryParams(1, 1, 0, "", 3, true, 0)
+	testcases := []struct {
+		title string
+		arg   *CreateViewParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewCreateViewParams("uid", "today", query), nil},
+		{"準正常系: UserIDが50文字を超える場合", NewCreateViewParams(strings.Repeat("*", 51), "today", query), errors.New("id must be 50 characters or less")},
+		{"準正常系: Nameが全角50文字を超える場合", NewCreateViewParams("uid", strings.Repeat("あ", 51), query), errors.New("name must be 50 characters or less")},
+		{"準正常系: 条件が不正な場合", NewCreateViewParams("uid", "today", NewViewQueryParams(0, 0, 0, "", 0, true, 5)), errors.New("invalid group by")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type CreateWorkspaceListParams struct {
+	workspaceID IDParam
+	userID      IDParam
+	name        string
+}
+
+func NewCreateWorkspaceListParams(workspaceID string, userID string, name string) *CreateWorkspaceListParams {
+	return &CreateWorkspaceListParams{
+		workspaceID: *NewIDParam(workspaceID),
+		userID:      *NewIDParam(userID),
+		name:        name,
+	}
+}
+
+func (f *CreateWorkspaceListParams) WorkspaceID() string {
+	return f.workspaceID.Value()
+}
+
+func (f *CreateWorkspaceListParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *CreateWorkspaceListParams) Name() string {
+	return f.name
+}
+
+func (f *CreateWorkspaceListParams) Validate() error {
+	if err := f.workspaceID.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if len([]rune(f.name)) > 100 {
+		return &app.ErrInputValidationFailed{Msg: "name must be 100 characters or less"}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type CreateWorkspaceParams struct {
+	userID IDParam
+	name   string
+}
+
+func NewCreateWorkspaceParams(userID string, name string) *CreateWorkspaceParams {
+	return &CreateWorkspaceParams{
+		userID: *NewIDParam(userID),
+		name:   name,
+	}
+}
+
+func (f *CreateWorkspaceParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *CreateWorkspaceParams) Name() string {
+	return f.name
+}
+
+func (f *CreateWorkspaceParams) Validate() error {
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if len([]rune(f.name)) > 100 {
+		return &app.ErrInputValidationFailed{Msg: "name must be 100 characters or less"}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+)
+
+// 一度に検索できる期間の上限
+const maxDueRange = 366 * 24 * time.Hour
+
+type DueRangeParams struct {
+	userID IDParam
+	from   time.Time
+	to     time.Time
+}
+
+func NewDueRangeParams(userID string, from time.Time, to time.Time) *DueRangeParams {
+	return &DueRangeParams{
+		userID: *NewIDParam(userID),
+		from:   from,
+		to:     to,
+	}
+}
+
+func (f *DueRangeParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *DueRangeParams) From() time.Time {
+	return f.from
+}
+
+func (f *DueRangeParams) To() time.Time {
+	return f.to
+}
+
+func (f *DueRangeParams) Validate() error {
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if f.from.IsZero() {
+		return &app.ErrInputValidationFailed{Msg: "from is empty"}
+	}
+	if f.to.IsZero() {
+		return &app.ErrInputValidationFailed{Msg: "to is empty"}
+	}
+	if !f.from.Before(f.to) {
+		return &app.ErrInputValidationFailed{Msg: "from must be before to"}
+	}
+	if f.to.Sub(f.from) > maxDueRange {
+		return &app.ErrInputValidationFailed{Msg: "range must be 366 days or less"}
+	}
+	return nil
+}
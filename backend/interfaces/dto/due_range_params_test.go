@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDueRangeParams_Validate(tt *testing.T) {
+	from := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	testcases := []struct {
+		title string
+		arg   *DueRangeParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewDueRangeParams("uid", from, to), nil},
+		{"正常系: 期間が366日の場合", NewDueRangeParams("uid", from, from.AddDate(0, 0, 366)), nil},
+		{"準正常系: UserIDが半角50文字を超える場合", NewDueRangeParams(strings.Repeat("*", 51), from, to), errors.New("id must be 50 characters or less")},
+		{"準正常系: fromが空の場合", NewDueRangeParams("uid", time.Time{}, to), errors.New("from is empty")},
+		{"準正常系: toが空の場合", NewDueRangeParams("uid", from, time.Time{}), errors.New("to is empty")},
+		{"準正常系: fromとtoが同じ場合", NewDueRangeParams("uid", from, from), errors.New("from must be before to")},
+		{"準正常系: fromがtoより後の場合", NewDueRangeParams("uid", to, from), errors.New("from must be before to")},
+		{"準正常系: 期間が366日を超える場合", NewDueRangeParams("uid", from, from.AddDate(0, 0, 367)), errors.New("range must be 366 days or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package dto
+
+type EditCommentParams struct {
+	id     IDParam
+	userID IDParam
+	body   string
+}
+
+func NewEditCommentParams(id string, userID string, body string) *EditCommentParams {
+	return &EditCommentParams{
+		id:     *NewIDParam(id),
+		userID: *NewIDParam(userID),
+		body:   body,
+	}
+}
+
+func (f *EditCommentParams) ID() string {
+	return f.id.Value()
+}
+
+func (f *EditCommentParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *EditCommentParams) Body() string {
+	return f.body
+}
+
+func (f *EditCommentParams) Validate() error {
+	if err := f.id.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if err := validateCommentBody(f.body); err != nil {
+		return err
+	}
+	return nil
+}
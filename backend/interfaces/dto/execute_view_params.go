@@ -0,0 +1,68 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type ExecuteViewParams struct {
+	id        IDParam
+	userID    IDParam
+	timezone  string
+	pageSize  int32
+	pageToken string
+}
+
+// timezoneが空の場合はUTCの暦で期間を判定する
+// pageSizeが0の場合は既定の件数、pageTokenが空の場合は最初のページを取得する
+func NewExecuteViewParams(id string, userID string, timezone string, pageSize int32, pageToken string) *ExecuteViewParams {
+	return &ExecuteViewParams{
+		id:        *NewIDParam(id),
+		userID:    *NewIDParam(userID),
+		timezone:  timezone,
+		pageSize:  pageSize,
+		pageToken: pageToken,
+	}
+}
+
+func (f *ExecuteViewParams) ID() string {
+	return f.id.Value()
+}
+
+func (f *ExecuteViewParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *ExecuteViewParams) Timezone() string {
+	if f.timezone == "" {
+		return "UTC"
+	}
+	return f.timezone
+}
+
+func (f *ExecuteViewParams) PageSize() int32 {
+	if f.pageSize == 0 {
+		return defaultPageSize
+	}
+	return f.pageSize
+}
+
+func (f *ExecuteViewParams) PageToken() string {
+	return f.pageToken
+}
+
+func (f *ExecuteViewParams) Validate() error {
+	if err := f.id.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if len(f.timezone) > 64 {
+		return &app.ErrInputValidationFailed{Msg: "timezone must be 64 characters or less"}
+	}
+	if err := validatePageSize(f.pageSize); err != nil {
+		return err
+	}
+	if len(f.pageToken) > 200 {
+		return &app.ErrInputValidationFailed{Msg: "page_token must be 200 characters or less"}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteViewParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *ExecuteViewParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewExecuteViewParams("id", "uid", "Asia/Tokyo", 50, "token"), nil},
+		{"正常系: 省略可能な値を指定しない場合", NewExecuteViewParams("id", "uid", "", 0, ""), nil},
+		{"準正常系: IDが50文字を超える場合", NewExecuteViewParams(strings.Repeat("*", 51), "uid", "", 0, ""), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが50文字を超える場合", NewExecuteViewParams("id", strings.Repeat("*", 51), "", 0, ""), errors.New("id must be 50 characters or less")},
+		{"準正常系: タイムゾーンが64文字を超える場合", NewExecuteViewParams("id", "uid", strings.Repeat("*", 65), 0, ""), errors.New("timezone must be 64 characters or less")},
+		{"準正常系: ページサイズが100を超える場合", NewExecuteViewParams("id", "uid", "", 101, ""), errors.New("page_size must be 100 or less")},
+		{"準正常系: ページトークンが200文字を超える場合", NewExecuteViewParams("id", "uid", "", 0, strings.Repeat("a", 201)), errors.New("page_token must be 200 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
+
+func TestExecuteViewParams_Timezone(tt *testing.T) {
+	tt.Run("正常系: 未指定の場合はUTCになること", func(t *testing.T) {
+		require.Equal(t, "UTC", NewExecuteViewParams("id", "uid", "", 0, "").Timezone())
+	})
+	tt.Run("正常系: 指定したタイムゾーンになること", func(t *testing.T) {
+		require.Equal(t, "Asia/Tokyo", NewExecuteViewParams("id", "uid", "Asia/Tokyo", 0, "").Timezone())
+	})
+}
@@ -0,0 +1,43 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type ExportTasksParams struct {
+	userID IDParam
+	listID IDParam
+	format int32
+}
+
+// listIDが空の場合は自分の全てのタスクを出力する
+func NewExportTasksParams(userID string, listID string, format int32) *ExportTasksParams {
+	return &ExportTasksParams{
+		userID: *NewIDParam(userID),
+		listID: *NewIDParam(listID),
+		format: format,
+	}
+}
+
+func (f *ExportTasksParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *ExportTasksParams) ListID() string {
+	return f.listID.Value()
+}
+
+func (f *ExportTasksParams) Format() int32 {
+	return f.format
+}
+
+func (f *ExportTasksParams) Validate() error {
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if err := f.listID.Validate(); err != nil {
+		return err
+	}
+	if f.format < 0 || f.format > 3 {
+		return &app.ErrInputValidationFailed{Msg: "invalid file format"}
+	}
+	return nil
+}
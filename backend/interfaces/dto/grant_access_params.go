@@ -0,0 +1,43 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type GrantAccessParams struct {
+	target SharingTargetParams
+	email  string
+	role   int32
+}
+
+func NewGrantAccessParams(taskID string, listID string, userID string, email string, role int32) *GrantAccessParams {
+	return &GrantAccessParams{
+		target: *NewSharingTargetParams(taskID, listID, userID),
+		email:  email,
+		role:   role,
+	}
+}
+
+func (f *GrantAccessParams) Target() *SharingTargetParams {
+	return &f.target
+}
+
+func (f *GrantAccessParams) Email() string {
+	return f.email
+}
+
+func (f *GrantAccessParams) Role() int32 {
+	return f.role
+}
+
+func (f *GrantAccessParams) Validate() error {
+	if err := f.target.Validate(); err != nil {
+		return err
+	}
+	if len([]rune(f.email)) > 100 {
+		return &app.ErrInputValidationFailed{Msg: "email must be 100 characters or less"}
+	}
+	// 1(閲覧者)から3(管理者)までの3種類
+	if f.role < 1 || f.role > 3 {
+		return &app.ErrInputValidationFailed{Msg: "invalid role"}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrantAccessParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *GrantAccessParams
+		err   error
+	}{
+		{"正常系: 閲覧者の場合", NewGrantAccessParams("tid", "", "uid", "a@example.com", 1), nil},
+		{"正常系: 管理者の場合", NewGrantAccessParams("", "lid", "uid", "a@example.com", 3), nil},
+		{"準正常系: 対象を指定しない場合", NewGrantAccessParams("", "", "uid", "a@example.com", 1), errors.New("either task_id or list_id is required")},
+		{"準正常系: メールアドレスが100文字を超える場合", NewGrantAccessParams("tid", "", "uid", strings.Repeat("a", 89)+"@example.com", 1), errors.New("email must be 100 characters or less")},
+		{"準正常系: 権限が未指定の場合", NewGrantAccessParams("tid", "", "uid", "a@example.com", 0), errors.New("invalid role")},
+		{"準正常系: 権限が範囲外の場合", NewGrantAccessParams("tid", "", "uid", "a@example.com", 4), errors.New("invalid role")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type ImportTasksParams struct {
+	userID IDParam
+	listID IDParam
+	format int32
+	dryRun bool
+}
+
+// listIDが空の場合はInboxにインポートする
+func NewImportTasksParams(userID string, listID string, format int32, dryRun bool) *ImportTasksParams {
+	return &ImportTasksParams{
+		userID: *NewIDParam(userID),
+		listID: *NewIDParam(listID),
+		format: format,
+		dryRun: dryRun,
+	}
+}
+
+func (f *ImportTasksParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *ImportTasksParams) ListID() string {
+	return f.listID.Value()
+}
+
+func (f *ImportTasksParams) Format() int32 {
+	return f.format
+}
+
+func (f *ImportTasksParams) DryRun() bool {
+	return f.dryRun
+}
+
+func (f *ImportTasksParams) Validate() error {
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if err := f.listID.Validate(); err != nil {
+		return err
+	}
+	if f.format < 0 || f.format > 3 {
+		return &app.ErrInputValidationFailed{Msg: "invalid file format"}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportTasksParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *ImportTasksParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewImportTasksParams("uid", "lid", 0, false), nil},
+		{"正常系: リストを指定しない場合", NewImportTasksParams("uid", "", 3, false), nil},
+		{"準正常系: UserIDが半角50文字を超える場合", NewImportTasksParams(strings.Repeat("*", 51), "lid", 0, false), errors.New("id must be 50 characters or less")},
+		{"準正常系: ListIDが半角50文字を超える場合", NewImportTasksParams("uid", strings.Repeat("*", 51), 0, false), errors.New("id must be 50 characters or less")},
+		{"準正常系: 形式が範囲外の場合", NewImportTasksParams("uid", "lid", 4, false), errors.New("invalid file format")},
+		{"準正常系: 形式が負の値の場合", NewImportTasksParams("uid", "lid", -1, false), errors.New("invalid file format")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
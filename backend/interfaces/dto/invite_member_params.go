@@ -0,0 +1,52 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type InviteMemberParams struct {
+	workspaceID IDParam
+	userID      IDParam
+	email       string
+	role        int32
+}
+
+func NewInviteMemberParams(workspaceID string, userID string, email string, role int32) *InviteMemberParams {
+	return &InviteMemberParams{
+		workspaceID: *NewIDParam(workspaceID),
+		userID:      *NewIDParam(userID),
+		email:       email,
+		role:        role,
+	}
+}
+
+func (f *InviteMemberParams) WorkspaceID() string {
+	return f.workspaceID.Value()
+}
+
+func (f *InviteMemberParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *InviteMemberParams) Email() string {
+	return f.email
+}
+
+func (f *InviteMemberParams) Role() int32 {
+	return f.role
+}
+
+func (f *InviteMemberParams) Validate() error {
+	if err := f.workspaceID.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if len([]rune(f.email)) > 100 {
+		return &app.ErrInputValidationFailed{Msg: "email must be 100 characters or less"}
+	}
+	// 1(メンバー)と2(管理者)の2種類
+	if f.role < 1 || f.role > 2 {
+		return &app.ErrInputValidationFailed{Msg: "invalid role"}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInviteMemberParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *InviteMemberParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewInviteMemberParams("wid", "uid", "dev@example.com", 1), nil},
+		{"準正常系: WorkspaceIDが50文字を超える場合", NewInviteMemberParams(strings.Repeat("*", 51), "uid", "dev@example.com", 1), errors.New("id must be 50 characters or less")},
+		{"準正常系: Emailが100文字を超える場合", NewInviteMemberParams("wid", "uid", strings.Repeat("a", 101), 1), errors.New("email must be 100 characters or less")},
+		{"準正常系: 権限が未指定の場合", NewInviteMemberParams("wid", "uid", "dev@example.com", 0), errors.New("invalid role")},
+		{"準正常系: 所有者を指定した場合", NewInviteMemberParams("wid", "uid", "dev@example.com", 3), errors.New("invalid role")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
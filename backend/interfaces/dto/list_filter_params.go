@@ -0,0 +1,50 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type ListFilterParams struct {
+	listID IDParam
+	userID IDParam
+	order  int32
+}
+
+func NewListFilterParams(listID string, userID string, order int32) *ListFilterParams {
+	return &ListFilterParams{
+		listID: *NewIDParam(listID),
+		userID: *NewIDParam(userID),
+		order:  order,
+	}
+}
+
+func (f *ListFilterParams) ListID() string {
+	return f.listID.Value()
+}
+
+func (f *ListFilterParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *ListFilterParams) Order() int32 {
+	return f.order
+}
+
+func (f *ListFilterParams) Validate() error {
+	if err := f.listID.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if err := validateOrder(f.order); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 0(更新日時)、1(優先度)、2(手動の並び順)のいずれか
+func validateOrder(order int32) error {
+	if order < 0 || order > 2 {
+		return &app.ErrInputValidationFailed{Msg: "invalid order"}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package dto
+
+type MoveSubtaskParams struct {
+	id       IDParam
+	userID   IDParam
+	parentID IDParam
+}
+
+// parentIDが空の場合はルートのタスクに戻す
+func NewMoveSubtaskParams(id string, userID string, parentID string) *MoveSubtaskParams {
+	return &MoveSubtaskParams{
+		id:       *NewIDParam(id),
+		userID:   *NewIDParam(userID),
+		parentID: *NewIDParam(parentID),
+	}
+}
+
+func (f *MoveSubtaskParams) ID() string {
+	return f.id.Value()
+}
+
+func (f *MoveSubtaskParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *MoveSubtaskParams) ParentID() string {
+	return f.parentID.Value()
+}
+
+func (f *MoveSubtaskParams) Validate() error {
+	if err := f.id.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if err := f.parentID.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
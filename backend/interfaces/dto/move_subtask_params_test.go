@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveSubtaskParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *MoveSubtaskParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewMoveSubtaskParams("id", "uid", "pid"), nil},
+		{"正常系: ParentIDが空の場合", NewMoveSubtaskParams("id", "uid", ""), nil},
+		{"準正常系: TaskIDが50文字を超える場合", NewMoveSubtaskParams(strings.Repeat("*", 51), "uid", "pid"), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが50文字を超える場合", NewMoveSubtaskParams("id", strings.Repeat("*", 51), "pid"), errors.New("id must be 50 characters or less")},
+		{"準正常系: ParentIDが50文字を超える場合", NewMoveSubtaskParams("id", "uid", strings.Repeat("*", 51)), errors.New("id must be 50 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
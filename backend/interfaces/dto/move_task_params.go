@@ -0,0 +1,47 @@
+package dto
+
+type MoveTaskParams struct {
+	id       IDParam
+	userID   IDParam
+	targetID IDParam
+	after    bool
+}
+
+// afterがtrueの場合は対象のタスクの後ろ、falseの場合は前に移動する
+func NewMoveTaskParams(id string, userID string, targetID string, after bool) *MoveTaskParams {
+	return &MoveTaskParams{
+		id:       *NewIDParam(id),
+		userID:   *NewIDParam(userID),
+		targetID: *NewIDParam(targetID),
+		after:    after,
+	}
+}
+
+func (f *MoveTaskParams) ID() string {
+	return f.id.Value()
+}
+
+func (f *MoveTaskParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *MoveTaskParams) TargetID() string {
+	return f.targetID.Value()
+}
+
+func (f *MoveTaskParams) After() bool {
+	return f.after
+}
+
+func (f *MoveTaskParams) Validate() error {
+	if err := f.id.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if err := f.targetID.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveTaskParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *MoveTaskParams
+		err   error
+	}{
+		{"正常系: 前に移動する場合", NewMoveTaskParams("id", "uid", "tid", false), nil},
+		{"正常系: 後ろに移動する場合", NewMoveTaskParams("id", "uid", "tid", true), nil},
+		{"準正常系: TaskIDが50文字を超える場合", NewMoveTaskParams(strings.Repeat("*", 51), "uid", "tid", false), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが50文字を超える場合", NewMoveTaskParams("id", strings.Repeat("*", 51), "tid", false), errors.New("id must be 50 characters or less")},
+		{"準正常系: TargetIDが50文字を超える場合", NewMoveTaskParams("id", "uid", strings.Repeat("*", 51), false), errors.New("id must be 50 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
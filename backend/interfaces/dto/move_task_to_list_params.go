@@ -0,0 +1,40 @@
+package dto
+
+type MoveTaskToListParams struct {
+	id     IDParam
+	userID IDParam
+	listID IDParam
+}
+
+func NewMoveTaskToListParams(id string, userID string, listID string) *MoveTaskToListParams {
+	return &MoveTaskToListParams{
+		id:     *NewIDParam(id),
+		userID: *NewIDParam(userID),
+		listID: *NewIDParam(listID),
+	}
+}
+
+func (f *MoveTaskToListParams) ID() string {
+	return f.id.Value()
+}
+
+func (f *MoveTaskToListParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *MoveTaskToListParams) ListID() string {
+	return f.listID.Value()
+}
+
+func (f *MoveTaskToListParams) Validate() error {
+	if err := f.id.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if err := f.listID.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
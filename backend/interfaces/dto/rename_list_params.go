@@ -0,0 +1,42 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type RenameListParams struct {
+	id     IDParam
+	userID IDParam
+	name   string
+}
+
+func NewRenameListParams(id string, userID string, name string) *RenameListParams {
+	return &RenameListParams{
+		id:     *NewIDParam(id),
+		userID: *NewIDParam(userID),
+		name:   name,
+	}
+}
+
+func (f *RenameListParams) ID() string {
+	return f.id.Value()
+}
+
+func (f *RenameListParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *RenameListParams) Name() string {
+	return f.name
+}
+
+func (f *RenameListParams) Validate() error {
+	if err := f.id.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if len([]rune(f.name)) > 100 {
+		return &app.ErrInputValidationFailed{Msg: "name must be 100 characters or less"}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameListParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *RenameListParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewRenameListParams("id", "uid", "work"), nil},
+		{"準正常系: ListIDが50文字を超える場合", NewRenameListParams(strings.Repeat("*", 51), "uid", "work"), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが50文字を超える場合", NewRenameListParams("id", strings.Repeat("*", 51), "work"), errors.New("id must be 50 characters or less")},
+		{"準正常系: Nameが100文字を超える場合", NewRenameListParams("id", "uid", strings.Repeat("*", 101)), errors.New("name must be 100 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
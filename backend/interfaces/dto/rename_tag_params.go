@@ -0,0 +1,42 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type RenameTagParams struct {
+	id     IDParam
+	userID IDParam
+	name   string
+}
+
+func NewRenameTagParams(id string, userID string, name string) *RenameTagParams {
+	return &RenameTagParams{
+		id:     *NewIDParam(id),
+		userID: *NewIDParam(userID),
+		name:   name,
+	}
+}
+
+func (f *RenameTagParams) ID() string {
+	return f.id.Value()
+}
+
+func (f *RenameTagParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *RenameTagParams) Name() string {
+	return f.name
+}
+
+func (f *RenameTagParams) Validate() error {
+	if err := f.id.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if len([]rune(f.name)) > 50 {
+		return &app.ErrInputValidationFailed{Msg: "name must be 50 characters or less"}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameTagParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *RenameTagParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewRenameTagParams("id", "uid", "work"), nil},
+		{"準正常系: TagIDが50文字を超える場合", NewRenameTagParams(strings.Repeat("*", 51), "uid", "work"), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが50文字を超える場合", NewRenameTagParams("id", strings.Repeat("*", 51), "work"), errors.New("id must be 50 characters or less")},
+		{"準正常系: Nameが50文字を超える場合", NewRenameTagParams("id", "uid", strings.Repeat("あ", 51)), errors.New("name must be 50 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
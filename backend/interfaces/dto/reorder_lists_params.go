@@ -0,0 +1,46 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type ReorderListsParams struct {
+	userID  IDParam
+	listIDs []IDParam
+}
+
+func NewReorderListsParams(userID string, listIDs []string) *ReorderListsParams {
+	ids := make([]IDParam, len(listIDs))
+	for i, v := range listIDs {
+		ids[i] = *NewIDParam(v)
+	}
+	return &ReorderListsParams{
+		userID:  *NewIDParam(userID),
+		listIDs: ids,
+	}
+}
+
+func (f *ReorderListsParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *ReorderListsParams) ListIDs() []string {
+	ids := make([]string, len(f.listIDs))
+	for i, v := range f.listIDs {
+		ids[i] = v.Value()
+	}
+	return ids
+}
+
+func (f *ReorderListsParams) Validate() error {
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if len(f.listIDs) > 100 {
+		return &app.ErrInputValidationFailed{Msg: "list_ids must be 100 or less"}
+	}
+	for _, v := range f.listIDs {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
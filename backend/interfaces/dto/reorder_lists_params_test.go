@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReorderListsParams_Validate(tt *testing.T) {
+	tooMany := make([]string, 101)
+	for i := range tooMany {
+		tooMany[i] = "list"
+	}
+	testcases := []struct {
+		title string
+		arg   *ReorderListsParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewReorderListsParams("uid", []string{"l1", "l2"}), nil},
+		{"準正常系: UserIDが50文字を超える場合", NewReorderListsParams(strings.Repeat("*", 51), []string{"l1"}), errors.New("id must be 50 characters or less")},
+		{"準正常系: ListIDが50文字を超える場合", NewReorderListsParams("uid", []string{"l1", strings.Repeat("*", 51)}), errors.New("id must be 50 characters or less")},
+		{"準正常系: ListIDが100個を超える場合", NewReorderListsParams("uid", tooMany), errors.New("list_ids must be 100 or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package dto
+
+type RevokeAccessParams struct {
+	target         SharingTargetParams
+	collaboratorID IDParam
+}
+
+func NewRevokeAccessParams(taskID string, listID string, userID string, collaboratorID string) *RevokeAccessParams {
+	return &RevokeAccessParams{
+		target:         *NewSharingTargetParams(taskID, listID, userID),
+		collaboratorID: *NewIDParam(collaboratorID),
+	}
+}
+
+func (f *RevokeAccessParams) Target() *SharingTargetParams {
+	return &f.target
+}
+
+// 共有を取り消すユーザーのID
+func (f *RevokeAccessParams) CollaboratorID() string {
+	return f.collaboratorID.Value()
+}
+
+func (f *RevokeAccessParams) Validate() error {
+	if err := f.target.Validate(); err != nil {
+		return err
+	}
+	if err := f.collaboratorID.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokeAccessParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *RevokeAccessParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewRevokeAccessParams("tid", "", "uid", "cid"), nil},
+		{"準正常系: 対象を指定しない場合", NewRevokeAccessParams("", "", "uid", "cid"), errors.New("either task_id or list_id is required")},
+		{"準正常系: CollaboratorIDが半角50文字を超える場合", NewRevokeAccessParams("", "lid", "uid", strings.Repeat("*", 51)), errors.New("id must be 50 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"strings"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+)
+
+type SearchTasksParams struct {
+	workspaceID IDParam
+	userID      IDParam
+	query       string
+	pageSize    int32
+	pageToken   string
+}
+
+// workspaceIDが空の場合は個人のタスクを検索する
+// pageSizeが0の場合は既定の件数、pageTokenが空の場合は最初のページを取得する
+func NewSearchTasksParams(workspaceID string, userID string, query string, pageSize int32, pageToken string) *SearchTasksParams {
+	return &SearchTasksParams{
+		workspaceID: *NewIDParam(workspaceID),
+		userID:      *NewIDParam(userID),
+		query:       query,
+		pageSize:    pageSize,
+		pageToken:   pageToken,
+	}
+}
+
+func (f *SearchTasksParams) WorkspaceID() string {
+	return f.workspaceID.Value()
+}
+
+func (f *SearchTasksParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *SearchTasksParams) Query() string {
+	return f.query
+}
+
+func (f *SearchTasksParams) PageSize() int32 {
+	if f.pageSize == 0 {
+		return defaultPageSize
+	}
+	return f.pageSize
+}
+
+func (f *SearchTasksParams) PageToken() string {
+	return f.pageToken
+}
+
+func (f *SearchTasksParams) Validate() error {
+	if err := f.workspaceID.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if strings.TrimSpace(f.query) == "" {
+		return &app.ErrInputValidationFailed{Msg: "query is empty"}
+	}
+	if len([]rune(f.query)) > 200 {
+		return &app.ErrInputValidationFailed{Msg: "query must be 200 characters or less"}
+	}
+	if err := validatePageSize(f.pageSize); err != nil {
+		return err
+	}
+	if len(f.pageToken) > 200 {
+		return &app.ErrInputValidationFailed{Msg: "page_token must be 200 characters or less"}
+	}
+	return nil
+}
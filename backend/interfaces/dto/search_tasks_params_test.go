@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchTasksParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *SearchTasksParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewSearchTasksParams("", "uid", "buy milk", 50, "token"), nil},
+		{"正常系: ワークスペースを指定した場合", NewSearchTasksParams("wid", "uid", "buy milk", 0, ""), nil},
+		{"正常系: 検索語が全角200文字の場合", NewSearchTasksParams("", "uid", strings.Repeat("あ", 200), 0, ""), nil},
+		{"準正常系: WorkspaceIDが半角50文字を超える場合", NewSearchTasksParams(strings.Repeat("*", 51), "uid", "buy", 0, ""), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが半角50文字を超える場合", NewSearchTasksParams("", strings.Repeat("*", 51), "buy", 0, ""), errors.New("id must be 50 characters or less")},
+		{"準正常系: 検索語が空の場合", NewSearchTasksParams("", "uid", "", 0, ""), errors.New("query is empty")},
+		{"準正常系: 検索語が空白のみの場合", NewSearchTasksParams("", "uid", " 　", 0, ""), errors.New("query is empty")},
+		{"準正常系: 検索語が200文字を超える場合", NewSearchTasksParams("", "uid", strings.Repeat("あ", 201), 0, ""), errors.New("query must be 200 characters or less")},
+		{"準正常系: ページサイズが負の場合", NewSearchTasksParams("", "uid", "buy", -1, ""), errors.New("page_size must not be negative")},
+		{"準正常系: ページサイズが100を超える場合", NewSearchTasksParams("", "uid", "buy", 101, ""), errors.New("page_size must be 100 or less")},
+		{"準正常系: ページトークンが200文字を超える場合", NewSearchTasksParams("", "uid", "buy", 0, strings.Repeat("a", 201)), errors.New("page_token must be 200 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
+
+func TestSearchTasksParams_PageSize(tt *testing.T) {
+	tt.Run("正常系: 未指定の場合は既定の件数になること", func(t *testing.T) {
+		require.Equal(t, int32(20), NewSearchTasksParams("", "uid", "buy", 0, "").PageSize(), "件数が一致すること")
+	})
+	tt.Run("正常系: 指定した件数になること", func(t *testing.T) {
+		require.Equal(t, int32(5), NewSearchTasksParams("", "uid", "buy", 5, "").PageSize(), "件数が一致すること")
+	})
+}
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+)
+
+type SetTaskDueDateParams struct {
+	id     IDParam
+	userID IDParam
+	dueAt  time.Time
+}
+
+func NewSetTaskDueDateParams(id string, userID string, dueAt time.Time) *SetTaskDueDateParams {
+	return &SetTaskDueDateParams{
+		id:     *NewIDParam(id),
+		userID: *NewIDParam(userID),
+		dueAt:  dueAt,
+	}
+}
+
+func (f *SetTaskDueDateParams) ID() string {
+	return f.id.Value()
+}
+
+func (f *SetTaskDueDateParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *SetTaskDueDateParams) DueAt() time.Time {
+	return f.dueAt
+}
+
+func (f *SetTaskDueDateParams) Validate() error {
+	if err := f.id.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if f.dueAt.IsZero() {
+		return &app.ErrInputValidationFailed{Msg: "due_at is empty"}
+	}
+	return nil
+}
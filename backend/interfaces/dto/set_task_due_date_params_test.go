@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetTaskDueDateParams_Validate(tt *testing.T) {
+	due := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	testcases := []struct {
+		title string
+		arg   *SetTaskDueDateParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewSetTaskDueDateParams("id", "uid", due), nil},
+		{"準正常系: IDが半角50文字を超える場合", NewSetTaskDueDateParams(strings.Repeat("*", 51), "uid", due), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが半角50文字を超える場合", NewSetTaskDueDateParams("id", strings.Repeat("*", 51), due), errors.New("id must be 50 characters or less")},
+		{"準正常系: 期限が空の場合", NewSetTaskDueDateParams("id", "uid", time.Time{}), errors.New("due_at is empty")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
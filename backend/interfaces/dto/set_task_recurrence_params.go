@@ -0,0 +1,58 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type SetTaskRecurrenceParams struct {
+	id       IDParam
+	userID   IDParam
+	rule     string
+	timezone string
+}
+
+// timezoneが空の場合はUTCとして扱う
+func NewSetTaskRecurrenceParams(id string, userID string, rule string, timezone string) *SetTaskRecurrenceParams {
+	return &SetTaskRecurrenceParams{
+		id:       *NewIDParam(id),
+		userID:   *NewIDParam(userID),
+		rule:     rule,
+		timezone: timezone,
+	}
+}
+
+func (f *SetTaskRecurrenceParams) ID() string {
+	return f.id.Value()
+}
+
+func (f *SetTaskRecurrenceParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *SetTaskRecurrenceParams) Rule() string {
+	return f.rule
+}
+
+func (f *SetTaskRecurrenceParams) Timezone() string {
+	if f.timezone == "" {
+		return "UTC"
+	}
+	return f.timezone
+}
+
+func (f *SetTaskRecurrenceParams) Validate() error {
+	if err := f.id.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if f.rule == "" {
+		return &app.ErrInputValidationFailed{Msg: "rule is empty"}
+	}
+	if len(f.rule) > 255 {
+		return &app.ErrInputValidationFailed{Msg: "rule must be 255 characters or less"}
+	}
+	if len(f.timezone) > 64 {
+		return &app.ErrInputValidationFailed{Msg: "timezone must be 64 characters or less"}
+	}
+	return nil
+}
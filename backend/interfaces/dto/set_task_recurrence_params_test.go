@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetTaskRecurrenceParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *SetTaskRecurrenceParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewSetTaskRecurrenceParams("id", "uid", "FREQ=DAILY", "Asia/Tokyo"), nil},
+		{"正常系: タイムゾーンが空の場合", NewSetTaskRecurrenceParams("id", "uid", "FREQ=DAILY", ""), nil},
+		{"準正常系: IDが50文字を超える場合", NewSetTaskRecurrenceParams(strings.Repeat("*", 51), "uid", "FREQ=DAILY", "UTC"), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが50文字を超える場合", NewSetTaskRecurrenceParams("id", strings.Repeat("*", 51), "FREQ=DAILY", "UTC"), errors.New("id must be 50 characters or less")},
+		{"準正常系: ルールが空の場合", NewSetTaskRecurrenceParams("id", "uid", "", "UTC"), errors.New("rule is empty")},
+		{"準正常系: ルールが255文字を超える場合", NewSetTaskRecurrenceParams("id", "uid", strings.Repeat("*", 256), "UTC"), errors.New("rule must be 255 characters or less")},
+		{"準正常系: タイムゾーンが64文字を超える場合", NewSetTaskRecurrenceParams("id", "uid", "FREQ=DAILY", strings.Repeat("*", 65)), errors.New("timezone must be 64 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
+
+func TestSetTaskRecurrenceParams_Timezone(tt *testing.T) {
+	tt.Run("正常系: 指定した場合はそのまま返すこと", func(t *testing.T) {
+		require.Equal(t, "Asia/Tokyo", NewSetTaskRecurrenceParams("id", "uid", "FREQ=DAILY", "Asia/Tokyo").Timezone())
+	})
+	tt.Run("正常系: 空の場合はUTCを返すこと", func(t *testing.T) {
+		require.Equal(t, "UTC", NewSetTaskRecurrenceParams("id", "uid", "FREQ=DAILY", "").Timezone())
+	})
+}
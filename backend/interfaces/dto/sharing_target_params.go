@@ -0,0 +1,46 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+// 共有の対象となるタスクまたはリスト。どちらか一方のみを指定する
+type SharingTargetParams struct {
+	taskID IDParam
+	listID IDParam
+	userID IDParam
+}
+
+func NewSharingTargetParams(taskID string, listID string, userID string) *SharingTargetParams {
+	return &SharingTargetParams{
+		taskID: *NewIDParam(taskID),
+		listID: *NewIDParam(listID),
+		userID: *NewIDParam(userID),
+	}
+}
+
+func (f *SharingTargetParams) TaskID() string {
+	return f.taskID.Value()
+}
+
+func (f *SharingTargetParams) ListID() string {
+	return f.listID.Value()
+}
+
+func (f *SharingTargetParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *SharingTargetParams) Validate() error {
+	if (f.taskID.Value() == "") == (f.listID.Value() == "") {
+		return &app.ErrInputValidationFailed{Msg: "either task_id or list_id is required"}
+	}
+	if err := f.taskID.Validate(); err != nil {
+		return err
+	}
+	if err := f.listID.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharingTargetParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *SharingTargetParams
+		err   error
+	}{
+		{"正常系: タスクを指定した場合", NewSharingTargetParams("tid", "", "uid"), nil},
+		{"正常系: リストを指定した場合", NewSharingTargetParams("", "lid", "uid"), nil},
+		{"準正常系: どちらも指定しない場合", NewSharingTargetParams("", "", "uid"), errors.New("either task_id or list_id is required")},
+		{"準正常系: 両方を指定した場合", NewSharingTargetParams("tid", "lid", "uid"), errors.New("either task_id or list_id is required")},
+		{"準正常系: TaskIDが半角50文字を超える場合", NewSharingTargetParams(strings.Repeat("*", 51), "", "uid"), errors.New("id must be 50 characters or less")},
+		{"準正常系: ListIDが半角50文字を超える場合", NewSharingTargetParams("", strings.Repeat("*", 51), "uid"), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが半角50文字を超える場合", NewSharingTargetParams("tid", "", strings.Repeat("*", 51)), errors.New("id must be 50 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type StartTimerParams struct {
+	taskID IDParam
+	userID IDParam
+	note   string
+}
+
+func NewStartTimerParams(taskID string, userID string, note string) *StartTimerParams {
+	return &StartTimerParams{
+		taskID: *NewIDParam(taskID),
+		userID: *NewIDParam(userID),
+		note:   note,
+	}
+}
+
+func (f *StartTimerParams) TaskID() string {
+	return f.taskID.Value()
+}
+
+func (f *StartTimerParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *StartTimerParams) Note() string {
+	return f.note
+}
+
+func (f *StartTimerParams) Validate() error {
+	if err := f.taskID.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if err := validateTimeEntryNote(f.note); err != nil {
+		return err
+	}
+	return nil
+}
+
+func validateTimeEntryNote(note string) error {
+	if len([]rune(note)) > 255 {
+		return &app.ErrInputValidationFailed{Msg: "note must be 255 characters or less"}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartTimerParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *StartTimerParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewStartTimerParams("tid", "uid", "note"), nil},
+		{"正常系: メモが255文字の場合", NewStartTimerParams("tid", "uid", strings.Repeat("あ", 255)), nil},
+		{"準正常系: TaskIDが50文字を超える場合", NewStartTimerParams(strings.Repeat("*", 51), "uid", ""), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが50文字を超える場合", NewStartTimerParams("tid", strings.Repeat("*", 51), ""), errors.New("id must be 50 characters or less")},
+		{"準正常系: メモが255文字を超える場合", NewStartTimerParams("tid", "uid", strings.Repeat("あ", 256)), errors.New("note must be 255 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type TagFilterParams struct {
+	userID   IDParam
+	listID   IDParam
+	tagIDs   []IDParam
+	matchAll bool
+	order    int32
+}
+
+// listIDが空の場合は全てのリストから取得する
+func NewTagFilterParams(userID string, listID string, tagIDs []string, matchAll bool, order int32) *TagFilterParams {
+	ids := make([]IDParam, len(tagIDs))
+	for i, v := range tagIDs {
+		ids[i] = *NewIDParam(v)
+	}
+	return &TagFilterParams{
+		userID:   *NewIDParam(userID),
+		listID:   *NewIDParam(listID),
+		tagIDs:   ids,
+		matchAll: matchAll,
+		order:    order,
+	}
+}
+
+func (f *TagFilterParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *TagFilterParams) ListID() string {
+	return f.listID.Value()
+}
+
+func (f *TagFilterParams) TagIDs() []string {
+	ids := make([]string, len(f.tagIDs))
+	for i, v := range f.tagIDs {
+		ids[i] = v.Value()
+	}
+	return ids
+}
+
+func (f *TagFilterParams) MatchAll() bool {
+	return f.matchAll
+}
+
+func (f *TagFilterParams) Order() int32 {
+	return f.order
+}
+
+func (f *TagFilterParams) Validate() error {
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if err := f.listID.Validate(); err != nil {
+		return err
+	}
+	if len(f.tagIDs) > 20 {
+		return &app.ErrInputValidationFailed{Msg: "tag_ids must be 20 or less"}
+	}
+	for _, v := range f.tagIDs {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := validateOrder(f.order); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagFilterParams_Validate(tt *testing.T) {
+	tooMany := make([]string, 21)
+	for i := range tooMany {
+		tooMany[i] = "tag"
+	}
+	testcases := []struct {
+		title string
+		arg   *TagFilterParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewTagFilterParams("uid", "", []string{"g1", "g2"}, true, 0), nil},
+		{"正常系: ListIDを指定した場合", NewTagFilterParams("uid", "lid", []string{"g1"}, false, 1), nil},
+		{"準正常系: UserIDが50文字を超える場合", NewTagFilterParams(strings.Repeat("*", 51), "", []string{"g1"}, false, 0), errors.New("id must be 50 characters or less")},
+		{"準正常系: ListIDが50文字を超える場合", NewTagFilterParams("uid", strings.Repeat("*", 51), []string{"g1"}, false, 0), errors.New("id must be 50 characters or less")},
+		{"準正常系: TagIDが50文字を超える場合", NewTagFilterParams("uid", "", []string{"g1", strings.Repeat("*", 51)}, false, 0), errors.New("id must be 50 characters or less")},
+		{"正常系: 手動の並び順を指定した場合", NewTagFilterParams("uid", "", []string{"g1"}, false, 2), nil},
+		{"準正常系: 並び順が範囲外の場合", NewTagFilterParams("uid", "", []string{"g1"}, false, 3), errors.New("invalid order")},
+		{"準正常系: TagIDが20個を超える場合", NewTagFilterParams("uid", "", tooMany, false, 0), errors.New("tag_ids must be 20 or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
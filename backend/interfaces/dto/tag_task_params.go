@@ -0,0 +1,40 @@
+package dto
+
+type TagTaskParams struct {
+	taskID IDParam
+	tagID  IDParam
+	userID IDParam
+}
+
+func NewTagTaskParams(taskID string, tagID string, userID string) *TagTaskParams {
+	return &TagTaskParams{
+		taskID: *NewIDParam(taskID),
+		tagID:  *NewIDParam(tagID),
+		userID: *NewIDParam(userID),
+	}
+}
+
+func (f *TagTaskParams) TaskID() string {
+	return f.taskID.Value()
+}
+
+func (f *TagTaskParams) TagID() string {
+	return f.tagID.Value()
+}
+
+func (f *TagTaskParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *TagTaskParams) Validate() error {
+	if err := f.taskID.Validate(); err != nil {
+		return err
+	}
+	if err := f.tagID.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagTaskParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *TagTaskParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewTagTaskParams("tid", "gid", "uid"), nil},
+		{"準正常系: TaskIDが50文字を超える場合", NewTagTaskParams(strings.Repeat("*", 51), "gid", "uid"), errors.New("id must be 50 characters or less")},
+		{"準正常系: TagIDが50文字を超える場合", NewTagTaskParams("tid", strings.Repeat("*", 51), "uid"), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが50文字を超える場合", NewTagTaskParams("tid", "gid", strings.Repeat("*", 51)), errors.New("id must be 50 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package dto
+
+type TaskDependencyParams struct {
+	taskID    IDParam
+	userID    IDParam
+	blockerID IDParam
+}
+
+func NewTaskDependencyParams(taskID string, userID string, blockerID string) *TaskDependencyParams {
+	return &TaskDependencyParams{
+		taskID:    *NewIDParam(taskID),
+		userID:    *NewIDParam(userID),
+		blockerID: *NewIDParam(blockerID),
+	}
+}
+
+func (f *TaskDependencyParams) TaskID() string {
+	return f.taskID.Value()
+}
+
+func (f *TaskDependencyParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *TaskDependencyParams) BlockerID() string {
+	return f.blockerID.Value()
+}
+
+func (f *TaskDependencyParams) Validate() error {
+	if err := f.taskID.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if err := f.blockerID.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
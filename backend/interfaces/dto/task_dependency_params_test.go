@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskDependencyParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *TaskDependencyParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewTaskDependencyParams("tid", "uid", "bid"), nil},
+		{"準正常系: TaskIDが50文字を超える場合", NewTaskDependencyParams(strings.Repeat("*", 51), "uid", "bid"), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが50文字を超える場合", NewTaskDependencyParams("tid", strings.Repeat("*", 51), "bid"), errors.New("id must be 50 characters or less")},
+		{"準正常系: BlockerIDが50文字を超える場合", NewTaskDependencyParams("tid", "uid", strings.Repeat("*", 51)), errors.New("id must be 50 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
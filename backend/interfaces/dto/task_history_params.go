@@ -0,0 +1,71 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+// ページサイズが未指定の場合の件数
+const defaultPageSize = 20
+
+// 1ページで取得できる最大の件数
+const maxPageSize = 100
+
+type TaskHistoryParams struct {
+	id        IDParam
+	userID    IDParam
+	pageSize  int32
+	pageToken string
+}
+
+// pageSizeが0の場合は既定の件数、pageTokenが空の場合は最初のページを取得する
+func NewTaskHistoryParams(id string, userID string, pageSize int32, pageToken string) *TaskHistoryParams {
+	return &TaskHistoryParams{
+		id:        *NewIDParam(id),
+		userID:    *NewIDParam(userID),
+		pageSize:  pageSize,
+		pageToken: pageToken,
+	}
+}
+
+func (f *TaskHistoryParams) ID() string {
+	return f.id.Value()
+}
+
+func (f *TaskHistoryParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *TaskHistoryParams) PageSize() int32 {
+	if f.pageSize == 0 {
+		return defaultPageSize
+	}
+	return f.pageSize
+}
+
+func (f *TaskHistoryParams) PageToken() string {
+	return f.pageToken
+}
+
+func (f *TaskHistoryParams) Validate() error {
+	if err := f.id.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if err := validatePageSize(f.pageSize); err != nil {
+		return err
+	}
+	if len(f.pageToken) > 200 {
+		return &app.ErrInputValidationFailed{Msg: "page_token must be 200 characters or less"}
+	}
+	return nil
+}
+
+func validatePageSize(pageSize int32) error {
+	if pageSize < 0 {
+		return &app.ErrInputValidationFailed{Msg: "page_size must not be negative"}
+	}
+	if pageSize > maxPageSize {
+		return &app.ErrInputValidationFailed{Msg: "page_size must be 100 or less"}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskHistoryParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *TaskHistoryParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewTaskHistoryParams("id", "uid", 50, "token"), nil},
+		{"正常系: ページサイズが未指定の場合", NewTaskHistoryParams("id", "uid", 0, ""), nil},
+		{"正常系: ページサイズが100の場合", NewTaskHistoryParams("id", "uid", 100, ""), nil},
+		{"準正常系: IDが半角50文字を超える場合", NewTaskHistoryParams(strings.Repeat("*", 51), "uid", 20, ""), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが半角50文字を超える場合", NewTaskHistoryParams("id", strings.Repeat("*", 51), 20, ""), errors.New("id must be 50 characters or less")},
+		{"準正常系: ページサイズが負の場合", NewTaskHistoryParams("id", "uid", -1, ""), errors.New("page_size must not be negative")},
+		{"準正常系: ページサイズが100を超える場合", NewTaskHistoryParams("id", "uid", 101, ""), errors.New("page_size must be 100 or less")},
+		{"準正常系: ページトークンが200文字を超える場合", NewTaskHistoryParams("id", "uid", 20, strings.Repeat("a", 201)), errors.New("page_token must be 200 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
+
+func TestTaskHistoryParams_PageSize(tt *testing.T) {
+	tt.Run("正常系: 未指定の場合は既定の件数になること", func(t *testing.T) {
+		require.Equal(t, int32(20), NewTaskHistoryParams("id", "uid", 0, "").PageSize(), "件数が一致すること")
+	})
+	tt.Run("正常系: 指定した件数になること", func(t *testing.T) {
+		require.Equal(t, int32(5), NewTaskHistoryParams("id", "uid", 5, "").PageSize(), "件数が一致すること")
+	})
+}
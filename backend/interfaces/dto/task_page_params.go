@@ -0,0 +1,116 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+)
+
+type TaskPageParams struct {
+	userID       IDParam
+	completion   int32
+	createdFrom  time.Time
+	createdTo    time.Time
+	updatedFrom  time.Time
+	updatedTo    time.Time
+	nameContains string
+	sortField    int32
+	descending   bool
+	pageSize     int32
+	pageToken    string
+}
+
+// 期間はゼロ値の場合、nameContainsは空の場合に絞り込まない
+// pageSizeが0の場合は既定の件数、pageTokenが空の場合は最初のページを取得する
+func NewTaskPageParams(userID string, completion int32, createdFrom time.Time, createdTo time.Time, updatedFrom time.Time, updatedTo time.Time, nameContains string, sortField int32, descending bool, pageSize int32, pageToken string) *TaskPageParams {
+	return &TaskPageParams{
+		userID:       *NewIDParam(userID),
+		completion:   completion,
+		createdFrom:  createdFrom,
+		createdTo:    createdTo,
+		updatedFrom:  updatedFrom,
+		updatedTo:    updatedTo,
+		nameContains: nameContains,
+		sortField:    sortField,
+		descending:   descending,
+		pageSize:     pageSize,
+		pageToken:    pageToken,
+	}
+}
+
+func (f *TaskPageParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *TaskPageParams) Completion() int32 {
+	return f.completion
+}
+
+func (f *TaskPageParams) CreatedFrom() *time.Time {
+	return optionalTime(f.createdFrom)
+}
+
+func (f *TaskPageParams) CreatedTo() *time.Time {
+	return optionalTime(f.createdTo)
+}
+
+func (f *TaskPageParams) UpdatedFrom() *time.Time {
+	return optionalTime(f.updatedFrom)
+}
+
+func (f *TaskPageParams) UpdatedTo() *time.Time {
+	return optionalTime(f.updatedTo)
+}
+
+func (f *TaskPageParams) NameContains() string {
+	return f.nameContains
+}
+
+func (f *TaskPageParams) SortField() int32 {
+	return f.sortField
+}
+
+func (f *TaskPageParams) Descending() bool {
+	return f.descending
+}
+
+func (f *TaskPageParams) PageSize() int32 {
+	if f.pageSize == 0 {
+		return defaultPageSize
+	}
+	return f.pageSize
+}
+
+func (f *TaskPageParams) PageToken() string {
+	return f.pageToken
+}
+
+func (f *TaskPageParams) Validate() error {
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if f.completion < 0 || f.completion > 2 {
+		return &app.ErrInputValidationFailed{Msg: "invalid completion"}
+	}
+	if len([]rune(f.nameContains)) > 100 {
+		return &app.ErrInputValidationFailed{Msg: "name_contains must be 100 characters or less"}
+	}
+	if f.sortField < 0 || f.sortField > 3 {
+		return &app.ErrInputValidationFailed{Msg: "invalid sort field"}
+	}
+	if err := validatePageSize(f.pageSize); err != nil {
+		return err
+	}
+	if len(f.pageToken) > 200 {
+		return &app.ErrInputValidationFailed{Msg: "page_token must be 200 characters or less"}
+	}
+	return nil
+}
+
+// ゼロ値の日時を未指定としてnilに変換する
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskPageParams_Validate(tt *testing.T) {
+	now := time.Now().UTC()
+	var zero time.Time
+	testcases := []struct {
+		title string
+		arg   *TaskPageParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewTaskPageParams("uid", 1, now.Add(-time.Hour), now, now.Add(-time.Hour), now, "milk", 2, true, 50, "token"), nil},
+		{"正常系: 条件を指定しない場合", NewTaskPageParams("uid", 0, zero, zero, zero, zero, "", 0, false, 0, ""), nil},
+		{"正常系: 名前が全角100文字の場合", NewTaskPageParams("uid", 0, zero, zero, zero, zero, strings.Repeat("あ", 100), 0, false, 0, ""), nil},
+		{"準正常系: UserIDが半角50文字を超える場合", NewTaskPageParams(strings.Repeat("*", 51), 0, zero, zero, zero, zero, "", 0, false, 0, ""), errors.New("id must be 50 characters or less")},
+		{"準正常系: 完了状態が範囲外の場合", NewTaskPageParams("uid", 3, zero, zero, zero, zero, "", 0, false, 0, ""), errors.New("invalid completion")},
+		{"準正常系: 名前が100文字を超える場合", NewTaskPageParams("uid", 0, zero, zero, zero, zero, strings.Repeat("あ", 101), 0, false, 0, ""), errors.New("name_contains must be 100 characters or less")},
+		{"準正常系: 並び替えの項目が範囲外の場合", NewTaskPageParams("uid", 0, zero, zero, zero, zero, "", 4, false, 0, ""), errors.New("invalid sort field")},
+		{"準正常系: ページサイズが負の場合", NewTaskPageParams("uid", 0, zero, zero, zero, zero, "", 0, false, -1, ""), errors.New("page_size must not be negative")},
+		{"準正常系: ページサイズが100を超える場合", NewTaskPageParams("uid", 0, zero, zero, zero, zero, "", 0, false, 101, ""), errors.New("page_size must be 100 or less")},
+		{"準正常系: ページトークンが200文字を超える場合", NewTaskPageParams("uid", 0, zero, zero, zero, zero, "", 0, false, 0, strings.Repeat("a", 201)), errors.New("page_token must be 200 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
+
+func TestTaskPageParams_CreatedFrom(tt *testing.T) {
+	tt.Run("正常系: 未指定の場合はnilになること", func(t *testing.T) {
+		require.Nil(t, NewTaskPageParams("uid", 0, time.Time{}, time.Time{}, time.Time{}, time.Time{}, "", 0, false, 0, "").CreatedFrom())
+	})
+	tt.Run("正常系: 指定した日時になること", func(t *testing.T) {
+		now := time.Now().UTC()
+		require.Equal(t, &now, NewTaskPageParams("uid", 0, now, time.Time{}, time.Time{}, time.Time{}, "", 0, false, 0, "").CreatedFrom())
+	})
+}
@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+)
+
+// 作業時間を一度に集計や出力できる期間の上限
+const maxTimeEntryRange = 366 * 24 * time.Hour
+
+type TimeEntryRangeParams struct {
+	userID   IDParam
+	from     time.Time
+	to       time.Time
+	timezone string
+}
+
+// timezoneが空の場合はUTCの暦で日ごとに集計する
+func NewTimeEntryRangeParams(userID string, from time.Time, to time.Time, timezone string) *TimeEntryRangeParams {
+	return &TimeEntryRangeParams{
+		userID:   *NewIDParam(userID),
+		from:     from,
+		to:       to,
+		timezone: timezone,
+	}
+}
+
+func (f *TimeEntryRangeParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *TimeEntryRangeParams) From() time.Time {
+	return f.from
+}
+
+func (f *TimeEntryRangeParams) To() time.Time {
+	return f.to
+}
+
+func (f *TimeEntryRangeParams) Timezone() string {
+	if f.timezone == "" {
+		return "UTC"
+	}
+	return f.timezone
+}
+
+func (f *TimeEntryRangeParams) Validate() error {
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if f.from.IsZero() {
+		return &app.ErrInputValidationFailed{Msg: "from is empty"}
+	}
+	if f.to.IsZero() {
+		return &app.ErrInputValidationFailed{Msg: "to is empty"}
+	}
+	if !f.from.Before(f.to) {
+		return &app.ErrInputValidationFailed{Msg: "from must be before to"}
+	}
+	if f.to.Sub(f.from) > maxTimeEntryRange {
+		return &app.ErrInputValidationFailed{Msg: "range must be 366 days or less"}
+	}
+	if len(f.timezone) > 64 {
+		return &app.ErrInputValidationFailed{Msg: "timezone must be 64 characters or less"}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type TransitionTaskParams struct {
+	id     IDParam
+	userID IDParam
+	status int32
+}
+
+func NewTransitionTaskParams(id string, userID string, status int32) *TransitionTaskParams {
+	return &TransitionTaskParams{
+		id:     *NewIDParam(id),
+		userID: *NewIDParam(userID),
+		status: status,
+	}
+}
+
+func (f *TransitionTaskParams) ID() string {
+	return f.id.Value()
+}
+
+func (f *TransitionTaskParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *TransitionTaskParams) Status() int32 {
+	return f.status
+}
+
+func (f *TransitionTaskParams) Validate() error {
+	if err := f.id.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	// 0(未着手)から4(中止)までの5種類
+	if f.status < 0 || f.status > 4 {
+		return &app.ErrInputValidationFailed{Msg: "invalid status"}
+	}
+	return nil
+}
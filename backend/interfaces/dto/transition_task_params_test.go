@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransitionTaskParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *TransitionTaskParams
+		err   error
+	}{
+		{"正常系: 未着手の場合", NewTransitionTaskParams("id", "uid", 0), nil},
+		{"正常系: 中止の場合", NewTransitionTaskParams("id", "uid", 4), nil},
+		{"準正常系: IDが半角50文字を超える場合", NewTransitionTaskParams(strings.Repeat("*", 51), "uid", 1), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが半角50文字を超える場合", NewTransitionTaskParams("id", strings.Repeat("*", 51), 1), errors.New("id must be 50 characters or less")},
+		{"準正常系: 状態が負の場合", NewTransitionTaskParams("id", "uid", -1), errors.New("invalid status")},
+		{"準正常系: 状態が範囲外の場合", NewTransitionTaskParams("id", "uid", 5), errors.New("invalid status")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
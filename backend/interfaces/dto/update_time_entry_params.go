@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/7oh2020/connect-tasklist/backend/app"
+)
+
+type UpdateTimeEntryParams struct {
+	id        IDParam
+	userID    IDParam
+	startedAt time.Time
+	endedAt   *time.Time
+	note      string
+}
+
+// endedAtがnilの場合は計測中のタイマーのまま変更する
+func NewUpdateTimeEntryParams(id string, userID string, startedAt time.Time, endedAt *time.Time, note string) *UpdateTimeEntryParams {
+	return &UpdateTimeEntryParams{
+		id:        *NewIDParam(id),
+		userID:    *NewIDParam(userID),
+		startedAt: startedAt,
+		endedAt:   endedAt,
+		note:      note,
+	}
+}
+
+func (f *UpdateTimeEntryParams) ID() string {
+	return f.id.Value()
+}
+
+func (f *UpdateTimeEntryParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *UpdateTimeEntryParams) StartedAt() time.Time {
+	return f.startedAt
+}
+
+func (f *UpdateTimeEntryParams) EndedAt() *time.Time {
+	return f.endedAt
+}
+
+func (f *UpdateTimeEntryParams) Note() string {
+	return f.note
+}
+
+func (f *UpdateTimeEntryParams) Validate() error {
+	if err := f.id.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if f.startedAt.IsZero() {
+		return &app.ErrInputValidationFailed{Msg: "started_at is empty"}
+	}
+	if err := validateTimeEntryNote(f.note); err != nil {
+		return err
+	}
+	return nil
+}
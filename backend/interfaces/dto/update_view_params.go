@@ -0,0 +1,48 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type UpdateViewParams struct {
+	id     IDParam
+	userID IDParam
+	name   string
+	query  ViewQueryParams
+}
+
+func NewUpdateViewParams(id string, userID string, name string, query *ViewQueryParams) *UpdateViewParams {
+	return &UpdateViewParams{
+		id:     *NewIDParam(id),
+		userID: *NewIDParam(userID),
+		name:   name,
+		query:  *query,
+	}
+}
+
+func (f *UpdateViewParams) ID() string {
+	return f.id.Value()
+}
+
+func (f *UpdateViewParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *UpdateViewParams) Name() string {
+	return f.name
+}
+
+func (f *UpdateViewParams) Query() *ViewQueryParams {
+	return &f.query
+}
+
+func (f *UpdateViewParams) Validate() error {
+	if err := f.id.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if len([]rune(f.name)) > 50 {
+		return &app.ErrInputValidationFailed{Msg: "name must be 50 characters or less"}
+	}
+	return f.query.Validate()
+}
@@ -0,0 +1,42 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+type UploadAttachmentParams struct {
+	taskID   IDParam
+	userID   IDParam
+	fileName string
+}
+
+func NewUploadAttachmentParams(taskID string, userID string, fileName string) *UploadAttachmentParams {
+	return &UploadAttachmentParams{
+		taskID:   *NewIDParam(taskID),
+		userID:   *NewIDParam(userID),
+		fileName: fileName,
+	}
+}
+
+func (f *UploadAttachmentParams) TaskID() string {
+	return f.taskID.Value()
+}
+
+func (f *UploadAttachmentParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *UploadAttachmentParams) FileName() string {
+	return f.fileName
+}
+
+func (f *UploadAttachmentParams) Validate() error {
+	if err := f.taskID.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if len([]rune(f.fileName)) > 255 {
+		return &app.ErrInputValidationFailed{Msg: "file name must be 255 characters or less"}
+	}
+	return nil
+}
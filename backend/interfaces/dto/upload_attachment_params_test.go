@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadAttachmentParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *UploadAttachmentParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewUploadAttachmentParams("id", "uid", "shot.png"), nil},
+		{"正常系: 全角255文字の場合", NewUploadAttachmentParams("id", "uid", strings.Repeat("あ", 255)), nil},
+		{"準正常系: IDが半角50文字を超える場合", NewUploadAttachmentParams(strings.Repeat("*", 51), "uid", "shot.png"), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが半角50文字を超える場合", NewUploadAttachmentParams("id", strings.Repeat("*", 51), "shot.png"), errors.New("id must be 50 characters or less")},
+		{"準正常系: ファイル名が255文字を超える場合", NewUploadAttachmentParams("id", "uid", strings.Repeat("あ", 256)), errors.New("file name must be 255 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
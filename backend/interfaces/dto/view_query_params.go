@@ -0,0 +1,76 @@
+package dto
+
+import "github.com/7oh2020/connect-tasklist/backend/app"
+
+// 保存済みのビューの条件
+type ViewQueryParams struct {
+	completion    int32
+	duePeriod     int32
+	updatedPeriod int32
+	nameContains  string
+	sortField     int32
+	descending    bool
+	groupBy       int32
+}
+
+func NewViewQueryParams(completion int32, duePeriod int32, updatedPeriod int32, nameContains string, sortField int32, descending bool, groupBy int32) *ViewQueryParams {
+	return &ViewQueryParams{
+		completion:    completion,
+		duePeriod:     duePeriod,
+		updatedPeriod: updatedPeriod,
+		nameContains:  nameContains,
+		sortField:     sortField,
+		descending:    descending,
+		groupBy:       groupBy,
+	}
+}
+
+func (f *ViewQueryParams) Completion() int32 {
+	return f.completion
+}
+
+func (f *ViewQueryParams) DuePeriod() int32 {
+	return f.duePeriod
+}
+
+func (f *ViewQueryParams) UpdatedPeriod() int32 {
+	return f.updatedPeriod
+}
+
+func (f *ViewQueryParams) NameContains() string {
+	return f.nameContains
+}
+
+func (f *ViewQueryParams) SortField() int32 {
+	return f.sortField
+}
+
+func (f *ViewQueryParams) Descending() bool {
+	return f.descending
+}
+
+func (f *ViewQueryParams) GroupBy() int32 {
+	return f.groupBy
+}
+
+func (f *ViewQueryParams) Validate() error {
+	if f.completion < 0 || f.completion > 2 {
+		return &app.ErrInputValidationFailed{Msg: "invalid completion"}
+	}
+	if f.duePeriod < 0 || f.duePeriod > 4 {
+		return &app.ErrInputValidationFailed{Msg: "invalid due period"}
+	}
+	if f.updatedPeriod < 0 || f.updatedPeriod > 3 {
+		return &app.ErrInputValidationFailed{Msg: "invalid updated period"}
+	}
+	if len([]rune(f.nameContains)) > 100 {
+		return &app.ErrInputValidationFailed{Msg: "name_contains must be 100 characters or less"}
+	}
+	if f.sortField < 0 || f.sortField > 3 {
+		return &app.ErrInputValidationFailed{Msg: "invalid sort field"}
+	}
+	if f.groupBy < 0 || f.groupBy > 4 {
+		return &app.ErrInputValidationFailed{Msg: "invalid group by"}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewQueryParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *ViewQueryParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewViewQueryParams(1, 4, 2, "milk", 2, false, 4), nil},
+		{"正常系: 条件を指定しない場合", NewViewQueryParams(0, 0, 0, "", 0, true, 0), nil},
+		{"準正常系: 完了状態が範囲外の場合", NewViewQueryParams(3, 0, 0, "", 0, true, 0), errors.New("invalid completion")},
+		{"準正常系: 期限の期間が範囲外の場合", NewViewQueryParams(0, 5, 0, "", 0, true, 0), errors.New("invalid due period")},
+		{"準正常系: 更新日時の期間が範囲外の場合", NewViewQueryParams(0, 0, 4, "", 0, true, 0), errors.New("invalid updated period")},
+		{"準正常系: 名前が100文字を超える場合", NewViewQueryParams(0, 0, 0, strings.Repeat("あ", 101), 0, true, 0), errors.New("name_contains must be 100 characters or less")},
+		{"準正常系: 並び替えの項目が範囲外の場合", NewViewQueryParams(0, 0, 0, "", 4, true, 0), errors.New("invalid sort field")},
+		{"準正常系: まとめる項目が範囲外の場合", NewViewQueryParams(0, 0, 0, "", 0, true, -1), errors.New("invalid group by")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package dto
+
+type WorkspaceFilterParams struct {
+	workspaceID IDParam
+	userID      IDParam
+	order       int32
+}
+
+func NewWorkspaceFilterParams(workspaceID string, userID string, order int32) *WorkspaceFilterParams {
+	return &WorkspaceFilterParams{
+		workspaceID: *NewIDParam(workspaceID),
+		userID:      *NewIDParam(userID),
+		order:       order,
+	}
+}
+
+func (f *WorkspaceFilterParams) WorkspaceID() string {
+	return f.workspaceID.Value()
+}
+
+func (f *WorkspaceFilterParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *WorkspaceFilterParams) Order() int32 {
+	return f.order
+}
+
+func (f *WorkspaceFilterParams) Validate() error {
+	if err := f.workspaceID.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if err := validateOrder(f.order); err != nil {
+		return err
+	}
+	return nil
+}
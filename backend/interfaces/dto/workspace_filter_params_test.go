@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceFilterParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *WorkspaceFilterParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewWorkspaceFilterParams("wid", "uid", 0), nil},
+		{"正常系: 手動の並び順を指定した場合", NewWorkspaceFilterParams("wid", "uid", 2), nil},
+		{"準正常系: WorkspaceIDが50文字を超える場合", NewWorkspaceFilterParams(strings.Repeat("*", 51), "uid", 0), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが50文字を超える場合", NewWorkspaceFilterParams("wid", strings.Repeat("*", 51), 0), errors.New("id must be 50 characters or less")},
+		{"準正常系: 並び順が範囲外の場合", NewWorkspaceFilterParams("wid", "uid", 3), errors.New("invalid order")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package dto
+
+type WorkspaceMemberParams struct {
+	workspaceID IDParam
+	userID      IDParam
+	memberID    IDParam
+}
+
+func NewWorkspaceMemberParams(workspaceID string, userID string, memberID string) *WorkspaceMemberParams {
+	return &WorkspaceMemberParams{
+		workspaceID: *NewIDParam(workspaceID),
+		userID:      *NewIDParam(userID),
+		memberID:    *NewIDParam(memberID),
+	}
+}
+
+func (f *WorkspaceMemberParams) WorkspaceID() string {
+	return f.workspaceID.Value()
+}
+
+func (f *WorkspaceMemberParams) UserID() string {
+	return f.userID.Value()
+}
+
+func (f *WorkspaceMemberParams) MemberID() string {
+	return f.memberID.Value()
+}
+
+func (f *WorkspaceMemberParams) Validate() error {
+	if err := f.workspaceID.Validate(); err != nil {
+		return err
+	}
+	if err := f.userID.Validate(); err != nil {
+		return err
+	}
+	if err := f.memberID.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
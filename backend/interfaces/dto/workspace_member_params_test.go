@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceMemberParams_Validate(tt *testing.T) {
+	testcases := []struct {
+		title string
+		arg   *WorkspaceMemberParams
+		err   error
+	}{
+		{"正常系: 正しい入力の場合", NewWorkspaceMemberParams("wid", "uid", "mid"), nil},
+		{"準正常系: WorkspaceIDが50文字を超える場合", NewWorkspaceMemberParams(strings.Repeat("*", 51), "uid", "mid"), errors.New("id must be 50 characters or less")},
+		{"準正常系: UserIDが50文字を超える場合", NewWorkspaceMemberParams("wid", strings.Repeat("*", 51), "mid"), errors.New("id must be 50 characters or less")},
+		{"準正常系: MemberIDが50文字を超える場合", NewWorkspaceMemberParams("wid", "uid", strings.Repeat("*", 51)), errors.New("id must be 50 characters or less")},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			err := v.arg.Validate()
+
+			if v.err == nil {
+				require.NoError(t, err, "エラーが発生しないこと")
+			} else {
+				require.EqualError(t, err, v.err.Error(), "エラーが一致すること")
+			}
+		})
+	}
+}
@@ -3,41 +3,87 @@ package interceptor
 import (
 	"context"
 	"errors"
+	"net/http"
 	"strings"
 
 	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/domain/repository"
 	"github.com/7oh2020/connect-tasklist/backend/util/auth"
 	"github.com/7oh2020/connect-tasklist/backend/util/contextkey"
 )
 
+// 操作対象のワークスペースを指定するリクエストヘッダー
+const WorkspaceHeader = "X-Workspace-Id"
+
 // リクエストのJWTを検証する。成功時にはUserIDをコンテキストにセットする
-func NewAuthInterceptor(issuer string, keyPath string) connect.UnaryInterceptorFunc {
-	interceptor := func(next connect.UnaryFunc) connect.UnaryFunc {
-		return connect.UnaryFunc(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
-			// リクエストヘッダーからJWTを取得する
-			token := req.Header().Get("Authorization")
-			if token == "" {
-				return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("error: invalid token"))
-			}
-			token = strings.TrimPrefix(token, "Bearer")
-			token = strings.TrimSpace(token)
-
-			// トークンを検証しUserIDを取得する
-			tm, err := auth.NewTokenManager(issuer, keyPath)
-			if err != nil {
-				return nil, connect.NewError(connect.CodeUnauthenticated, err)
-			}
-			uid, err := tm.GetUserID(token)
-			if err != nil {
-				return nil, connect.NewError(connect.CodeUnauthenticated, err)
-			}
-
-			// コンテキストにUserIDをセットする
-			cw := contextkey.NewContextWriter()
-			ctx = cw.SetUserID(ctx, uid)
-
-			return next(ctx, req)
-		})
+// ワークスペースが指定された場合はメンバーであることを検証し、WorkspaceIDもコンテキストにセットする
+// ストリーミングのRPCではストリームの開始時に一度だけ検証する
+type AuthInterceptor struct {
+	issuer        string
+	keyPath       string
+	workspaceRepo repository.IWorkspaceRepository
+}
+
+func NewAuthInterceptor(issuer string, keyPath string, workspaceRepo repository.IWorkspaceRepository) *AuthInterceptor {
+	return &AuthInterceptor{issuer, keyPath, workspaceRepo}
+}
+
+func (i *AuthInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return connect.UnaryFunc(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		ctx, err := i.authenticate(ctx, req.Header())
+		if err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	})
+}
+
+// クライアント側では何もしない
+func (i *AuthInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *AuthInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return connect.StreamingHandlerFunc(func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, err := i.authenticate(ctx, conn.RequestHeader())
+		if err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	})
+}
+
+// リクエストヘッダーのJWTを検証し、UserIDをセットしたコンテキストを返す
+func (i *AuthInterceptor) authenticate(ctx context.Context, header http.Header) (context.Context, error) {
+	// リクエストヘッダーからJWTを取得する
+	token := header.Get("Authorization")
+	if token == "" {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("error: invalid token"))
+	}
+	token = strings.TrimPrefix(token, "Bearer")
+	token = strings.TrimSpace(token)
+
+	// トークンを検証しUserIDを取得する
+	tm, err := auth.NewTokenManager(i.issuer, i.keyPath)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	uid, err := tm.GetUserID(token)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	// コンテキストにUserIDをセットする
+	cw := contextkey.NewContextWriter()
+	ctx = cw.SetUserID(ctx, uid)
+
+	// ワークスペースが指定された場合はメンバーであることを検証する
+	workspaceID := header.Get(WorkspaceHeader)
+	if workspaceID == "" {
+		return ctx, nil
+	}
+	if _, err := i.workspaceRepo.FindWorkspaceMember(ctx, workspaceID, uid); err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("error: not a member of the workspace"))
 	}
-	return connect.UnaryInterceptorFunc(interceptor)
+	return cw.SetWorkspaceID(ctx, workspaceID), nil
 }
@@ -6,15 +6,29 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app/handler"
+	"github.com/7oh2020/connect-tasklist/backend/domain/repository"
 	"github.com/7oh2020/connect-tasklist/backend/infrastructure/persistence/model/db"
+	"github.com/7oh2020/connect-tasklist/backend/infrastructure/storage"
 	"github.com/7oh2020/connect-tasklist/backend/interfaces/di"
-	"github.com/7oh2020/connect-tasklist/backend/interfaces/interceptor"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/attachment/v1/attachment_v1connect"
 	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1/auth_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/calendar/v1/calendar_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/comment/v1/comment_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/list/v1/list_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/search/v1/search_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/sharing/v1/sharing_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/tag/v1/tag_v1connect"
 	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1/task_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/timeentry/v1/timeentry_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/transfer/v1/transfer_v1connect"
 	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/user/v1/user_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/view/v1/view_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/workspace/v1/workspace_v1connect"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/rs/cors"
 	"golang.org/x/net/http2"
@@ -42,6 +56,39 @@ func run() error {
 		return fmt.Errorf("database-url not set: %s", url)
 	}
 
+	// ゴミ箱のタスクの保持期間。未設定の場合は30日
+	retention := 30 * 24 * time.Hour
+	if v, ok := os.LookupEnv("TRASH_RETENTION"); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid trash-retention: %s", v)
+		}
+		retention = d
+	}
+
+	// 添付ファイルのユーザー毎の使用量の上限(バイト)。未設定の場合は100MiB
+	quota := int64(100 << 20)
+	if v, ok := os.LookupEnv("ATTACHMENT_QUOTA"); ok {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid attachment-quota: %s", v)
+		}
+		quota = n
+	}
+
+	// 全文検索に使用するPostgreSQLのテキスト検索設定。未設定の場合は言語に依存しないsimple
+	// tasks.search_configの既定値と一致させる。一致しない場合は起動に失敗する
+	searchConfig, ok := os.LookupEnv("SEARCH_CONFIG")
+	if !ok {
+		searchConfig = "simple"
+	}
+
+	// 添付ファイルの保存先を作成する
+	blobStorage, err := newBlobStorage(context.Background())
+	if err != nil {
+		return err
+	}
+
 	// PostgreSQLに接続する
 	poolCfg, err := pgxpool.ParseConfig(url)
 	if err != nil {
@@ -67,16 +114,58 @@ func run() error {
 		return err
 	}
 	userServer := di.InitUser(qry)
-	taskServer := di.InitTask(qry)
+	taskServer := di.InitTask(qry, pool)
+	tagServer := di.InitTag(qry)
+	listServer := di.InitList(qry)
+	commentServer := di.InitComment(qry)
+	attachmentServer := di.InitAttachment(qry, blobStorage, quota)
+	sharingServer := di.InitSharing(qry)
+	workspaceServer := di.InitWorkspace(qry, pool)
+	searchServer, err := di.InitSearch(qry, searchConfig)
+	if err != nil {
+		return err
+	}
+	viewServer := di.InitView(qry)
+	timeEntryServer := di.InitTimeEntry(qry)
+	transferServer := di.InitTransfer(qry, pool)
+	calendarServer := di.InitCalendar(qry)
+	calendarFeedServer := di.InitCalendarFeed(qry)
+
+	// タスクの位置のキーをバックグラウンドで再配置する
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rebalanceWorker := di.InitRebalanceWorker(qry, pool, 10*time.Minute)
+	go rebalanceWorker.Run(ctx)
+
+	// 保持期間を過ぎたゴミ箱のタスクをバックグラウンドで完全に削除する
+	purgeWorker := di.InitPurgeWorker(qry, pool, 1*time.Hour, retention)
+	go purgeWorker.Run(ctx)
+
+	// 削除された添付ファイルの実体をバックグラウンドでストレージから削除する
+	blobPurgeWorker := di.InitBlobPurgeWorker(qry, blobStorage, 10*time.Minute)
+	go blobPurgeWorker.Run(ctx)
 
 	// インターセプタを作成する
-	authInterceptor := connect.WithInterceptors(interceptor.NewAuthInterceptor(issuer, keyPath))
+	authInterceptor := connect.WithInterceptors(di.InitAuthInterceptor(issuer, keyPath, qry))
 
 	// サーバーの起動
 	mux := http.NewServeMux()
 	mux.Handle(auth_v1connect.NewAuthServiceHandler(authServer))
 	mux.Handle(user_v1connect.NewUserServiceHandler(userServer))
 	mux.Handle(task_v1connect.NewTaskServiceHandler(taskServer, authInterceptor))
+	mux.Handle(tag_v1connect.NewTagServiceHandler(tagServer, authInterceptor))
+	mux.Handle(list_v1connect.NewListServiceHandler(listServer, authInterceptor))
+	mux.Handle(comment_v1connect.NewCommentServiceHandler(commentServer, authInterceptor))
+	mux.Handle(attachment_v1connect.NewAttachmentServiceHandler(attachmentServer, authInterceptor))
+	mux.Handle(sharing_v1connect.NewSharingServiceHandler(sharingServer, authInterceptor))
+	mux.Handle(workspace_v1connect.NewWorkspaceServiceHandler(workspaceServer, authInterceptor))
+	mux.Handle(search_v1connect.NewSearchServiceHandler(searchServer, authInterceptor))
+	mux.Handle(view_v1connect.NewViewServiceHandler(viewServer, authInterceptor))
+	mux.Handle(timeentry_v1connect.NewTimeEntryServiceHandler(timeEntryServer, authInterceptor))
+	mux.Handle(transfer_v1connect.NewTransferServiceHandler(transferServer, authInterceptor))
+	mux.Handle(calendar_v1connect.NewCalendarServiceHandler(calendarServer, authInterceptor))
+	// カレンダーアプリは認証ヘッダーを送れないため、フィードはインターセプタを通さずにパスのトークンで認証する
+	mux.Handle(handler.CalendarFeedPattern, calendarFeedServer)
 
 	return http.ListenAndServe(
 		"localhost:8080",
@@ -88,3 +177,41 @@ func run() error {
 	)
 
 }
+
+// 環境変数ATTACHMENT_STORAGEに応じて添付ファイルの保存先を作成する。未設定の場合はローカルディスクに保存する
+func newBlobStorage(ctx context.Context) (repository.IBlobStorage, error) {
+	switch v := os.Getenv("ATTACHMENT_STORAGE"); v {
+	case "", "local":
+		dir, ok := os.LookupEnv("ATTACHMENT_DIR")
+		if !ok {
+			dir = "attachments"
+		}
+		return storage.NewFSBlobStorage(dir)
+	case "s3":
+		var endpoint, accessKey, secretKey, bucket string
+		var ok bool
+		if endpoint, ok = os.LookupEnv("S3_ENDPOINT"); !ok {
+			return nil, fmt.Errorf("s3-endpoint not set: %s", endpoint)
+		}
+		if accessKey, ok = os.LookupEnv("S3_ACCESS_KEY"); !ok {
+			return nil, fmt.Errorf("s3-access-key not set")
+		}
+		if secretKey, ok = os.LookupEnv("S3_SECRET_KEY"); !ok {
+			return nil, fmt.Errorf("s3-secret-key not set")
+		}
+		if bucket, ok = os.LookupEnv("S3_BUCKET"); !ok {
+			return nil, fmt.Errorf("s3-bucket not set: %s", bucket)
+		}
+		useSSL := os.Getenv("S3_USE_SSL") == "true"
+		s, err := storage.NewS3BlobStorage(endpoint, accessKey, secretKey, bucket, useSSL)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.EnsureBucket(ctx); err != nil {
+			return nil, err
+		}
+		return s, nil
+	default:
+		return nil, fmt.Errorf("invalid attachment-storage: %s", v)
+	}
+}
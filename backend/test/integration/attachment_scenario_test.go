@@ -0,0 +1,154 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/domain/service"
+	"github.com/7oh2020/connect-tasklist/backend/infrastructure/persistence/sqlc"
+	"github.com/7oh2020/connect-tasklist/backend/infrastructure/storage"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/di"
+	attachment_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/attachment/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/attachment/v1/attachment_v1connect"
+	auth_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1/auth_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1/task_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/util/clock"
+	"github.com/7oh2020/connect-tasklist/backend/util/identification"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentScenario(t *testing.T) {
+	// テストサーバーの起動
+	blobStorage, err := storage.NewFSBlobStorage(t.TempDir())
+	require.NoError(t, err, "エラーが発生しないこと")
+	authInterceptor := connect.WithInterceptors(di.InitAuthInterceptor(issuer, keyPath, qry))
+	attachmentHdr := di.InitAttachment(qry, blobStorage, 1<<20)
+	taskHdr := di.InitTask(qry, pool)
+	authHdr, err := di.InitAuth(issuer, keyPath, qry, timeout)
+	require.NoError(t, err, "エラーが発生しないこと")
+	mux := http.NewServeMux()
+	mux.Handle(auth_v1connect.NewAuthServiceHandler(authHdr))
+	mux.Handle(attachment_v1connect.NewAttachmentServiceHandler(attachmentHdr, authInterceptor))
+	mux.Handle(task_v1connect.NewTaskServiceHandler(taskHdr, authInterceptor))
+	ts := newTestServer(t, mux)
+	defer ts.Close()
+
+	// アップロードとダウンロードはストリーミングのためクライアントを使用する
+	ctx := context.Background()
+	client := attachment_v1connect.NewAttachmentServiceClient(ts.Client(), ts.URL)
+	anotherTaskID := "t1"
+
+	// Login: ログインしてトークンを取得する
+	res, err := ts.sendPostRequest(t, "", "/rpc.auth.v1.AuthService/Login", fmt.Sprintf(`{"email":"%s", "password":"%s"}`, "dev@example.com", "pass"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var data auth_v1.LoginResponse
+	err = json.Unmarshal([]byte(res.body), &data)
+	require.NoError(t, err, "エラーが発生しないこと")
+	token := data.Token
+
+	// ファイルをチャンクに分割してアップロードする
+	// 送信中にサーバーがエラーを返した場合、エラーはCloseAndReceiveで取得する
+	upload := func(taskID string, fileName string, content []byte) (*connect.Response[attachment_v1.UploadAttachmentResponse], error) {
+		stream := client.UploadAttachment(ctx)
+		stream.RequestHeader().Set("Authorization", "Bearer "+token)
+		err := stream.Send(&attachment_v1.UploadAttachmentRequest{
+			Data: &attachment_v1.UploadAttachmentRequest_Metadata{Metadata: &attachment_v1.UploadAttachmentMetadata{TaskId: taskID, FileName: fileName}},
+		})
+		for err == nil && len(content) > 0 {
+			n := min(len(content), 1024)
+			err = stream.Send(&attachment_v1.UploadAttachmentRequest{
+				Data: &attachment_v1.UploadAttachmentRequest_Chunk{Chunk: content[:n]},
+			})
+			content = content[n:]
+		}
+		return stream.CloseAndReceive()
+	}
+
+	// CreateTask: ファイルを添付するタスクを作成する
+	var created struct {
+		CreatedID string `json:"createdId"`
+	}
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CreateTask", `{"name":"Attached Task"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	taskID := created.CreatedID
+
+	// UploadAttachment: 他人のTaskIDの場合
+	_, err = upload(anotherTaskID, "memo.txt", []byte("hello"))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), "パーミッションエラーになること")
+
+	// UploadAttachment: 空のファイルの場合
+	_, err = upload(taskID, "memo.txt", nil)
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "入力エラーになること")
+
+	// UploadAttachment: 許可されていないMIMEタイプの場合
+	_, err = upload(taskID, "page.png", []byte("<html><body>hello</body></html>"))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "入力エラーになること")
+
+	// UploadAttachment: 使用量の上限を超える場合
+	_, err = upload(taskID, "large.txt", bytes.Repeat([]byte("a"), 1<<20+1))
+	require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err), "上限エラーになること")
+
+	// UploadAttachment: 正しい入力の場合
+	content := bytes.Repeat([]byte("hello attachment\n"), 1000)
+	uploaded, err := upload(taskID, "memo.txt", content)
+	require.NoError(t, err, "エラーが発生しないこと")
+	attachmentID := uploaded.Msg.CreatedId
+
+	// GetAttachmentList: 添付ファイルの一覧を取得する
+	res, err = ts.sendPostRequest(t, token, "/rpc.attachment.v1.AttachmentService/GetAttachmentList", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var list attachment_v1.GetAttachmentListResponse
+	err = json.Unmarshal([]byte(res.body), &list)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Len(t, list.Attachments, 1, "アップロードしたファイルのみ取得できること")
+	require.Equal(t, attachmentID, list.Attachments[0].Id)
+	require.Equal(t, "text/plain; charset=utf-8", list.Attachments[0].ContentType, "内容からMIMEタイプが判定されること")
+	require.Equal(t, int64(len(content)), list.Attachments[0].Size)
+
+	// DownloadAttachment: 正しい入力の場合
+	req := connect.NewRequest(&attachment_v1.DownloadAttachmentRequest{AttachmentId: attachmentID})
+	req.Header().Set("Authorization", "Bearer "+token)
+	stream, err := client.DownloadAttachment(ctx, req)
+	require.NoError(t, err, "エラーが発生しないこと")
+	var received bytes.Buffer
+	for stream.Receive() {
+		if m := stream.Msg().GetMetadata(); m != nil {
+			require.Equal(t, "memo.txt", m.FileName, "最初にメタデータが返されること")
+			continue
+		}
+		received.Write(stream.Msg().GetChunk())
+	}
+	require.NoError(t, stream.Err(), "エラーが発生しないこと")
+	require.Equal(t, content, received.Bytes(), "アップロードした内容と一致すること")
+
+	// DeleteAttachment: 存在しないIDの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.attachment.v1.AttachmentService/DeleteAttachment", `{"attachment_id":"another"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 404, res.status, "NotFoundエラーになること")
+
+	// DeleteAttachment: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.attachment.v1.AttachmentService/DeleteAttachment", fmt.Sprintf(`{"attachment_id":"%s"}`, attachmentID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// PurgeDeletedBlobs: 削除した添付ファイルの実体がストレージから削除されること
+	rc, err := blobStorage.GetBlob(ctx, attachmentID)
+	require.NoError(t, err, "削除待ちの間は実体が残っていること")
+	rc.Close()
+	srv := service.NewAttachmentService(sqlc.NewSQLCAttachmentRepository(qry), sqlc.NewSQLCTaskRepository(qry), service.NewAuthorizationPolicy(sqlc.NewSQLCCollaboratorRepository(qry), sqlc.NewSQLCWorkspaceRepository(qry)), blobStorage, identification.NewUUIDManager(), clock.NewClockManager(), 0)
+	_, err = srv.PurgeDeletedBlobs(ctx)
+	require.NoError(t, err, "エラーが発生しないこと")
+	_, err = blobStorage.GetBlob(ctx, attachmentID)
+	require.Error(t, err, "実体が削除されていること")
+}
@@ -0,0 +1,126 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/app/handler"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/di"
+	auth_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1/auth_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/calendar/v1/calendar_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1/task_v1connect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarScenario(t *testing.T) {
+	// テストサーバーの起動
+	authInterceptor := connect.WithInterceptors(di.InitAuthInterceptor(issuer, keyPath, qry))
+	calendarHdr := di.InitCalendar(qry)
+	calendarFeedHdr := di.InitCalendarFeed(qry)
+	taskHdr := di.InitTask(qry, pool)
+	authHdr, err := di.InitAuth(issuer, keyPath, qry, timeout)
+	require.NoError(t, err, "エラーが発生しないこと")
+	mux := http.NewServeMux()
+	mux.Handle(auth_v1connect.NewAuthServiceHandler(authHdr))
+	mux.Handle(calendar_v1connect.NewCalendarServiceHandler(calendarHdr, authInterceptor))
+	mux.Handle(handler.CalendarFeedPattern, calendarFeedHdr)
+	mux.Handle(task_v1connect.NewTaskServiceHandler(taskHdr, authInterceptor))
+	ts := newTestServer(t, mux)
+	defer ts.Close()
+
+	// フィードは認証ヘッダーなしで取得する
+	getFeed := func(path string) (int, string, http.Header) {
+		resp, err := ts.Client().Get(ts.URL + path)
+		require.NoError(t, err, "エラーが発生しないこと")
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err, "エラーが発生しないこと")
+		return resp.StatusCode, string(body), resp.Header
+	}
+
+	// Login: ログインしてトークンを取得する
+	res, err := ts.sendPostRequest(t, "", "/rpc.auth.v1.AuthService/Login", fmt.Sprintf(`{"email":"%s", "password":"%s"}`, "dev@example.com", "pass"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var data auth_v1.LoginResponse
+	err = json.Unmarshal([]byte(res.body), &data)
+	require.NoError(t, err, "エラーが発生しないこと")
+	token := data.Token
+
+	// CreateTask: フィードに含めるタスクを作成する
+	var created struct {
+		CreatedID string `json:"createdId"`
+	}
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CreateTask", `{"name":"Calendar, Task; <1>"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	taskID := created.CreatedID
+
+	// CreateFeedToken: 認証していない場合
+	res, err = ts.sendPostRequest(t, "", "/rpc.calendar.v1.CalendarService/CreateFeedToken", `{}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 401, res.status, "認証エラーになること")
+
+	// CreateFeedToken: フィードのトークンを発行する
+	var feed struct {
+		Token    string `json:"token"`
+		FeedPath string `json:"feedPath"`
+	}
+	res, err = ts.sendPostRequest(t, token, "/rpc.calendar.v1.CalendarService/CreateFeedToken", `{}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &feed)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, "/calendar/"+feed.Token+".ics", feed.FeedPath, "フィードのパスが返されること")
+
+	// フィード: 自分のタスクがVTODOとして配信されること
+	status, body, header := getFeed(feed.FeedPath)
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Equal(t, "text/calendar; charset=utf-8", header.Get("Content-Type"))
+	require.Contains(t, body, "BEGIN:VCALENDAR\r\n")
+	require.Contains(t, body, "UID:"+taskID+"@connect-tasklist\r\n", "UIDにタスクのIDが含まれること")
+	require.Contains(t, body, `SUMMARY:Calendar\, Task\; <1>`+"\r\n", "エスケープを戻してTEXTとしてエスケープすること")
+	require.NotContains(t, body, "UID:t1@", "他人のタスクを含まないこと")
+
+	// フィード: 同じ内容の場合は同じ出力になること
+	_, again, _ := getFeed(feed.FeedPath)
+	require.Equal(t, body, again, "UIDや日時が変わらないこと")
+
+	// CreateFeedToken: 再発行すると以前のトークンは使えなくなること
+	oldPath := feed.FeedPath
+	res, err = ts.sendPostRequest(t, token, "/rpc.calendar.v1.CalendarService/CreateFeedToken", `{}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &feed)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.NotEqual(t, oldPath, feed.FeedPath, "異なるトークンが発行されること")
+	status, _, _ = getFeed(oldPath)
+	require.Equal(t, 404, status, "以前のトークンは存在しないものとして扱うこと")
+	status, _, _ = getFeed(feed.FeedPath)
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+
+	// フィード: 存在しないトークンや拡張子が違う場合
+	status, _, _ = getFeed("/calendar/unknown.ics")
+	require.Equal(t, 404, status, "存在しないものとして扱うこと")
+	status, _, _ = getFeed("/calendar/" + feed.Token)
+	require.Equal(t, 404, status, "存在しないものとして扱うこと")
+
+	// RevokeFeedToken: トークンを無効にする
+	res, err = ts.sendPostRequest(t, token, "/rpc.calendar.v1.CalendarService/RevokeFeedToken", `{}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	status, _, _ = getFeed(feed.FeedPath)
+	require.Equal(t, 404, status, "無効にしたトークンは存在しないものとして扱うこと")
+
+	// RevokeFeedToken: 発行していない場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.calendar.v1.CalendarService/RevokeFeedToken", `{}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 404, res.status, "存在しないエラーになること")
+}
@@ -0,0 +1,171 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/di"
+	auth_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1/auth_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/comment/v1/comment_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1/task_v1connect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentScenario(t *testing.T) {
+	// テストサーバーの起動
+	authInterceptor := connect.WithInterceptors(di.InitAuthInterceptor(issuer, keyPath, qry))
+	commentHdr := di.InitComment(qry)
+	taskHdr := di.InitTask(qry, pool)
+	authHdr, err := di.InitAuth(issuer, keyPath, qry, timeout)
+	require.NoError(t, err, "エラーが発生しないこと")
+	mux := http.NewServeMux()
+	mux.Handle(auth_v1connect.NewAuthServiceHandler(authHdr))
+	mux.Handle(comment_v1connect.NewCommentServiceHandler(commentHdr, authInterceptor))
+	mux.Handle(task_v1connect.NewTaskServiceHandler(taskHdr, authInterceptor))
+	ts := newTestServer(t, mux)
+	defer ts.Close()
+
+	anotherTaskID := "t1"
+
+	// Login: ログインしてトークンを取得する
+	res, err := ts.sendPostRequest(t, "", "/rpc.auth.v1.AuthService/Login", fmt.Sprintf(`{"email":"%s", "password":"%s"}`, "dev@example.com", "pass"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var data auth_v1.LoginResponse
+	err = json.Unmarshal([]byte(res.body), &data)
+	require.NoError(t, err, "エラーが発生しないこと")
+	token := data.Token
+
+	// CreateTask: コメントを付けるタスクを作成する
+	var created struct {
+		CreatedID string `json:"createdId"`
+	}
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CreateTask", `{"name":"Commented Task"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	taskID := created.CreatedID
+
+	// AddComment: 本文が空の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.comment.v1.CommentService/AddComment", fmt.Sprintf(`{"task_id":"%s", "body":""}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// AddComment: 本文が長すぎる場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.comment.v1.CommentService/AddComment", fmt.Sprintf(`{"task_id":"%s", "body":"%s"}`, taskID, strings.Repeat("*", 5001)))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// AddComment: 存在しないTaskIDの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.comment.v1.CommentService/AddComment", `{"task_id":"another", "body":"comment"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 404, res.status, "NotFoundエラーになること")
+
+	// AddComment: 他人のTaskIDの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.comment.v1.CommentService/AddComment", fmt.Sprintf(`{"task_id":"%s", "body":"comment"}`, anotherTaskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// AddComment: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.comment.v1.CommentService/AddComment", fmt.Sprintf(`{"task_id":"%s", "body":"first comment"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	commentID := created.CreatedID
+
+	// GetCommentList: 他人のTaskIDの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.comment.v1.CommentService/GetCommentList", fmt.Sprintf(`{"task_id":"%s"}`, anotherTaskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// GetCommentList: 追加したコメントが編集されていないこと
+	res, err = ts.sendPostRequest(t, token, "/rpc.comment.v1.CommentService/GetCommentList", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.Contains(t, res.body, "first comment", "追加したコメントが含まれること")
+	require.NotContains(t, res.body, "isEdited", "編集済みではないこと")
+
+	// EditComment: 存在しないCommentIDの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.comment.v1.CommentService/EditComment", `{"comment_id":"another", "body":"edited"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 404, res.status, "NotFoundエラーになること")
+
+	// EditComment: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.comment.v1.CommentService/EditComment", fmt.Sprintf(`{"comment_id":"%s", "body":"edited comment"}`, commentID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetCommentList: 編集済みになっていること
+	res, err = ts.sendPostRequest(t, token, "/rpc.comment.v1.CommentService/GetCommentList", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var list struct {
+		Comments []struct {
+			ID       string `json:"id"`
+			Body     string `json:"body"`
+			IsEdited bool   `json:"isEdited"`
+		} `json:"comments"`
+	}
+	err = json.Unmarshal([]byte(res.body), &list)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Len(t, list.Comments, 1, "コメントが1件であること")
+	require.Equal(t, "edited comment", list.Comments[0].Body, "本文が変更されていること")
+	require.True(t, list.Comments[0].IsEdited, "編集済みであること")
+
+	// DeleteTask: タスクをゴミ箱に移動する
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/DeleteTask", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetCommentList: ゴミ箱のタスクの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.comment.v1.CommentService/GetCommentList", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 404, res.status, "NotFoundエラーになること")
+
+	// RestoreTask: 復元するとコメントも戻ること
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/RestoreTask", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	res, err = ts.sendPostRequest(t, token, "/rpc.comment.v1.CommentService/GetCommentList", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.Contains(t, res.body, commentID, "コメントが残っていること")
+
+	// DeleteComment: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.comment.v1.CommentService/DeleteComment", fmt.Sprintf(`{"comment_id":"%s"}`, commentID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// DeleteComment: 削除済みのCommentIDの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.comment.v1.CommentService/DeleteComment", fmt.Sprintf(`{"comment_id":"%s"}`, commentID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 404, res.status, "NotFoundエラーになること")
+
+	// AddComment: 完全に削除するタスクにコメントを追加する
+	res, err = ts.sendPostRequest(t, token, "/rpc.comment.v1.CommentService/AddComment", fmt.Sprintf(`{"task_id":"%s", "body":"purged comment"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	purgedID := created.CreatedID
+
+	// DeleteTask, EmptyTrash: タスクを完全に削除する
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/DeleteTask", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/EmptyTrash", `{}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// EditComment: タスクと一緒にコメントも削除されていること
+	res, err = ts.sendPostRequest(t, token, "/rpc.comment.v1.CommentService/EditComment", fmt.Sprintf(`{"comment_id":"%s", "body":"edited"}`, purgedID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 404, res.status, "NotFoundエラーになること")
+}
@@ -17,6 +17,7 @@ import (
 
 var (
 	qry     db.Querier
+	pool    *pgxpool.Pool
 	issuer  string
 	keyPath string
 	timeout time.Duration
@@ -42,10 +43,18 @@ func newTestServer(t *testing.T, hdr http.Handler) *testServer {
 }
 
 func (ts *testServer) sendPostRequest(t *testing.T, token string, urlPath string, input string) (*testResponse, error) {
+	return ts.sendPostRequestWithHeader(t, token, urlPath, input, nil)
+}
+
+// 追加のリクエストヘッダーを指定してリクエストを送信する
+func (ts *testServer) sendPostRequestWithHeader(t *testing.T, token string, urlPath string, input string, header http.Header) (*testResponse, error) {
 	req, err := http.NewRequest(http.MethodPost, ts.URL+urlPath, bytes.NewBuffer([]byte(input)))
 	if err != nil {
 		return nil, err
 	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
 	req.Header.Set("Content-Type", "application/json")
 	if token != "" {
 		req.Header.Set("Authorization", "Bearer "+token)
@@ -93,7 +102,7 @@ func TestMain(m *testing.M) {
 	}
 	poolCfg.MaxConns = 10
 	poolCfg.MinConns = 1
-	pool, err := pgxpool.ConnectConfig(context.Background(), poolCfg)
+	pool, err = pgxpool.ConnectConfig(context.Background(), poolCfg)
 	if err != nil {
 		panic(fmt.Errorf("unable to connect pool: %s", dbURL))
 	}
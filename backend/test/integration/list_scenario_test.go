@@ -0,0 +1,143 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/di"
+	auth_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1/auth_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/list/v1/list_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1/task_v1connect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListScenario(t *testing.T) {
+	// テストサーバーの起動
+	authInterceptor := connect.WithInterceptors(di.InitAuthInterceptor(issuer, keyPath, qry))
+	listHdr := di.InitList(qry)
+	taskHdr := di.InitTask(qry, pool)
+	authHdr, err := di.InitAuth(issuer, keyPath, qry, timeout)
+	require.NoError(t, err, "エラーが発生しないこと")
+	mux := http.NewServeMux()
+	mux.Handle(auth_v1connect.NewAuthServiceHandler(authHdr))
+	mux.Handle(list_v1connect.NewListServiceHandler(listHdr, authInterceptor))
+	mux.Handle(task_v1connect.NewTaskServiceHandler(taskHdr, authInterceptor))
+	ts := newTestServer(t, mux)
+	defer ts.Close()
+
+	inboxID := "l3"
+	anotherListID := "l1"
+
+	// Login: ログインしてトークンを取得する
+	res, err := ts.sendPostRequest(t, "", "/rpc.auth.v1.AuthService/Login", fmt.Sprintf(`{"email":"%s", "password":"%s"}`, "admin@example.com", "pass"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var data auth_v1.LoginResponse
+	err = json.Unmarshal([]byte(res.body), &data)
+	require.NoError(t, err, "エラーが発生しないこと")
+	token := data.Token
+
+	// GetLists: Inboxが取得できること
+	res, err = ts.sendPostRequest(t, token, "/rpc.list.v1.ListService/GetLists", "{}")
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.Contains(t, res.body, inboxID, "Inboxが含まれること")
+
+	// CreateList: Nameが長すぎる場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.list.v1.ListService/CreateList", fmt.Sprintf(`{"name":"%s"}`, strings.Repeat("*", 101)))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// CreateList: 正しい入力の場合
+	var created struct {
+		CreatedID string `json:"createdId"`
+	}
+	res, err = ts.sendPostRequest(t, token, "/rpc.list.v1.ListService/CreateList", `{"name":"work"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	listID := created.CreatedID
+
+	// CreateTask: 他人のリストを指定した場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CreateTask", fmt.Sprintf(`{"name":"task", "list_id":"%s"}`, anotherListID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// CreateTask: リストを指定した場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CreateTask", fmt.Sprintf(`{"name":"listed task", "list_id":"%s"}`, listID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	taskID := created.CreatedID
+
+	// GetTaskList: リストで絞り込む場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskList", fmt.Sprintf(`{"list_id":"%s"}`, listID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.Contains(t, res.body, taskID, "リストのタスクが含まれること")
+	require.NotContains(t, res.body, "t4", "別のリストのタスクは含まれないこと")
+
+	// ArchiveList: Inboxの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.list.v1.ListService/ArchiveList", fmt.Sprintf(`{"list_id":"%s"}`, inboxID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "前提条件エラーになること")
+
+	// ArchiveList: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.list.v1.ListService/ArchiveList", fmt.Sprintf(`{"list_id":"%s"}`, listID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetTaskList: アーカイブされたリストのタスクは含まれないこと
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskList", "{}")
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.NotContains(t, res.body, taskID, "アーカイブされたリストのタスクは含まれないこと")
+	require.Contains(t, res.body, "t4", "Inboxのタスクが含まれること")
+
+	// MoveTaskToList: アーカイブされたリストへ移動する場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/MoveTaskToList", fmt.Sprintf(`{"task_id":"%s", "list_id":"%s"}`, "t4", listID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "前提条件エラーになること")
+
+	// UnarchiveList: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.list.v1.ListService/UnarchiveList", fmt.Sprintf(`{"list_id":"%s"}`, listID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// MoveTaskToList: Inboxへ移動する場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/MoveTaskToList", fmt.Sprintf(`{"task_id":"%s", "list_id":"%s"}`, taskID, inboxID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// ReorderLists: リストが不足している場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.list.v1.ListService/ReorderLists", fmt.Sprintf(`{"list_ids":["%s"]}`, listID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// ReorderLists: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.list.v1.ListService/ReorderLists", fmt.Sprintf(`{"list_ids":["%s", "%s"]}`, listID, inboxID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// DeleteList: Inboxの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.list.v1.ListService/DeleteList", fmt.Sprintf(`{"list_id":"%s"}`, inboxID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "前提条件エラーになること")
+
+	// DeleteList: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.list.v1.ListService/DeleteList", fmt.Sprintf(`{"list_id":"%s"}`, listID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// DeleteTask: 後片付け
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/DeleteTask", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+}
@@ -0,0 +1,133 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/di"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/interceptor"
+	auth_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1/auth_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/list/v1/list_v1connect"
+	search_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/search/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/search/v1/search_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1/task_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/workspace/v1/workspace_v1connect"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestSearchScenario(t *testing.T) {
+	// InitSearch: 検索設定がtasks.search_configの既定値と異なる場合は起動できないこと
+	_, err := di.InitSearch(qry, "english")
+	require.Error(t, err, "エラーが発生すること")
+
+	// テストサーバーの起動
+	authInterceptor := connect.WithInterceptors(di.InitAuthInterceptor(issuer, keyPath, qry))
+	searchHdr, err := di.InitSearch(qry, "simple")
+	require.NoError(t, err, "エラーが発生しないこと")
+	taskHdr := di.InitTask(qry, pool)
+	listHdr := di.InitList(qry)
+	workspaceHdr := di.InitWorkspace(qry, pool)
+	authHdr, err := di.InitAuth(issuer, keyPath, qry, timeout)
+	require.NoError(t, err, "エラーが発生しないこと")
+	mux := http.NewServeMux()
+	mux.Handle(auth_v1connect.NewAuthServiceHandler(authHdr))
+	mux.Handle(search_v1connect.NewSearchServiceHandler(searchHdr, authInterceptor))
+	mux.Handle(task_v1connect.NewTaskServiceHandler(taskHdr, authInterceptor))
+	mux.Handle(list_v1connect.NewListServiceHandler(listHdr, authInterceptor))
+	mux.Handle(workspace_v1connect.NewWorkspaceServiceHandler(workspaceHdr, authInterceptor))
+	ts := newTestServer(t, mux)
+	defer ts.Close()
+
+	// Login: ログインしてトークンを取得する
+	res, err := ts.sendPostRequest(t, "", "/rpc.auth.v1.AuthService/Login", fmt.Sprintf(`{"email":"%s", "password":"%s"}`, "dev@example.com", "pass"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var data auth_v1.LoginResponse
+	err = json.Unmarshal([]byte(res.body), &data)
+	require.NoError(t, err, "エラーが発生しないこと")
+	token := data.Token
+
+	// タスクを作成する
+	var created struct {
+		CreatedID string `json:"createdId"`
+	}
+	createTask := func(name string, description string) string {
+		res, err := ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CreateTask", fmt.Sprintf(`{"name":"%s"}`, name))
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+		err = json.Unmarshal([]byte(res.body), &created)
+		require.NoError(t, err, "エラーが発生しないこと")
+		if description != "" {
+			res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/ChangeTaskDescription", fmt.Sprintf(`{"task_id":"%s", "description":"%s"}`, created.CreatedID, description))
+			require.NoError(t, err, "エラーが発生しないこと")
+			require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+		}
+		return created.CreatedID
+	}
+	nameMatchID := createTask("Searchable groceries", "")
+	descriptionMatchID := createTask("Weekend", "buy <b>searchable</b> things")
+	createTask("Unrelated", "nothing here")
+
+	search := func(body string, header http.Header) (*search_v1.SearchTasksResponse, int) {
+		res, err := ts.sendPostRequestWithHeader(t, token, "/rpc.search.v1.SearchService/SearchTasks", body, header)
+		require.NoError(t, err, "エラーが発生しないこと")
+		var data search_v1.SearchTasksResponse
+		if res.status == 200 {
+			err = protojson.Unmarshal([]byte(res.body), &data)
+			require.NoError(t, err, "エラーが発生しないこと")
+		}
+		return &data, res.status
+	}
+
+	// SearchTasks: 検索語が空の場合
+	_, status := search(`{"query":" "}`, nil)
+	require.Equal(t, 400, status, "入力エラーになること")
+
+	// SearchTasks: 不正なページトークンの場合
+	_, status = search(`{"query":"searchable", "page_token":"***"}`, nil)
+	require.Equal(t, 400, status, "入力エラーになること")
+
+	// SearchTasks: 名前に一致したタスクが説明に一致したタスクより先になること
+	found, status := search(`{"query":"searchable"}`, nil)
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Len(t, found.Results, 2, "一致したタスクのみ取得できること")
+	require.Equal(t, nameMatchID, found.Results[0].Task.Id)
+	require.Equal(t, "<mark>Searchable</mark> groceries", found.Results[0].NameHeadline, "一致した部分が強調されること")
+	require.Equal(t, descriptionMatchID, found.Results[1].Task.Id)
+	require.Contains(t, found.Results[1].DescriptionHeadline, "&lt;b&gt;<mark>searchable</mark>&lt;/b&gt;", "説明はエスケープされること")
+	require.Greater(t, found.Results[0].Rank, found.Results[1].Rank, "関連度の高い順になること")
+	require.Empty(t, found.NextPageToken, "続きがないこと")
+
+	// SearchTasks: ページサイズを指定した場合は続きのトークンが返されること
+	first, status := search(`{"query":"searchable", "page_size":1}`, nil)
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Len(t, first.Results, 1)
+	require.Equal(t, nameMatchID, first.Results[0].Task.Id)
+	require.NotEmpty(t, first.NextPageToken, "続きのトークンが返されること")
+	second, status := search(fmt.Sprintf(`{"query":"searchable", "page_size":1, "page_token":"%s"}`, first.NextPageToken), nil)
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Len(t, second.Results, 1)
+	require.Equal(t, descriptionMatchID, second.Results[0].Task.Id, "続きのタスクが取得できること")
+
+	// SearchTasks: 他人のタスクは検索されないこと
+	found, status = search(`{"query":"\"Test Task 1\""}`, nil)
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Empty(t, found.Results, "他人のタスクが含まれないこと")
+
+	// SearchTasks: ワークスペースを指定した場合はワークスペースのタスクのみ検索されること
+	res, err = ts.sendPostRequest(t, token, "/rpc.workspace.v1.WorkspaceService/CreateWorkspace", `{"name":"Search Team"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	header := http.Header{}
+	header.Set(interceptor.WorkspaceHeader, created.CreatedID)
+	found, status = search(`{"query":"searchable"}`, header)
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Empty(t, found.Results, "個人のタスクが含まれないこと")
+}
@@ -0,0 +1,175 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/di"
+	auth_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1/auth_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/sharing/v1/sharing_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1/task_v1connect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharingScenario(t *testing.T) {
+	// テストサーバーの起動
+	authInterceptor := connect.WithInterceptors(di.InitAuthInterceptor(issuer, keyPath, qry))
+	sharingHdr := di.InitSharing(qry)
+	taskHdr := di.InitTask(qry, pool)
+	authHdr, err := di.InitAuth(issuer, keyPath, qry, timeout)
+	require.NoError(t, err, "エラーが発生しないこと")
+	mux := http.NewServeMux()
+	mux.Handle(auth_v1connect.NewAuthServiceHandler(authHdr))
+	mux.Handle(sharing_v1connect.NewSharingServiceHandler(sharingHdr, authInterceptor))
+	mux.Handle(task_v1connect.NewTaskServiceHandler(taskHdr, authInterceptor))
+	ts := newTestServer(t, mux)
+	defer ts.Close()
+
+	// Login: 所有者と共有相手でログインしてトークンを取得する
+	login := func(email string) string {
+		res, err := ts.sendPostRequest(t, "", "/rpc.auth.v1.AuthService/Login", fmt.Sprintf(`{"email":"%s", "password":"%s"}`, email, "pass"))
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+		var data auth_v1.LoginResponse
+		err = json.Unmarshal([]byte(res.body), &data)
+		require.NoError(t, err, "エラーが発生しないこと")
+		return data.Token
+	}
+	token := login("dev@example.com")
+	otherToken := login("test@example.com")
+
+	// CreateTask: 共有するタスクを作成する
+	var created struct {
+		CreatedID string `json:"createdId"`
+	}
+	res, err := ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CreateTask", `{"name":"Shared Task"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	taskID := created.CreatedID
+
+	// GetTaskTree: 共有される前は閲覧できないこと
+	res, err = ts.sendPostRequest(t, otherToken, "/rpc.task.v1.TaskService/GetTaskTree", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// GrantAccess: 対象を指定しない場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.sharing.v1.SharingService/GrantAccess", `{"email":"test@example.com", "role":"ROLE_VIEWER"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// GrantAccess: 存在しないユーザーの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.sharing.v1.SharingService/GrantAccess", fmt.Sprintf(`{"task_id":"%s", "email":"nobody@example.com", "role":"ROLE_VIEWER"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 404, res.status, "NotFoundエラーになること")
+
+	// GrantAccess: 閲覧者として共有する
+	res, err = ts.sendPostRequest(t, token, "/rpc.sharing.v1.SharingService/GrantAccess", fmt.Sprintf(`{"task_id":"%s", "email":"test@example.com", "role":"ROLE_VIEWER"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetTaskTree: 閲覧者は閲覧できること
+	res, err = ts.sendPostRequest(t, otherToken, "/rpc.task.v1.TaskService/GetTaskTree", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// ChangeTaskName: 閲覧者は編集できないこと
+	res, err = ts.sendPostRequest(t, otherToken, "/rpc.task.v1.TaskService/ChangeTaskName", fmt.Sprintf(`{"task_id":"%s", "name":"Renamed"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// GrantAccess: 閲覧者は共有できないこと
+	res, err = ts.sendPostRequest(t, otherToken, "/rpc.sharing.v1.SharingService/GrantAccess", fmt.Sprintf(`{"task_id":"%s", "email":"admin@example.com", "role":"ROLE_VIEWER"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// GrantAccess: 編集者に変更する
+	res, err = ts.sendPostRequest(t, token, "/rpc.sharing.v1.SharingService/GrantAccess", fmt.Sprintf(`{"task_id":"%s", "email":"test@example.com", "role":"ROLE_EDITOR"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetCollaboratorList: 共有相手の一覧を取得する
+	res, err = ts.sendPostRequest(t, token, "/rpc.sharing.v1.SharingService/GetCollaboratorList", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var list struct {
+		Collaborators []struct {
+			UserID string `json:"userId"`
+			Email  string `json:"email"`
+			Role   string `json:"role"`
+		} `json:"collaborators"`
+	}
+	err = json.Unmarshal([]byte(res.body), &list)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Len(t, list.Collaborators, 1)
+	require.Equal(t, "test", list.Collaborators[0].UserID)
+	require.Equal(t, "test@example.com", list.Collaborators[0].Email)
+	require.Equal(t, "ROLE_EDITOR", list.Collaborators[0].Role)
+
+	// ChangeTaskName: 編集者は編集できること
+	res, err = ts.sendPostRequest(t, otherToken, "/rpc.task.v1.TaskService/ChangeTaskName", fmt.Sprintf(`{"task_id":"%s", "name":"Renamed"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// DeleteTask: 編集者は削除できないこと
+	res, err = ts.sendPostRequest(t, otherToken, "/rpc.task.v1.TaskService/DeleteTask", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// AssignTask: 共有されていないユーザーは担当者にできないこと
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/AssignTask", fmt.Sprintf(`{"task_id":"%s", "assignee_id":"admin"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "前提条件エラーになること")
+
+	// AssignTask: 共有相手を担当者にする
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/AssignTask", fmt.Sprintf(`{"task_id":"%s", "assignee_id":"test"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetTaskList: 担当者は他のユーザーのタスクを自分の担当として取得できること
+	var assigned struct {
+		Tasks []struct {
+			ID         string `json:"id"`
+			UserID     string `json:"userId"`
+			AssigneeID string `json:"assigneeId"`
+		} `json:"tasks"`
+	}
+	res, err = ts.sendPostRequest(t, otherToken, "/rpc.task.v1.TaskService/GetTaskList", `{"assigned_to_me":true}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &assigned)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Len(t, assigned.Tasks, 1)
+	require.Equal(t, taskID, assigned.Tasks[0].ID)
+	require.Equal(t, "dev", assigned.Tasks[0].UserID)
+	require.Equal(t, "test", assigned.Tasks[0].AssigneeID)
+
+	// RevokeAccess: 共有された本人が共有を取り消す
+	res, err = ts.sendPostRequest(t, otherToken, "/rpc.sharing.v1.SharingService/RevokeAccess", fmt.Sprintf(`{"task_id":"%s", "user_id":"test"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetTaskTree: 取り消した後は閲覧できないこと
+	res, err = ts.sendPostRequest(t, otherToken, "/rpc.task.v1.TaskService/GetTaskTree", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// GetTaskList: 閲覧できなくなったタスクは担当していても取得されないこと
+	res, err = ts.sendPostRequest(t, otherToken, "/rpc.task.v1.TaskService/GetTaskList", `{"assigned_to_me":true}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	assigned.Tasks = nil
+	err = json.Unmarshal([]byte(res.body), &assigned)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Empty(t, assigned.Tasks)
+
+	// DeleteTask: 後片付け
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/DeleteTask", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+}
@@ -0,0 +1,146 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/di"
+	auth_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1/auth_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/tag/v1/tag_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1/task_v1connect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagScenario(t *testing.T) {
+	// テストサーバーの起動
+	authInterceptor := connect.WithInterceptors(di.InitAuthInterceptor(issuer, keyPath, qry))
+	tagHdr := di.InitTag(qry)
+	taskHdr := di.InitTask(qry, pool)
+	authHdr, err := di.InitAuth(issuer, keyPath, qry, timeout)
+	require.NoError(t, err, "エラーが発生しないこと")
+	mux := http.NewServeMux()
+	mux.Handle(auth_v1connect.NewAuthServiceHandler(authHdr))
+	mux.Handle(tag_v1connect.NewTagServiceHandler(tagHdr, authInterceptor))
+	mux.Handle(task_v1connect.NewTaskServiceHandler(taskHdr, authInterceptor))
+	ts := newTestServer(t, mux)
+	defer ts.Close()
+
+	taskID := "t3"
+	anotherTaskID := "t1"
+
+	// Login: ログインしてトークンを取得する
+	res, err := ts.sendPostRequest(t, "", "/rpc.auth.v1.AuthService/Login", fmt.Sprintf(`{"email":"%s", "password":"%s"}`, "dev@example.com", "pass"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var data auth_v1.LoginResponse
+	err = json.Unmarshal([]byte(res.body), &data)
+	require.NoError(t, err, "エラーが発生しないこと")
+	token := data.Token
+
+	// CreateTag: Nameが空の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.tag.v1.TagService/CreateTag", `{"name":""}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// CreateTag: Nameが長すぎる場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.tag.v1.TagService/CreateTag", fmt.Sprintf(`{"name":"%s"}`, strings.Repeat("*", 51)))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// CreateTag: 正しい入力の場合
+	var created struct {
+		CreatedID string `json:"createdId"`
+	}
+	res, err = ts.sendPostRequest(t, token, "/rpc.tag.v1.TagService/CreateTag", `{"name":"work"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	workID := created.CreatedID
+
+	res, err = ts.sendPostRequest(t, token, "/rpc.tag.v1.TagService/CreateTag", `{"name":"home"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	homeID := created.CreatedID
+
+	// CreateTag: 同じ名前のタグが存在する場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.tag.v1.TagService/CreateTag", `{"name":"work"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 409, res.status, "重複エラーになること")
+
+	// RenameTag: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.tag.v1.TagService/RenameTag", fmt.Sprintf(`{"tag_id":"%s", "name":"%s"}`, workID, "office"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// AttachTag: 他人のTaskIDの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.tag.v1.TagService/AttachTag", fmt.Sprintf(`{"task_id":"%s", "tag_id":"%s"}`, anotherTaskID, workID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// AttachTag: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.tag.v1.TagService/AttachTag", fmt.Sprintf(`{"task_id":"%s", "tag_id":"%s"}`, taskID, workID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetTagList: 使用数が集計されること
+	res, err = ts.sendPostRequest(t, token, "/rpc.tag.v1.TagService/GetTagList", "{}")
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.Contains(t, res.body, `"name":"office"`, "変更後の名前が取得できること")
+	require.Contains(t, res.body, `"usageCount":"1"`, "使用数が取得できること")
+
+	// GetTagList: ゴミ箱のタスクは使用数に含まれないこと
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/DeleteTask", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	res, err = ts.sendPostRequest(t, token, "/rpc.tag.v1.TagService/GetTagList", "{}")
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.NotContains(t, res.body, `"usageCount"`, "ゴミ箱のタスクが集計されないこと")
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/RestoreTask", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetTaskList: いずれかのタグで絞り込む場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskList", fmt.Sprintf(`{"tag_ids":["%s", "%s"], "tag_match":"TAG_MATCH_ANY"}`, workID, homeID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.Contains(t, res.body, taskID, "タグが付いたタスクが含まれること")
+
+	// GetTaskList: 全てのタグで絞り込む場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskList", fmt.Sprintf(`{"tag_ids":["%s", "%s"], "tag_match":"TAG_MATCH_ALL"}`, workID, homeID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.NotContains(t, res.body, taskID, "一部のタグしか付いていないタスクは含まれないこと")
+
+	// GetTaskList: 全てのタグで絞り込む場合にTagIDが重複している場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskList", fmt.Sprintf(`{"tag_ids":["%s", "%s"], "tag_match":"TAG_MATCH_ALL"}`, workID, workID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.Contains(t, res.body, taskID, "重複したタグは1つとして扱われること")
+
+	// DetachTag: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.tag.v1.TagService/DetachTag", fmt.Sprintf(`{"task_id":"%s", "tag_id":"%s"}`, taskID, workID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// DeleteTag: 正しい入力の場合
+	for _, v := range []string{workID, homeID} {
+		res, err = ts.sendPostRequest(t, token, "/rpc.tag.v1.TagService/DeleteTag", fmt.Sprintf(`{"tag_id":"%s"}`, v))
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	}
+
+	// DeleteTag: 存在しないTagIDの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.tag.v1.TagService/DeleteTag", fmt.Sprintf(`{"tag_id":"%s"}`, workID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 404, res.status, "存在しないエラーになること")
+}
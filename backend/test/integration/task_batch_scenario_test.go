@@ -0,0 +1,101 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/di"
+	auth_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1/auth_v1connect"
+	task_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1/task_v1connect"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestTaskBatchScenario(t *testing.T) {
+	// テストサーバーの起動
+	authInterceptor := connect.WithInterceptors(di.InitAuthInterceptor(issuer, keyPath, qry))
+	taskHdr := di.InitTask(qry, pool)
+	authHdr, err := di.InitAuth(issuer, keyPath, qry, timeout)
+	require.NoError(t, err, "エラーが発生しないこと")
+	mux := http.NewServeMux()
+	mux.Handle(auth_v1connect.NewAuthServiceHandler(authHdr))
+	mux.Handle(task_v1connect.NewTaskServiceHandler(taskHdr, authInterceptor))
+	ts := newTestServer(t, mux)
+	defer ts.Close()
+
+	// Login: ログインしてトークンを取得する
+	res, err := ts.sendPostRequest(t, "", "/rpc.auth.v1.AuthService/Login", fmt.Sprintf(`{"email":"%s", "password":"%s"}`, "dev@example.com", "pass"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var data auth_v1.LoginResponse
+	err = json.Unmarshal([]byte(res.body), &data)
+	require.NoError(t, err, "エラーが発生しないこと")
+	token := data.Token
+
+	batch := func(body string) (*task_v1.BatchMutateTasksResponse, int) {
+		res, err := ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/BatchMutateTasks", body)
+		require.NoError(t, err, "エラーが発生しないこと")
+		var data task_v1.BatchMutateTasksResponse
+		if res.status == 200 {
+			err = protojson.Unmarshal([]byte(res.body), &data)
+			require.NoError(t, err, "エラーが発生しないこと")
+		}
+		return &data, res.status
+	}
+	isCompleted := func(name string) bool {
+		res, err := ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskList", fmt.Sprintf(`{"filter":{"name_contains":"%s"}}`, name))
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+		var data task_v1.GetTaskListResponse
+		err = protojson.Unmarshal([]byte(res.body), &data)
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Len(t, data.Tasks, 1)
+		return data.Tasks[0].IsCompleted
+	}
+
+	// BatchMutateTasks: 複数のタスクをまとめて作成する
+	ret, status := batch(`{"operations":[{"kind":"TASK_OPERATION_KIND_CREATE", "name":"Batch A"}, {"kind":"TASK_OPERATION_KIND_CREATE", "name":"Batch B"}]}`)
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.True(t, ret.Committed, "コミットされること")
+	require.Len(t, ret.Results, 2)
+	for _, v := range ret.Results {
+		require.Equal(t, task_v1.TaskOperationStatus_TASK_OPERATION_STATUS_SUCCEEDED, v.Status)
+		require.NotEmpty(t, v.TaskId, "作成したタスクのIDが返されること")
+	}
+	idA := ret.Results[0].TaskId
+	idB := ret.Results[1].TaskId
+
+	// BatchMutateTasks: 一括実行で失敗した操作がある場合はすべて取り消されること
+	ops := fmt.Sprintf(`[{"kind":"TASK_OPERATION_KIND_COMPLETE", "task_id":"%s"}, {"kind":"TASK_OPERATION_KIND_DELETE", "task_id":"unknown"}, {"kind":"TASK_OPERATION_KIND_RENAME", "task_id":"%s", "name":"Batch B renamed"}]`, idA, idB)
+	ret, status = batch(fmt.Sprintf(`{"operations":%s, "mode":"BATCH_MODE_ALL_OR_NOTHING"}`, ops))
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.False(t, ret.Committed, "コミットされないこと")
+	require.Equal(t, task_v1.TaskOperationStatus_TASK_OPERATION_STATUS_ABORTED, ret.Results[0].Status)
+	require.Equal(t, task_v1.TaskOperationStatus_TASK_OPERATION_STATUS_FAILED, ret.Results[1].Status)
+	require.Equal(t, "not_found", ret.Results[1].ErrorCode)
+	require.Equal(t, task_v1.TaskOperationStatus_TASK_OPERATION_STATUS_ABORTED, ret.Results[2].Status)
+	require.False(t, isCompleted("Batch A"), "完了が取り消されること")
+
+	// BatchMutateTasks: ベストエフォートの場合は成功した操作のみ反映されること
+	ret, status = batch(fmt.Sprintf(`{"operations":%s, "mode":"BATCH_MODE_BEST_EFFORT"}`, ops))
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.True(t, ret.Committed, "コミットされること")
+	require.Equal(t, task_v1.TaskOperationStatus_TASK_OPERATION_STATUS_SUCCEEDED, ret.Results[0].Status)
+	require.Equal(t, task_v1.TaskOperationStatus_TASK_OPERATION_STATUS_FAILED, ret.Results[1].Status)
+	require.Equal(t, task_v1.TaskOperationStatus_TASK_OPERATION_STATUS_SUCCEEDED, ret.Results[2].Status)
+	require.True(t, isCompleted("Batch A"), "完了が反映されること")
+	require.False(t, isCompleted("Batch B renamed"), "名前の変更が反映されること")
+
+	// BatchMutateTasks: 操作が空の場合
+	_, status = batch(`{"operations":[]}`)
+	require.Equal(t, 400, status, "入力エラーになること")
+
+	// BatchMutateTasks: 操作の種類が不正な場合
+	_, status = batch(fmt.Sprintf(`{"operations":[{"task_id":"%s"}]}`, idA))
+	require.Equal(t, 400, status, "入力エラーになること")
+}
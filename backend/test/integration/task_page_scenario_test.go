@@ -0,0 +1,111 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/di"
+	auth_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1/auth_v1connect"
+	task_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1/task_v1connect"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestTaskPageScenario(t *testing.T) {
+	// テストサーバーの起動
+	authInterceptor := connect.WithInterceptors(di.InitAuthInterceptor(issuer, keyPath, qry))
+	taskHdr := di.InitTask(qry, pool)
+	authHdr, err := di.InitAuth(issuer, keyPath, qry, timeout)
+	require.NoError(t, err, "エラーが発生しないこと")
+	mux := http.NewServeMux()
+	mux.Handle(auth_v1connect.NewAuthServiceHandler(authHdr))
+	mux.Handle(task_v1connect.NewTaskServiceHandler(taskHdr, authInterceptor))
+	ts := newTestServer(t, mux)
+	defer ts.Close()
+
+	// Login: ログインしてトークンを取得する
+	res, err := ts.sendPostRequest(t, "", "/rpc.auth.v1.AuthService/Login", fmt.Sprintf(`{"email":"%s", "password":"%s"}`, "dev@example.com", "pass"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var data auth_v1.LoginResponse
+	err = json.Unmarshal([]byte(res.body), &data)
+	require.NoError(t, err, "エラーが発生しないこと")
+	token := data.Token
+
+	// 名前に共通の文字列を含むタスクを作成し、1件を完了にする
+	var created struct {
+		CreatedID string `json:"createdId"`
+	}
+	ids := make([]string, 0, 3)
+	for _, name := range []string{"Paged C", "Paged A", "Paged B"} {
+		res, err := ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CreateTask", fmt.Sprintf(`{"name":"%s"}`, name))
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+		err = json.Unmarshal([]byte(res.body), &created)
+		require.NoError(t, err, "エラーが発生しないこと")
+		ids = append(ids, created.CreatedID)
+	}
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CompleteTask", fmt.Sprintf(`{"task_id":"%s"}`, ids[2]))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	getPage := func(body string) (*task_v1.GetTaskListResponse, int) {
+		res, err := ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskList", body)
+		require.NoError(t, err, "エラーが発生しないこと")
+		var data task_v1.GetTaskListResponse
+		if res.status == 200 {
+			err = protojson.Unmarshal([]byte(res.body), &data)
+			require.NoError(t, err, "エラーが発生しないこと")
+		}
+		return &data, res.status
+	}
+	names := func(tasks []*task_v1.Task) []string {
+		ret := make([]string, 0, len(tasks))
+		for _, v := range tasks {
+			ret = append(ret, v.Name)
+		}
+		return ret
+	}
+
+	// GetTaskList: 名前順で1件ずつページングする
+	filter := `{"name_contains":"Paged"}`
+	page, status := getPage(fmt.Sprintf(`{"filter":%s, "sort_field":"TASK_SORT_FIELD_NAME", "page_size":2}`, filter))
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Equal(t, []string{"Paged A", "Paged B"}, names(page.Tasks), "名前の昇順で取得できること")
+	require.NotEmpty(t, page.NextPageToken, "次のページのトークンが返されること")
+	page, status = getPage(fmt.Sprintf(`{"filter":%s, "sort_field":"TASK_SORT_FIELD_NAME", "page_size":2, "page_token":"%s"}`, filter, page.NextPageToken))
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Equal(t, []string{"Paged C"}, names(page.Tasks), "続きのページを取得できること")
+	require.Empty(t, page.NextPageToken, "最後のページではトークンが返されないこと")
+
+	// GetTaskList: 降順を指定した場合
+	page, status = getPage(fmt.Sprintf(`{"filter":%s, "sort_field":"TASK_SORT_FIELD_NAME", "sort_direction":"SORT_DIRECTION_DESC"}`, filter))
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Equal(t, []string{"Paged C", "Paged B", "Paged A"}, names(page.Tasks), "名前の降順で取得できること")
+
+	// GetTaskList: 完了状態で絞り込む場合
+	page, status = getPage(`{"filter":{"name_contains":"Paged", "completion":"TASK_COMPLETION_CLOSED"}}`)
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Equal(t, []string{"Paged B"}, names(page.Tasks), "完了したタスクのみ取得できること")
+	page, status = getPage(`{"filter":{"name_contains":"Paged", "completion":"TASK_COMPLETION_OPEN"}, "sort_field":"TASK_SORT_FIELD_NAME"}`)
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Equal(t, []string{"Paged A", "Paged C"}, names(page.Tasks), "未完了のタスクのみ取得できること")
+
+	// GetTaskList: 並び順の異なるページトークンを指定した場合
+	page, _ = getPage(fmt.Sprintf(`{"filter":%s, "sort_field":"TASK_SORT_FIELD_NAME", "page_size":1}`, filter))
+	_, status = getPage(fmt.Sprintf(`{"filter":%s, "sort_field":"TASK_SORT_FIELD_CREATED_AT", "page_size":1, "page_token":"%s"}`, filter, page.NextPageToken))
+	require.Equal(t, 400, status, "入力エラーになること")
+
+	// GetTaskList: 不正なページトークンを指定した場合
+	_, status = getPage(`{"page_token":"invalid"}`)
+	require.Equal(t, 400, status, "入力エラーになること")
+
+	// GetTaskList: タグと同時に指定した場合
+	_, status = getPage(`{"page_size":10, "tag_ids":["tag"]}`)
+	require.Equal(t, 400, status, "入力エラーになること")
+}
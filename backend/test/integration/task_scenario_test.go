@@ -9,7 +9,6 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/7oh2020/connect-tasklist/backend/interfaces/di"
-	"github.com/7oh2020/connect-tasklist/backend/interfaces/interceptor"
 	auth_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1"
 	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1/auth_v1connect"
 	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1/task_v1connect"
@@ -18,8 +17,8 @@ import (
 
 func TestTaskScenario(t *testing.T) {
 	// テストサーバーの起動
-	authInterceptor := connect.WithInterceptors(interceptor.NewAuthInterceptor(issuer, keyPath))
-	taskHdr := di.InitTask(qry)
+	authInterceptor := connect.WithInterceptors(di.InitAuthInterceptor(issuer, keyPath, qry))
+	taskHdr := di.InitTask(qry, pool)
 	authHdr, err := di.InitAuth(issuer, keyPath, qry, timeout)
 	require.NoError(t, err, "エラーが発生しないこと")
 	mux := http.NewServeMux()
@@ -150,6 +149,256 @@ func TestTaskScenario(t *testing.T) {
 	require.NoError(t, err, "エラーが発生しないこと")
 	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
 
+	// TransitionTask: 状態が未指定の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/TransitionTask", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// TransitionTask: 他人のTaskIDの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/TransitionTask", fmt.Sprintf(`{"task_id":"%s", "status":"%s"}`, anotherTaskID, "TASK_STATUS_IN_PROGRESS"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// TransitionTask: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/TransitionTask", fmt.Sprintf(`{"task_id":"%s", "status":"%s"}`, taskID, "TASK_STATUS_IN_PROGRESS"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetTaskList: 変更した状態が取得できること
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskList", `{}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.Contains(t, res.body, `"status":"TASK_STATUS_IN_PROGRESS"`, "進行中の状態が含まれること")
+
+	// TransitionTask: 中止にする場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/TransitionTask", fmt.Sprintf(`{"task_id":"%s", "status":"%s"}`, taskID, "TASK_STATUS_CANCELLED"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// CompleteTask: 中止したタスクは完了にできないこと
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CompleteTask", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "前提条件エラーになること")
+
+	// UncompleteTask: 中止したタスクを未着手に戻す場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/UncompleteTask", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// SetTaskDueDate: 期限が空の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/SetTaskDueDate", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// SetTaskDueDate: 他人のTaskIDの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/SetTaskDueDate", fmt.Sprintf(`{"task_id":"%s", "due_at":"%s"}`, anotherTaskID, "2000-01-01T00:00:00Z"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// SetTaskDueDate: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/SetTaskDueDate", fmt.Sprintf(`{"task_id":"%s", "due_at":"%s"}`, taskID, "2000-01-01T00:00:00Z"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetOverdueTaskList: 期限切れのタスクが含まれること
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetOverdueTaskList", "{}")
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.Contains(t, res.body, taskID, "期限切れのタスクが含まれること")
+
+	// GetDueTaskList: 期間が不正な場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetDueTaskList", fmt.Sprintf(`{"from":"%s", "to":"%s"}`, "2000-01-02T00:00:00Z", "2000-01-01T00:00:00Z"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// GetDueTaskList: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetDueTaskList", fmt.Sprintf(`{"from":"%s", "to":"%s"}`, "2000-01-01T00:00:00Z", "2000-01-02T00:00:00Z"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.Contains(t, res.body, taskID, "期間内のタスクが含まれること")
+
+	// ClearTaskDueDate: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/ClearTaskDueDate", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// ChangeTaskPriority: 優先度が未指定の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/ChangeTaskPriority", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// ChangeTaskPriority: 他人のTaskIDの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/ChangeTaskPriority", fmt.Sprintf(`{"task_id":"%s", "priority":"%s"}`, anotherTaskID, "PRIORITY_URGENT"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// ChangeTaskPriority: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/ChangeTaskPriority", fmt.Sprintf(`{"task_id":"%s", "priority":"%s"}`, taskID, "PRIORITY_URGENT"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetTaskList: 優先度順の場合は緊急のタスクが先頭になること
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskList", `{"order":"TASK_ORDER_PRIORITY"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var list struct {
+		Tasks []struct {
+			ID string `json:"id"`
+		} `json:"tasks"`
+	}
+	err = json.Unmarshal([]byte(res.body), &list)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.NotEmpty(t, list.Tasks, "タスクが取得できること")
+	require.Equal(t, taskID, list.Tasks[0].ID, "優先度の高いタスクが先頭になること")
+
+	// ChangeTaskDescription: 説明が長すぎる場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/ChangeTaskDescription", fmt.Sprintf(`{"task_id":"%s", "description":"%s"}`, taskID, strings.Repeat("*", 10001)))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// ChangeTaskDescription: 他人のTaskIDの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/ChangeTaskDescription", fmt.Sprintf(`{"task_id":"%s", "description":"%s"}`, anotherTaskID, "memo"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// ChangeTaskDescription: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/ChangeTaskDescription", fmt.Sprintf(`{"task_id":"%s", "description":"%s"}`, taskID, "**memo** <script>alert(1)</script>"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetTaskList: サニタイズされたHTMLが取得できること
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskList", "{}")
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.Contains(t, res.body, `<strong>memo</strong>`, "HTMLに変換されていること")
+	require.NotContains(t, res.body, `<script>alert(1)</script></p>`, "scriptタグが除去されていること")
+
+	// CreateSubtask: 他人のTaskIDの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CreateSubtask", fmt.Sprintf(`{"parent_task_id":"%s", "name":"%s"}`, anotherTaskID, "subtask"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// CreateSubtask: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CreateSubtask", fmt.Sprintf(`{"parent_task_id":"%s", "name":"%s"}`, taskID, "subtask"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var subtask struct {
+		CreatedID string `json:"createdId"`
+	}
+	err = json.Unmarshal([]byte(res.body), &subtask)
+	require.NoError(t, err, "エラーが発生しないこと")
+
+	// GetTaskTree: サブタスクが含まれること
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskTree", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.Contains(t, res.body, subtask.CreatedID, "サブタスクが含まれること")
+
+	// MoveSubtask: 自分の子孫の下に移動する場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/MoveSubtask", fmt.Sprintf(`{"task_id":"%s", "parent_task_id":"%s"}`, taskID, subtask.CreatedID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// CompleteTask: 未完了のサブタスクが残っている場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CompleteTask", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "前提条件エラーになること")
+
+	// MoveSubtask: ルートに移動する場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/MoveSubtask", fmt.Sprintf(`{"task_id":"%s", "parent_task_id":""}`, subtask.CreatedID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// MoveSubtask: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/MoveSubtask", fmt.Sprintf(`{"task_id":"%s", "parent_task_id":"%s"}`, subtask.CreatedID, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// MoveTask: 自分自身を指定した場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/MoveTask", fmt.Sprintf(`{"task_id":"%s", "target_task_id":"%s"}`, taskID, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// MoveTask: 他人のタスクを指定した場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/MoveTask", fmt.Sprintf(`{"task_id":"%s", "target_task_id":"%s"}`, taskID, anotherTaskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// MoveTask: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/MoveTask", fmt.Sprintf(`{"task_id":"%s", "target_task_id":"%s", "placement":"%s"}`, taskID, "t2", "MOVE_PLACEMENT_AFTER"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetTaskList: 手動の並び順の場合は移動したタスクが末尾になること
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskList", `{"order":"TASK_ORDER_POSITION", "list_id":"l1"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &list)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.NotEmpty(t, list.Tasks, "タスクが取得できること")
+	require.Equal(t, taskID, list.Tasks[len(list.Tasks)-1].ID, "移動したタスクが末尾になること")
+
+	// SetTaskRecurrence: ルールが不正な場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/SetTaskRecurrence", fmt.Sprintf(`{"task_id":"%s", "rule":"%s"}`, taskID, "FREQ=HOURLY"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// SetTaskRecurrence: 他人のタスクの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/SetTaskRecurrence", fmt.Sprintf(`{"task_id":"%s", "rule":"%s"}`, anotherTaskID, "FREQ=DAILY"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// SetTaskRecurrence: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/SetTaskRecurrence", fmt.Sprintf(`{"task_id":"%s", "rule":"%s", "timezone":"%s"}`, taskID, "FREQ=DAILY", "Asia/Tokyo"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// ClearTaskRecurrence: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/ClearTaskRecurrence", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// AddTaskDependency: 自分自身に依存する場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/AddTaskDependency", fmt.Sprintf(`{"task_id":"%s", "blocker_task_id":"%s"}`, subtask.CreatedID, subtask.CreatedID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// AddTaskDependency: 他人のタスクに依存する場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/AddTaskDependency", fmt.Sprintf(`{"task_id":"%s", "blocker_task_id":"%s"}`, subtask.CreatedID, anotherTaskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// AddTaskDependency: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/AddTaskDependency", fmt.Sprintf(`{"task_id":"%s", "blocker_task_id":"%s"}`, subtask.CreatedID, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// AddTaskDependency: 依存関係が循環する場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/AddTaskDependency", fmt.Sprintf(`{"task_id":"%s", "blocker_task_id":"%s"}`, taskID, subtask.CreatedID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// GetTaskList: ブロックされているタスクが含まれること
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskList", `{"list_id":"l1"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.Contains(t, res.body, `"isBlocked":true`, "ブロックされていること")
+
+	// CompleteTask: 未完了のブロッカーが残っている場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CompleteTask", fmt.Sprintf(`{"task_id":"%s"}`, subtask.CreatedID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "前提条件エラーになること")
+
+	// RemoveTaskDependency: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/RemoveTaskDependency", fmt.Sprintf(`{"task_id":"%s", "blocker_task_id":"%s"}`, subtask.CreatedID, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// CompleteTask: ブロッカーがなくなった場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CompleteTask", fmt.Sprintf(`{"task_id":"%s"}`, subtask.CreatedID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
 	// DeleteTask: TaskIDが空の場合
 	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/DeleteTask", fmt.Sprintf(`{"task_id":"%s"}`, ""))
 	require.NoError(t, err, "エラーが発生しないこと")
@@ -174,4 +423,106 @@ func TestTaskScenario(t *testing.T) {
 	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/DeleteTask", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
 	require.NoError(t, err, "エラーが発生しないこと")
 	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetTaskList: ゴミ箱のタスクが含まれないこと
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskList", `{}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.NotContains(t, res.body, taskID, "ゴミ箱のタスクが含まれないこと")
+	require.NotContains(t, res.body, subtask.CreatedID, "ゴミ箱のサブタスクが含まれないこと")
+
+	// ListTrash: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/ListTrash", `{}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.Contains(t, res.body, taskID, "ゴミ箱のタスクが含まれること")
+	require.NotContains(t, res.body, subtask.CreatedID, "親と一緒に移動したサブタスクが含まれないこと")
+
+	// RestoreTask: ゴミ箱に存在しないTaskIDの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/RestoreTask", fmt.Sprintf(`{"task_id":"%s"}`, "another"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 404, res.status, "NotFoundエラーになること")
+
+	// RestoreTask: 親タスクがゴミ箱にある場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/RestoreTask", fmt.Sprintf(`{"task_id":"%s"}`, subtask.CreatedID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "前提条件エラーになること")
+
+	// RestoreTask: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/RestoreTask", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetTaskList: 復元したタスクがサブタスクごと含まれること
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskList", `{}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.Contains(t, res.body, taskID, "復元したタスクが含まれること")
+	require.Contains(t, res.body, subtask.CreatedID, "復元したサブタスクが含まれること")
+
+	// GetTaskHistory: 他人のTaskIDの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskHistory", fmt.Sprintf(`{"task_id":"%s"}`, anotherTaskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// GetTaskHistory: 不正なページトークンの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskHistory", fmt.Sprintf(`{"task_id":"%s","page_token":"!!"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// GetTaskHistory: 最新の履歴が復元であること
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskHistory", fmt.Sprintf(`{"task_id":"%s","page_size":1}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var history struct {
+		Histories []struct {
+			Action string `json:"action"`
+		} `json:"histories"`
+		NextPageToken string `json:"nextPageToken"`
+	}
+	err = json.Unmarshal([]byte(res.body), &history)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Len(t, history.Histories, 1, "指定した件数の履歴が取得できること")
+	require.Equal(t, "TASK_HISTORY_ACTION_RESTORED", history.Histories[0].Action, "最新の履歴が復元であること")
+	require.NotEmpty(t, history.NextPageToken, "次のページのトークンが取得できること")
+
+	// GetTaskHistory: 次のページの最初の履歴がゴミ箱への移動であること
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskHistory", fmt.Sprintf(`{"task_id":"%s","page_size":1,"page_token":"%s"}`, taskID, history.NextPageToken))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	history.Histories = nil
+	err = json.Unmarshal([]byte(res.body), &history)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Len(t, history.Histories, 1, "指定した件数の履歴が取得できること")
+	require.Equal(t, "TASK_HISTORY_ACTION_DELETED", history.Histories[0].Action, "ゴミ箱への移動の履歴であること")
+
+	// GetTaskHistory: 作成から復元までの全ての履歴が含まれること
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskHistory", fmt.Sprintf(`{"task_id":"%s","page_size":100}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.Contains(t, res.body, "TASK_HISTORY_ACTION_CREATED", "作成の履歴が含まれること")
+	require.Contains(t, res.body, "TASK_HISTORY_ACTION_RENAMED", "名前の変更の履歴が含まれること")
+	require.Contains(t, res.body, "TASK_HISTORY_ACTION_STATUS_CHANGED", "状態の変更の履歴が含まれること")
+	require.NotContains(t, res.body, "nextPageToken", "続きがないこと")
+
+	// DeleteTask: 再度ゴミ箱に移動する場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/DeleteTask", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// EmptyTrash: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/EmptyTrash", `{}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// ListTrash: 空になっていること
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/ListTrash", `{}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.NotContains(t, res.body, taskID, "完全に削除したタスクが含まれないこと")
+
+	// RestoreTask: 完全に削除したタスクの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/RestoreTask", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 404, res.status, "NotFoundエラーになること")
 }
@@ -0,0 +1,132 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/di"
+	auth_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1/auth_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/sharing/v1/sharing_v1connect"
+	task_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1/task_v1connect"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestTaskUndoScenario(t *testing.T) {
+	// テストサーバーの起動
+	authInterceptor := connect.WithInterceptors(di.InitAuthInterceptor(issuer, keyPath, qry))
+	sharingHdr := di.InitSharing(qry)
+	taskHdr := di.InitTask(qry, pool)
+	authHdr, err := di.InitAuth(issuer, keyPath, qry, timeout)
+	require.NoError(t, err, "エラーが発生しないこと")
+	mux := http.NewServeMux()
+	mux.Handle(auth_v1connect.NewAuthServiceHandler(authHdr))
+	mux.Handle(sharing_v1connect.NewSharingServiceHandler(sharingHdr, authInterceptor))
+	mux.Handle(task_v1connect.NewTaskServiceHandler(taskHdr, authInterceptor))
+	ts := newTestServer(t, mux)
+	defer ts.Close()
+
+	// Login: 所有者と共有相手でログインしてトークンを取得する
+	login := func(email string) string {
+		res, err := ts.sendPostRequest(t, "", "/rpc.auth.v1.AuthService/Login", fmt.Sprintf(`{"email":"%s", "password":"%s"}`, email, "pass"))
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+		var data auth_v1.LoginResponse
+		err = json.Unmarshal([]byte(res.body), &data)
+		require.NoError(t, err, "エラーが発生しないこと")
+		return data.Token
+	}
+	token := login("dev@example.com")
+	otherToken := login("test@example.com")
+
+	post := func(token string, method string, body string) int {
+		res, err := ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/"+method, body)
+		require.NoError(t, err, "エラーが発生しないこと")
+		return res.status
+	}
+	replay := func(method string) (*task_v1.UndoResponse, int) {
+		res, err := ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/"+method, `{}`)
+		require.NoError(t, err, "エラーが発生しないこと")
+		var data task_v1.UndoResponse
+		if res.status == 200 {
+			err = protojson.Unmarshal([]byte(res.body), &data)
+			require.NoError(t, err, "エラーが発生しないこと")
+		}
+		return &data, res.status
+	}
+
+	// CreateTask: 取り消しを試すタスクを作成する
+	var created struct {
+		CreatedID string `json:"createdId"`
+	}
+	res, err := ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CreateTask", `{"name":"Undo target"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	taskID := created.CreatedID
+	getTask := func() *task_v1.Task {
+		res, err := ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskTree", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+		var data task_v1.GetTaskTreeResponse
+		err = protojson.Unmarshal([]byte(res.body), &data)
+		require.NoError(t, err, "エラーが発生しないこと")
+		return data.Root.Task
+	}
+
+	// 名前を変更してから完了にする
+	require.Equal(t, 200, post(token, "ChangeTaskName", fmt.Sprintf(`{"task_id":"%s", "name":"Undo renamed"}`, taskID)))
+	require.Equal(t, 200, post(token, "CompleteTask", fmt.Sprintf(`{"task_id":"%s"}`, taskID)))
+
+	// Undo: 新しい操作から順に取り消すこと
+	ret, status := replay("Undo")
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Equal(t, taskID, ret.TaskId)
+	require.Equal(t, task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_STATUS_CHANGED, ret.Action)
+	require.False(t, getTask().IsCompleted, "完了が取り消されること")
+	ret, status = replay("Undo")
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Equal(t, task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_RENAMED, ret.Action)
+	require.Equal(t, "Undo target", getTask().Name, "名前の変更が取り消されること")
+
+	// Redo: 取り消した操作をやり直すこと
+	ret, status = replay("Redo")
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Equal(t, task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_RENAMED, ret.Action)
+	require.Equal(t, "Undo renamed", getTask().Name, "名前の変更がやり直されること")
+
+	// Redo: 新しい操作をした場合はやり直せないこと
+	require.Equal(t, 200, post(token, "DeleteTask", fmt.Sprintf(`{"task_id":"%s"}`, taskID)))
+	_, status = replay("Redo")
+	require.Equal(t, 404, status, "存在しないエラーになること")
+
+	// Undo: 削除を取り消すこと
+	ret, status = replay("Undo")
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Equal(t, task_v1.TaskHistoryAction_TASK_HISTORY_ACTION_DELETED, ret.Action)
+	require.Equal(t, "Undo renamed", getTask().Name, "ゴミ箱から復元されること")
+
+	// 共有相手が名前を変更する
+	res, err = ts.sendPostRequest(t, token, "/rpc.sharing.v1.SharingService/GrantAccess", fmt.Sprintf(`{"task_id":"%s", "email":"test@example.com", "role":"ROLE_EDITOR"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	require.Equal(t, 200, post(otherToken, "ChangeTaskName", fmt.Sprintf(`{"task_id":"%s", "name":"Changed by other"}`, taskID)))
+
+	// Undo: 操作後に変更されたタスクの場合は競合になり、その操作はスタックから取り除かれること
+	_, status = replay("Undo")
+	require.Equal(t, 400, status, "前提条件エラーになること")
+	require.Equal(t, "Changed by other", getTask().Name, "名前が変わらないこと")
+
+	// Undo: 作成した後に変更されたタスクの場合は作成も取り消せないこと
+	_, status = replay("Undo")
+	require.Equal(t, 400, status, "前提条件エラーになること")
+	require.Equal(t, 200, post(token, "GetTaskTree", fmt.Sprintf(`{"task_id":"%s"}`, taskID)), "タスクがゴミ箱に移動されないこと")
+	_, status = replay("Undo")
+	require.Equal(t, 404, status, "取り消す操作が残っていないこと")
+}
@@ -0,0 +1,178 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/di"
+	auth_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1/auth_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1/task_v1connect"
+	timeentry_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/timeentry/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/timeentry/v1/timeentry_v1connect"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestTimeEntryScenario(t *testing.T) {
+	// テストサーバーの起動
+	authInterceptor := connect.WithInterceptors(di.InitAuthInterceptor(issuer, keyPath, qry))
+	timeEntryHdr := di.InitTimeEntry(qry)
+	taskHdr := di.InitTask(qry, pool)
+	authHdr, err := di.InitAuth(issuer, keyPath, qry, timeout)
+	require.NoError(t, err, "エラーが発生しないこと")
+	mux := http.NewServeMux()
+	mux.Handle(auth_v1connect.NewAuthServiceHandler(authHdr))
+	mux.Handle(timeentry_v1connect.NewTimeEntryServiceHandler(timeEntryHdr, authInterceptor))
+	mux.Handle(task_v1connect.NewTaskServiceHandler(taskHdr, authInterceptor))
+	ts := newTestServer(t, mux)
+	defer ts.Close()
+
+	// Login: ログインしてトークンを取得する
+	res, err := ts.sendPostRequest(t, "", "/rpc.auth.v1.AuthService/Login", fmt.Sprintf(`{"email":"%s", "password":"%s"}`, "dev@example.com", "pass"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var data auth_v1.LoginResponse
+	err = json.Unmarshal([]byte(res.body), &data)
+	require.NoError(t, err, "エラーが発生しないこと")
+	token := data.Token
+
+	// 作業時間を記録するタスクを作成する
+	var created struct {
+		CreatedID string `json:"createdId"`
+	}
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CreateTask", `{"name":"Billable task"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	taskID := created.CreatedID
+
+	// StartTimer: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.timeentry.v1.TimeEntryService/StartTimer", fmt.Sprintf(`{"task_id":"%s", "note":"coding"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	timerID := created.CreatedID
+
+	// StartTimer: 計測中のタイマーがある場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.timeentry.v1.TimeEntryService/StartTimer", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 409, res.status, "重複エラーになること")
+
+	// GetRunningTimer: 計測中のタイマーが取得できること
+	res, err = ts.sendPostRequest(t, token, "/rpc.timeentry.v1.TimeEntryService/GetRunningTimer", `{}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var running timeentry_v1.GetRunningTimerResponse
+	err = protojson.Unmarshal([]byte(res.body), &running)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, timerID, running.TimeEntry.Id)
+	require.Nil(t, running.TimeEntry.EndedAt, "終了日時が省略されること")
+
+	// StopTimer: 計測中のタイマーを停止する
+	res, err = ts.sendPostRequest(t, token, "/rpc.timeentry.v1.TimeEntryService/StopTimer", `{}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var stopped timeentry_v1.StopTimerResponse
+	err = protojson.Unmarshal([]byte(res.body), &stopped)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, timerID, stopped.TimeEntry.Id)
+	require.NotNil(t, stopped.TimeEntry.EndedAt, "終了日時が設定されること")
+
+	// StopTimer: 計測していない場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.timeentry.v1.TimeEntryService/StopTimer", `{}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 404, res.status, "存在しないエラーになること")
+
+	// CreateTimeEntry: 昨日の作業時間を手動で記録する
+	now := time.Now().UTC()
+	startedAt := time.Date(now.Year(), now.Month(), now.Day()-1, 9, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(90 * time.Minute)
+	res, err = ts.sendPostRequest(t, token, "/rpc.timeentry.v1.TimeEntryService/CreateTimeEntry", fmt.Sprintf(`{"task_id":"%s", "started_at":"%s", "ended_at":"%s", "note":"=review"}`, taskID, startedAt.Format(time.RFC3339), endedAt.Format(time.RFC3339)))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	manualID := created.CreatedID
+
+	// CreateTimeEntry: 未来の日時の場合
+	future := now.Add(time.Hour)
+	res, err = ts.sendPostRequest(t, token, "/rpc.timeentry.v1.TimeEntryService/CreateTimeEntry", fmt.Sprintf(`{"task_id":"%s", "started_at":"%s", "ended_at":"%s"}`, taskID, now.Add(-time.Hour).Format(time.RFC3339), future.Format(time.RFC3339)))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// UpdateTimeEntry: 終了日時を変更して2時間にする
+	endedAt = startedAt.Add(2 * time.Hour)
+	res, err = ts.sendPostRequest(t, token, "/rpc.timeentry.v1.TimeEntryService/UpdateTimeEntry", fmt.Sprintf(`{"time_entry_id":"%s", "started_at":"%s", "ended_at":"%s", "note":"=review"}`, manualID, startedAt.Format(time.RFC3339), endedAt.Format(time.RFC3339)))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetTimeSummary: 昨日と今日の作業時間を集計する
+	timeRange := fmt.Sprintf(`{"from":"%s", "to":"%s", "timezone":"UTC"}`, startedAt.Add(-9*time.Hour).Format(time.RFC3339), now.Add(time.Minute).Format(time.RFC3339))
+	res, err = ts.sendPostRequest(t, token, "/rpc.timeentry.v1.TimeEntryService/GetTimeSummary", fmt.Sprintf(`{"range":%s}`, timeRange))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var summary timeentry_v1.GetTimeSummaryResponse
+	err = protojson.Unmarshal([]byte(res.body), &summary)
+	require.NoError(t, err, "エラーが発生しないこと")
+	var taskSeconds int64
+	for _, v := range summary.Tasks {
+		if v.TaskId == taskID {
+			taskSeconds = v.DurationSeconds
+			require.Equal(t, "Billable task", v.TaskName)
+		}
+	}
+	require.GreaterOrEqual(t, taskSeconds, int64(7200), "手動で記録した時間とタイマーの時間が合計されること")
+	require.NotEmpty(t, summary.Days, "日ごとに集計されること")
+	require.Equal(t, startedAt.Format(time.DateOnly), summary.Days[0].Date, "昨日の作業時間が含まれること")
+
+	// GetTimeSummary: 期間を指定しない場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.timeentry.v1.TimeEntryService/GetTimeSummary", `{}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "入力エラーになること")
+
+	// ExportTimeEntries: CSVで出力する
+	res, err = ts.sendPostRequest(t, token, "/rpc.timeentry.v1.TimeEntryService/ExportTimeEntries", fmt.Sprintf(`{"range":%s}`, timeRange))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var export timeentry_v1.ExportTimeEntriesResponse
+	err = protojson.Unmarshal([]byte(res.body), &export)
+	require.NoError(t, err, "エラーが発生しないこと")
+	csv := string(export.Content)
+	require.True(t, strings.HasPrefix(csv, "id,task_id,task_name,date,started_at,ended_at,duration_seconds,note\n"), "ヘッダ行が出力されること")
+	require.Contains(t, csv, fmt.Sprintf("%s,%s,Billable task,%s,", manualID, taskID, startedAt.Format(time.DateOnly)))
+	require.Contains(t, csv, ",7200,'=review\n", "数式として解釈されないこと")
+
+	// Login: 別のユーザーでログインする
+	res, err = ts.sendPostRequest(t, "", "/rpc.auth.v1.AuthService/Login", fmt.Sprintf(`{"email":"%s", "password":"%s"}`, "test@example.com", "pass"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &data)
+	require.NoError(t, err, "エラーが発生しないこと")
+	anotherToken := data.Token
+
+	// StartTimer: 共有されていないタスクの場合
+	res, err = ts.sendPostRequest(t, anotherToken, "/rpc.timeentry.v1.TimeEntryService/StartTimer", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// DeleteTimeEntry: 他人の作業時間の場合
+	res, err = ts.sendPostRequest(t, anotherToken, "/rpc.timeentry.v1.TimeEntryService/DeleteTimeEntry", fmt.Sprintf(`{"time_entry_id":"%s"}`, manualID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// DeleteTimeEntry: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.timeentry.v1.TimeEntryService/DeleteTimeEntry", fmt.Sprintf(`{"time_entry_id":"%s"}`, manualID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	res, err = ts.sendPostRequest(t, token, "/rpc.timeentry.v1.TimeEntryService/DeleteTimeEntry", fmt.Sprintf(`{"time_entry_id":"%s"}`, manualID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 404, res.status, "削除した作業時間は存在しないこと")
+}
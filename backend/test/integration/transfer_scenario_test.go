@@ -0,0 +1,159 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/di"
+	auth_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1/auth_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1/task_v1connect"
+	transfer_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/transfer/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/transfer/v1/transfer_v1connect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferScenario(t *testing.T) {
+	// テストサーバーの起動
+	authInterceptor := connect.WithInterceptors(di.InitAuthInterceptor(issuer, keyPath, qry))
+	transferHdr := di.InitTransfer(qry, pool)
+	taskHdr := di.InitTask(qry, pool)
+	authHdr, err := di.InitAuth(issuer, keyPath, qry, timeout)
+	require.NoError(t, err, "エラーが発生しないこと")
+	mux := http.NewServeMux()
+	mux.Handle(auth_v1connect.NewAuthServiceHandler(authHdr))
+	mux.Handle(transfer_v1connect.NewTransferServiceHandler(transferHdr, authInterceptor))
+	mux.Handle(task_v1connect.NewTaskServiceHandler(taskHdr, authInterceptor))
+	ts := newTestServer(t, mux)
+	defer ts.Close()
+
+	// インポートとエクスポートはストリーミングのためクライアントを使用する
+	ctx := context.Background()
+	client := transfer_v1connect.NewTransferServiceClient(ts.Client(), ts.URL)
+	devInboxID := "l2"
+	anotherInboxID := "l1"
+
+	// Login: ログインしてトークンを取得する
+	res, err := ts.sendPostRequest(t, "", "/rpc.auth.v1.AuthService/Login", fmt.Sprintf(`{"email":"%s", "password":"%s"}`, "dev@example.com", "pass"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var data auth_v1.LoginResponse
+	err = json.Unmarshal([]byte(res.body), &data)
+	require.NoError(t, err, "エラーが発生しないこと")
+	token := data.Token
+
+	// ファイルをチャンクに分割してインポートする
+	importTasks := func(format transfer_v1.TaskFileFormat, listID string, dryRun bool, content string) (*connect.Response[transfer_v1.ImportTasksResponse], error) {
+		stream := client.ImportTasks(ctx)
+		stream.RequestHeader().Set("Authorization", "Bearer "+token)
+		err := stream.Send(&transfer_v1.ImportTasksRequest{
+			Data: &transfer_v1.ImportTasksRequest_Metadata{Metadata: &transfer_v1.ImportTasksMetadata{Format: format, ListId: listID, DryRun: dryRun}},
+		})
+		b := []byte(content)
+		for err == nil && len(b) > 0 {
+			n := min(len(b), 16)
+			err = stream.Send(&transfer_v1.ImportTasksRequest{
+				Data: &transfer_v1.ImportTasksRequest_Chunk{Chunk: b[:n]},
+			})
+			b = b[n:]
+		}
+		return stream.CloseAndReceive()
+	}
+	// エクスポートしたチャンクを結合して返す
+	exportTasks := func(format transfer_v1.TaskFileFormat, listID string) (string, error) {
+		req := connect.NewRequest(&transfer_v1.ExportTasksRequest{Format: format, ListId: listID})
+		req.Header().Set("Authorization", "Bearer "+token)
+		stream, err := client.ExportTasks(ctx, req)
+		if err != nil {
+			return "", err
+		}
+		var received bytes.Buffer
+		for stream.Receive() {
+			received.Write(stream.Msg().Chunk)
+		}
+		return received.String(), stream.Err()
+	}
+
+	// CreateTask: 重複を判定するためのタスクを作成する
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CreateTask", `{"name":"Transfer Existing"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	checklist := strings.Join([]string{
+		"# Transfer",
+		"- [ ] Transfer Existing",
+		"- [ ] Transfer <A>",
+		"- [x] Transfer B",
+		"- Transfer plain",
+		"",
+	}, "\n")
+
+	// ImportTasks: 試行の場合は作成される予定の結果を返すこと
+	ret, err := importTasks(transfer_v1.TaskFileFormat_TASK_FILE_FORMAT_MARKDOWN, "", true, checklist)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.True(t, ret.Msg.DryRun)
+	require.Equal(t, int32(2), ret.Msg.CreatedCount)
+	require.Equal(t, int32(1), ret.Msg.DuplicateCount, "既存のタスクと同じ名前は重複になること")
+	require.Equal(t, int32(1), ret.Msg.FailedCount)
+	require.Equal(t, int32(5), ret.Msg.Results[3].Line, "行番号が返されること")
+	require.Equal(t, "invalid_argument", ret.Msg.Results[3].ErrorCode)
+	for _, v := range ret.Msg.Results {
+		require.Empty(t, v.TaskId, "試行ではタスクを作成しないこと")
+	}
+	exported, err := exportTasks(transfer_v1.TaskFileFormat_TASK_FILE_FORMAT_MARKDOWN, devInboxID)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.NotContains(t, exported, "Transfer B", "試行ではタスクを作成しないこと")
+
+	// ImportTasks: 正しい入力の場合
+	ret, err = importTasks(transfer_v1.TaskFileFormat_TASK_FILE_FORMAT_MARKDOWN, "", false, checklist)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.False(t, ret.Msg.DryRun)
+	require.Equal(t, int32(2), ret.Msg.CreatedCount)
+	require.Equal(t, transfer_v1.TaskImportStatus_TASK_IMPORT_STATUS_CREATED, ret.Msg.Results[1].Status)
+	require.NotEmpty(t, ret.Msg.Results[1].TaskId, "作成したタスクのIDが返されること")
+
+	// ImportTasks: 同じファイルを再度インポートする場合
+	ret, err = importTasks(transfer_v1.TaskFileFormat_TASK_FILE_FORMAT_MARKDOWN, devInboxID, false, checklist)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, int32(0), ret.Msg.CreatedCount)
+	require.Equal(t, int32(3), ret.Msg.DuplicateCount, "インポートしたタスクも重複になること")
+
+	// ExportTasks: Inboxのタスクを並び順にMarkdownで出力する
+	exported, err = exportTasks(transfer_v1.TaskFileFormat_TASK_FILE_FORMAT_MARKDOWN, devInboxID)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Contains(t, exported, "- [ ] Transfer <A>\n- [x] Transfer B\n", "エスケープを戻し、ファイルの順に並ぶこと")
+
+	// ExportTasks: 全てのタスクをtodo.txtで出力し、CSVでインポートする
+	exported, err = exportTasks(transfer_v1.TaskFileFormat_TASK_FILE_FORMAT_TODO_TXT, "")
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Contains(t, exported, " Transfer B\n", "完了したタスクが出力されること")
+	ret, err = importTasks(transfer_v1.TaskFileFormat_TASK_FILE_FORMAT_CSV, "", false, "name,priority,due_at\nTransfer C,urgent,2030-01-01\n")
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, int32(1), ret.Msg.CreatedCount)
+	exported, err = exportTasks(transfer_v1.TaskFileFormat_TASK_FILE_FORMAT_TODO_TXT, "")
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Contains(t, exported, "Transfer C due:2030-01-01\n", "期限が出力されること")
+	require.Contains(t, exported, "(A) ", "優先度が出力されること")
+
+	// ImportTasks: 他人のリストの場合
+	_, err = importTasks(transfer_v1.TaskFileFormat_TASK_FILE_FORMAT_JSON, anotherInboxID, false, `[{"name":"Transfer D"}]`)
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), "パーミッションエラーになること")
+
+	// ImportTasks: JSONが不正な場合
+	_, err = importTasks(transfer_v1.TaskFileFormat_TASK_FILE_FORMAT_JSON, "", false, `{"name":"Transfer D"}`)
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "入力エラーになること")
+
+	// ExportTasks: 他人のリストの場合
+	_, err = exportTasks(transfer_v1.TaskFileFormat_TASK_FILE_FORMAT_CSV, anotherInboxID)
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), "パーミッションエラーになること")
+
+	// ExportTasks: 形式を指定しない場合
+	_, err = exportTasks(transfer_v1.TaskFileFormat_TASK_FILE_FORMAT_UNSPECIFIED, devInboxID)
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "入力エラーになること")
+}
@@ -0,0 +1,169 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/di"
+	auth_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1/auth_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1/task_v1connect"
+	view_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/view/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/view/v1/view_v1connect"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestViewScenario(t *testing.T) {
+	// テストサーバーの起動
+	authInterceptor := connect.WithInterceptors(di.InitAuthInterceptor(issuer, keyPath, qry))
+	viewHdr := di.InitView(qry)
+	taskHdr := di.InitTask(qry, pool)
+	authHdr, err := di.InitAuth(issuer, keyPath, qry, timeout)
+	require.NoError(t, err, "エラーが発生しないこと")
+	mux := http.NewServeMux()
+	mux.Handle(auth_v1connect.NewAuthServiceHandler(authHdr))
+	mux.Handle(view_v1connect.NewViewServiceHandler(viewHdr, authInterceptor))
+	mux.Handle(task_v1connect.NewTaskServiceHandler(taskHdr, authInterceptor))
+	ts := newTestServer(t, mux)
+	defer ts.Close()
+
+	// Login: ログインしてトークンを取得する
+	res, err := ts.sendPostRequest(t, "", "/rpc.auth.v1.AuthService/Login", fmt.Sprintf(`{"email":"%s", "password":"%s"}`, "dev@example.com", "pass"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var data auth_v1.LoginResponse
+	err = json.Unmarshal([]byte(res.body), &data)
+	require.NoError(t, err, "エラーが発生しないこと")
+	token := data.Token
+
+	// タスクを作成する。1件は今日が期限、1件は期限なしにする
+	var created struct {
+		CreatedID string `json:"createdId"`
+	}
+	createTask := func(name string) string {
+		res, err := ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CreateTask", fmt.Sprintf(`{"name":"%s"}`, name))
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+		err = json.Unmarshal([]byte(res.body), &created)
+		require.NoError(t, err, "エラーが発生しないこと")
+		return created.CreatedID
+	}
+	dueTaskID := createTask("Viewable due today")
+	noDueTaskID := createTask("Viewable no due")
+	now := time.Now().UTC()
+	dueAt := time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, time.UTC)
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/SetTaskDueDate", fmt.Sprintf(`{"task_id":"%s", "due_at":"%s"}`, dueTaskID, dueAt.Format(time.RFC3339)))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	execute := func(body string) (*view_v1.ExecuteViewResponse, int) {
+		res, err := ts.sendPostRequest(t, token, "/rpc.view.v1.ViewService/ExecuteView", body)
+		require.NoError(t, err, "エラーが発生しないこと")
+		var data view_v1.ExecuteViewResponse
+		if res.status == 200 {
+			err = protojson.Unmarshal([]byte(res.body), &data)
+			require.NoError(t, err, "エラーが発生しないこと")
+		}
+		return &data, res.status
+	}
+	taskIDs := func(data *view_v1.ExecuteViewResponse) []string {
+		ret := []string{}
+		for _, g := range data.Groups {
+			for _, v := range g.Tasks {
+				ret = append(ret, v.Id)
+			}
+		}
+		return ret
+	}
+
+	// GetViewList: 組み込みのビューが取得できること
+	res, err = ts.sendPostRequest(t, token, "/rpc.view.v1.ViewService/GetViewList", `{}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var list view_v1.GetViewListResponse
+	err = protojson.Unmarshal([]byte(res.body), &list)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.GreaterOrEqual(t, len(list.Views), 3)
+	require.Equal(t, []string{"Today", "Completed this week", "No due date"}, []string{list.Views[0].Name, list.Views[1].Name, list.Views[2].Name}, "組み込みのビューが先頭に並ぶこと")
+
+	// ExecuteView: 組み込みの今日のビューの場合
+	page, status := execute(`{"view_id":"builtin-today", "timezone":"UTC", "page_size":100}`)
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Contains(t, taskIDs(page), dueTaskID, "今日が期限のタスクが含まれること")
+	require.NotContains(t, taskIDs(page), noDueTaskID, "期限なしのタスクが含まれないこと")
+
+	// ExecuteView: 組み込みの期限なしのビューの場合
+	page, status = execute(`{"view_id":"builtin-no-due-date", "page_size":100}`)
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Contains(t, taskIDs(page), noDueTaskID, "期限なしのタスクが含まれること")
+	require.NotContains(t, taskIDs(page), dueTaskID, "期限のあるタスクが含まれないこと")
+
+	// ExecuteView: タイムゾーンが不正な場合
+	_, status = execute(`{"view_id":"builtin-today", "timezone":"Mars/Olympus"}`)
+	require.Equal(t, 400, status, "入力エラーになること")
+
+	// CreateView: 名前で絞り込み、期限の日付でまとめるビューを作成する
+	query := `{"name_contains":"Viewable", "sort_field":"TASK_SORT_FIELD_NAME", "group_by":"VIEW_GROUP_BY_DUE_DATE"}`
+	res, err = ts.sendPostRequest(t, token, "/rpc.view.v1.ViewService/CreateView", fmt.Sprintf(`{"name":"Viewable tasks", "query":%s}`, query))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	viewID := created.CreatedID
+
+	// CreateView: 同じ名前のビューの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.view.v1.ViewService/CreateView", fmt.Sprintf(`{"name":"Viewable tasks", "query":%s}`, query))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 409, res.status, "重複エラーになること")
+
+	// ExecuteView: 保存したビューの場合
+	page, status = execute(fmt.Sprintf(`{"view_id":"%s", "page_size":1}`, viewID))
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Len(t, page.Groups, 1)
+	require.Equal(t, dueAt.Format(time.DateOnly), page.Groups[0].Key, "期限の日付でまとめられること")
+	require.Equal(t, []string{dueTaskID}, taskIDs(page), "名前順の最初のタスクが取得できること")
+	require.NotEmpty(t, page.NextPageToken, "次のページのトークンが返されること")
+	page, status = execute(fmt.Sprintf(`{"view_id":"%s", "page_size":1, "page_token":"%s"}`, viewID, page.NextPageToken))
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Equal(t, "", page.Groups[0].Key, "期限なしのタスクのキーが空になること")
+	require.Equal(t, []string{noDueTaskID}, taskIDs(page), "続きのタスクが取得できること")
+	require.Empty(t, page.NextPageToken, "最後のページではトークンが返されないこと")
+
+	// UpdateView: 組み込みのビューの場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.view.v1.ViewService/UpdateView", `{"view_id":"builtin-today", "name":"Mine"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "事前条件エラーになること")
+
+	// UpdateView: 期限なしのみに絞り込む
+	res, err = ts.sendPostRequest(t, token, "/rpc.view.v1.ViewService/UpdateView", fmt.Sprintf(`{"view_id":"%s", "name":"Viewable without due", "query":{"name_contains":"Viewable", "due":"VIEW_PERIOD_UNSET"}}`, viewID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	page, status = execute(fmt.Sprintf(`{"view_id":"%s"}`, viewID))
+	require.Equal(t, 200, status, "ステータスコードが正常であること")
+	require.Equal(t, []string{noDueTaskID}, taskIDs(page), "変更した条件で取得できること")
+
+	// Login: 別のユーザーでログインする
+	res, err = ts.sendPostRequest(t, "", "/rpc.auth.v1.AuthService/Login", fmt.Sprintf(`{"email":"%s", "password":"%s"}`, "test@example.com", "pass"))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &data)
+	require.NoError(t, err, "エラーが発生しないこと")
+	anotherToken := data.Token
+
+	// ExecuteView: 他人のビューの場合
+	res, err = ts.sendPostRequest(t, anotherToken, "/rpc.view.v1.ViewService/ExecuteView", fmt.Sprintf(`{"view_id":"%s"}`, viewID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// DeleteView: 正しい入力の場合
+	res, err = ts.sendPostRequest(t, token, "/rpc.view.v1.ViewService/DeleteView", fmt.Sprintf(`{"view_id":"%s"}`, viewID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	_, status = execute(fmt.Sprintf(`{"view_id":"%s"}`, viewID))
+	require.Equal(t, 404, status, "削除したビューは実行できないこと")
+}
@@ -0,0 +1,190 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/di"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/interceptor"
+	auth_v1 "github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/auth/v1/auth_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/list/v1/list_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/task/v1/task_v1connect"
+	"github.com/7oh2020/connect-tasklist/backend/interfaces/rpc/workspace/v1/workspace_v1connect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceScenario(t *testing.T) {
+	// テストサーバーの起動
+	authInterceptor := connect.WithInterceptors(di.InitAuthInterceptor(issuer, keyPath, qry))
+	workspaceHdr := di.InitWorkspace(qry, pool)
+	listHdr := di.InitList(qry)
+	taskHdr := di.InitTask(qry, pool)
+	authHdr, err := di.InitAuth(issuer, keyPath, qry, timeout)
+	require.NoError(t, err, "エラーが発生しないこと")
+	mux := http.NewServeMux()
+	mux.Handle(auth_v1connect.NewAuthServiceHandler(authHdr))
+	mux.Handle(workspace_v1connect.NewWorkspaceServiceHandler(workspaceHdr, authInterceptor))
+	mux.Handle(list_v1connect.NewListServiceHandler(listHdr, authInterceptor))
+	mux.Handle(task_v1connect.NewTaskServiceHandler(taskHdr, authInterceptor))
+	ts := newTestServer(t, mux)
+	defer ts.Close()
+
+	// Login: 所有者と招待するユーザーでログインしてトークンを取得する
+	login := func(email string) string {
+		res, err := ts.sendPostRequest(t, "", "/rpc.auth.v1.AuthService/Login", fmt.Sprintf(`{"email":"%s", "password":"%s"}`, email, "pass"))
+		require.NoError(t, err, "エラーが発生しないこと")
+		require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+		var data auth_v1.LoginResponse
+		err = json.Unmarshal([]byte(res.body), &data)
+		require.NoError(t, err, "エラーが発生しないこと")
+		return data.Token
+	}
+	token := login("dev@example.com")
+	otherToken := login("test@example.com")
+	var created struct {
+		CreatedID string `json:"createdId"`
+	}
+
+	// CreateWorkspace: ワークスペースを作成する
+	res, err := ts.sendPostRequest(t, token, "/rpc.workspace.v1.WorkspaceService/CreateWorkspace", `{"name":"Team"}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	workspaceID := created.CreatedID
+	header := http.Header{}
+	header.Set(interceptor.WorkspaceHeader, workspaceID)
+
+	// GetLists: メンバーではない場合はインターセプタで拒否されること
+	res, err = ts.sendPostRequestWithHeader(t, otherToken, "/rpc.list.v1.ListService/GetLists", `{}`, header)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// CreateList: ワークスペースにリストを作成する
+	res, err = ts.sendPostRequestWithHeader(t, token, "/rpc.list.v1.ListService/CreateList", `{"name":"Backlog"}`, header)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	listID := created.CreatedID
+
+	// CreateTask: ワークスペースのリストにタスクを作成する
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/CreateTask", fmt.Sprintf(`{"name":"Team Task", "list_id":"%s"}`, listID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	taskID := created.CreatedID
+
+	// GetTaskList: 個人のタスクにはワークスペースのタスクが含まれないこと
+	var tasks struct {
+		Tasks []struct {
+			ID string `json:"id"`
+		} `json:"tasks"`
+	}
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/GetTaskList", `{}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &tasks)
+	require.NoError(t, err, "エラーが発生しないこと")
+	for _, v := range tasks.Tasks {
+		require.NotEqual(t, taskID, v.ID, "ワークスペースのタスクが含まれないこと")
+	}
+
+	// InviteMember: 存在するユーザーをメンバーとして招待する
+	res, err = ts.sendPostRequest(t, token, "/rpc.workspace.v1.WorkspaceService/InviteMember", fmt.Sprintf(`{"workspace_id":"%s", "email":"test@example.com", "role":"WORKSPACE_ROLE_MEMBER"}`, workspaceID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetInvitationList: 招待されたユーザーは自分宛ての招待を取得できること
+	res, err = ts.sendPostRequest(t, otherToken, "/rpc.workspace.v1.WorkspaceService/GetInvitationList", `{}`)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	var invitations struct {
+		Invitations []struct {
+			ID            string `json:"id"`
+			WorkspaceName string `json:"workspaceName"`
+		} `json:"invitations"`
+	}
+	err = json.Unmarshal([]byte(res.body), &invitations)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Len(t, invitations.Invitations, 1)
+	require.Equal(t, "Team", invitations.Invitations[0].WorkspaceName)
+
+	// AcceptInvitation: 招待を承諾してメンバーになる
+	res, err = ts.sendPostRequest(t, otherToken, "/rpc.workspace.v1.WorkspaceService/AcceptInvitation", fmt.Sprintf(`{"invitation_id":"%s"}`, invitations.Invitations[0].ID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetTaskList: メンバーはワークスペースのタスクを取得できること
+	res, err = ts.sendPostRequestWithHeader(t, otherToken, "/rpc.task.v1.TaskService/GetTaskList", `{}`, header)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &tasks)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Len(t, tasks.Tasks, 1)
+	require.Equal(t, taskID, tasks.Tasks[0].ID)
+
+	// ChangeTaskName: メンバーはワークスペースのタスクを編集できること
+	res, err = ts.sendPostRequest(t, otherToken, "/rpc.task.v1.TaskService/ChangeTaskName", fmt.Sprintf(`{"task_id":"%s", "name":"Renamed"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// DeleteTask: メンバーはワークスペースのタスクを削除できないこと
+	res, err = ts.sendPostRequest(t, otherToken, "/rpc.task.v1.TaskService/DeleteTask", fmt.Sprintf(`{"task_id":"%s"}`, taskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// InviteMember: メンバーは招待できないこと
+	res, err = ts.sendPostRequest(t, otherToken, "/rpc.workspace.v1.WorkspaceService/InviteMember", fmt.Sprintf(`{"workspace_id":"%s", "email":"admin@example.com", "role":"WORKSPACE_ROLE_MEMBER"}`, workspaceID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// CreateList, CreateTask: メンバーがワークスペースにリストとタスクを作成する
+	res, err = ts.sendPostRequestWithHeader(t, otherToken, "/rpc.list.v1.ListService/CreateList", `{"name":"Member List"}`, header)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	memberListID := created.CreatedID
+	res, err = ts.sendPostRequest(t, otherToken, "/rpc.task.v1.TaskService/CreateTask", fmt.Sprintf(`{"name":"Member Task", "list_id":"%s"}`, memberListID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+	err = json.Unmarshal([]byte(res.body), &created)
+	require.NoError(t, err, "エラーが発生しないこと")
+	memberTaskID := created.CreatedID
+
+	// RemoveMember: 所有者は脱退できないこと
+	res, err = ts.sendPostRequest(t, token, "/rpc.workspace.v1.WorkspaceService/RemoveMember", fmt.Sprintf(`{"workspace_id":"%s", "user_id":"dev"}`, workspaceID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 400, res.status, "前提条件エラーになること")
+
+	// RemoveMember: メンバーは脱退できること
+	res, err = ts.sendPostRequest(t, otherToken, "/rpc.workspace.v1.WorkspaceService/RemoveMember", fmt.Sprintf(`{"workspace_id":"%s", "user_id":"test"}`, workspaceID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// GetTaskList: 脱退後はインターセプタで拒否されること
+	res, err = ts.sendPostRequestWithHeader(t, otherToken, "/rpc.task.v1.TaskService/GetTaskList", `{}`, header)
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// ChangeTaskName: 脱退後は自分が作成したタスクも編集できないこと
+	res, err = ts.sendPostRequest(t, otherToken, "/rpc.task.v1.TaskService/ChangeTaskName", fmt.Sprintf(`{"task_id":"%s", "name":"Renamed"}`, memberTaskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 403, res.status, "パーミッションエラーになること")
+
+	// ChangeTaskName: 所有者は脱退したメンバーが作成したタスクを編集できること
+	res, err = ts.sendPostRequest(t, token, "/rpc.task.v1.TaskService/ChangeTaskName", fmt.Sprintf(`{"task_id":"%s", "name":"Renamed"}`, memberTaskID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+
+	// DeleteWorkspace: 所有者はワークスペースを削除できること
+	res, err = ts.sendPostRequest(t, token, "/rpc.workspace.v1.WorkspaceService/DeleteWorkspace", fmt.Sprintf(`{"workspace_id":"%s"}`, workspaceID))
+	require.NoError(t, err, "エラーが発生しないこと")
+	require.Equal(t, 200, res.status, "ステータスコードが正常であること")
+}
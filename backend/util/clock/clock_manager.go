@@ -1,11 +1,19 @@
 package clock
 
-import "time"
+import (
+	"errors"
+	"time"
+
+	// 実行環境にタイムゾーンのデータベースがない場合に備えて埋め込む
+	_ "time/tzdata"
+)
 
 // 時刻を操作する
 type IClockManager interface {
 	// 現在時刻を取得する
 	GetNow() time.Time
+	// IANAのタイムゾーン名からロケーションを取得する
+	LoadLocation(name string) (*time.Location, error)
 }
 
 type ClockManager struct{}
@@ -17,3 +25,11 @@ func NewClockManager() *ClockManager {
 func (m *ClockManager) GetNow() time.Time {
 	return time.Now().UTC()
 }
+
+func (m *ClockManager) LoadLocation(name string) (*time.Location, error) {
+	// 空文字とLocalはサーバーの設定に依存するため許可しない
+	if name == "" || name == "Local" {
+		return nil, errors.New("unknown time zone " + name)
+	}
+	return time.LoadLocation(name)
+}
@@ -0,0 +1,34 @@
+package clock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockManager_LoadLocation(tt *testing.T) {
+	testcases := []struct {
+		title string
+		name  string
+		ok    bool
+	}{
+		{"正常系: UTCの場合", "UTC", true},
+		{"正常系: IANAのタイムゾーン名の場合", "Asia/Tokyo", true},
+		{"準正常系: 空文字の場合", "", false},
+		{"準正常系: Localの場合", "Local", false},
+		{"準正常系: 存在しないタイムゾーン名の場合", "Mars/Olympus", false},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			m := NewClockManager()
+			loc, err := m.LoadLocation(v.name)
+
+			if v.ok {
+				require.NoError(t, err, "エラーが発生しないこと")
+				require.Equal(t, v.name, loc.String(), "期待通りの値であること")
+			} else {
+				require.Error(t, err, "エラーが発生すること")
+			}
+		})
+	}
+}
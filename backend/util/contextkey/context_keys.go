@@ -6,4 +6,6 @@ type contextKey string
 const (
 	// ユーザーを識別するID。認証後にコンテキストにセットされる
 	ContextKeyUserID contextKey = "ctx-user-id"
+	// 操作対象のワークスペースのID。メンバーであることを検証した後にコンテキストにセットされる
+	ContextKeyWorkspaceID contextKey = "ctx-workspace-id"
 )
@@ -9,6 +9,8 @@ import (
 type IContextReader interface {
 	// UserIDをコンテキストから取得する
 	GetUserID(ctx context.Context) (string, error)
+	// WorkspaceIDをコンテキストから取得する。個人のリストを操作する場合は空文字を返す
+	GetWorkspaceID(ctx context.Context) string
 }
 
 type ContextReader struct{}
@@ -26,3 +28,10 @@ func (r *ContextReader) GetUserID(ctx context.Context) (string, error) {
 	}
 	return "", errors.New("error: context value not found for user-id")
 }
+
+func (r *ContextReader) GetWorkspaceID(ctx context.Context) string {
+	if workspaceID, ok := ctx.Value(ContextKeyWorkspaceID).(string); ok {
+		return workspaceID
+	}
+	return ""
+}
@@ -31,3 +31,22 @@ func TestContextReader_GetUserID(tt *testing.T) {
 
 	}
 }
+
+func TestContextReader_GetWorkspaceID(tt *testing.T) {
+	ctx := context.WithValue(context.Background(), ContextKeyWorkspaceID, "wid")
+
+	testcases := []struct {
+		title string
+		ctx   context.Context
+		res   string
+	}{
+		{title: "正常系: コンテキストがセットされている場合", ctx: ctx, res: "wid"},
+		{title: "正常系: コンテキストがセットされていない場合は空文字を返すこと", ctx: context.Background(), res: ""},
+	}
+	for _, tc := range testcases {
+		tt.Run(tc.title, func(t *testing.T) {
+			cr := NewContextReader()
+			require.Equal(t, tc.res, cr.GetWorkspaceID(tc.ctx))
+		})
+	}
+}
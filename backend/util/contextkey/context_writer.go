@@ -8,6 +8,8 @@ import (
 type IContextWriter interface {
 	// UserIDをコンテキストにセットする
 	SetUserID(ctx context.Context, userID string) context.Context
+	// WorkspaceIDをコンテキストにセットする
+	SetWorkspaceID(ctx context.Context, workspaceID string) context.Context
 }
 
 type ContextWriter struct{}
@@ -19,3 +21,7 @@ func NewContextWriter() *ContextWriter {
 func (w *ContextWriter) SetUserID(ctx context.Context, userID string) context.Context {
 	return context.WithValue(ctx, ContextKeyUserID, userID)
 }
+
+func (w *ContextWriter) SetWorkspaceID(ctx context.Context, workspaceID string) context.Context {
+	return context.WithValue(ctx, ContextKeyWorkspaceID, workspaceID)
+}
@@ -0,0 +1,38 @@
+package identification
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// 秘密のトークンのバイト数
+const secretSize = 32
+
+// URLに含めて使う推測できないトークンの操作
+type ISecretManager interface {
+	// URLに使える文字のみのトークンの生成
+	GenerateSecret() (string, error)
+	// 保存と照合のためのハッシュ値(16進数)の計算
+	HashSecret(secret string) string
+}
+
+type RandomSecretManager struct{}
+
+func NewRandomSecretManager() *RandomSecretManager {
+	return &RandomSecretManager{}
+}
+
+func (m *RandomSecretManager) GenerateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (m *RandomSecretManager) HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,28 @@
+package identification
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomSecretManager_GenerateSecret(tt *testing.T) {
+	tt.Run("正常系: URLに使える43文字のトークンを毎回異なる値で生成すること", func(t *testing.T) {
+		m := NewRandomSecretManager()
+		a, err := m.GenerateSecret()
+		require.NoError(t, err, "エラーが発生しないこと")
+		b, err := m.GenerateSecret()
+		require.NoError(t, err, "エラーが発生しないこと")
+
+		require.Regexp(t, `^[A-Za-z0-9_-]{43}$`, a)
+		require.NotEqual(t, a, b, "毎回異なる値であること")
+	})
+}
+
+func TestRandomSecretManager_HashSecret(tt *testing.T) {
+	tt.Run("正常系: SHA-256のハッシュ値を16進数で返すこと", func(t *testing.T) {
+		m := NewRandomSecretManager()
+
+		require.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", m.HashSecret("hello"))
+	})
+}
@@ -0,0 +1,37 @@
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// Markdownの操作
+type IMarkdownRenderer interface {
+	// MarkdownをサニタイズされたHTMLに変換する
+	Render(source string) (string, error)
+}
+
+type MarkdownRenderer struct {
+	md     goldmark.Markdown
+	policy *bluemonday.Policy
+}
+
+func NewMarkdownRenderer() *MarkdownRenderer {
+	return &MarkdownRenderer{
+		// GitHub Flavored Markdown(表、取り消し線、タスクリスト、自動リンク)に対応する
+		md: goldmark.New(goldmark.WithExtensions(extension.GFM)),
+		// ユーザー入力を表示するためのポリシーでscriptやイベント属性などを除去する
+		policy: bluemonday.UGCPolicy(),
+	}
+}
+
+func (r *MarkdownRenderer) Render(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := r.md.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+	return r.policy.Sanitize(buf.String()), nil
+}
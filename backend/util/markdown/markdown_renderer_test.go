@@ -0,0 +1,32 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownRenderer_Render(tt *testing.T) {
+	testcases := []struct {
+		title  string
+		source string
+		res    string
+	}{
+		{"正常系: 空文字の場合", "", ""},
+		{"正常系: 見出しと強調の場合", "# Title\n\n**bold**", "<h1>Title</h1>\n<p><strong>bold</strong></p>\n"},
+		{"正常系: リストの場合", "- a\n- b", "<ul>\n<li>a</li>\n<li>b</li>\n</ul>\n"},
+		{"正常系: 取り消し線の場合", "~~done~~", "<p><del>done</del></p>\n"},
+		{"準正常系: scriptタグが含まれる場合", "<script>alert(1)</script>", "\n"},
+		{"準正常系: javascriptスキームのリンクの場合", "[link](javascript:alert(1))", "<p>link</p>\n"},
+		{"準正常系: イベント属性が含まれる場合", "<img src=\"x\" onerror=\"alert(1)\">", "\n"},
+	}
+	for _, v := range testcases {
+		tt.Run(v.title, func(t *testing.T) {
+			r := NewMarkdownRenderer()
+			res, err := r.Render(v.source)
+
+			require.NoError(t, err, "エラーが発生しないこと")
+			require.Equal(t, v.res, res, "期待通りの値であること")
+		})
+	}
+}